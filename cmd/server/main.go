@@ -1,31 +1,63 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	audithandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/audit"
+	backuphandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/backup"
+	campaignhandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/campaign"
 	healthcheckhandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/healthcheck"
+	metricshandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/metrics"
 	pagehandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/page"
 	pagedetailhandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/pagedetail"
+	pagetemplatehandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/pagetemplate"
+	propertyhandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/property"
+	tokenhandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/token"
+	versionhandler "github.com/Pergamene/project-spiderweb-service/internal/api/handlers/version"
+	auditservice "github.com/Pergamene/project-spiderweb-service/internal/services/audit"
+	backupservice "github.com/Pergamene/project-spiderweb-service/internal/services/backup"
+	campaignservice "github.com/Pergamene/project-spiderweb-service/internal/services/campaign"
 	healthcheckservice "github.com/Pergamene/project-spiderweb-service/internal/services/healthcheck"
 	pageservice "github.com/Pergamene/project-spiderweb-service/internal/services/page"
 	pagedetailservice "github.com/Pergamene/project-spiderweb-service/internal/services/pagedetail"
+	pagetemplateservice "github.com/Pergamene/project-spiderweb-service/internal/services/pagetemplate"
+	propertyservice "github.com/Pergamene/project-spiderweb-service/internal/services/property"
+	tokenservice "github.com/Pergamene/project-spiderweb-service/internal/services/token"
+	versionservice "github.com/Pergamene/project-spiderweb-service/internal/services/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/instrumentedstore"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/memorystore"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/mysqlstore"
 	"github.com/Pergamene/project-spiderweb-service/internal/util/env"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/logging"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/metrics"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/transaction"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/usertoken"
 	"github.com/rs/cors"
+	"go.uber.org/zap"
 )
 
 const localUIURL = "http://127.0.0.1:8081"
 
 const (
-	defaultAdminAuthSecret = "DEFAULT_SECRET"
-	defaultPort            = "8782"
-	defaultStaticPath      = "../../static"
-	defaultDatacenter      = "LOCAL"
+	defaultAdminAuthSecret   = "DEFAULT_SECRET"
+	defaultPort              = "8782"
+	defaultStaticPath        = "../../static"
+	defaultDatacenter        = "LOCAL"
+	defaultPageURL           = localUIURL + "/pages/"
+	defaultTrashRetention    = "30"
+	defaultLogLevel          = "info"
+	defaultUserTokenSecret   = "DEFAULT_USER_TOKEN_SECRET"
+	defaultUserTokenIssuer   = "spiderweb-service"
+	defaultUserTokenAudience = "spiderweb"
+	defaultUserTokenTTL      = "24"
+	defaultDisableProxyUsers = "false"
 )
 
 func getHTTPServerAddr() string {
@@ -53,10 +85,63 @@ func getStaticPath() string {
 	return env.Get("STATIC_PATH", defaultStaticPath)
 }
 
+func getPageURL() string {
+	return env.Get("PAGE_URL", defaultPageURL)
+}
+
 func getDatacenter() string {
 	return env.Get("DATACENTER", api.LocalDatacenterEnv)
 }
 
+// getLogger returns the logger configured by LOG_LEVEL and LOG_FORMAT.
+// Logs are easier to read as console output when running locally, and are JSON everywhere else.
+func getLogger(datacenter string) (*zap.Logger, error) {
+	defaultLogFormat := logging.FormatJSON
+	if datacenter == api.LocalDatacenterEnv {
+		defaultLogFormat = logging.FormatConsole
+	}
+	return logging.New(env.Get("LOG_LEVEL", defaultLogLevel), env.Get("LOG_FORMAT", defaultLogFormat))
+}
+
+// getTrashRetentionDays returns how many days removed pages are kept in the trash before being purged.
+// Zero keeps them until they are purged by hand.
+func getTrashRetentionDays() (int, error) {
+	days, err := strconv.Atoi(env.Get("TRASH_RETENTION_DAYS", defaultTrashRetention))
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("TRASH_RETENTION_DAYS must be a non-negative number of days")
+	}
+	return days, nil
+}
+
+func getTrashPurgeInterval() time.Duration {
+	return time.Hour
+}
+
+// getUserTokens returns the signer of the tokens that users authenticate with, configured by the USER_TOKEN_ environment variables.
+func getUserTokens(datacenter string) (usertoken.Signer, error) {
+	secret, err := getUserTokenSecret(datacenter)
+	if err != nil {
+		return usertoken.Signer{}, err
+	}
+	hours, err := strconv.Atoi(env.Get("USER_TOKEN_TTL_HOURS", defaultUserTokenTTL))
+	if err != nil || hours < 1 {
+		return usertoken.Signer{}, fmt.Errorf("USER_TOKEN_TTL_HOURS must be a positive number of hours")
+	}
+	return usertoken.Signer{
+		Secret:   []byte(secret),
+		Issuer:   env.Get("USER_TOKEN_ISSUER", defaultUserTokenIssuer),
+		Audience: env.Get("USER_TOKEN_AUDIENCE", defaultUserTokenAudience),
+		TTL:      time.Duration(hours) * time.Hour,
+	}, nil
+}
+
+func getUserTokenSecret(datacenter string) (string, error) {
+	if datacenter != api.LocalDatacenterEnv {
+		return env.Require("USER_TOKEN_SECRET")
+	}
+	return env.Get("USER_TOKEN_SECRET", defaultUserTokenSecret), nil
+}
+
 func main() {
 	mysqldb, err := mysqlstore.SetupMySQL("")
 	if err != nil {
@@ -67,13 +152,18 @@ func main() {
 	apiPath := getAPIPath()
 	staticPath := getStaticPath()
 	datacenter := getDatacenter()
-	handler, err := setupHandler(apiPath, staticPath, datacenter, mysqldb)
+	logger, err := getLogger(datacenter)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer logger.Sync()
+	handler, err := setupHandler(apiPath, staticPath, datacenter, mysqldb, logger)
+	if err != nil {
+		logger.Fatal("Failed to set up the handler", zap.Error(err))
+	}
 	handler, err = setupCors(datacenter, handler)
 	if err != nil {
-		log.Fatal(err)
+		logger.Fatal("Failed to set up CORS", zap.Error(err))
 	}
 	s := &http.Server{
 		Addr:           getHTTPServerAddr(),
@@ -81,36 +171,115 @@ func main() {
 		ReadTimeout:    getHTTPServerReadTimeout(),
 		WriteTimeout:   getHTTPServerWriteTimeout(),
 		MaxHeaderBytes: getHTTPServerMaxHeaderBytes(),
+		ErrorLog:       zap.NewStdLog(logger),
 	}
 	fmt.Printf("Starting server at http://localhost%v\nVerify locally by running:\ncurl -X GET http://localhost%v/%v/healthcheck\nAPI docs: http://localhost%v/%v/docs\n", getHTTPServerAddr(), getHTTPServerAddr(), getAPIPath(), getHTTPServerAddr(), getAPIPath())
-	log.Fatal(s.ListenAndServe())
+	logger.Fatal("Server stopped", zap.Error(s.ListenAndServe()))
 }
 
-func setupHandler(apiPath, staticPath, datacenter string, mysqldb *sql.DB) (http.Handler, error) {
+func setupHandler(apiPath, staticPath, datacenter string, mysqldb *sql.DB, logger *zap.Logger) (http.Handler, error) {
+	ctx := logging.SetOnContext(context.Background(), logger)
 	var handler http.Handler
-	pageStore := mysqlstore.NewPageStore(mysqldb)
-	userStore := mysqlstore.NewUserStore(mysqldb)
-	healthcheckStore := mysqlstore.NewHealthcheckStore(mysqldb)
-	pageTemplateStore := mysqlstore.NewPageTemplateStore(mysqldb)
-	versionStore := mysqlstore.NewVersionStore(mysqldb)
+	serviceMetrics := metrics.New()
+	serviceMetrics.ObserveDB(mysqldb)
+	pageStore := instrumentedstore.NewPageStore(mysqlstore.NewPageStore(mysqldb), serviceMetrics)
+	userStore := instrumentedstore.NewUserStore(mysqlstore.NewUserStore(mysqldb), serviceMetrics)
+	healthcheckStore := instrumentedstore.NewHealthcheckStore(mysqlstore.NewHealthcheckStore(mysqldb), serviceMetrics)
+	pageTemplateStore := instrumentedstore.NewPageTemplateStore(mysqlstore.NewPageTemplateStore(mysqldb), serviceMetrics)
+	versionStore := instrumentedstore.NewVersionStore(mysqlstore.NewVersionStore(mysqldb), serviceMetrics)
+	pageDetailStore := instrumentedstore.NewPageDetailStore(mysqlstore.NewPageDetailStore(mysqldb), serviceMetrics)
+	propertyStore := instrumentedstore.NewPropertyStore(mysqlstore.NewPropertyStore(mysqldb), serviceMetrics)
+	campaignStore := instrumentedstore.NewCampaignStore(mysqlstore.NewCampaignStore(mysqldb), serviceMetrics)
+	relationStore := instrumentedstore.NewRelationStore(mysqlstore.NewRelationStore(mysqldb), serviceMetrics)
+	revisionStore := instrumentedstore.NewRevisionStore(mysqlstore.NewRevisionStore(mysqldb), serviceMetrics)
+	auditStore := instrumentedstore.NewAuditStore(mysqlstore.NewAuditStore(mysqldb), serviceMetrics)
+	searchStore := instrumentedstore.NewSearchStore(memorystore.NewSearchStore(), serviceMetrics)
 	pageService := pageservice.PageService{
 		PageStore:         pageStore,
 		PageTemplateStore: pageTemplateStore,
 		VersionStore:      versionStore,
 		UserStore:         userStore,
+		PageDetailStore:   pageDetailStore,
+		PropertyStore:     propertyStore,
+		RelationStore:     relationStore,
+		SearchStore:       searchStore,
+		RevisionStore:     revisionStore,
 	}
+	// searches find nothing until the index is built, which is done in the background so that the server can start serving right away.
+	go indexAllPages(ctx, pageService)
 	pageDetailService := pagedetailservice.PageDetailService{
-		PageDetailStore: pageDetailStore,
+		PageStore:        pageStore,
+		PageDetailStore:  pageDetailStore,
+		SearchIndexer:    pageService,
+		RevisionRecorder: pageService,
+		PageURL:          getPageURL(),
+	}
+	pageTemplateService := pagetemplateservice.PageTemplateService{
+		PageTemplateStore: pageTemplateStore,
+		PropertyStore:     propertyStore,
+		UserStore:         userStore,
+	}
+	propertyService := propertyservice.PropertyService{
+		PropertyStore: propertyStore,
+		UserStore:     userStore,
+	}
+	campaignService := campaignservice.CampaignService{
+		CampaignStore: campaignStore,
+		PageStore:     pageStore,
+		UserStore:     userStore,
+	}
+	versionService := versionservice.VersionService{
+		VersionStore:      versionStore,
+		PageStore:         pageStore,
+		PageDetailStore:   pageDetailStore,
+		PageTemplateStore: pageTemplateStore,
+		UserStore:         userStore,
+		SearchIndexer:     pageService,
+		RevisionRecorder:  pageService,
+	}
+	backupService := backupservice.BackupService{
+		PageStore:         pageStore,
+		PageDetailStore:   pageDetailStore,
+		PageTemplateStore: pageTemplateStore,
+		PropertyStore:     propertyStore,
+		VersionStore:      versionStore,
+		UserStore:         userStore,
+		SearchIndexer:     pageService,
+	}
+	auditService := auditservice.AuditService{
+		AuditStore: auditStore,
 	}
 	healthcheckService := healthcheckservice.HealthcheckService{
 		HealthcheckStore: healthcheckStore,
 	}
+	userTokens, err := getUserTokens(datacenter)
+	if err != nil {
+		return handler, err
+	}
+	tokenService := tokenservice.TokenService{
+		UserStore:  userStore,
+		UserTokens: userTokens,
+	}
+	trashRetentionDays, err := getTrashRetentionDays()
+	if err != nil {
+		return handler, err
+	}
+	go purgeTrash(ctx, pageService, trashRetentionDays)
 	var routerHandlers []api.RouterHandler
 	routerHandlers = append(routerHandlers, pagehandler.PageRouterHandlers(apiPath, pageService)...)
 	routerHandlers = append(routerHandlers, pagedetailhandler.PageDetailRouterHandlers(apiPath, pageDetailService)...)
+	routerHandlers = append(routerHandlers, pagetemplatehandler.PageTemplateRouterHandlers(apiPath, pageTemplateService)...)
+	routerHandlers = append(routerHandlers, propertyhandler.PropertyRouterHandlers(apiPath, propertyService)...)
+	routerHandlers = append(routerHandlers, campaignhandler.CampaignRouterHandlers(apiPath, campaignService)...)
+	routerHandlers = append(routerHandlers, versionhandler.VersionRouterHandlers(apiPath, versionService)...)
+	routerHandlers = append(routerHandlers, backuphandler.BackupRouterHandlers(apiPath, backupService)...)
+	routerHandlers = append(routerHandlers, audithandler.AuditRouterHandlers(apiPath, auditService)...)
 	routerHandlers = append(routerHandlers, healthcheckhandler.HealthcheckRouterHandlers(apiPath, healthcheckService)...)
-	router := api.NewRouter(apiPath, staticPath, routerHandlers)
-	authN, authZ, err := getAuths(apiPath, datacenter)
+	routerHandlers = append(routerHandlers, tokenhandler.TokenRouterHandlers(apiPath, tokenService)...)
+	var nonAuthRoutes []api.NonAuthRoute
+	nonAuthRoutes = append(nonAuthRoutes, metricshandler.MetricsNonAuthRoutes(apiPath, serviceMetrics)...)
+	router := api.NewRouter(apiPath, staticPath, routerHandlers, nonAuthRoutes)
+	authN, authZ, err := getAuths(apiPath, datacenter, userTokens)
 	if err != nil {
 		return handler, err
 	}
@@ -120,17 +289,59 @@ func setupHandler(apiPath, staticPath, datacenter string, mysqldb *sql.DB) (http
 		Router:     router,
 		Datacenter: datacenter,
 		APIPath:    apiPath,
+		Auditor:    auditService,
+		Logger:     logger,
+		Metrics:    serviceMetrics,
 	}, nil
 }
 
-func getAuths(apiPath, datacenter string) (api.AuthN, api.AuthZ, error) {
+// purgeTrash periodically purges the pages that have been in the trash for longer than the retention period.
+func purgeTrash(ctx context.Context, pageService pageservice.PageService, retentionDays int) {
+	if retentionDays == 0 {
+		return
+	}
+	ticker := time.NewTicker(getTrashPurgeInterval())
+	defer ticker.Stop()
+	for {
+		purged, err := pageService.PurgeRemovedPages(ctx, pageservice.PurgeRemovedPagesParams{
+			RemovedBefore: time.Now().AddDate(0, 0, -retentionDays),
+		})
+		if err != nil {
+			logging.GetFromContext(ctx).Error("Failed to purge the trash",
+				zap.Int("purged", purged),
+				zap.String("err", err.Error()),
+				zap.String("errVerbose", fmt.Sprintf("%+v", err)),
+			)
+		}
+		<-ticker.C
+	}
+}
+
+// indexAllPages builds the search index from every page.
+func indexAllPages(ctx context.Context, pageService pageservice.PageService) {
+	err := pageService.IndexAllPages(ctx)
+	if err != nil {
+		logging.GetFromContext(ctx).Error("Failed to index the pages",
+			zap.String("err", err.Error()),
+			zap.String("errVerbose", fmt.Sprintf("%+v", err)),
+		)
+	}
+}
+
+func getAuths(apiPath, datacenter string, userTokens usertoken.Signer) (api.AuthN, api.AuthZ, error) {
 	adminAuthSecret, err := getAdminAuthSecret(datacenter)
 	if err != nil {
 		return api.AuthN{}, api.AuthZ{}, err
 	}
+	disableProxyUsers, err := strconv.ParseBool(env.Get("DISABLE_PROXY_USERS", defaultDisableProxyUsers))
+	if err != nil {
+		return api.AuthN{}, api.AuthZ{}, fmt.Errorf("DISABLE_PROXY_USERS must be true or false")
+	}
 	authN := api.AuthN{
-		Datacenter:      datacenter,
-		AdminAuthSecret: adminAuthSecret,
+		Datacenter:        datacenter,
+		AdminAuthSecret:   adminAuthSecret,
+		UserTokens:        userTokens,
+		DisableProxyUsers: disableProxyUsers,
 	}
 	authZ := api.AuthZ{
 		APIPath: apiPath,
@@ -152,7 +363,8 @@ func setupCors(datacenter string, handler http.Handler) (http.Handler, error) {
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{localUIURL},
 		AllowedMethods: []string{"GET", "POST", "DELETE", "PUT", "OPTIONS", "PATCH"},
-		AllowedHeaders: []string{"X-AUTH-TOKEN", "Content-Type", "X-USER-ID"},
+		AllowedHeaders: []string{"X-AUTH-TOKEN", "Content-Type", "X-USER-ID", api.AuthorizationHeaderKey, transaction.RequestIDHeader},
+		ExposedHeaders: []string{transaction.RequestIDHeader},
 	})
 	return c.Handler(handler), nil
 }
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/util/logging"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/transaction"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 // LocalDatacenterEnv should be set on DATACENTER when running locally.
@@ -18,6 +22,17 @@ type Handler struct {
 	Router     Router
 	Datacenter string
 	APIPath    string
+	// Auditor is optional; when set, every create, update and delete is recorded in the audit log.
+	Auditor Auditor
+	// Logger is optional; when not set, nothing is logged.
+	Logger *zap.Logger
+	// Metrics is optional; when set, every request is observed once it has been responded to.
+	Metrics RequestObserver
+}
+
+// RequestObserver records the requests that were responded to, such as for metrics.
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, latency time.Duration)
 }
 
 // Authenticator inteface for authenticating.
@@ -27,32 +42,77 @@ type Authenticator interface {
 
 // Authorizer inteface for authorizing.
 type Authorizer interface {
-	Authorize(path string, authData AuthData) (AuthData, error)
+	Authorize(access Access, authData AuthData) (AuthData, error)
 }
 
 // ServeHTTP handles responding to HTTP requests.
+// Every request is given a transaction, whose request id is echoed in the response so that it can be matched to the logs.
+// The request's logger is set on its context, and every request is logged once it has been responded to.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	start := time.Now()
+	t := transaction.New(r.Header.Get(transaction.RequestIDHeader))
+	w.Header().Set(transaction.RequestIDHeader, t.RequestID)
+	route := h.Router.Route(r.Method, r.URL.Path)
+	logger := h.getLogger().With(
+		zap.String("requestId", t.RequestID),
+		zap.String("transactionId", t.TransactionID),
+		zap.String("method", r.Method),
+		zap.String("route", route),
+	)
+	ctx := transaction.SetOnContext(r.Context(), t)
+	recorder := newStatusRecorder(w)
+	logger = h.serve(recorder, r.WithContext(ctx), logger)
+	latency := time.Since(start)
+	logger.Info("Handled request",
+		zap.String("path", r.URL.Path),
+		zap.Int("status", recorder.status),
+		zap.Duration("latency", latency),
+	)
+	if h.Metrics != nil {
+		h.Metrics.ObserveRequest(r.Method, route, recorder.status, latency)
+	}
+}
+
+// serve authenticates, authorizes and then routes the request, returning its logger with the user once they are known.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, logger *zap.Logger) *zap.Logger {
+	ctx := logging.SetOnContext(r.Context(), logger)
 	r = r.WithContext(ctx)
-	if h.requiresNoAuth(w, r) {
+	if h.requiresNoAuth(r) {
 		h.Router.ServeHTTP(w, r)
-		return
+		return logger
 	}
 	r, authData, responded := h.authenticate(w, r)
 	if responded {
-		return
+		return logger
 	}
 	r = r.WithContext(ctx)
 	r, authData, responded = h.authorize(w, r, authData)
 	if responded {
-		return
+		return logger
 	}
+	logger = logger.With(
+		zap.String("authType", string(authData.Type)),
+		zap.String("userId", authData.UserID),
+	)
+	ctx = logging.SetOnContext(ctx, logger)
 	ctx = SetDataOnContext(ctx, authData)
 	r = r.WithContext(ctx)
+	if h.Auditor != nil && isMutation(r.Method) {
+		h.serveAudited(w, r, authData)
+		return logger
+	}
 	h.Router.ServeHTTP(w, r)
+	return logger
+}
+
+func (h *Handler) getLogger() *zap.Logger {
+	if h.Logger == nil {
+		return zap.NewNop()
+	}
+	return h.Logger
 }
 
-func (h *Handler) requiresNoAuth(w http.ResponseWriter, r *http.Request) bool {
+func (h *Handler) requiresNoAuth(r *http.Request) bool {
 	if strings.HasPrefix(r.URL.Path, fmt.Sprintf("/%v/docs", h.APIPath)) {
 		return true
 	}
@@ -61,7 +121,6 @@ func (h *Handler) requiresNoAuth(w http.ResponseWriter, r *http.Request) bool {
 	}
 	for _, nonAuthRoute := range h.Router.NonAuthRoutes {
 		if r.URL.Path == nonAuthRoute.Path && r.Method == nonAuthRoute.Method {
-			h.Router.ServeHTTP(w, r)
 			return true
 		}
 	}
@@ -82,7 +141,7 @@ func (h *Handler) authenticate(w http.ResponseWriter, r *http.Request) (*http.Re
 }
 
 func (h *Handler) authorize(w http.ResponseWriter, r *http.Request, authData AuthData) (*http.Request, AuthData, bool) {
-	authData, err := h.AuthZ.Authorize(r.URL.Path, authData)
+	authData, err := h.AuthZ.Authorize(h.Router.Access(r.Method, r.URL.Path), authData)
 	if castErr, ok := err.(*FailedAuthorization); ok {
 		RespondWith(r, w, http.StatusForbidden, castErr, err)
 		return r, authData, true
@@ -93,3 +152,21 @@ func (h *Handler) authorize(w http.ResponseWriter, r *http.Request, authData Aut
 	}
 	return r, authData, false
 }
+
+// statusRecorder keeps the status of the response written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func newStatusRecorder(w http.ResponseWriter) *statusRecorder {
+	return &statusRecorder{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
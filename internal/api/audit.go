@@ -0,0 +1,166 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	auditservice "github.com/Pergamene/project-spiderweb-service/internal/services/audit"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/logging"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/transaction"
+	"go.uber.org/zap"
+)
+
+// Auditor records the creates, updates and deletes made through the API.
+type Auditor interface {
+	RecordAuditEntry(ctx context.Context, params auditservice.RecordAuditEntryParams) error
+}
+
+func isMutation(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch || method == http.MethodDelete
+}
+
+// serveAudited serves the request and then records it in the audit log, whether or not it succeeded.
+// The entity is summarized before and after the request by reading it from the GET route of the same path,
+// if that route addresses a single entity. Otherwise, the after summary of a successful request is its response, such as the id of a created page.
+func (h *Handler) serveAudited(w http.ResponseWriter, r *http.Request, authData AuthData) {
+	// the responses of secret routes are left out, so that the audit log cannot be used to obtain their secrets.
+	secret := h.Router.RespondsWithSecret(r.Method, r.URL.Path)
+	var before string
+	if !secret {
+		before = h.getEntitySummary(r)
+	}
+	recorder := newAuditRecorder(w)
+	h.Router.ServeHTTP(recorder, r)
+	var result json.RawMessage
+	if !secret {
+		result = getResponseResult(recorder.body.Bytes())
+	}
+	entry := audit.Entry{
+		Actor:      string(authData.Type),
+		UserID:     authData.UserID,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Status:     recorder.status,
+		EntityGUID: h.getEntityGUID(r, result),
+		Before:     before,
+	}
+	if recorder.status >= 200 && recorder.status < 300 && !secret {
+		entry.After = h.getEntitySummary(r)
+		if entry.After == "" && len(result) > 0 {
+			entry.After = audit.Summarize(result)
+		}
+	}
+	err := h.Auditor.RecordAuditEntry(r.Context(), auditservice.RecordAuditEntryParams{Entry: entry})
+	if err != nil {
+		// the response has already been written, so all that can be done is to report it.
+		err = transaction.WrapError(r.Context(), err)
+		logging.GetFromContext(r.Context()).Error("Failed to record audit entry",
+			zap.String("err", err.Error()),
+			zap.String("errVerbose", fmt.Sprintf("%+v", err)),
+		)
+	}
+}
+
+// getEntitySummary returns the summarized result of the GET route for the request's path,
+// or an empty string if there is no such route, it did not succeed or it does not address a single entity.
+// Routes of collections, such as listing every page or exporting a backup, are never replayed, since they can be expensive
+// and their responses are not summaries of what is being changed.
+func (h *Handler) getEntitySummary(r *http.Request) string {
+	if !h.isEntityRoute(http.MethodGet, r.URL.Path) {
+		return ""
+	}
+	u := *r.URL
+	u.RawQuery = ""
+	// the GET route's own failures, such as the entity not existing yet, are expected and not worth logging.
+	getRequest := r.WithContext(logging.SetOnContext(r.Context(), zap.NewNop()))
+	getRequest.Method = http.MethodGet
+	getRequest.URL = &u
+	getRequest.Body = http.NoBody
+	getRequest.ContentLength = 0
+	recorder := newAuditRecorder(nil)
+	h.Router.ServeHTTP(recorder, getRequest)
+	if recorder.status != http.StatusOK {
+		return ""
+	}
+	return audit.Summarize(getResponseResult(recorder.body.Bytes()))
+}
+
+// isEntityRoute returns true if the route for the method and path addresses a single entity, by ending with its id,
+// such as /api/pages/:pageID, and does not respond with secrets.
+func (h *Handler) isEntityRoute(method, path string) bool {
+	route := h.Router.Route(method, path)
+	if route == "" || h.Router.RespondsWithSecret(method, path) {
+		return false
+	}
+	return strings.HasPrefix(route[strings.LastIndex(route, "/")+1:], ":")
+}
+
+// getEntityGUID returns the id in the response's result if there is one, such as for a created entity,
+// or else the last param of the request's route, which identifies the entity being changed.
+func (h *Handler) getEntityGUID(r *http.Request, result json.RawMessage) string {
+	var identified struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(result, &identified); err == nil && identified.ID != "" {
+		return identified.ID
+	}
+	params, ok := h.Router.Lookup(r.Method, r.URL.Path)
+	if !ok || len(params) == 0 {
+		return ""
+	}
+	return params[len(params)-1].Value
+}
+
+// getResponseResult returns the result from a body in the response format, or nil if the body is not in that format.
+func getResponseResult(body []byte) json.RawMessage {
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+	return response.Result
+}
+
+// auditRecorder keeps the status and body of a response, passing them on to the underlying writer if there is one.
+type auditRecorder struct {
+	w      http.ResponseWriter
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newAuditRecorder(w http.ResponseWriter) *auditRecorder {
+	return &auditRecorder{
+		w:      w,
+		header: http.Header{},
+		status: http.StatusOK,
+	}
+}
+
+func (a *auditRecorder) Header() http.Header {
+	if a.w != nil {
+		return a.w.Header()
+	}
+	return a.header
+}
+
+func (a *auditRecorder) WriteHeader(status int) {
+	a.status = status
+	if a.w != nil {
+		a.w.WriteHeader(status)
+	}
+}
+
+func (a *auditRecorder) Write(b []byte) (int, error) {
+	a.body.Write(b)
+	if a.w != nil {
+		return a.w.Write(b)
+	}
+	return len(b), nil
+}
@@ -13,6 +13,7 @@ type AuthType string
 const (
 	AuthTypeAdmin     AuthType = "admin"
 	AuthTypeProxyUser AuthType = "proxyUser"
+	AuthTypeUser      AuthType = "user"
 )
 
 // AuthData are the data for authn/authz.
@@ -43,3 +44,8 @@ func GetDataFromContext(ctx context.Context) (AuthData, error) {
 func (ad AuthData) IsAdmin() bool {
 	return ad.Type == AuthTypeAdmin
 }
+
+// IsUser returns true if the AuthData is of a user, including an admin acting as a user.
+func (ad AuthData) IsUser() bool {
+	return ad.UserID != "" && (ad.Type == AuthTypeUser || ad.Type == AuthTypeProxyUser)
+}
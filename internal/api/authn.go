@@ -2,20 +2,30 @@ package api
 
 import (
 	"net/http"
+	"strings"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/usertoken"
 )
 
 // AuthN struct for fulfilling authentication
 type AuthN struct {
 	Datacenter      string
 	AdminAuthSecret string
+	UserTokens      usertoken.Signer
+	// DisableProxyUsers stops admins from acting as any user with the X-USER-ID header,
+	// so that users can only be authenticated by their own tokens.
+	DisableProxyUsers bool
 }
 
 // Different header key names
 const (
 	AdminAuthSecretHeaderKey = "X-ADMIN-AUTH-SECRET"
 	UserIDHeaderKey          = "X-USER-ID"
+	AuthorizationHeaderKey   = "Authorization"
 )
 
+const bearerPrefix = "Bearer "
+
 // FailedAuthentication is an error that signifies that the request failed authentication.
 type FailedAuthentication struct{}
 
@@ -23,12 +33,27 @@ func (e *FailedAuthentication) Error() string {
 	return "not authenticated"
 }
 
-// Authenticate first checks if we are running locally, if so it will load AuthData from the headers
+// Authenticate first checks for a user's bearer token, which must be valid if it is given.
+// Otherwise it checks if we are running locally, if so it will load AuthData from the headers
 // it will also check for an admin auth secret authentication.
+// An admin acting as a user is not authenticated when proxy users are disabled.
 // FailedAuthentication will be returned if they are not authenticated.
 func (a AuthN) Authenticate(r *http.Request) (AuthData, error) {
+	if token, ok := a.getBearerToken(r); ok {
+		claims, err := a.UserTokens.Verify(token)
+		if err != nil {
+			return AuthData{}, &FailedAuthentication{}
+		}
+		return AuthData{
+			Type:   AuthTypeUser,
+			UserID: claims.Subject,
+		}, nil
+	}
 	if a.isAdmin(r) {
 		if a.hasUserID(r) {
+			if a.DisableProxyUsers {
+				return AuthData{}, &FailedAuthentication{}
+			}
 			return AuthData{
 				Type:   AuthTypeProxyUser,
 				UserID: r.Header.Get(UserIDHeaderKey),
@@ -48,3 +73,11 @@ func (a AuthN) isAdmin(r *http.Request) bool {
 func (a AuthN) hasUserID(r *http.Request) bool {
 	return r.Header.Get(UserIDHeaderKey) != ""
 }
+
+func (a AuthN) getBearerToken(r *http.Request) (string, bool) {
+	authorization := r.Header.Get(AuthorizationHeaderKey)
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(authorization, bearerPrefix), true
+}
@@ -1,5 +1,19 @@
 package api
 
+// Access is who may call a route, which is declared along with the route.
+// Whether they may do so on the particular entities of the request is decided by the services.
+type Access string
+
+// All the valid values for Access
+const (
+	// AccessAuthenticated routes can be called by anyone who is authenticated, and is the default.
+	AccessAuthenticated Access = ""
+	// AccessAdmin routes can only be called by an admin who is not acting as a user.
+	AccessAdmin Access = "admin"
+	// AccessUser routes can only be called by a user, whether they authenticated with a token or through an admin acting as them.
+	AccessUser Access = "user"
+)
+
 // AuthZ struct for fulfilling authorization
 type AuthZ struct {
 	APIPath string
@@ -12,8 +26,24 @@ func (e *FailedAuthorization) Error() string {
 	return "not authorized"
 }
 
-// Authorize determines if the user is authorized to access the given entities.
+// Authorize determines if the caller is allowed to call a route with the given access.
 // FailedAuthorization will be returned if they are not authorized.
-func (a AuthZ) Authorize(path string, authData AuthData) (AuthData, error) {
-	return authData, nil
+func (a AuthZ) Authorize(access Access, authData AuthData) (AuthData, error) {
+	switch access {
+	case AccessAuthenticated:
+		return authData, nil
+	case AccessAdmin:
+		if !authData.IsAdmin() {
+			return authData, &FailedAuthorization{}
+		}
+		return authData, nil
+	case AccessUser:
+		if !authData.IsUser() {
+			return authData, &FailedAuthorization{}
+		}
+		return authData, nil
+	default:
+		// a route that is declared with an unknown access is never allowed, rather than being open by mistake.
+		return authData, &FailedAuthorization{}
+	}
 }
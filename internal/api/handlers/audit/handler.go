@@ -0,0 +1,60 @@
+package audithandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/nextbatch"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	auditservice "github.com/Pergamene/project-spiderweb-service/internal/services/audit"
+	"github.com/julienschmidt/httprouter"
+)
+
+// AuditHandler is the handler for the associated API
+type AuditHandler struct {
+	AuditService AuditService
+}
+
+// AuditService see Service for more details
+type AuditService interface {
+	GetAuditEntries(ctx context.Context, params auditservice.GetAuditEntriesParams) ([]audit.Entry, string, error)
+}
+
+// GetAuditEntries see Service for more details
+func (h AuditHandler) GetAuditEntries(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetAuditEntriesRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	entries, nextBatchID, err := h.AuditService.GetAuditEntries(r.Context(), auditservice.GetAuditEntriesParams{
+		Filter:      request.Filter,
+		NextBatchID: request.NextBatchID,
+		Limit:       request.Limit,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	if nextBatchID == "" {
+		responseBody := struct {
+			Batch []audit.Entry `json:"batch"`
+		}{
+			Batch: entries,
+		}
+		api.RespondWith(r, w, http.StatusOK, responseBody, nil)
+		return
+	}
+	responseBody := struct {
+		Batch     []audit.Entry       `json:"batch"`
+		NextBatch nextbatch.NextBatch `json:"nextBatch"`
+	}{
+		Batch: entries,
+		NextBatch: nextbatch.NextBatch{
+			ParamKey:   "nextBatchId",
+			ParamValue: nextBatchID,
+		},
+	}
+	api.RespondWith(r, w, http.StatusOK, responseBody, nil)
+}
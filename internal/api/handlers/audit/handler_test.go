@@ -0,0 +1,167 @@
+package audithandler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	auditservice "github.com/Pergamene/project-spiderweb-service/internal/services/audit"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/audit/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+)
+
+type getAuditEntriesCall struct {
+	auditParams       auditservice.GetAuditEntriesParams
+	returnEntries     []audit.Entry
+	returnNextBatchID string
+	returnErr         error
+}
+
+func TestGetAuditEntries(t *testing.T) {
+	from := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC)
+	entry := audit.Entry{
+		ID:         1,
+		GUID:       "AU_1",
+		Actor:      "proxyUser",
+		UserID:     "UR_1",
+		Method:     http.MethodPatch,
+		Path:       "/api/pages/PG_1",
+		Status:     200,
+		EntityGUID: "PG_1",
+		Before:     "{\"title\":\"Barovia\"}",
+		After:      "{\"title\":\"Village of Barovia\"}",
+		CreatedAt:  &from,
+	}
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		params               url.Values
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		getAuditEntriesCalls []getAuditEntriesCall
+	}{
+		{
+			name:                 "not authenticated",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "not an admin",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"403 - Forbidden\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   403,
+		},
+		{
+			name:                 "bad time range",
+			params:               url.Values{"from": []string{"2020-03-02T00:00:00Z"}, "to": []string{"2020-03-01T00:00:00Z"}},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"from must be before to\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:                 "bad time",
+			params:               url.Values{"from": []string{"yesterday"}},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"from must be an RFC 3339 time\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:                 "bad limit",
+			params:               url.Values{"limit": []string{"1000"}},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"limit must be a number between 1 and 500\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "happy path",
+			params: url.Values{
+				"userId":   []string{"UR_1"},
+				"entityId": []string{"PG_1"},
+				"from":     []string{"2020-03-01T00:00:00Z"},
+				"to":       []string{"2020-03-02T00:00:00Z"},
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"batch\":[{\"id\":\"AU_1\",\"actor\":\"proxyUser\",\"userId\":\"UR_1\",\"method\":\"PATCH\",\"path\":\"/api/pages/PG_1\",\"status\":200,\"entityId\":\"PG_1\",\"before\":\"{\\\"title\\\":\\\"Barovia\\\"}\",\"after\":\"{\\\"title\\\":\\\"Village of Barovia\\\"}\",\"createdAt\":\"2020-03-01T00:00:00Z\"}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getAuditEntriesCalls: []getAuditEntriesCall{
+				{
+					auditParams: auditservice.GetAuditEntriesParams{
+						Filter: audit.Filter{UserID: "UR_1", EntityGUID: "PG_1", From: &from, To: &to},
+						Limit:  50,
+					},
+					returnEntries: []audit.Entry{entry},
+				},
+			},
+		},
+		{
+			name:                 "next batch",
+			params:               url.Values{"nextBatchId": []string{"AU_3"}, "limit": []string{"1"}},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"batch\":[],\"nextBatch\":{\"paramKey\":\"nextBatchId\",\"paramValue\":\"AU_2\"}},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getAuditEntriesCalls: []getAuditEntriesCall{
+				{
+					auditParams:       auditservice.GetAuditEntriesParams{NextBatchID: "AU_3", Limit: 1},
+					returnEntries:     []audit.Entry{},
+					returnNextBatchID: "AU_2",
+				},
+			},
+		},
+		{
+			name:                 "service failure",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"500 - Internal Server Error\",\"message\":\"internal server error\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   500,
+			getAuditEntriesCalls: []getAuditEntriesCall{
+				{
+					auditParams: auditservice.GetAuditEntriesParams{Limit: 50},
+					returnErr:   errors.New("failure"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditService := new(mocks.AuditService)
+			for index := range tc.getAuditEntriesCalls {
+				auditService.On("GetAuditEntries", mock.Anything, tc.getAuditEntriesCalls[index].auditParams).Return(tc.getAuditEntriesCalls[index].returnEntries, tc.getAuditEntriesCalls[index].returnNextBatchID, tc.getAuditEntriesCalls[index].returnErr)
+			}
+			routerHandlers := AuditRouterHandlers(tc.authZ.APIPath, auditService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "audit",
+				Params:         tc.params,
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			auditService.AssertNumberOfCalls(t, "GetAuditEntries", len(tc.getAuditEntriesCalls))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package audithandler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// The bounds of the number of audit entries in a batch
+const (
+	defaultAuditEntriesLimit = 50
+	maxAuditEntriesLimit     = 500
+)
+
+// GetAuditEntriesRequest parameters from the GetAuditEntries call
+type GetAuditEntriesRequest struct {
+	NextBatchID string
+	Limit       int
+	Filter      audit.Filter
+}
+
+// NewGetAuditEntriesRequest extracts the GetAuditEntriesRequest
+func NewGetAuditEntriesRequest(r *http.Request, p httprouter.Params) (GetAuditEntriesRequest, error) {
+	var request GetAuditEntriesRequest
+	query := r.URL.Query()
+	request.NextBatchID = query.Get("nextBatchId")
+	request.Limit = defaultAuditEntriesLimit
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		request.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return request, fmt.Errorf("limit must be a number between 1 and %v", maxAuditEntriesLimit)
+		}
+	}
+	if request.Limit < 1 || request.Limit > maxAuditEntriesLimit {
+		return request, fmt.Errorf("limit must be a number between 1 and %v", maxAuditEntriesLimit)
+	}
+	request.Filter.UserID = query.Get("userId")
+	request.Filter.EntityGUID = query.Get("entityId")
+	if from := query.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return request, errors.New("from must be an RFC 3339 time")
+		}
+		request.Filter.From = &t
+	}
+	if to := query.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return request, errors.New("to must be an RFC 3339 time")
+		}
+		request.Filter.To = &t
+	}
+	if request.Filter.From != nil && request.Filter.To != nil && !request.Filter.From.Before(*request.Filter.To) {
+		return request, errors.New("from must be before to")
+	}
+	return request, nil
+}
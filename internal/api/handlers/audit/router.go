@@ -0,0 +1,23 @@
+package audithandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+)
+
+// AuditRouterHandlers returns the requests for the associated routes.
+func AuditRouterHandlers(apiPath string, auditService AuditService) []api.RouterHandler {
+	handler := AuditHandler{
+		AuditService: auditService,
+	}
+	var routerHandlers []api.RouterHandler
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/audit", apiPath),
+		Handle:   handler.GetAuditEntries,
+		Access:   api.AccessAdmin,
+	})
+	return routerHandlers
+}
@@ -0,0 +1,76 @@
+package backuphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/backup"
+	backupservice "github.com/Pergamene/project-spiderweb-service/internal/services/backup"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// BackupService see Service for more details
+type BackupService interface {
+	ExportBackup(ctx context.Context, params backupservice.ExportBackupParams) (backup.Bundle, error)
+	RestoreBackup(ctx context.Context, params backupservice.RestoreBackupParams) (backup.Restoration, error)
+}
+
+// BackupHandler is the handler for the associated API
+type BackupHandler struct {
+	BackupService BackupService
+}
+
+// ExportBackup see Service for more details
+func (h BackupHandler) ExportBackup(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	bundle, err := h.BackupService.ExportBackup(ctx, backupservice.ExportBackupParams{
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, bundle.GetJSONConformed(), nil)
+}
+
+// RestoreBackup see Service for more details
+func (h BackupHandler) RestoreBackup(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewRestoreBackupRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	restoration, err := h.BackupService.RestoreBackup(ctx, backupservice.RestoreBackupParams{
+		Bundle: request.Bundle,
+		UserID: authData.UserID,
+	})
+	if castErr, ok := errors.Cause(err).(*storeerror.DupEntry); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, restoration, nil)
+}
@@ -0,0 +1,199 @@
+package backuphandler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/backup"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	backupservice "github.com/Pergamene/project-spiderweb-service/internal/services/backup"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/backup/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+)
+
+type exportBackupCall struct {
+	backupParams backupservice.ExportBackupParams
+	returnBundle backup.Bundle
+	returnErr    error
+}
+
+func TestExportBackup(t *testing.T) {
+	createdAt := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		exportBackupCalls    []exportBackupCall
+	}{
+		{
+			name:                 "not authenticated",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"format\":1,\"createdAt\":\"2020-03-01T12:00:00Z\",\"properties\":[],\"versions\":[{\"id\":\"VR_1\",\"name\":\"Default\",\"parentId\":\"\"}],\"pageTemplates\":[],\"pages\":[{\"id\":\"PG_1\",\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"title\":\"Barovia\",\"summary\":\"\",\"permission\":\"PR\",\"properties\":[],\"details\":[]}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			exportBackupCalls: []exportBackupCall{
+				{
+					backupParams: backupservice.ExportBackupParams{UserID: "UR_1"},
+					returnBundle: backup.Bundle{
+						Format:    backup.Format,
+						CreatedAt: &createdAt,
+						Versions:  []version.Version{{ID: 1, GUID: "VR_1", Name: "Default"}},
+						Pages:     []backup.Page{{GUID: "PG_1", VersionGUID: "VR_1", PageTemplateGUID: "PGT_1", Title: "Barovia", PermissionType: permission.TypePrivate}},
+					},
+				},
+			},
+		},
+		{
+			name: "service failure",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"500 - Internal Server Error\",\"message\":\"internal server error\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   500,
+			exportBackupCalls: []exportBackupCall{
+				{
+					backupParams: backupservice.ExportBackupParams{UserID: "UR_1"},
+					returnErr:    errors.New("failure"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			backupService := new(mocks.BackupService)
+			for index := range tc.exportBackupCalls {
+				backupService.On("ExportBackup", mock.Anything, tc.exportBackupCalls[index].backupParams).Return(tc.exportBackupCalls[index].returnBundle, tc.exportBackupCalls[index].returnErr)
+			}
+			routerHandlers := BackupRouterHandlers(tc.authZ.APIPath, backupService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "backup",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			backupService.AssertNumberOfCalls(t, "ExportBackup", len(tc.exportBackupCalls))
+		})
+	}
+}
+
+type restoreBackupCall struct {
+	backupParams      backupservice.RestoreBackupParams
+	returnRestoration backup.Restoration
+	returnErr         error
+}
+
+func TestRestoreBackup(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		requestBody          string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		restoreBackupCalls   []restoreBackupCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			requestBody:          "{\"format\":1,\"properties\":[{\"key\":\"ruler\",\"type\":\"string\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"properties\":1,\"versions\":0,\"pageTemplates\":0,\"pages\":0,\"details\":0,\"remappedIds\":{}},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			restoreBackupCalls: []restoreBackupCall{
+				{
+					backupParams: backupservice.RestoreBackupParams{
+						Bundle: backup.Bundle{Format: 1, Properties: []property.Property{{Key: "ruler", Type: property.TypeString}}},
+						UserID: "UR_2",
+					},
+					returnRestoration: backup.Restoration{Properties: 1, RemappedIDs: map[string]string{}},
+				},
+			},
+		},
+		{
+			name: "missing backup",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			requestBody:          "{}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide a backup\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "invalid backup",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			requestBody:          "{\"format\":2}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"the backup cannot be restored\\nformat 2 is not supported, only format 1 can be restored\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			restoreBackupCalls: []restoreBackupCall{
+				{
+					backupParams: backupservice.RestoreBackupParams{
+						Bundle: backup.Bundle{Format: 2},
+						UserID: "UR_2",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "the backup cannot be restored", Err: errors.New("format 2 is not supported, only format 1 can be restored")},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			backupService := new(mocks.BackupService)
+			for index := range tc.restoreBackupCalls {
+				backupService.On("RestoreBackup", mock.Anything, tc.restoreBackupCalls[index].backupParams).Return(tc.restoreBackupCalls[index].returnRestoration, tc.restoreBackupCalls[index].returnErr)
+			}
+			routerHandlers := BackupRouterHandlers(tc.authZ.APIPath, backupService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "backup",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			backupService.AssertNumberOfCalls(t, "RestoreBackup", len(tc.restoreBackupCalls))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package backuphandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/backup"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// RestoreBackupRequest parameters from the RestoreBackup call
+type RestoreBackupRequest struct {
+	Bundle backup.Bundle
+}
+
+// NewRestoreBackupRequest extracts the RestoreBackupRequest
+func NewRestoreBackupRequest(r *http.Request, p httprouter.Params) (RestoreBackupRequest, error) {
+	var request RestoreBackupRequest
+	err := json.NewDecoder(r.Body).Decode(&request.Bundle)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	if request.Bundle.Format == 0 {
+		return request, errors.New("must provide a backup")
+	}
+	return request, nil
+}
@@ -0,0 +1,27 @@
+package backuphandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+)
+
+// BackupRouterHandlers returns the requests for the associated routes.
+func BackupRouterHandlers(apiPath string, backupService BackupService) []api.RouterHandler {
+	handler := BackupHandler{
+		BackupService: backupService,
+	}
+	var routerHandlers []api.RouterHandler
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/backup", apiPath),
+		Handle:   handler.ExportBackup,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/backup", apiPath),
+		Handle:   handler.RestoreBackup,
+	})
+	return routerHandlers
+}
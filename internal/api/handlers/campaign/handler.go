@@ -0,0 +1,329 @@
+package campaignhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	campaignservice "github.com/Pergamene/project-spiderweb-service/internal/services/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// CampaignService see Service for more details
+type CampaignService interface {
+	CreateCampaign(ctx context.Context, params campaignservice.CreateCampaignParams) (campaign.Campaign, error)
+	GetCampaign(ctx context.Context, params campaignservice.GetCampaignParams) (campaign.Campaign, error)
+	GetCampaigns(ctx context.Context, params campaignservice.GetCampaignsParams) ([]campaign.Campaign, error)
+	UpdateCampaign(ctx context.Context, params campaignservice.UpdateCampaignParams) error
+	RemoveCampaign(ctx context.Context, params campaignservice.RemoveCampaignParams) error
+	SetCampaignMember(ctx context.Context, params campaignservice.SetCampaignMemberParams) error
+	RemoveCampaignMember(ctx context.Context, params campaignservice.RemoveCampaignMemberParams) error
+	AddCampaignPage(ctx context.Context, params campaignservice.CampaignPageParams) error
+	RemoveCampaignPage(ctx context.Context, params campaignservice.CampaignPageParams) error
+}
+
+// CampaignHandler is the handler for the associated API
+type CampaignHandler struct {
+	CampaignService CampaignService
+}
+
+// CreateCampaign see Service for more details
+func (h CampaignHandler) CreateCampaign(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewCreateCampaignRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.CampaignService.CreateCampaign(ctx, campaignservice.CreateCampaignParams{
+		Campaign: campaign.Campaign{
+			Name:    request.Name,
+			Summary: request.Summary,
+		},
+		OwnerID: authData.UserID,
+	})
+	if castErr, ok := err.(*storeerror.DupEntry); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, map[string]string{"id": record.GUID}, nil)
+}
+
+// GetCampaign see Service for more details
+func (h CampaignHandler) GetCampaign(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetCampaignRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.CampaignService.GetCampaign(ctx, campaignservice.GetCampaignParams{
+		Campaign: campaign.Campaign{
+			GUID: request.CampaignID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, record, nil)
+}
+
+// GetCampaigns see Service for more details
+func (h CampaignHandler) GetCampaigns(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.CampaignService.GetCampaigns(ctx, campaignservice.GetCampaignsParams{
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, records, nil)
+}
+
+// UpdateCampaign see Service for more details
+func (h CampaignHandler) UpdateCampaign(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewUpdateCampaignRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.CampaignService.UpdateCampaign(ctx, campaignservice.UpdateCampaignParams{
+		Campaign: campaign.Campaign{
+			GUID:    request.CampaignID,
+			Name:    request.Name,
+			Summary: request.Summary,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// RemoveCampaign see Service for more details
+func (h CampaignHandler) RemoveCampaign(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewRemoveCampaignRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.CampaignService.RemoveCampaign(ctx, campaignservice.RemoveCampaignParams{
+		Campaign: campaign.Campaign{
+			GUID: request.CampaignID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// SetCampaignMember see Service for more details
+func (h CampaignHandler) SetCampaignMember(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewSetCampaignMemberRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.CampaignService.SetCampaignMember(ctx, campaignservice.SetCampaignMemberParams{
+		Campaign: campaign.Campaign{
+			GUID: request.CampaignID,
+		},
+		Member: campaign.Member{
+			UserID: request.MemberID,
+			Role:   request.Role,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// RemoveCampaignMember see Service for more details
+func (h CampaignHandler) RemoveCampaignMember(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewRemoveCampaignMemberRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.CampaignService.RemoveCampaignMember(ctx, campaignservice.RemoveCampaignMemberParams{
+		Campaign: campaign.Campaign{
+			GUID: request.CampaignID,
+		},
+		MemberID: request.MemberID,
+		UserID:   authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// AddCampaignPage see Service for more details
+func (h CampaignHandler) AddCampaignPage(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewCampaignPageRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.CampaignService.AddCampaignPage(ctx, campaignservice.CampaignPageParams{
+		Campaign: campaign.Campaign{
+			GUID: request.CampaignID,
+		},
+		PageID: request.PageID,
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// RemoveCampaignPage see Service for more details
+func (h CampaignHandler) RemoveCampaignPage(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewCampaignPageRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.CampaignService.RemoveCampaignPage(ctx, campaignservice.CampaignPageParams{
+		Campaign: campaign.Campaign{
+			GUID: request.CampaignID,
+		},
+		PageID: request.PageID,
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
@@ -0,0 +1,280 @@
+package campaignhandler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	campaignservice "github.com/Pergamene/project-spiderweb-service/internal/services/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/campaign/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+)
+
+type createCampaignCall struct {
+	campaignParams campaignservice.CreateCampaignParams
+	returnRecord   campaign.Campaign
+	returnErr      error
+}
+
+func TestCreateCampaign(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		requestBody          string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		createCampaignCalls  []createCampaignCall
+	}{
+		{
+			name:                 "not authenticated",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"Home Group\",\"summary\":\"Thursday nights\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"CP_1\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			createCampaignCalls: []createCampaignCall{
+				{
+					campaignParams: campaignservice.CreateCampaignParams{
+						Campaign: campaign.Campaign{Name: "Home Group", Summary: "Thursday nights"},
+						OwnerID:  "UR_1",
+					},
+					returnRecord: campaign.Campaign{ID: 1, GUID: "CP_1", Name: "Home Group", Summary: "Thursday nights"},
+				},
+			},
+		},
+		{
+			name: "missing name",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"summary\":\"Thursday nights\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide name\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignService := new(mocks.CampaignService)
+			for index := range tc.createCampaignCalls {
+				campaignService.On("CreateCampaign", mock.Anything, tc.createCampaignCalls[index].campaignParams).Return(tc.createCampaignCalls[index].returnRecord, tc.createCampaignCalls[index].returnErr)
+			}
+			routerHandlers := CampaignRouterHandlers(tc.authZ.APIPath, campaignService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "campaigns",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			campaignService.AssertNumberOfCalls(t, "CreateCampaign", len(tc.createCampaignCalls))
+		})
+	}
+}
+
+type getCampaignCall struct {
+	campaignParams campaignservice.GetCampaignParams
+	returnRecord   campaign.Campaign
+	returnErr      error
+}
+
+func TestGetCampaign(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		campaignID           string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		getCampaignCalls     []getCampaignCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			campaignID:           "CP_1",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"CP_1\",\"name\":\"Home Group\",\"summary\":\"Thursday nights\",\"members\":[{\"userId\":\"UR_1\",\"role\":\"OW\"},{\"userId\":\"UR_2\",\"role\":\"VI\"}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getCampaignCalls: []getCampaignCall{
+				{
+					campaignParams: campaignservice.GetCampaignParams{
+						Campaign: campaign.Campaign{GUID: "CP_1"},
+						UserID:   "UR_2",
+					},
+					returnRecord: campaign.Campaign{
+						ID:      1,
+						GUID:    "CP_1",
+						Name:    "Home Group",
+						Summary: "Thursday nights",
+						Members: []campaign.Member{
+							{UserID: "UR_1", Role: campaign.RoleOwner},
+							{UserID: "UR_2", Role: campaign.RoleViewer},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "not a member",
+			headers: map[string]string{
+				"X-USER-ID": "UR_3",
+			},
+			campaignID:           "CP_1",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			getCampaignCalls: []getCampaignCall{
+				{
+					campaignParams: campaignservice.GetCampaignParams{
+						Campaign: campaign.Campaign{GUID: "CP_1"},
+						UserID:   "UR_3",
+					},
+					returnErr: &storeerror.NotAuthorized{UserID: "UR_3", TableID: "CP_1"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignService := new(mocks.CampaignService)
+			for index := range tc.getCampaignCalls {
+				campaignService.On("GetCampaign", mock.Anything, tc.getCampaignCalls[index].campaignParams).Return(tc.getCampaignCalls[index].returnRecord, tc.getCampaignCalls[index].returnErr)
+			}
+			routerHandlers := CampaignRouterHandlers(tc.authZ.APIPath, campaignService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("campaigns/%v", tc.campaignID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			campaignService.AssertNumberOfCalls(t, "GetCampaign", len(tc.getCampaignCalls))
+		})
+	}
+}
+
+type setCampaignMemberCall struct {
+	memberParams campaignservice.SetCampaignMemberParams
+	returnErr    error
+}
+
+func TestSetCampaignMember(t *testing.T) {
+	cases := []struct {
+		name                   string
+		headers                map[string]string
+		requestBody            string
+		authN                  api.AuthN
+		authZ                  api.AuthZ
+		expectedResponseBody   string
+		expectedStatusCode     int
+		setCampaignMemberCalls []setCampaignMemberCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"role\":\"ED\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			setCampaignMemberCalls: []setCampaignMemberCall{
+				{
+					memberParams: campaignservice.SetCampaignMemberParams{
+						Campaign: campaign.Campaign{GUID: "CP_1"},
+						Member:   campaign.Member{UserID: "UR_2", Role: campaign.RoleEditor},
+						UserID:   "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid role",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"role\":\"admin\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide a valid role\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "second owner",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"role\":\"OW\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"a campaign can only have one owner\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			setCampaignMemberCalls: []setCampaignMemberCall{
+				{
+					memberParams: campaignservice.SetCampaignMemberParams{
+						Campaign: campaign.Campaign{GUID: "CP_1"},
+						Member:   campaign.Member{UserID: "UR_2", Role: campaign.RoleOwner},
+						UserID:   "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "a campaign can only have one owner"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignService := new(mocks.CampaignService)
+			for index := range tc.setCampaignMemberCalls {
+				campaignService.On("SetCampaignMember", mock.Anything, tc.setCampaignMemberCalls[index].memberParams).Return(tc.setCampaignMemberCalls[index].returnErr)
+			}
+			routerHandlers := CampaignRouterHandlers(tc.authZ.APIPath, campaignService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPut,
+				Endpoint:       "campaigns/CP_1/members/UR_2",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			campaignService.AssertNumberOfCalls(t, "SetCampaignMember", len(tc.setCampaignMemberCalls))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package campaignhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// CreateCampaignRequest parameters from the CreateCampaign call
+type CreateCampaignRequest struct {
+	Name    string `json:"name"`
+	Summary string `json:"summary"`
+}
+
+// NewCreateCampaignRequest extracts the CreateCampaignRequest
+func NewCreateCampaignRequest(r *http.Request, p httprouter.Params) (CreateCampaignRequest, error) {
+	var request CreateCampaignRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	return request.validate()
+}
+
+func (request CreateCampaignRequest) validate() (CreateCampaignRequest, error) {
+	if request.Name == "" {
+		return request, errors.New("must provide name")
+	}
+	return request, nil
+}
+
+// GetCampaignRequest parameters from the GetCampaign call
+type GetCampaignRequest struct {
+	CampaignID string
+}
+
+// NewGetCampaignRequest extracts the GetCampaignRequest
+func NewGetCampaignRequest(r *http.Request, p httprouter.Params) (GetCampaignRequest, error) {
+	var request GetCampaignRequest
+	request.CampaignID = p.ByName(CampaignIDRouteKey)
+	return request.validate()
+}
+
+func (request GetCampaignRequest) validate() (GetCampaignRequest, error) {
+	if request.CampaignID == "" {
+		return request, errors.New("must provide a campaign id")
+	}
+	return request, nil
+}
+
+// UpdateCampaignRequest parameters from the UpdateCampaign call
+type UpdateCampaignRequest struct {
+	CampaignID string
+	Name       string `json:"name"`
+	Summary    string `json:"summary"`
+}
+
+// NewUpdateCampaignRequest extracts the UpdateCampaignRequest
+func NewUpdateCampaignRequest(r *http.Request, p httprouter.Params) (UpdateCampaignRequest, error) {
+	var request UpdateCampaignRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.CampaignID = p.ByName(CampaignIDRouteKey)
+	return request.validate()
+}
+
+func (request UpdateCampaignRequest) validate() (UpdateCampaignRequest, error) {
+	if request.CampaignID == "" {
+		return request, errors.New("must provide a campaign id")
+	}
+	if request.Name == "" {
+		return request, errors.New("a campaign must retain a name")
+	}
+	return request, nil
+}
+
+// RemoveCampaignRequest parameters from the RemoveCampaign call
+type RemoveCampaignRequest struct {
+	CampaignID string
+}
+
+// NewRemoveCampaignRequest extracts the RemoveCampaignRequest
+func NewRemoveCampaignRequest(r *http.Request, p httprouter.Params) (RemoveCampaignRequest, error) {
+	var request RemoveCampaignRequest
+	request.CampaignID = p.ByName(CampaignIDRouteKey)
+	return request.validate()
+}
+
+func (request RemoveCampaignRequest) validate() (RemoveCampaignRequest, error) {
+	if request.CampaignID == "" {
+		return request, errors.New("must provide a campaign id")
+	}
+	return request, nil
+}
+
+// SetCampaignMemberRequest parameters from the SetCampaignMember call
+type SetCampaignMemberRequest struct {
+	CampaignID string
+	MemberID   string
+	RoleString string `json:"role"`
+	Role       campaign.Role
+}
+
+// NewSetCampaignMemberRequest extracts the SetCampaignMemberRequest
+func NewSetCampaignMemberRequest(r *http.Request, p httprouter.Params) (SetCampaignMemberRequest, error) {
+	var request SetCampaignMemberRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.CampaignID = p.ByName(CampaignIDRouteKey)
+	request.MemberID = p.ByName(MemberIDRouteKey)
+	return request.validate()
+}
+
+func (request SetCampaignMemberRequest) validate() (SetCampaignMemberRequest, error) {
+	if request.CampaignID == "" {
+		return request, errors.New("must provide a campaign id")
+	}
+	if request.MemberID == "" {
+		return request, errors.New("must provide a member id")
+	}
+	role, err := campaign.GetRole(request.RoleString)
+	if err != nil {
+		return request, errors.New("must provide a valid role")
+	}
+	request.Role = role
+	return request, nil
+}
+
+// RemoveCampaignMemberRequest parameters from the RemoveCampaignMember call
+type RemoveCampaignMemberRequest struct {
+	CampaignID string
+	MemberID   string
+}
+
+// NewRemoveCampaignMemberRequest extracts the RemoveCampaignMemberRequest
+func NewRemoveCampaignMemberRequest(r *http.Request, p httprouter.Params) (RemoveCampaignMemberRequest, error) {
+	var request RemoveCampaignMemberRequest
+	request.CampaignID = p.ByName(CampaignIDRouteKey)
+	request.MemberID = p.ByName(MemberIDRouteKey)
+	return request.validate()
+}
+
+func (request RemoveCampaignMemberRequest) validate() (RemoveCampaignMemberRequest, error) {
+	if request.CampaignID == "" {
+		return request, errors.New("must provide a campaign id")
+	}
+	if request.MemberID == "" {
+		return request, errors.New("must provide a member id")
+	}
+	return request, nil
+}
+
+// CampaignPageRequest parameters from the AddCampaignPage and RemoveCampaignPage calls
+type CampaignPageRequest struct {
+	CampaignID string
+	PageID     string
+}
+
+// NewCampaignPageRequest extracts the CampaignPageRequest
+func NewCampaignPageRequest(r *http.Request, p httprouter.Params) (CampaignPageRequest, error) {
+	var request CampaignPageRequest
+	request.CampaignID = p.ByName(CampaignIDRouteKey)
+	request.PageID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request CampaignPageRequest) validate() (CampaignPageRequest, error) {
+	if request.CampaignID == "" {
+		return request, errors.New("must provide a campaign id")
+	}
+	if request.PageID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	return request, nil
+}
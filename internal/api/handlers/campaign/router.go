@@ -0,0 +1,69 @@
+package campaignhandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+)
+
+// HTTP path fragments keys
+const (
+	CampaignIDRouteKey = "campaignID"
+	MemberIDRouteKey   = "memberID"
+	PageIDRouteKey     = "pageID"
+)
+
+// CampaignRouterHandlers returns the requests for the associated routes.
+func CampaignRouterHandlers(apiPath string, campaignService CampaignService) []api.RouterHandler {
+	handler := CampaignHandler{
+		CampaignService: campaignService,
+	}
+	var routerHandlers []api.RouterHandler
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/campaigns", apiPath),
+		Handle:   handler.CreateCampaign,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/campaigns", apiPath),
+		Handle:   handler.GetCampaigns,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/campaigns/:%v", apiPath, CampaignIDRouteKey),
+		Handle:   handler.GetCampaign,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPut,
+		Endpoint: fmt.Sprintf("/%v/campaigns/:%v", apiPath, CampaignIDRouteKey),
+		Handle:   handler.UpdateCampaign,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodDelete,
+		Endpoint: fmt.Sprintf("/%v/campaigns/:%v", apiPath, CampaignIDRouteKey),
+		Handle:   handler.RemoveCampaign,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPut,
+		Endpoint: fmt.Sprintf("/%v/campaigns/:%v/members/:%v", apiPath, CampaignIDRouteKey, MemberIDRouteKey),
+		Handle:   handler.SetCampaignMember,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodDelete,
+		Endpoint: fmt.Sprintf("/%v/campaigns/:%v/members/:%v", apiPath, CampaignIDRouteKey, MemberIDRouteKey),
+		Handle:   handler.RemoveCampaignMember,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPut,
+		Endpoint: fmt.Sprintf("/%v/campaigns/:%v/pages/:%v", apiPath, CampaignIDRouteKey, PageIDRouteKey),
+		Handle:   handler.AddCampaignPage,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodDelete,
+		Endpoint: fmt.Sprintf("/%v/campaigns/:%v/pages/:%v", apiPath, CampaignIDRouteKey, PageIDRouteKey),
+		Handle:   handler.RemoveCampaignPage,
+	})
+	return routerHandlers
+}
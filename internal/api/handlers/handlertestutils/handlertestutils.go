@@ -8,10 +8,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"time"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/transaction"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/usertoken"
+	"go.uber.org/zap"
 )
 
+// TestRequestID is the request id given with every test request that does not set its own, so that responses are predictable.
+const TestRequestID = "TEST_REQUEST"
+
 // HandleTestRequestParams are the params for the HandleTestRequest function.
 type HandleTestRequestParams struct {
 	Method         string
@@ -20,19 +27,26 @@ type HandleTestRequestParams struct {
 	Headers        map[string]string
 	Body           io.Reader
 	RouterHandlers []api.RouterHandler
+	NonAuthRoutes  []api.NonAuthRoute
 	AuthZ          api.AuthZ
 	AuthN          api.AuthN
+	Auditor        api.Auditor
+	Logger         *zap.Logger
+	Metrics        api.RequestObserver
 }
 
 // HandleTestRequest handles making the request for a given test and returning the response and response body.
 func HandleTestRequest(p HandleTestRequestParams) (*http.Response, string) {
-	router := api.NewRouter(p.AuthZ.APIPath, "static/test", p.RouterHandlers)
+	router := api.NewRouter(p.AuthZ.APIPath, "static/test", p.RouterHandlers, p.NonAuthRoutes)
 	testHandler := api.Handler{
 		AuthN:      p.AuthN,
 		AuthZ:      p.AuthZ,
 		Router:     router,
 		Datacenter: p.AuthN.Datacenter,
 		APIPath:    p.AuthZ.APIPath,
+		Auditor:    p.Auditor,
+		Logger:     p.Logger,
+		Metrics:    p.Metrics,
 	}
 	uri := fmt.Sprintf("http://test.com/%v/%v", p.AuthZ.APIPath, p.Endpoint)
 	params := p.Params.Encode()
@@ -40,6 +54,7 @@ func HandleTestRequest(p HandleTestRequestParams) (*http.Response, string) {
 		uri = uri + "?" + params
 	}
 	r := httptest.NewRequest(p.Method, uri, p.Body)
+	r.Header.Set(transaction.RequestIDHeader, TestRequestID)
 	for key, value := range p.Headers {
 		r.Header.Set(key, value)
 	}
@@ -55,6 +70,17 @@ func DefaultAuthN(datacenter string) api.AuthN {
 	return api.AuthN{
 		Datacenter:      datacenter,
 		AdminAuthSecret: "SECRET",
+		UserTokens:      DefaultUserTokens(),
+	}
+}
+
+// DefaultUserTokens is a quick way to sign and verify user tokens in a test, such as to sign a bearer token for a request.
+func DefaultUserTokens() usertoken.Signer {
+	return usertoken.Signer{
+		Secret:   []byte("SECRET"),
+		Issuer:   "test",
+		Audience: "test",
+		TTL:      time.Hour,
 	}
 }
 
@@ -2,12 +2,25 @@
 package handlertestutils
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	auditservice "github.com/Pergamene/project-spiderweb-service/internal/services/audit"
 )
 
 func TestAPI(t *testing.T) {
@@ -28,7 +41,7 @@ func TestAPI(t *testing.T) {
 			endpoint:             "doesnotexist",
 			authN:                DefaultAuthN("LOCAL"),
 			authZ:                DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"not found\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"not found\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   404,
 		},
 		{
@@ -57,3 +70,317 @@ func TestAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestIDHeader(t *testing.T) {
+	cases := []struct {
+		name              string
+		headers           map[string]string
+		expectedRequestID string
+	}{
+		{
+			name:              "the caller's request id is echoed",
+			headers:           map[string]string{"X-Request-ID": "3f2c9a1e-7d4b-4c8e-9a51"},
+			expectedRequestID: "3f2c9a1e-7d4b-4c8e-9a51",
+		},
+		{
+			name:    "an invalid request id is replaced",
+			headers: map[string]string{"X-Request-ID": "not valid"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, respBody := HandleTestRequest(HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "doesnotexist",
+				Headers:        tc.headers,
+				RouterHandlers: []api.RouterHandler{},
+				AuthZ:          DefaultAuthZ(),
+				AuthN:          DefaultAuthN("LOCAL"),
+			})
+			requestID := resp.Header.Get("X-Request-ID")
+			if tc.expectedRequestID != "" {
+				require.Equal(t, tc.expectedRequestID, requestID)
+			} else {
+				require.True(t, strings.HasPrefix(requestID, "TX_"))
+			}
+			require.Equal(t, fmt.Sprintf("{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"not found\",\"requestId\":\"%v\"}}\n", requestID), respBody)
+		})
+	}
+}
+
+type recordAuditEntryCall struct {
+	paramEntry audit.Entry
+	returnErr  error
+}
+
+// auditTestRouterHandlers are routes over a single title per page, for checking what gets audited.
+func auditTestRouterHandlers(titles map[string]string) []api.RouterHandler {
+	return []api.RouterHandler{
+		{
+			Method:   http.MethodGet,
+			Endpoint: "/api/test/pages/:pageID",
+			Handle: func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+				title, ok := titles[p.ByName("pageID")]
+				if !ok {
+					api.RespondWith(r, w, http.StatusNotFound, errors.New("not found"), nil)
+					return
+				}
+				api.RespondWith(r, w, http.StatusOK, map[string]string{"id": p.ByName("pageID"), "title": title}, nil)
+			},
+		},
+		{
+			Method:   http.MethodPost,
+			Endpoint: "/api/test/pages",
+			Handle: func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+				titles["PG_2"] = "Vallaki"
+				api.RespondWith(r, w, http.StatusOK, map[string]string{"id": "PG_2"}, nil)
+			},
+		},
+		{
+			Method:   http.MethodPatch,
+			Endpoint: "/api/test/pages/:pageID",
+			Handle: func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+				if r.URL.Query().Get("title") == "" {
+					api.RespondWith(r, w, http.StatusBadRequest, errors.New("title is required"), nil)
+					return
+				}
+				titles[p.ByName("pageID")] = r.URL.Query().Get("title")
+				api.RespondWith(r, w, http.StatusOK, nil, nil)
+			},
+		},
+		{
+			Method:   http.MethodDelete,
+			Endpoint: "/api/test/pages/:pageID",
+			Handle: func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+				delete(titles, p.ByName("pageID"))
+				api.RespondWith(r, w, http.StatusOK, nil, nil)
+			},
+		},
+		{
+			Method:   http.MethodPost,
+			Endpoint: "/api/test/tokens",
+			Handle: func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+				api.RespondWith(r, w, http.StatusOK, map[string]string{"id": "TK_1", "token": "SECRET_TOKEN"}, nil)
+			},
+			Secret: true,
+		},
+		{
+			// the listing is never replayed to summarize a created page, which is summarized by its response instead.
+			Method:   http.MethodGet,
+			Endpoint: "/api/test/pages",
+			Handle: func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+				api.RespondWith(r, w, http.StatusOK, titles, nil)
+			},
+		},
+	}
+}
+
+func TestAudit(t *testing.T) {
+	cases := []struct {
+		name                  string
+		method                string
+		endpoint              string
+		params                url.Values
+		headers               map[string]string
+		expectedStatusCode    int
+		recordAuditEntryCalls []recordAuditEntryCall
+	}{
+		{
+			name:               "test reads are not audited",
+			method:             http.MethodGet,
+			endpoint:           "pages/PG_1",
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "test create",
+			method:             http.MethodPost,
+			endpoint:           "pages",
+			headers:            map[string]string{api.UserIDHeaderKey: "UR_1"},
+			expectedStatusCode: 200,
+			recordAuditEntryCalls: []recordAuditEntryCall{{paramEntry: audit.Entry{
+				Actor:      "proxyUser",
+				UserID:     "UR_1",
+				Method:     http.MethodPost,
+				Path:       "/api/test/pages",
+				Status:     200,
+				EntityGUID: "PG_2",
+				After:      "{\"id\":\"PG_2\"}",
+			}}},
+		},
+		{
+			name:               "test update",
+			method:             http.MethodPatch,
+			endpoint:           "pages/PG_1",
+			params:             url.Values{"title": []string{"Village of Barovia"}},
+			headers:            map[string]string{api.UserIDHeaderKey: "UR_1"},
+			expectedStatusCode: 200,
+			recordAuditEntryCalls: []recordAuditEntryCall{{paramEntry: audit.Entry{
+				Actor:      "proxyUser",
+				UserID:     "UR_1",
+				Method:     http.MethodPatch,
+				Path:       "/api/test/pages/PG_1",
+				Status:     200,
+				EntityGUID: "PG_1",
+				Before:     "{\"id\":\"PG_1\",\"title\":\"Barovia\"}",
+				After:      "{\"id\":\"PG_1\",\"title\":\"Village of Barovia\"}",
+			}}},
+		},
+		{
+			name:               "test failed update",
+			method:             http.MethodPatch,
+			endpoint:           "pages/PG_1",
+			headers:            map[string]string{api.UserIDHeaderKey: "UR_1"},
+			expectedStatusCode: 400,
+			recordAuditEntryCalls: []recordAuditEntryCall{{paramEntry: audit.Entry{
+				Actor:      "proxyUser",
+				UserID:     "UR_1",
+				Method:     http.MethodPatch,
+				Path:       "/api/test/pages/PG_1",
+				Status:     400,
+				EntityGUID: "PG_1",
+				Before:     "{\"id\":\"PG_1\",\"title\":\"Barovia\"}",
+			}}},
+		},
+		{
+			name:               "test delete by admin",
+			method:             http.MethodDelete,
+			endpoint:           "pages/PG_1",
+			expectedStatusCode: 200,
+			recordAuditEntryCalls: []recordAuditEntryCall{{paramEntry: audit.Entry{
+				Actor:      "admin",
+				Method:     http.MethodDelete,
+				Path:       "/api/test/pages/PG_1",
+				Status:     200,
+				EntityGUID: "PG_1",
+				Before:     "{\"id\":\"PG_1\",\"title\":\"Barovia\"}",
+			}}},
+		},
+		{
+			name:               "test secret responses are not recorded",
+			method:             http.MethodPost,
+			endpoint:           "tokens",
+			expectedStatusCode: 200,
+			recordAuditEntryCalls: []recordAuditEntryCall{{paramEntry: audit.Entry{
+				Actor:  "admin",
+				Method: http.MethodPost,
+				Path:   "/api/test/tokens",
+				Status: 200,
+			}}},
+		},
+		{
+			name:               "test audit failure does not affect the response",
+			method:             http.MethodDelete,
+			endpoint:           "pages/PG_1",
+			expectedStatusCode: 200,
+			recordAuditEntryCalls: []recordAuditEntryCall{{
+				paramEntry: audit.Entry{
+					Actor:      "admin",
+					Method:     http.MethodDelete,
+					Path:       "/api/test/pages/PG_1",
+					Status:     200,
+					EntityGUID: "PG_1",
+					Before:     "{\"id\":\"PG_1\",\"title\":\"Barovia\"}",
+				},
+				returnErr: errors.New("failure"),
+			}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditor := new(mocks.Auditor)
+			for _, call := range tc.recordAuditEntryCalls {
+				auditor.On("RecordAuditEntry", mock.Anything, auditservice.RecordAuditEntryParams{Entry: call.paramEntry}).Return(call.returnErr)
+			}
+			resp, _ := HandleTestRequest(HandleTestRequestParams{
+				Method:         tc.method,
+				Endpoint:       tc.endpoint,
+				Params:         tc.params,
+				Headers:        tc.headers,
+				RouterHandlers: auditTestRouterHandlers(map[string]string{"PG_1": "Barovia"}),
+				AuthZ:          DefaultAuthZ(),
+				AuthN:          DefaultAuthN("LOCAL"),
+				Auditor:        auditor,
+			})
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			auditor.AssertNumberOfCalls(t, "RecordAuditEntry", len(tc.recordAuditEntryCalls))
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	cases := []struct {
+		name            string
+		method          string
+		endpoint        string
+		headers         map[string]string
+		expectedEntries []map[string]interface{}
+	}{
+		{
+			name:     "successful request",
+			method:   http.MethodGet,
+			endpoint: "pages/PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			expectedEntries: []map[string]interface{}{
+				{"msg": "Handled request", "requestId": TestRequestID, "method": "GET", "route": "/api/test/pages/:pageID", "path": "/api/test/pages/PG_1", "authType": "proxyUser", "userId": "UR_1", "status": float64(200)},
+			},
+		},
+		{
+			name:     "failed request",
+			method:   http.MethodPatch,
+			endpoint: "pages/PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			expectedEntries: []map[string]interface{}{
+				{"msg": "Response error", "requestId": TestRequestID, "method": "PATCH", "route": "/api/test/pages/:pageID", "authType": "proxyUser", "userId": "UR_1", "status": float64(400), "err": "request TEST_REQUEST (transaction %v): title is required"},
+				{"msg": "Handled request", "requestId": TestRequestID, "method": "PATCH", "route": "/api/test/pages/:pageID", "path": "/api/test/pages/PG_1", "authType": "proxyUser", "userId": "UR_1", "status": float64(400)},
+			},
+		},
+		{
+			name:     "unknown route",
+			method:   http.MethodGet,
+			endpoint: "doesnotexist",
+			expectedEntries: []map[string]interface{}{
+				{"msg": "Handled request", "requestId": TestRequestID, "method": "GET", "route": "", "path": "/api/test/doesnotexist", "authType": "admin", "userId": "", "status": float64(404)},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&logs), zap.DebugLevel))
+			routerHandlers := auditTestRouterHandlers(map[string]string{"PG_1": "Barovia"})
+			// fail the PATCH route with an error to log
+			routerHandlers[2].Handle = func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+				api.RespondWith(r, w, http.StatusBadRequest, errors.New("title is required"), errors.New("title is required"))
+			}
+			HandleTestRequest(HandleTestRequestParams{
+				Method:         tc.method,
+				Endpoint:       tc.endpoint,
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          DefaultAuthZ(),
+				AuthN:          DefaultAuthN("LOCAL"),
+				Logger:         logger,
+			})
+			lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+			require.Len(t, lines, len(tc.expectedEntries))
+			for i, line := range lines {
+				entry := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal([]byte(line), &entry))
+				require.NotEmpty(t, entry["transactionId"])
+				for key, value := range tc.expectedEntries[i] {
+					if key == "err" {
+						value = fmt.Sprintf(value.(string), entry["transactionId"])
+					}
+					require.Equal(t, value, entry[key], key)
+				}
+				if entry["msg"] == "Handled request" {
+					require.Contains(t, entry, "latency")
+				}
+			}
+		})
+	}
+}
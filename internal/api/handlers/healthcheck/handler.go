@@ -6,7 +6,6 @@ import (
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api"
 	"github.com/julienschmidt/httprouter"
-	"github.com/pkg/errors"
 )
 
 // HealthcheckHandler is the handler for the associated API
@@ -21,17 +20,7 @@ type HealthcheckService interface {
 
 // IsHealthy see Service for more details
 func (h HealthcheckHandler) IsHealthy(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
-	ctx := r.Context()
-	authData, err := api.GetDataFromContext(ctx)
-	if err != nil {
-		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
-		return
-	}
-	if !authData.IsAdmin() {
-		api.RespondWith(r, w, http.StatusUnauthorized, &api.InternalErr{}, errors.Wrapf(err, "user not authorized for healthcheck: %v", authData.UserID))
-		return
-	}
-	isHealthy, err := h.HealthcheckService.IsHealthy(ctx)
+	isHealthy, err := h.HealthcheckService.IsHealthy(r.Context())
 	if err != nil {
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
 		return
@@ -1,7 +1,6 @@
 package healthcheckhandler
 
 import (
-	"fmt"
 	"net/http"
 	"testing"
 
@@ -37,7 +36,7 @@ func TestMethodNotAllowed(t *testing.T) {
 			authZ: api.AuthZ{
 				APIPath: "api/test",
 			},
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"405 - Method Not Allowed\",\"message\":\"method not allowed\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"405 - Method Not Allowed\",\"message\":\"method not allowed\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   405,
 		},
 	}
@@ -79,7 +78,7 @@ func TestIsHealthy(t *testing.T) {
 			name:                 "not authenticated",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
@@ -89,14 +88,24 @@ func TestIsHealthy(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
+		{
+			name: "user, not an admin",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"403 - Forbidden\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   403,
+		},
 		{
 			name:                 "happy healthy healthcheck, local",
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"status\":\"ok\"},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"status\":\"ok\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			isHealthyCalls:       []isHealthyCall{{returnIsHealthy: true}},
 		},
@@ -107,7 +116,7 @@ func TestIsHealthy(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"status\":\"ok\"},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"status\":\"ok\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			isHealthyCalls:       []isHealthyCall{{returnIsHealthy: true}},
 		},
@@ -115,13 +124,13 @@ func TestIsHealthy(t *testing.T) {
 			name:                 "bad healthcheck, local",
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"status\":\"error\"},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"status\":\"error\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			isHealthyCalls:       []isHealthyCall{{returnIsHealthy: false}},
 		},
 	}
 	for _, tc := range cases {
-		t.Run(fmt.Sprintf(tc.name), func(t *testing.T) {
+		t.Run(tc.name, func(t *testing.T) {
 			healthcheckService := new(mocks.HealthcheckService)
 			for index := range tc.isHealthyCalls {
 				healthcheckService.On("IsHealthy", mock.Anything).Return(tc.isHealthyCalls[index].returnIsHealthy, tc.isHealthyCalls[index].returnErr)
@@ -17,6 +17,7 @@ func HealthcheckRouterHandlers(apiPath string, healthcheckService HealthcheckSer
 		Method:   http.MethodGet,
 		Endpoint: fmt.Sprintf("/%v/healthcheck", apiPath),
 		Handle:   handler.IsHealthy,
+		Access:   api.AccessAdmin,
 	})
 	return routerHandlers
 }
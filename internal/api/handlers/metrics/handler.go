@@ -0,0 +1,17 @@
+package metricshandler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// MetricsHandler is the handler for the associated API
+type MetricsHandler struct {
+	Metrics http.Handler
+}
+
+// GetMetrics responds with the metrics in the Prometheus text format.
+func (h MetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	h.Metrics.ServeHTTP(w, r)
+}
@@ -0,0 +1,58 @@
+package metricshandler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/metrics"
+)
+
+func TestGetMetrics(t *testing.T) {
+	cases := []struct {
+		name               string
+		headers            map[string]string
+		authN              api.AuthN
+		authZ              api.AuthZ
+		expectedReportLine string
+		expectedStatusCode int
+	}{
+		{
+			name:               "happy path",
+			authN:              handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:              handlertestutils.DefaultAuthZ(),
+			expectedReportLine: "spiderweb_http_requests_total{method=\"GET\",route=\"/api/test/pages\",status=\"200\"} 1\n",
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "no authentication is required",
+			authN:              handlertestutils.DefaultAuthN("PROD"),
+			authZ:              handlertestutils.DefaultAuthZ(),
+			expectedReportLine: "spiderweb_http_requests_total{method=\"GET\",route=\"/api/test/pages\",status=\"200\"} 1\n",
+			expectedStatusCode: 200,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMetrics := metrics.New()
+			serviceMetrics.ObserveRequest(http.MethodGet, "/api/test/pages", http.StatusOK, time.Millisecond)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:        http.MethodGet,
+				Endpoint:      "metrics",
+				Headers:       tc.headers,
+				NonAuthRoutes: MetricsNonAuthRoutes(tc.authZ.APIPath, serviceMetrics),
+				AuthZ:         tc.authZ,
+				AuthN:         tc.authN,
+				Metrics:       serviceMetrics,
+			})
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			require.Equal(t, metrics.ContentType, resp.Header.Get("Content-Type"))
+			require.True(t, strings.Contains(respBody, tc.expectedReportLine), respBody)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package metricshandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+)
+
+// MetricsNonAuthRoutes returns the requests for the associated routes.
+// They do not require authentication, so that the metrics can be scraped.
+func MetricsNonAuthRoutes(apiPath string, metrics http.Handler) []api.NonAuthRoute {
+	handler := MetricsHandler{
+		Metrics: metrics,
+	}
+	var nonAuthRoutes []api.NonAuthRoute
+	nonAuthRoutes = append(nonAuthRoutes, api.NonAuthRoute{
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/%v/metrics", apiPath),
+		Handler: handler.GetMetrics,
+	})
+	return nonAuthRoutes
+}
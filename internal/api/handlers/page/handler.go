@@ -2,17 +2,23 @@ package pagehandler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/search"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/nextbatch"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
 	pageservice "github.com/Pergamene/project-spiderweb-service/internal/services/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 
 	"github.com/julienschmidt/httprouter"
@@ -23,12 +29,24 @@ import (
 type PageService interface {
 	CreatePage(ctx context.Context, params pageservice.CreatePageParams) (page.Page, error)
 	UpdatePage(ctx context.Context, params pageservice.UpdatePageParams) error
-	RemovePage(ctx context.Context, params pageservice.RemovePageParams) error
+	RemovePage(ctx context.Context, params pageservice.RemovePageParams) ([]relation.AffectedPage, error)
 	GetPages(ctx context.Context, params pageservice.GetPagesParams) ([]page.Page, int, string, error)
+	GetPageFacets(ctx context.Context, params pageservice.GetPageFacetsParams) (pagefilter.Facets, error)
 	GetPage(ctx context.Context, params pageservice.GetPageParams) (page.Page, error)
 	GetEntirePage(ctx context.Context, params pageservice.GetEntirePageParams) (page.Page, error)
 	GetPageProperties(ctx context.Context, params pageservice.GetPagePropertiesParams) ([]property.Property, error)
 	ReplacePageProperties(ctx context.Context, params pageservice.ReplacePagePropertiesParams) error
+	GetPageBacklinks(ctx context.Context, params pageservice.GetPageBacklinksParams) ([]relation.Backlink, error)
+	GetPageGraph(ctx context.Context, params pageservice.GetPageGraphParams) (relation.Graph, error)
+	GetDanglingRelations(ctx context.Context, params pageservice.GetDanglingRelationsParams) ([]relation.Link, error)
+	SearchPages(ctx context.Context, params pageservice.SearchPagesParams) ([]search.Result, error)
+	ExportPageMarkdown(ctx context.Context, params pageservice.ExportPageMarkdownParams) (string, error)
+	GetPageRevisions(ctx context.Context, params pageservice.GetPageRevisionsParams) ([]revision.Revision, error)
+	GetPageRevision(ctx context.Context, params pageservice.GetPageRevisionParams) (revision.Revision, error)
+	RestorePageRevision(ctx context.Context, params pageservice.RestorePageRevisionParams) error
+	GetRemovedPages(ctx context.Context, params pageservice.GetRemovedPagesParams) ([]page.Page, error)
+	RestorePage(ctx context.Context, params pageservice.RestorePageParams) error
+	PurgePage(ctx context.Context, params pageservice.PurgePageParams) error
 }
 
 // PageHandler is the handler for the associated API
@@ -67,6 +85,10 @@ func (h PageHandler) CreatePage(w http.ResponseWriter, r *http.Request, p httpro
 		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
 		return
 	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
 	if err != nil {
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
 		return
@@ -106,6 +128,10 @@ func (h PageHandler) UpdatePage(w http.ResponseWriter, r *http.Request, p httpro
 		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
 		return
 	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
 	if err != nil {
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
 		return
@@ -190,6 +216,9 @@ func (h PageHandler) GetPages(w http.ResponseWriter, r *http.Request, p httprout
 		return
 	}
 	records, total, nextBatchID, err := h.PageService.GetPages(ctx, pageservice.GetPagesParams{
+		Filter:      request.Filter,
+		Sort:        request.Sort,
+		Limit:       request.Limit,
 		NextBatchID: request.NextBatchID,
 		UserID:      authData.UserID,
 	})
@@ -197,6 +226,18 @@ func (h PageHandler) GetPages(w http.ResponseWriter, r *http.Request, p httprout
 		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
 		return
 	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	facets, err := h.PageService.GetPageFacets(ctx, pageservice.GetPageFacetsParams{
+		Filter: request.Filter,
+		UserID: authData.UserID,
+	})
 	if err != nil {
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
 		return
@@ -208,11 +249,13 @@ func (h PageHandler) GetPages(w http.ResponseWriter, r *http.Request, p httprout
 	}
 	if nextBatchID == "" {
 		responseBody := struct {
-			Batch []interface{} `json:"batch"`
-			Total int           `json:"total"`
+			Batch  []interface{}     `json:"batch"`
+			Total  int               `json:"total"`
+			Facets pagefilter.Facets `json:"facets"`
 		}{
-			Batch: conformedRecords,
-			Total: total,
+			Batch:  conformedRecords,
+			Total:  total,
+			Facets: facets,
 		}
 		api.RespondWith(r, w, http.StatusOK, responseBody, nil)
 		return
@@ -221,6 +264,7 @@ func (h PageHandler) GetPages(w http.ResponseWriter, r *http.Request, p httprout
 		Batch     []interface{}       `json:"batch"`
 		Total     int                 `json:"total"`
 		NextBatch nextbatch.NextBatch `json:"nextBatch"`
+		Facets    pagefilter.Facets   `json:"facets"`
 	}{
 		Batch: conformedRecords,
 		Total: total,
@@ -228,6 +272,7 @@ func (h PageHandler) GetPages(w http.ResponseWriter, r *http.Request, p httprout
 			ParamKey:   "nextBatchId",
 			ParamValue: nextBatchID,
 		},
+		Facets: facets,
 	}
 	api.RespondWith(r, w, http.StatusOK, responseBody, nil)
 }
@@ -245,21 +290,30 @@ func (h PageHandler) DeletePage(w http.ResponseWriter, r *http.Request, p httpro
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
 		return
 	}
-	err = h.PageService.RemovePage(ctx, pageservice.RemovePageParams{
+	affected, err := h.PageService.RemovePage(ctx, pageservice.RemovePageParams{
 		Page: page.Page{
 			GUID: request.GUID,
 		},
+		Preview: request.Preview,
+		Unlink:  request.Unlink,
+		ReplacementPage: page.Page{
+			GUID: request.ReplacementID,
+		},
 		UserID: authData.UserID,
 	})
 	if _, ok := err.(*storeerror.NotAuthorized); ok {
 		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
 		return
 	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
 	if err != nil {
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
 		return
 	}
-	api.RespondWith(r, w, http.StatusOK, nil, nil)
+	api.RespondWith(r, w, http.StatusOK, affected, nil)
 }
 
 // GetPageProperties see Service for more details
@@ -316,6 +370,355 @@ func (h PageHandler) ReplacePageProperties(w http.ResponseWriter, r *http.Reques
 		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
 		return
 	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// GetPageBacklinks see Service for more details
+func (h PageHandler) GetPageBacklinks(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetPageBacklinksRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.PageService.GetPageBacklinks(ctx, pageservice.GetPageBacklinksParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, records, nil)
+}
+
+// GetPageGraph see Service for more details
+func (h PageHandler) GetPageGraph(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetPageGraphRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	graph, err := h.PageService.GetPageGraph(ctx, pageservice.GetPageGraphParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		Depth:            request.Depth,
+		PageTemplateGUID: request.PageTemplateID,
+		VersionGUID:      request.VersionID,
+		UserID:           authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, graph, nil)
+}
+
+// GetDanglingRelations see Service for more details
+func (h PageHandler) GetDanglingRelations(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	links, err := h.PageService.GetDanglingRelations(ctx, pageservice.GetDanglingRelationsParams{
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, links, nil)
+}
+
+// SearchPages see Service for more details
+func (h PageHandler) SearchPages(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewSearchPagesRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	results, err := h.PageService.SearchPages(ctx, pageservice.SearchPagesParams{
+		Query:  request.Query,
+		Limit:  request.Limit,
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, results, nil)
+}
+
+// ExportPage see Service for more details
+func (h PageHandler) ExportPage(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewExportPageRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	markdown, err := h.PageService.ExportPageMarkdown(ctx, pageservice.ExportPageMarkdownParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", request.GUID+".md"))
+	api.RespondWithContent(r, w, http.StatusOK, "text/markdown; charset=utf-8", []byte(markdown))
+}
+
+// GetPageRevisions see Service for more details
+func (h PageHandler) GetPageRevisions(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetPageRevisionsRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.PageService.GetPageRevisions(ctx, pageservice.GetPageRevisionsParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	conformedRecords := make([]interface{}, 0)
+	for _, record := range records {
+		conformedRecords = append(conformedRecords, record.GetJSONConformed())
+	}
+	api.RespondWith(r, w, http.StatusOK, conformedRecords, nil)
+}
+
+// GetPageRevision see Service for more details
+func (h PageHandler) GetPageRevision(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetPageRevisionRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.PageService.GetPageRevision(ctx, pageservice.GetPageRevisionParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		Revision: revision.Revision{
+			GUID: request.RevisionGUID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, record.GetJSONConformed(), nil)
+}
+
+// RestorePageRevision see Service for more details
+func (h PageHandler) RestorePageRevision(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewRestorePageRevisionRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageService.RestorePageRevision(ctx, pageservice.RestorePageRevisionParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		Revision: revision.Revision{
+			GUID: request.RevisionGUID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// GetRemovedPages see Service for more details
+func (h PageHandler) GetRemovedPages(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.PageService.GetRemovedPages(ctx, pageservice.GetRemovedPagesParams{
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	conformedRecords := make([]interface{}, 0)
+	for _, record := range records {
+		reducedPage := record.Reduce()
+		conformedRecords = append(conformedRecords, reducedPage.GetJSONConformed())
+	}
+	responseBody := struct {
+		Batch []interface{} `json:"batch"`
+	}{
+		Batch: conformedRecords,
+	}
+	api.RespondWith(r, w, http.StatusOK, responseBody, nil)
+}
+
+// RestorePage see Service for more details
+func (h PageHandler) RestorePage(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewRestorePageRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageService.RestorePage(ctx, pageservice.RestorePageParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// PurgePage see Service for more details
+func (h PageHandler) PurgePage(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewPurgePageRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageService.PurgePage(ctx, pageservice.PurgePageParams{
+		Page: page.Page{
+			GUID: request.GUID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
 	if err != nil {
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
 		return
@@ -6,13 +6,23 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 	"github.com/pkg/errors"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/search"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
 
 	"github.com/stretchr/testify/mock"
@@ -63,7 +73,7 @@ func TestCreatePage(t *testing.T) {
 			name:                 "not authenticated",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
@@ -80,7 +90,7 @@ func TestCreatePage(t *testing.T) {
 			// },
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"id\":\"PG_1\"},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"id\":\"PG_1\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			createPageCalls: []createPageCall{
 				{
@@ -100,7 +110,7 @@ func TestCreatePage(t *testing.T) {
 			requestBody:          "{\"summary\":\"test summary\",\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"permission\":\"PR\"}",
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide title\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide title\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   400,
 		},
 	}
@@ -151,7 +161,7 @@ func TestUpdatePage(t *testing.T) {
 			pageID:               "PG_1",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
@@ -163,7 +173,7 @@ func TestUpdatePage(t *testing.T) {
 			requestBody:          "{\"title\":\"test title\",\"summary\":\"test summary\"}",
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			updatePageCalls: []updatePageCall{
 				{
@@ -183,7 +193,7 @@ func TestUpdatePage(t *testing.T) {
 			requestBody:          "{\"title\":\"test title\",\"summary\":\"test summary\"}",
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 			updatePageCalls: []updatePageCall{
 				{
@@ -199,6 +209,27 @@ func TestUpdatePage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "moving a page into another user's version",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"versionId\":\"VR_2\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"version VR_2 cannot be used by user UR_1\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			updatePageCalls: []updatePageCall{
+				{
+					pageParams: pageservice.UpdatePageParams{
+						Page:   getPage("PG_1", "", "", "VR_2", "", ""),
+						UserID: "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "version VR_2 cannot be used by user UR_1"},
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -247,7 +278,7 @@ func TestGetEntirePage(t *testing.T) {
 			pageID:               "PG_1",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
@@ -258,7 +289,7 @@ func TestGetEntirePage(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"version\":{\"id\":\"VR_1\",\"name\":\"\",\"parentId\":\"\"},\"pageTemplate\":{\"name\":\"\",\"guid\":\"PGT_1\"},\"id\":\"PG_1\",\"title\":\"test title\",\"summary\":\"test summary\",\"permission\":\"PR\",\"properties\":[],\"details\":[],\"createdAt\":null,\"updatedAt\":null},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"version\":{\"id\":\"VR_1\",\"name\":\"\",\"parentId\":\"\"},\"pageTemplate\":{\"name\":\"\",\"guid\":\"PGT_1\"},\"id\":\"PG_1\",\"title\":\"test title\",\"summary\":\"test summary\",\"permission\":\"PR\",\"properties\":[],\"details\":[],\"createdAt\":null,\"updatedAt\":null},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			getEntirePageCalls: []getEntirePageCall{
 				{
@@ -278,7 +309,7 @@ func TestGetEntirePage(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 			getEntirePageCalls: []getEntirePageCall{
 				{
@@ -342,7 +373,7 @@ func TestGetPage(t *testing.T) {
 			pageID:               "PG_1",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
@@ -353,7 +384,7 @@ func TestGetPage(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"id\":\"PG_1\",\"title\":\"test title\",\"summary\":\"test summary\",\"permission\":\"PR\",\"createdAt\":null,\"updatedAt\":null},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"id\":\"PG_1\",\"title\":\"test title\",\"summary\":\"test summary\",\"permission\":\"PR\",\"createdAt\":null,\"updatedAt\":null},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			getPageCalls: []getPageCall{
 				{
@@ -373,7 +404,7 @@ func TestGetPage(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 			getPageCalls: []getPageCall{
 				{
@@ -421,9 +452,28 @@ type getPagesCall struct {
 	returnErr         error
 }
 
+type getPageFacetsCall struct {
+	pageParams   pageservice.GetPageFacetsParams
+	returnFacets pagefilter.Facets
+	returnErr    error
+}
+
+func getTestPageFilter() pagefilter.Filter {
+	return pagefilter.Filter{
+		PageTemplateGUID: "PGT_1",
+		VersionGUID:      "VR_1",
+		PermissionType:   permission.TypePrivate,
+		Conditions: []pagefilter.Condition{
+			{Key: "population", Operator: pagefilter.OperatorGreater, Type: property.TypeNumber, NumberValue: 10000},
+			{Key: "faction", Operator: pagefilter.OperatorEqual, Type: property.TypeString, StringValue: "Zhentarim"},
+		},
+	}
+}
+
 func TestGetPages(t *testing.T) {
 	cases := []struct {
 		name                 string
+		query                string
 		headers              map[string]string
 		requestBody          string
 		authN                api.AuthN
@@ -432,12 +482,13 @@ func TestGetPages(t *testing.T) {
 		expectedResponseBody string
 		expectedStatusCode   int
 		getPagesCalls        []getPagesCall
+		getPageFacetsCalls   []getPageFacetsCall
 	}{
 		{
 			name:                 "not authenticated",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
@@ -447,11 +498,13 @@ func TestGetPages(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"batch\":[{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"id\":\"PG_1\",\"title\":\"test title\",\"summary\":\"test summary\",\"permission\":\"PR\",\"createdAt\":null,\"updatedAt\":null},{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"id\":\"PG_2\",\"title\":\"test title 2 \",\"summary\":\"test summary 2\",\"permission\":\"PR\",\"createdAt\":null,\"updatedAt\":null},{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_2\",\"id\":\"PG_3\",\"title\":\"test title 3\",\"summary\":\"test summary 3\",\"permission\":\"PU\",\"createdAt\":null,\"updatedAt\":null}],\"total\":10,\"nextBatch\":{\"paramKey\":\"nextBatchId\",\"paramValue\":\"PG_4\"}},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"batch\":[{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"id\":\"PG_1\",\"title\":\"test title\",\"summary\":\"test summary\",\"permission\":\"PR\",\"createdAt\":null,\"updatedAt\":null},{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"id\":\"PG_2\",\"title\":\"test title 2 \",\"summary\":\"test summary 2\",\"permission\":\"PR\",\"createdAt\":null,\"updatedAt\":null},{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_2\",\"id\":\"PG_3\",\"title\":\"test title 3\",\"summary\":\"test summary 3\",\"permission\":\"PU\",\"createdAt\":null,\"updatedAt\":null}],\"total\":10,\"nextBatch\":{\"paramKey\":\"nextBatchId\",\"paramValue\":\"PG_4\"},\"facets\":{\"pageTemplates\":[{\"id\":\"PGT_1\",\"name\":\"Settlement\",\"count\":2},{\"id\":\"PGT_2\",\"name\":\"Location\",\"count\":1}],\"properties\":[{\"key\":\"faction\",\"values\":[{\"value\":\"Zhentarim\",\"count\":2}]}]}},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			getPagesCalls: []getPagesCall{
 				{
 					pageParams: pageservice.GetPagesParams{
+						Sort:        pagesort.DefaultSort,
+						Limit:       10,
 						NextBatchID: "",
 						UserID:      "UR_1",
 					},
@@ -464,6 +517,22 @@ func TestGetPages(t *testing.T) {
 					returnNextBatchID: "PG_4",
 				},
 			},
+			getPageFacetsCalls: []getPageFacetsCall{
+				{
+					pageParams: pageservice.GetPageFacetsParams{
+						UserID: "UR_1",
+					},
+					returnFacets: pagefilter.Facets{
+						PageTemplates: []pagefilter.PageTemplateFacet{
+							{PageTemplateGUID: "PGT_1", Name: "Settlement", Count: 2},
+							{PageTemplateGUID: "PGT_2", Name: "Location", Count: 1},
+						},
+						Properties: []pagefilter.PropertyFacet{
+							{Key: "faction", Values: []pagefilter.ValueFacet{{Value: "Zhentarim", Count: 2}}},
+						},
+					},
+				},
+			},
 		},
 		{
 			name: "returning no pages",
@@ -472,11 +541,13 @@ func TestGetPages(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"result\":{\"batch\":[],\"total\":0},\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":{\"batch\":[],\"total\":0,\"facets\":{\"pageTemplates\":[],\"properties\":[]}},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			getPagesCalls: []getPagesCall{
 				{
 					pageParams: pageservice.GetPagesParams{
+						Sort:        pagesort.DefaultSort,
+						Limit:       10,
 						NextBatchID: "",
 						UserID:      "UR_1",
 					},
@@ -485,6 +556,163 @@ func TestGetPages(t *testing.T) {
 					returnNextBatchID: "",
 				},
 			},
+			getPageFacetsCalls: []getPageFacetsCall{
+				{
+					pageParams: pageservice.GetPageFacetsParams{
+						UserID: "UR_1",
+					},
+					returnFacets: pagefilter.Facets{
+						PageTemplates: []pagefilter.PageTemplateFacet{},
+						Properties:    []pagefilter.PropertyFacet{},
+					},
+				},
+			},
+		},
+		{
+			name:  "filtered",
+			query: "?pageTemplateId=PGT_1&versionId=VR_1&permission=PR&property=" + url.QueryEscape("population > 10000") + "&property=" + url.QueryEscape(`faction = "Zhentarim"`),
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"batch\":[],\"total\":0,\"facets\":{\"pageTemplates\":[],\"properties\":[]}},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPagesCalls: []getPagesCall{
+				{
+					pageParams: pageservice.GetPagesParams{
+						Filter:      getTestPageFilter(),
+						Sort:        pagesort.DefaultSort,
+						Limit:       10,
+						NextBatchID: "",
+						UserID:      "UR_1",
+					},
+					returnPages: []page.Page{},
+				},
+			},
+			getPageFacetsCalls: []getPageFacetsCall{
+				{
+					pageParams: pageservice.GetPageFacetsParams{
+						Filter: getTestPageFilter(),
+						UserID: "UR_1",
+					},
+					returnFacets: pagefilter.Facets{
+						PageTemplates: []pagefilter.PageTemplateFacet{},
+						Properties:    []pagefilter.PropertyFacet{},
+					},
+				},
+			},
+		},
+		{
+			name:  "invalid property filter",
+			query: "?property=" + url.QueryEscape(`faction > "Zhentarim"`),
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"property filter faction \\u003e \\\"Zhentarim\\\" can only compare strings with = or !=\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:  "invalid permission",
+			query: "?permission=XX",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"permission is not a valid value\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:  "sorted with a limit",
+			query: "?sort=title&order=desc&limit=25&nextBatchId=abc",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"batch\":[],\"total\":0,\"facets\":{\"pageTemplates\":[],\"properties\":[]}},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPagesCalls: []getPagesCall{
+				{
+					pageParams: pageservice.GetPagesParams{
+						Sort:        pagesort.Sort{Field: pagesort.FieldTitle, Order: pagesort.OrderDescending},
+						Limit:       25,
+						NextBatchID: "abc",
+						UserID:      "UR_1",
+					},
+					returnPages: []page.Page{},
+				},
+			},
+			getPageFacetsCalls: []getPageFacetsCall{
+				{
+					pageParams: pageservice.GetPageFacetsParams{
+						UserID: "UR_1",
+					},
+					returnFacets: pagefilter.Facets{
+						PageTemplates: []pagefilter.PageTemplateFacet{},
+						Properties:    []pagefilter.PropertyFacet{},
+					},
+				},
+			},
+		},
+		{
+			name:  "next batch id for a different sort",
+			query: "?sort=updatedAt&nextBatchId=abc",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"nextBatchId was given for a different sort\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			getPagesCalls: []getPagesCall{
+				{
+					pageParams: pageservice.GetPagesParams{
+						Sort:        pagesort.Sort{Field: pagesort.FieldUpdatedAt, Order: pagesort.OrderAscending},
+						Limit:       10,
+						NextBatchID: "abc",
+						UserID:      "UR_1",
+					},
+					returnPages: []page.Page{},
+					returnErr:   &serviceerror.InvalidRequest{Message: "nextBatchId was given for a different sort"},
+				},
+			},
+		},
+		{
+			name:  "invalid sort",
+			query: "?sort=summary",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"sort must be title, createdAt or updatedAt\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:  "invalid order",
+			query: "?order=up",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"order must be asc or desc\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:  "limit too large",
+			query: "?limit=101",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"limit must be a number between 1 and 100\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
 		},
 	}
 	for _, tc := range cases {
@@ -493,10 +721,13 @@ func TestGetPages(t *testing.T) {
 			for index := range tc.getPagesCalls {
 				pageService.On("GetPages", mock.Anything, tc.getPagesCalls[index].pageParams).Return(tc.getPagesCalls[index].returnPages, tc.getPagesCalls[index].returnTotal, tc.getPagesCalls[index].returnNextBatchID, tc.getPagesCalls[index].returnErr)
 			}
+			for index := range tc.getPageFacetsCalls {
+				pageService.On("GetPageFacets", mock.Anything, tc.getPageFacetsCalls[index].pageParams).Return(tc.getPageFacetsCalls[index].returnFacets, tc.getPageFacetsCalls[index].returnErr)
+			}
 			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
 			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
 				Method:         http.MethodGet,
-				Endpoint:       "pages",
+				Endpoint:       "pages" + tc.query,
 				Headers:        tc.headers,
 				Body:           strings.NewReader(tc.requestBody),
 				RouterHandlers: routerHandlers,
@@ -506,19 +737,22 @@ func TestGetPages(t *testing.T) {
 			require.Equal(t, tc.expectedResponseBody, respBody)
 			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
 			pageService.AssertNumberOfCalls(t, "GetPages", len(tc.getPagesCalls))
+			pageService.AssertNumberOfCalls(t, "GetPageFacets", len(tc.getPageFacetsCalls))
 		})
 	}
 }
 
 type removePageCall struct {
-	pageParams pageservice.RemovePageParams
-	returnErr  error
+	pageParams     pageservice.RemovePageParams
+	returnAffected []relation.AffectedPage
+	returnErr      error
 }
 
 func TestDeletePage(t *testing.T) {
 	cases := []struct {
 		name                 string
 		pageID               string
+		query                string
 		headers              map[string]string
 		requestBody          string
 		authN                api.AuthN
@@ -533,7 +767,7 @@ func TestDeletePage(t *testing.T) {
 			pageID:               "PG_1",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
@@ -544,7 +778,59 @@ func TestDeletePage(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"result\":[],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			removePageCalls: []removePageCall{
+				{
+					pageParams: pageservice.RemovePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnAffected: []relation.AffectedPage{},
+				},
+			},
+		},
+		{
+			name:   "preview the pages affected by re-pointing the relations",
+			pageID: "PG_1",
+			query:  "?preview=true&replacementId=PG_5",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"pageId\":\"PG_2\",\"pageTitle\":\"Vallaki\",\"relations\":2,\"repaired\":false}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			removePageCalls: []removePageCall{
+				{
+					pageParams: pageservice.RemovePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Preview: true,
+						ReplacementPage: page.Page{
+							GUID: "PG_5",
+						},
+						UserID: "UR_1",
+					},
+					returnAffected: []relation.AffectedPage{
+						{PageGUID: "PG_2", PageTitle: "Vallaki", Relations: 2},
+					},
+				},
+			},
+		},
+		{
+			name:   "unlink the relations",
+			pageID: "PG_1",
+			query:  "?unlink=true",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"pageId\":\"PG_2\",\"pageTitle\":\"Vallaki\",\"relations\":2,\"repaired\":true}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
 			removePageCalls: []removePageCall{
 				{
@@ -552,8 +838,62 @@ func TestDeletePage(t *testing.T) {
 						Page: page.Page{
 							GUID: "PG_1",
 						},
+						Unlink: true,
+						UserID: "UR_1",
+					},
+					returnAffected: []relation.AffectedPage{
+						{PageGUID: "PG_2", PageTitle: "Vallaki", Relations: 2, Repaired: true},
+					},
+				},
+			},
+		},
+		{
+			name:   "both unlink and re-point the relations",
+			pageID: "PG_1",
+			query:  "?unlink=true&replacementId=PG_5",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"cannot both unlink the relations and re-point them to a replacement page\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "invalid preview",
+			pageID: "PG_1",
+			query:  "?preview=maybe",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"preview must be true or false\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "replacement page does not exist",
+			pageID: "PG_1",
+			query:  "?replacementId=PG_5",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"replacement page PG_5 does not exist\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			removePageCalls: []removePageCall{
+				{
+					pageParams: pageservice.RemovePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						ReplacementPage: page.Page{
+							GUID: "PG_5",
+						},
 						UserID: "UR_1",
 					},
+					returnErr: &serviceerror.InvalidRequest{Message: "replacement page PG_5 does not exist"},
 				},
 			},
 		},
@@ -565,7 +905,7 @@ func TestDeletePage(t *testing.T) {
 			},
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 			removePageCalls: []removePageCall{
 				{
@@ -588,12 +928,12 @@ func TestDeletePage(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pageService := new(mocks.PageService)
 			for index := range tc.removePageCalls {
-				pageService.On("RemovePage", mock.Anything, tc.removePageCalls[index].pageParams).Return(tc.removePageCalls[index].returnErr)
+				pageService.On("RemovePage", mock.Anything, tc.removePageCalls[index].pageParams).Return(tc.removePageCalls[index].returnAffected, tc.removePageCalls[index].returnErr)
 			}
 			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
 			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
 				Method:         http.MethodDelete,
-				Endpoint:       fmt.Sprintf("pages/%v", tc.pageID),
+				Endpoint:       fmt.Sprintf("pages/%v%v", tc.pageID, tc.query),
 				Headers:        tc.headers,
 				Body:           strings.NewReader(tc.requestBody),
 				RouterHandlers: routerHandlers,
@@ -606,3 +946,1203 @@ func TestDeletePage(t *testing.T) {
 		})
 	}
 }
+
+type getPageBacklinksCall struct {
+	pageParams      pageservice.GetPageBacklinksParams
+	returnBacklinks []relation.Backlink
+	returnErr       error
+}
+
+func TestGetPageBacklinks(t *testing.T) {
+	cases := []struct {
+		name                  string
+		pageID                string
+		headers               map[string]string
+		authN                 api.AuthN
+		authZ                 api.AuthZ
+		expectedResponseBody  string
+		expectedStatusCode    int
+		getPageBacklinksCalls []getPageBacklinksCall
+	}{
+		{
+			name:   "happy path",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"pageId\":\"PG_2\",\"pageTitle\":\"Vallaki\",\"detailId\":\"DT_1\",\"detailTitle\":\"Roads\",\"text\":\"Barovia\",\"context\":\"The road east leads to Barovia.\"}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageBacklinksCalls: []getPageBacklinksCall{
+				{
+					pageParams: pageservice.GetPageBacklinksParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnBacklinks: []relation.Backlink{
+						{PageGUID: "PG_2", PageTitle: "Vallaki", DetailGUID: "DT_1", DetailTitle: "Roads", Text: "Barovia", Context: "The road east leads to Barovia."},
+					},
+				},
+			},
+		},
+		{
+			name:   "trying to get the backlinks of a page that you don't have permission to read",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			getPageBacklinksCalls: []getPageBacklinksCall{
+				{
+					pageParams: pageservice.GetPageBacklinksParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{
+						UserID:  "UR_1",
+						TableID: "PG_1",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.getPageBacklinksCalls {
+				pageService.On("GetPageBacklinks", mock.Anything, tc.getPageBacklinksCalls[index].pageParams).Return(tc.getPageBacklinksCalls[index].returnBacklinks, tc.getPageBacklinksCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("pages/%v/backlinks", tc.pageID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "GetPageBacklinks", len(tc.getPageBacklinksCalls))
+		})
+	}
+}
+
+type getPageGraphCall struct {
+	pageParams  pageservice.GetPageGraphParams
+	returnGraph relation.Graph
+	returnErr   error
+}
+
+func TestGetPageGraph(t *testing.T) {
+	cases := []struct {
+		name                 string
+		pageID               string
+		query                string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		getPageGraphCalls    []getPageGraphCall
+	}{
+		{
+			name:   "happy path",
+			pageID: "PG_1",
+			query:  "?depth=2&pageTemplateId=PT_1&versionId=VR_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"nodes\":[{\"id\":\"PG_1\",\"title\":\"Barovia\",\"pageTemplateId\":\"PT_1\",\"versionId\":\"VR_1\",\"depth\":0},{\"id\":\"PG_3\",\"title\":\"Krezk\",\"pageTemplateId\":\"PT_1\",\"versionId\":\"VR_1\",\"depth\":1}],\"edges\":[{\"source\":\"PG_3\",\"target\":\"PG_1\",\"count\":2}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageGraphCalls: []getPageGraphCall{
+				{
+					pageParams: pageservice.GetPageGraphParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Depth:            2,
+						PageTemplateGUID: "PT_1",
+						VersionGUID:      "VR_1",
+						UserID:           "UR_1",
+					},
+					returnGraph: relation.Graph{
+						Nodes: []relation.Node{
+							{PageGUID: "PG_1", Title: "Barovia", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 0},
+							{PageGUID: "PG_3", Title: "Krezk", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 1},
+						},
+						Edges: []relation.Edge{
+							{SourcePageGUID: "PG_3", TargetPageGUID: "PG_1", Count: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "default depth",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"nodes\":[{\"id\":\"PG_1\",\"title\":\"Barovia\",\"pageTemplateId\":\"PT_1\",\"versionId\":\"VR_1\",\"depth\":0}],\"edges\":[]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageGraphCalls: []getPageGraphCall{
+				{
+					pageParams: pageservice.GetPageGraphParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Depth:  1,
+						UserID: "UR_1",
+					},
+					returnGraph: relation.Graph{
+						Nodes: []relation.Node{
+							{PageGUID: "PG_1", Title: "Barovia", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 0},
+						},
+						Edges: []relation.Edge{},
+					},
+				},
+			},
+		},
+		{
+			name:   "depth too large",
+			pageID: "PG_1",
+			query:  "?depth=6",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"depth must be a number between 1 and 5\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "depth not a number",
+			pageID: "PG_1",
+			query:  "?depth=all",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"depth must be a number between 1 and 5\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "trying to get the graph of a page that you don't have permission to read",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			getPageGraphCalls: []getPageGraphCall{
+				{
+					pageParams: pageservice.GetPageGraphParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Depth:  1,
+						UserID: "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{
+						UserID:  "UR_1",
+						TableID: "PG_1",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.getPageGraphCalls {
+				pageService.On("GetPageGraph", mock.Anything, tc.getPageGraphCalls[index].pageParams).Return(tc.getPageGraphCalls[index].returnGraph, tc.getPageGraphCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("pages/%v/graph%v", tc.pageID, tc.query),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "GetPageGraph", len(tc.getPageGraphCalls))
+		})
+	}
+}
+
+type getDanglingRelationsCall struct {
+	params      pageservice.GetDanglingRelationsParams
+	returnLinks []relation.Link
+	returnErr   error
+}
+
+func TestGetDanglingRelations(t *testing.T) {
+	cases := []struct {
+		name                      string
+		headers                   map[string]string
+		authN                     api.AuthN
+		authZ                     api.AuthZ
+		expectedResponseBody      string
+		expectedStatusCode        int
+		getDanglingRelationsCalls []getDanglingRelationsCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"pageId\":\"PG_1\",\"pageTitle\":\"Barovia\",\"detailId\":\"DT_2\",\"detailTitle\":\"Ruins\",\"targetPageId\":\"PG_3\",\"text\":\"Berez\",\"context\":\"The road leads to Berez\",\"status\":\"deleted\"}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getDanglingRelationsCalls: []getDanglingRelationsCall{
+				{
+					params: pageservice.GetDanglingRelationsParams{
+						UserID: "UR_1",
+					},
+					returnLinks: []relation.Link{
+						{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_2", DetailTitle: "Ruins", TargetPageGUID: "PG_3", Text: "Berez", Context: "The road leads to Berez", Status: relation.StatusDeleted},
+					},
+				},
+			},
+		},
+		{
+			name: "service error",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"500 - Internal Server Error\",\"message\":\"internal server error\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   500,
+			getDanglingRelationsCalls: []getDanglingRelationsCall{
+				{
+					params: pageservice.GetDanglingRelationsParams{
+						UserID: "UR_1",
+					},
+					returnErr: errors.New("failure"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.getDanglingRelationsCalls {
+				pageService.On("GetDanglingRelations", mock.Anything, tc.getDanglingRelationsCalls[index].params).Return(tc.getDanglingRelationsCalls[index].returnLinks, tc.getDanglingRelationsCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "relations/dangling",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "GetDanglingRelations", len(tc.getDanglingRelationsCalls))
+		})
+	}
+}
+
+type searchPagesCall struct {
+	params        pageservice.SearchPagesParams
+	returnResults []search.Result
+	returnErr     error
+}
+
+func TestSearchPages(t *testing.T) {
+	cases := []struct {
+		name                 string
+		query                string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		searchPagesCalls     []searchPagesCall
+	}{
+		{
+			name:  "happy path",
+			query: "?q=castle+strahd&limit=5",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"id\":\"PG_1\",\"title\":\"Castle Ravenloft\",\"score\":2.5,\"snippets\":[{\"field\":\"detail\",\"detailId\":\"PD_1\",\"text\":\"Strahd rules from the castle\",\"highlights\":[{\"start\":0,\"end\":6},{\"start\":22,\"end\":28}]}]}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			searchPagesCalls: []searchPagesCall{
+				{
+					params: pageservice.SearchPagesParams{
+						Query:  "castle strahd",
+						Limit:  5,
+						UserID: "UR_1",
+					},
+					returnResults: []search.Result{
+						{
+							PageGUID: "PG_1",
+							Title:    "Castle Ravenloft",
+							Score:    2.5,
+							Snippets: []search.Snippet{
+								{
+									Field:      search.FieldDetail,
+									DetailGUID: "PD_1",
+									Text:       "Strahd rules from the castle",
+									Highlights: []search.Highlight{{Start: 0, End: 6}, {Start: 22, End: 28}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "default limit",
+			query: "?q=castle",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			searchPagesCalls: []searchPagesCall{
+				{
+					params: pageservice.SearchPagesParams{
+						Query:  "castle",
+						Limit:  10,
+						UserID: "UR_1",
+					},
+					returnResults: []search.Result{},
+				},
+			},
+		},
+		{
+			name:  "missing query",
+			query: "?q=%20",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide a search query\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:  "limit too large",
+			query: "?q=castle&limit=51",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"limit must be a number between 1 and 50\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:  "service error",
+			query: "?q=castle",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"500 - Internal Server Error\",\"message\":\"internal server error\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   500,
+			searchPagesCalls: []searchPagesCall{
+				{
+					params: pageservice.SearchPagesParams{
+						Query:  "castle",
+						Limit:  10,
+						UserID: "UR_1",
+					},
+					returnErr: errors.New("failure"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.searchPagesCalls {
+				pageService.On("SearchPages", mock.Anything, tc.searchPagesCalls[index].params).Return(tc.searchPagesCalls[index].returnResults, tc.searchPagesCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("search/pages%v", tc.query),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "SearchPages", len(tc.searchPagesCalls))
+		})
+	}
+}
+
+type exportPageMarkdownCall struct {
+	pageParams     pageservice.ExportPageMarkdownParams
+	returnMarkdown string
+	returnErr      error
+}
+
+func TestExportPage(t *testing.T) {
+	cases := []struct {
+		name                    string
+		pageID                  string
+		query                   string
+		headers                 map[string]string
+		authN                   api.AuthN
+		authZ                   api.AuthZ
+		expectedResponseBody    string
+		expectedStatusCode      int
+		expectedContentType     string
+		exportPageMarkdownCalls []exportPageMarkdownCall
+	}{
+		{
+			name:   "happy path",
+			pageID: "PG_1",
+			query:  "?format=markdown",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "---\nid: PG_1\ntitle: \"Barovia\"\n---\n",
+			expectedStatusCode:   200,
+			expectedContentType:  "text/markdown; charset=utf-8",
+			exportPageMarkdownCalls: []exportPageMarkdownCall{
+				{
+					pageParams: pageservice.ExportPageMarkdownParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnMarkdown: "---\nid: PG_1\ntitle: \"Barovia\"\n---\n",
+				},
+			},
+		},
+		{
+			name:   "unsupported format",
+			pageID: "PG_1",
+			query:  "?format=pdf",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"format must be markdown\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			expectedContentType:  "application/json",
+		},
+		{
+			name:   "trying to export a page that you don't have permission to read",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			expectedContentType:  "application/json",
+			exportPageMarkdownCalls: []exportPageMarkdownCall{
+				{
+					pageParams: pageservice.ExportPageMarkdownParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{
+						UserID:  "UR_1",
+						TableID: "PG_1",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.exportPageMarkdownCalls {
+				pageService.On("ExportPageMarkdown", mock.Anything, tc.exportPageMarkdownCalls[index].pageParams).Return(tc.exportPageMarkdownCalls[index].returnMarkdown, tc.exportPageMarkdownCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("pages/%v/export%v", tc.pageID, tc.query),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			require.Equal(t, tc.expectedContentType, resp.Header.Get("Content-Type"))
+			pageService.AssertNumberOfCalls(t, "ExportPageMarkdown", len(tc.exportPageMarkdownCalls))
+		})
+	}
+}
+
+type getPageRevisionsCall struct {
+	pageParams      pageservice.GetPageRevisionsParams
+	returnRevisions []revision.Revision
+	returnErr       error
+}
+
+func TestGetPageRevisions(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                  string
+		pageID                string
+		headers               map[string]string
+		authN                 api.AuthN
+		authZ                 api.AuthZ
+		expectedResponseBody  string
+		expectedStatusCode    int
+		getPageRevisionsCalls []getPageRevisionsCall
+	}{
+		{
+			name:   "happy path",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"id\":\"RV_2\",\"pageId\":\"PG_1\",\"authorId\":\"UR_1\",\"createdAt\":\"2020-01-01T00:00:00Z\",\"changes\":[{\"target\":\"title\",\"before\":\"Barovia\",\"after\":\"Village of Barovia\"}]},{\"id\":\"RV_1\",\"pageId\":\"PG_1\",\"authorId\":\"\",\"createdAt\":\"2020-01-01T00:00:00Z\",\"changes\":[]}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageRevisionsCalls: []getPageRevisionsCall{
+				{
+					pageParams: pageservice.GetPageRevisionsParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnRevisions: []revision.Revision{
+						{
+							GUID:       "RV_2",
+							PageGUID:   "PG_1",
+							AuthorGUID: "UR_1",
+							CreatedAt:  &createdAt,
+							Changes:    []revision.Change{{Target: pagediff.TargetTitle, Before: "Barovia", After: "Village of Barovia"}},
+						},
+						{GUID: "RV_1", PageGUID: "PG_1", CreatedAt: &createdAt},
+					},
+				},
+			},
+		},
+		{
+			name:   "trying to get the revisions of a page that you don't have permission to read",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			getPageRevisionsCalls: []getPageRevisionsCall{
+				{
+					pageParams: pageservice.GetPageRevisionsParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{
+						UserID:  "UR_1",
+						TableID: "PG_1",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.getPageRevisionsCalls {
+				pageService.On("GetPageRevisions", mock.Anything, tc.getPageRevisionsCalls[index].pageParams).Return(tc.getPageRevisionsCalls[index].returnRevisions, tc.getPageRevisionsCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("pages/%v/revisions", tc.pageID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "GetPageRevisions", len(tc.getPageRevisionsCalls))
+		})
+	}
+}
+
+type getPageRevisionCall struct {
+	pageParams     pageservice.GetPageRevisionParams
+	returnRevision revision.Revision
+	returnErr      error
+}
+
+func TestGetPageRevision(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                 string
+		pageID               string
+		revisionID           string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		getPageRevisionCalls []getPageRevisionCall
+	}{
+		{
+			name:       "happy path",
+			pageID:     "PG_1",
+			revisionID: "RV_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"RV_1\",\"pageId\":\"PG_1\",\"authorId\":\"\",\"createdAt\":\"2020-01-01T00:00:00Z\",\"changes\":[],\"snapshot\":{\"title\":\"Barovia\",\"summary\":\"\",\"properties\":[],\"details\":[{\"id\":\"DT_1\",\"title\":\"History\",\"summary\":\"\",\"partitions\":[]}]}},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageRevisionCalls: []getPageRevisionCall{
+				{
+					pageParams: pageservice.GetPageRevisionParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Revision: revision.Revision{
+							GUID: "RV_1",
+						},
+						UserID: "UR_1",
+					},
+					returnRevision: revision.Revision{
+						GUID:      "RV_1",
+						PageGUID:  "PG_1",
+						CreatedAt: &createdAt,
+						Snapshot: &pagediff.Snapshot{
+							Title:   "Barovia",
+							Details: []pagedetail.PageDetail{{GUID: "DT_1", Title: "History"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "revision does not exist",
+			pageID:     "PG_1",
+			revisionID: "RV_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: RV_1\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			getPageRevisionCalls: []getPageRevisionCall{
+				{
+					pageParams: pageservice.GetPageRevisionParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Revision: revision.Revision{
+							GUID: "RV_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: errors.Wrap(&storeerror.NotFound{ID: "RV_1"}, "failed to get page revision"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.getPageRevisionCalls {
+				pageService.On("GetPageRevision", mock.Anything, tc.getPageRevisionCalls[index].pageParams).Return(tc.getPageRevisionCalls[index].returnRevision, tc.getPageRevisionCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("pages/%v/revisions/%v", tc.pageID, tc.revisionID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "GetPageRevision", len(tc.getPageRevisionCalls))
+		})
+	}
+}
+
+type restorePageRevisionCall struct {
+	pageParams pageservice.RestorePageRevisionParams
+	returnErr  error
+}
+
+func TestRestorePageRevision(t *testing.T) {
+	cases := []struct {
+		name                     string
+		pageID                   string
+		revisionID               string
+		headers                  map[string]string
+		authN                    api.AuthN
+		authZ                    api.AuthZ
+		expectedResponseBody     string
+		expectedStatusCode       int
+		restorePageRevisionCalls []restorePageRevisionCall
+	}{
+		{
+			name:       "happy path",
+			pageID:     "PG_1",
+			revisionID: "RV_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			restorePageRevisionCalls: []restorePageRevisionCall{
+				{
+					pageParams: pageservice.RestorePageRevisionParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Revision: revision.Revision{
+							GUID: "RV_1",
+						},
+						UserID: "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name:       "trying to restore a revision of a page that you don't have permission to edit",
+			pageID:     "PG_1",
+			revisionID: "RV_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			restorePageRevisionCalls: []restorePageRevisionCall{
+				{
+					pageParams: pageservice.RestorePageRevisionParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Revision: revision.Revision{
+							GUID: "RV_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{
+						UserID:  "UR_1",
+						TableID: "PG_1",
+					},
+				},
+			},
+		},
+		{
+			name:       "restoring a property that is no longer registered",
+			pageID:     "PG_1",
+			revisionID: "RV_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"property ruler is not registered or is disabled\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			restorePageRevisionCalls: []restorePageRevisionCall{
+				{
+					pageParams: pageservice.RestorePageRevisionParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						Revision: revision.Revision{
+							GUID: "RV_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "property ruler is not registered or is disabled"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.restorePageRevisionCalls {
+				pageService.On("RestorePageRevision", mock.Anything, tc.restorePageRevisionCalls[index].pageParams).Return(tc.restorePageRevisionCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       fmt.Sprintf("pages/%v/revisions/%v/restore", tc.pageID, tc.revisionID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "RestorePageRevision", len(tc.restorePageRevisionCalls))
+		})
+	}
+}
+
+type getRemovedPagesCall struct {
+	params      pageservice.GetRemovedPagesParams
+	returnPages []page.Page
+	returnErr   error
+}
+
+func TestGetRemovedPages(t *testing.T) {
+	deletedAt := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		getRemovedPagesCalls []getRemovedPagesCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"batch\":[{\"versionId\":\"VR_1\",\"pageTemplateId\":\"PGT_1\",\"id\":\"PG_1\",\"title\":\"Berez\",\"summary\":\"\",\"permission\":\"PR\",\"createdAt\":null,\"updatedAt\":null,\"deletedAt\":\"2020-01-02T00:00:00Z\"}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getRemovedPagesCalls: []getRemovedPagesCall{
+				{
+					params: pageservice.GetRemovedPagesParams{
+						UserID: "UR_1",
+					},
+					returnPages: []page.Page{
+						{
+							GUID:           "PG_1",
+							Title:          "Berez",
+							PermissionType: permission.TypePrivate,
+							Version:        version.Version{GUID: "VR_1"},
+							PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+							DeletedAt:      &deletedAt,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "empty trash",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"batch\":[]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getRemovedPagesCalls: []getRemovedPagesCall{
+				{
+					params: pageservice.GetRemovedPagesParams{
+						UserID: "UR_1",
+					},
+					returnPages: []page.Page{},
+				},
+			},
+		},
+		{
+			name: "service error",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"500 - Internal Server Error\",\"message\":\"internal server error\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   500,
+			getRemovedPagesCalls: []getRemovedPagesCall{
+				{
+					params: pageservice.GetRemovedPagesParams{
+						UserID: "UR_1",
+					},
+					returnErr: errors.New("failure"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.getRemovedPagesCalls {
+				pageService.On("GetRemovedPages", mock.Anything, tc.getRemovedPagesCalls[index].params).Return(tc.getRemovedPagesCalls[index].returnPages, tc.getRemovedPagesCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "trash",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "GetRemovedPages", len(tc.getRemovedPagesCalls))
+		})
+	}
+}
+
+type restorePageCall struct {
+	pageParams pageservice.RestorePageParams
+	returnErr  error
+}
+
+func TestRestorePage(t *testing.T) {
+	cases := []struct {
+		name                 string
+		pageID               string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		restorePageCalls     []restorePageCall
+	}{
+		{
+			name:   "happy path",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			restorePageCalls: []restorePageCall{
+				{
+					pageParams: pageservice.RestorePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name:   "trying to restore a page that you don't have permission to edit",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			restorePageCalls: []restorePageCall{
+				{
+					pageParams: pageservice.RestorePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{
+						UserID:  "UR_1",
+						TableID: "PG_1",
+					},
+				},
+			},
+		},
+		{
+			name:   "trying to restore a page that is not in the trash",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: PG_1\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			restorePageCalls: []restorePageCall{
+				{
+					pageParams: pageservice.RestorePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: errors.Wrap(&storeerror.NotFound{ID: "PG_1"}, "failed to restore page"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.restorePageCalls {
+				pageService.On("RestorePage", mock.Anything, tc.restorePageCalls[index].pageParams).Return(tc.restorePageCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       fmt.Sprintf("pages/%v/restore", tc.pageID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "RestorePage", len(tc.restorePageCalls))
+		})
+	}
+}
+
+type purgePageCall struct {
+	pageParams pageservice.PurgePageParams
+	returnErr  error
+}
+
+func TestPurgePage(t *testing.T) {
+	cases := []struct {
+		name                 string
+		pageID               string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		purgePageCalls       []purgePageCall
+	}{
+		{
+			name:   "happy path",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			purgePageCalls: []purgePageCall{
+				{
+					pageParams: pageservice.PurgePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name:   "trying to purge a page that you don't own",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			purgePageCalls: []purgePageCall{
+				{
+					pageParams: pageservice.PurgePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{
+						UserID:  "UR_1",
+						TableID: "PG_1",
+					},
+				},
+			},
+		},
+		{
+			name:   "trying to purge a page that is not in the trash",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: PG_1\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			purgePageCalls: []purgePageCall{
+				{
+					pageParams: pageservice.PurgePageParams{
+						Page: page.Page{
+							GUID: "PG_1",
+						},
+						UserID: "UR_1",
+					},
+					returnErr: errors.Wrap(&storeerror.NotFound{ID: "PG_1"}, "failed to purge page"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageService := new(mocks.PageService)
+			for index := range tc.purgePageCalls {
+				pageService.On("PurgePage", mock.Anything, tc.purgePageCalls[index].pageParams).Return(tc.purgePageCalls[index].returnErr)
+			}
+			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodDelete,
+				Endpoint:       fmt.Sprintf("trash/%v", tc.pageID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageService.AssertNumberOfCalls(t, "PurgePage", len(tc.purgePageCalls))
+		})
+	}
+}
@@ -5,8 +5,12 @@ package mocks
 import context "context"
 import mock "github.com/stretchr/testify/mock"
 import page "github.com/Pergamene/project-spiderweb-service/internal/models/page"
-
+import pagefilter "github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
 import pageservice "github.com/Pergamene/project-spiderweb-service/internal/services/page"
+import property "github.com/Pergamene/project-spiderweb-service/internal/models/property"
+import relation "github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+import revision "github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+import search "github.com/Pergamene/project-spiderweb-service/internal/models/search"
 
 // PageService is an autogenerated mock type for the PageService type
 type PageService struct {
@@ -34,6 +38,50 @@ func (_m *PageService) CreatePage(ctx context.Context, params pageservice.Create
 	return r0, r1
 }
 
+// ExportPageMarkdown provides a mock function with given fields: ctx, params
+func (_m *PageService) ExportPageMarkdown(ctx context.Context, params pageservice.ExportPageMarkdownParams) (string, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.ExportPageMarkdownParams) string); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.ExportPageMarkdownParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDanglingRelations provides a mock function with given fields: ctx, params
+func (_m *PageService) GetDanglingRelations(ctx context.Context, params pageservice.GetDanglingRelationsParams) ([]relation.Link, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []relation.Link
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetDanglingRelationsParams) []relation.Link); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]relation.Link)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetDanglingRelationsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEntirePage provides a mock function with given fields: ctx, params
 func (_m *PageService) GetEntirePage(ctx context.Context, params pageservice.GetEntirePageParams) (page.Page, error) {
 	ret := _m.Called(ctx, params)
@@ -76,6 +124,138 @@ func (_m *PageService) GetPage(ctx context.Context, params pageservice.GetPagePa
 	return r0, r1
 }
 
+// GetPageBacklinks provides a mock function with given fields: ctx, params
+func (_m *PageService) GetPageBacklinks(ctx context.Context, params pageservice.GetPageBacklinksParams) ([]relation.Backlink, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []relation.Backlink
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetPageBacklinksParams) []relation.Backlink); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]relation.Backlink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetPageBacklinksParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageFacets provides a mock function with given fields: ctx, params
+func (_m *PageService) GetPageFacets(ctx context.Context, params pageservice.GetPageFacetsParams) (pagefilter.Facets, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 pagefilter.Facets
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetPageFacetsParams) pagefilter.Facets); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(pagefilter.Facets)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetPageFacetsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageGraph provides a mock function with given fields: ctx, params
+func (_m *PageService) GetPageGraph(ctx context.Context, params pageservice.GetPageGraphParams) (relation.Graph, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 relation.Graph
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetPageGraphParams) relation.Graph); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(relation.Graph)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetPageGraphParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageProperties provides a mock function with given fields: ctx, params
+func (_m *PageService) GetPageProperties(ctx context.Context, params pageservice.GetPagePropertiesParams) ([]property.Property, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []property.Property
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetPagePropertiesParams) []property.Property); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]property.Property)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetPagePropertiesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageRevision provides a mock function with given fields: ctx, params
+func (_m *PageService) GetPageRevision(ctx context.Context, params pageservice.GetPageRevisionParams) (revision.Revision, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 revision.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetPageRevisionParams) revision.Revision); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(revision.Revision)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetPageRevisionParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageRevisions provides a mock function with given fields: ctx, params
+func (_m *PageService) GetPageRevisions(ctx context.Context, params pageservice.GetPageRevisionsParams) ([]revision.Revision, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []revision.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetPageRevisionsParams) []revision.Revision); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]revision.Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetPageRevisionsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPages provides a mock function with given fields: ctx, params
 func (_m *PageService) GetPages(ctx context.Context, params pageservice.GetPagesParams) ([]page.Page, int, string, error) {
 	ret := _m.Called(ctx, params)
@@ -113,12 +293,72 @@ func (_m *PageService) GetPages(ctx context.Context, params pageservice.GetPages
 	return r0, r1, r2, r3
 }
 
+// GetRemovedPages provides a mock function with given fields: ctx, params
+func (_m *PageService) GetRemovedPages(ctx context.Context, params pageservice.GetRemovedPagesParams) ([]page.Page, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []page.Page
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.GetRemovedPagesParams) []page.Page); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]page.Page)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.GetRemovedPagesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgePage provides a mock function with given fields: ctx, params
+func (_m *PageService) PurgePage(ctx context.Context, params pageservice.PurgePageParams) error {
+	ret := _m.Called(ctx, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.PurgePageParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemovePage provides a mock function with given fields: ctx, params
-func (_m *PageService) RemovePage(ctx context.Context, params pageservice.RemovePageParams) error {
+func (_m *PageService) RemovePage(ctx context.Context, params pageservice.RemovePageParams) ([]relation.AffectedPage, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []relation.AffectedPage
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.RemovePageParams) []relation.AffectedPage); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]relation.AffectedPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.RemovePageParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplacePageProperties provides a mock function with given fields: ctx, params
+func (_m *PageService) ReplacePageProperties(ctx context.Context, params pageservice.ReplacePagePropertiesParams) error {
 	ret := _m.Called(ctx, params)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, pageservice.RemovePageParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.ReplacePagePropertiesParams) error); ok {
 		r0 = rf(ctx, params)
 	} else {
 		r0 = ret.Error(0)
@@ -127,6 +367,57 @@ func (_m *PageService) RemovePage(ctx context.Context, params pageservice.Remove
 	return r0
 }
 
+// RestorePage provides a mock function with given fields: ctx, params
+func (_m *PageService) RestorePage(ctx context.Context, params pageservice.RestorePageParams) error {
+	ret := _m.Called(ctx, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.RestorePageParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestorePageRevision provides a mock function with given fields: ctx, params
+func (_m *PageService) RestorePageRevision(ctx context.Context, params pageservice.RestorePageRevisionParams) error {
+	ret := _m.Called(ctx, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.RestorePageRevisionParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchPages provides a mock function with given fields: ctx, params
+func (_m *PageService) SearchPages(ctx context.Context, params pageservice.SearchPagesParams) ([]search.Result, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []search.Result
+	if rf, ok := ret.Get(0).(func(context.Context, pageservice.SearchPagesParams) []search.Result); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]search.Result)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pageservice.SearchPagesParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePage provides a mock function with given fields: ctx, params
 func (_m *PageService) UpdatePage(ctx context.Context, params pageservice.UpdatePageParams) error {
 	ret := _m.Called(ctx, params)
@@ -2,9 +2,15 @@ package pagehandler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 )
@@ -117,13 +123,31 @@ func (request GetPageRequest) validate() (GetPageRequest, error) {
 
 // DeletePageRequest parameters from the DeletePage call
 type DeletePageRequest struct {
-	GUID string
+	GUID          string
+	Preview       bool
+	Unlink        bool
+	ReplacementID string
 }
 
 // NewDeletePageRequest extracts the DeletePageRequest
 func NewDeletePageRequest(r *http.Request, p httprouter.Params) (DeletePageRequest, error) {
 	var request DeletePageRequest
 	request.GUID = p.ByName(PageIDRouteKey)
+	query := r.URL.Query()
+	var err error
+	if preview := query.Get("preview"); preview != "" {
+		request.Preview, err = strconv.ParseBool(preview)
+		if err != nil {
+			return request, errors.New("preview must be true or false")
+		}
+	}
+	if unlink := query.Get("unlink"); unlink != "" {
+		request.Unlink, err = strconv.ParseBool(unlink)
+		if err != nil {
+			return request, errors.New("unlink must be true or false")
+		}
+	}
+	request.ReplacementID = query.Get("replacementId")
 	return request.validate()
 }
 
@@ -131,21 +155,347 @@ func (request DeletePageRequest) validate() (DeletePageRequest, error) {
 	if request.GUID == "" {
 		return request, errors.New("must provide a page id")
 	}
+	if request.Unlink && request.ReplacementID != "" {
+		return request, errors.New("cannot both unlink the relations and re-point them to a replacement page")
+	}
 	return request, nil
 }
 
+// The bounds of the number of pages in a batch
+const (
+	defaultPagesLimit = 10
+	maxPagesLimit     = 100
+)
+
 // GetPagesRequest parameters from the GetPages call
 type GetPagesRequest struct {
-	NextBatchID string
+	NextBatchID          string
+	Limit                int
+	SortString           string
+	OrderString          string
+	Sort                 pagesort.Sort
+	PermissionTypeString string
+	PropertyFilters      []string
+	Filter               pagefilter.Filter
 }
 
 // NewGetPagesRequest extracts the GetPagesRequest
 func NewGetPagesRequest(r *http.Request, p httprouter.Params) (GetPagesRequest, error) {
 	var request GetPagesRequest
-	request.NextBatchID = r.URL.Query().Get("nextBatchId")
+	query := r.URL.Query()
+	request.NextBatchID = query.Get("nextBatchId")
+	request.Limit = defaultPagesLimit
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		request.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return request, fmt.Errorf("limit must be a number between 1 and %v", maxPagesLimit)
+		}
+	}
+	request.SortString = query.Get("sort")
+	request.OrderString = query.Get("order")
+	request.Filter.PageTemplateGUID = query.Get("pageTemplateId")
+	request.Filter.VersionGUID = query.Get("versionId")
+	request.PermissionTypeString = query.Get("permission")
+	request.PropertyFilters = query["property"]
 	return request.validate()
 }
 
 func (request GetPagesRequest) validate() (GetPagesRequest, error) {
+	if request.Limit < 1 || request.Limit > maxPagesLimit {
+		return request, fmt.Errorf("limit must be a number between 1 and %v", maxPagesLimit)
+	}
+	request.Sort = pagesort.DefaultSort
+	if request.SortString != "" {
+		field, err := pagesort.GetField(request.SortString)
+		if err != nil {
+			return request, errors.New("sort must be title, createdAt or updatedAt")
+		}
+		request.Sort.Field = field
+	}
+	if request.OrderString != "" {
+		order, err := pagesort.GetOrder(request.OrderString)
+		if err != nil {
+			return request, errors.New("order must be asc or desc")
+		}
+		request.Sort.Order = order
+	}
+	if request.PermissionTypeString != "" {
+		permissionType, err := permission.GetPermissionType(request.PermissionTypeString)
+		if err != nil {
+			return request, errors.New("permission is not a valid value")
+		}
+		request.Filter.PermissionType = permissionType
+	}
+	for _, propertyFilter := range request.PropertyFilters {
+		condition, err := pagefilter.ParseCondition(propertyFilter)
+		if err != nil {
+			return request, err
+		}
+		request.Filter.Conditions = append(request.Filter.Conditions, condition)
+	}
+	return request, nil
+}
+
+// GetPagePropertiesRequest parameters from the GetPageProperties call
+type GetPagePropertiesRequest struct {
+	GUID string
+}
+
+// NewGetPagePropertiesRequest extracts the GetPagePropertiesRequest
+func NewGetPagePropertiesRequest(r *http.Request, p httprouter.Params) (GetPagePropertiesRequest, error) {
+	request, err := NewGetPageRequest(r, p)
+	return GetPagePropertiesRequest{
+		GUID: request.GUID,
+	}, err
+}
+
+// GetPageBacklinksRequest parameters from the GetPageBacklinks call
+type GetPageBacklinksRequest struct {
+	GUID string
+}
+
+// NewGetPageBacklinksRequest extracts the GetPageBacklinksRequest
+func NewGetPageBacklinksRequest(r *http.Request, p httprouter.Params) (GetPageBacklinksRequest, error) {
+	request, err := NewGetPageRequest(r, p)
+	return GetPageBacklinksRequest{
+		GUID: request.GUID,
+	}, err
+}
+
+// The bounds of the depth of a page graph
+const (
+	defaultPageGraphDepth = 1
+	maxPageGraphDepth     = 5
+)
+
+// GetPageGraphRequest parameters from the GetPageGraph call
+type GetPageGraphRequest struct {
+	GUID           string
+	Depth          int
+	PageTemplateID string
+	VersionID      string
+}
+
+// NewGetPageGraphRequest extracts the GetPageGraphRequest
+func NewGetPageGraphRequest(r *http.Request, p httprouter.Params) (GetPageGraphRequest, error) {
+	var request GetPageGraphRequest
+	request.GUID = p.ByName(PageIDRouteKey)
+	query := r.URL.Query()
+	request.Depth = defaultPageGraphDepth
+	if depth := query.Get("depth"); depth != "" {
+		var err error
+		request.Depth, err = strconv.Atoi(depth)
+		if err != nil {
+			return request, fmt.Errorf("depth must be a number between 1 and %v", maxPageGraphDepth)
+		}
+	}
+	request.PageTemplateID = query.Get("pageTemplateId")
+	request.VersionID = query.Get("versionId")
+	return request.validate()
+}
+
+func (request GetPageGraphRequest) validate() (GetPageGraphRequest, error) {
+	if request.GUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.Depth < 1 || request.Depth > maxPageGraphDepth {
+		return request, fmt.Errorf("depth must be a number between 1 and %v", maxPageGraphDepth)
+	}
+	return request, nil
+}
+
+// The formats a page can be exported as
+const (
+	exportFormatMarkdown = "markdown"
+)
+
+// ExportPageRequest parameters from the ExportPage call
+type ExportPageRequest struct {
+	GUID   string
+	Format string
+}
+
+// NewExportPageRequest extracts the ExportPageRequest
+func NewExportPageRequest(r *http.Request, p httprouter.Params) (ExportPageRequest, error) {
+	var request ExportPageRequest
+	request.GUID = p.ByName(PageIDRouteKey)
+	request.Format = r.URL.Query().Get("format")
+	if request.Format == "" {
+		request.Format = exportFormatMarkdown
+	}
+	return request.validate()
+}
+
+func (request ExportPageRequest) validate() (ExportPageRequest, error) {
+	if request.GUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.Format != exportFormatMarkdown {
+		return request, errors.New("format must be markdown")
+	}
 	return request, nil
 }
+
+// The bounds of the number of search results
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+// SearchPagesRequest parameters from the SearchPages call
+type SearchPagesRequest struct {
+	Query string
+	Limit int
+}
+
+// NewSearchPagesRequest extracts the SearchPagesRequest
+func NewSearchPagesRequest(r *http.Request, p httprouter.Params) (SearchPagesRequest, error) {
+	var request SearchPagesRequest
+	query := r.URL.Query()
+	request.Query = query.Get("q")
+	request.Limit = defaultSearchLimit
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		request.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return request, fmt.Errorf("limit must be a number between 1 and %v", maxSearchLimit)
+		}
+	}
+	return request.validate()
+}
+
+func (request SearchPagesRequest) validate() (SearchPagesRequest, error) {
+	if strings.TrimSpace(request.Query) == "" {
+		return request, errors.New("must provide a search query")
+	}
+	if request.Limit < 1 || request.Limit > maxSearchLimit {
+		return request, fmt.Errorf("limit must be a number between 1 and %v", maxSearchLimit)
+	}
+	return request, nil
+}
+
+// ReplacePagePropertiesRequest parameters from the ReplacePageProperties call
+type ReplacePagePropertiesRequest struct {
+	GUID       string
+	Properties []property.Property
+}
+
+// NewReplacePagePropertiesRequest extracts the ReplacePagePropertiesRequest
+func NewReplacePagePropertiesRequest(r *http.Request, p httprouter.Params) (ReplacePagePropertiesRequest, error) {
+	var request ReplacePagePropertiesRequest
+	err := json.NewDecoder(r.Body).Decode(&request.Properties)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.GUID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request ReplacePagePropertiesRequest) validate() (ReplacePagePropertiesRequest, error) {
+	if request.GUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.Properties == nil {
+		request.Properties = []property.Property{}
+	}
+	for i, p := range request.Properties {
+		if p.Key == "" {
+			return request, errors.Errorf("property at %v must provide key", i)
+		}
+		propertyType, err := property.GetPropertyType(string(p.Type))
+		if err != nil {
+			return request, errors.Errorf("property %v does not have a valid type", p.Key)
+		}
+		switch propertyType {
+		case property.TypeNumber:
+			if _, ok := p.Value.(float64); !ok {
+				return request, errors.Errorf("property %v must have a number value", p.Key)
+			}
+		case property.TypeString:
+			if _, ok := p.Value.(string); !ok {
+				return request, errors.Errorf("property %v must have a string value", p.Key)
+			}
+		}
+		request.Properties[i].Type = propertyType
+	}
+	return request, nil
+}
+
+// GetPageRevisionsRequest parameters from the GetPageRevisions call
+type GetPageRevisionsRequest struct {
+	GUID string
+}
+
+// NewGetPageRevisionsRequest extracts the GetPageRevisionsRequest
+func NewGetPageRevisionsRequest(r *http.Request, p httprouter.Params) (GetPageRevisionsRequest, error) {
+	request, err := NewGetPageRequest(r, p)
+	return GetPageRevisionsRequest{
+		GUID: request.GUID,
+	}, err
+}
+
+// GetPageRevisionRequest parameters from the GetPageRevision call
+type GetPageRevisionRequest struct {
+	GUID         string
+	RevisionGUID string
+}
+
+// NewGetPageRevisionRequest extracts the GetPageRevisionRequest
+func NewGetPageRevisionRequest(r *http.Request, p httprouter.Params) (GetPageRevisionRequest, error) {
+	var request GetPageRevisionRequest
+	request.GUID = p.ByName(PageIDRouteKey)
+	request.RevisionGUID = p.ByName(RevisionIDRouteKey)
+	return request.validate()
+}
+
+func (request GetPageRevisionRequest) validate() (GetPageRevisionRequest, error) {
+	if request.GUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.RevisionGUID == "" {
+		return request, errors.New("must provide a revision id")
+	}
+	return request, nil
+}
+
+// RestorePageRevisionRequest parameters from the RestorePageRevision call
+type RestorePageRevisionRequest struct {
+	GUID         string
+	RevisionGUID string
+}
+
+// NewRestorePageRevisionRequest extracts the RestorePageRevisionRequest
+func NewRestorePageRevisionRequest(r *http.Request, p httprouter.Params) (RestorePageRevisionRequest, error) {
+	request, err := NewGetPageRevisionRequest(r, p)
+	return RestorePageRevisionRequest{
+		GUID:         request.GUID,
+		RevisionGUID: request.RevisionGUID,
+	}, err
+}
+
+// RestorePageRequest parameters from the RestorePage call
+type RestorePageRequest struct {
+	GUID string
+}
+
+// NewRestorePageRequest extracts the RestorePageRequest
+func NewRestorePageRequest(r *http.Request, p httprouter.Params) (RestorePageRequest, error) {
+	request, err := NewGetPageRequest(r, p)
+	return RestorePageRequest{
+		GUID: request.GUID,
+	}, err
+}
+
+// PurgePageRequest parameters from the PurgePage call
+type PurgePageRequest struct {
+	GUID string
+}
+
+// NewPurgePageRequest extracts the PurgePageRequest
+func NewPurgePageRequest(r *http.Request, p httprouter.Params) (PurgePageRequest, error) {
+	request, err := NewGetPageRequest(r, p)
+	return PurgePageRequest{
+		GUID: request.GUID,
+	}, err
+}
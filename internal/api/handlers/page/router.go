@@ -9,7 +9,8 @@ import (
 
 // HTTP path fragments keys
 const (
-	PageIDRouteKey = "pageID"
+	PageIDRouteKey     = "pageID"
+	RevisionIDRouteKey = "revisionID"
 )
 
 // PageRouterHandlers returns the requests for the associated routes.
@@ -58,5 +59,60 @@ func PageRouterHandlers(apiPath string, pageService PageService) []api.RouterHan
 		Endpoint: fmt.Sprintf("/%v/pages/:%v/properties", apiPath, PageIDRouteKey),
 		Handle:   handler.ReplacePageProperties,
 	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/backlinks", apiPath, PageIDRouteKey),
+		Handle:   handler.GetPageBacklinks,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/graph", apiPath, PageIDRouteKey),
+		Handle:   handler.GetPageGraph,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/export", apiPath, PageIDRouteKey),
+		Handle:   handler.ExportPage,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/revisions", apiPath, PageIDRouteKey),
+		Handle:   handler.GetPageRevisions,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/revisions/:%v", apiPath, PageIDRouteKey, RevisionIDRouteKey),
+		Handle:   handler.GetPageRevision,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/revisions/:%v/restore", apiPath, PageIDRouteKey, RevisionIDRouteKey),
+		Handle:   handler.RestorePageRevision,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/search/pages", apiPath),
+		Handle:   handler.SearchPages,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/relations/dangling", apiPath),
+		Handle:   handler.GetDanglingRelations,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/trash", apiPath),
+		Handle:   handler.GetRemovedPages,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/restore", apiPath, PageIDRouteKey),
+		Handle:   handler.RestorePage,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodDelete,
+		Endpoint: fmt.Sprintf("/%v/trash/:%v", apiPath, PageIDRouteKey),
+		Handle:   handler.PurgePage,
+	})
 	return routerHandlers
 }
@@ -5,9 +5,11 @@ import (
 	"net/http"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagemarkdown"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api"
 	pagedetailservice "github.com/Pergamene/project-spiderweb-service/internal/services/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 
 	"github.com/julienschmidt/httprouter"
@@ -16,7 +18,14 @@ import (
 
 // PageDetailService see Service for more details
 type PageDetailService interface {
+	CreatePageDetail(ctx context.Context, params pagedetailservice.CreatePageDetailParams) (pagedetail.PageDetail, error)
+	ImportPageDetails(ctx context.Context, params pagedetailservice.ImportPageDetailsParams) ([]pagedetail.PageDetail, []pagemarkdown.Problem, error)
+	GetPageDetail(ctx context.Context, params pagedetailservice.GetPageDetailParams) (pagedetail.PageDetail, error)
+	RenderPageDetail(ctx context.Context, params pagedetailservice.RenderPageDetailParams) (string, error)
+	GetPageDetails(ctx context.Context, params pagedetailservice.GetPageDetailsParams) ([]pagedetail.PageDetail, error)
 	UpdatePageDetail(ctx context.Context, params pagedetailservice.UpdatePageDetailParams) error
+	RemovePageDetail(ctx context.Context, params pagedetailservice.RemovePageDetailParams) error
+	ReorderPageDetails(ctx context.Context, params pagedetailservice.ReorderPageDetailsParams) error
 }
 
 // PageDetailHandler is the handler for the associated API
@@ -24,6 +33,171 @@ type PageDetailHandler struct {
 	PageDetailService PageDetailService
 }
 
+// CreatePageDetail see Service for more details
+func (h PageDetailHandler) CreatePageDetail(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewCreatePageDetailRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.PageDetailService.CreatePageDetail(ctx, pagedetailservice.CreatePageDetailParams{
+		Detail: pagedetail.PageDetail{
+			Title:      request.Title,
+			Summary:    request.Summary,
+			Partitions: request.Partitions,
+		},
+		PageID: request.PageGUID,
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, map[string]string{"id": record.GUID}, nil)
+}
+
+// ImportPageDetails see Service for more details
+func (h PageDetailHandler) ImportPageDetails(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewImportPageDetailsRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, problems, err := h.PageDetailService.ImportPageDetails(ctx, pagedetailservice.ImportPageDetailsParams{
+		Markdown: request.Markdown,
+		Title:    request.Title,
+		Preview:  request.Preview,
+		PageID:   request.PageGUID,
+		UserID:   authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	conformedRecords := make([]interface{}, 0)
+	for _, record := range records {
+		conformedRecords = append(conformedRecords, record.GetJSONConformed())
+	}
+	api.RespondWith(r, w, http.StatusOK, map[string]interface{}{
+		"details":  conformedRecords,
+		"problems": problems,
+	}, nil)
+}
+
+// GetPageDetail see Service for more details
+func (h PageDetailHandler) GetPageDetail(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetPageDetailRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	if request.Format == detailFormatHTML {
+		h.renderPageDetail(w, r, request, authData.UserID)
+		return
+	}
+	record, err := h.PageDetailService.GetPageDetail(ctx, pagedetailservice.GetPageDetailParams{
+		Detail: pagedetail.PageDetail{
+			GUID: request.PageDetailGUID,
+		},
+		PageID: request.PageGUID,
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, record.GetJSONConformed(), nil)
+}
+
+func (h PageDetailHandler) renderPageDetail(w http.ResponseWriter, r *http.Request, request GetPageDetailRequest, userID string) {
+	content, err := h.PageDetailService.RenderPageDetail(r.Context(), pagedetailservice.RenderPageDetailParams{
+		Detail: pagedetail.PageDetail{
+			GUID: request.PageDetailGUID,
+		},
+		PageID: request.PageGUID,
+		UserID: userID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWithContent(r, w, http.StatusOK, "text/html; charset=utf-8", []byte(content))
+}
+
+// GetPageDetails see Service for more details
+func (h PageDetailHandler) GetPageDetails(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetPageDetailsRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.PageDetailService.GetPageDetails(ctx, pagedetailservice.GetPageDetailsParams{
+		PageID: request.PageGUID,
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	conformedRecords := make([]interface{}, 0)
+	for _, record := range records {
+		conformedRecords = append(conformedRecords, record.GetJSONConformed())
+	}
+	api.RespondWith(r, w, http.StatusOK, conformedRecords, nil)
+}
+
 // UpdatePageDetail see Service for more details
 func (h PageDetailHandler) UpdatePageDetail(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	request, err := NewUpdatePageDetailRequest(r, p)
@@ -39,17 +213,90 @@ func (h PageDetailHandler) UpdatePageDetail(w http.ResponseWriter, r *http.Reque
 	}
 	err = h.PageDetailService.UpdatePageDetail(ctx, pagedetailservice.UpdatePageDetailParams{
 		Detail: pagedetail.PageDetail{
-			Title:   request.Title,
-			Summary: request.Summary,
-			//@TODO:
-			// Partitions: request.Partitions,
+			GUID:       request.PageDetailGUID,
+			Title:      request.Title,
+			Summary:    request.Summary,
+			Partitions: request.Partitions,
+		},
+		PageID: request.PageGUID,
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// RemovePageDetail see Service for more details
+func (h PageDetailHandler) RemovePageDetail(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewRemovePageDetailRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageDetailService.RemovePageDetail(ctx, pagedetailservice.RemovePageDetailParams{
+		Detail: pagedetail.PageDetail{
+			GUID: request.PageDetailGUID,
 		},
+		PageID: request.PageGUID,
 		UserID: authData.UserID,
 	})
 	if _, ok := err.(*storeerror.NotAuthorized); ok {
 		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
 		return
 	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// ReorderPageDetails see Service for more details
+func (h PageDetailHandler) ReorderPageDetails(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewReorderPageDetailsRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageDetailService.ReorderPageDetails(ctx, pagedetailservice.ReorderPageDetailsParams{
+		PageDetailIDs: request.PageDetailGUIDs,
+		PageID:        request.PageGUID,
+		UserID:        authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
 	if err != nil {
 		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
 		return
@@ -6,7 +6,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagemarkdown"
 	pagedetailservice "github.com/Pergamene/project-spiderweb-service/internal/services/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 	"github.com/pkg/errors"
 
@@ -15,70 +18,567 @@ import (
 
 	"github.com/Pergamene/project-spiderweb-service/internal/api"
 	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
-	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/page/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/pagedetail/mocks"
 )
 
-type updatePageDetailCall struct {
-	pageDetailParams pagedetailservice.UpdatePageDetailParams
+type createPageDetailCall struct {
+	pageDetailParams pagedetailservice.CreatePageDetailParams
+	returnRecord     pagedetail.PageDetail
 	returnErr        error
 }
 
-func TestUpdatePageDetail(t *testing.T) {
+func TestCreatePageDetail(t *testing.T) {
+	cases := []struct {
+		name                  string
+		pageID                string
+		headers               map[string]string
+		requestBody           string
+		authN                 api.AuthN
+		authZ                 api.AuthZ
+		expectedResponseBody  string
+		expectedStatusCode    int
+		createPageDetailCalls []createPageDetailCall
+	}{
+		{
+			name:                 "not authenticated",
+			pageID:               "PG_1",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name:   "happy detail creation, local",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"title\":\"test title\",\"summary\":\"test summary\",\"partitions\":[{\"type\":\"p\",\"partitions\":[{\"type\":\"relation\",\"value\":\"a link\",\"relation\":\"PG_2\"}]}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"DT_1\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			createPageDetailCalls: []createPageDetailCall{
+				{
+					pageDetailParams: pagedetailservice.CreatePageDetailParams{
+						Detail: pagedetail.PageDetail{
+							Title:   "test title",
+							Summary: "test summary",
+							Partitions: []pagedetail.Partition{
+								{
+									Type:       pagedetail.PartitionTypeParagraph,
+									TypeString: "p",
+									Partitions: []pagedetail.Partition{
+										{
+											Type:       pagedetail.PartitionTypeRelation,
+											TypeString: "relation",
+											Value:      "a link",
+											Relation:   "PG_2",
+										},
+									},
+								},
+							},
+						},
+						PageID: "PG_1",
+						UserID: "UR_1",
+					},
+					returnRecord: pagedetail.PageDetail{GUID: "DT_1"},
+				},
+			},
+		},
+		{
+			name:   "invalid partition type",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"title\":\"test title\",\"partitions\":[{\"type\":\"marquee\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"not valid page partitions\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "missing title",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"summary\":\"test summary\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide title\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailService := new(mocks.PageDetailService)
+			for index := range tc.createPageDetailCalls {
+				pageDetailService.On("CreatePageDetail", mock.Anything, tc.createPageDetailCalls[index].pageDetailParams).Return(tc.createPageDetailCalls[index].returnRecord, tc.createPageDetailCalls[index].returnErr)
+			}
+			routerHandlers := PageDetailRouterHandlers(tc.authZ.APIPath, pageDetailService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       fmt.Sprintf("pages/%v/details", tc.pageID),
+				Headers:        tc.headers,
+				Body:           strings.NewReader(tc.requestBody),
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageDetailService.AssertNumberOfCalls(t, "CreatePageDetail", len(tc.createPageDetailCalls))
+		})
+	}
+}
+
+type importPageDetailsCall struct {
+	pageDetailParams pagedetailservice.ImportPageDetailsParams
+	returnRecords    []pagedetail.PageDetail
+	returnProblems   []pagemarkdown.Problem
+	returnErr        error
+}
+
+func TestImportPageDetails(t *testing.T) {
+	cases := []struct {
+		name                   string
+		pageID                 string
+		headers                map[string]string
+		requestBody            string
+		authN                  api.AuthN
+		authZ                  api.AuthZ
+		expectedResponseBody   string
+		expectedStatusCode     int
+		importPageDetailsCalls []importPageDetailsCall
+	}{
+		{
+			name:                 "not authenticated",
+			pageID:               "PG_1",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name:   "happy import, local",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"markdown\":\"# History\\n\\nUse `this`.\",\"title\":\"Overview\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"details\":[{\"id\":\"DT_1\",\"title\":\"History\",\"summary\":\"\",\"partitions\":[{\"type\":\"p\",\"value\":\"Use this.\"}]}],\"problems\":[{\"line\":3,\"message\":\"inline code is not supported and is kept as plain text\"}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			importPageDetailsCalls: []importPageDetailsCall{
+				{
+					pageDetailParams: pagedetailservice.ImportPageDetailsParams{
+						Markdown: "# History\n\nUse `this`.",
+						Title:    "Overview",
+						PageID:   "PG_1",
+						UserID:   "UR_1",
+					},
+					returnRecords: []pagedetail.PageDetail{
+						{
+							GUID:       "DT_1",
+							Title:      "History",
+							Partitions: []pagedetail.Partition{{Type: pagedetail.PartitionTypeParagraph, TypeString: "p", Value: "Use this."}},
+						},
+					},
+					returnProblems: []pagemarkdown.Problem{{Line: 3, Message: "inline code is not supported and is kept as plain text"}},
+				},
+			},
+		},
+		{
+			name:   "preview",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"markdown\":\"# History\",\"preview\":true}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"details\":[{\"id\":\"\",\"title\":\"History\",\"summary\":\"\",\"partitions\":[]}],\"problems\":[]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			importPageDetailsCalls: []importPageDetailsCall{
+				{
+					pageDetailParams: pagedetailservice.ImportPageDetailsParams{
+						Markdown: "# History",
+						Preview:  true,
+						PageID:   "PG_1",
+						UserID:   "UR_1",
+					},
+					returnRecords:  []pagedetail.PageDetail{{Title: "History"}},
+					returnProblems: []pagemarkdown.Problem{},
+				},
+			},
+		},
+		{
+			name:   "missing markdown",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"markdown\":\" \\n\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide markdown\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "unauthorized",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"markdown\":\"# History\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			importPageDetailsCalls: []importPageDetailsCall{
+				{
+					pageDetailParams: pagedetailservice.ImportPageDetailsParams{
+						Markdown: "# History",
+						PageID:   "PG_1",
+						UserID:   "UR_1",
+					},
+					returnErr: &storeerror.NotAuthorized{},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailService := new(mocks.PageDetailService)
+			for index := range tc.importPageDetailsCalls {
+				pageDetailService.On("ImportPageDetails", mock.Anything, tc.importPageDetailsCalls[index].pageDetailParams).Return(tc.importPageDetailsCalls[index].returnRecords, tc.importPageDetailsCalls[index].returnProblems, tc.importPageDetailsCalls[index].returnErr)
+			}
+			routerHandlers := PageDetailRouterHandlers(tc.authZ.APIPath, pageDetailService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       fmt.Sprintf("pages/%v/details/import", tc.pageID),
+				Headers:        tc.headers,
+				Body:           strings.NewReader(tc.requestBody),
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageDetailService.AssertNumberOfCalls(t, "ImportPageDetails", len(tc.importPageDetailsCalls))
+		})
+	}
+}
+
+type getPageDetailCall struct {
+	pageDetailParams pagedetailservice.GetPageDetailParams
+	returnRecord     pagedetail.PageDetail
+	returnErr        error
+}
+
+func TestGetPageDetail(t *testing.T) {
 	cases := []struct {
 		name                 string
 		pageID               string
+		detailID             string
 		headers              map[string]string
-		requestBody          string
 		authN                api.AuthN
 		authZ                api.AuthZ
-		datacenter           string
 		expectedResponseBody string
 		expectedStatusCode   int
-		updatePageCalls      []updatePageCall
+		getPageDetailCalls   []getPageDetailCall
+	}{
+		{
+			name:     "happy path, partitions round-trip",
+			pageID:   "PG_1",
+			detailID: "DT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"DT_1\",\"title\":\"test title\",\"summary\":\"\",\"partitions\":[{\"type\":\"ul\",\"items\":[{\"type\":\"color\",\"value\":\"red\",\"color\":\"#FF0000\"}]}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageDetailCalls: []getPageDetailCall{
+				{
+					pageDetailParams: pagedetailservice.GetPageDetailParams{
+						Detail: pagedetail.PageDetail{GUID: "DT_1"},
+						PageID: "PG_1",
+						UserID: "UR_1",
+					},
+					returnRecord: pagedetail.PageDetail{
+						GUID:  "DT_1",
+						Title: "test title",
+						Partitions: []pagedetail.Partition{
+							{
+								Type:       pagedetail.PartitionTypeUnorderedList,
+								TypeString: "ul",
+								Items: []pagedetail.Partition{
+									{
+										Type:       pagedetail.PartitionTypeColor,
+										TypeString: "color",
+										Value:      "red",
+										Color:      "#FF0000",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "detail without partitions",
+			pageID:   "PG_1",
+			detailID: "DT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"DT_1\",\"title\":\"test title\",\"summary\":\"\",\"partitions\":[]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageDetailCalls: []getPageDetailCall{
+				{
+					pageDetailParams: pagedetailservice.GetPageDetailParams{
+						Detail: pagedetail.PageDetail{GUID: "DT_1"},
+						PageID: "PG_1",
+						UserID: "UR_1",
+					},
+					returnRecord: pagedetail.PageDetail{
+						GUID:  "DT_1",
+						Title: "test title",
+					},
+				},
+			},
+		},
+		{
+			name:     "detail not found",
+			pageID:   "PG_1",
+			detailID: "DT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: DT_1\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			getPageDetailCalls: []getPageDetailCall{
+				{
+					pageDetailParams: pagedetailservice.GetPageDetailParams{
+						Detail: pagedetail.PageDetail{GUID: "DT_1"},
+						PageID: "PG_1",
+						UserID: "UR_1",
+					},
+					returnErr: errors.Wrap(&storeerror.NotFound{ID: "DT_1"}, "failed to get detail"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailService := new(mocks.PageDetailService)
+			for index := range tc.getPageDetailCalls {
+				pageDetailService.On("GetPageDetail", mock.Anything, tc.getPageDetailCalls[index].pageDetailParams).Return(tc.getPageDetailCalls[index].returnRecord, tc.getPageDetailCalls[index].returnErr)
+			}
+			routerHandlers := PageDetailRouterHandlers(tc.authZ.APIPath, pageDetailService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("pages/%v/details/%v", tc.pageID, tc.detailID),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageDetailService.AssertNumberOfCalls(t, "GetPageDetail", len(tc.getPageDetailCalls))
+		})
+	}
+}
+
+type renderPageDetailCall struct {
+	pageDetailParams pagedetailservice.RenderPageDetailParams
+	returnHTML       string
+	returnErr        error
+}
+
+func TestRenderPageDetail(t *testing.T) {
+	cases := []struct {
+		name                  string
+		query                 string
+		headers               map[string]string
+		authN                 api.AuthN
+		authZ                 api.AuthZ
+		expectedResponseBody  string
+		expectedStatusCode    int
+		expectedContentType   string
+		renderPageDetailCalls []renderPageDetailCall
+	}{
+		{
+			name:  "happy path",
+			query: "?format=html",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "<section id=\"DT_1\">\n<h1>test title</h1>\n</section>",
+			expectedStatusCode:   200,
+			expectedContentType:  "text/html; charset=utf-8",
+			renderPageDetailCalls: []renderPageDetailCall{
+				{
+					pageDetailParams: pagedetailservice.RenderPageDetailParams{
+						Detail: pagedetail.PageDetail{GUID: "DT_1"},
+						PageID: "PG_1",
+						UserID: "UR_1",
+					},
+					returnHTML: "<section id=\"DT_1\">\n<h1>test title</h1>\n</section>",
+				},
+			},
+		},
+		{
+			name:  "unsupported format",
+			query: "?format=pdf",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"format must be json or html\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			expectedContentType:  "application/json",
+		},
+		{
+			name:  "trying to render a detail of a page that you don't have permission to read",
+			query: "?format=html",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			expectedContentType:  "application/json",
+			renderPageDetailCalls: []renderPageDetailCall{
+				{
+					pageDetailParams: pagedetailservice.RenderPageDetailParams{
+						Detail: pagedetail.PageDetail{GUID: "DT_1"},
+						PageID: "PG_1",
+						UserID: "UR_2",
+					},
+					returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "PG_1"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailService := new(mocks.PageDetailService)
+			for index := range tc.renderPageDetailCalls {
+				pageDetailService.On("RenderPageDetail", mock.Anything, tc.renderPageDetailCalls[index].pageDetailParams).Return(tc.renderPageDetailCalls[index].returnHTML, tc.renderPageDetailCalls[index].returnErr)
+			}
+			routerHandlers := PageDetailRouterHandlers(tc.authZ.APIPath, pageDetailService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       fmt.Sprintf("pages/PG_1/details/DT_1%v", tc.query),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			require.Equal(t, tc.expectedContentType, resp.Header.Get("Content-Type"))
+			pageDetailService.AssertNumberOfCalls(t, "RenderPageDetail", len(tc.renderPageDetailCalls))
+			pageDetailService.AssertNumberOfCalls(t, "GetPageDetail", 0)
+		})
+	}
+}
+
+type updatePageDetailCall struct {
+	pageDetailParams pagedetailservice.UpdatePageDetailParams
+	returnErr        error
+}
+
+func TestUpdatePageDetail(t *testing.T) {
+	cases := []struct {
+		name                  string
+		pageID                string
+		detailID              string
+		headers               map[string]string
+		requestBody           string
+		authN                 api.AuthN
+		authZ                 api.AuthZ
+		expectedResponseBody  string
+		expectedStatusCode    int
+		updatePageDetailCalls []updatePageDetailCall
 	}{
 		{
 			name:                 "not authenticated",
 			pageID:               "PG_1",
+			detailID:             "DT_1",
 			authN:                handlertestutils.DefaultAuthN("PROD"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
 		},
 		{
-			name:   "happy page creation, local",
-			pageID: "PG_1",
+			name:     "happy detail update, local",
+			pageID:   "PG_1",
+			detailID: "DT_1",
 			headers: map[string]string{
 				"X-USER-ID": "UR_1",
 			},
-			requestBody:          "{\"title\":\"test title\",\"summary\":\"test summary\",\"versionId\":1,\"permission\":\"PR\"}",
+			requestBody:          "{\"title\":\"test title\",\"summary\":\"test summary\",\"partitions\":[{\"type\":\"h1\",\"value\":\"header\"}]}",
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   200,
-			updatePageCalls: []updatePageCall{
+			updatePageDetailCalls: []updatePageDetailCall{
 				{
-					pageParams: pageservice.UpdatePageParams{
-						Page:   getPage("PG_1", 0, ""),
+					pageDetailParams: pagedetailservice.UpdatePageDetailParams{
+						Detail: pagedetail.PageDetail{
+							GUID:    "DT_1",
+							Title:   "test title",
+							Summary: "test summary",
+							Partitions: []pagedetail.Partition{
+								{
+									Type:       pagedetail.PartitionTypeHeaderOne,
+									TypeString: "h1",
+									Value:      "header",
+								},
+							},
+						},
+						PageID: "PG_1",
 						UserID: "UR_1",
 					},
 				},
 			},
 		},
 		{
-			name:   "trying to edit a page that you don't have permission to update",
-			pageID: "PG_1",
+			name:     "trying to edit a detail that you don't have permission to update",
+			pageID:   "PG_1",
+			detailID: "DT_1",
 			headers: map[string]string{
 				"X-USER-ID": "UR_1",
 			},
-			requestBody:          "{\"title\":\"test title\",\"summary\":\"test summary\",\"versionId\":1,\"permission\":\"PR\"}",
+			requestBody:          "{\"title\":\"test title\"}",
 			authN:                handlertestutils.DefaultAuthN("LOCAL"),
 			authZ:                handlertestutils.DefaultAuthZ(),
-			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\"}}\n",
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
 			expectedStatusCode:   401,
-			updatePageCalls: []updatePageCall{
+			updatePageDetailCalls: []updatePageDetailCall{
 				{
-					pageParams: pageservice.UpdatePageParams{
-						Page:   getPage("PG_1", 0, ""),
+					pageDetailParams: pagedetailservice.UpdatePageDetailParams{
+						Detail: pagedetail.PageDetail{
+							GUID:  "DT_1",
+							Title: "test title",
+						},
+						PageID: "PG_1",
 						UserID: "UR_1",
 					},
 					returnErr: &storeerror.NotAuthorized{
@@ -89,26 +589,208 @@ func TestUpdatePageDetail(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "missing title",
+			pageID:   "PG_1",
+			detailID: "DT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"summary\":\"test summary\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"a page detail must retain a title\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
 	}
 	for _, tc := range cases {
-		t.Run(fmt.Sprintf(tc.name), func(t *testing.T) {
-			pageService := new(mocks.PageService)
-			for index := range tc.updatePageCalls {
-				pageService.On("UpdatePage", mock.Anything, tc.updatePageCalls[index].pageParams).Return(tc.updatePageCalls[index].returnErr)
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailService := new(mocks.PageDetailService)
+			for index := range tc.updatePageDetailCalls {
+				pageDetailService.On("UpdatePageDetail", mock.Anything, tc.updatePageDetailCalls[index].pageDetailParams).Return(tc.updatePageDetailCalls[index].returnErr)
 			}
-			routerHandlers := PageRouterHandlers(tc.authZ.APIPath, pageService)
+			routerHandlers := PageDetailRouterHandlers(tc.authZ.APIPath, pageDetailService)
 			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
 				Method:         http.MethodPut,
-				Endpoint:       fmt.Sprintf("pages/%v", tc.pageID),
+				Endpoint:       fmt.Sprintf("pages/%v/details/%v", tc.pageID, tc.detailID),
+				Headers:        tc.headers,
+				Body:           strings.NewReader(tc.requestBody),
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageDetailService.AssertNumberOfCalls(t, "UpdatePageDetail", len(tc.updatePageDetailCalls))
+		})
+	}
+}
+
+type removePageDetailCall struct {
+	pageDetailParams pagedetailservice.RemovePageDetailParams
+	returnErr        error
+}
+
+func TestRemovePageDetail(t *testing.T) {
+	cases := []struct {
+		name                  string
+		pageID                string
+		detailID              string
+		headers               map[string]string
+		authN                 api.AuthN
+		authZ                 api.AuthZ
+		expectedResponseBody  string
+		expectedStatusCode    int
+		removePageDetailCalls []removePageDetailCall
+	}{
+		{
+			name:     "happy path",
+			pageID:   "PG_1",
+			detailID: "DT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			removePageDetailCalls: []removePageDetailCall{
+				{
+					pageDetailParams: pagedetailservice.RemovePageDetailParams{
+						Detail: pagedetail.PageDetail{GUID: "DT_1"},
+						PageID: "PG_1",
+						UserID: "UR_1",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailService := new(mocks.PageDetailService)
+			for index := range tc.removePageDetailCalls {
+				pageDetailService.On("RemovePageDetail", mock.Anything, tc.removePageDetailCalls[index].pageDetailParams).Return(tc.removePageDetailCalls[index].returnErr)
+			}
+			routerHandlers := PageDetailRouterHandlers(tc.authZ.APIPath, pageDetailService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodDelete,
+				Endpoint:       fmt.Sprintf("pages/%v/details/%v", tc.pageID, tc.detailID),
 				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageDetailService.AssertNumberOfCalls(t, "RemovePageDetail", len(tc.removePageDetailCalls))
+		})
+	}
+}
+
+type reorderPageDetailsCall struct {
+	pageDetailParams pagedetailservice.ReorderPageDetailsParams
+	returnErr        error
+}
+
+func TestReorderPageDetails(t *testing.T) {
+	cases := []struct {
+		name                    string
+		pageID                  string
+		headers                 map[string]string
+		requestBody             string
+		authN                   api.AuthN
+		authZ                   api.AuthZ
+		expectedResponseBody    string
+		expectedStatusCode      int
+		reorderPageDetailsCalls []reorderPageDetailsCall
+	}{
+		{
+			name:   "happy path",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "[\"DT_2\",\"DT_1\"]",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			reorderPageDetailsCalls: []reorderPageDetailsCall{
+				{
+					pageDetailParams: pagedetailservice.ReorderPageDetailsParams{
+						PageDetailIDs: []string{"DT_2", "DT_1"},
+						PageID:        "PG_1",
+						UserID:        "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name:   "not a list",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"id\":\"DT_1\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"invalid request\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "empty detail id",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "[\"DT_1\",\"\"]",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide a detail id at 1\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name:   "order does not match the page's details",
+			pageID: "PG_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "[\"DT_1\"]",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"detail DT_2 is missing from the order\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			reorderPageDetailsCalls: []reorderPageDetailsCall{
+				{
+					pageDetailParams: pagedetailservice.ReorderPageDetailsParams{
+						PageDetailIDs: []string{"DT_1"},
+						PageID:        "PG_1",
+						UserID:        "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "detail DT_2 is missing from the order"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailService := new(mocks.PageDetailService)
+			for index := range tc.reorderPageDetailsCalls {
+				pageDetailService.On("ReorderPageDetails", mock.Anything, tc.reorderPageDetailsCalls[index].pageDetailParams).Return(tc.reorderPageDetailsCalls[index].returnErr)
+			}
+			routerHandlers := PageDetailRouterHandlers(tc.authZ.APIPath, pageDetailService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPut,
+				Endpoint:       fmt.Sprintf("pages/%v/details", tc.pageID),
 				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
 				RouterHandlers: routerHandlers,
 				AuthZ:          tc.authZ,
 				AuthN:          tc.authN,
 			})
 			require.Equal(t, tc.expectedResponseBody, respBody)
 			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
-			pageService.AssertNumberOfCalls(t, "UpdatePage", len(tc.updatePageCalls))
+			pageDetailService.AssertNumberOfCalls(t, "ReorderPageDetails", len(tc.reorderPageDetailsCalls))
 		})
 	}
 }
@@ -3,12 +3,132 @@ package pagedetailhandler
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 )
 
+// CreatePageDetailRequest parameters from the CreatePageDetail call
+type CreatePageDetailRequest struct {
+	PageGUID   string
+	Title      string                 `json:"title"`
+	Summary    string                 `json:"summary"`
+	Partitions []pagedetail.Partition `json:"partitions"`
+}
+
+// NewCreatePageDetailRequest extracts the CreatePageDetailRequest
+func NewCreatePageDetailRequest(r *http.Request, p httprouter.Params) (CreatePageDetailRequest, error) {
+	var request CreatePageDetailRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	err = pagedetail.UnmarshalPartitions(request.Partitions)
+	if err != nil {
+		return request, errors.New("not valid page partitions")
+	}
+	request.PageGUID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request CreatePageDetailRequest) validate() (CreatePageDetailRequest, error) {
+	if request.PageGUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.Title == "" {
+		return request, errors.New("must provide title")
+	}
+	return request, nil
+}
+
+// ImportPageDetailsRequest parameters from the ImportPageDetails call
+type ImportPageDetailsRequest struct {
+	PageGUID string
+	Markdown string `json:"markdown"`
+	Title    string `json:"title"`
+	Preview  bool   `json:"preview"`
+}
+
+// NewImportPageDetailsRequest extracts the ImportPageDetailsRequest
+func NewImportPageDetailsRequest(r *http.Request, p httprouter.Params) (ImportPageDetailsRequest, error) {
+	var request ImportPageDetailsRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.PageGUID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request ImportPageDetailsRequest) validate() (ImportPageDetailsRequest, error) {
+	if request.PageGUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if strings.TrimSpace(request.Markdown) == "" {
+		return request, errors.New("must provide markdown")
+	}
+	return request, nil
+}
+
+// The formats a detail can be returned as
+const (
+	detailFormatJSON = "json"
+	detailFormatHTML = "html"
+)
+
+// GetPageDetailRequest parameters from the GetPageDetail call
+type GetPageDetailRequest struct {
+	PageGUID       string
+	PageDetailGUID string
+	Format         string
+}
+
+// NewGetPageDetailRequest extracts the GetPageDetailRequest
+func NewGetPageDetailRequest(r *http.Request, p httprouter.Params) (GetPageDetailRequest, error) {
+	var request GetPageDetailRequest
+	request.PageGUID = p.ByName(PageIDRouteKey)
+	request.PageDetailGUID = p.ByName(PageDetailIDRouteKey)
+	request.Format = r.URL.Query().Get("format")
+	if request.Format == "" {
+		request.Format = detailFormatJSON
+	}
+	return request.validate()
+}
+
+func (request GetPageDetailRequest) validate() (GetPageDetailRequest, error) {
+	if request.PageGUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.PageDetailGUID == "" {
+		return request, errors.New("must provide a detail id")
+	}
+	if request.Format != detailFormatJSON && request.Format != detailFormatHTML {
+		return request, errors.New("format must be json or html")
+	}
+	return request, nil
+}
+
+// GetPageDetailsRequest parameters from the GetPageDetails call
+type GetPageDetailsRequest struct {
+	PageGUID string
+}
+
+// NewGetPageDetailsRequest extracts the GetPageDetailsRequest
+func NewGetPageDetailsRequest(r *http.Request, p httprouter.Params) (GetPageDetailsRequest, error) {
+	var request GetPageDetailsRequest
+	request.PageGUID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request GetPageDetailsRequest) validate() (GetPageDetailsRequest, error) {
+	if request.PageGUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	return request, nil
+}
+
 // UpdatePageDetailRequest parameters from the UpdatePageDetail call
 type UpdatePageDetailRequest struct {
 	PageGUID       string
@@ -35,8 +155,67 @@ func NewUpdatePageDetailRequest(r *http.Request, p httprouter.Params) (UpdatePag
 }
 
 func (request UpdatePageDetailRequest) validate() (UpdatePageDetailRequest, error) {
+	if request.PageGUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.PageDetailGUID == "" {
+		return request, errors.New("must provide a detail id")
+	}
 	if request.Title == "" {
 		return request, errors.New("a page detail must retain a title")
 	}
 	return request, nil
 }
+
+// RemovePageDetailRequest parameters from the RemovePageDetail call
+type RemovePageDetailRequest struct {
+	PageGUID       string
+	PageDetailGUID string
+}
+
+// NewRemovePageDetailRequest extracts the RemovePageDetailRequest
+func NewRemovePageDetailRequest(r *http.Request, p httprouter.Params) (RemovePageDetailRequest, error) {
+	var request RemovePageDetailRequest
+	request.PageGUID = p.ByName(PageIDRouteKey)
+	request.PageDetailGUID = p.ByName(PageDetailIDRouteKey)
+	return request.validate()
+}
+
+func (request RemovePageDetailRequest) validate() (RemovePageDetailRequest, error) {
+	if request.PageGUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if request.PageDetailGUID == "" {
+		return request, errors.New("must provide a detail id")
+	}
+	return request, nil
+}
+
+// ReorderPageDetailsRequest parameters from the ReorderPageDetails call
+type ReorderPageDetailsRequest struct {
+	PageGUID        string
+	PageDetailGUIDs []string
+}
+
+// NewReorderPageDetailsRequest extracts the ReorderPageDetailsRequest
+func NewReorderPageDetailsRequest(r *http.Request, p httprouter.Params) (ReorderPageDetailsRequest, error) {
+	var request ReorderPageDetailsRequest
+	err := json.NewDecoder(r.Body).Decode(&request.PageDetailGUIDs)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.PageGUID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request ReorderPageDetailsRequest) validate() (ReorderPageDetailsRequest, error) {
+	if request.PageGUID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	for i, pageDetailGUID := range request.PageDetailGUIDs {
+		if pageDetailGUID == "" {
+			return request, errors.Errorf("must provide a detail id at %v", i)
+		}
+	}
+	return request, nil
+}
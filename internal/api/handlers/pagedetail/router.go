@@ -19,10 +19,40 @@ func PageDetailRouterHandlers(apiPath string, pageDetailService PageDetailServic
 		PageDetailService: pageDetailService,
 	}
 	var routerHandlers []api.RouterHandler
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/details", apiPath, PageIDRouteKey),
+		Handle:   handler.CreatePageDetail,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/details", apiPath, PageIDRouteKey),
+		Handle:   handler.GetPageDetails,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPut,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/details", apiPath, PageIDRouteKey),
+		Handle:   handler.ReorderPageDetails,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/details/import", apiPath, PageIDRouteKey),
+		Handle:   handler.ImportPageDetails,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/details/:%v", apiPath, PageIDRouteKey, PageDetailIDRouteKey),
+		Handle:   handler.GetPageDetail,
+	})
 	routerHandlers = append(routerHandlers, api.RouterHandler{
 		Method:   http.MethodPut,
 		Endpoint: fmt.Sprintf("/%v/pages/:%v/details/:%v", apiPath, PageIDRouteKey, PageDetailIDRouteKey),
 		Handle:   handler.UpdatePageDetail,
 	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodDelete,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/details/:%v", apiPath, PageIDRouteKey, PageDetailIDRouteKey),
+		Handle:   handler.RemovePageDetail,
+	})
 	return routerHandlers
 }
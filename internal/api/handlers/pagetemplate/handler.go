@@ -0,0 +1,184 @@
+package pagetemplatehandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	pagetemplateservice "github.com/Pergamene/project-spiderweb-service/internal/services/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// PageTemplateService see Service for more details
+type PageTemplateService interface {
+	CreatePageTemplate(ctx context.Context, params pagetemplateservice.CreatePageTemplateParams) (pagetemplate.PageTemplate, error)
+	UpdatePageTemplate(ctx context.Context, params pagetemplateservice.UpdatePageTemplateParams) error
+	DisablePageTemplate(ctx context.Context, params pagetemplateservice.DisablePageTemplateParams) error
+	EnablePageTemplate(ctx context.Context, params pagetemplateservice.EnablePageTemplateParams) error
+	GetPageTemplates(ctx context.Context, params pagetemplateservice.GetPageTemplatesParams) ([]pagetemplate.PageTemplate, error)
+}
+
+// PageTemplateHandler is the handler for the associated API
+type PageTemplateHandler struct {
+	PageTemplateService PageTemplateService
+}
+
+// CreatePageTemplate see Service for more details
+func (h PageTemplateHandler) CreatePageTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewCreatePageTemplateRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.PageTemplateService.CreatePageTemplate(ctx, pagetemplateservice.CreatePageTemplateParams{
+		PageTemplate: pagetemplate.PageTemplate{
+			Name:       request.Name,
+			Summary:    request.Summary,
+			Properties: request.Properties,
+		},
+		OwnerID: authData.UserID,
+	})
+	if castErr, ok := err.(*storeerror.DupEntry); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, map[string]string{"id": record.GUID}, nil)
+}
+
+// UpdatePageTemplate see Service for more details
+func (h PageTemplateHandler) UpdatePageTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewUpdatePageTemplateRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageTemplateService.UpdatePageTemplate(ctx, pagetemplateservice.UpdatePageTemplateParams{
+		PageTemplate: pagetemplate.PageTemplate{
+			GUID:       request.PageTemplateID,
+			Name:       request.Name,
+			Summary:    request.Summary,
+			Properties: request.Properties,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// DisablePageTemplate see Service for more details
+func (h PageTemplateHandler) DisablePageTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewDisablePageTemplateRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageTemplateService.DisablePageTemplate(ctx, pagetemplateservice.DisablePageTemplateParams{
+		PageTemplate: pagetemplate.PageTemplate{
+			GUID: request.PageTemplateID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// EnablePageTemplate see Service for more details
+func (h PageTemplateHandler) EnablePageTemplate(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewEnablePageTemplateRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PageTemplateService.EnablePageTemplate(ctx, pagetemplateservice.EnablePageTemplateParams{
+		PageTemplate: pagetemplate.PageTemplate{
+			GUID: request.PageTemplateID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// GetPageTemplates see Service for more details
+func (h PageTemplateHandler) GetPageTemplates(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.PageTemplateService.GetPageTemplates(ctx, pagetemplateservice.GetPageTemplatesParams{
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, records, nil)
+}
@@ -0,0 +1,396 @@
+package pagetemplatehandler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	pagetemplateservice "github.com/Pergamene/project-spiderweb-service/internal/services/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/pagetemplate/mocks"
+)
+
+type createPageTemplateCall struct {
+	pageTemplateParams pagetemplateservice.CreatePageTemplateParams
+	returnRecord       pagetemplate.PageTemplate
+	returnErr          error
+}
+
+func TestCreatePageTemplate(t *testing.T) {
+	cases := []struct {
+		name                    string
+		headers                 map[string]string
+		requestBody             string
+		authN                   api.AuthN
+		authZ                   api.AuthZ
+		expectedResponseBody    string
+		expectedStatusCode      int
+		createPageTemplateCalls []createPageTemplateCall
+	}{
+		{
+			name:                 "not authenticated",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"Place\",\"properties\":[{\"key\":\"population\",\"type\":\"number\",\"required\":true},{\"key\":\"banner\",\"type\":\"string\",\"default\":\"none\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"PGT_1\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			createPageTemplateCalls: []createPageTemplateCall{
+				{
+					pageTemplateParams: pagetemplateservice.CreatePageTemplateParams{
+						PageTemplate: pagetemplate.PageTemplate{
+							Name: "Place",
+							Properties: []pagetemplate.TemplateProperty{
+								{Key: "population", Type: property.TypeNumber, Required: true},
+								{Key: "banner", Type: property.TypeString, DefaultValue: "none"},
+							},
+						},
+						OwnerID: "UR_1",
+					},
+					returnRecord: pagetemplate.PageTemplate{ID: 1, GUID: "PGT_1", Name: "Place"},
+				},
+			},
+		},
+		{
+			name: "missing name",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"summary\":\"A place\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide name\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "default value of the wrong type",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"Place\",\"properties\":[{\"key\":\"population\",\"type\":\"number\",\"default\":\"many\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"the default value of property population must be of type number\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "unregistered property",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"Place\",\"properties\":[{\"key\":\"climate\",\"type\":\"string\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"property climate is not registered or is disabled\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			createPageTemplateCalls: []createPageTemplateCall{
+				{
+					pageTemplateParams: pagetemplateservice.CreatePageTemplateParams{
+						PageTemplate: pagetemplate.PageTemplate{
+							Name:       "Place",
+							Properties: []pagetemplate.TemplateProperty{{Key: "climate", Type: property.TypeString}},
+						},
+						OwnerID: "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "property climate is not registered or is disabled"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateService := new(mocks.PageTemplateService)
+			for index := range tc.createPageTemplateCalls {
+				pageTemplateService.On("CreatePageTemplate", mock.Anything, tc.createPageTemplateCalls[index].pageTemplateParams).Return(tc.createPageTemplateCalls[index].returnRecord, tc.createPageTemplateCalls[index].returnErr)
+			}
+			routerHandlers := PageTemplateRouterHandlers(tc.authZ.APIPath, pageTemplateService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "pagetemplates",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageTemplateService.AssertNumberOfCalls(t, "CreatePageTemplate", len(tc.createPageTemplateCalls))
+		})
+	}
+}
+
+type updatePageTemplateCall struct {
+	pageTemplateParams pagetemplateservice.UpdatePageTemplateParams
+	returnErr          error
+}
+
+func TestUpdatePageTemplate(t *testing.T) {
+	cases := []struct {
+		name                    string
+		pageTemplateID          string
+		headers                 map[string]string
+		requestBody             string
+		authN                   api.AuthN
+		authZ                   api.AuthZ
+		expectedResponseBody    string
+		expectedStatusCode      int
+		updatePageTemplateCalls []updatePageTemplateCall
+	}{
+		{
+			name:           "happy path",
+			pageTemplateID: "PGT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"Item\",\"summary\":\"An item\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			updatePageTemplateCalls: []updatePageTemplateCall{
+				{
+					pageTemplateParams: pagetemplateservice.UpdatePageTemplateParams{
+						PageTemplate: pagetemplate.PageTemplate{
+							GUID:       "PGT_1",
+							Name:       "Item",
+							Summary:    "An item",
+							Properties: []pagetemplate.TemplateProperty{},
+						},
+						UserID: "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name:           "not authorized",
+			pageTemplateID: "PGT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			requestBody:          "{\"name\":\"Item\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			updatePageTemplateCalls: []updatePageTemplateCall{
+				{
+					pageTemplateParams: pagetemplateservice.UpdatePageTemplateParams{
+						PageTemplate: pagetemplate.PageTemplate{
+							GUID:       "PGT_1",
+							Name:       "Item",
+							Properties: []pagetemplate.TemplateProperty{},
+						},
+						UserID: "UR_2",
+					},
+					returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "PGT_1"},
+				},
+			},
+		},
+		{
+			name:           "invalid property type",
+			pageTemplateID: "PGT_1",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"Item\",\"properties\":[{\"key\":\"weight\",\"type\":\"boolean\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide a valid type for property weight\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateService := new(mocks.PageTemplateService)
+			for index := range tc.updatePageTemplateCalls {
+				pageTemplateService.On("UpdatePageTemplate", mock.Anything, tc.updatePageTemplateCalls[index].pageTemplateParams).Return(tc.updatePageTemplateCalls[index].returnErr)
+			}
+			routerHandlers := PageTemplateRouterHandlers(tc.authZ.APIPath, pageTemplateService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPut,
+				Endpoint:       fmt.Sprintf("pagetemplates/%v", tc.pageTemplateID),
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageTemplateService.AssertNumberOfCalls(t, "UpdatePageTemplate", len(tc.updatePageTemplateCalls))
+		})
+	}
+}
+
+type disablePageTemplateCall struct {
+	pageTemplateParams pagetemplateservice.DisablePageTemplateParams
+	returnErr          error
+}
+
+type enablePageTemplateCall struct {
+	pageTemplateParams pagetemplateservice.EnablePageTemplateParams
+	returnErr          error
+}
+
+func TestDisableAndEnablePageTemplate(t *testing.T) {
+	cases := []struct {
+		name                     string
+		method                   string
+		headers                  map[string]string
+		authN                    api.AuthN
+		authZ                    api.AuthZ
+		expectedResponseBody     string
+		expectedStatusCode       int
+		disablePageTemplateCalls []disablePageTemplateCall
+		enablePageTemplateCalls  []enablePageTemplateCall
+	}{
+		{
+			name:   "disable",
+			method: http.MethodDelete,
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			disablePageTemplateCalls: []disablePageTemplateCall{
+				{
+					pageTemplateParams: pagetemplateservice.DisablePageTemplateParams{
+						PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+						UserID:       "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name:   "enable",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			enablePageTemplateCalls: []enablePageTemplateCall{
+				{
+					pageTemplateParams: pagetemplateservice.EnablePageTemplateParams{
+						PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+						UserID:       "UR_1",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateService := new(mocks.PageTemplateService)
+			for index := range tc.disablePageTemplateCalls {
+				pageTemplateService.On("DisablePageTemplate", mock.Anything, tc.disablePageTemplateCalls[index].pageTemplateParams).Return(tc.disablePageTemplateCalls[index].returnErr)
+			}
+			for index := range tc.enablePageTemplateCalls {
+				pageTemplateService.On("EnablePageTemplate", mock.Anything, tc.enablePageTemplateCalls[index].pageTemplateParams).Return(tc.enablePageTemplateCalls[index].returnErr)
+			}
+			routerHandlers := PageTemplateRouterHandlers(tc.authZ.APIPath, pageTemplateService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         tc.method,
+				Endpoint:       "pagetemplates/PGT_1",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageTemplateService.AssertNumberOfCalls(t, "DisablePageTemplate", len(tc.disablePageTemplateCalls))
+			pageTemplateService.AssertNumberOfCalls(t, "EnablePageTemplate", len(tc.enablePageTemplateCalls))
+		})
+	}
+}
+
+type getPageTemplatesCall struct {
+	pageTemplateParams  pagetemplateservice.GetPageTemplatesParams
+	returnPageTemplates []pagetemplate.PageTemplate
+	returnErr           error
+}
+
+func TestGetPageTemplates(t *testing.T) {
+	cases := []struct {
+		name                  string
+		headers               map[string]string
+		authN                 api.AuthN
+		authZ                 api.AuthZ
+		expectedResponseBody  string
+		expectedStatusCode    int
+		getPageTemplatesCalls []getPageTemplatesCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"name\":\"Place\",\"guid\":\"PGT_1\",\"summary\":\"A place\",\"properties\":[{\"key\":\"population\",\"type\":\"number\",\"required\":true},{\"key\":\"banner\",\"type\":\"string\",\"required\":false,\"default\":\"none\"}]}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPageTemplatesCalls: []getPageTemplatesCall{
+				{
+					pageTemplateParams: pagetemplateservice.GetPageTemplatesParams{UserID: "UR_1"},
+					returnPageTemplates: []pagetemplate.PageTemplate{
+						{
+							ID:      1,
+							GUID:    "PGT_1",
+							Name:    "Place",
+							Summary: "A place",
+							Properties: []pagetemplate.TemplateProperty{
+								{PropertyID: 1, Key: "population", Type: property.TypeNumber, Required: true},
+								{PropertyID: 2, Key: "banner", Type: property.TypeString, DefaultValue: "none"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateService := new(mocks.PageTemplateService)
+			for index := range tc.getPageTemplatesCalls {
+				pageTemplateService.On("GetPageTemplates", mock.Anything, tc.getPageTemplatesCalls[index].pageTemplateParams).Return(tc.getPageTemplatesCalls[index].returnPageTemplates, tc.getPageTemplatesCalls[index].returnErr)
+			}
+			routerHandlers := PageTemplateRouterHandlers(tc.authZ.APIPath, pageTemplateService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "pagetemplates",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			pageTemplateService.AssertNumberOfCalls(t, "GetPageTemplates", len(tc.getPageTemplatesCalls))
+		})
+	}
+}
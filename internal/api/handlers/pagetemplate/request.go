@@ -0,0 +1,146 @@
+package pagetemplatehandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// TemplatePropertyRequest a single declared property of a page template
+type TemplatePropertyRequest struct {
+	Key          string      `json:"key"`
+	TypeString   string      `json:"type"`
+	Required     bool        `json:"required"`
+	DefaultValue interface{} `json:"default"`
+}
+
+func getTemplateProperties(requests []TemplatePropertyRequest) ([]pagetemplate.TemplateProperty, error) {
+	templateProperties := make([]pagetemplate.TemplateProperty, 0, len(requests))
+	for i, request := range requests {
+		if request.Key == "" {
+			return templateProperties, errors.Errorf("must provide a key for the property at %v", i)
+		}
+		propertyType, err := property.GetPropertyType(request.TypeString)
+		if err != nil {
+			return templateProperties, errors.Errorf("must provide a valid type for property %v", request.Key)
+		}
+		templateProperty := pagetemplate.TemplateProperty{
+			Key:          request.Key,
+			Type:         propertyType,
+			Required:     request.Required,
+			DefaultValue: request.DefaultValue,
+		}
+		if !templateProperty.IsValidDefaultValue() {
+			return templateProperties, errors.Errorf("the default value of property %v must be of type %v", request.Key, propertyType)
+		}
+		templateProperties = append(templateProperties, templateProperty)
+	}
+	return templateProperties, nil
+}
+
+// CreatePageTemplateRequest parameters from the CreatePageTemplate call
+type CreatePageTemplateRequest struct {
+	Name               string                    `json:"name"`
+	Summary            string                    `json:"summary"`
+	PropertiesRequests []TemplatePropertyRequest `json:"properties"`
+	Properties         []pagetemplate.TemplateProperty
+}
+
+// NewCreatePageTemplateRequest extracts the CreatePageTemplateRequest
+func NewCreatePageTemplateRequest(r *http.Request, p httprouter.Params) (CreatePageTemplateRequest, error) {
+	var request CreatePageTemplateRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	return request.validate()
+}
+
+func (request CreatePageTemplateRequest) validate() (CreatePageTemplateRequest, error) {
+	if request.Name == "" {
+		return request, errors.New("must provide name")
+	}
+	templateProperties, err := getTemplateProperties(request.PropertiesRequests)
+	if err != nil {
+		return request, err
+	}
+	request.Properties = templateProperties
+	return request, nil
+}
+
+// UpdatePageTemplateRequest parameters from the UpdatePageTemplate call
+type UpdatePageTemplateRequest struct {
+	PageTemplateID     string
+	Name               string                    `json:"name"`
+	Summary            string                    `json:"summary"`
+	PropertiesRequests []TemplatePropertyRequest `json:"properties"`
+	Properties         []pagetemplate.TemplateProperty
+}
+
+// NewUpdatePageTemplateRequest extracts the UpdatePageTemplateRequest
+func NewUpdatePageTemplateRequest(r *http.Request, p httprouter.Params) (UpdatePageTemplateRequest, error) {
+	var request UpdatePageTemplateRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.PageTemplateID = p.ByName(PageTemplateIDRouteKey)
+	return request.validate()
+}
+
+func (request UpdatePageTemplateRequest) validate() (UpdatePageTemplateRequest, error) {
+	if request.PageTemplateID == "" {
+		return request, errors.New("must provide a page template id")
+	}
+	if request.Name == "" {
+		return request, errors.New("a page template must retain a name")
+	}
+	templateProperties, err := getTemplateProperties(request.PropertiesRequests)
+	if err != nil {
+		return request, err
+	}
+	request.Properties = templateProperties
+	return request, nil
+}
+
+// DisablePageTemplateRequest parameters from the DisablePageTemplate call
+type DisablePageTemplateRequest struct {
+	PageTemplateID string
+}
+
+// NewDisablePageTemplateRequest extracts the DisablePageTemplateRequest
+func NewDisablePageTemplateRequest(r *http.Request, p httprouter.Params) (DisablePageTemplateRequest, error) {
+	var request DisablePageTemplateRequest
+	request.PageTemplateID = p.ByName(PageTemplateIDRouteKey)
+	return request.validate()
+}
+
+func (request DisablePageTemplateRequest) validate() (DisablePageTemplateRequest, error) {
+	if request.PageTemplateID == "" {
+		return request, errors.New("must provide a page template id")
+	}
+	return request, nil
+}
+
+// EnablePageTemplateRequest parameters from the EnablePageTemplate call
+type EnablePageTemplateRequest struct {
+	PageTemplateID string
+}
+
+// NewEnablePageTemplateRequest extracts the EnablePageTemplateRequest
+func NewEnablePageTemplateRequest(r *http.Request, p httprouter.Params) (EnablePageTemplateRequest, error) {
+	var request EnablePageTemplateRequest
+	request.PageTemplateID = p.ByName(PageTemplateIDRouteKey)
+	return request.validate()
+}
+
+func (request EnablePageTemplateRequest) validate() (EnablePageTemplateRequest, error) {
+	if request.PageTemplateID == "" {
+		return request, errors.New("must provide a page template id")
+	}
+	return request, nil
+}
@@ -0,0 +1,47 @@
+package pagetemplatehandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+)
+
+// HTTP path fragments keys
+const (
+	PageTemplateIDRouteKey = "pageTemplateID"
+)
+
+// PageTemplateRouterHandlers returns the requests for the associated routes.
+func PageTemplateRouterHandlers(apiPath string, pageTemplateService PageTemplateService) []api.RouterHandler {
+	handler := PageTemplateHandler{
+		PageTemplateService: pageTemplateService,
+	}
+	var routerHandlers []api.RouterHandler
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/pagetemplates", apiPath),
+		Handle:   handler.CreatePageTemplate,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPut,
+		Endpoint: fmt.Sprintf("/%v/pagetemplates/:%v", apiPath, PageTemplateIDRouteKey),
+		Handle:   handler.UpdatePageTemplate,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodDelete,
+		Endpoint: fmt.Sprintf("/%v/pagetemplates/:%v", apiPath, PageTemplateIDRouteKey),
+		Handle:   handler.DisablePageTemplate,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/pagetemplates/:%v", apiPath, PageTemplateIDRouteKey),
+		Handle:   handler.EnablePageTemplate,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pagetemplates", apiPath),
+		Handle:   handler.GetPageTemplates,
+	})
+	return routerHandlers
+}
@@ -0,0 +1,174 @@
+package propertyhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	propertyservice "github.com/Pergamene/project-spiderweb-service/internal/services/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// PropertyService see Service for more details
+type PropertyService interface {
+	CreateProperty(ctx context.Context, params propertyservice.CreatePropertyParams) (property.Property, error)
+	UpdateProperty(ctx context.Context, params propertyservice.UpdatePropertyParams) error
+	DisableProperty(ctx context.Context, params propertyservice.DisablePropertyParams) error
+	EnableProperty(ctx context.Context, params propertyservice.EnablePropertyParams) error
+	GetProperties(ctx context.Context, params propertyservice.GetPropertiesParams) ([]property.Property, error)
+}
+
+// PropertyHandler is the handler for the associated API
+type PropertyHandler struct {
+	PropertyService PropertyService
+}
+
+// CreateProperty see Service for more details
+func (h PropertyHandler) CreateProperty(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewCreatePropertyRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	_, err = h.PropertyService.CreateProperty(ctx, propertyservice.CreatePropertyParams{
+		Property: property.Property{
+			Key:  request.Key,
+			Type: request.Type,
+		},
+		OwnerID: authData.UserID,
+	})
+	if castErr, ok := err.(*storeerror.DupEntry); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// UpdateProperty see Service for more details
+func (h PropertyHandler) UpdateProperty(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewUpdatePropertyRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PropertyService.UpdateProperty(ctx, propertyservice.UpdatePropertyParams{
+		Key: request.OriginalKey,
+		Property: property.Property{
+			Key:  request.Key,
+			Type: request.Type,
+		},
+		UserID: authData.UserID,
+	})
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := err.(*storeerror.DupEntry); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// DisableProperty see Service for more details
+func (h PropertyHandler) DisableProperty(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewDisablePropertyRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PropertyService.DisableProperty(ctx, propertyservice.DisablePropertyParams{
+		Key:    request.Key,
+		UserID: authData.UserID,
+	})
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// EnableProperty see Service for more details
+func (h PropertyHandler) EnableProperty(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewEnablePropertyRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.PropertyService.EnableProperty(ctx, propertyservice.EnablePropertyParams{
+		Key:    request.Key,
+		UserID: authData.UserID,
+	})
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// GetProperties see Service for more details
+func (h PropertyHandler) GetProperties(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.PropertyService.GetProperties(ctx, propertyservice.GetPropertiesParams{
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, records, nil)
+}
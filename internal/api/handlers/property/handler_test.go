@@ -0,0 +1,393 @@
+package propertyhandler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	propertyservice "github.com/Pergamene/project-spiderweb-service/internal/services/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/property/mocks"
+)
+
+type createPropertyCall struct {
+	propertyParams propertyservice.CreatePropertyParams
+	returnRecord   property.Property
+	returnErr      error
+}
+
+func TestCreateProperty(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		requestBody          string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		createPropertyCalls  []createPropertyCall
+	}{
+		{
+			name:                 "not authenticated",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"key\":\"population\",\"type\":\"number\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			createPropertyCalls: []createPropertyCall{
+				{
+					propertyParams: propertyservice.CreatePropertyParams{
+						Property: property.Property{Key: "population", Type: property.TypeNumber},
+						OwnerID:  "UR_1",
+					},
+					returnRecord: property.Property{ID: 1, Key: "population", Type: property.TypeNumber},
+				},
+			},
+		},
+		{
+			name: "invalid type",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"key\":\"population\",\"type\":\"boolean\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide a valid type\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "missing key",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"type\":\"string\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide key\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "duplicate key",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"key\":\"population\",\"type\":\"number\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"Duplicate id: population\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			createPropertyCalls: []createPropertyCall{
+				{
+					propertyParams: propertyservice.CreatePropertyParams{
+						Property: property.Property{Key: "population", Type: property.TypeNumber},
+						OwnerID:  "UR_1",
+					},
+					returnErr: &storeerror.DupEntry{ID: "population"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyService := new(mocks.PropertyService)
+			for index := range tc.createPropertyCalls {
+				propertyService.On("CreateProperty", mock.Anything, tc.createPropertyCalls[index].propertyParams).Return(tc.createPropertyCalls[index].returnRecord, tc.createPropertyCalls[index].returnErr)
+			}
+			routerHandlers := PropertyRouterHandlers(tc.authZ.APIPath, propertyService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "properties",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			propertyService.AssertNumberOfCalls(t, "CreateProperty", len(tc.createPropertyCalls))
+		})
+	}
+}
+
+type updatePropertyCall struct {
+	propertyParams propertyservice.UpdatePropertyParams
+	returnErr      error
+}
+
+func TestUpdateProperty(t *testing.T) {
+	cases := []struct {
+		name                 string
+		key                  string
+		headers              map[string]string
+		requestBody          string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		updatePropertyCalls  []updatePropertyCall
+	}{
+		{
+			name: "happy path, renamed",
+			key:  "population",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"key\":\"citizens\",\"type\":\"number\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			updatePropertyCalls: []updatePropertyCall{
+				{
+					propertyParams: propertyservice.UpdatePropertyParams{
+						Key:      "population",
+						Property: property.Property{Key: "citizens", Type: property.TypeNumber},
+						UserID:   "UR_1",
+					},
+				},
+			},
+		},
+		{
+			name: "type change while in use",
+			key:  "population",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"key\":\"population\",\"type\":\"string\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"cannot change the type of property population while pages use it\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			updatePropertyCalls: []updatePropertyCall{
+				{
+					propertyParams: propertyservice.UpdatePropertyParams{
+						Key:      "population",
+						Property: property.Property{Key: "population", Type: property.TypeString},
+						UserID:   "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "cannot change the type of property population while pages use it"},
+				},
+			},
+		},
+		{
+			name: "not found",
+			key:  "population",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"key\":\"population\",\"type\":\"string\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: population\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			updatePropertyCalls: []updatePropertyCall{
+				{
+					propertyParams: propertyservice.UpdatePropertyParams{
+						Key:      "population",
+						Property: property.Property{Key: "population", Type: property.TypeString},
+						UserID:   "UR_1",
+					},
+					returnErr: errors.Wrap(&storeerror.NotFound{ID: "population"}, "failed to get property"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyService := new(mocks.PropertyService)
+			for index := range tc.updatePropertyCalls {
+				propertyService.On("UpdateProperty", mock.Anything, tc.updatePropertyCalls[index].propertyParams).Return(tc.updatePropertyCalls[index].returnErr)
+			}
+			routerHandlers := PropertyRouterHandlers(tc.authZ.APIPath, propertyService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPatch,
+				Endpoint:       fmt.Sprintf("properties/%v", tc.key),
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			propertyService.AssertNumberOfCalls(t, "UpdateProperty", len(tc.updatePropertyCalls))
+		})
+	}
+}
+
+type disablePropertyCall struct {
+	propertyParams propertyservice.DisablePropertyParams
+	returnErr      error
+}
+
+type enablePropertyCall struct {
+	propertyParams propertyservice.EnablePropertyParams
+	returnErr      error
+}
+
+func TestDisableAndEnableProperty(t *testing.T) {
+	cases := []struct {
+		name                 string
+		method               string
+		key                  string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		disablePropertyCalls []disablePropertyCall
+		enablePropertyCalls  []enablePropertyCall
+	}{
+		{
+			name:   "disable",
+			method: http.MethodDelete,
+			key:    "population",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			disablePropertyCalls: []disablePropertyCall{
+				{propertyParams: propertyservice.DisablePropertyParams{Key: "population", UserID: "UR_1"}},
+			},
+		},
+		{
+			name:   "enable",
+			method: http.MethodPost,
+			key:    "population",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			enablePropertyCalls: []enablePropertyCall{
+				{propertyParams: propertyservice.EnablePropertyParams{Key: "population", UserID: "UR_1"}},
+			},
+		},
+		{
+			name:   "enable unknown property",
+			method: http.MethodPost,
+			key:    "population",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: population\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			enablePropertyCalls: []enablePropertyCall{
+				{
+					propertyParams: propertyservice.EnablePropertyParams{Key: "population", UserID: "UR_1"},
+					returnErr:      errors.Wrap(&storeerror.NotFound{ID: "population"}, "failed to get property"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyService := new(mocks.PropertyService)
+			for index := range tc.disablePropertyCalls {
+				propertyService.On("DisableProperty", mock.Anything, tc.disablePropertyCalls[index].propertyParams).Return(tc.disablePropertyCalls[index].returnErr)
+			}
+			for index := range tc.enablePropertyCalls {
+				propertyService.On("EnableProperty", mock.Anything, tc.enablePropertyCalls[index].propertyParams).Return(tc.enablePropertyCalls[index].returnErr)
+			}
+			routerHandlers := PropertyRouterHandlers(tc.authZ.APIPath, propertyService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         tc.method,
+				Endpoint:       fmt.Sprintf("properties/%v", tc.key),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			propertyService.AssertNumberOfCalls(t, "DisableProperty", len(tc.disablePropertyCalls))
+			propertyService.AssertNumberOfCalls(t, "EnableProperty", len(tc.enablePropertyCalls))
+		})
+	}
+}
+
+type getPropertiesCall struct {
+	propertyParams   propertyservice.GetPropertiesParams
+	returnProperties []property.Property
+	returnErr        error
+}
+
+func TestGetProperties(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		getPropertiesCalls   []getPropertiesCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"key\":\"banner\",\"type\":\"string\"},{\"key\":\"population\",\"type\":\"number\"}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getPropertiesCalls: []getPropertiesCall{
+				{
+					propertyParams: propertyservice.GetPropertiesParams{UserID: "UR_1"},
+					returnProperties: []property.Property{
+						{ID: 2, Key: "banner", Type: property.TypeString},
+						{ID: 1, Key: "population", Type: property.TypeNumber},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyService := new(mocks.PropertyService)
+			for index := range tc.getPropertiesCalls {
+				propertyService.On("GetProperties", mock.Anything, tc.getPropertiesCalls[index].propertyParams).Return(tc.getPropertiesCalls[index].returnProperties, tc.getPropertiesCalls[index].returnErr)
+			}
+			routerHandlers := PropertyRouterHandlers(tc.authZ.APIPath, propertyService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "properties",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			propertyService.AssertNumberOfCalls(t, "GetProperties", len(tc.getPropertiesCalls))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package propertyhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// CreatePropertyRequest parameters from the CreateProperty call
+type CreatePropertyRequest struct {
+	Key        string `json:"key"`
+	TypeString string `json:"type"`
+	Type       property.Type
+}
+
+// NewCreatePropertyRequest extracts the CreatePropertyRequest
+func NewCreatePropertyRequest(r *http.Request, p httprouter.Params) (CreatePropertyRequest, error) {
+	var request CreatePropertyRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	return request.validate()
+}
+
+func (request CreatePropertyRequest) validate() (CreatePropertyRequest, error) {
+	if request.Key == "" {
+		return request, errors.New("must provide key")
+	}
+	propertyType, err := property.GetPropertyType(request.TypeString)
+	if err != nil {
+		return request, errors.New("must provide a valid type")
+	}
+	request.Type = propertyType
+	return request, nil
+}
+
+// UpdatePropertyRequest parameters from the UpdateProperty call
+type UpdatePropertyRequest struct {
+	OriginalKey string
+	Key         string `json:"key"`
+	TypeString  string `json:"type"`
+	Type        property.Type
+}
+
+// NewUpdatePropertyRequest extracts the UpdatePropertyRequest
+func NewUpdatePropertyRequest(r *http.Request, p httprouter.Params) (UpdatePropertyRequest, error) {
+	var request UpdatePropertyRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.OriginalKey = p.ByName(PropertyIDRouteKey)
+	return request.validate()
+}
+
+func (request UpdatePropertyRequest) validate() (UpdatePropertyRequest, error) {
+	if request.OriginalKey == "" {
+		return request, errors.New("must provide a property key")
+	}
+	if request.Key == "" {
+		return request, errors.New("a property must retain a key")
+	}
+	propertyType, err := property.GetPropertyType(request.TypeString)
+	if err != nil {
+		return request, errors.New("must provide a valid type")
+	}
+	request.Type = propertyType
+	return request, nil
+}
+
+// DisablePropertyRequest parameters from the DisableProperty call
+type DisablePropertyRequest struct {
+	Key string
+}
+
+// NewDisablePropertyRequest extracts the DisablePropertyRequest
+func NewDisablePropertyRequest(r *http.Request, p httprouter.Params) (DisablePropertyRequest, error) {
+	var request DisablePropertyRequest
+	request.Key = p.ByName(PropertyIDRouteKey)
+	return request.validate()
+}
+
+func (request DisablePropertyRequest) validate() (DisablePropertyRequest, error) {
+	if request.Key == "" {
+		return request, errors.New("must provide a property key")
+	}
+	return request, nil
+}
+
+// EnablePropertyRequest parameters from the EnableProperty call
+type EnablePropertyRequest struct {
+	Key string
+}
+
+// NewEnablePropertyRequest extracts the EnablePropertyRequest
+func NewEnablePropertyRequest(r *http.Request, p httprouter.Params) (EnablePropertyRequest, error) {
+	var request EnablePropertyRequest
+	request.Key = p.ByName(PropertyIDRouteKey)
+	return request.validate()
+}
+
+func (request EnablePropertyRequest) validate() (EnablePropertyRequest, error) {
+	if request.Key == "" {
+		return request, errors.New("must provide a property key")
+	}
+	return request, nil
+}
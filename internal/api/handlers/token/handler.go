@@ -0,0 +1,46 @@
+package tokenhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	tokenservice "github.com/Pergamene/project-spiderweb-service/internal/services/token"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/usertoken"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// TokenHandler is the handler for the associated API
+type TokenHandler struct {
+	TokenService TokenService
+}
+
+// TokenService see Service for more details
+type TokenService interface {
+	IssueToken(ctx context.Context, params tokenservice.IssueTokenParams) (usertoken.Token, error)
+}
+
+// IssueToken see Service for more details
+// Tokens are only ever issued to the user asking for one, so that an admin cannot mint a token for someone else.
+func (h TokenHandler) IssueToken(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	token, err := h.TokenService.IssueToken(ctx, tokenservice.IssueTokenParams{
+		UserID: authData.UserID,
+	})
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, token, nil)
+}
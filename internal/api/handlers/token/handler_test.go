@@ -0,0 +1,172 @@
+package tokenhandler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	tokenservice "github.com/Pergamene/project-spiderweb-service/internal/services/token"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/usertoken"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/token/mocks"
+)
+
+type issueTokenCall struct {
+	tokenParams tokenservice.IssueTokenParams
+	returnToken usertoken.Token
+	returnErr   error
+}
+
+func TestIssueToken(t *testing.T) {
+	userToken, err := handlertestutils.DefaultUserTokens().Sign("UR_1")
+	require.NoError(t, err)
+	cases := []struct {
+		name                 string
+		body                 string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		issueTokenCalls      []issueTokenCall
+	}{
+		{
+			name:                 "not authenticated",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "invalid bearer token",
+			headers: map[string]string{
+				"Authorization": "Bearer " + userToken.Token + "x",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "admin secret cannot obtain another user's token",
+			body: "{\"userId\":\"UR_2\"}",
+			headers: map[string]string{
+				"X-ADMIN-AUTH-SECRET": "SECRET",
+			},
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"403 - Forbidden\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   403,
+		},
+		{
+			name: "admin secret cannot act as a user when proxy users are disabled",
+			headers: map[string]string{
+				"X-ADMIN-AUTH-SECRET": "SECRET",
+				"X-USER-ID":           "UR_2",
+			},
+			authN: func() api.AuthN {
+				authN := handlertestutils.DefaultAuthN("PROD")
+				authN.DisableProxyUsers = true
+				return authN
+			}(),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "user with a bearer token gets their own token",
+			body: "{\"userId\":\"UR_2\"}",
+			headers: map[string]string{
+				"Authorization": "Bearer " + userToken.Token,
+			},
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"token\":\"TOKEN\",\"expiresAt\":\"2020-03-01T01:00:00Z\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			issueTokenCalls: []issueTokenCall{
+				{
+					tokenParams: tokenservice.IssueTokenParams{UserID: "UR_1"},
+					returnToken: usertoken.Token{Token: "TOKEN", ExpiresAt: time.Date(2020, time.March, 1, 1, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+		{
+			name: "unknown user",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: UR_2\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			issueTokenCalls: []issueTokenCall{
+				{
+					tokenParams: tokenservice.IssueTokenParams{UserID: "UR_2"},
+					returnErr:   &storeerror.NotFound{ID: "UR_2"},
+				},
+			},
+		},
+		{
+			name: "service failure",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"500 - Internal Server Error\",\"message\":\"internal server error\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   500,
+			issueTokenCalls: []issueTokenCall{
+				{
+					tokenParams: tokenservice.IssueTokenParams{UserID: "UR_1"},
+					returnErr:   errors.New("failure"),
+				},
+			},
+		},
+		{
+			name: "proxy user gets their own token",
+			headers: map[string]string{
+				"X-ADMIN-AUTH-SECRET": "SECRET",
+				"X-USER-ID":           "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"token\":\"TOKEN\",\"expiresAt\":\"2020-03-01T01:00:00Z\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			issueTokenCalls: []issueTokenCall{
+				{
+					tokenParams: tokenservice.IssueTokenParams{UserID: "UR_1"},
+					returnToken: usertoken.Token{Token: "TOKEN", ExpiresAt: time.Date(2020, time.March, 1, 1, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenService := new(mocks.TokenService)
+			for index := range tc.issueTokenCalls {
+				tokenService.On("IssueToken", mock.Anything, tc.issueTokenCalls[index].tokenParams).Return(tc.issueTokenCalls[index].returnToken, tc.issueTokenCalls[index].returnErr)
+			}
+			routerHandlers := TokenRouterHandlers(tc.authZ.APIPath, tokenService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "tokens",
+				Headers:        tc.headers,
+				Body:           strings.NewReader(tc.body),
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			tokenService.AssertNumberOfCalls(t, "IssueToken", len(tc.issueTokenCalls))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package tokenhandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+)
+
+// TokenRouterHandlers returns the requests for the associated routes.
+func TokenRouterHandlers(apiPath string, tokenService TokenService) []api.RouterHandler {
+	handler := TokenHandler{
+		TokenService: tokenService,
+	}
+	var routerHandlers []api.RouterHandler
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/tokens", apiPath),
+		Handle:   handler.IssueToken,
+		Access:   api.AccessUser,
+		Secret:   true,
+	})
+	return routerHandlers
+}
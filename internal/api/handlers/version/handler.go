@@ -0,0 +1,341 @@
+package versionhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	versionservice "github.com/Pergamene/project-spiderweb-service/internal/services/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// VersionService see Service for more details
+type VersionService interface {
+	CreateVersion(ctx context.Context, params versionservice.CreateVersionParams) (version.Version, error)
+	GetVersion(ctx context.Context, params versionservice.GetVersionParams) (version.Version, error)
+	GetVersions(ctx context.Context, params versionservice.GetVersionsParams) ([]version.Version, error)
+	UpdateVersion(ctx context.Context, params versionservice.UpdateVersionParams) error
+	RemoveVersion(ctx context.Context, params versionservice.RemoveVersionParams) error
+	GetVersionAncestry(ctx context.Context, params versionservice.GetVersionAncestryParams) ([]version.Version, error)
+	ForkPages(ctx context.Context, params versionservice.ForkPagesParams) ([]version.PageFork, error)
+	DiffPage(ctx context.Context, params versionservice.DiffPageParams) (pagediff.Diff, error)
+	MergePage(ctx context.Context, params versionservice.MergePageParams) (pagediff.Diff, error)
+}
+
+// VersionHandler is the handler for the associated API
+type VersionHandler struct {
+	VersionService VersionService
+}
+
+// CreateVersion see Service for more details
+func (h VersionHandler) CreateVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewCreateVersionRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.VersionService.CreateVersion(ctx, versionservice.CreateVersionParams{
+		Version: version.Version{
+			Name:       request.Name,
+			ParentGUID: request.ParentID,
+		},
+		OwnerID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := err.(*storeerror.DupEntry); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, map[string]string{"id": record.GUID}, nil)
+}
+
+// GetVersion see Service for more details
+func (h VersionHandler) GetVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetVersionRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.VersionService.GetVersion(ctx, versionservice.GetVersionParams{
+		Version: version.Version{
+			GUID: request.VersionID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, record, nil)
+}
+
+// GetVersions see Service for more details
+func (h VersionHandler) GetVersions(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.VersionService.GetVersions(ctx, versionservice.GetVersionsParams{
+		UserID: authData.UserID,
+	})
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, records, nil)
+}
+
+// UpdateVersion see Service for more details
+func (h VersionHandler) UpdateVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewUpdateVersionRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.VersionService.UpdateVersion(ctx, versionservice.UpdateVersionParams{
+		Version: version.Version{
+			GUID: request.VersionID,
+			Name: request.Name,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// RemoveVersion see Service for more details
+func (h VersionHandler) RemoveVersion(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewRemoveVersionRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	err = h.VersionService.RemoveVersion(ctx, versionservice.RemoveVersionParams{
+		Version: version.Version{
+			GUID: request.VersionID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, nil, nil)
+}
+
+// GetVersionAncestry see Service for more details
+func (h VersionHandler) GetVersionAncestry(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewGetVersionRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.VersionService.GetVersionAncestry(ctx, versionservice.GetVersionAncestryParams{
+		Version: version.Version{
+			GUID: request.VersionID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, records, nil)
+}
+
+// ForkPages see Service for more details
+func (h VersionHandler) ForkPages(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewForkPagesRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	records, err := h.VersionService.ForkPages(ctx, versionservice.ForkPagesParams{
+		Version: version.Version{
+			GUID: request.VersionID,
+		},
+		PageIDs: request.PageIDs,
+		UserID:  authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, records, nil)
+}
+
+// DiffPage see Service for more details
+func (h VersionHandler) DiffPage(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewDiffPageRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.VersionService.DiffPage(ctx, versionservice.DiffPageParams{
+		Page: page.Page{
+			GUID: request.PageID,
+		},
+		UserID: authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, record.GetJSONConformed(), nil)
+}
+
+// MergePage see Service for more details
+func (h VersionHandler) MergePage(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	request, err := NewMergePageRequest(r, p)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusBadRequest, err, err)
+		return
+	}
+	ctx := r.Context()
+	authData, err := api.GetDataFromContext(ctx)
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, errors.Wrap(err, "failed to get auth data"))
+		return
+	}
+	record, err := h.VersionService.MergePage(ctx, versionservice.MergePageParams{
+		Page: page.Page{
+			GUID: request.PageID,
+		},
+		Selections: request.Selections,
+		UserID:     authData.UserID,
+	})
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		api.RespondWith(r, w, http.StatusUnauthorized, &api.FailedAuthorization{}, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		api.RespondWith(r, w, http.StatusNotFound, castErr, err)
+		return
+	}
+	if castErr, ok := errors.Cause(err).(*serviceerror.InvalidRequest); ok {
+		api.RespondWith(r, w, http.StatusBadRequest, castErr, err)
+		return
+	}
+	if err != nil {
+		api.RespondWith(r, w, http.StatusInternalServerError, &api.InternalErr{}, err)
+		return
+	}
+	api.RespondWith(r, w, http.StatusOK, record.GetJSONConformed(), nil)
+}
@@ -0,0 +1,570 @@
+package versionhandler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	versionservice "github.com/Pergamene/project-spiderweb-service/internal/services/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/handlertestutils"
+	"github.com/Pergamene/project-spiderweb-service/internal/api/handlers/version/mocks"
+)
+
+type createVersionCall struct {
+	versionParams versionservice.CreateVersionParams
+	returnRecord  version.Version
+	returnErr     error
+}
+
+func TestCreateVersion(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		requestBody          string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		createVersionCalls   []createVersionCall
+	}{
+		{
+			name:                 "not authenticated",
+			authN:                handlertestutils.DefaultAuthN("PROD"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authenticated\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+		},
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"New Campaign Changes\",\"parentId\":\"VR_1\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"VR_2\"},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			createVersionCalls: []createVersionCall{
+				{
+					versionParams: versionservice.CreateVersionParams{
+						Version: version.Version{Name: "New Campaign Changes", ParentGUID: "VR_1"},
+						OwnerID: "UR_1",
+					},
+					returnRecord: version.Version{ID: 2, GUID: "VR_2", Name: "New Campaign Changes", ParentGUID: "VR_1"},
+				},
+			},
+		},
+		{
+			name: "missing name",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"parentId\":\"VR_1\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide name\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "parent does not exist",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"name\":\"New Campaign Changes\",\"parentId\":\"VR_9\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"parent version VR_9 does not exist\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			createVersionCalls: []createVersionCall{
+				{
+					versionParams: versionservice.CreateVersionParams{
+						Version: version.Version{Name: "New Campaign Changes", ParentGUID: "VR_9"},
+						OwnerID: "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "parent version VR_9 does not exist"},
+				},
+			},
+		},
+		{
+			name: "parent owned by another user",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			requestBody:          "{\"name\":\"New Campaign Changes\",\"parentId\":\"VR_1\"}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			createVersionCalls: []createVersionCall{
+				{
+					versionParams: versionservice.CreateVersionParams{
+						Version: version.Version{Name: "New Campaign Changes", ParentGUID: "VR_1"},
+						OwnerID: "UR_2",
+					},
+					returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_1"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionService := new(mocks.VersionService)
+			for index := range tc.createVersionCalls {
+				versionService.On("CreateVersion", mock.Anything, tc.createVersionCalls[index].versionParams).Return(tc.createVersionCalls[index].returnRecord, tc.createVersionCalls[index].returnErr)
+			}
+			routerHandlers := VersionRouterHandlers(tc.authZ.APIPath, versionService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "versions",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			versionService.AssertNumberOfCalls(t, "CreateVersion", len(tc.createVersionCalls))
+		})
+	}
+}
+
+type getVersionAncestryCall struct {
+	versionParams versionservice.GetVersionAncestryParams
+	returnRecords []version.Version
+	returnErr     error
+}
+
+func TestGetVersionAncestry(t *testing.T) {
+	cases := []struct {
+		name                    string
+		headers                 map[string]string
+		authN                   api.AuthN
+		authZ                   api.AuthZ
+		expectedResponseBody    string
+		expectedStatusCode      int
+		getVersionAncestryCalls []getVersionAncestryCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"id\":\"VR_1\",\"name\":\"Default\",\"parentId\":\"\"}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			getVersionAncestryCalls: []getVersionAncestryCall{
+				{
+					versionParams: versionservice.GetVersionAncestryParams{
+						Version: version.Version{GUID: "VR_2"},
+						UserID:  "UR_1",
+					},
+					returnRecords: []version.Version{{ID: 1, GUID: "VR_1", Name: "Default"}},
+				},
+			},
+		},
+		{
+			name: "not found",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"404 - Not Found\",\"message\":\"Could not find: VR_2\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   404,
+			getVersionAncestryCalls: []getVersionAncestryCall{
+				{
+					versionParams: versionservice.GetVersionAncestryParams{
+						Version: version.Version{GUID: "VR_2"},
+						UserID:  "UR_1",
+					},
+					returnErr: &storeerror.NotFound{ID: "VR_2"},
+				},
+			},
+		},
+		{
+			name: "not the owner of the version",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			getVersionAncestryCalls: []getVersionAncestryCall{
+				{
+					versionParams: versionservice.GetVersionAncestryParams{
+						Version: version.Version{GUID: "VR_2"},
+						UserID:  "UR_2",
+					},
+					returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_2"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionService := new(mocks.VersionService)
+			for index := range tc.getVersionAncestryCalls {
+				versionService.On("GetVersionAncestry", mock.Anything, tc.getVersionAncestryCalls[index].versionParams).Return(tc.getVersionAncestryCalls[index].returnRecords, tc.getVersionAncestryCalls[index].returnErr)
+			}
+			routerHandlers := VersionRouterHandlers(tc.authZ.APIPath, versionService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "versions/VR_2/ancestry",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			versionService.AssertNumberOfCalls(t, "GetVersionAncestry", len(tc.getVersionAncestryCalls))
+		})
+	}
+}
+
+type forkPagesCall struct {
+	forkParams    versionservice.ForkPagesParams
+	returnRecords []version.PageFork
+	returnErr     error
+}
+
+func TestForkPages(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		requestBody          string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		forkPagesCalls       []forkPagesCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"pageIds\":[\"PG_1\"]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":[{\"sourceId\":\"PG_1\",\"id\":\"PG_2\"}],\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			forkPagesCalls: []forkPagesCall{
+				{
+					forkParams: versionservice.ForkPagesParams{
+						Version: version.Version{GUID: "VR_2"},
+						PageIDs: []string{"PG_1"},
+						UserID:  "UR_1",
+					},
+					returnRecords: []version.PageFork{{SourcePageGUID: "PG_1", PageGUID: "PG_2", DetailGUIDs: map[string]string{"DT_2": "DT_1"}}},
+				},
+			},
+		},
+		{
+			name: "no pages",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"pageIds\":[]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide at least one page id\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "not the owner of the version",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			requestBody:          "{\"pageIds\":[\"PG_1\"]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			forkPagesCalls: []forkPagesCall{
+				{
+					forkParams: versionservice.ForkPagesParams{
+						Version: version.Version{GUID: "VR_2"},
+						PageIDs: []string{"PG_1"},
+						UserID:  "UR_2",
+					},
+					returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_2"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionService := new(mocks.VersionService)
+			for index := range tc.forkPagesCalls {
+				versionService.On("ForkPages", mock.Anything, tc.forkPagesCalls[index].forkParams).Return(tc.forkPagesCalls[index].returnRecords, tc.forkPagesCalls[index].returnErr)
+			}
+			routerHandlers := VersionRouterHandlers(tc.authZ.APIPath, versionService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "versions/VR_2/forks",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			versionService.AssertNumberOfCalls(t, "ForkPages", len(tc.forkPagesCalls))
+		})
+	}
+}
+
+type diffPageCall struct {
+	diffParams versionservice.DiffPageParams
+	returnDiff pagediff.Diff
+	returnErr  error
+}
+
+func TestDiffPage(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		diffPageCalls        []diffPageCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"PG_2\",\"parentId\":\"PG_1\",\"changes\":[{\"target\":\"title\",\"status\":\"conflict\",\"base\":\"Barovia\",\"parent\":\"Barovia Valley\",\"child\":\"Valley of Barovia\"}]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			diffPageCalls: []diffPageCall{
+				{
+					diffParams: versionservice.DiffPageParams{
+						Page:   page.Page{GUID: "PG_2"},
+						UserID: "UR_1",
+					},
+					returnDiff: pagediff.Diff{
+						PageGUID:       "PG_2",
+						ParentPageGUID: "PG_1",
+						Changes: []pagediff.Change{
+							{Target: pagediff.TargetTitle, Status: pagediff.StatusConflict, Base: "Barovia", Parent: "Barovia Valley", Child: "Valley of Barovia"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no changes",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"PG_2\",\"parentId\":\"PG_1\",\"changes\":[]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			diffPageCalls: []diffPageCall{
+				{
+					diffParams: versionservice.DiffPageParams{
+						Page:   page.Page{GUID: "PG_2"},
+						UserID: "UR_1",
+					},
+					returnDiff: pagediff.Diff{PageGUID: "PG_2", ParentPageGUID: "PG_1"},
+				},
+			},
+		},
+		{
+			name: "page not forked",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"page PG_2 was not forked from a parent version\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			diffPageCalls: []diffPageCall{
+				{
+					diffParams: versionservice.DiffPageParams{
+						Page:   page.Page{GUID: "PG_2"},
+						UserID: "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "page PG_2 was not forked from a parent version"},
+				},
+			},
+		},
+		{
+			name: "not able to read the page",
+			headers: map[string]string{
+				"X-USER-ID": "UR_2",
+			},
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"401 - Unauthorized\",\"message\":\"not authorized\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   401,
+			diffPageCalls: []diffPageCall{
+				{
+					diffParams: versionservice.DiffPageParams{
+						Page:   page.Page{GUID: "PG_2"},
+						UserID: "UR_2",
+					},
+					returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "PG_2"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionService := new(mocks.VersionService)
+			for index := range tc.diffPageCalls {
+				versionService.On("DiffPage", mock.Anything, tc.diffPageCalls[index].diffParams).Return(tc.diffPageCalls[index].returnDiff, tc.diffPageCalls[index].returnErr)
+			}
+			routerHandlers := VersionRouterHandlers(tc.authZ.APIPath, versionService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodGet,
+				Endpoint:       "pages/PG_2/diff",
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			versionService.AssertNumberOfCalls(t, "DiffPage", len(tc.diffPageCalls))
+		})
+	}
+}
+
+type mergePageCall struct {
+	mergeParams versionservice.MergePageParams
+	returnDiff  pagediff.Diff
+	returnErr   error
+}
+
+func TestMergePage(t *testing.T) {
+	cases := []struct {
+		name                 string
+		headers              map[string]string
+		requestBody          string
+		authN                api.AuthN
+		authZ                api.AuthZ
+		expectedResponseBody string
+		expectedStatusCode   int
+		mergePageCalls       []mergePageCall
+	}{
+		{
+			name: "happy path",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"changes\":[{\"target\":\"title\",\"side\":\"child\"},{\"target\":\"property\",\"key\":\"population\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"result\":{\"id\":\"PG_2\",\"parentId\":\"PG_1\",\"changes\":[]},\"meta\":{\"httpStatus\":\"200 - OK\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   200,
+			mergePageCalls: []mergePageCall{
+				{
+					mergeParams: versionservice.MergePageParams{
+						Page: page.Page{GUID: "PG_2"},
+						Selections: []pagediff.Selection{
+							{Target: pagediff.TargetTitle, Side: pagediff.SideChild},
+							{Target: pagediff.TargetProperty, Key: "population"},
+						},
+						UserID: "UR_1",
+					},
+					returnDiff: pagediff.Diff{PageGUID: "PG_2", ParentPageGUID: "PG_1"},
+				},
+			},
+		},
+		{
+			name: "no changes selected",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"changes\":[]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide at least one change\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "property change without a key",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"changes\":[{\"target\":\"property\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"must provide a key for the property change\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "invalid side",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"changes\":[{\"target\":\"title\",\"side\":\"both\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"invalid change side both\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+		},
+		{
+			name: "conflict without a side",
+			headers: map[string]string{
+				"X-USER-ID": "UR_1",
+			},
+			requestBody:          "{\"changes\":[{\"target\":\"title\"}]}",
+			authN:                handlertestutils.DefaultAuthN("LOCAL"),
+			authZ:                handlertestutils.DefaultAuthZ(),
+			expectedResponseBody: "{\"meta\":{\"httpStatus\":\"400 - Bad Request\",\"message\":\"the change to title conflicts with the parent version, so a side must be picked\",\"requestId\":\"TEST_REQUEST\"}}\n",
+			expectedStatusCode:   400,
+			mergePageCalls: []mergePageCall{
+				{
+					mergeParams: versionservice.MergePageParams{
+						Page:       page.Page{GUID: "PG_2"},
+						Selections: []pagediff.Selection{{Target: pagediff.TargetTitle}},
+						UserID:     "UR_1",
+					},
+					returnErr: &serviceerror.InvalidRequest{Message: "the change to title conflicts with the parent version, so a side must be picked"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionService := new(mocks.VersionService)
+			for index := range tc.mergePageCalls {
+				versionService.On("MergePage", mock.Anything, tc.mergePageCalls[index].mergeParams).Return(tc.mergePageCalls[index].returnDiff, tc.mergePageCalls[index].returnErr)
+			}
+			routerHandlers := VersionRouterHandlers(tc.authZ.APIPath, versionService)
+			resp, respBody := handlertestutils.HandleTestRequest(handlertestutils.HandleTestRequestParams{
+				Method:         http.MethodPost,
+				Endpoint:       "pages/PG_2/merge",
+				Body:           strings.NewReader(tc.requestBody),
+				Headers:        tc.headers,
+				RouterHandlers: routerHandlers,
+				AuthZ:          tc.authZ,
+				AuthN:          tc.authN,
+			})
+			require.Equal(t, tc.expectedResponseBody, respBody)
+			require.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			versionService.AssertNumberOfCalls(t, "MergePage", len(tc.mergePageCalls))
+		})
+	}
+}
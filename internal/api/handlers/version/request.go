@@ -0,0 +1,193 @@
+package versionhandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+)
+
+// CreateVersionRequest parameters from the CreateVersion call
+type CreateVersionRequest struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parentId"`
+}
+
+// NewCreateVersionRequest extracts the CreateVersionRequest
+func NewCreateVersionRequest(r *http.Request, p httprouter.Params) (CreateVersionRequest, error) {
+	var request CreateVersionRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	return request.validate()
+}
+
+func (request CreateVersionRequest) validate() (CreateVersionRequest, error) {
+	if request.Name == "" {
+		return request, errors.New("must provide name")
+	}
+	return request, nil
+}
+
+// GetVersionRequest parameters from the GetVersion and GetVersionAncestry calls
+type GetVersionRequest struct {
+	VersionID string
+}
+
+// NewGetVersionRequest extracts the GetVersionRequest
+func NewGetVersionRequest(r *http.Request, p httprouter.Params) (GetVersionRequest, error) {
+	var request GetVersionRequest
+	request.VersionID = p.ByName(VersionIDRouteKey)
+	return request.validate()
+}
+
+func (request GetVersionRequest) validate() (GetVersionRequest, error) {
+	if request.VersionID == "" {
+		return request, errors.New("must provide a version id")
+	}
+	return request, nil
+}
+
+// UpdateVersionRequest parameters from the UpdateVersion call
+type UpdateVersionRequest struct {
+	VersionID string
+	Name      string `json:"name"`
+}
+
+// NewUpdateVersionRequest extracts the UpdateVersionRequest
+func NewUpdateVersionRequest(r *http.Request, p httprouter.Params) (UpdateVersionRequest, error) {
+	var request UpdateVersionRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.VersionID = p.ByName(VersionIDRouteKey)
+	return request.validate()
+}
+
+func (request UpdateVersionRequest) validate() (UpdateVersionRequest, error) {
+	if request.VersionID == "" {
+		return request, errors.New("must provide a version id")
+	}
+	if request.Name == "" {
+		return request, errors.New("a version must retain a name")
+	}
+	return request, nil
+}
+
+// RemoveVersionRequest parameters from the RemoveVersion call
+type RemoveVersionRequest struct {
+	VersionID string
+}
+
+// NewRemoveVersionRequest extracts the RemoveVersionRequest
+func NewRemoveVersionRequest(r *http.Request, p httprouter.Params) (RemoveVersionRequest, error) {
+	var request RemoveVersionRequest
+	request.VersionID = p.ByName(VersionIDRouteKey)
+	return request.validate()
+}
+
+func (request RemoveVersionRequest) validate() (RemoveVersionRequest, error) {
+	if request.VersionID == "" {
+		return request, errors.New("must provide a version id")
+	}
+	return request, nil
+}
+
+// ForkPagesRequest parameters from the ForkPages call
+type ForkPagesRequest struct {
+	VersionID string
+	PageIDs   []string `json:"pageIds"`
+}
+
+// NewForkPagesRequest extracts the ForkPagesRequest
+func NewForkPagesRequest(r *http.Request, p httprouter.Params) (ForkPagesRequest, error) {
+	var request ForkPagesRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.VersionID = p.ByName(VersionIDRouteKey)
+	return request.validate()
+}
+
+func (request ForkPagesRequest) validate() (ForkPagesRequest, error) {
+	if request.VersionID == "" {
+		return request, errors.New("must provide a version id")
+	}
+	if len(request.PageIDs) == 0 {
+		return request, errors.New("must provide at least one page id")
+	}
+	for _, pageID := range request.PageIDs {
+		if pageID == "" {
+			return request, errors.New("must not provide an empty page id")
+		}
+	}
+	return request, nil
+}
+
+// DiffPageRequest parameters from the DiffPage call
+type DiffPageRequest struct {
+	PageID string
+}
+
+// NewDiffPageRequest extracts the DiffPageRequest
+func NewDiffPageRequest(r *http.Request, p httprouter.Params) (DiffPageRequest, error) {
+	var request DiffPageRequest
+	request.PageID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request DiffPageRequest) validate() (DiffPageRequest, error) {
+	if request.PageID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	return request, nil
+}
+
+// MergePageRequest parameters from the MergePage call
+type MergePageRequest struct {
+	PageID     string
+	Selections []pagediff.Selection `json:"changes"`
+}
+
+// NewMergePageRequest extracts the MergePageRequest
+func NewMergePageRequest(r *http.Request, p httprouter.Params) (MergePageRequest, error) {
+	var request MergePageRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return request, errors.New("invalid request")
+	}
+	request.PageID = p.ByName(PageIDRouteKey)
+	return request.validate()
+}
+
+func (request MergePageRequest) validate() (MergePageRequest, error) {
+	if request.PageID == "" {
+		return request, errors.New("must provide a page id")
+	}
+	if len(request.Selections) == 0 {
+		return request, errors.New("must provide at least one change")
+	}
+	for _, selection := range request.Selections {
+		switch selection.Target {
+		case pagediff.TargetTitle, pagediff.TargetSummary:
+		case pagediff.TargetProperty, pagediff.TargetDetail:
+			if selection.Key == "" {
+				return request, errors.Errorf("must provide a key for the %v change", selection.Target)
+			}
+		default:
+			return request, errors.Errorf("invalid change target %v", selection.Target)
+		}
+		switch selection.Side {
+		case "", pagediff.SideChild, pagediff.SideParent:
+		default:
+			return request, errors.Errorf("invalid change side %v", selection.Side)
+		}
+	}
+	return request, nil
+}
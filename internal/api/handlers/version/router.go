@@ -0,0 +1,68 @@
+package versionhandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/api"
+)
+
+// HTTP path fragments keys
+const (
+	VersionIDRouteKey = "versionID"
+	PageIDRouteKey    = "pageID"
+)
+
+// VersionRouterHandlers returns the requests for the associated routes.
+func VersionRouterHandlers(apiPath string, versionService VersionService) []api.RouterHandler {
+	handler := VersionHandler{
+		VersionService: versionService,
+	}
+	var routerHandlers []api.RouterHandler
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/versions", apiPath),
+		Handle:   handler.CreateVersion,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/versions", apiPath),
+		Handle:   handler.GetVersions,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/versions/:%v", apiPath, VersionIDRouteKey),
+		Handle:   handler.GetVersion,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPut,
+		Endpoint: fmt.Sprintf("/%v/versions/:%v", apiPath, VersionIDRouteKey),
+		Handle:   handler.UpdateVersion,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodDelete,
+		Endpoint: fmt.Sprintf("/%v/versions/:%v", apiPath, VersionIDRouteKey),
+		Handle:   handler.RemoveVersion,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/versions/:%v/ancestry", apiPath, VersionIDRouteKey),
+		Handle:   handler.GetVersionAncestry,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/versions/:%v/forks", apiPath, VersionIDRouteKey),
+		Handle:   handler.ForkPages,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodGet,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/diff", apiPath, PageIDRouteKey),
+		Handle:   handler.DiffPage,
+	})
+	routerHandlers = append(routerHandlers, api.RouterHandler{
+		Method:   http.MethodPost,
+		Endpoint: fmt.Sprintf("/%v/pages/:%v/merge", apiPath, PageIDRouteKey),
+		Handle:   handler.MergePage,
+	})
+	return routerHandlers
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/util/logging"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/transaction"
 	"go.uber.org/zap"
 )
 
@@ -13,6 +15,7 @@ type responseFormat struct {
 	Meta   struct {
 		HTTPStatus string `json:"httpStatus"`
 		Message    string `json:"message,omitempty"`
+		RequestID  string `json:"requestId,omitempty"`
 	} `json:"meta"`
 }
 
@@ -20,7 +23,8 @@ type responseFormat struct {
 // It also logs information regarding the request and response.
 func RespondWith(r *http.Request, w http.ResponseWriter, status int, responseData interface{}, errToLog error) {
 	dataWrapper := responseFormat{}
-	// @TODO: add in transaction/request ids.
+	t := transaction.GetFromContext(r.Context())
+	dataWrapper.Meta.RequestID = t.RequestID
 	dataWrapper.Meta.HTTPStatus = fmt.Sprintf("%v - %v", status, http.StatusText(status))
 	if errMsg, ok := responseData.(error); ok {
 		dataWrapper.Meta.Message = errMsg.Error()
@@ -28,17 +32,28 @@ func RespondWith(r *http.Request, w http.ResponseWriter, status int, responseDat
 		dataWrapper.Result = responseData
 	}
 	if errToLog != nil {
-		// @TODO: we need a better logging paradigm.
-		logger, _ := zap.NewProduction()
-		defer logger.Sync()
-		logger.Info("Response error",
+		errToLog = transaction.WrapError(r.Context(), errToLog)
+		logger := logging.GetFromContext(r.Context())
+		fields := []zap.Field{
+			zap.Int("status", status),
 			zap.String("err", errToLog.Error()),
 			zap.String("errVerbose", fmt.Sprintf("%+v", errToLog)),
-		)
-		// @DEBUG: probably shouldn't have, but need to handler tests to get clean output
-		fmt.Printf("%+v\n", errToLog)
+		}
+		if status >= http.StatusInternalServerError {
+			logger.Error("Response error", fields...)
+		} else {
+			logger.Info("Response error", fields...)
+		}
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(dataWrapper)
 }
+
+// RespondWithContent responds to the given request with the content as it is, rather than wrapped in the JSON response format.
+// Errors should still be responded to with RespondWith.
+func RespondWithContent(r *http.Request, w http.ResponseWriter, status int, contentType string, content []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write(content)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
@@ -12,6 +13,12 @@ import (
 type Router struct {
 	http.Handler
 	NonAuthRoutes []NonAuthRoute
+	// endpoints are the patterns of the routes by method, such as /api/pages/:pageID for GET.
+	endpoints map[string][]string
+	// access is who may call each route, by method and then pattern.
+	access map[string]map[string]Access
+	// secret are the routes that respond with secrets, by method and then pattern.
+	secret map[string]map[string]bool
 }
 
 // NonAuthRoute a route that does not require authentication
@@ -26,26 +33,94 @@ type RouterHandler struct {
 	Method   string
 	Endpoint string
 	Handle   httprouter.Handle
+	// Access is who may call the route, which is anyone who is authenticated by default.
+	Access Access
+	// Secret routes respond with secrets, such as tokens, so their responses are never recorded in the audit log.
+	Secret bool
 }
 
 // NewRouter adds the routes to a new handler and returns the handler with non-auth routes.
-func NewRouter(apiPath, staticPath string, routerHandlers []RouterHandler) Router {
+func NewRouter(apiPath, staticPath string, routerHandlers []RouterHandler, nonAuthRoutes []NonAuthRoute) Router {
 	handler := httprouter.New()
 	handleAuthRoutes(handler, routerHandlers)
-	nonAuthRoutes := newNonAuthRoutes()
 	handleNonAuthRoutes(handler, nonAuthRoutes)
 	serveFiles(handler, apiPath, staticPath)
 	handler.NotFound = http.HandlerFunc(handleNotFound)
 	handler.MethodNotAllowed = http.HandlerFunc(handleMethodNotAllowed)
 	handler.PanicHandler = panicHandler()
+	endpoints := map[string][]string{}
+	access := map[string]map[string]Access{}
+	secret := map[string]map[string]bool{}
+	for _, routerHandler := range routerHandlers {
+		endpoints[routerHandler.Method] = append(endpoints[routerHandler.Method], routerHandler.Endpoint)
+		if access[routerHandler.Method] == nil {
+			access[routerHandler.Method] = map[string]Access{}
+			secret[routerHandler.Method] = map[string]bool{}
+		}
+		access[routerHandler.Method][routerHandler.Endpoint] = routerHandler.Access
+		secret[routerHandler.Method][routerHandler.Endpoint] = routerHandler.Secret
+	}
+	for _, route := range nonAuthRoutes {
+		endpoints[route.Method] = append(endpoints[route.Method], route.Path)
+	}
 	return Router{
 		Handler:       handler,
 		NonAuthRoutes: nonAuthRoutes,
+		endpoints:     endpoints,
+		access:        access,
+		secret:        secret,
+	}
+}
+
+// Route returns the pattern of the route that handles the method and path, such as /api/pages/:pageID,
+// so that requests can be grouped by route regardless of their ids. It returns an empty string if there is no such route.
+func (r Router) Route(method, path string) string {
+	pathSegments := strings.Split(path, "/")
+	for _, endpoint := range r.endpoints[method] {
+		if matchesEndpoint(strings.Split(endpoint, "/"), pathSegments) {
+			return endpoint
+		}
+	}
+	return ""
+}
+
+// Access returns who may call the route that handles the method and path.
+// A path without a route may be called by anyone who is authenticated, to be told that it was not found.
+func (r Router) Access(method, path string) Access {
+	return r.access[method][r.Route(method, path)]
+}
+
+// RespondsWithSecret returns true if the route that handles the method and path responds with secrets.
+func (r Router) RespondsWithSecret(method, path string) bool {
+	return r.secret[method][r.Route(method, path)]
+}
+
+func matchesEndpoint(endpointSegments, pathSegments []string) bool {
+	if len(endpointSegments) != len(pathSegments) {
+		return false
 	}
+	for i, endpointSegment := range endpointSegments {
+		if strings.HasPrefix(endpointSegment, ":") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if endpointSegment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
 }
 
-func newNonAuthRoutes() []NonAuthRoute {
-	return []NonAuthRoute{}
+// Lookup returns the params of the route that handles the method and path, and whether there is such a route.
+func (r Router) Lookup(method, path string) (httprouter.Params, bool) {
+	router, ok := r.Handler.(*httprouter.Router)
+	if !ok {
+		return nil, false
+	}
+	handle, params, _ := router.Lookup(method, path)
+	return params, handle != nil
 }
 
 func handleAuthRoutes(handler *httprouter.Router, routerHandlers []RouterHandler) {
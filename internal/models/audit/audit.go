@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSummaryLength is the most bytes of a before or after summary that are recorded.
+const MaxSummaryLength = 4096
+
+// truncatedSuffix marks a summary that was cut short.
+const truncatedSuffix = "..."
+
+// Entry is an append-only record of a single create, update or delete made through the API.
+// Anyone holding the admin secret may act as any user, so an entry records how the caller authenticated
+// along with the user they acted as.
+type Entry struct {
+	ID   int64  `json:"-"`
+	GUID string `json:"id"`
+	// Actor is how the caller authenticated, such as admin or proxyUser.
+	Actor string `json:"actor"`
+	// UserID is the user the caller acted as. It is empty for an admin acting as no one.
+	UserID string `json:"userId"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Status int    `json:"status"`
+	// EntityGUID is the id of the entity that was created, updated or deleted, if it could be determined.
+	EntityGUID string `json:"entityId"`
+	// Before and After are JSON summaries of the entity on either side of the call, truncated to MaxSummaryLength.
+	// They are empty when the entity did not exist or could not be read on that side.
+	Before    string     `json:"before"`
+	After     string     `json:"after"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+// Filter narrows down the audit entries. Zero-valued fields do not filter.
+type Filter struct {
+	UserID     string
+	EntityGUID string
+	// From is inclusive and To is exclusive.
+	From *time.Time
+	To   *time.Time
+}
+
+// Summarize returns the JSON compacted and truncated to MaxSummaryLength, so that it can be recorded on an entry.
+// Data that is not JSON is summarized as it is.
+func Summarize(data []byte) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err == nil {
+		data = compacted.Bytes()
+	}
+	if len(data) <= MaxSummaryLength {
+		return string(data)
+	}
+	data = data[:MaxSummaryLength-len(truncatedSuffix)]
+	// avoid leaving half of a multi-byte character at the end
+	for len(data) > 0 {
+		r, size := utf8.DecodeLastRune(data)
+		if r != utf8.RuneError || size != 1 {
+			break
+		}
+		data = data[:len(data)-1]
+	}
+	return string(data) + truncatedSuffix
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	cases := []struct {
+		name         string
+		paramData    []byte
+		returnString string
+	}{
+		{
+			name:         "test json is compacted",
+			paramData:    []byte("{\n  \"id\": \"PG_1\",\n  \"title\": \"Barovia\"\n}\n"),
+			returnString: "{\"id\":\"PG_1\",\"title\":\"Barovia\"}",
+		},
+		{
+			name:         "test not json",
+			paramData:    []byte("# Barovia"),
+			returnString: "# Barovia",
+		},
+		{
+			name:         "test empty",
+			paramData:    nil,
+			returnString: "",
+		},
+		{
+			name:         "test long data is truncated",
+			paramData:    []byte(strings.Repeat("a", MaxSummaryLength+1)),
+			returnString: strings.Repeat("a", MaxSummaryLength-3) + "...",
+		},
+		{
+			name:         "test truncation does not split characters",
+			paramData:    []byte(strings.Repeat("a", MaxSummaryLength-4) + strings.Repeat("é", 3)),
+			returnString: strings.Repeat("a", MaxSummaryLength-4) + "...",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnString, Summarize(tc.paramData))
+		})
+	}
+}
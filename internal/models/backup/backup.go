@@ -0,0 +1,274 @@
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
+	"github.com/pkg/errors"
+)
+
+// Format is the format of the bundles that are created. Bump it whenever a bundle would no longer restore the same way.
+const Format = 1
+
+const guidLength = 15
+
+// Bundle is a portable copy of everything a user owns, which can be restored into any account on any deployment.
+// Relations between pages are kept in the partitions of the pages' details.
+type Bundle struct {
+	Format        int                         `json:"format"`
+	CreatedAt     *time.Time                  `json:"createdAt"`
+	Properties    []property.Property         `json:"properties"`
+	Versions      []version.Version           `json:"versions"`
+	PageTemplates []pagetemplate.PageTemplate `json:"pageTemplates"`
+	Pages         []Page                      `json:"pages"`
+}
+
+// Page is a page along with its properties and details.
+type Page struct {
+	GUID             string                  `json:"id"`
+	VersionGUID      string                  `json:"versionId"`
+	PageTemplateGUID string                  `json:"pageTemplateId"`
+	Title            string                  `json:"title"`
+	Summary          string                  `json:"summary"`
+	PermissionType   permission.Type         `json:"permission"`
+	Properties       []property.Property     `json:"properties"`
+	Details          []pagedetail.PageDetail `json:"details"`
+}
+
+// GetJSONConformed conforms the bundle to be ready for JSON marshelling.
+func (b Bundle) GetJSONConformed() interface{} {
+	if b.Properties == nil {
+		b.Properties = []property.Property{}
+	}
+	if b.Versions == nil {
+		b.Versions = []version.Version{}
+	}
+	if b.PageTemplates == nil {
+		b.PageTemplates = []pagetemplate.PageTemplate{}
+	}
+	pages := make([]Page, 0, len(b.Pages))
+	for _, p := range b.Pages {
+		if p.Properties == nil {
+			p.Properties = []property.Property{}
+		}
+		details := make([]pagedetail.PageDetail, 0, len(p.Details))
+		for _, d := range p.Details {
+			details = append(details, d.GetJSONConformed().(pagedetail.PageDetail))
+		}
+		p.Details = details
+		pages = append(pages, p)
+	}
+	b.Pages = pages
+	return b
+}
+
+// Restoration sums up what was restored from a bundle.
+type Restoration struct {
+	Properties    int `json:"properties"`
+	Versions      int `json:"versions"`
+	PageTemplates int `json:"pageTemplates"`
+	Pages         int `json:"pages"`
+	Details       int `json:"details"`
+	// RemappedIDs maps each id of the bundle that was already taken to the new id it was restored with.
+	RemappedIDs map[string]string `json:"remappedIds"`
+}
+
+// Validate checks that the bundle is complete: every id is well formed and listed once,
+// and everything the pages and templates refer to is part of the bundle.
+func (b Bundle) Validate() error {
+	if b.Format != Format {
+		return errors.Errorf("format %v is not supported, only format %v can be restored", b.Format, Format)
+	}
+	properties := make(map[string]property.Type)
+	for _, p := range b.Properties {
+		if p.Key == "" {
+			return errors.New("every property must have a key")
+		}
+		if _, ok := properties[p.Key]; ok {
+			return errors.Errorf("property %v is listed more than once", p.Key)
+		}
+		if p.Type != property.TypeNumber && p.Type != property.TypeString {
+			return errors.Errorf("property %v has the invalid type %v", p.Key, p.Type)
+		}
+		properties[p.Key] = p.Type
+	}
+	_, err := b.GetVersionsInOrder()
+	if err != nil {
+		return err
+	}
+	pageTemplates, err := validatePageTemplates(b.PageTemplates, properties)
+	if err != nil {
+		return err
+	}
+	return b.validatePages(pageTemplates, properties)
+}
+
+func checkGUID(guid, prefix, name string) error {
+	if guid == "" {
+		return errors.Errorf("every %v must have an id", name)
+	}
+	err := guidgen.CheckProposedGUID(guid, prefix, guidLength)
+	if err != nil {
+		return errors.Wrapf(err, "%v %v", name, guid)
+	}
+	return nil
+}
+
+// GetVersionsInOrder returns the bundle's versions with every parent before its children.
+func (b Bundle) GetVersionsInOrder() ([]version.Version, error) {
+	versions := make(map[string]version.Version)
+	for _, v := range b.Versions {
+		err := checkGUID(v.GUID, "VR", "version")
+		if err != nil {
+			return nil, err
+		}
+		if v.Name == "" {
+			return nil, errors.Errorf("version %v must have a name", v.GUID)
+		}
+		if _, ok := versions[v.GUID]; ok {
+			return nil, errors.Errorf("version %v is listed more than once", v.GUID)
+		}
+		versions[v.GUID] = v
+	}
+	ordered := make([]version.Version, 0, len(b.Versions))
+	added := make(map[string]bool)
+	for _, v := range b.Versions {
+		var ancestry []version.Version
+		for current := v; !added[current.GUID]; {
+			for _, descendant := range ancestry {
+				if descendant.GUID == current.GUID {
+					return nil, errors.Errorf("version %v is its own ancestor", current.GUID)
+				}
+			}
+			ancestry = append(ancestry, current)
+			if current.IsRoot() {
+				break
+			}
+			parent, ok := versions[current.ParentGUID]
+			if !ok {
+				return nil, errors.Errorf("the parent %v of version %v is not part of the bundle", current.ParentGUID, current.GUID)
+			}
+			current = parent
+		}
+		for i := len(ancestry) - 1; i >= 0; i-- {
+			if !added[ancestry[i].GUID] {
+				added[ancestry[i].GUID] = true
+				ordered = append(ordered, ancestry[i])
+			}
+		}
+	}
+	return ordered, nil
+}
+
+func validatePageTemplates(pageTemplates []pagetemplate.PageTemplate, properties map[string]property.Type) (map[string]bool, error) {
+	guids := make(map[string]bool)
+	for _, pt := range pageTemplates {
+		err := checkGUID(pt.GUID, "PGT", "page template")
+		if err != nil {
+			return nil, err
+		}
+		if pt.Name == "" {
+			return nil, errors.Errorf("page template %v must have a name", pt.GUID)
+		}
+		if guids[pt.GUID] {
+			return nil, errors.Errorf("page template %v is listed more than once", pt.GUID)
+		}
+		guids[pt.GUID] = true
+		for _, p := range pt.Properties {
+			err := checkProperty(p.Key, p.Type, properties)
+			if err != nil {
+				return nil, errors.Wrapf(err, "page template %v", pt.GUID)
+			}
+			if !p.IsValidDefaultValue() {
+				return nil, errors.Errorf("page template %v: the default value of property %v must be a %v", pt.GUID, p.Key, p.Type)
+			}
+		}
+	}
+	return guids, nil
+}
+
+func checkProperty(key string, propertyType property.Type, properties map[string]property.Type) error {
+	registeredType, ok := properties[key]
+	if !ok {
+		return errors.Errorf("property %v is not part of the bundle", key)
+	}
+	if registeredType != propertyType {
+		return errors.Errorf("property %v must be a %v", key, registeredType)
+	}
+	return nil
+}
+
+func isValueOfType(value interface{}, propertyType property.Type) bool {
+	switch value.(type) {
+	case float64:
+		return propertyType == property.TypeNumber
+	case string:
+		return propertyType == property.TypeString
+	default:
+		return false
+	}
+}
+
+func (b Bundle) validatePages(pageTemplates map[string]bool, properties map[string]property.Type) error {
+	versions := make(map[string]bool)
+	for _, v := range b.Versions {
+		versions[v.GUID] = true
+	}
+	pages := make(map[string]bool)
+	details := make(map[string]bool)
+	for _, p := range b.Pages {
+		err := checkGUID(p.GUID, "PG", "page")
+		if err != nil {
+			return err
+		}
+		if pages[p.GUID] {
+			return errors.Errorf("page %v is listed more than once", p.GUID)
+		}
+		pages[p.GUID] = true
+		if p.Title == "" {
+			return errors.Errorf("page %v must have a title", p.GUID)
+		}
+		if _, err := permission.GetPermissionType(string(p.PermissionType)); err != nil {
+			return errors.Wrapf(err, "page %v", p.GUID)
+		}
+		if !versions[p.VersionGUID] {
+			return errors.Errorf("the version %v of page %v is not part of the bundle", p.VersionGUID, p.GUID)
+		}
+		if !pageTemplates[p.PageTemplateGUID] {
+			return errors.Errorf("the page template %v of page %v is not part of the bundle", p.PageTemplateGUID, p.GUID)
+		}
+		for _, prop := range p.Properties {
+			err := checkProperty(prop.Key, prop.Type, properties)
+			if err != nil {
+				return errors.Wrapf(err, "page %v", p.GUID)
+			}
+			if !isValueOfType(prop.Value, prop.Type) {
+				return errors.Errorf("page %v: the value of property %v must be a %v", p.GUID, prop.Key, prop.Type)
+			}
+		}
+		for _, d := range p.Details {
+			err := checkGUID(d.GUID, "DT", fmt.Sprintf("detail of page %v", p.GUID))
+			if err != nil {
+				return err
+			}
+			if details[d.GUID] {
+				return errors.Errorf("detail %v is listed more than once", d.GUID)
+			}
+			details[d.GUID] = true
+			if d.Title == "" {
+				return errors.Errorf("detail %v must have a title", d.GUID)
+			}
+			err = pagedetail.UnmarshalPartitions(d.Partitions)
+			if err != nil {
+				return errors.Wrapf(err, "detail %v", d.GUID)
+			}
+		}
+	}
+	return nil
+}
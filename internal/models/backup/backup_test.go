@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func getBundle() Bundle {
+	return Bundle{
+		Format: Format,
+		Properties: []property.Property{
+			{Key: "population", Type: property.TypeNumber},
+			{Key: "ruler", Type: property.TypeString},
+		},
+		Versions: []version.Version{
+			{GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"},
+			{GUID: "VR_000000000001", Name: "Default"},
+		},
+		PageTemplates: []pagetemplate.PageTemplate{
+			{
+				GUID:       "PGT_00000000001",
+				Name:       "Settlement",
+				Properties: []pagetemplate.TemplateProperty{{Key: "population", Type: property.TypeNumber, DefaultValue: float64(0)}},
+			},
+		},
+		Pages: []Page{
+			{
+				GUID:             "PG_000000000001",
+				VersionGUID:      "VR_000000000002",
+				PageTemplateGUID: "PGT_00000000001",
+				Title:            "Barovia",
+				PermissionType:   permission.TypePrivate,
+				Properties:       []property.Property{{Key: "ruler", Type: property.TypeString, Value: "Strahd"}},
+				Details: []pagedetail.PageDetail{
+					{
+						GUID:  "DT_000000000001",
+						Title: "Roads",
+						Partitions: []pagedetail.Partition{
+							{TypeString: "relation", Value: "Vallaki", Relation: "PG_000000000002"},
+						},
+					},
+				},
+			},
+			{
+				GUID:             "PG_000000000002",
+				VersionGUID:      "VR_000000000001",
+				PageTemplateGUID: "PGT_00000000001",
+				Title:            "Vallaki",
+				PermissionType:   permission.TypePublic,
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name      string
+		edit      func(b *Bundle)
+		returnErr error
+	}{
+		{
+			name: "test valid bundle",
+			edit: func(b *Bundle) {},
+		},
+		{
+			name:      "test unsupported format",
+			edit:      func(b *Bundle) { b.Format = 2 },
+			returnErr: errors.New("format 2 is not supported, only format 1 can be restored"),
+		},
+		{
+			name: "test duplicate property",
+			edit: func(b *Bundle) {
+				b.Properties = append(b.Properties, property.Property{Key: "ruler", Type: property.TypeString})
+			},
+			returnErr: errors.New("property ruler is listed more than once"),
+		},
+		{
+			name:      "test invalid property type",
+			edit:      func(b *Bundle) { b.Properties[0].Type = "boolean" },
+			returnErr: errors.New("property population has the invalid type boolean"),
+		},
+		{
+			name:      "test malformed version id",
+			edit:      func(b *Bundle) { b.Versions[1].GUID = "PG_000000000001" },
+			returnErr: errors.New("version PG_000000000001: proposed guid must start with 'VR_'"),
+		},
+		{
+			name:      "test template property not in bundle",
+			edit:      func(b *Bundle) { b.PageTemplates[0].Properties[0].Key = "area" },
+			returnErr: errors.New("page template PGT_00000000001: property area is not part of the bundle"),
+		},
+		{
+			name:      "test invalid template default value",
+			edit:      func(b *Bundle) { b.PageTemplates[0].Properties[0].DefaultValue = "none" },
+			returnErr: errors.New("page template PGT_00000000001: the default value of property population must be a number"),
+		},
+		{
+			name:      "test duplicate page",
+			edit:      func(b *Bundle) { b.Pages[1].GUID = "PG_000000000001" },
+			returnErr: errors.New("page PG_000000000001 is listed more than once"),
+		},
+		{
+			name:      "test page version not in bundle",
+			edit:      func(b *Bundle) { b.Pages[1].VersionGUID = "VR_000000000003" },
+			returnErr: errors.New("the version VR_000000000003 of page PG_000000000002 is not part of the bundle"),
+		},
+		{
+			name:      "test page property of the wrong type",
+			edit:      func(b *Bundle) { b.Pages[0].Properties[0].Value = float64(1) },
+			returnErr: errors.New("page PG_000000000001: the value of property ruler must be a string"),
+		},
+		{
+			name:      "test detail without a title",
+			edit:      func(b *Bundle) { b.Pages[0].Details[0].Title = "" },
+			returnErr: errors.New("detail DT_000000000001 must have a title"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := getBundle()
+			tc.edit(&b)
+			err := b.Validate()
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+func TestGetVersionsInOrder(t *testing.T) {
+	cases := []struct {
+		name           string
+		paramVersions  []version.Version
+		returnVersions []version.Version
+		returnErr      error
+	}{
+		{
+			name: "test children listed first",
+			paramVersions: []version.Version{
+				{GUID: "VR_000000000003", Name: "Session 13", ParentGUID: "VR_000000000002"},
+				{GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"},
+				{GUID: "VR_000000000001", Name: "Default"},
+				{GUID: "VR_000000000004", Name: "Alternate", ParentGUID: "VR_000000000001"},
+			},
+			returnVersions: []version.Version{
+				{GUID: "VR_000000000001", Name: "Default"},
+				{GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"},
+				{GUID: "VR_000000000003", Name: "Session 13", ParentGUID: "VR_000000000002"},
+				{GUID: "VR_000000000004", Name: "Alternate", ParentGUID: "VR_000000000001"},
+			},
+		},
+		{
+			name: "test missing parent",
+			paramVersions: []version.Version{
+				{GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"},
+			},
+			returnErr: errors.New("the parent VR_000000000001 of version VR_000000000002 is not part of the bundle"),
+		},
+		{
+			name: "test cycle",
+			paramVersions: []version.Version{
+				{GUID: "VR_000000000001", Name: "Default", ParentGUID: "VR_000000000002"},
+				{GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"},
+			},
+			returnErr: errors.New("version VR_000000000001 is its own ancestor"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Bundle{Versions: tc.paramVersions}.GetVersionsInOrder()
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnVersions, result)
+		})
+	}
+}
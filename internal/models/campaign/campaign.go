@@ -0,0 +1,42 @@
+package campaign
+
+import "github.com/pkg/errors"
+
+// Campaign is a group of users that share pages with each other.
+type Campaign struct {
+	ID      int64    `json:"-"`
+	GUID    string   `json:"id"`
+	Name    string   `json:"name"`
+	Summary string   `json:"summary"`
+	Members []Member `json:"members,omitempty"`
+}
+
+// Member is a user that belongs to a campaign.
+type Member struct {
+	UserID string `json:"userId"`
+	Role   Role   `json:"role"`
+}
+
+// Role is a valid campaign member role.
+type Role string
+
+// All the valid values for Role
+const (
+	RoleOwner  Role = "OW"
+	RoleEditor Role = "ED"
+	RoleViewer Role = "VI"
+)
+
+// GetRole returns the correct role for the given string.
+func GetRole(roleString string) (Role, error) {
+	switch roleString {
+	case string(RoleOwner):
+		return RoleOwner, nil
+	case string(RoleEditor):
+		return RoleEditor, nil
+	case string(RoleViewer):
+		return RoleViewer, nil
+	default:
+		return RoleViewer, errors.Errorf("invalid campaign role %v", roleString)
+	}
+}
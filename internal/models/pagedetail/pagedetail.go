@@ -1,5 +1,7 @@
 package pagedetail
 
+import "encoding/json"
+
 // PageDetail is a single detail for a page.
 type PageDetail struct {
 	ID         int64       `json:"-"`
@@ -8,3 +10,40 @@ type PageDetail struct {
 	Summary    string      `json:"summary"`
 	Partitions []Partition `json:"partitions"`
 }
+
+// GetJSONConformed conforms the page detail to be ready for JSON marshelling.
+func (d PageDetail) GetJSONConformed() interface{} {
+	if d.Partitions == nil {
+		d.Partitions = []Partition{}
+	}
+	return d
+}
+
+// EncodePartitions returns the partition tree in the form it is persisted to a store.
+func EncodePartitions(p []Partition) (string, error) {
+	if p == nil {
+		p = []Partition{}
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// DecodePartitions returns the partition tree from the form it is persisted to a store.
+func DecodePartitions(encoded string) ([]Partition, error) {
+	p := []Partition{}
+	if encoded == "" {
+		return p, nil
+	}
+	err := json.Unmarshal([]byte(encoded), &p)
+	if err != nil {
+		return p, err
+	}
+	err = UnmarshalPartitions(p)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
@@ -1,6 +1,10 @@
 package pagedetail
 
-import "github.com/pkg/errors"
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
 
 // Partition is a single markdown partition for a detail.
 type Partition struct {
@@ -38,6 +42,29 @@ func UnmarshalPartitions(p []Partition) error {
 	return nil
 }
 
+// PlainText returns the text of the partition, along with all of its nested partitions and items, without any formatting.
+func (p Partition) PlainText() string {
+	var b strings.Builder
+	b.WriteString(p.Value)
+	if p.Value == "" {
+		b.WriteString(p.AltText)
+	}
+	for _, child := range p.Partitions {
+		b.WriteString(child.PlainText())
+	}
+	for _, item := range p.Items {
+		text := item.PlainText()
+		if text == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
 // PartitionType is a valid property type.
 type PartitionType string
 
@@ -69,3 +69,101 @@ func TestUnmarshelPartitions(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeDecodePartitions(t *testing.T) {
+	cases := []struct {
+		name            string
+		paramPartitions []Partition
+		returnEncoded   string
+		returnErr       error
+	}{
+		{
+			name:            "no partitions",
+			paramPartitions: nil,
+			returnEncoded:   "[]",
+		},
+		{
+			name: "nested partitions, items, links, relations and colors",
+			paramPartitions: []Partition{
+				{
+					Type:       PartitionTypeParagraph,
+					TypeString: "p",
+					Partitions: []Partition{
+						{Type: PartitionTypeLink, TypeString: "link", Value: "a link", Link: "https://example.com"},
+						{Type: PartitionTypeRelation, TypeString: "relation", Value: "a relation", Relation: "PG_123456789012"},
+						{Type: PartitionTypeColor, TypeString: "color", Value: "red", Color: "#FF0000"},
+					},
+				},
+				{
+					Type:       PartitionTypeOrderedList,
+					TypeString: "ol",
+					Items: []Partition{
+						{Type: PartitionTypeText, TypeString: "text", Value: "item 1"},
+					},
+				},
+				{Type: PartitionTypeImage, TypeString: "image", AltText: "caption", Link: "https://example.com/img.png"},
+			},
+			returnEncoded: `[{"type":"p","partitions":[{"type":"link","value":"a link","link":"https://example.com"},{"type":"relation","value":"a relation","relation":"PG_123456789012"},{"type":"color","value":"red","color":"#FF0000"}]},{"type":"ol","items":[{"type":"text","value":"item 1"}]},{"type":"image","altText":"caption","link":"https://example.com/img.png"}]`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := EncodePartitions(tc.paramPartitions)
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if err != nil {
+				return
+			}
+			require.Equal(t, tc.returnEncoded, encoded)
+			decoded, err := DecodePartitions(encoded)
+			require.NoError(t, err)
+			if tc.paramPartitions == nil {
+				require.Equal(t, []Partition{}, decoded)
+				return
+			}
+			require.Equal(t, tc.paramPartitions, decoded)
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	cases := []struct {
+		name           string
+		paramPartition Partition
+		returnText     string
+	}{
+		{
+			name: "nested partitions",
+			paramPartition: Partition{
+				TypeString: "p",
+				Partitions: []Partition{
+					{TypeString: "text", Value: "The road east leads to "},
+					{TypeString: "bold", Partitions: []Partition{{TypeString: "relation", Value: "Vallaki", Relation: "PG_2"}}},
+					{TypeString: "text", Value: "."},
+				},
+			},
+			returnText: "The road east leads to Vallaki.",
+		},
+		{
+			name: "items",
+			paramPartition: Partition{
+				TypeString: "ul",
+				Items: []Partition{
+					{TypeString: "text", Value: "Vallaki"},
+					{TypeString: "hr"},
+					{TypeString: "text", Value: "Krezk"},
+				},
+			},
+			returnText: "Vallaki Krezk",
+		},
+		{
+			name:           "image",
+			paramPartition: Partition{TypeString: "image", AltText: "map of Barovia", Link: "https://example.com/map.png"},
+			returnText:     "map of Barovia",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnText, tc.paramPartition.PlainText())
+		})
+	}
+}
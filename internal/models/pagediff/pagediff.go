@@ -0,0 +1,279 @@
+package pagediff
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+)
+
+// Snapshot is the content of a page that is compared between versions.
+type Snapshot struct {
+	Title      string                  `json:"title"`
+	Summary    string                  `json:"summary"`
+	Properties []property.Property     `json:"properties"`
+	Details    []pagedetail.PageDetail `json:"details"`
+}
+
+// EncodeSnapshot returns the snapshot in the form it is persisted to a store.
+func EncodeSnapshot(s Snapshot) (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// DecodeSnapshot returns the snapshot from the form it is persisted to a store.
+func DecodeSnapshot(encoded string) (Snapshot, error) {
+	var s Snapshot
+	err := json.Unmarshal([]byte(encoded), &s)
+	if err != nil {
+		return s, err
+	}
+	for i := range s.Details {
+		err = pagedetail.UnmarshalPartitions(s.Details[i].Partitions)
+		if err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// Target is the part of a page that a change was made to.
+type Target string
+
+// All the valid values for Target
+const (
+	TargetTitle    Target = "title"
+	TargetSummary  Target = "summary"
+	TargetProperty Target = "property"
+	TargetDetail   Target = "detail"
+)
+
+// Status describes which side of the version tree a change was made on.
+type Status string
+
+// All the valid values for Status
+const (
+	// StatusChild changes were only made in the child version, and can be merged into the parent.
+	StatusChild Status = "child"
+	// StatusParent changes were only made in the parent version since the page was forked.
+	StatusParent Status = "parent"
+	// StatusConflict changes were made differently on both sides, so a side must be picked to merge them.
+	StatusConflict Status = "conflict"
+)
+
+// Side is the side of a conflict that is kept when it is merged.
+type Side string
+
+// All the valid values for Side
+const (
+	SideChild  Side = "child"
+	SideParent Side = "parent"
+)
+
+// Change is a single difference between a page in a child version and its counterpart in the parent version.
+// Base, Parent and Child hold the value of the target at the time of the fork, in the parent and in the child.
+// A nil value means the property or detail does not exist on that side.
+type Change struct {
+	Target Target      `json:"target"`
+	Key    string      `json:"key,omitempty"`
+	Status Status      `json:"status"`
+	Base   interface{} `json:"base"`
+	Parent interface{} `json:"parent"`
+	Child  interface{} `json:"child"`
+	// Partitions is the structural diff from the parent's partitions to the child's, for details on both sides.
+	Partitions []PartitionDiff `json:"partitions,omitempty"`
+}
+
+// Diff is every difference between a page in a child version and its counterpart in the parent version.
+type Diff struct {
+	PageGUID       string   `json:"id"`
+	ParentPageGUID string   `json:"parentId"`
+	Changes        []Change `json:"changes"`
+}
+
+// GetJSONConformed conforms the diff to be ready for JSON marshelling.
+func (d Diff) GetJSONConformed() interface{} {
+	if d.Changes == nil {
+		d.Changes = []Change{}
+	}
+	return d
+}
+
+// GetChange returns the change made to the given target and key, if there is one.
+func (d Diff) GetChange(target Target, key string) (Change, bool) {
+	for _, change := range d.Changes {
+		if change.Target == target && change.Key == key {
+			return change, true
+		}
+	}
+	return Change{}, false
+}
+
+// Selection picks a change to merge into the parent version.
+// Side only needs to be provided when the change is a conflict.
+type Selection struct {
+	Target Target `json:"target"`
+	Key    string `json:"key"`
+	Side   Side   `json:"side"`
+}
+
+// Compare returns the changes between the child and parent snapshots, relative to the base snapshot they were forked from.
+// Details are matched by the parent's guids: detailGUIDs maps each child detail guid to the parent detail guid it was forked from,
+// and the details of the base snapshot carry the parent's guids. Changes to details use the child's guid as their key, unless
+// the detail only exists in the parent.
+func Compare(base, parent, child Snapshot, detailGUIDs map[string]string) []Change {
+	changes := []Change{}
+	if change, ok := compareValues(TargetTitle, "", base.Title, parent.Title, child.Title); ok {
+		changes = append(changes, change)
+	}
+	if change, ok := compareValues(TargetSummary, "", base.Summary, parent.Summary, child.Summary); ok {
+		changes = append(changes, change)
+	}
+	changes = append(changes, compareProperties(base.Properties, parent.Properties, child.Properties)...)
+	changes = append(changes, compareDetails(base.Details, parent.Details, child.Details, detailGUIDs)...)
+	return changes
+}
+
+func compareProperties(base, parent, child []property.Property) []Change {
+	baseByKey := propertiesByKey(base)
+	parentByKey := propertiesByKey(parent)
+	childByKey := propertiesByKey(child)
+	changes := []Change{}
+	for _, key := range orderedKeys(propertyKeys(child), propertyKeys(parent), propertyKeys(base)) {
+		if change, ok := compareValues(TargetProperty, key, baseByKey[key], parentByKey[key], childByKey[key]); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func propertiesByKey(properties []property.Property) map[string]interface{} {
+	byKey := make(map[string]interface{})
+	for _, p := range properties {
+		byKey[p.Key] = p
+	}
+	return byKey
+}
+
+func propertyKeys(properties []property.Property) []string {
+	keys := make([]string, 0, len(properties))
+	for _, p := range properties {
+		keys = append(keys, p.Key)
+	}
+	return keys
+}
+
+func compareDetails(base, parent, child []pagedetail.PageDetail, detailGUIDs map[string]string) []Change {
+	baseByGUID := detailsByGUID(base)
+	parentByGUID := detailsByGUID(parent)
+	childByParentGUID := make(map[string]interface{})
+	childGUIDs := make(map[string]string)
+	childKeys := make([]string, 0, len(child))
+	for _, d := range child {
+		// details added in the child have no counterpart, so they are keyed by their own guid.
+		parentGUID, ok := detailGUIDs[d.GUID]
+		if !ok {
+			parentGUID = d.GUID
+		}
+		childByParentGUID[parentGUID] = d
+		childGUIDs[parentGUID] = d.GUID
+		childKeys = append(childKeys, parentGUID)
+	}
+	changes := []Change{}
+	for _, parentGUID := range orderedKeys(childKeys, detailGUIDsOf(parent), detailGUIDsOf(base)) {
+		key, ok := childGUIDs[parentGUID]
+		if !ok {
+			key = parentGUID
+		}
+		change, ok := compareValues(TargetDetail, key, baseByGUID[parentGUID], parentByGUID[parentGUID], childByParentGUID[parentGUID])
+		if !ok {
+			continue
+		}
+		parentDetail, parentOK := change.Parent.(pagedetail.PageDetail)
+		childDetail, childOK := change.Child.(pagedetail.PageDetail)
+		if parentOK && childOK {
+			change.Partitions = DiffPartitions(parentDetail.Partitions, childDetail.Partitions)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func detailsByGUID(details []pagedetail.PageDetail) map[string]interface{} {
+	byGUID := make(map[string]interface{})
+	for _, d := range details {
+		byGUID[d.GUID] = d
+	}
+	return byGUID
+}
+
+func detailGUIDsOf(details []pagedetail.PageDetail) []string {
+	guids := make([]string, 0, len(details))
+	for _, d := range details {
+		guids = append(guids, d.GUID)
+	}
+	return guids
+}
+
+// orderedKeys returns each key once, in the order they are first seen.
+func orderedKeys(keyLists ...[]string) []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+	for _, keyList := range keyLists {
+		for _, key := range keyList {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// compareValues makes a three-way comparison of a single target, returning false if nothing changed.
+func compareValues(target Target, key string, base, parent, child interface{}) (Change, bool) {
+	childChanged := !isEqual(base, child)
+	parentChanged := !isEqual(base, parent)
+	change := Change{Target: target, Key: key, Base: base, Parent: parent, Child: child}
+	switch {
+	case childChanged && parentChanged:
+		if isEqual(parent, child) {
+			return change, false
+		}
+		change.Status = StatusConflict
+	case childChanged:
+		change.Status = StatusChild
+	case parentChanged:
+		change.Status = StatusParent
+	default:
+		return change, false
+	}
+	return change, true
+}
+
+// isEqual compares the content of two values. Details are compared without their guids,
+// since a detail and its fork will always have different guids.
+func isEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if d, ok := a.(pagedetail.PageDetail); ok {
+		d.GUID = ""
+		a = d.GetJSONConformed()
+	}
+	if d, ok := b.(pagedetail.PageDetail); ok {
+		d.GUID = ""
+		b = d.GetJSONConformed()
+	}
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(encodedA) == string(encodedB)
+}
@@ -0,0 +1,144 @@
+package pagediff
+
+import (
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPartitions(t *testing.T) {
+	heading := pagedetail.Partition{TypeString: "h1", Value: "Barovia"}
+	cases := []struct {
+		name        string
+		paramFrom   []pagedetail.Partition
+		paramTo     []pagedetail.Partition
+		returnDiffs []PartitionDiff
+	}{
+		{
+			name:        "no partitions",
+			returnDiffs: []PartitionDiff{},
+		},
+		{
+			name:      "added and removed around an equal partition",
+			paramFrom: []pagedetail.Partition{{TypeString: "hr"}, heading},
+			paramTo:   []pagedetail.Partition{heading, {TypeString: "image", Link: "https://example.com/map.png"}},
+			returnDiffs: []PartitionDiff{
+				{Op: OpRemoved, Partition: pagedetail.Partition{TypeString: "hr"}},
+				{Op: OpEqual, Partition: heading},
+				{Op: OpAdded, Partition: pagedetail.Partition{TypeString: "image", Link: "https://example.com/map.png"}},
+			},
+		},
+		{
+			name: "modified partition with nested changes",
+			paramFrom: []pagedetail.Partition{
+				heading,
+				{TypeString: "ul", Items: []pagedetail.Partition{{TypeString: "text", Value: "Vallaki"}, {TypeString: "text", Value: "Krezk"}}},
+			},
+			paramTo: []pagedetail.Partition{
+				heading,
+				{TypeString: "ul", Items: []pagedetail.Partition{{TypeString: "text", Value: "Vallaki"}, {TypeString: "text", Value: "Kresk"}}},
+			},
+			returnDiffs: []PartitionDiff{
+				{Op: OpEqual, Partition: heading},
+				{
+					Op:        OpModified,
+					Partition: pagedetail.Partition{TypeString: "ul", Items: []pagedetail.Partition{{TypeString: "text", Value: "Vallaki"}, {TypeString: "text", Value: "Kresk"}}},
+					Previous:  &pagedetail.Partition{TypeString: "ul", Items: []pagedetail.Partition{{TypeString: "text", Value: "Vallaki"}, {TypeString: "text", Value: "Krezk"}}},
+					Items: []PartitionDiff{
+						{Op: OpEqual, Partition: pagedetail.Partition{TypeString: "text", Value: "Vallaki"}},
+						{
+							Op:        OpModified,
+							Partition: pagedetail.Partition{TypeString: "text", Value: "Kresk"},
+							Previous:  &pagedetail.Partition{TypeString: "text", Value: "Krezk"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "replaced partition of a different type",
+			paramFrom: []pagedetail.Partition{{TypeString: "p", Value: "Mists"}},
+			paramTo:   []pagedetail.Partition{{TypeString: "quotes", Value: "Mists"}},
+			returnDiffs: []PartitionDiff{
+				{Op: OpRemoved, Partition: pagedetail.Partition{TypeString: "p", Value: "Mists"}},
+				{Op: OpAdded, Partition: pagedetail.Partition{TypeString: "quotes", Value: "Mists"}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnDiffs, DiffPartitions(tc.paramFrom, tc.paramTo))
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	population := property.Property{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(500)}
+	ruler := property.Property{ID: 2, Key: "ruler", Type: property.TypeString, Value: "Strahd"}
+	history := pagedetail.PageDetail{GUID: "DT_1", Title: "History"}
+	cases := []struct {
+		name             string
+		paramBase        Snapshot
+		paramParent      Snapshot
+		paramChild       Snapshot
+		paramDetailGUIDs map[string]string
+		returnChanges    []Change
+	}{
+		{
+			name:             "no changes",
+			paramBase:        Snapshot{Title: "Barovia", Properties: []property.Property{population}, Details: []pagedetail.PageDetail{history}},
+			paramParent:      Snapshot{Title: "Barovia", Properties: []property.Property{population}, Details: []pagedetail.PageDetail{history}},
+			paramChild:       Snapshot{Title: "Barovia", Properties: []property.Property{population}, Details: []pagedetail.PageDetail{{GUID: "DT_2", Title: "History"}}},
+			paramDetailGUIDs: map[string]string{"DT_2": "DT_1"},
+			returnChanges:    []Change{},
+		},
+		{
+			name:          "same change made on both sides",
+			paramBase:     Snapshot{Title: "Barovia"},
+			paramParent:   Snapshot{Title: "Barovia Valley"},
+			paramChild:    Snapshot{Title: "Barovia Valley"},
+			returnChanges: []Change{},
+		},
+		{
+			name:             "added, removed and conflicting properties and details",
+			paramBase:        Snapshot{Properties: []property.Property{population}, Details: []pagedetail.PageDetail{history}},
+			paramParent:      Snapshot{Properties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(700)}}, Details: []pagedetail.PageDetail{history}},
+			paramChild:       Snapshot{Properties: []property.Property{ruler}, Details: []pagedetail.PageDetail{{GUID: "DT_3", Title: "Geography"}}},
+			paramDetailGUIDs: map[string]string{},
+			returnChanges: []Change{
+				{Target: TargetProperty, Key: "ruler", Status: StatusChild, Child: ruler},
+				{
+					Target: TargetProperty,
+					Key:    "population",
+					Status: StatusConflict,
+					Base:   population,
+					Parent: property.Property{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(700)},
+				},
+				{Target: TargetDetail, Key: "DT_3", Status: StatusChild, Child: pagedetail.PageDetail{GUID: "DT_3", Title: "Geography"}},
+				{Target: TargetDetail, Key: "DT_1", Status: StatusChild, Base: history, Parent: history},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnChanges, Compare(tc.paramBase, tc.paramParent, tc.paramChild, tc.paramDetailGUIDs))
+		})
+	}
+}
+
+func TestEncodeDecodeSnapshot(t *testing.T) {
+	snapshot := Snapshot{
+		Title:      "Barovia",
+		Properties: []property.Property{{Key: "ruler", Type: property.TypeString, Value: "Strahd"}},
+		Details: []pagedetail.PageDetail{
+			{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{Type: pagedetail.PartitionTypeParagraph, TypeString: "p", Value: "Founded by Barov."}}},
+		},
+	}
+	encoded, err := EncodeSnapshot(snapshot)
+	require.NoError(t, err)
+	decoded, err := DecodeSnapshot(encoded)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, decoded)
+}
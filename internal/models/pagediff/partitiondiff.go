@@ -0,0 +1,99 @@
+package pagediff
+
+import "github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+
+// Op is the operation that turns the parent's partition into the child's.
+type Op string
+
+// All the valid values for Op
+const (
+	OpEqual    Op = "equal"
+	OpAdded    Op = "added"
+	OpRemoved  Op = "removed"
+	OpModified Op = "modified"
+)
+
+// PartitionDiff is the structural difference of a single partition.
+// Partition is the child's partition, or the parent's when it was removed. Previous is the parent's partition when it was modified.
+type PartitionDiff struct {
+	Op         Op                    `json:"op"`
+	Partition  pagedetail.Partition  `json:"partition"`
+	Previous   *pagedetail.Partition `json:"previous,omitempty"`
+	Partitions []PartitionDiff       `json:"partitions,omitempty"`
+	Items      []PartitionDiff       `json:"items,omitempty"`
+}
+
+// DiffPartitions returns the structural diff that turns the from partitions into the to partitions.
+// Partitions are aligned by their longest common subsequence. A removed partition that is replaced
+// by an added partition of the same type is reported as modified, along with a diff of its children.
+func DiffPartitions(from, to []pagedetail.Partition) []PartitionDiff {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:] and to[j:].
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if isEqual(from[i], to[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	diffs := []PartitionDiff{}
+	var removed, added []pagedetail.Partition
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && isEqual(from[i], to[j]):
+			diffs = append(diffs, pairChanges(removed, added)...)
+			removed, added = nil, nil
+			diffs = append(diffs, PartitionDiff{Op: OpEqual, Partition: to[j]})
+			i++
+			j++
+		case j >= len(to) || (i < len(from) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, from[i])
+			i++
+		default:
+			added = append(added, to[j])
+			j++
+		}
+	}
+	return append(diffs, pairChanges(removed, added)...)
+}
+
+// pairChanges turns a run of removed and added partitions into diffs, pairing up partitions of the same type as modifications.
+func pairChanges(removed, added []pagedetail.Partition) []PartitionDiff {
+	diffs := []PartitionDiff{}
+	k := 0
+	for ; k < len(removed) && k < len(added); k++ {
+		if removed[k].TypeString != added[k].TypeString {
+			break
+		}
+		previous := removed[k]
+		diffs = append(diffs, PartitionDiff{
+			Op:         OpModified,
+			Partition:  added[k],
+			Previous:   &previous,
+			Partitions: diffChildren(removed[k].Partitions, added[k].Partitions),
+			Items:      diffChildren(removed[k].Items, added[k].Items),
+		})
+	}
+	for _, p := range removed[k:] {
+		diffs = append(diffs, PartitionDiff{Op: OpRemoved, Partition: p})
+	}
+	for _, p := range added[k:] {
+		diffs = append(diffs, PartitionDiff{Op: OpAdded, Partition: p})
+	}
+	return diffs
+}
+
+func diffChildren(from, to []pagedetail.Partition) []PartitionDiff {
+	if len(from) == 0 && len(to) == 0 {
+		return nil
+	}
+	return DiffPartitions(from, to)
+}
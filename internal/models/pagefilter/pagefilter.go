@@ -0,0 +1,133 @@
+package pagefilter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/pkg/errors"
+)
+
+// Filter narrows down a list of pages. Empty fields do not filter.
+type Filter struct {
+	PageTemplateGUID string
+	VersionGUID      string
+	PermissionType   permission.Type
+	// OwnedOnly leaves out the pages that were only shared with the user to edit.
+	OwnedOnly bool
+	// Conditions must all be met by the page's properties.
+	Conditions []Condition
+}
+
+// Operator compares a property's value against the value of a condition.
+type Operator string
+
+// All the valid values for Operator
+const (
+	OperatorEqual          Operator = "="
+	OperatorNotEqual       Operator = "!="
+	OperatorGreater        Operator = ">"
+	OperatorGreaterOrEqual Operator = ">="
+	OperatorLess           Operator = "<"
+	OperatorLessOrEqual    Operator = "<="
+)
+
+// operators are ordered so that two character operators are matched before their one character prefixes.
+var operators = []Operator{OperatorGreaterOrEqual, OperatorLessOrEqual, OperatorNotEqual, OperatorEqual, OperatorGreater, OperatorLess}
+
+// Condition is a comparison that a page's property must meet, such as `population > 10000`.
+// A page without the property never meets the condition.
+type Condition struct {
+	Key      string
+	Operator Operator
+	// Type decides whether the condition is compared against number or string properties.
+	Type        property.Type
+	NumberValue float64
+	StringValue string
+}
+
+// Value returns the value of the condition for its type.
+func (c Condition) Value() interface{} {
+	if c.Type == property.TypeNumber {
+		return c.NumberValue
+	}
+	return c.StringValue
+}
+
+// ParseCondition parses a condition of the form `key operator value`.
+// Quoted values are strings, other values are numbers if they can be, and strings otherwise.
+// Numbers must be finite.
+// Strings can only be compared with = and !=.
+func ParseCondition(s string) (Condition, error) {
+	index, operator := findOperator(s)
+	if index == -1 {
+		return Condition{}, errors.Errorf("property filter %v must be of the form key operator value", s)
+	}
+	c := Condition{
+		Key:      strings.TrimSpace(s[:index]),
+		Operator: operator,
+	}
+	if c.Key == "" {
+		return Condition{}, errors.Errorf("property filter %v must have a property key", s)
+	}
+	value := strings.TrimSpace(s[index+len(operator):])
+	if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+		c.Type = property.TypeString
+		c.StringValue = unquoted
+	} else if number, err := strconv.ParseFloat(value, 64); err == nil {
+		// NaN and infinities cannot be stored in number properties, so they can never be matched.
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return Condition{}, errors.Errorf("property filter %v must compare against a finite number", s)
+		}
+		c.Type = property.TypeNumber
+		c.NumberValue = number
+	} else if value != "" {
+		c.Type = property.TypeString
+		c.StringValue = value
+	} else {
+		return Condition{}, errors.Errorf("property filter %v must have a value", s)
+	}
+	if c.Type == property.TypeString && c.Operator != OperatorEqual && c.Operator != OperatorNotEqual {
+		return Condition{}, errors.Errorf("property filter %v can only compare strings with = or !=", s)
+	}
+	return c, nil
+}
+
+// findOperator returns the first operator in the string and where it starts, or -1 if there is none.
+func findOperator(s string) (int, Operator) {
+	for i := range s {
+		for _, operator := range operators {
+			if strings.HasPrefix(s[i:], string(operator)) {
+				return i, operator
+			}
+		}
+	}
+	return -1, ""
+}
+
+// Facets are the number of pages matching a filter, broken down by page template and by the values of string properties.
+type Facets struct {
+	PageTemplates []PageTemplateFacet `json:"pageTemplates"`
+	Properties    []PropertyFacet     `json:"properties"`
+}
+
+// PageTemplateFacet is the number of matching pages of a page template.
+type PageTemplateFacet struct {
+	PageTemplateGUID string `json:"id"`
+	Name             string `json:"name"`
+	Count            int    `json:"count"`
+}
+
+// PropertyFacet is the number of matching pages for each value of a string property.
+type PropertyFacet struct {
+	Key    string       `json:"key"`
+	Values []ValueFacet `json:"values"`
+}
+
+// ValueFacet is the number of matching pages with a property set to the value.
+type ValueFacet struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,90 @@
+package pagefilter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCondition(t *testing.T) {
+	cases := []struct {
+		name            string
+		paramCondition  string
+		returnCondition Condition
+		returnErr       error
+	}{
+		{
+			name:            "number comparison",
+			paramCondition:  "population > 10000",
+			returnCondition: Condition{Key: "population", Operator: OperatorGreater, Type: property.TypeNumber, NumberValue: 10000},
+		},
+		{
+			name:            "two character operator without spaces",
+			paramCondition:  "population>=1.5e3",
+			returnCondition: Condition{Key: "population", Operator: OperatorGreaterOrEqual, Type: property.TypeNumber, NumberValue: 1500},
+		},
+		{
+			name:            "quoted string",
+			paramCondition:  `faction = "Zhentarim"`,
+			returnCondition: Condition{Key: "faction", Operator: OperatorEqual, Type: property.TypeString, StringValue: "Zhentarim"},
+		},
+		{
+			name:            "quoted number is a string",
+			paramCondition:  `code != "42"`,
+			returnCondition: Condition{Key: "code", Operator: OperatorNotEqual, Type: property.TypeString, StringValue: "42"},
+		},
+		{
+			name:            "unquoted string",
+			paramCondition:  "faction=Lords' Alliance",
+			returnCondition: Condition{Key: "faction", Operator: OperatorEqual, Type: property.TypeString, StringValue: "Lords' Alliance"},
+		},
+		{
+			name:           "no operator",
+			paramCondition: "population",
+			returnErr:      errors.New("property filter population must be of the form key operator value"),
+		},
+		{
+			name:           "no key",
+			paramCondition: " < 5",
+			returnErr:      errors.New("property filter  < 5 must have a property key"),
+		},
+		{
+			name:           "no value",
+			paramCondition: "population <= ",
+			returnErr:      errors.New("property filter population <=  must have a value"),
+		},
+		{
+			name:           "not a number",
+			paramCondition: "population > NaN",
+			returnErr:      errors.New("property filter population > NaN must compare against a finite number"),
+		},
+		{
+			name:           "infinity",
+			paramCondition: "population < Infinity",
+			returnErr:      errors.New("property filter population < Infinity must compare against a finite number"),
+		},
+		{
+			name:           "negative infinity",
+			paramCondition: "population >= -inf",
+			returnErr:      errors.New("property filter population >= -inf must compare against a finite number"),
+		},
+		{
+			name:           "ordering strings",
+			paramCondition: "faction < Zhentarim",
+			returnErr:      errors.New("property filter faction < Zhentarim can only compare strings with = or !="),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseCondition(tc.paramCondition)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnCondition, result)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package pagehtml
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+)
+
+// Renderer renders details as HTML that is safe to embed as it is.
+// Every value is escaped, and links, images and colors that cannot be shown safely are dropped, keeping their text.
+type Renderer struct {
+	// PageURL is prefixed to the id of a related page to link to it.
+	PageURL string
+	// Titles maps the ids of the pages that may be linked to their current titles.
+	// Relations to any other page are rendered as plain text.
+	Titles map[string]string
+}
+
+// Render renders the detail's title, summary and partitions as a section.
+func (r Renderer) Render(detail pagedetail.PageDetail) string {
+	lines := []string{fmt.Sprintf("<section id=\"%v\">", escape(detail.GUID))}
+	if detail.Title != "" {
+		lines = append(lines, "<h1>"+escape(detail.Title)+"</h1>")
+	}
+	if detail.Summary != "" {
+		lines = append(lines, "<p class=\"summary\">"+escape(detail.Summary)+"</p>")
+	}
+	for _, p := range detail.Partitions {
+		lines = append(lines, r.renderBlock(p))
+	}
+	lines = append(lines, "</section>")
+	return strings.Join(lines, "\n")
+}
+
+func (r Renderer) renderBlock(p pagedetail.Partition) string {
+	switch pagedetail.PartitionType(p.TypeString) {
+	case pagedetail.PartitionTypeHeaderOne, pagedetail.PartitionTypeHeaderTwo, pagedetail.PartitionTypeHeaderThree,
+		pagedetail.PartitionTypeHeaderFour, pagedetail.PartitionTypeHeaderFive, pagedetail.PartitionTypeHeaderSix:
+		return fmt.Sprintf("<%v>%v</%v>", p.TypeString, r.renderInline(p), p.TypeString)
+	case pagedetail.PartitionTypeUnorderedList, pagedetail.PartitionTypeOrderedList:
+		return r.renderList(p)
+	case pagedetail.PartitionTypeImage:
+		return r.renderInline(p)
+	case pagedetail.PartitionTypePageBreak:
+		return "<hr>"
+	case pagedetail.PartitionTypeQuotes:
+		return "<blockquote><p>" + r.renderInline(p) + "</p></blockquote>"
+	default:
+		return "<p>" + r.renderInline(p) + "</p>"
+	}
+}
+
+// renderList renders the items of a list, with nested lists inside the item before them.
+func (r Renderer) renderList(p pagedetail.Partition) string {
+	var items []string
+	for _, item := range p.Items {
+		if isList(item) && len(items) > 0 {
+			items[len(items)-1] += r.renderList(item)
+			continue
+		}
+		if isList(item) {
+			items = append(items, r.renderList(item))
+			continue
+		}
+		items = append(items, r.renderInline(item))
+	}
+	var b strings.Builder
+	b.WriteString("<" + p.TypeString + ">")
+	for _, item := range items {
+		b.WriteString("<li>" + item + "</li>")
+	}
+	b.WriteString("</" + p.TypeString + ">")
+	return b.String()
+}
+
+func isList(p pagedetail.Partition) bool {
+	return p.TypeString == string(pagedetail.PartitionTypeUnorderedList) || p.TypeString == string(pagedetail.PartitionTypeOrderedList)
+}
+
+// renderInline renders the partition's value followed by its nested partitions, with the formatting of the partition's type.
+func (r Renderer) renderInline(p pagedetail.Partition) string {
+	var b strings.Builder
+	b.WriteString(escape(p.Value))
+	for _, child := range p.Partitions {
+		b.WriteString(r.renderInline(child))
+	}
+	for _, item := range p.Items {
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(r.renderInline(item))
+	}
+	content := b.String()
+	switch pagedetail.PartitionType(p.TypeString) {
+	case pagedetail.PartitionTypeBold:
+		return "<strong>" + content + "</strong>"
+	case pagedetail.PartitionTypeItalics:
+		return "<em>" + content + "</em>"
+	case pagedetail.PartitionTypeLink:
+		if !isSafeURL(p.Link) {
+			return content
+		}
+		return fmt.Sprintf("<a href=\"%v\" rel=\"nofollow noopener noreferrer\">%v</a>", escape(p.Link), content)
+	case pagedetail.PartitionTypeRelation:
+		return r.renderRelation(p, content)
+	case pagedetail.PartitionTypeColor:
+		if !isHexColor(p.Color) {
+			return content
+		}
+		return fmt.Sprintf("<span style=\"color: %v\">%v</span>", p.Color, content)
+	case pagedetail.PartitionTypeImage:
+		if !isSafeURL(p.Link) {
+			return escape(p.AltText)
+		}
+		return fmt.Sprintf("<img src=\"%v\" alt=\"%v\">", escape(p.Link), escape(p.AltText))
+	default:
+		return content
+	}
+}
+
+// renderRelation links to the related page, labelled with the partition's text or, without any, the page's current title.
+// The current title is always given as the anchor's title, since the text may have been written for an older one.
+func (r Renderer) renderRelation(p pagedetail.Partition, content string) string {
+	title, ok := r.Titles[p.Relation]
+	if !ok {
+		return content
+	}
+	if content == "" {
+		content = escape(title)
+	}
+	return fmt.Sprintf("<a href=\"%v\" class=\"relation\" title=\"%v\">%v</a>", escape(r.PageURL+url.PathEscape(p.Relation)), escape(title), content)
+}
+
+func escape(s string) string {
+	return html.EscapeString(s)
+}
+
+// isSafeURL returns whether the link is an absolute http(s) URL.
+func isSafeURL(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return (scheme == "http" || scheme == "https") && u.Host != ""
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{4}|[0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$`)
+
+// isHexColor returns whether the color is a CSS hex color, such as #F00 or #FF0000.
+func isHexColor(color string) bool {
+	return hexColor.MatchString(color)
+}
@@ -0,0 +1,141 @@
+package pagehtml
+
+import (
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name         string
+		paramTitles  map[string]string
+		paramDetail  pagedetail.PageDetail
+		returnString string
+	}{
+		{
+			name: "test blocks",
+			paramDetail: pagedetail.PageDetail{
+				GUID:    "DT_1",
+				Title:   "History",
+				Summary: "How it came to be",
+				Partitions: []pagedetail.Partition{
+					{TypeString: "h2", Value: "The Founding"},
+					{TypeString: "p", Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "Ruled by "},
+						{TypeString: "bold", Value: "Strahd"},
+						{TypeString: "text", Value: " for "},
+						{TypeString: "italics", Value: "centuries"},
+						{TypeString: "text", Value: "."},
+					}},
+					{TypeString: "hr"},
+					{TypeString: "quotes", Value: "I am the land."},
+				},
+			},
+			returnString: "<section id=\"DT_1\">\n" +
+				"<h1>History</h1>\n" +
+				"<p class=\"summary\">How it came to be</p>\n" +
+				"<h2>The Founding</h2>\n" +
+				"<p>Ruled by <strong>Strahd</strong> for <em>centuries</em>.</p>\n" +
+				"<hr>\n" +
+				"<blockquote><p>I am the land.</p></blockquote>\n" +
+				"</section>",
+		},
+		{
+			name: "test nested lists",
+			paramDetail: pagedetail.PageDetail{
+				GUID: "DT_1",
+				Partitions: []pagedetail.Partition{
+					{TypeString: "ol", Items: []pagedetail.Partition{
+						{TypeString: "text", Value: "Vallaki"},
+						{TypeString: "ul", Items: []pagedetail.Partition{
+							{TypeString: "text", Value: "Blue Water Inn"},
+						}},
+						{TypeString: "text", Value: "Krezk"},
+					}},
+				},
+			},
+			returnString: "<section id=\"DT_1\">\n" +
+				"<ol><li>Vallaki<ul><li>Blue Water Inn</li></ul></li><li>Krezk</li></ol>\n" +
+				"</section>",
+		},
+		{
+			name: "test values are escaped",
+			paramDetail: pagedetail.PageDetail{
+				GUID:  "DT_\"1\"",
+				Title: "<script>alert(1)</script>",
+				Partitions: []pagedetail.Partition{
+					{TypeString: "p", Value: "Fish & <b>chips</b>"},
+					{TypeString: "image", AltText: "\" onerror=\"alert(1)", Link: "https://example.com/a.png?b=1&c=\"2\""},
+				},
+			},
+			returnString: "<section id=\"DT_&#34;1&#34;\">\n" +
+				"<h1>&lt;script&gt;alert(1)&lt;/script&gt;</h1>\n" +
+				"<p>Fish &amp; &lt;b&gt;chips&lt;/b&gt;</p>\n" +
+				"<img src=\"https://example.com/a.png?b=1&amp;c=&#34;2&#34;\" alt=\"&#34; onerror=&#34;alert(1)\">\n" +
+				"</section>",
+		},
+		{
+			name: "test only http links are kept",
+			paramDetail: pagedetail.PageDetail{
+				GUID: "DT_1",
+				Partitions: []pagedetail.Partition{
+					{TypeString: "p", Partitions: []pagedetail.Partition{
+						{TypeString: "link", Value: "map", Link: "HTTPS://example.com/map"},
+						{TypeString: "link", Value: "script", Link: "javascript:alert(1)"},
+						{TypeString: "link", Value: "relative", Link: "/pages/PG_1"},
+						{TypeString: "link", Value: "mail", Link: "mailto:dm@example.com"},
+					}},
+					{TypeString: "image", AltText: "map", Link: "data:image/png;base64,AAAA"},
+				},
+			},
+			returnString: "<section id=\"DT_1\">\n" +
+				"<p><a href=\"HTTPS://example.com/map\" rel=\"nofollow noopener noreferrer\">map</a>scriptrelativemail</p>\n" +
+				"map\n" +
+				"</section>",
+		},
+		{
+			name: "test only hex colors are kept",
+			paramDetail: pagedetail.PageDetail{
+				GUID: "DT_1",
+				Partitions: []pagedetail.Partition{
+					{TypeString: "p", Partitions: []pagedetail.Partition{
+						{TypeString: "color", Value: "red", Color: "#FF0000"},
+						{TypeString: "color", Value: "short", Color: "#f00"},
+						{TypeString: "color", Value: "named", Color: "red"},
+						{TypeString: "color", Value: "injected", Color: "#f00; background: url(x)"},
+					}},
+				},
+			},
+			returnString: "<section id=\"DT_1\">\n" +
+				"<p><span style=\"color: #FF0000\">red</span><span style=\"color: #f00\">short</span>namedinjected</p>\n" +
+				"</section>",
+		},
+		{
+			name:        "test relations",
+			paramTitles: map[string]string{"PG_2": "Vallaki & Surroundings", "PG_3": "Krezk"},
+			paramDetail: pagedetail.PageDetail{
+				GUID: "DT_1",
+				Partitions: []pagedetail.Partition{
+					{TypeString: "p", Partitions: []pagedetail.Partition{
+						{TypeString: "relation", Value: "the town", Relation: "PG_2"},
+						{TypeString: "relation", Relation: "PG_3"},
+						{TypeString: "relation", Value: "Castle Ravenloft", Relation: "PG_4"},
+					}},
+				},
+			},
+			returnString: "<section id=\"DT_1\">\n" +
+				"<p><a href=\"https://example.com/pages/PG_2\" class=\"relation\" title=\"Vallaki &amp; Surroundings\">the town</a>" +
+				"<a href=\"https://example.com/pages/PG_3\" class=\"relation\" title=\"Krezk\">Krezk</a>" +
+				"Castle Ravenloft</p>\n" +
+				"</section>",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer := Renderer{PageURL: "https://example.com/pages/", Titles: tc.paramTitles}
+			require.Equal(t, tc.returnString, renderer.Render(tc.paramDetail))
+		})
+	}
+}
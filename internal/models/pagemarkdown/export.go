@@ -0,0 +1,255 @@
+package pagemarkdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+)
+
+// Export renders the page as a Markdown document.
+// The page's ID, title, summary and properties are written as YAML front matter,
+// followed by each of the page's details with its title as a heading.
+func Export(p page.Page, properties []property.Property) string {
+	blocks := []string{getFrontMatter(p, properties)}
+	for _, detail := range p.PageDetails {
+		blocks = append(blocks, ExportDetail(detail))
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// ExportDetail renders the detail's title, summary and partitions as Markdown.
+func ExportDetail(detail pagedetail.PageDetail) string {
+	var blocks []string
+	if title := strings.TrimSpace(detail.Title); title != "" {
+		blocks = append(blocks, "# "+singleLine(escapeText(title)))
+	}
+	if summary := strings.TrimSpace(detail.Summary); summary != "" {
+		blocks = append(blocks, "*"+escapeText(summary)+"*")
+	}
+	blocks = append(blocks, exportBlocks(detail.Partitions)...)
+	return strings.Join(blocks, "\n\n")
+}
+
+func getFrontMatter(p page.Page, properties []property.Property) string {
+	lines := []string{
+		"---",
+		"id: " + p.GUID,
+		"title: " + strconv.Quote(p.Title),
+	}
+	if p.Summary != "" {
+		lines = append(lines, "summary: "+strconv.Quote(p.Summary))
+	}
+	if len(properties) > 0 {
+		lines = append(lines, "properties:")
+		for _, prop := range properties {
+			lines = append(lines, fmt.Sprintf("  %v: %v", getYAMLKey(prop.Key), getYAMLValue(prop.Value)))
+		}
+	}
+	lines = append(lines, "---")
+	return strings.Join(lines, "\n")
+}
+
+var plainYAMLKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func getYAMLKey(key string) string {
+	if plainYAMLKey.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+func getYAMLValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return strconv.Quote(v)
+	default:
+		return strconv.Quote(fmt.Sprintf("%v", v))
+	}
+}
+
+func exportBlocks(partitions []pagedetail.Partition) []string {
+	blocks := make([]string, 0, len(partitions))
+	for _, partition := range partitions {
+		block := exportBlock(partition)
+		if block == "" {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func exportBlock(p pagedetail.Partition) string {
+	switch pagedetail.PartitionType(p.TypeString) {
+	case pagedetail.PartitionTypeHeaderOne, pagedetail.PartitionTypeHeaderTwo, pagedetail.PartitionTypeHeaderThree,
+		pagedetail.PartitionTypeHeaderFour, pagedetail.PartitionTypeHeaderFive, pagedetail.PartitionTypeHeaderSix:
+		level, _ := strconv.Atoi(p.TypeString[1:])
+		return strings.Repeat("#", level) + " " + singleLine(exportInline(p))
+	case pagedetail.PartitionTypeUnorderedList:
+		return exportList(p.Items, func(int) string { return "- " })
+	case pagedetail.PartitionTypeOrderedList:
+		return exportList(p.Items, func(i int) string { return fmt.Sprintf("%v. ", i+1) })
+	case pagedetail.PartitionTypeImage:
+		return exportImage(p)
+	case pagedetail.PartitionTypePageBreak:
+		return "---"
+	case pagedetail.PartitionTypeQuotes:
+		return prefixLines(exportParagraph(p), "> ", "> ")
+	default:
+		return exportParagraph(p)
+	}
+}
+
+// exportList renders the items of a list, with nested lists indented under the item before them.
+func exportList(items []pagedetail.Partition, getMarker func(int) string) string {
+	var lines []string
+	index := 0
+	indent := ""
+	for _, item := range items {
+		if isList(item) {
+			lines = append(lines, prefixLines(exportBlock(item), indent, indent))
+			continue
+		}
+		marker := getMarker(index)
+		indent = strings.Repeat(" ", len(marker))
+		lines = append(lines, prefixLines(exportParagraph(item), marker, indent))
+		index++
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isList(p pagedetail.Partition) bool {
+	return p.TypeString == string(pagedetail.PartitionTypeUnorderedList) || p.TypeString == string(pagedetail.PartitionTypeOrderedList)
+}
+
+func exportImage(p pagedetail.Partition) string {
+	return fmt.Sprintf("![%v](%v)", singleLine(escapeText(p.AltText)), escapeDestination(p.Link))
+}
+
+// exportParagraph renders the partition's inline content, escaping anything that would otherwise start a different kind of block.
+func exportParagraph(p pagedetail.Partition) string {
+	return escapeBlockStart(exportInline(p))
+}
+
+// exportInline renders the partition's value followed by its nested partitions, with the formatting of the partition's type.
+func exportInline(p pagedetail.Partition) string {
+	var b strings.Builder
+	b.WriteString(escapeText(p.Value))
+	for _, child := range p.Partitions {
+		b.WriteString(exportInline(child))
+	}
+	for _, item := range p.Items {
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(exportInline(item))
+	}
+	content := b.String()
+	switch pagedetail.PartitionType(p.TypeString) {
+	case pagedetail.PartitionTypeBold:
+		return wrapEmphasis(content, "**")
+	case pagedetail.PartitionTypeItalics:
+		return wrapEmphasis(content, "*")
+	case pagedetail.PartitionTypeLink:
+		if p.Link == "" {
+			return content
+		}
+		return fmt.Sprintf("[%v](%v)", content, escapeDestination(p.Link))
+	case pagedetail.PartitionTypeRelation:
+		if p.Relation == "" {
+			return content
+		}
+		if content == "" {
+			return "[[" + p.Relation + "]]"
+		}
+		return "[[" + p.Relation + "|" + content + "]]"
+	case pagedetail.PartitionTypeImage:
+		return exportImage(p)
+	default:
+		return content
+	}
+}
+
+// wrapEmphasis wraps the content in the delimiter, keeping any surrounding whitespace outside of it
+// since emphasis cannot start or end with whitespace.
+func wrapEmphasis(content, delimiter string) string {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return content
+	}
+	start := strings.Index(content, trimmed)
+	return content[:start] + delimiter + trimmed + delimiter + content[start+len(trimmed):]
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+	"|", `\|`,
+)
+
+func escapeText(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var destinationEscaper = strings.NewReplacer(
+	" ", "%20",
+	"(", "%28",
+	")", "%29",
+	"<", "%3C",
+	">", "%3E",
+)
+
+func escapeDestination(link string) string {
+	return destinationEscaper.Replace(link)
+}
+
+var (
+	listStart = regexp.MustCompile(`^(\s*)([-+]|[0-9]+[.)])(\s|$)`)
+	ruleLine  = regexp.MustCompile(`^\s*[-=]+\s*$`)
+)
+
+// escapeBlockStart escapes the start of each line of text that would otherwise be read as a list item, a rule or a heading underline.
+func escapeBlockStart(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if match := listStart.FindStringSubmatchIndex(line); match != nil {
+			markerEnd := match[5]
+			lines[i] = line[:markerEnd-1] + `\` + line[markerEnd-1:]
+		} else if ruleLine.MatchString(line) {
+			start := len(line) - len(strings.TrimLeft(line, " \t"))
+			lines[i] = line[:start] + `\` + line[start:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// prefixLines prefixes the first line of the text with first and every other line with rest.
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
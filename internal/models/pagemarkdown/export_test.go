@@ -0,0 +1,122 @@
+package pagemarkdown
+
+import (
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	cases := []struct {
+		name            string
+		paramPage       page.Page
+		paramProperties []property.Property
+		returnMarkdown  string
+	}{
+		{
+			name:           "page without details",
+			paramPage:      page.Page{GUID: "PG_1", Title: "Barovia"},
+			returnMarkdown: "---\nid: PG_1\ntitle: \"Barovia\"\n---\n",
+		},
+		{
+			name:      "page with properties",
+			paramPage: page.Page{GUID: "PG_1", Title: "Barovia", Summary: "A land of \"mists\""},
+			paramProperties: []property.Property{
+				{Key: "population", Type: property.TypeNumber, Value: float64(1500)},
+				{Key: "ruler", Type: property.TypeString, Value: "Strahd"},
+				{Key: "founded in", Type: property.TypeNumber, Value: 35.5},
+			},
+			returnMarkdown: "---\nid: PG_1\ntitle: \"Barovia\"\nsummary: \"A land of \\\"mists\\\"\"\nproperties:\n  population: 1500\n  ruler: \"Strahd\"\n  \"founded in\": 35.5\n---\n",
+		},
+		{
+			name: "page with details",
+			paramPage: page.Page{
+				GUID:  "PG_1",
+				Title: "Barovia",
+				PageDetails: []pagedetail.PageDetail{
+					{
+						GUID:    "DT_1",
+						Title:   "History",
+						Summary: "How it came to be",
+						Partitions: []pagedetail.Partition{
+							{TypeString: "h1", Value: "The Dark Lord"},
+							{TypeString: "h3", Value: "Before"},
+							{
+								TypeString: "p",
+								Partitions: []pagedetail.Partition{
+									{TypeString: "text", Value: "Strahd rules from "},
+									{TypeString: "relation", Value: "Castle Ravenloft", Relation: "PG_2"},
+									{TypeString: "text", Value: " with "},
+									{TypeString: "bold", Value: "an iron fist "},
+									{TypeString: "text", Value: "and "},
+									{
+										TypeString: "italics",
+										Partitions: []pagedetail.Partition{
+											{TypeString: "link", Value: "dark gifts", Link: "https://example.com/dark gifts"},
+										},
+									},
+									{TypeString: "color", Value: ".", Color: "#FF0000"},
+								},
+							},
+							{TypeString: "hr"},
+							{TypeString: "quotes", Value: "I am the land."},
+							{TypeString: "image", AltText: "The castle", Link: "https://example.com/castle.png"},
+						},
+					},
+					{
+						GUID:  "DT_2",
+						Title: "Places",
+						Partitions: []pagedetail.Partition{
+							{
+								TypeString: "ul",
+								Items: []pagedetail.Partition{
+									{TypeString: "text", Value: "Vallaki"},
+									{
+										TypeString: "ol",
+										Items: []pagedetail.Partition{
+											{TypeString: "relation", Relation: "PG_3"},
+											{TypeString: "text", Value: "Blue Water Inn"},
+										},
+									},
+									{TypeString: "text", Value: "Krezk"},
+								},
+							},
+						},
+					},
+				},
+			},
+			returnMarkdown: "---\nid: PG_1\ntitle: \"Barovia\"\n---\n\n" +
+				"# History\n\n*How it came to be*\n\n# The Dark Lord\n\n### Before\n\n" +
+				"Strahd rules from [[PG_2|Castle Ravenloft]] with **an iron fist** and *[dark gifts](https://example.com/dark%20gifts)*.\n\n" +
+				"---\n\n> I am the land.\n\n![The castle](https://example.com/castle.png)\n\n" +
+				"# Places\n\n- Vallaki\n  1. [[PG_3]]\n  2. Blue Water Inn\n- Krezk\n",
+		},
+		{
+			name: "text that looks like markdown is escaped",
+			paramPage: page.Page{
+				GUID:  "PG_1",
+				Title: "Barovia",
+				PageDetails: []pagedetail.PageDetail{
+					{
+						GUID: "DT_1",
+						Partitions: []pagedetail.Partition{
+							{TypeString: "p", Value: "- not a *list* or [link]"},
+							{TypeString: "p", Value: "1. not a # heading"},
+							{TypeString: "p", Value: "==="},
+						},
+					},
+				},
+			},
+			returnMarkdown: "---\nid: PG_1\ntitle: \"Barovia\"\n---\n\n" +
+				"\\- not a \\*list\\* or \\[link\\]\n\n1\\. not a \\# heading\n\n\\===\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnMarkdown, Export(tc.paramPage, tc.paramProperties))
+		})
+	}
+}
@@ -0,0 +1,555 @@
+package pagemarkdown
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+)
+
+// DefaultDetailTitle is the title of the detail made from the content before the first `#` heading of an imported document.
+const DefaultDetailTitle = "Notes"
+
+// Problem is a part of a Markdown document that could not be represented as partitions as it was written.
+type Problem struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Import parses the Markdown document into page details.
+// Each `#` heading starts a new detail titled with the heading, or the default title if the heading is empty, and an emphasized paragraph directly after it becomes the detail's summary.
+// Content before the first `#` heading goes into a detail titled with the default title.
+// Front matter, such as the front matter written by Export, is skipped.
+func Import(markdown, defaultTitle string) ([]pagedetail.PageDetail, []Problem) {
+	lines := getLines(markdown)
+	p := newParser()
+	lines = p.skipFrontMatter(lines)
+	partitions := p.parse(lines)
+	if defaultTitle == "" {
+		defaultTitle = DefaultDetailTitle
+	}
+	details := make([]pagedetail.PageDetail, 0)
+	for _, partition := range partitions {
+		if partition.TypeString == string(pagedetail.PartitionTypeHeaderOne) {
+			title := strings.TrimSpace(partition.PlainText())
+			if title == "" {
+				title = defaultTitle
+			}
+			details = append(details, pagedetail.PageDetail{Title: title, Partitions: []pagedetail.Partition{}})
+			continue
+		}
+		if len(details) == 0 {
+			details = append(details, pagedetail.PageDetail{Title: defaultTitle, Partitions: []pagedetail.Partition{}})
+		}
+		detail := &details[len(details)-1]
+		if summary, ok := getSummary(partition); ok && len(detail.Partitions) == 0 && detail.Summary == "" {
+			detail.Summary = summary
+			continue
+		}
+		detail.Partitions = append(detail.Partitions, partition)
+	}
+	return details, p.getProblems()
+}
+
+// Parse parses the Markdown document into a partition tree.
+func Parse(markdown string) ([]pagedetail.Partition, []Problem) {
+	p := newParser()
+	partitions := p.parse(getLines(markdown))
+	return partitions, p.getProblems()
+}
+
+// getSummary returns the text of a paragraph that is entirely emphasized.
+func getSummary(partition pagedetail.Partition) (string, bool) {
+	if partition.TypeString != string(pagedetail.PartitionTypeParagraph) || len(partition.Partitions) != 1 {
+		return "", false
+	}
+	child := partition.Partitions[0]
+	if child.TypeString != string(pagedetail.PartitionTypeItalics) {
+		return "", false
+	}
+	return child.PlainText(), true
+}
+
+type line struct {
+	number int
+	text   string
+}
+
+func getLines(markdown string) []line {
+	markdown = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", "    ").Replace(markdown)
+	texts := strings.Split(markdown, "\n")
+	lines := make([]line, len(texts))
+	for i, text := range texts {
+		lines[i] = line{number: i + 1, text: text}
+	}
+	return lines
+}
+
+type parser struct {
+	references map[string]string
+	problems   []Problem
+}
+
+func newParser() *parser {
+	return &parser{references: map[string]string{}}
+}
+
+func (p *parser) report(lineNumber int, format string, a ...interface{}) {
+	p.problems = append(p.problems, Problem{Line: lineNumber, Message: fmt.Sprintf(format, a...)})
+}
+
+func (p *parser) getProblems() []Problem {
+	problems := p.problems
+	if problems == nil {
+		problems = []Problem{}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	return problems
+}
+
+func (p *parser) parse(lines []line) []pagedetail.Partition {
+	lines = p.collectReferences(lines)
+	partitions := p.parseBlocks(lines)
+	// every partition is built with a known type, so this cannot fail
+	_ = pagedetail.UnmarshalPartitions(partitions)
+	return partitions
+}
+
+func (p *parser) skipFrontMatter(lines []line) []line {
+	if len(lines) == 0 || strings.TrimSpace(lines[0].text) != "---" {
+		return lines
+	}
+	for i := 1; i < len(lines); i++ {
+		if text := strings.TrimSpace(lines[i].text); text == "---" || text == "..." {
+			p.report(lines[0].number, "front matter is not imported")
+			return lines[i+1:]
+		}
+	}
+	return lines
+}
+
+var (
+	referenceDefinition = regexp.MustCompile(`^ {0,3}\[((?:[^\]\\]|\\.)+)\]:\s*(<[^>]*>|\S+)(\s+("[^"]*"|'[^']*'|\([^)]*\)))?\s*$`)
+	fenceStart          = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+)
+
+// collectReferences removes the link reference definitions from the lines, keeping their destinations for reference links.
+func (p *parser) collectReferences(lines []line) []line {
+	result := make([]line, 0, len(lines))
+	fence := ""
+	canStartDefinition := true
+	for _, l := range lines {
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(l.text), fence) {
+				fence = ""
+			}
+			result = append(result, l)
+			continue
+		}
+		if m := fenceStart.FindStringSubmatch(l.text); m != nil {
+			fence = m[1]
+		}
+		if m := referenceDefinition.FindStringSubmatch(l.text); m != nil && canStartDefinition && fence == "" {
+			label := normalizeLabel(unescape(m[1]))
+			if _, ok := p.references[label]; !ok {
+				p.references[label] = unescape(strings.TrimSuffix(strings.TrimPrefix(m[2], "<"), ">"))
+			}
+			if m[3] != "" {
+				p.report(l.number, "link titles are not imported")
+			}
+			result = append(result, line{number: l.number})
+			continue
+		}
+		canStartDefinition = isBlank(l.text)
+		result = append(result, l)
+	}
+	return result
+}
+
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+var (
+	atxHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)?[ ]*$`)
+	thematicBreak  = regexp.MustCompile(`^ {0,3}((\*[ ]*){3,}|(-[ ]*){3,}|(_[ ]*){3,})$`)
+	setextOne      = regexp.MustCompile(`^ {0,3}=+[ ]*$`)
+	setextTwo      = regexp.MustCompile(`^ {0,3}-+[ ]*$`)
+	listItemStart  = regexp.MustCompile(`^( {0,3})([-+*]|([0-9]{1,9})([.)]))([ ]+|$)`)
+	htmlBlockStart = regexp.MustCompile(`^ {0,3}<(/?[A-Za-z][A-Za-z0-9-]*[\s/>]|/?[A-Za-z][A-Za-z0-9-]*$|!--|\?|![A-Z])`)
+)
+
+func isBlank(text string) bool {
+	return strings.TrimSpace(text) == ""
+}
+
+func getIndent(text string) int {
+	return len(text) - len(strings.TrimLeft(text, " "))
+}
+
+// startsBlock returns true if the line starts a block that can interrupt a paragraph.
+func startsBlock(text string) bool {
+	if atxHeading.MatchString(text) || thematicBreak.MatchString(text) || fenceStart.MatchString(text) || htmlBlockStart.MatchString(text) {
+		return true
+	}
+	if strings.HasPrefix(strings.TrimLeft(text, " "), ">") && getIndent(text) < 4 {
+		return true
+	}
+	if m := listItemStart.FindStringSubmatch(text); m != nil {
+		// only lists starting at 1 and items with content can interrupt a paragraph
+		return (m[3] == "" || m[3] == "1") && !isBlank(text[len(m[0]):])
+	}
+	return false
+}
+
+func (p *parser) parseBlocks(lines []line) []pagedetail.Partition {
+	partitions := make([]pagedetail.Partition, 0)
+	i := 0
+	for i < len(lines) {
+		l := lines[i]
+		switch {
+		case isBlank(l.text):
+			i++
+		case getIndent(l.text) >= 4:
+			var partition pagedetail.Partition
+			partition, i = p.parseIndentedCode(lines, i)
+			partitions = append(partitions, partition)
+		case fenceStart.MatchString(l.text):
+			var partition pagedetail.Partition
+			partition, i = p.parseFencedCode(lines, i)
+			partitions = append(partitions, partition)
+		case atxHeading.MatchString(l.text):
+			m := atxHeading.FindStringSubmatch(l.text)
+			partitions = append(partitions, newBlock(fmt.Sprintf("h%v", len(m[1])), p.parseInline(m[2], l.number)))
+			i++
+		case thematicBreak.MatchString(l.text):
+			partitions = append(partitions, pagedetail.Partition{TypeString: string(pagedetail.PartitionTypePageBreak)})
+			i++
+		case strings.HasPrefix(strings.TrimLeft(l.text, " "), ">"):
+			var partition pagedetail.Partition
+			partition, i = p.parseQuote(lines, i)
+			partitions = append(partitions, partition)
+		case listItemStart.MatchString(l.text):
+			var partition pagedetail.Partition
+			partition, i = p.parseList(lines, i)
+			partitions = append(partitions, partition)
+		case htmlBlockStart.MatchString(l.text):
+			start := i
+			for i < len(lines) && !isBlank(lines[i].text) {
+				i++
+			}
+			p.report(l.number, "HTML is not supported and is kept as plain text")
+			partitions = append(partitions, newPlainParagraph(joinLines(lines[start:i], 0)))
+		default:
+			var partition pagedetail.Partition
+			partition, i = p.parseParagraph(lines, i)
+			partitions = append(partitions, partition)
+		}
+	}
+	return partitions
+}
+
+func joinLines(lines []line, indent int) string {
+	texts := make([]string, len(lines))
+	for i, l := range lines {
+		texts[i] = stripIndent(l.text, indent)
+	}
+	return strings.Join(texts, "\n")
+}
+
+// joinTrimmedLines joins the lines of a paragraph, whose indentation does not matter.
+func joinTrimmedLines(lines []line) string {
+	texts := make([]string, len(lines))
+	for i, l := range lines {
+		texts[i] = strings.TrimLeft(l.text, " ")
+	}
+	return strings.TrimRight(strings.Join(texts, "\n"), " ")
+}
+
+// stripIndent removes up to the number of leading spaces from the text.
+func stripIndent(text string, indent int) string {
+	if i := getIndent(text); i < indent {
+		indent = i
+	}
+	return text[indent:]
+}
+
+func newPlainParagraph(text string) pagedetail.Partition {
+	return pagedetail.Partition{TypeString: string(pagedetail.PartitionTypeParagraph), Value: text}
+}
+
+// getInlineContent returns the content of a paragraph as inline partitions.
+func getInlineContent(paragraph pagedetail.Partition) []pagedetail.Partition {
+	if paragraph.Value != "" {
+		return []pagedetail.Partition{newText(paragraph.Value)}
+	}
+	return paragraph.Partitions
+}
+
+func (p *parser) parseIndentedCode(lines []line, i int) (pagedetail.Partition, int) {
+	start := i
+	end := i
+	for i < len(lines) && (isBlank(lines[i].text) || getIndent(lines[i].text) >= 4) {
+		if !isBlank(lines[i].text) {
+			end = i + 1
+		}
+		i++
+	}
+	p.report(lines[start].number, "code blocks are not supported and are kept as plain text")
+	return newPlainParagraph(joinLines(lines[start:end], 4)), end
+}
+
+func (p *parser) parseFencedCode(lines []line, i int) (pagedetail.Partition, int) {
+	start := lines[i]
+	indent := getIndent(start.text)
+	fence := fenceStart.FindStringSubmatch(start.text)[1]
+	i++
+	contentStart := i
+	for i < len(lines) {
+		text := strings.TrimSpace(lines[i].text)
+		if strings.HasPrefix(text, fence) && strings.Trim(text, fence[:1]) == "" {
+			break
+		}
+		i++
+	}
+	content := joinLines(lines[contentStart:i], indent)
+	if i < len(lines) {
+		i++
+	}
+	p.report(start.number, "code blocks are not supported and are kept as plain text")
+	return newPlainParagraph(content), i
+}
+
+func (p *parser) parseParagraph(lines []line, i int) (pagedetail.Partition, int) {
+	start := i
+	i++
+	for i < len(lines) {
+		text := lines[i].text
+		if setextOne.MatchString(text) || setextTwo.MatchString(text) {
+			level := "h1"
+			if setextTwo.MatchString(text) {
+				level = "h2"
+			}
+			return newBlock(level, p.parseInline(joinTrimmedLines(lines[start:i]), lines[start].number)), i + 1
+		}
+		if isBlank(text) || startsBlock(text) {
+			break
+		}
+		i++
+	}
+	children := p.parseInline(joinTrimmedLines(lines[start:i]), lines[start].number)
+	if len(children) == 1 && children[0].TypeString == string(pagedetail.PartitionTypeImage) {
+		return children[0], i
+	}
+	return newBlock(string(pagedetail.PartitionTypeParagraph), children), i
+}
+
+func (p *parser) parseQuote(lines []line, i int) (pagedetail.Partition, int) {
+	start := lines[i].number
+	quoted := make([]line, 0)
+	lazy := false
+	for i < len(lines) {
+		text := lines[i].text
+		trimmed := strings.TrimLeft(text, " ")
+		if strings.HasPrefix(trimmed, ">") && getIndent(text) < 4 {
+			text = strings.TrimPrefix(trimmed[1:], " ")
+			lazy = !isBlank(text) && !startsBlock(text)
+		} else if !lazy || isBlank(text) || startsBlock(text) {
+			break
+		}
+		quoted = append(quoted, line{number: lines[i].number, text: text})
+		i++
+	}
+	children := make([]pagedetail.Partition, 0)
+	for _, block := range p.parseBlocks(quoted) {
+		if len(children) > 0 {
+			children = append(children, newText("\n"))
+		}
+		if block.TypeString == string(pagedetail.PartitionTypeParagraph) {
+			children = append(children, getInlineContent(block)...)
+			continue
+		}
+		p.report(start, "a quote can only hold text, so the %v in it is kept as plain text", getBlockName(block))
+		children = append(children, newText(block.PlainText()))
+	}
+	return newBlock(string(pagedetail.PartitionTypeQuotes), children), i
+}
+
+func getBlockName(block pagedetail.Partition) string {
+	switch pagedetail.PartitionType(block.TypeString) {
+	case pagedetail.PartitionTypeHeaderOne, pagedetail.PartitionTypeHeaderTwo, pagedetail.PartitionTypeHeaderThree,
+		pagedetail.PartitionTypeHeaderFour, pagedetail.PartitionTypeHeaderFive, pagedetail.PartitionTypeHeaderSix:
+		return "heading"
+	case pagedetail.PartitionTypeUnorderedList, pagedetail.PartitionTypeOrderedList:
+		return "list"
+	case pagedetail.PartitionTypeImage:
+		return "image"
+	case pagedetail.PartitionTypeQuotes:
+		return "quote"
+	case pagedetail.PartitionTypePageBreak:
+		return "rule"
+	default:
+		return "paragraph"
+	}
+}
+
+// listMarker is the kind of marker of a list item. Items with a different kind of marker start a new list.
+type listMarker struct {
+	bullet    string
+	delimiter string
+}
+
+func getListItem(text string) (marker listMarker, number string, contentIndent int, ok bool) {
+	m := listItemStart.FindStringSubmatch(text)
+	if m == nil || thematicBreak.MatchString(text) {
+		return marker, "", 0, false
+	}
+	if m[3] != "" {
+		marker.delimiter = m[4]
+	} else {
+		marker.bullet = m[2]
+	}
+	width := len(m[1]) + len(m[2])
+	spaces := len(m[5])
+	if spaces == 0 || spaces > 4 || isBlank(text[len(m[0]):]) {
+		spaces = 1
+	}
+	return marker, m[3], width + spaces, true
+}
+
+func (p *parser) parseList(lines []line, i int) (pagedetail.Partition, int) {
+	marker, number, _, _ := getListItem(lines[i].text)
+	list := pagedetail.Partition{TypeString: string(pagedetail.PartitionTypeUnorderedList), Items: []pagedetail.Partition{}}
+	if marker.delimiter != "" {
+		list.TypeString = string(pagedetail.PartitionTypeOrderedList)
+		if start, _ := strconv.Atoi(number); start != 1 {
+			p.report(lines[i].number, "ordered lists always start at 1, so the list starting at %v is renumbered", start)
+		}
+	}
+	for i < len(lines) {
+		itemMarker, _, contentIndent, ok := getListItem(lines[i].text)
+		if !ok || itemMarker != marker {
+			break
+		}
+		text := lines[i].text
+		if len(text) > contentIndent {
+			text = text[contentIndent:]
+		} else {
+			text = ""
+		}
+		itemLines := []line{{number: lines[i].number, text: text}}
+		i++
+		lazy := !isBlank(itemLines[0].text)
+		for i < len(lines) {
+			text := lines[i].text
+			if isBlank(text) {
+				itemLines = append(itemLines, line{number: lines[i].number})
+				lazy = false
+			} else if getIndent(text) >= contentIndent {
+				itemLines = append(itemLines, line{number: lines[i].number, text: text[contentIndent:]})
+				lazy = true
+			} else if _, _, _, isItem := getListItem(text); lazy && !isItem && !startsBlock(text) {
+				itemLines = append(itemLines, lines[i])
+			} else {
+				break
+			}
+			i++
+		}
+		// blank lines at the end of an item belong between the items
+		for len(itemLines) > 1 && isBlank(itemLines[len(itemLines)-1].text) {
+			itemLines = itemLines[:len(itemLines)-1]
+			i--
+		}
+		list.Items = append(list.Items, p.getListItems(p.parseBlocks(itemLines), itemLines[0].number)...)
+		for i < len(lines) && isBlank(lines[i].text) {
+			i++
+		}
+	}
+	return list, i
+}
+
+// getListItems returns the items for the blocks of a list item. Nested lists and images are items of their own.
+func (p *parser) getListItems(blocks []pagedetail.Partition, lineNumber int) []pagedetail.Partition {
+	items := make([]pagedetail.Partition, 0)
+	content := make([]pagedetail.Partition, 0)
+	hasContent := false
+	flush := func() {
+		if hasContent {
+			items = append(items, newItem(content))
+		}
+		content = make([]pagedetail.Partition, 0)
+		hasContent = false
+	}
+	for _, block := range blocks {
+		switch pagedetail.PartitionType(block.TypeString) {
+		case pagedetail.PartitionTypeParagraph:
+			if hasContent {
+				content = append(content, newText("\n"))
+			}
+			content = append(content, getInlineContent(block)...)
+			hasContent = true
+		case pagedetail.PartitionTypeUnorderedList, pagedetail.PartitionTypeOrderedList, pagedetail.PartitionTypeImage:
+			flush()
+			items = append(items, block)
+		default:
+			p.report(lineNumber, "a list item can only hold text, lists and images, so the %v in it is kept as plain text", getBlockName(block))
+			if hasContent {
+				content = append(content, newText("\n"))
+			}
+			content = append(content, newText(block.PlainText()))
+			hasContent = true
+		}
+	}
+	flush()
+	if len(items) == 0 {
+		items = append(items, newText(""))
+	}
+	return items
+}
+
+// newItem returns the item for the inline content of a list item, as a paragraph if it is more than a single partition.
+func newItem(content []pagedetail.Partition) pagedetail.Partition {
+	content = mergeText(content)
+	if len(content) == 1 {
+		return content[0]
+	}
+	return newBlock(string(pagedetail.PartitionTypeParagraph), content)
+}
+
+func newText(value string) pagedetail.Partition {
+	return pagedetail.Partition{TypeString: string(pagedetail.PartitionTypeText), Value: value}
+}
+
+func isPlainText(p pagedetail.Partition) bool {
+	return p.TypeString == string(pagedetail.PartitionTypeText) && len(p.Partitions) == 0
+}
+
+// newBlock returns a partition of the type holding the children, as its value if they are just text.
+func newBlock(typeString string, children []pagedetail.Partition) pagedetail.Partition {
+	children = mergeText(children)
+	partition := pagedetail.Partition{TypeString: typeString}
+	if len(children) == 1 && isPlainText(children[0]) {
+		partition.Value = children[0].Value
+	} else if len(children) > 0 {
+		partition.Partitions = children
+	}
+	return partition
+}
+
+// mergeText merges neighbouring text partitions.
+func mergeText(partitions []pagedetail.Partition) []pagedetail.Partition {
+	merged := make([]pagedetail.Partition, 0, len(partitions))
+	for _, partition := range partitions {
+		if n := len(merged); n > 0 && isPlainText(partition) && isPlainText(merged[n-1]) {
+			merged[n-1].Value += partition.Value
+			continue
+		}
+		merged = append(merged, partition)
+	}
+	return merged
+}
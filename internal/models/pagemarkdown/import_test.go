@@ -0,0 +1,358 @@
+package pagemarkdown
+
+import (
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name             string
+		paramMarkdown    string
+		returnPartitions []pagedetail.Partition
+		returnProblems   []Problem
+	}{
+		{
+			name:             "empty document",
+			returnPartitions: []pagedetail.Partition{},
+		},
+		{
+			name:          "headings and rules",
+			paramMarkdown: "# One\n\n### Three ###\n\n***\n\nTwo\n---\n\nOne again\n===",
+			returnPartitions: []pagedetail.Partition{
+				{TypeString: "h1", Value: "One"},
+				{TypeString: "h3", Value: "Three"},
+				{TypeString: "hr"},
+				{TypeString: "h2", Value: "Two"},
+				{TypeString: "h1", Value: "One again"},
+			},
+		},
+		{
+			name:          "emphasis",
+			paramMarkdown: "Strahd is **very *old*** and _snake_case_ stays, but * this * does not",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "p",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "Strahd is "},
+						{
+							TypeString: "bold",
+							Partitions: []pagedetail.Partition{
+								{TypeString: "text", Value: "very "},
+								{TypeString: "italics", Value: "old"},
+							},
+						},
+						{TypeString: "text", Value: " and "},
+						{TypeString: "italics", Value: "snake_case"},
+						{TypeString: "text", Value: " stays, but * this * does not"},
+					},
+				},
+			},
+		},
+		{
+			name:          "links and images",
+			paramMarkdown: "[ravenloft]: https://example.com/ravenloft\n\nSee [the castle](https://example.com/castle \"Castle\"), [Ravenloft] and <https://example.com>.\n\n![The castle](https://example.com/castle.png)",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "p",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "See "},
+						{TypeString: "link", Value: "the castle", Link: "https://example.com/castle"},
+						{TypeString: "text", Value: ", "},
+						{TypeString: "link", Value: "Ravenloft", Link: "https://example.com/ravenloft"},
+						{TypeString: "text", Value: " and "},
+						{TypeString: "link", Value: "https://example.com", Link: "https://example.com"},
+						{TypeString: "text", Value: "."},
+					},
+				},
+				{TypeString: "image", AltText: "The castle", Link: "https://example.com/castle.png"},
+			},
+			returnProblems: []Problem{
+				{Line: 3, Message: "link titles are not imported"},
+			},
+		},
+		{
+			name:          "relations",
+			paramMarkdown: "Ruled from [[PG_2]] by [[PG_3|the *dark* lord]],\nnot [[Strahd]] or [[PG_4|a \\| b]].",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "p",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "Ruled from "},
+						{TypeString: "relation", Relation: "PG_2"},
+						{TypeString: "text", Value: " by "},
+						{
+							TypeString: "relation",
+							Relation:   "PG_3",
+							Partitions: []pagedetail.Partition{
+								{TypeString: "text", Value: "the "},
+								{TypeString: "italics", Value: "dark"},
+								{TypeString: "text", Value: " lord"},
+							},
+						},
+						{TypeString: "text", Value: ", not Strahd or "},
+						{TypeString: "relation", Value: "a | b", Relation: "PG_4"},
+						{TypeString: "text", Value: "."},
+					},
+				},
+			},
+			returnProblems: []Problem{
+				{Line: 2, Message: "the relation [[Strahd]] must link to a page ID such as [[PG_123456789012]], so it is kept as plain text"},
+			},
+		},
+		{
+			name:          "lists",
+			paramMarkdown: "- Vallaki\n- Krezk\n  1. Abbey\n  2. Pool\n\n3. Three\n4. Four",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "ul",
+					Items: []pagedetail.Partition{
+						{TypeString: "text", Value: "Vallaki"},
+						{TypeString: "text", Value: "Krezk"},
+						{
+							TypeString: "ol",
+							Items: []pagedetail.Partition{
+								{TypeString: "text", Value: "Abbey"},
+								{TypeString: "text", Value: "Pool"},
+							},
+						},
+					},
+				},
+				{
+					TypeString: "ol",
+					Items: []pagedetail.Partition{
+						{TypeString: "text", Value: "Three"},
+						{TypeString: "text", Value: "Four"},
+					},
+				},
+			},
+			returnProblems: []Problem{
+				{Line: 6, Message: "ordered lists always start at 1, so the list starting at 3 is renumbered"},
+			},
+		},
+		{
+			name:          "list item with other blocks",
+			paramMarkdown: "- **Vallaki**\n\n  ## Inns\n\n- ![Krezk](krezk.png)",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "ul",
+					Items: []pagedetail.Partition{
+						{
+							TypeString: "p",
+							Partitions: []pagedetail.Partition{
+								{TypeString: "bold", Value: "Vallaki"},
+								{TypeString: "text", Value: "\nInns"},
+							},
+						},
+						{TypeString: "image", AltText: "Krezk", Link: "krezk.png"},
+					},
+				},
+			},
+			returnProblems: []Problem{
+				{Line: 1, Message: "a list item can only hold text, lists and images, so the heading in it is kept as plain text"},
+			},
+		},
+		{
+			name:          "quotes",
+			paramMarkdown: "> I am *the* land.\nThe land is me.\n>\n> - Strahd",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "quotes",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "I am "},
+						{TypeString: "italics", Value: "the"},
+						{TypeString: "text", Value: " land. The land is me.\nStrahd"},
+					},
+				},
+			},
+			returnProblems: []Problem{
+				{Line: 1, Message: "a quote can only hold text, so the list in it is kept as plain text"},
+			},
+		},
+		{
+			name:          "unsupported markdown",
+			paramMarkdown: "Use `roll()`\nor <b>this</b>.\n\n```go\nroll()\n```\n\n    indented\n\n<div>\nhtml\n</div>",
+			returnPartitions: []pagedetail.Partition{
+				{TypeString: "p", Value: "Use roll() or <b>this</b>."},
+				{TypeString: "p", Value: "roll()"},
+				{TypeString: "p", Value: "indented"},
+				{TypeString: "p", Value: "<div>\nhtml\n</div>"},
+			},
+			returnProblems: []Problem{
+				{Line: 1, Message: "inline code is not supported and is kept as plain text"},
+				{Line: 2, Message: "HTML is not supported and is kept as plain text"},
+				{Line: 2, Message: "HTML is not supported and is kept as plain text"},
+				{Line: 4, Message: "code blocks are not supported and are kept as plain text"},
+				{Line: 8, Message: "code blocks are not supported and are kept as plain text"},
+				{Line: 10, Message: "HTML is not supported and is kept as plain text"},
+			},
+		},
+		{
+			name:          "escapes, entities and line breaks",
+			paramMarkdown: "\\*not emphasis\\* &amp; &copy;  \nnext\\\nlast",
+			returnPartitions: []pagedetail.Partition{
+				{TypeString: "p", Value: "*not emphasis* & ©\nnext\nlast"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, pagedetail.UnmarshalPartitions(tc.returnPartitions))
+			if tc.returnProblems == nil {
+				tc.returnProblems = []Problem{}
+			}
+			partitions, problems := Parse(tc.paramMarkdown)
+			require.Equal(t, tc.returnPartitions, partitions)
+			require.Equal(t, tc.returnProblems, problems)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	cases := []struct {
+		name              string
+		paramMarkdown     string
+		paramDefaultTitle string
+		returnDetails     []pagedetail.PageDetail
+		returnProblems    []Problem
+	}{
+		{
+			name:          "empty document",
+			returnDetails: []pagedetail.PageDetail{},
+		},
+		{
+			name:          "content before the first heading",
+			paramMarkdown: "Mists surround the land.\n\n# History\n\n*How it came to be*\n\nLong ago.\n\n# Places",
+			returnDetails: []pagedetail.PageDetail{
+				{
+					Title: DefaultDetailTitle,
+					Partitions: []pagedetail.Partition{
+						{TypeString: "p", Value: "Mists surround the land."},
+					},
+				},
+				{
+					Title:   "History",
+					Summary: "How it came to be",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "p", Value: "Long ago."},
+					},
+				},
+				{
+					Title:      "Places",
+					Partitions: []pagedetail.Partition{},
+				},
+			},
+		},
+		{
+			name:              "default title",
+			paramMarkdown:     "*The land of mists*",
+			paramDefaultTitle: "Overview",
+			returnDetails: []pagedetail.PageDetail{
+				{
+					Title:   "Overview",
+					Summary: "The land of mists",
+				},
+			},
+		},
+		{
+			name:          "front matter",
+			paramMarkdown: "---\nid: PG_1\ntitle: \"Barovia\"\n---\n\n# History\n\nPartly *emphasized*.",
+			returnDetails: []pagedetail.PageDetail{
+				{
+					Title: "History",
+					Partitions: []pagedetail.Partition{
+						{
+							TypeString: "p",
+							Partitions: []pagedetail.Partition{
+								{TypeString: "text", Value: "Partly "},
+								{TypeString: "italics", Value: "emphasized"},
+								{TypeString: "text", Value: "."},
+							},
+						},
+					},
+				},
+			},
+			returnProblems: []Problem{
+				{Line: 1, Message: "front matter is not imported"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := range tc.returnDetails {
+				if tc.returnDetails[i].Partitions == nil {
+					tc.returnDetails[i].Partitions = []pagedetail.Partition{}
+				}
+				require.NoError(t, pagedetail.UnmarshalPartitions(tc.returnDetails[i].Partitions))
+			}
+			if tc.returnProblems == nil {
+				tc.returnProblems = []Problem{}
+			}
+			details, problems := Import(tc.paramMarkdown, tc.paramDefaultTitle)
+			require.Equal(t, tc.returnDetails, details)
+			require.Equal(t, tc.returnProblems, problems)
+		})
+	}
+}
+
+func TestImportExported(t *testing.T) {
+	details := []pagedetail.PageDetail{
+		{
+			Title:   "History",
+			Summary: "How it came to be",
+			Partitions: []pagedetail.Partition{
+				{TypeString: "h3", Value: "Before"},
+				{
+					TypeString: "p",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "Strahd rules from "},
+						{TypeString: "relation", Value: "Castle [Ravenloft]", Relation: "PG_2"},
+						{TypeString: "text", Value: " with "},
+						{TypeString: "bold", Value: "an iron fist"},
+						{TypeString: "text", Value: " and "},
+						{
+							TypeString: "italics",
+							Partitions: []pagedetail.Partition{
+								{TypeString: "link", Value: "dark gifts", Link: "https://example.com/gifts"},
+							},
+						},
+						{TypeString: "text", Value: "."},
+					},
+				},
+				{TypeString: "hr"},
+				{TypeString: "quotes", Value: "I am the land."},
+				{TypeString: "image", AltText: "The castle", Link: "https://example.com/castle.png"},
+				{TypeString: "p", Value: "- not a *list* or [link]"},
+			},
+		},
+		{
+			Title: "Places",
+			Partitions: []pagedetail.Partition{
+				{
+					TypeString: "ul",
+					Items: []pagedetail.Partition{
+						{TypeString: "text", Value: "Vallaki"},
+						{
+							TypeString: "ol",
+							Items: []pagedetail.Partition{
+								{TypeString: "relation", Relation: "PG_3"},
+								{TypeString: "text", Value: "Blue Water Inn"},
+							},
+						},
+						{TypeString: "text", Value: "Krezk"},
+					},
+				},
+			},
+		},
+	}
+	for i := range details {
+		require.NoError(t, pagedetail.UnmarshalPartitions(details[i].Partitions))
+	}
+	markdown := Export(page.Page{GUID: "PG_1", Title: "Barovia", PageDetails: details}, nil)
+	result, problems := Import(markdown, "")
+	require.Equal(t, details, result)
+	require.Equal(t, []Problem{{Line: 1, Message: "front matter is not imported"}}, problems)
+}
@@ -0,0 +1,497 @@
+package pagemarkdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+)
+
+// inlineNode is a node of the list that inline content is parsed into before emphasis and links are resolved.
+// Delimiter nodes are runs of `*` or `_` that may become emphasis, or the `[` and `![` that may start a link or image.
+// All other nodes hold finished partitions.
+type inlineNode struct {
+	partition pagedetail.Partition
+	delimiter string
+	// count is the number of delimiter characters of a run that have not been used for emphasis.
+	count         int
+	originalCount int
+	canOpen       bool
+	canClose      bool
+	// active is false for a `[` that can no longer start a link, since links cannot contain other links.
+	active bool
+	// start is where the text of a `[` or `![` starts in the source.
+	start int
+	prev  *inlineNode
+	next  *inlineNode
+}
+
+func (n *inlineNode) isEmphasis() bool {
+	return n.delimiter == "*" || n.delimiter == "_"
+}
+
+func (n *inlineNode) isBracket() bool {
+	return n.delimiter == "[" || n.delimiter == "!["
+}
+
+type inlineParser struct {
+	*parser
+	s          string
+	lineNumber int
+	head       *inlineNode
+	tail       *inlineNode
+	brackets   []*inlineNode
+	pending    string
+}
+
+// parseInline parses the inline content of a block, whose first line is the given line number.
+func (p *parser) parseInline(s string, lineNumber int) []pagedetail.Partition {
+	head := &inlineNode{}
+	ip := &inlineParser{parser: p, s: s, lineNumber: lineNumber, head: head, tail: head}
+	return ip.parse()
+}
+
+var (
+	relationTarget = regexp.MustCompile(`^PG_[A-Za-z0-9]+$`)
+	uriAutolink    = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^<>\s]*)>`)
+	emailAutolink  = regexp.MustCompile(`^<([A-Za-z0-9.!#$%&'*+/=?^_` + "`" + `{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*)>`)
+	inlineHTML     = regexp.MustCompile(`^(?:</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>|<!--[\s\S]*?-->)`)
+	entity         = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9A-Fa-f]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+)
+
+func (ip *inlineParser) parse() []pagedetail.Partition {
+	i := 0
+	for i < len(ip.s) {
+		c := ip.s[i]
+		switch {
+		case c == '\\' && i+1 < len(ip.s) && ip.s[i+1] == '\n':
+			ip.pending = strings.TrimRight(ip.pending, " ") + "\n"
+			i = skipSpaces(ip.s, i+2)
+		case c == '\\' && i+1 < len(ip.s) && isASCIIPunctuation(ip.s[i+1]):
+			ip.pending += ip.s[i+1 : i+2]
+			i += 2
+		case c == '\n':
+			if strings.HasSuffix(ip.pending, "  ") {
+				ip.pending = strings.TrimRight(ip.pending, " ") + "\n"
+			} else {
+				ip.pending = strings.TrimRight(ip.pending, " ") + " "
+			}
+			i = skipSpaces(ip.s, i+1)
+		case c == '`':
+			i = ip.parseCode(i)
+		case strings.HasPrefix(ip.s[i:], "[["):
+			i = ip.parseRelation(i)
+		case c == '[':
+			ip.pushBracket("[", i+1)
+			i++
+		case strings.HasPrefix(ip.s[i:], "!["):
+			ip.pushBracket("![", i+2)
+			i += 2
+		case c == ']':
+			i = ip.closeBracket(i)
+		case c == '*' || c == '_':
+			i = ip.pushDelimiterRun(i)
+		case c == '<':
+			i = ip.parseAngleBrackets(i)
+		case c == '&':
+			if m := entity.FindString(ip.s[i:]); m != "" {
+				ip.pending += html.UnescapeString(m)
+				i += len(m)
+			} else {
+				ip.pending += "&"
+				i++
+			}
+		default:
+			ip.pending += ip.s[i : i+1]
+			i++
+		}
+	}
+	ip.flush()
+	ip.processEmphasis(ip.head)
+	return getPartitions(ip.head.next, nil)
+}
+
+func (ip *inlineParser) getLine(i int) int {
+	return ip.lineNumber + strings.Count(ip.s[:i], "\n")
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return i
+}
+
+func isASCIIPunctuation(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) != -1
+}
+
+func isPunctuation(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// flush adds the pending text to the list as a node.
+func (ip *inlineParser) flush() {
+	if ip.pending == "" {
+		return
+	}
+	text := &inlineNode{partition: newText(ip.pending)}
+	ip.pending = ""
+	ip.link(text)
+}
+
+// append adds the node to the list after any pending text.
+func (ip *inlineParser) append(n *inlineNode) {
+	ip.flush()
+	ip.link(n)
+}
+
+func (ip *inlineParser) link(n *inlineNode) {
+	n.prev = ip.tail
+	ip.tail.next = n
+	ip.tail = n
+}
+
+func (ip *inlineParser) remove(n *inlineNode) {
+	n.prev.next = n.next
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		ip.tail = n.prev
+	}
+}
+
+func (ip *inlineParser) parseCode(i int) int {
+	run := len(ip.s[i:]) - len(strings.TrimLeft(ip.s[i:], "`"))
+	for j := i + run; j < len(ip.s); {
+		if ip.s[j] != '`' {
+			j++
+			continue
+		}
+		closing := len(ip.s[j:]) - len(strings.TrimLeft(ip.s[j:], "`"))
+		if closing == run {
+			code := strings.Replace(ip.s[i+run:j], "\n", " ", -1)
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			ip.report(ip.getLine(i), "inline code is not supported and is kept as plain text")
+			ip.pending += code
+			return j + closing
+		}
+		j += closing
+	}
+	ip.pending += ip.s[i : i+run]
+	return i + run
+}
+
+// parseRelation parses a relation of the form `[[PG_123456789012]]` or `[[PG_123456789012|text]]`.
+func (ip *inlineParser) parseRelation(i int) int {
+	end := findUnescaped(ip.s, "]]", i+2)
+	if end == -1 {
+		ip.pushBracket("[", i+1)
+		return i + 1
+	}
+	target := ip.s[i+2 : end]
+	text := ""
+	if bar := findUnescaped(target, "|", 0); bar != -1 {
+		target, text = target[:bar], target[bar+1:]
+	}
+	target = strings.TrimSpace(unescape(target))
+	var children []pagedetail.Partition
+	if text != "" {
+		children = ip.parseInline(text, ip.getLine(i))
+	}
+	if !relationTarget.MatchString(target) {
+		ip.report(ip.getLine(i), "the relation [[%v]] must link to a page ID such as [[PG_123456789012]], so it is kept as plain text", target)
+		if text == "" {
+			ip.pending += target
+			return end + 2
+		}
+		for _, child := range children {
+			ip.append(&inlineNode{partition: child})
+		}
+		return end + 2
+	}
+	relation := newBlock(string(pagedetail.PartitionTypeRelation), children)
+	relation.Relation = target
+	ip.append(&inlineNode{partition: relation})
+	return end + 2
+}
+
+// findUnescaped returns the index of the first match of the substring from the start that is not escaped by a backslash, or -1.
+func findUnescaped(s, substr string, start int) int {
+	for i := start; i <= len(s)-len(substr); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+var backslashEscape = regexp.MustCompile("\\\\([!\"#$%&'()*+,\\-./:;<=>?@\\[\\\\\\]^_`{|}~])")
+
+// unescape removes the backslashes that escape punctuation, and decodes entities.
+func unescape(s string) string {
+	return html.UnescapeString(backslashEscape.ReplaceAllString(s, "$1"))
+}
+
+func (ip *inlineParser) pushBracket(delimiter string, start int) {
+	n := &inlineNode{delimiter: delimiter, active: true, start: start}
+	ip.append(n)
+	ip.brackets = append(ip.brackets, n)
+}
+
+// closeBracket turns the nodes since the last `[` or `![` into a link or image if the bracket is followed by a destination,
+// and into text otherwise.
+func (ip *inlineParser) closeBracket(i int) int {
+	if len(ip.brackets) == 0 {
+		ip.pending += "]"
+		return i + 1
+	}
+	opener := ip.brackets[len(ip.brackets)-1]
+	ip.brackets = ip.brackets[:len(ip.brackets)-1]
+	if !opener.active {
+		ip.pending += "]"
+		return i + 1
+	}
+	destination, title, end, ok := ip.parseLinkDestination(i + 1)
+	if !ok {
+		destination, end, ok = ip.parseLinkReference(opener, i)
+	}
+	if !ok {
+		ip.pending += "]"
+		return i + 1
+	}
+	if title != "" {
+		ip.report(ip.getLine(i), "link titles are not imported")
+	}
+	ip.flush()
+	ip.processEmphasis(opener)
+	children := getPartitions(opener.next, nil)
+	opener.next = nil
+	ip.tail = opener
+	ip.remove(opener)
+	var partition pagedetail.Partition
+	if opener.delimiter == "![" {
+		partition = pagedetail.Partition{TypeString: string(pagedetail.PartitionTypeImage), AltText: getPlainText(children), Link: destination}
+	} else {
+		partition = newBlock(string(pagedetail.PartitionTypeLink), children)
+		partition.Link = destination
+		for _, bracket := range ip.brackets {
+			if bracket.delimiter == "[" {
+				bracket.active = false
+			}
+		}
+	}
+	ip.append(&inlineNode{partition: partition})
+	return end
+}
+
+func getPlainText(partitions []pagedetail.Partition) string {
+	var b strings.Builder
+	for _, partition := range partitions {
+		b.WriteString(partition.PlainText())
+	}
+	return b.String()
+}
+
+// parseLinkDestination parses a destination of the form `(destination "title")` starting at the index.
+func (ip *inlineParser) parseLinkDestination(i int) (destination, title string, end int, ok bool) {
+	s := ip.s
+	if i >= len(s) || s[i] != '(' {
+		return "", "", 0, false
+	}
+	j := skipWhitespace(s, i+1)
+	if j < len(s) && s[j] == '<' {
+		k := strings.IndexAny(s[j+1:], ">\n")
+		if k == -1 || s[j+1+k] != '>' {
+			return "", "", 0, false
+		}
+		destination = s[j+1 : j+1+k]
+		j = j + 1 + k + 1
+	} else {
+		start := j
+		depth := 0
+		for j < len(s) {
+			c := s[j]
+			if c == '\\' && j+1 < len(s) && isASCIIPunctuation(s[j+1]) {
+				j += 2
+				continue
+			}
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			} else if c == ' ' || c == '\n' {
+				break
+			}
+			j++
+		}
+		destination = s[start:j]
+	}
+	j = skipWhitespace(s, j)
+	if j < len(s) && strings.IndexByte(`"'(`, s[j]) != -1 {
+		closing := s[j]
+		if closing == '(' {
+			closing = ')'
+		}
+		k := findUnescaped(s, string(closing), j+1)
+		if k == -1 {
+			return "", "", 0, false
+		}
+		title = unescape(s[j+1 : k])
+		j = skipWhitespace(s, k+1)
+	}
+	if j >= len(s) || s[j] != ')' {
+		return "", "", 0, false
+	}
+	return unescape(destination), title, j + 1, true
+}
+
+func skipWhitespace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+// parseLinkReference parses a full `[text][label]`, collapsed `[label][]` or shortcut `[label]` reference link
+// whose closing bracket is at the index.
+func (ip *inlineParser) parseLinkReference(opener *inlineNode, i int) (destination string, end int, ok bool) {
+	label := ip.s[opener.start:i]
+	end = i + 1
+	if strings.HasPrefix(ip.s[end:], "[") {
+		closing := findUnescaped(ip.s, "]", end+1)
+		if closing != -1 {
+			if full := ip.s[end+1 : closing]; full != "" {
+				label = full
+			}
+			end = closing + 1
+		}
+	}
+	destination, ok = ip.references[normalizeLabel(unescape(label))]
+	return destination, end, ok
+}
+
+func (ip *inlineParser) pushDelimiterRun(i int) int {
+	c := ip.s[i : i+1]
+	end := i + len(ip.s[i:]) - len(strings.TrimLeft(ip.s[i:], c))
+	before, after := ' ', ' '
+	if i > 0 {
+		before, _ = utf8.DecodeLastRuneInString(ip.s[:i])
+	}
+	if end < len(ip.s) {
+		after, _ = utf8.DecodeRuneInString(ip.s[end:])
+	}
+	leftFlanking := !unicode.IsSpace(after) && (!isPunctuation(after) || unicode.IsSpace(before) || isPunctuation(before))
+	rightFlanking := !unicode.IsSpace(before) && (!isPunctuation(before) || unicode.IsSpace(after) || isPunctuation(after))
+	n := &inlineNode{delimiter: c, count: end - i, originalCount: end - i}
+	if c == "*" {
+		n.canOpen = leftFlanking
+		n.canClose = rightFlanking
+	} else {
+		n.canOpen = leftFlanking && (!rightFlanking || isPunctuation(before))
+		n.canClose = rightFlanking && (!leftFlanking || isPunctuation(after))
+	}
+	ip.append(n)
+	return end
+}
+
+// parseAngleBrackets parses an autolink such as `<https://example.com>`, or inline HTML which is kept as plain text.
+func (ip *inlineParser) parseAngleBrackets(i int) int {
+	if m := uriAutolink.FindStringSubmatch(ip.s[i:]); m != nil {
+		ip.append(&inlineNode{partition: pagedetail.Partition{TypeString: string(pagedetail.PartitionTypeLink), Value: m[1], Link: m[1]}})
+		return i + len(m[0])
+	}
+	if m := emailAutolink.FindStringSubmatch(ip.s[i:]); m != nil {
+		ip.append(&inlineNode{partition: pagedetail.Partition{TypeString: string(pagedetail.PartitionTypeLink), Value: m[1], Link: "mailto:" + m[1]}})
+		return i + len(m[0])
+	}
+	if m := inlineHTML.FindString(ip.s[i:]); m != "" {
+		ip.report(ip.getLine(i), "HTML is not supported and is kept as plain text")
+		ip.pending += m
+		return i + len(m)
+	}
+	ip.pending += "<"
+	return i + 1
+}
+
+// processEmphasis matches the runs of `*` and `_` after the bottom node into bold and italics,
+// closing each run with the nearest run before it that can open it.
+func (ip *inlineParser) processEmphasis(bottom *inlineNode) {
+	closer := bottom.next
+	for closer != nil {
+		if !closer.isEmphasis() || !closer.canClose || closer.count == 0 {
+			closer = closer.next
+			continue
+		}
+		opener := closer.prev
+		for opener != bottom {
+			if opener.delimiter == closer.delimiter && opener.canOpen && opener.count > 0 && !isOddMatch(opener, closer) {
+				break
+			}
+			opener = opener.prev
+		}
+		if opener == bottom {
+			closer = closer.next
+			continue
+		}
+		use := 1
+		typeString := string(pagedetail.PartitionTypeItalics)
+		if opener.count >= 2 && closer.count >= 2 {
+			use = 2
+			typeString = string(pagedetail.PartitionTypeBold)
+		}
+		opener.count -= use
+		closer.count -= use
+		emphasis := &inlineNode{partition: newBlock(typeString, getPartitions(opener.next, closer)), prev: opener, next: closer}
+		opener.next = emphasis
+		closer.prev = emphasis
+		if opener.count == 0 {
+			ip.remove(opener)
+		}
+		if closer.count == 0 {
+			next := closer.next
+			ip.remove(closer)
+			closer = next
+		}
+	}
+}
+
+// isOddMatch returns true if the runs cannot match because a run that can both open and close
+// would otherwise be split in a way that leaves a single character, such as in `*foo**bar*`.
+func isOddMatch(opener, closer *inlineNode) bool {
+	if !opener.canClose && !closer.canOpen {
+		return false
+	}
+	return (opener.originalCount+closer.originalCount)%3 == 0 && !(opener.originalCount%3 == 0 && closer.originalCount%3 == 0)
+}
+
+// getPartitions returns the partitions of the nodes from the first node up to but not including the last node,
+// with any delimiters that were not matched as text.
+func getPartitions(first, last *inlineNode) []pagedetail.Partition {
+	partitions := make([]pagedetail.Partition, 0)
+	for n := first; n != nil && n != last; n = n.next {
+		switch {
+		case n.isEmphasis():
+			if n.count > 0 {
+				partitions = append(partitions, newText(strings.Repeat(n.delimiter, n.count)))
+			}
+		case n.isBracket():
+			partitions = append(partitions, newText(n.delimiter))
+		default:
+			partitions = append(partitions, n.partition)
+		}
+	}
+	return mergeText(partitions)
+}
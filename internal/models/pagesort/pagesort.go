@@ -0,0 +1,113 @@
+package pagesort
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Field is a field that a list of pages can be sorted by.
+type Field string
+
+// All the valid values for Field
+const (
+	FieldTitle     Field = "title"
+	FieldCreatedAt Field = "createdAt"
+	FieldUpdatedAt Field = "updatedAt"
+)
+
+// GetField returns the correct field for the given string.
+func GetField(fieldString string) (Field, error) {
+	switch fieldString {
+	case string(FieldTitle):
+		return FieldTitle, nil
+	case string(FieldCreatedAt):
+		return FieldCreatedAt, nil
+	case string(FieldUpdatedAt):
+		return FieldUpdatedAt, nil
+	default:
+		return FieldCreatedAt, errors.Errorf("invalid sort field %v", fieldString)
+	}
+}
+
+// Order is the direction a list of pages is sorted in.
+type Order string
+
+// All the valid values for Order
+const (
+	OrderAscending  Order = "asc"
+	OrderDescending Order = "desc"
+)
+
+// GetOrder returns the correct order for the given string.
+func GetOrder(orderString string) (Order, error) {
+	switch orderString {
+	case string(OrderAscending):
+		return OrderAscending, nil
+	case string(OrderDescending):
+		return OrderDescending, nil
+	default:
+		return OrderAscending, errors.Errorf("invalid sort order %v", orderString)
+	}
+}
+
+// Sort is how a list of pages is ordered. Pages with the same value for the field are kept in a stable order.
+type Sort struct {
+	Field Field `json:"f"`
+	Order Order `json:"o"`
+}
+
+// DefaultSort is the order pages are listed in when none is given.
+var DefaultSort = Sort{Field: FieldCreatedAt, Order: OrderAscending}
+
+// Cursor marks where a batch of pages starts, as the sort value and ID of its first page.
+// Since the ID breaks ties in the sort value, the position stays stable as pages are added and removed.
+type Cursor struct {
+	Sort Sort `json:"s"`
+	// Value is the sort field of the first page, with times formatted as RFC 3339.
+	Value  string `json:"v"`
+	PageID int64  `json:"i"`
+}
+
+// IsZero returns true if the cursor does not mark a position, meaning the list starts from the beginning.
+func (c Cursor) IsZero() bool {
+	return c.PageID == 0
+}
+
+// Encode returns the cursor as an opaque string that can be passed back to get the batch it marks.
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeCursor returns the cursor encoded in the string. An empty string is the zero cursor.
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	if s == "" {
+		return c, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errors.Wrap(err, "cursor is not encoded correctly")
+	}
+	err = json.Unmarshal(decoded, &c)
+	if err != nil {
+		return c, errors.Wrap(err, "cursor is not encoded correctly")
+	}
+	if c.IsZero() {
+		return c, errors.New("cursor does not mark a page")
+	}
+	_, err = GetField(string(c.Sort.Field))
+	if err != nil {
+		return c, err
+	}
+	_, err = GetOrder(string(c.Sort.Order))
+	if err != nil {
+		return c, err
+	}
+	return c, nil
+}
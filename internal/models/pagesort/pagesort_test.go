@@ -0,0 +1,74 @@
+package pagesort
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeCursor(t *testing.T) {
+	require.Equal(t, "", Cursor{}.Encode())
+	cursor := Cursor{Sort: Sort{Field: FieldTitle, Order: OrderDescending}, Value: "Castle Ravenloft", PageID: 12}
+	decoded, err := DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	cases := []struct {
+		name         string
+		paramCursor  string
+		returnCursor Cursor
+		returnErr    error
+	}{
+		{
+			name: "empty cursor",
+		},
+		{
+			name:         "valid cursor",
+			paramCursor:  encode(`{"s":{"f":"createdAt","o":"asc"},"v":"2020-01-01T00:00:03Z","i":3}`),
+			returnCursor: Cursor{Sort: DefaultSort, Value: "2020-01-01T00:00:03Z", PageID: 3},
+		},
+		{
+			name:        "not base64",
+			paramCursor: "PG_3!",
+			returnErr:   errors.New("cursor is not encoded correctly: illegal base64 data at input byte 4"),
+		},
+		{
+			name:        "not json",
+			paramCursor: encode("PG_3"),
+			returnErr:   errors.New("cursor is not encoded correctly: invalid character 'P' looking for beginning of value"),
+		},
+		{
+			name:        "no page",
+			paramCursor: encode(`{"s":{"f":"createdAt","o":"asc"},"v":"2020-01-01T00:00:03Z"}`),
+			returnErr:   errors.New("cursor does not mark a page"),
+		},
+		{
+			name:        "invalid field",
+			paramCursor: encode(`{"s":{"f":"summary","o":"asc"},"v":"","i":3}`),
+			returnErr:   errors.New("invalid sort field summary"),
+		},
+		{
+			name:        "invalid order",
+			paramCursor: encode(`{"s":{"f":"title","o":"up"},"v":"","i":3}`),
+			returnErr:   errors.New("invalid sort order up"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := DecodeCursor(tc.paramCursor)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnCursor, result)
+		})
+	}
+}
@@ -1,8 +1,105 @@
 package pagetemplate
 
+import (
+	"encoding/json"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/pkg/errors"
+)
+
 // PageTemplate keeps track of the pagetemplate of a particular object.
 type PageTemplate struct {
-	ID   int64  `json:"-"`
-	Name string `json:"name"`
-	GUID string `json:"guid"`
+	ID         int64              `json:"-"`
+	Name       string             `json:"name"`
+	GUID       string             `json:"guid"`
+	Summary    string             `json:"summary,omitempty"`
+	Properties []TemplateProperty `json:"properties,omitempty"`
+	Disabled   bool               `json:"-"`
+}
+
+// TemplateProperty is a registered property that pages of the template are expected to have.
+// Pages created from the template start with every declared property, set to its default value.
+type TemplateProperty struct {
+	PropertyID   int64         `json:"-"`
+	Key          string        `json:"key"`
+	Type         property.Type `json:"type"`
+	Required     bool          `json:"required"`
+	DefaultValue interface{}   `json:"default,omitempty"`
+}
+
+// GetRequiredKeys returns the keys of the template's required properties.
+func (pt PageTemplate) GetRequiredKeys() []string {
+	keys := make([]string, 0)
+	for _, p := range pt.Properties {
+		if p.Required {
+			keys = append(keys, p.Key)
+		}
+	}
+	return keys
+}
+
+// GetDefaultProperties returns the page properties a new page of the template starts with.
+// Properties without a default value are set to the zero value of their type.
+func (pt PageTemplate) GetDefaultProperties() []property.Property {
+	properties := make([]property.Property, 0, len(pt.Properties))
+	for _, p := range pt.Properties {
+		value := p.DefaultValue
+		if value == nil {
+			value = getZeroValue(p.Type)
+		}
+		properties = append(properties, property.Property{
+			ID:    p.PropertyID,
+			Key:   p.Key,
+			Type:  p.Type,
+			Value: value,
+		})
+	}
+	return properties
+}
+
+func getZeroValue(propertyType property.Type) interface{} {
+	if propertyType == property.TypeNumber {
+		return float64(0)
+	}
+	return ""
+}
+
+// IsValidDefaultValue returns true if the default value is unset or matches the property's type.
+func (p TemplateProperty) IsValidDefaultValue() bool {
+	switch p.DefaultValue.(type) {
+	case nil:
+		return true
+	case float64:
+		return p.Type == property.TypeNumber
+	case string:
+		return p.Type == property.TypeString
+	default:
+		return false
+	}
+}
+
+// EncodeDefaultValue returns the default value as it is stored in the db.
+func EncodeDefaultValue(defaultValue interface{}) (*string, error) {
+	if defaultValue == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(defaultValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode the default value")
+	}
+	s := string(b)
+	return &s, nil
+}
+
+// DecodeDefaultValue returns the default value from how it is stored in the db.
+func DecodeDefaultValue(encodedDefaultValue *string) (interface{}, error) {
+	if encodedDefaultValue == nil || *encodedDefaultValue == "" {
+		return nil, nil
+	}
+	var defaultValue interface{}
+	err := json.Unmarshal([]byte(*encodedDefaultValue), &defaultValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode the default value")
+	}
+	return defaultValue, nil
 }
@@ -0,0 +1,108 @@
+package permission
+
+// Role is how a user is related to an entity, which decides what the Policy allows them to do with it.
+type Role string
+
+// All the valid values for Role
+const (
+	// RoleNone is of a user who is not related to the entity at all, or of an entity that does not exist.
+	RoleNone Role = ""
+	// RoleOwner is of the user who created the entity.
+	RoleOwner Role = "owner"
+	// RoleEditor is of a user the entity was shared with to edit.
+	RoleEditor Role = "editor"
+	// RoleViewer is of a user the entity was shared with to read, such as through a campaign.
+	RoleViewer Role = "viewer"
+	// RolePublic is of any user, for a public entity.
+	RolePublic Role = "public"
+	// RoleLinkOnly is of any user, for an entity that can only be found through a link to it.
+	RoleLinkOnly Role = "linkOnly"
+)
+
+// Action is something a user may do with an entity.
+type Action string
+
+// All the valid values for Action
+const (
+	// ActionRead is reading the entity when it is asked for by its id.
+	ActionRead Action = "read"
+	// ActionDiscover is finding the entity without knowing its id, such as in search results or backlinks.
+	ActionDiscover Action = "discover"
+	// ActionEdit is changing the entity, including removing and restoring it.
+	ActionEdit Action = "edit"
+	// ActionPurge is permanently deleting the entity.
+	ActionPurge Action = "purge"
+	// ActionRemove is removing the entity, for entities that are removed by other users than those who edit them.
+	ActionRemove Action = "remove"
+	// ActionManageMembers is adding, changing and removing the members of the entity.
+	ActionManageMembers Action = "manageMembers"
+	// ActionManagePages is adding pages to and removing pages from the entity.
+	ActionManagePages Action = "managePages"
+)
+
+// Entity is a type of entity that the Policy has rules for.
+type Entity string
+
+// All the valid values for Entity
+const (
+	EntityPage         Entity = "page"
+	EntityPageTemplate Entity = "pageTemplate"
+	EntityVersion      Entity = "version"
+	EntityCampaign     Entity = "campaign"
+	EntityProperty     Entity = "property"
+)
+
+// Policy are the roles that are allowed to take each action on each type of entity.
+// Anything not listed is not allowed.
+var Policy = map[Entity]map[Action][]Role{
+	EntityPage: {
+		ActionRead:     {RoleOwner, RoleEditor, RoleViewer, RolePublic, RoleLinkOnly},
+		ActionDiscover: {RoleOwner, RoleEditor, RoleViewer, RolePublic},
+		ActionEdit:     {RoleOwner, RoleEditor},
+		ActionPurge:    {RoleOwner},
+	},
+	// page templates and versions cannot be shared, so only their owners may read them.
+	EntityPageTemplate: {
+		ActionRead: {RoleOwner},
+		ActionEdit: {RoleOwner},
+	},
+	EntityVersion: {
+		ActionRead: {RoleOwner},
+		ActionEdit: {RoleOwner},
+	},
+	// properties are registered by each user for their own pages, so they only belong to that user.
+	EntityProperty: {
+		ActionRead: {RoleOwner},
+		ActionEdit: {RoleOwner},
+	},
+	// every member of a campaign may read it, but only its owner may change it or its members.
+	EntityCampaign: {
+		ActionRead:          {RoleOwner, RoleEditor, RoleViewer},
+		ActionEdit:          {RoleOwner},
+		ActionRemove:        {RoleOwner},
+		ActionManageMembers: {RoleOwner},
+		ActionManagePages:   {RoleOwner, RoleEditor},
+	},
+}
+
+// Allows returns true if the Policy allows the role to take the action on the type of entity.
+func Allows(entity Entity, action Action, role Role) bool {
+	for _, allowed := range Policy[entity][action] {
+		if role == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Role returns the role that the Type gives to every user.
+func (t Type) Role() Role {
+	switch t {
+	case TypePublic, TypePublicOnly:
+		return RolePublic
+	case TypeLinkOnly:
+		return RoleLinkOnly
+	default:
+		return RoleNone
+	}
+}
@@ -0,0 +1,133 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllows(t *testing.T) {
+	cases := []struct {
+		name         string
+		paramEntity  Entity
+		paramAction  Action
+		paramRole    Role
+		returnAllows bool
+	}{
+		{
+			name:         "owner purges page",
+			paramEntity:  EntityPage,
+			paramAction:  ActionPurge,
+			paramRole:    RoleOwner,
+			returnAllows: true,
+		},
+		{
+			name:        "editor purges page",
+			paramEntity: EntityPage,
+			paramAction: ActionPurge,
+			paramRole:   RoleEditor,
+		},
+		{
+			name:         "editor edits page",
+			paramEntity:  EntityPage,
+			paramAction:  ActionEdit,
+			paramRole:    RoleEditor,
+			returnAllows: true,
+		},
+		{
+			name:        "viewer edits page",
+			paramEntity: EntityPage,
+			paramAction: ActionEdit,
+			paramRole:   RoleViewer,
+		},
+		{
+			name:        "public edits page",
+			paramEntity: EntityPage,
+			paramAction: ActionEdit,
+			paramRole:   RolePublic,
+		},
+		{
+			name:         "public discovers page",
+			paramEntity:  EntityPage,
+			paramAction:  ActionDiscover,
+			paramRole:    RolePublic,
+			returnAllows: true,
+		},
+		{
+			name:         "link only reads page",
+			paramEntity:  EntityPage,
+			paramAction:  ActionRead,
+			paramRole:    RoleLinkOnly,
+			returnAllows: true,
+		},
+		{
+			name:        "link only discovers page",
+			paramEntity: EntityPage,
+			paramAction: ActionDiscover,
+			paramRole:   RoleLinkOnly,
+		},
+		{
+			name:        "none reads page",
+			paramEntity: EntityPage,
+			paramAction: ActionRead,
+			paramRole:   RoleNone,
+		},
+		{
+			name:         "owner edits version",
+			paramEntity:  EntityVersion,
+			paramAction:  ActionEdit,
+			paramRole:    RoleOwner,
+			returnAllows: true,
+		},
+		{
+			name:         "owner reads page template",
+			paramEntity:  EntityPageTemplate,
+			paramAction:  ActionRead,
+			paramRole:    RoleOwner,
+			returnAllows: true,
+		},
+		{
+			name:        "none reads version",
+			paramEntity: EntityVersion,
+			paramAction: ActionRead,
+			paramRole:   RoleNone,
+		},
+		{
+			name:        "action without a rule",
+			paramEntity: EntityPageTemplate,
+			paramAction: ActionPurge,
+			paramRole:   RoleOwner,
+		},
+		{
+			name:         "campaign editor manages pages",
+			paramEntity:  EntityCampaign,
+			paramAction:  ActionManagePages,
+			paramRole:    RoleEditor,
+			returnAllows: true,
+		},
+		{
+			name:        "campaign editor manages members",
+			paramEntity: EntityCampaign,
+			paramAction: ActionManageMembers,
+			paramRole:   RoleEditor,
+		},
+		{
+			name:        "entity without rules",
+			paramEntity: Entity("unknown"),
+			paramAction: ActionRead,
+			paramRole:   RoleOwner,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnAllows, Allows(tc.paramEntity, tc.paramAction, tc.paramRole))
+		})
+	}
+}
+
+func TestTypeRole(t *testing.T) {
+	require.Equal(t, RoleNone, TypePrivate.Role())
+	require.Equal(t, RolePublic, TypePublic.Role())
+	require.Equal(t, RolePublic, TypePublicOnly.Role())
+	require.Equal(t, RoleLinkOnly, TypeLinkOnly.Role())
+}
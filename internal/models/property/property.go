@@ -3,11 +3,13 @@ package property
 import "github.com/pkg/errors"
 
 // Property is a key/value pair with a specified type.
+// Registered properties (the definitions a user may use on their pages) have no value.
 type Property struct {
-	ID    int64       `json:"-"`
-	Key   string      `json:"key"`
-	Type  Type        `json:"type"`
-	Value interface{} `json:"value"`
+	ID       int64       `json:"-"`
+	Key      string      `json:"key"`
+	Type     Type        `json:"type"`
+	Value    interface{} `json:"value,omitempty"`
+	Disabled bool        `json:"-"`
 }
 
 // DBProperty is the Property struct as it comes out of the DB.  This ensures that
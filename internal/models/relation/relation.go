@@ -0,0 +1,173 @@
+package relation
+
+import "github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+
+// Relation is a link from a page detail to another page, made by a relation partition.
+type Relation struct {
+	TargetPageGUID string `json:"pageId"`
+	// Text is the text of the relation partition itself.
+	Text string `json:"text"`
+	// Context is the text of the partition the relation is part of, so the relation can be read in place.
+	Context string `json:"context"`
+}
+
+// Backlink is a relation as seen from the page that is linked to.
+type Backlink struct {
+	PageGUID    string `json:"pageId"`
+	PageTitle   string `json:"pageTitle"`
+	DetailGUID  string `json:"detailId"`
+	DetailTitle string `json:"detailTitle"`
+	Text        string `json:"text"`
+	Context     string `json:"context"`
+}
+
+// Extract returns every relation in the partitions, including those nested in partitions and items, in the order they appear.
+func Extract(partitions []pagedetail.Partition) []Relation {
+	relations := []Relation{}
+	for _, p := range partitions {
+		relations = extract(p, p.PlainText(), relations)
+	}
+	return relations
+}
+
+func extract(p pagedetail.Partition, context string, relations []Relation) []Relation {
+	if p.TypeString == string(pagedetail.PartitionTypeRelation) && p.Relation != "" {
+		relations = append(relations, Relation{
+			TargetPageGUID: p.Relation,
+			Text:           p.PlainText(),
+			Context:        context,
+		})
+	}
+	// inline formatting, such as bold text, is read in the context of the block it is part of.
+	childContext := context
+	if !isInline(p) {
+		childContext = p.PlainText()
+	}
+	for _, child := range p.Partitions {
+		relations = extract(child, childContext, relations)
+	}
+	for _, item := range p.Items {
+		relations = extract(item, item.PlainText(), relations)
+	}
+	return relations
+}
+
+func isInline(p pagedetail.Partition) bool {
+	switch pagedetail.PartitionType(p.TypeString) {
+	case pagedetail.PartitionTypeText, pagedetail.PartitionTypeBold, pagedetail.PartitionTypeItalics,
+		pagedetail.PartitionTypeLink, pagedetail.PartitionTypeRelation, pagedetail.PartitionTypeColor:
+		return true
+	default:
+		return false
+	}
+}
+
+// Graph is the pages reachable from a page through relations, and the relations between them.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Node is a page in a graph of relations.
+type Node struct {
+	PageGUID         string `json:"id"`
+	Title            string `json:"title"`
+	PageTemplateGUID string `json:"pageTemplateId"`
+	VersionGUID      string `json:"versionId"`
+	// Depth is the number of hops from the page the graph was built from.
+	Depth int `json:"depth"`
+}
+
+// Edge is every relation from the details of one page to another page.
+type Edge struct {
+	SourcePageGUID string `json:"source"`
+	TargetPageGUID string `json:"target"`
+	// Count is the number of relations from the source page to the target page.
+	Count int `json:"count"`
+}
+
+// Status is the state of the page a relation links to.
+type Status string
+
+// All the valid values for Status
+const (
+	StatusLive Status = "live"
+	// StatusDeleted relations link to a page that has been removed.
+	StatusDeleted Status = "deleted"
+	// StatusNonexistent relations link to a page that never existed.
+	StatusNonexistent Status = "nonexistent"
+	// StatusUnreadable relations link to a page the user cannot read.
+	StatusUnreadable Status = "unreadable"
+)
+
+// Link is a relation as seen from the page detail it is made in.
+type Link struct {
+	PageGUID       string `json:"pageId"`
+	PageTitle      string `json:"pageTitle"`
+	DetailGUID     string `json:"detailId"`
+	DetailTitle    string `json:"detailTitle"`
+	TargetPageGUID string `json:"targetPageId"`
+	Text           string `json:"text"`
+	Context        string `json:"context"`
+	Status         Status `json:"status"`
+}
+
+// AffectedPage is a page with relations to a page that is being removed.
+type AffectedPage struct {
+	PageGUID  string `json:"pageId"`
+	PageTitle string `json:"pageTitle"`
+	// Relations is the number of relations the page has to the removed page.
+	Relations int `json:"relations"`
+	// Repaired is whether the relations were rewritten as plain text or re-pointed to a replacement page.
+	Repaired bool `json:"repaired"`
+}
+
+// Repoint returns a copy of the partitions with every relation to the target page linking to the replacement page instead.
+// When the replacement page is empty, the relations are rewritten as plain text. Returns false if no relation was changed.
+func Repoint(partitions []pagedetail.Partition, targetPageGUID, replacementPageGUID string) ([]pagedetail.Partition, bool) {
+	if partitions == nil {
+		return nil, false
+	}
+	repointed := make([]pagedetail.Partition, 0, len(partitions))
+	changed := false
+	for _, p := range partitions {
+		if p.TypeString == string(pagedetail.PartitionTypeRelation) && p.Relation == targetPageGUID {
+			changed = true
+			if replacementPageGUID == "" {
+				repointed = append(repointed, pagedetail.Partition{
+					Type:       pagedetail.PartitionTypeText,
+					TypeString: string(pagedetail.PartitionTypeText),
+					Value:      p.PlainText(),
+				})
+				continue
+			}
+			p.Relation = replacementPageGUID
+		}
+		var partitionsChanged, itemsChanged bool
+		p.Partitions, partitionsChanged = Repoint(p.Partitions, targetPageGUID, replacementPageGUID)
+		p.Items, itemsChanged = Repoint(p.Items, targetPageGUID, replacementPageGUID)
+		changed = changed || partitionsChanged || itemsChanged
+		repointed = append(repointed, p)
+	}
+	return repointed, changed
+}
+
+// Remap returns a copy of the partitions with every relation to a page in the map linking to the page it is mapped to.
+// Relations to other pages are left as they are.
+func Remap(partitions []pagedetail.Partition, pageGUIDs map[string]string) []pagedetail.Partition {
+	if partitions == nil {
+		return nil
+	}
+	remapped := make([]pagedetail.Partition, 0, len(partitions))
+	for _, p := range partitions {
+		if p.TypeString == string(pagedetail.PartitionTypeRelation) {
+			if pageGUID, ok := pageGUIDs[p.Relation]; ok {
+				p.Relation = pageGUID
+			}
+		}
+		p.Partitions = Remap(p.Partitions, pageGUIDs)
+		p.Items = Remap(p.Items, pageGUIDs)
+		remapped = append(remapped, p)
+	}
+	return remapped
+}
@@ -0,0 +1,188 @@
+package relation
+
+import (
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	cases := []struct {
+		name            string
+		paramPartitions []pagedetail.Partition
+		returnRelations []Relation
+	}{
+		{
+			name:            "no partitions",
+			returnRelations: []Relation{},
+		},
+		{
+			name: "relations nested in partitions and items",
+			paramPartitions: []pagedetail.Partition{
+				{TypeString: "h1", Value: "Barovia"},
+				{
+					TypeString: "p",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "The road east leads to "},
+						{TypeString: "relation", Value: "Vallaki", Relation: "PG_2"},
+						{TypeString: "text", Value: "."},
+					},
+				},
+				{
+					TypeString: "ul",
+					Items: []pagedetail.Partition{
+						{
+							TypeString: "text",
+							Partitions: []pagedetail.Partition{
+								{TypeString: "text", Value: "Tribute from "},
+								{TypeString: "bold", Partitions: []pagedetail.Partition{{TypeString: "relation", Value: "Krezk", Relation: "PG_3"}}},
+							},
+						},
+						{TypeString: "relation", Value: "Castle Ravenloft", Relation: "PG_4"},
+					},
+				},
+				{TypeString: "relation", Value: "unlinked"},
+			},
+			returnRelations: []Relation{
+				{TargetPageGUID: "PG_2", Text: "Vallaki", Context: "The road east leads to Vallaki."},
+				{TargetPageGUID: "PG_3", Text: "Krezk", Context: "Tribute from Krezk"},
+				{TargetPageGUID: "PG_4", Text: "Castle Ravenloft", Context: "Castle Ravenloft"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnRelations, Extract(tc.paramPartitions))
+		})
+	}
+}
+
+func TestRepoint(t *testing.T) {
+	partitions := []pagedetail.Partition{
+		{
+			TypeString: "p",
+			Partitions: []pagedetail.Partition{
+				{TypeString: "text", Value: "The road east leads to "},
+				{TypeString: "relation", Value: "Vallaki", Relation: "PG_2"},
+			},
+		},
+		{
+			TypeString: "ul",
+			Items: []pagedetail.Partition{
+				{TypeString: "relation", Value: "Vallaki", Relation: "PG_2"},
+				{TypeString: "relation", Value: "Krezk", Relation: "PG_3"},
+			},
+		},
+	}
+	cases := []struct {
+		name                     string
+		paramPartitions          []pagedetail.Partition
+		paramTargetPageGUID      string
+		paramReplacementPageGUID string
+		returnPartitions         []pagedetail.Partition
+		returnChanged            bool
+	}{
+		{
+			name:                "no partitions",
+			paramTargetPageGUID: "PG_2",
+		},
+		{
+			name:                     "re-pointed to a replacement page",
+			paramPartitions:          partitions,
+			paramTargetPageGUID:      "PG_2",
+			paramReplacementPageGUID: "PG_4",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "p",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "The road east leads to "},
+						{TypeString: "relation", Value: "Vallaki", Relation: "PG_4"},
+					},
+				},
+				{
+					TypeString: "ul",
+					Items: []pagedetail.Partition{
+						{TypeString: "relation", Value: "Vallaki", Relation: "PG_4"},
+						{TypeString: "relation", Value: "Krezk", Relation: "PG_3"},
+					},
+				},
+			},
+			returnChanged: true,
+		},
+		{
+			name:                "rewritten as plain text",
+			paramPartitions:     partitions,
+			paramTargetPageGUID: "PG_2",
+			returnPartitions: []pagedetail.Partition{
+				{
+					TypeString: "p",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "text", Value: "The road east leads to "},
+						{Type: pagedetail.PartitionTypeText, TypeString: "text", Value: "Vallaki"},
+					},
+				},
+				{
+					TypeString: "ul",
+					Items: []pagedetail.Partition{
+						{Type: pagedetail.PartitionTypeText, TypeString: "text", Value: "Vallaki"},
+						{TypeString: "relation", Value: "Krezk", Relation: "PG_3"},
+					},
+				},
+			},
+			returnChanged: true,
+		},
+		{
+			name:                "no relations to the page",
+			paramPartitions:     partitions,
+			paramTargetPageGUID: "PG_5",
+			returnPartitions:    partitions,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, changed := Repoint(tc.paramPartitions, tc.paramTargetPageGUID, tc.paramReplacementPageGUID)
+			require.Equal(t, tc.returnPartitions, result)
+			require.Equal(t, tc.returnChanged, changed)
+			require.Equal(t, "PG_2", partitions[0].Partitions[1].Relation)
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	partitions := []pagedetail.Partition{
+		{
+			TypeString: "p",
+			Partitions: []pagedetail.Partition{
+				{TypeString: "relation", Value: "Vallaki", Relation: "PG_2"},
+				{TypeString: "text", Value: " and "},
+				{TypeString: "relation", Value: "Krezk", Relation: "PG_3"},
+			},
+		},
+		{
+			TypeString: "ul",
+			Items: []pagedetail.Partition{
+				{TypeString: "relation", Value: "Castle Ravenloft", Relation: "PG_4"},
+			},
+		},
+	}
+	remapped := Remap(partitions, map[string]string{"PG_2": "PG_3", "PG_3": "PG_5"})
+	require.Equal(t, []pagedetail.Partition{
+		{
+			TypeString: "p",
+			Partitions: []pagedetail.Partition{
+				{TypeString: "relation", Value: "Vallaki", Relation: "PG_3"},
+				{TypeString: "text", Value: " and "},
+				{TypeString: "relation", Value: "Krezk", Relation: "PG_5"},
+			},
+		},
+		{
+			TypeString: "ul",
+			Items: []pagedetail.Partition{
+				{TypeString: "relation", Value: "Castle Ravenloft", Relation: "PG_4"},
+			},
+		},
+	}, remapped)
+	require.Equal(t, "PG_2", partitions[0].Partitions[0].Relation)
+	require.Nil(t, Remap(nil, map[string]string{"PG_2": "PG_3"}))
+}
@@ -0,0 +1,200 @@
+package revision
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+)
+
+// TargetDetailOrder is the target of a change to the order of a page's details.
+const TargetDetailOrder pagediff.Target = "detailOrder"
+
+// Revision is an immutable record of a single change to a page.
+// The first revision of a page that already had content is recorded without an author or changes,
+// so that the page can always be restored to how it was before it was first changed.
+type Revision struct {
+	ID         int64      `json:"-"`
+	GUID       string     `json:"id"`
+	PageGUID   string     `json:"pageId"`
+	AuthorID   int64      `json:"-"`
+	AuthorGUID string     `json:"authorId"`
+	CreatedAt  *time.Time `json:"createdAt"`
+	Changes    []Change   `json:"changes"`
+	// Snapshot is the content of the page as of the revision. It is only provided for a single revision.
+	Snapshot *pagediff.Snapshot `json:"snapshot,omitempty"`
+}
+
+// GetJSONConformed conforms the revision to be ready for JSON marshelling.
+func (r Revision) GetJSONConformed() interface{} {
+	if r.Changes == nil {
+		r.Changes = []Change{}
+	}
+	if r.Snapshot != nil {
+		snapshot := *r.Snapshot
+		if snapshot.Properties == nil {
+			snapshot.Properties = []property.Property{}
+		}
+		details := make([]pagedetail.PageDetail, 0, len(snapshot.Details))
+		for _, d := range snapshot.Details {
+			details = append(details, d.GetJSONConformed().(pagedetail.PageDetail))
+		}
+		snapshot.Details = details
+		r.Snapshot = &snapshot
+	}
+	return r
+}
+
+// Change is a single difference made to a page by a revision.
+// Before and After hold the value of the target on either side of the revision.
+// A nil value means the property or detail did not exist on that side.
+type Change struct {
+	Target pagediff.Target `json:"target"`
+	Key    string          `json:"key,omitempty"`
+	Before interface{}     `json:"before"`
+	After  interface{}     `json:"after"`
+	// Partitions is the structural diff of the partitions, for details on both sides.
+	Partitions []pagediff.PartitionDiff `json:"partitions,omitempty"`
+}
+
+// EncodeChanges returns the changes in the form they are persisted to a store.
+func EncodeChanges(changes []Change) (string, error) {
+	b, err := json.Marshal(changes)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// DecodeChanges returns the changes from the form they are persisted to a store.
+func DecodeChanges(encoded string) ([]Change, error) {
+	var changes []Change
+	err := json.Unmarshal([]byte(encoded), &changes)
+	return changes, err
+}
+
+// Compare returns the changes that turn the before snapshot into the after snapshot.
+func Compare(before, after pagediff.Snapshot) []Change {
+	changes := []Change{}
+	if before.Title != after.Title {
+		changes = append(changes, Change{Target: pagediff.TargetTitle, Before: before.Title, After: after.Title})
+	}
+	if before.Summary != after.Summary {
+		changes = append(changes, Change{Target: pagediff.TargetSummary, Before: before.Summary, After: after.Summary})
+	}
+	changes = append(changes, compareProperties(before.Properties, after.Properties)...)
+	changes = append(changes, compareDetails(before.Details, after.Details)...)
+	beforeOrder := getDetailGUIDs(before.Details)
+	afterOrder := getDetailGUIDs(after.Details)
+	if isReordered(beforeOrder, afterOrder) {
+		changes = append(changes, Change{Target: TargetDetailOrder, Before: beforeOrder, After: afterOrder})
+	}
+	return changes
+}
+
+func compareProperties(before, after []property.Property) []Change {
+	changes := []Change{}
+	afterByKey := make(map[string]property.Property)
+	for _, p := range after {
+		afterByKey[p.Key] = p
+	}
+	beforeKeys := make(map[string]bool)
+	for _, b := range before {
+		beforeKeys[b.Key] = true
+		a, ok := afterByKey[b.Key]
+		if !ok {
+			changes = append(changes, Change{Target: pagediff.TargetProperty, Key: b.Key, Before: b})
+			continue
+		}
+		if b.Type != a.Type || !reflect.DeepEqual(b.Value, a.Value) {
+			changes = append(changes, Change{Target: pagediff.TargetProperty, Key: b.Key, Before: b, After: a})
+		}
+	}
+	for _, a := range after {
+		if !beforeKeys[a.Key] {
+			changes = append(changes, Change{Target: pagediff.TargetProperty, Key: a.Key, After: a})
+		}
+	}
+	return changes
+}
+
+func compareDetails(before, after []pagedetail.PageDetail) []Change {
+	changes := []Change{}
+	afterByGUID := make(map[string]pagedetail.PageDetail)
+	for _, d := range after {
+		afterByGUID[d.GUID] = d
+	}
+	beforeGUIDs := make(map[string]bool)
+	for _, b := range before {
+		beforeGUIDs[b.GUID] = true
+		a, ok := afterByGUID[b.GUID]
+		if !ok {
+			changes = append(changes, Change{Target: pagediff.TargetDetail, Key: b.GUID, Before: b})
+			continue
+		}
+		if b.Title != a.Title || b.Summary != a.Summary || !isEqualPartitions(b.Partitions, a.Partitions) {
+			changes = append(changes, Change{
+				Target:     pagediff.TargetDetail,
+				Key:        b.GUID,
+				Before:     b,
+				After:      a,
+				Partitions: pagediff.DiffPartitions(b.Partitions, a.Partitions),
+			})
+		}
+	}
+	for _, a := range after {
+		if !beforeGUIDs[a.GUID] {
+			changes = append(changes, Change{Target: pagediff.TargetDetail, Key: a.GUID, After: a})
+		}
+	}
+	return changes
+}
+
+// isEqualPartitions compares the partitions as they are persisted, so that an empty and a missing list are the same.
+func isEqualPartitions(a, b []pagedetail.Partition) bool {
+	encodedA, errA := pagedetail.EncodePartitions(a)
+	encodedB, errB := pagedetail.EncodePartitions(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return encodedA == encodedB
+}
+
+func getDetailGUIDs(details []pagedetail.PageDetail) []string {
+	guids := make([]string, 0, len(details))
+	for _, d := range details {
+		guids = append(guids, d.GUID)
+	}
+	return guids
+}
+
+// isReordered returns whether the details both sides have in common are in a different order.
+// Details that were only added or removed are reported as changes to the details themselves.
+func isReordered(before, after []string) bool {
+	inAfter := make(map[string]bool)
+	for _, guid := range after {
+		inAfter[guid] = true
+	}
+	inBefore := make(map[string]bool)
+	common := make([]string, 0, len(before))
+	for _, guid := range before {
+		inBefore[guid] = true
+		if inAfter[guid] {
+			common = append(common, guid)
+		}
+	}
+	i := 0
+	for _, guid := range after {
+		if !inBefore[guid] {
+			continue
+		}
+		if common[i] != guid {
+			return true
+		}
+		i++
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package revision
+
+import (
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	population := property.Property{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(500)}
+	ruler := property.Property{ID: 2, Key: "ruler", Type: property.TypeString, Value: "Strahd"}
+	history := pagedetail.PageDetail{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded"}}}
+	geography := pagedetail.PageDetail{GUID: "DT_2", Title: "Geography"}
+	cases := []struct {
+		name          string
+		paramBefore   pagediff.Snapshot
+		paramAfter    pagediff.Snapshot
+		returnChanges []Change
+	}{
+		{
+			name:          "no changes",
+			paramBefore:   pagediff.Snapshot{Title: "Barovia", Properties: []property.Property{ruler}, Details: []pagedetail.PageDetail{history}},
+			paramAfter:    pagediff.Snapshot{Title: "Barovia", Properties: []property.Property{ruler}, Details: []pagedetail.PageDetail{history}},
+			returnChanges: []Change{},
+		},
+		{
+			name:        "title and summary",
+			paramBefore: pagediff.Snapshot{Title: "Barovia", Summary: "A village"},
+			paramAfter:  pagediff.Snapshot{Title: "Village of Barovia", Summary: "A village"},
+			returnChanges: []Change{
+				{Target: pagediff.TargetTitle, Before: "Barovia", After: "Village of Barovia"},
+			},
+		},
+		{
+			name:        "properties added, removed and changed",
+			paramBefore: pagediff.Snapshot{Properties: []property.Property{population, ruler}},
+			paramAfter: pagediff.Snapshot{Properties: []property.Property{
+				{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(450)},
+				{ID: 3, Key: "region", Type: property.TypeString, Value: "Svalich Woods"},
+			}},
+			returnChanges: []Change{
+				{
+					Target: pagediff.TargetProperty,
+					Key:    "population",
+					Before: population,
+					After:  property.Property{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(450)},
+				},
+				{Target: pagediff.TargetProperty, Key: "ruler", Before: ruler},
+				{Target: pagediff.TargetProperty, Key: "region", After: property.Property{ID: 3, Key: "region", Type: property.TypeString, Value: "Svalich Woods"}},
+			},
+		},
+		{
+			name:        "details added, removed and changed",
+			paramBefore: pagediff.Snapshot{Details: []pagedetail.PageDetail{history, geography}},
+			paramAfter: pagediff.Snapshot{Details: []pagedetail.PageDetail{
+				{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded long ago"}}},
+				{GUID: "DT_3", Title: "People"},
+			}},
+			returnChanges: []Change{
+				{
+					Target: pagediff.TargetDetail,
+					Key:    "DT_1",
+					Before: history,
+					After:  pagedetail.PageDetail{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded long ago"}}},
+					Partitions: []pagediff.PartitionDiff{
+						{
+							Op:        pagediff.OpModified,
+							Partition: pagedetail.Partition{TypeString: "p", Value: "Founded long ago"},
+							Previous:  &pagedetail.Partition{TypeString: "p", Value: "Founded"},
+						},
+					},
+				},
+				{Target: pagediff.TargetDetail, Key: "DT_2", Before: geography},
+				{Target: pagediff.TargetDetail, Key: "DT_3", After: pagedetail.PageDetail{GUID: "DT_3", Title: "People"}},
+			},
+		},
+		{
+			name:          "empty and missing partitions are the same",
+			paramBefore:   pagediff.Snapshot{Details: []pagedetail.PageDetail{geography}},
+			paramAfter:    pagediff.Snapshot{Details: []pagedetail.PageDetail{{GUID: "DT_2", Title: "Geography", Partitions: []pagedetail.Partition{}}}},
+			returnChanges: []Change{},
+		},
+		{
+			name:        "details reordered",
+			paramBefore: pagediff.Snapshot{Details: []pagedetail.PageDetail{history, geography}},
+			paramAfter:  pagediff.Snapshot{Details: []pagedetail.PageDetail{geography, history}},
+			returnChanges: []Change{
+				{Target: TargetDetailOrder, Before: []string{"DT_1", "DT_2"}, After: []string{"DT_2", "DT_1"}},
+			},
+		},
+		{
+			name:        "adding a detail is not a reorder",
+			paramBefore: pagediff.Snapshot{Details: []pagedetail.PageDetail{history}},
+			paramAfter:  pagediff.Snapshot{Details: []pagedetail.PageDetail{geography, history}},
+			returnChanges: []Change{
+				{Target: pagediff.TargetDetail, Key: "DT_2", After: geography},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnChanges, Compare(tc.paramBefore, tc.paramAfter))
+		})
+	}
+}
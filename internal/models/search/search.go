@@ -0,0 +1,142 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+)
+
+// Field is the part of a page that a piece of searchable text comes from.
+type Field string
+
+// All the valid values for Field
+const (
+	FieldTitle   Field = "title"
+	FieldSummary Field = "summary"
+	FieldDetail  Field = "detail"
+)
+
+// Text is a single piece of searchable text from a page.
+type Text struct {
+	Field Field
+	// DetailGUID is the detail the text comes from, for detail texts.
+	DetailGUID string
+	Value      string
+}
+
+// Document is the searchable text of a page.
+type Document struct {
+	PageGUID string
+	Title    string
+	// PermissionType decides whether users without a role in the page may find it.
+	PermissionType permission.Type
+	Texts          []Text
+}
+
+// NewDocument returns the searchable text of the page and its details.
+// Each detail's title, summary and top level partitions are separate texts, so that snippets stay within a single block.
+func NewDocument(p page.Page, details []pagedetail.PageDetail) Document {
+	d := Document{
+		PageGUID:       p.GUID,
+		Title:          p.Title,
+		PermissionType: p.PermissionType,
+		Texts:          []Text{},
+	}
+	d.Texts = appendText(d.Texts, FieldTitle, "", p.Title)
+	d.Texts = appendText(d.Texts, FieldSummary, "", p.Summary)
+	for _, detail := range details {
+		d.Texts = appendText(d.Texts, FieldDetail, detail.GUID, detail.Title)
+		d.Texts = appendText(d.Texts, FieldDetail, detail.GUID, detail.Summary)
+		for _, partition := range detail.Partitions {
+			d.Texts = appendText(d.Texts, FieldDetail, detail.GUID, partition.PlainText())
+		}
+	}
+	return d
+}
+
+// Filter is the pages that a user can find in a search.
+type Filter struct {
+	// PageGUIDs are the pages the user has a role in whatever their permission type, such as those they own or are in their campaigns.
+	PageGUIDs map[string]bool
+}
+
+// NewFilter returns a Filter for a user with a role in the given pages.
+func NewFilter(pageGUIDs []string) Filter {
+	f := Filter{PageGUIDs: make(map[string]bool)}
+	for _, pageGUID := range pageGUIDs {
+		f.PageGUIDs[pageGUID] = true
+	}
+	return f
+}
+
+// Allows returns true if the user can discover the page of the document,
+// because they have a role in it or its permission type lets anyone discover it.
+func (f Filter) Allows(d Document) bool {
+	if f.PageGUIDs[d.PageGUID] {
+		return true
+	}
+	return permission.Allows(permission.EntityPage, permission.ActionDiscover, d.PermissionType.Role())
+}
+
+func appendText(texts []Text, field Field, detailGUID, value string) []Text {
+	if strings.TrimSpace(value) == "" {
+		return texts
+	}
+	return append(texts, Text{Field: field, DetailGUID: detailGUID, Value: value})
+}
+
+// Token is a normalized term found in a text, along with its position in the text in characters.
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize splits the text into lowercase terms made of letters and digits.
+func Tokenize(text string) []Token {
+	tokens := []Token{}
+	var term strings.Builder
+	start := 0
+	position := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if term.Len() == 0 {
+				start = position
+			}
+			term.WriteRune(unicode.ToLower(r))
+		} else if term.Len() > 0 {
+			tokens = append(tokens, Token{Term: term.String(), Start: start, End: position})
+			term.Reset()
+		}
+		position++
+	}
+	if term.Len() > 0 {
+		tokens = append(tokens, Token{Term: term.String(), Start: start, End: position})
+	}
+	return tokens
+}
+
+// Highlight is the position of a matched term in a snippet, in characters.
+type Highlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Snippet is an excerpt of a page that matched a search.
+type Snippet struct {
+	Field      Field       `json:"field"`
+	DetailGUID string      `json:"detailId,omitempty"`
+	Text       string      `json:"text"`
+	Highlights []Highlight `json:"highlights"`
+}
+
+// Result is a page that matched a search.
+type Result struct {
+	PageGUID string    `json:"id"`
+	Title    string    `json:"title"`
+	Score    float64   `json:"score"`
+	Snippets []Snippet `json:"snippets"`
+}
@@ -0,0 +1,146 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDocument(t *testing.T) {
+	cases := []struct {
+		name           string
+		paramPage      page.Page
+		paramDetails   []pagedetail.PageDetail
+		returnDocument Document
+	}{
+		{
+			name:      "page without details",
+			paramPage: page.Page{GUID: "PG_1", Title: "Barovia"},
+			returnDocument: Document{
+				PageGUID: "PG_1",
+				Title:    "Barovia",
+				Texts: []Text{
+					{Field: FieldTitle, Value: "Barovia"},
+				},
+			},
+		},
+		{
+			name:      "page with details",
+			paramPage: page.Page{GUID: "PG_1", Title: "Barovia", Summary: "A land of mists", PermissionType: permission.TypePublic},
+			paramDetails: []pagedetail.PageDetail{
+				{
+					GUID:    "PD_1",
+					Title:   "History",
+					Summary: " ",
+					Partitions: []pagedetail.Partition{
+						{TypeString: "h1", Value: "The Dark Lord"},
+						{
+							TypeString: "p",
+							Partitions: []pagedetail.Partition{
+								{TypeString: "text", Value: "Strahd rules from "},
+								{TypeString: "relation", Value: "Castle Ravenloft", Relation: "PG_2"},
+							},
+						},
+					},
+				},
+			},
+			returnDocument: Document{
+				PageGUID:       "PG_1",
+				Title:          "Barovia",
+				PermissionType: permission.TypePublic,
+				Texts: []Text{
+					{Field: FieldTitle, Value: "Barovia"},
+					{Field: FieldSummary, Value: "A land of mists"},
+					{Field: FieldDetail, DetailGUID: "PD_1", Value: "History"},
+					{Field: FieldDetail, DetailGUID: "PD_1", Value: "The Dark Lord"},
+					{Field: FieldDetail, DetailGUID: "PD_1", Value: "Strahd rules from Castle Ravenloft"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnDocument, NewDocument(tc.paramPage, tc.paramDetails))
+		})
+	}
+}
+
+func TestFilterAllows(t *testing.T) {
+	filter := NewFilter([]string{"PG_1"})
+	cases := []struct {
+		name          string
+		paramDocument Document
+		returnAllows  bool
+	}{
+		{
+			name:          "private page the user has a role in",
+			paramDocument: Document{PageGUID: "PG_1", PermissionType: permission.TypePrivate},
+			returnAllows:  true,
+		},
+		{
+			name:          "private page of another user",
+			paramDocument: Document{PageGUID: "PG_2", PermissionType: permission.TypePrivate},
+			returnAllows:  false,
+		},
+		{
+			name:          "public page of another user",
+			paramDocument: Document{PageGUID: "PG_2", PermissionType: permission.TypePublic},
+			returnAllows:  true,
+		},
+		{
+			name:          "public only page of another user",
+			paramDocument: Document{PageGUID: "PG_2", PermissionType: permission.TypePublicOnly},
+			returnAllows:  true,
+		},
+		{
+			name:          "link only page of another user",
+			paramDocument: Document{PageGUID: "PG_2", PermissionType: permission.TypeLinkOnly},
+			returnAllows:  false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnAllows, filter.Allows(tc.paramDocument))
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		name         string
+		paramText    string
+		returnTokens []Token
+	}{
+		{
+			name:         "empty text",
+			returnTokens: []Token{},
+		},
+		{
+			name:      "punctuation and case",
+			paramText: "Castle Ravenloft, 1st floor!",
+			returnTokens: []Token{
+				{Term: "castle", Start: 0, End: 6},
+				{Term: "ravenloft", Start: 7, End: 16},
+				{Term: "1st", Start: 18, End: 21},
+				{Term: "floor", Start: 22, End: 27},
+			},
+		},
+		{
+			name:      "positions count characters rather than bytes",
+			paramText: "Château d'Ïle",
+			returnTokens: []Token{
+				{Term: "château", Start: 0, End: 7},
+				{Term: "d", Start: 8, End: 9},
+				{Term: "ïle", Start: 10, End: 13},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.returnTokens, Tokenize(tc.paramText))
+		})
+	}
+}
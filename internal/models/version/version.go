@@ -1,5 +1,7 @@
 package version
 
+import "github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+
 // Version keeps track of the version of a particular object.
 type Version struct {
 	ID         int64  `json:"-"`
@@ -7,3 +9,19 @@ type Version struct {
 	Name       string `json:"name"`
 	ParentGUID string `json:"parentId"`
 }
+
+// IsRoot returns whether or not the version is at the top of its version tree.
+func (v Version) IsRoot() bool {
+	return v.ParentGUID == ""
+}
+
+// PageFork links a page that was forked into a child version back to the page it was forked from.
+type PageFork struct {
+	SourcePageGUID string `json:"sourceId"`
+	PageGUID       string `json:"id"`
+	// DetailGUIDs maps the guid of each forked detail to the guid of the detail it was forked from.
+	DetailGUIDs map[string]string `json:"-"`
+	// Base is the content of the source page as it was when the page was forked, or when it was last merged back.
+	// Changes on either side are measured against it.
+	Base *pagediff.Snapshot `json:"-"`
+}
@@ -0,0 +1,51 @@
+package auditservice
+
+import (
+	"context"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/pkg/errors"
+)
+
+// AuditService is the service for handling audit-related APIs.
+// Audit entries belong to no user, so there is no role for the policy to check; their routes are only open to admins.
+type AuditService struct {
+	AuditStore store.AuditStore
+}
+
+// RecordAuditEntryParams params for RecordAuditEntry
+type RecordAuditEntryParams struct {
+	Entry audit.Entry
+}
+
+// RecordAuditEntry appends the entry to the audit log.
+func (s AuditService) RecordAuditEntry(ctx context.Context, params RecordAuditEntryParams) error {
+	guid, err := s.AuditStore.GetUniqueAuditEntryGUID("")
+	if err != nil {
+		return errors.Wrapf(err, "failed to get unique audit entry guid: %+v", params)
+	}
+	params.Entry.GUID = guid
+	_, err = s.AuditStore.CreateAuditEntry(params.Entry)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create audit entry: %+v", params)
+	}
+	return nil
+}
+
+// GetAuditEntriesParams params for GetAuditEntries
+type GetAuditEntriesParams struct {
+	Filter      audit.Filter
+	NextBatchID string
+	Limit       int
+}
+
+// GetAuditEntries returns a batch of the audit entries matching the filter, newest first,
+// along with the id of the next batch, which is empty if there are no more entries.
+func (s AuditService) GetAuditEntries(ctx context.Context, params GetAuditEntriesParams) ([]audit.Entry, string, error) {
+	entries, nextBatchID, err := s.AuditStore.GetAuditEntries(params.Filter, params.NextBatchID, params.Limit)
+	if err != nil {
+		return []audit.Entry{}, "", errors.Wrapf(err, "failed to get audit entries: %+v", params)
+	}
+	return entries, nextBatchID, nil
+}
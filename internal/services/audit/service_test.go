@@ -0,0 +1,145 @@
+package auditservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+var auditService AuditService
+var ctx context.Context
+
+func TestMain(m *testing.M) {
+	ctx = context.Background()
+	result := m.Run()
+	os.Exit(result)
+}
+
+type getUniqueAuditEntryGUIDCall struct {
+	returnGUID string
+	returnErr  error
+}
+
+type createAuditEntryCall struct {
+	paramEntry  audit.Entry
+	returnEntry audit.Entry
+	returnErr   error
+}
+
+func TestRecordAuditEntry(t *testing.T) {
+	entry := audit.Entry{Actor: "proxyUser", UserID: "UR_1", Method: "PATCH", Path: "/api/pages/PG_1", Status: 200, EntityGUID: "PG_1"}
+	recorded := entry
+	recorded.GUID = "AU_1"
+	cases := []struct {
+		name                         string
+		paramEntry                   audit.Entry
+		getUniqueAuditEntryGUIDCalls []getUniqueAuditEntryGUIDCall
+		createAuditEntryCalls        []createAuditEntryCall
+		returnErr                    error
+	}{
+		{
+			name:                         "test recorded",
+			paramEntry:                   entry,
+			getUniqueAuditEntryGUIDCalls: []getUniqueAuditEntryGUIDCall{{returnGUID: "AU_1"}},
+			createAuditEntryCalls:        []createAuditEntryCall{{paramEntry: recorded, returnEntry: recorded}},
+		},
+		{
+			name:                         "test guid failure",
+			paramEntry:                   entry,
+			getUniqueAuditEntryGUIDCalls: []getUniqueAuditEntryGUIDCall{{returnErr: errors.New("failure")}},
+			returnErr:                    errors.New("failed to get unique audit entry guid: {Entry:{ID:0 GUID: Actor:proxyUser UserID:UR_1 Method:PATCH Path:/api/pages/PG_1 Status:200 EntityGUID:PG_1 Before: After: CreatedAt:<nil>}}: failure"),
+		},
+		{
+			name:                         "test create failure",
+			paramEntry:                   entry,
+			getUniqueAuditEntryGUIDCalls: []getUniqueAuditEntryGUIDCall{{returnGUID: "AU_1"}},
+			createAuditEntryCalls:        []createAuditEntryCall{{paramEntry: recorded, returnErr: errors.New("failure")}},
+			returnErr:                    errors.New("failed to create audit entry: {Entry:{ID:0 GUID:AU_1 Actor:proxyUser UserID:UR_1 Method:PATCH Path:/api/pages/PG_1 Status:200 EntityGUID:PG_1 Before: After: CreatedAt:<nil>}}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditStore := new(mocks.AuditStore)
+			for _, call := range tc.getUniqueAuditEntryGUIDCalls {
+				auditStore.On("GetUniqueAuditEntryGUID", "").Return(call.returnGUID, call.returnErr)
+			}
+			for _, call := range tc.createAuditEntryCalls {
+				auditStore.On("CreateAuditEntry", call.paramEntry).Return(call.returnEntry, call.returnErr)
+			}
+			auditService = AuditService{
+				AuditStore: auditStore,
+			}
+			err := auditService.RecordAuditEntry(ctx, RecordAuditEntryParams{Entry: tc.paramEntry})
+			auditStore.AssertNumberOfCalls(t, "GetUniqueAuditEntryGUID", len(tc.getUniqueAuditEntryGUIDCalls))
+			auditStore.AssertNumberOfCalls(t, "CreateAuditEntry", len(tc.createAuditEntryCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getAuditEntriesCall struct {
+	returnEntries  []audit.Entry
+	returnNextGUID string
+	returnErr      error
+}
+
+func TestGetAuditEntries(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []audit.Entry{{GUID: "AU_2", Actor: "admin"}, {GUID: "AU_1", Actor: "proxyUser", UserID: "UR_1"}}
+	cases := []struct {
+		name                 string
+		paramFilter          audit.Filter
+		paramNextBatchID     string
+		paramLimit           int
+		getAuditEntriesCalls []getAuditEntriesCall
+		returnEntries        []audit.Entry
+		returnNextBatchID    string
+		returnErr            error
+	}{
+		{
+			name:                 "test entries",
+			paramFilter:          audit.Filter{EntityGUID: "PG_1", From: &from},
+			paramNextBatchID:     "AU_3",
+			paramLimit:           2,
+			getAuditEntriesCalls: []getAuditEntriesCall{{returnEntries: entries, returnNextGUID: "AU_0"}},
+			returnEntries:        entries,
+			returnNextBatchID:    "AU_0",
+		},
+		{
+			name:                 "test store failure",
+			paramLimit:           2,
+			getAuditEntriesCalls: []getAuditEntriesCall{{returnErr: errors.New("failure")}},
+			returnErr:            errors.New("failed to get audit entries: {Filter:{UserID: EntityGUID: From:<nil> To:<nil>} NextBatchID: Limit:2}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditStore := new(mocks.AuditStore)
+			for _, call := range tc.getAuditEntriesCalls {
+				auditStore.On("GetAuditEntries", tc.paramFilter, tc.paramNextBatchID, tc.paramLimit).Return(call.returnEntries, call.returnNextGUID, call.returnErr)
+			}
+			auditService = AuditService{
+				AuditStore: auditStore,
+			}
+			result, nextBatchID, err := auditService.GetAuditEntries(ctx, GetAuditEntriesParams{
+				Filter:      tc.paramFilter,
+				NextBatchID: tc.paramNextBatchID,
+				Limit:       tc.paramLimit,
+			})
+			auditStore.AssertNumberOfCalls(t, "GetAuditEntries", len(tc.getAuditEntriesCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnEntries, result)
+			require.Equal(t, tc.returnNextBatchID, nextBatchID)
+		})
+	}
+}
@@ -0,0 +1,473 @@
+package backupservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/backup"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	pageservice "github.com/Pergamene/project-spiderweb-service/internal/services/page"
+	policyservice "github.com/Pergamene/project-spiderweb-service/internal/services/policy"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/pkg/errors"
+)
+
+// exportBatchSize is the number of pages fetched at a time when exporting.
+const exportBatchSize = 100
+
+// BackupService is the service for handling backup-related APIs
+type BackupService struct {
+	PageStore         store.PageStore
+	PageDetailStore   store.PageDetailStore
+	PageTemplateStore store.PageTemplateStore
+	PropertyStore     store.PropertyStore
+	VersionStore      store.VersionStore
+	UserStore         store.UserStore
+	// SearchIndexer is optional; when set, restored pages are indexed.
+	SearchIndexer SearchIndexer
+}
+
+func (s BackupService) policy() policyservice.PolicyService {
+	return policyservice.PolicyService{PageStore: s.PageStore, PageTemplateStore: s.PageTemplateStore, VersionStore: s.VersionStore}
+}
+
+// SearchIndexer keeps the search index up to date with the pages.
+type SearchIndexer interface {
+	IndexPage(ctx context.Context, params pageservice.IndexPageParams) error
+}
+
+func (s BackupService) indexPage(ctx context.Context, pageGUID string) error {
+	if s.SearchIndexer == nil {
+		return nil
+	}
+	return s.SearchIndexer.IndexPage(ctx, pageservice.IndexPageParams{Page: page.Page{GUID: pageGUID}})
+}
+
+// ExportBackupParams params for ExportBackup
+type ExportBackupParams struct {
+	UserID string
+}
+
+// ExportBackup returns a bundle of the pages the user owns, along with their properties and details, and the user's properties, versions and page templates.
+// The versions, page templates and properties the pages use are always included, even if they are disabled or belong to someone else,
+// so that the bundle can be restored on its own.
+func (s BackupService) ExportBackup(ctx context.Context, params ExportBackupParams) (backup.Bundle, error) {
+	createdAt := time.Now()
+	b := backup.Bundle{Format: backup.Format, CreatedAt: &createdAt}
+	var err error
+	b.Properties, err = s.PropertyStore.GetProperties(params.UserID)
+	if err != nil {
+		return backup.Bundle{}, errors.Wrapf(err, "failed to get properties: %+v", params)
+	}
+	b.Versions, err = s.VersionStore.GetVersions(params.UserID)
+	if err != nil {
+		return backup.Bundle{}, errors.Wrapf(err, "failed to get versions: %+v", params)
+	}
+	b.PageTemplates, err = s.PageTemplateStore.GetPageTemplates(params.UserID)
+	if err != nil {
+		return backup.Bundle{}, errors.Wrapf(err, "failed to get page templates: %+v", params)
+	}
+	b.Pages, err = s.getPages(params.UserID)
+	if err != nil {
+		return backup.Bundle{}, errors.Wrapf(err, "failed to get pages: %+v", params)
+	}
+	b.Versions, err = s.addMissingVersions(b.Versions, b.Pages)
+	if err != nil {
+		return backup.Bundle{}, errors.Wrapf(err, "failed to get versions used by the pages: %+v", params)
+	}
+	b.PageTemplates, err = s.addMissingPageTemplates(b.PageTemplates, b.Pages)
+	if err != nil {
+		return backup.Bundle{}, errors.Wrapf(err, "failed to get page templates used by the pages: %+v", params)
+	}
+	b.Properties = addMissingProperties(b.Properties, b.PageTemplates, b.Pages)
+	return b, nil
+}
+
+// getPages returns the pages the user owns, leaving out the ones that were only shared with them to edit, since those belong to someone else's backup.
+func (s BackupService) getPages(userID string) ([]backup.Page, error) {
+	pages := make([]backup.Page, 0)
+	cursor := pagesort.Cursor{}
+	for {
+		batch, _, nextCursor, err := s.PageStore.GetPages(userID, pagefilter.Filter{OwnedOnly: true}, pagesort.DefaultSort, cursor, exportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range batch {
+			properties, err := s.PageStore.GetPageProperties(p.GUID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get properties of page %v", p.GUID)
+			}
+			details, err := s.PageDetailStore.GetPageDetails(p.GUID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get details of page %v", p.GUID)
+			}
+			pages = append(pages, backup.Page{
+				GUID:             p.GUID,
+				VersionGUID:      p.Version.GUID,
+				PageTemplateGUID: p.PageTemplate.GUID,
+				Title:            p.Title,
+				Summary:          p.Summary,
+				PermissionType:   p.PermissionType,
+				Properties:       properties,
+				Details:          details,
+			})
+		}
+		if nextCursor.IsZero() {
+			return pages, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// addMissingVersions adds the versions of the pages, and the ancestors of every version, that are not yet part of the bundle.
+func (s BackupService) addMissingVersions(versions []version.Version, pages []backup.Page) ([]version.Version, error) {
+	included := make(map[string]bool)
+	for _, v := range versions {
+		included[v.GUID] = true
+	}
+	missing := make([]string, 0)
+	for _, p := range pages {
+		missing = append(missing, p.VersionGUID)
+	}
+	for _, v := range versions {
+		missing = append(missing, v.ParentGUID)
+	}
+	for len(missing) > 0 {
+		versionGUID := missing[0]
+		missing = missing[1:]
+		if versionGUID == "" || included[versionGUID] {
+			continue
+		}
+		v, err := s.VersionStore.GetVersion(versionGUID)
+		if err != nil {
+			return nil, err
+		}
+		included[v.GUID] = true
+		versions = append(versions, v)
+		missing = append(missing, v.ParentGUID)
+	}
+	return versions, nil
+}
+
+func (s BackupService) addMissingPageTemplates(pageTemplates []pagetemplate.PageTemplate, pages []backup.Page) ([]pagetemplate.PageTemplate, error) {
+	included := make(map[string]bool)
+	for _, pt := range pageTemplates {
+		included[pt.GUID] = true
+	}
+	for _, p := range pages {
+		if included[p.PageTemplateGUID] {
+			continue
+		}
+		pt, err := s.PageTemplateStore.GetPageTemplate(p.PageTemplateGUID)
+		if err != nil {
+			return nil, err
+		}
+		included[pt.GUID] = true
+		pageTemplates = append(pageTemplates, pt)
+	}
+	return pageTemplates, nil
+}
+
+func addMissingProperties(properties []property.Property, pageTemplates []pagetemplate.PageTemplate, pages []backup.Page) []property.Property {
+	included := make(map[string]bool)
+	for _, p := range properties {
+		included[p.Key] = true
+	}
+	add := func(key string, propertyType property.Type) {
+		if included[key] {
+			return
+		}
+		included[key] = true
+		properties = append(properties, property.Property{Key: key, Type: propertyType})
+	}
+	for _, pt := range pageTemplates {
+		for _, p := range pt.Properties {
+			add(p.Key, p.Type)
+		}
+	}
+	for _, page := range pages {
+		for _, p := range page.Properties {
+			add(p.Key, p.Type)
+		}
+	}
+	return properties
+}
+
+// RestoreBackupParams params for RestoreBackup
+type RestoreBackupParams struct {
+	Bundle backup.Bundle
+	UserID string
+}
+
+// RestoreBackup recreates everything in the bundle for the user.
+// Ids are kept where they are free and replaced with new ones where they are taken by other users,
+// with the relations between the restored pages rewritten to match. Properties the user has already registered are reused.
+// Nothing is restored if the user already owns a version, page template or page with an id in the bundle,
+// so that restoring the same backup again, such as after a failed restore, does not duplicate what was already restored.
+func (s BackupService) RestoreBackup(ctx context.Context, params RestoreBackupParams) (backup.Restoration, error) {
+	err := params.Bundle.Validate()
+	if err != nil {
+		return backup.Restoration{}, &serviceerror.InvalidRequest{Message: "the backup cannot be restored", Err: err}
+	}
+	versions, err := params.Bundle.GetVersionsInOrder()
+	if err != nil {
+		return backup.Restoration{}, &serviceerror.InvalidRequest{Message: "the backup cannot be restored", Err: err}
+	}
+	u, err := s.UserStore.GetUser(params.UserID)
+	if err != nil {
+		return backup.Restoration{}, errors.Wrapf(err, "failed to get owner: %v", params.UserID)
+	}
+	restorer := restorer{
+		BackupService: s,
+		userID:        params.UserID,
+		ownerID:       u.ID,
+		restoration:   backup.Restoration{RemappedIDs: make(map[string]string)},
+		propertyIDs:   make(map[string]int64),
+		versions:      make(map[string]version.Version),
+		pageTemplates: make(map[string]pagetemplate.PageTemplate),
+		pageGUIDs:     make(map[string]string),
+	}
+	err = restorer.checkNotRestored(params.Bundle)
+	if err != nil {
+		return restorer.restoration, err
+	}
+	err = restorer.restoreProperties(params.Bundle.Properties)
+	if err != nil {
+		return restorer.restoration, err
+	}
+	err = restorer.restoreVersions(versions)
+	if err != nil {
+		return restorer.restoration, err
+	}
+	err = restorer.restorePageTemplates(params.Bundle.PageTemplates)
+	if err != nil {
+		return restorer.restoration, err
+	}
+	err = restorer.restorePages(params.Bundle.Pages)
+	if err != nil {
+		return restorer.restoration, err
+	}
+	for _, pageGUID := range restorer.pageGUIDs {
+		err = s.indexPage(ctx, pageGUID)
+		if err != nil {
+			return restorer.restoration, err
+		}
+	}
+	return restorer.restoration, nil
+}
+
+// restorer keeps track of what has been restored so far, so that later parts of the bundle can refer to it.
+type restorer struct {
+	BackupService
+	userID        string
+	ownerID       int64
+	restoration   backup.Restoration
+	propertyIDs   map[string]int64
+	versions      map[string]version.Version
+	pageTemplates map[string]pagetemplate.PageTemplate
+	pageGUIDs     map[string]string
+}
+
+// getGUID returns the proposed guid if it is free, and a new guid if it is already taken.
+func (r *restorer) getGUID(getUniqueGUID func(string) (string, error), proposedGUID string) (string, error) {
+	guid, err := getUniqueGUID(proposedGUID)
+	if _, ok := err.(*storeerror.DupEntry); ok {
+		guid, err = getUniqueGUID("")
+		if err == nil {
+			r.restoration.RemappedIDs[proposedGUID] = guid
+		}
+	}
+	return guid, err
+}
+
+// checkNotRestored returns an InvalidRequest if the user already owns any of the versions, page templates or pages of the bundle.
+func (r *restorer) checkNotRestored(b backup.Bundle) error {
+	for _, v := range b.Versions {
+		owned, err := isOwned(r.policy().AuthorizeVersion(v.GUID, r.userID, permission.ActionEdit))
+		if err != nil {
+			return errors.Wrapf(err, "failed to check the owner of version %v", v.GUID)
+		}
+		if owned {
+			return getAlreadyRestoredError("version", v.GUID)
+		}
+	}
+	for _, pt := range b.PageTemplates {
+		owned, err := isOwned(r.policy().AuthorizePageTemplate(pt.GUID, r.userID, permission.ActionEdit))
+		if err != nil {
+			return errors.Wrapf(err, "failed to check the owner of page template %v", pt.GUID)
+		}
+		if owned {
+			return getAlreadyRestoredError("page template", pt.GUID)
+		}
+	}
+	for _, p := range b.Pages {
+		// only the owner of a page may purge it, so being allowed to is being its owner.
+		_, err := r.policy().AuthorizePage(p.GUID, r.userID, permission.ActionPurge)
+		owned, err := isOwned(err)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check the owner of page %v", p.GUID)
+		}
+		if owned {
+			return getAlreadyRestoredError("page", p.GUID)
+		}
+	}
+	return nil
+}
+
+// isOwned returns the result of authorizing the user to take an action on an entity that only its owner may take.
+func isOwned(err error) (bool, error) {
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func getAlreadyRestoredError(entity, guid string) error {
+	return &serviceerror.InvalidRequest{
+		Message: "the backup cannot be restored",
+		Err:     errors.Errorf("%v %v is already yours, so the backup may have already been restored", entity, guid),
+	}
+}
+
+// restoreProperties checks every property against the ones the user has already registered before registering any of them,
+// so that a conflicting property does not leave the restore half done.
+func (r *restorer) restoreProperties(properties []property.Property) error {
+	missing := make([]property.Property, 0)
+	for _, p := range properties {
+		registered, err := r.PropertyStore.GetProperty(p.Key, r.userID)
+		if _, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+			missing = append(missing, p)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get property %v", p.Key)
+		}
+		if registered.Type != p.Type {
+			return &serviceerror.InvalidRequest{Message: "the backup cannot be restored", Err: errors.Errorf("property %v is already registered as a %v", p.Key, registered.Type)}
+		}
+		if registered.Disabled {
+			err = r.PropertyStore.SetPropertyDisabled(registered.ID, false)
+			if err != nil {
+				return errors.Wrapf(err, "failed to enable property %v", p.Key)
+			}
+		}
+		r.propertyIDs[p.Key] = registered.ID
+	}
+	for _, p := range missing {
+		created, err := r.PropertyStore.CreateProperty(property.Property{Key: p.Key, Type: p.Type}, r.ownerID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create property %v", p.Key)
+		}
+		r.propertyIDs[p.Key] = created.ID
+		r.restoration.Properties++
+	}
+	return nil
+}
+
+func (r *restorer) restoreVersions(versions []version.Version) error {
+	for _, v := range versions {
+		versionGUID, err := r.getGUID(r.VersionStore.GetUniqueVersionGUID, v.GUID)
+		if err != nil {
+			return err
+		}
+		restored := version.Version{GUID: versionGUID, Name: v.Name}
+		if !v.IsRoot() {
+			restored.ParentGUID = r.versions[v.ParentGUID].GUID
+		}
+		restored, err = r.VersionStore.CreateVersion(restored, r.ownerID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create version %v", v.GUID)
+		}
+		r.versions[v.GUID] = restored
+		r.restoration.Versions++
+	}
+	return nil
+}
+
+func (r *restorer) restorePageTemplates(pageTemplates []pagetemplate.PageTemplate) error {
+	for _, pt := range pageTemplates {
+		pageTemplateGUID, err := r.getGUID(r.PageTemplateStore.GetUniquePageTemplateGUID, pt.GUID)
+		if err != nil {
+			return err
+		}
+		restored := pagetemplate.PageTemplate{GUID: pageTemplateGUID, Name: pt.Name, Summary: pt.Summary}
+		for _, p := range pt.Properties {
+			p.PropertyID = r.propertyIDs[p.Key]
+			restored.Properties = append(restored.Properties, p)
+		}
+		restored, err = r.PageTemplateStore.CreatePageTemplate(restored, r.ownerID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create page template %v", pt.GUID)
+		}
+		r.pageTemplates[pt.GUID] = restored
+		r.restoration.PageTemplates++
+	}
+	return nil
+}
+
+// restorePages picks the guid of every page before restoring any details, so that relations to pages later in the bundle can be rewritten.
+func (r *restorer) restorePages(pages []backup.Page) error {
+	for _, p := range pages {
+		pageGUID, err := r.getGUID(r.PageStore.GetUniquePageGUID, p.GUID)
+		if err != nil {
+			return err
+		}
+		r.pageGUIDs[p.GUID] = pageGUID
+	}
+	for _, p := range pages {
+		err := r.restorePage(p)
+		if err != nil {
+			return errors.Wrapf(err, "failed to restore page %v", p.GUID)
+		}
+		r.restoration.Pages++
+	}
+	return nil
+}
+
+func (r *restorer) restorePage(p backup.Page) error {
+	restored, err := r.PageStore.CreatePage(page.Page{
+		GUID:           r.pageGUIDs[p.GUID],
+		Version:        r.versions[p.VersionGUID],
+		PageTemplate:   r.pageTemplates[p.PageTemplateGUID],
+		Title:          p.Title,
+		Summary:        p.Summary,
+		PermissionType: p.PermissionType,
+	}, r.ownerID)
+	if err != nil {
+		return err
+	}
+	if len(p.Properties) > 0 {
+		properties := make([]property.Property, 0, len(p.Properties))
+		for _, prop := range p.Properties {
+			prop.ID = r.propertyIDs[prop.Key]
+			properties = append(properties, prop)
+		}
+		err = r.PageStore.ReplacePageProperties(restored.GUID, properties)
+		if err != nil {
+			return errors.Wrap(err, "failed to add page properties")
+		}
+	}
+	for _, d := range p.Details {
+		d.GUID, err = r.getGUID(r.PageDetailStore.GetUniquePageDetailGUID, d.GUID)
+		if err != nil {
+			return err
+		}
+		d.ID = 0
+		d.Partitions = relation.Remap(d.Partitions, r.pageGUIDs)
+		_, err = r.PageDetailStore.CreatePageDetail(restored.GUID, d)
+		if err != nil {
+			return errors.Wrap(err, "failed to create page detail")
+		}
+		r.restoration.Details++
+	}
+	return nil
+}
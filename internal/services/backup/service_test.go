@@ -0,0 +1,395 @@
+package backupservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/backup"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var backupService BackupService
+var ctx context.Context
+
+func TestMain(m *testing.M) {
+	ctx = context.Background()
+	result := m.Run()
+	os.Exit(result)
+}
+
+type mockStores struct {
+	pageStore         *mocks.PageStore
+	pageDetailStore   *mocks.PageDetailStore
+	pageTemplateStore *mocks.PageTemplateStore
+	propertyStore     *mocks.PropertyStore
+	versionStore      *mocks.VersionStore
+	userStore         *mocks.UserStore
+}
+
+func newMockStores() mockStores {
+	return mockStores{
+		pageStore:         new(mocks.PageStore),
+		pageDetailStore:   new(mocks.PageDetailStore),
+		pageTemplateStore: new(mocks.PageTemplateStore),
+		propertyStore:     new(mocks.PropertyStore),
+		versionStore:      new(mocks.VersionStore),
+		userStore:         new(mocks.UserStore),
+	}
+}
+
+func (m mockStores) getService() BackupService {
+	return BackupService{
+		PageStore:         m.pageStore,
+		PageDetailStore:   m.pageDetailStore,
+		PageTemplateStore: m.pageTemplateStore,
+		PropertyStore:     m.propertyStore,
+		VersionStore:      m.versionStore,
+		UserStore:         m.userStore,
+	}
+}
+
+func (m mockStores) assertExpectations(t *testing.T) {
+	m.pageStore.AssertExpectations(t)
+	m.pageDetailStore.AssertExpectations(t)
+	m.pageTemplateStore.AssertExpectations(t)
+	m.propertyStore.AssertExpectations(t)
+	m.versionStore.AssertExpectations(t)
+	m.userStore.AssertExpectations(t)
+}
+
+func TestExportBackup(t *testing.T) {
+	relationPartitions := []pagedetail.Partition{{TypeString: "relation", Value: "Vallaki", Relation: "PG_000000000002"}}
+	cases := []struct {
+		name         string
+		params       ExportBackupParams
+		setupMocks   func(m mockStores)
+		returnBundle backup.Bundle
+		returnErr    error
+	}{
+		{
+			name:   "test pages using another user's version and template",
+			params: ExportBackupParams{UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.propertyStore.On("GetProperties", "UR_1").Return([]property.Property{{ID: 1, Key: "ruler", Type: property.TypeString}}, nil)
+				m.versionStore.On("GetVersions", "UR_1").Return([]version.Version{{ID: 2, GUID: "VR_2", Name: "Session 12", ParentGUID: "VR_1"}}, nil)
+				m.versionStore.On("GetVersion", "VR_1").Return(version.Version{ID: 1, GUID: "VR_1", Name: "Default"}, nil)
+				m.pageTemplateStore.On("GetPageTemplates", "UR_1").Return([]pagetemplate.PageTemplate{}, nil)
+				m.pageTemplateStore.On("GetPageTemplate", "PGT_1").Return(pagetemplate.PageTemplate{
+					ID:         1,
+					GUID:       "PGT_1",
+					Name:       "Settlement",
+					Properties: []pagetemplate.TemplateProperty{{PropertyID: 3, Key: "population", Type: property.TypeNumber}},
+				}, nil)
+				m.pageStore.On("GetPages", "UR_1", pagefilter.Filter{OwnedOnly: true}, pagesort.DefaultSort, pagesort.Cursor{}, exportBatchSize).Return([]page.Page{
+					{ID: 1, GUID: "PG_1", Version: version.Version{GUID: "VR_2"}, PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}, Title: "Barovia", PermissionType: permission.TypePrivate},
+				}, 2, pagesort.Cursor{Sort: pagesort.DefaultSort, PageID: 1}, nil).Once()
+				m.pageStore.On("GetPages", "UR_1", pagefilter.Filter{OwnedOnly: true}, pagesort.DefaultSort, pagesort.Cursor{Sort: pagesort.DefaultSort, PageID: 1}, exportBatchSize).Return([]page.Page{
+					{ID: 2, GUID: "PG_2", Version: version.Version{GUID: "VR_1"}, PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}, Title: "Vallaki", PermissionType: permission.TypePublic},
+				}, 2, pagesort.Cursor{}, nil).Once()
+				m.pageStore.On("GetPageProperties", "PG_1").Return([]property.Property{{ID: 1, Key: "ruler", Type: property.TypeString, Value: "Strahd"}}, nil)
+				m.pageStore.On("GetPageProperties", "PG_2").Return([]property.Property{}, nil)
+				m.pageDetailStore.On("GetPageDetails", "PG_1").Return([]pagedetail.PageDetail{{ID: 1, GUID: "DT_1", Title: "Roads", Partitions: relationPartitions}}, nil)
+				m.pageDetailStore.On("GetPageDetails", "PG_2").Return([]pagedetail.PageDetail{}, nil)
+			},
+			returnBundle: backup.Bundle{
+				Format:     backup.Format,
+				Properties: []property.Property{{ID: 1, Key: "ruler", Type: property.TypeString}, {Key: "population", Type: property.TypeNumber}},
+				Versions:   []version.Version{{ID: 2, GUID: "VR_2", Name: "Session 12", ParentGUID: "VR_1"}, {ID: 1, GUID: "VR_1", Name: "Default"}},
+				PageTemplates: []pagetemplate.PageTemplate{{
+					ID:         1,
+					GUID:       "PGT_1",
+					Name:       "Settlement",
+					Properties: []pagetemplate.TemplateProperty{{PropertyID: 3, Key: "population", Type: property.TypeNumber}},
+				}},
+				Pages: []backup.Page{
+					{
+						GUID:             "PG_1",
+						VersionGUID:      "VR_2",
+						PageTemplateGUID: "PGT_1",
+						Title:            "Barovia",
+						PermissionType:   permission.TypePrivate,
+						Properties:       []property.Property{{ID: 1, Key: "ruler", Type: property.TypeString, Value: "Strahd"}},
+						Details:          []pagedetail.PageDetail{{ID: 1, GUID: "DT_1", Title: "Roads", Partitions: relationPartitions}},
+					},
+					{
+						GUID:             "PG_2",
+						VersionGUID:      "VR_1",
+						PageTemplateGUID: "PGT_1",
+						Title:            "Vallaki",
+						PermissionType:   permission.TypePublic,
+						Properties:       []property.Property{},
+						Details:          []pagedetail.PageDetail{},
+					},
+				},
+			},
+		},
+		{
+			name:   "test page shared with the user to edit",
+			params: ExportBackupParams{UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.propertyStore.On("GetProperties", "UR_1").Return([]property.Property{}, nil)
+				m.versionStore.On("GetVersions", "UR_1").Return([]version.Version{{ID: 1, GUID: "VR_1", Name: "Default"}}, nil)
+				m.pageTemplateStore.On("GetPageTemplates", "UR_1").Return([]pagetemplate.PageTemplate{{ID: 1, GUID: "PGT_1", Name: "Settlement"}}, nil)
+				// PG_2 is owned by another user and only shared with UR_1 to edit.
+				m.pageStore.On("GetPages", "UR_1", mock.Anything, pagesort.DefaultSort, pagesort.Cursor{}, exportBatchSize).Return(
+					func(userID string, filter pagefilter.Filter, sort pagesort.Sort, cursor pagesort.Cursor, limit int) []page.Page {
+						pages := []page.Page{{ID: 1, GUID: "PG_1", Version: version.Version{GUID: "VR_1"}, PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}, Title: "Barovia", PermissionType: permission.TypePrivate}}
+						if filter.OwnedOnly {
+							return pages
+						}
+						return append(pages, page.Page{ID: 2, GUID: "PG_2", Version: version.Version{GUID: "VR_1"}, PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}, Title: "Vallaki", PermissionType: permission.TypePrivate})
+					}, 1, pagesort.Cursor{}, nil)
+				m.pageStore.On("GetPageProperties", "PG_1").Return([]property.Property{}, nil)
+				m.pageDetailStore.On("GetPageDetails", "PG_1").Return([]pagedetail.PageDetail{}, nil)
+			},
+			returnBundle: backup.Bundle{
+				Format:        backup.Format,
+				Properties:    []property.Property{},
+				Versions:      []version.Version{{ID: 1, GUID: "VR_1", Name: "Default"}},
+				PageTemplates: []pagetemplate.PageTemplate{{ID: 1, GUID: "PGT_1", Name: "Settlement"}},
+				Pages: []backup.Page{
+					{
+						GUID:             "PG_1",
+						VersionGUID:      "VR_1",
+						PageTemplateGUID: "PGT_1",
+						Title:            "Barovia",
+						PermissionType:   permission.TypePrivate,
+						Properties:       []property.Property{},
+						Details:          []pagedetail.PageDetail{},
+					},
+				},
+			},
+		},
+		{
+			name:   "test page store failure",
+			params: ExportBackupParams{UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.propertyStore.On("GetProperties", "UR_1").Return([]property.Property{}, nil)
+				m.versionStore.On("GetVersions", "UR_1").Return([]version.Version{}, nil)
+				m.pageTemplateStore.On("GetPageTemplates", "UR_1").Return([]pagetemplate.PageTemplate{}, nil)
+				m.pageStore.On("GetPages", "UR_1", pagefilter.Filter{OwnedOnly: true}, pagesort.DefaultSort, pagesort.Cursor{}, exportBatchSize).Return(nil, 0, pagesort.Cursor{}, errors.New("failure"))
+			},
+			returnErr: errors.New("failed to get pages: {UserID:UR_1}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newMockStores()
+			tc.setupMocks(m)
+			backupService = m.getService()
+			result, err := backupService.ExportBackup(ctx, tc.params)
+			m.assertExpectations(t)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.NotNil(t, result.CreatedAt)
+			result.CreatedAt = nil
+			require.Equal(t, tc.returnBundle, result)
+		})
+	}
+}
+
+func getBundle() backup.Bundle {
+	return backup.Bundle{
+		Format: backup.Format,
+		Properties: []property.Property{
+			{Key: "population", Type: property.TypeNumber},
+			{Key: "ruler", Type: property.TypeString},
+		},
+		Versions: []version.Version{
+			{GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"},
+			{GUID: "VR_000000000001", Name: "Default"},
+		},
+		PageTemplates: []pagetemplate.PageTemplate{{
+			GUID:       "PGT_00000000001",
+			Name:       "Settlement",
+			Properties: []pagetemplate.TemplateProperty{{Key: "population", Type: property.TypeNumber, DefaultValue: float64(0)}},
+		}},
+		Pages: []backup.Page{
+			{
+				GUID:             "PG_000000000001",
+				VersionGUID:      "VR_000000000002",
+				PageTemplateGUID: "PGT_00000000001",
+				Title:            "Vallaki",
+				PermissionType:   permission.TypePrivate,
+				Properties:       []property.Property{{Key: "ruler", Type: property.TypeString, Value: "Baron Vallakovich"}},
+			},
+			{
+				GUID:             "PG_000000000002",
+				VersionGUID:      "VR_000000000001",
+				PageTemplateGUID: "PGT_00000000001",
+				Title:            "Barovia",
+				PermissionType:   permission.TypePublic,
+				Details: []pagedetail.PageDetail{{
+					GUID:       "DT_000000000001",
+					Title:      "Roads",
+					Partitions: []pagedetail.Partition{{TypeString: "relation", Value: "Vallaki", Relation: "PG_000000000001"}},
+				}},
+			},
+		},
+	}
+}
+
+// mockNotOwned mocks none of the versions, page templates and pages of the bundle being owned by the user.
+func mockNotOwned(m mockStores, userID string) {
+	for _, versionGUID := range []string{"VR_000000000002", "VR_000000000001"} {
+		m.versionStore.On("CanEditVersion", versionGUID, userID).Return(&storeerror.NotAuthorized{UserID: userID, TableID: versionGUID})
+	}
+	m.pageTemplateStore.On("CanEditPageTemplate", "PGT_00000000001", userID).Return(&storeerror.NotAuthorized{UserID: userID, TableID: "PGT_00000000001"})
+	m.pageStore.On("GetPageRole", "PG_000000000001", userID).Return(permission.RolePublic, nil)
+	m.pageStore.On("GetPageRole", "PG_000000000002", userID).Return(permission.RoleNone, nil)
+}
+
+func TestRestoreBackup(t *testing.T) {
+	cases := []struct {
+		name              string
+		params            RestoreBackupParams
+		setupMocks        func(m mockStores)
+		returnRestoration backup.Restoration
+		returnErr         error
+	}{
+		{
+			name:   "test page id collision",
+			params: RestoreBackupParams{Bundle: getBundle(), UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				mockNotOwned(m, "UR_1")
+				m.propertyStore.On("GetProperty", "population", "UR_1").Return(property.Property{ID: 5, Key: "population", Type: property.TypeNumber, Disabled: true}, nil)
+				m.propertyStore.On("SetPropertyDisabled", int64(5), false).Return(nil)
+				m.propertyStore.On("GetProperty", "ruler", "UR_1").Return(property.Property{}, &storeerror.NotFound{ID: "ruler"})
+				m.propertyStore.On("CreateProperty", property.Property{Key: "ruler", Type: property.TypeString}, int64(1)).Return(property.Property{ID: 6, Key: "ruler", Type: property.TypeString}, nil)
+				rootVersion := version.Version{GUID: "VR_000000000001", Name: "Default"}
+				childVersion := version.Version{GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"}
+				m.versionStore.On("GetUniqueVersionGUID", "VR_000000000001").Return("VR_000000000001", nil)
+				m.versionStore.On("CreateVersion", rootVersion, int64(1)).Return(version.Version{ID: 11, GUID: "VR_000000000001", Name: "Default"}, nil)
+				m.versionStore.On("GetUniqueVersionGUID", "VR_000000000002").Return("VR_000000000002", nil)
+				m.versionStore.On("CreateVersion", childVersion, int64(1)).Return(version.Version{ID: 12, GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"}, nil)
+				pageTemplate := pagetemplate.PageTemplate{
+					GUID:       "PGT_00000000001",
+					Name:       "Settlement",
+					Properties: []pagetemplate.TemplateProperty{{PropertyID: 5, Key: "population", Type: property.TypeNumber, DefaultValue: float64(0)}},
+				}
+				m.pageTemplateStore.On("GetUniquePageTemplateGUID", "PGT_00000000001").Return("PGT_00000000001", nil)
+				createdPageTemplate := pageTemplate
+				createdPageTemplate.ID = 21
+				m.pageTemplateStore.On("CreatePageTemplate", pageTemplate, int64(1)).Return(createdPageTemplate, nil)
+				m.pageStore.On("GetUniquePageGUID", "PG_000000000001").Return("", &storeerror.DupEntry{ID: "PG_000000000001"})
+				m.pageStore.On("GetUniquePageGUID", "").Return("PG_00000000000A", nil)
+				m.pageStore.On("GetUniquePageGUID", "PG_000000000002").Return("PG_000000000002", nil)
+				firstPage := page.Page{
+					GUID:           "PG_00000000000A",
+					Version:        version.Version{ID: 12, GUID: "VR_000000000002", Name: "Session 12", ParentGUID: "VR_000000000001"},
+					PageTemplate:   createdPageTemplate,
+					Title:          "Vallaki",
+					PermissionType: permission.TypePrivate,
+				}
+				m.pageStore.On("CreatePage", firstPage, int64(1)).Return(firstPage, nil)
+				m.pageStore.On("ReplacePageProperties", "PG_00000000000A", []property.Property{{ID: 6, Key: "ruler", Type: property.TypeString, Value: "Baron Vallakovich"}}).Return(nil)
+				secondPage := page.Page{
+					GUID:           "PG_000000000002",
+					Version:        version.Version{ID: 11, GUID: "VR_000000000001", Name: "Default"},
+					PageTemplate:   createdPageTemplate,
+					Title:          "Barovia",
+					PermissionType: permission.TypePublic,
+				}
+				m.pageStore.On("CreatePage", secondPage, int64(1)).Return(secondPage, nil)
+				m.pageDetailStore.On("GetUniquePageDetailGUID", "DT_000000000001").Return("DT_000000000001", nil)
+				detail := pagedetail.PageDetail{
+					GUID:       "DT_000000000001",
+					Title:      "Roads",
+					Partitions: []pagedetail.Partition{{Type: pagedetail.PartitionTypeRelation, TypeString: "relation", Value: "Vallaki", Relation: "PG_00000000000A"}},
+				}
+				m.pageDetailStore.On("CreatePageDetail", "PG_000000000002", detail).Return(detail, nil)
+			},
+			returnRestoration: backup.Restoration{
+				Properties:    1,
+				Versions:      2,
+				PageTemplates: 1,
+				Pages:         2,
+				Details:       1,
+				RemappedIDs:   map[string]string{"PG_000000000001": "PG_00000000000A"},
+			},
+		},
+		{
+			name: "test invalid bundle",
+			params: RestoreBackupParams{
+				Bundle: backup.Bundle{Format: 2},
+				UserID: "UR_1",
+			},
+			setupMocks: func(m mockStores) {},
+			returnErr:  errors.New("the backup cannot be restored\nformat 2 is not supported, only format 1 can be restored"),
+		},
+		{
+			name:   "test version already restored",
+			params: RestoreBackupParams{Bundle: getBundle(), UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				m.versionStore.On("CanEditVersion", "VR_000000000002", "UR_1").Return(nil)
+			},
+			returnErr: errors.New("the backup cannot be restored\nversion VR_000000000002 is already yours, so the backup may have already been restored"),
+		},
+		{
+			name:   "test page already restored",
+			params: RestoreBackupParams{Bundle: getBundle(), UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				m.versionStore.On("CanEditVersion", "VR_000000000001", "UR_1").Return(&storeerror.NotAuthorized{UserID: "UR_1", TableID: "VR_000000000001"})
+				m.versionStore.On("CanEditVersion", "VR_000000000002", "UR_1").Return(&storeerror.NotAuthorized{UserID: "UR_1", TableID: "VR_000000000002"})
+				m.pageTemplateStore.On("CanEditPageTemplate", "PGT_00000000001", "UR_1").Return(&storeerror.NotAuthorized{UserID: "UR_1", TableID: "PGT_00000000001"})
+				m.pageStore.On("GetPageRole", "PG_000000000001", "UR_1").Return(permission.RoleOwner, nil)
+			},
+			returnErr: errors.New("the backup cannot be restored\npage PG_000000000001 is already yours, so the backup may have already been restored"),
+		},
+		{
+			name:   "test CanEditPageTemplate error",
+			params: RestoreBackupParams{Bundle: getBundle(), UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				m.versionStore.On("CanEditVersion", "VR_000000000001", "UR_1").Return(&storeerror.NotAuthorized{UserID: "UR_1", TableID: "VR_000000000001"})
+				m.versionStore.On("CanEditVersion", "VR_000000000002", "UR_1").Return(&storeerror.NotAuthorized{UserID: "UR_1", TableID: "VR_000000000002"})
+				m.pageTemplateStore.On("CanEditPageTemplate", "PGT_00000000001", "UR_1").Return(errors.New("failure"))
+			},
+			returnErr: errors.New("failed to check the owner of page template PGT_00000000001: failed to get role: {Entity:pageTemplate GUID:PGT_00000000001 UserID:UR_1 Action:edit}: failure"),
+		},
+		{
+			name:   "test property registered with another type",
+			params: RestoreBackupParams{Bundle: getBundle(), UserID: "UR_1"},
+			setupMocks: func(m mockStores) {
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				mockNotOwned(m, "UR_1")
+				m.propertyStore.On("GetProperty", "population", "UR_1").Return(property.Property{ID: 5, Key: "population", Type: property.TypeString}, nil)
+			},
+			returnErr: errors.New("the backup cannot be restored\nproperty population is already registered as a string"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newMockStores()
+			tc.setupMocks(m)
+			backupService = m.getService()
+			result, err := backupService.RestoreBackup(ctx, tc.params)
+			m.assertExpectations(t)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnRestoration, result)
+		})
+	}
+}
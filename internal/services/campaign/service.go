@@ -0,0 +1,222 @@
+package campaignservice
+
+import (
+	"context"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	policyservice "github.com/Pergamene/project-spiderweb-service/internal/services/policy"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/pkg/errors"
+)
+
+// CampaignService is the service for handling campaign-related APIs
+type CampaignService struct {
+	CampaignStore store.CampaignStore
+	PageStore     store.PageStore
+	UserStore     store.UserStore
+}
+
+// policy returns the policy that the service authorizes users with.
+func (s CampaignService) policy() policyservice.PolicyService {
+	return policyservice.PolicyService{PageStore: s.PageStore, CampaignStore: s.CampaignStore}
+}
+
+// CreateCampaignParams params for CreateCampaign
+type CreateCampaignParams struct {
+	Campaign campaign.Campaign
+	OwnerID  string
+}
+
+// CreateCampaign creates a new campaign owned by the given user.
+func (s CampaignService) CreateCampaign(ctx context.Context, params CreateCampaignParams) (campaign.Campaign, error) {
+	campaignGUID, err := s.CampaignStore.GetUniqueCampaignGUID(params.Campaign.GUID)
+	if err != nil {
+		return campaign.Campaign{}, err
+	}
+	params.Campaign.GUID = campaignGUID
+	u, err := s.UserStore.GetUser(params.OwnerID)
+	if err != nil {
+		return campaign.Campaign{}, errors.Wrapf(err, "failed to get owner: %+v", params)
+	}
+	c, err := s.CampaignStore.CreateCampaign(params.Campaign, u.ID)
+	if err != nil {
+		return c, errors.Wrapf(err, "failed to create campaign: %+v", params)
+	}
+	return c, nil
+}
+
+// GetCampaignParams params for GetCampaign
+type GetCampaignParams struct {
+	Campaign campaign.Campaign
+	UserID   string
+}
+
+// GetCampaign returns the campaign along with its members. Only members may get the campaign.
+func (s CampaignService) GetCampaign(ctx context.Context, params GetCampaignParams) (campaign.Campaign, error) {
+	_, err := s.policy().AuthorizeCampaign(params.Campaign.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return campaign.Campaign{}, err
+	}
+	c, err := s.CampaignStore.GetCampaign(params.Campaign.GUID)
+	if err != nil {
+		return c, errors.Wrapf(err, "failed to get campaign: %+v", params)
+	}
+	c.Members, err = s.CampaignStore.GetCampaignMembers(params.Campaign.GUID)
+	if err != nil {
+		return c, errors.Wrapf(err, "failed to get campaign members: %+v", params)
+	}
+	return c, nil
+}
+
+// GetCampaignsParams params for GetCampaigns
+type GetCampaignsParams struct {
+	UserID string
+}
+
+// GetCampaigns returns the campaigns the user is a member of.
+func (s CampaignService) GetCampaigns(ctx context.Context, params GetCampaignsParams) ([]campaign.Campaign, error) {
+	cs, err := s.CampaignStore.GetCampaigns(params.UserID)
+	if err != nil {
+		return cs, errors.Wrapf(err, "failed to get campaigns: %+v", params)
+	}
+	return cs, nil
+}
+
+// UpdateCampaignParams params for UpdateCampaign
+type UpdateCampaignParams struct {
+	Campaign campaign.Campaign
+	UserID   string
+}
+
+// UpdateCampaign sets the campaign to what is provided. Only the owner may update the campaign.
+func (s CampaignService) UpdateCampaign(ctx context.Context, params UpdateCampaignParams) error {
+	_, err := s.policy().AuthorizeCampaign(params.Campaign.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.CampaignStore.UpdateCampaign(params.Campaign)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update campaign: %+v", params)
+	}
+	return nil
+}
+
+// RemoveCampaignParams params for RemoveCampaign
+type RemoveCampaignParams struct {
+	Campaign campaign.Campaign
+	UserID   string
+}
+
+// RemoveCampaign marks the campaign as removed. Only the owner may remove the campaign.
+func (s CampaignService) RemoveCampaign(ctx context.Context, params RemoveCampaignParams) error {
+	_, err := s.policy().AuthorizeCampaign(params.Campaign.GUID, params.UserID, permission.ActionRemove)
+	if err != nil {
+		return err
+	}
+	err = s.CampaignStore.RemoveCampaign(params.Campaign.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove campaign: %+v", params)
+	}
+	return nil
+}
+
+// SetCampaignMemberParams params for SetCampaignMember
+type SetCampaignMemberParams struct {
+	Campaign campaign.Campaign
+	Member   campaign.Member
+	UserID   string
+}
+
+// SetCampaignMember adds the member to the campaign, or changes the role of an existing member.
+// Only the owner may manage members, and the ownership of a campaign cannot be changed.
+func (s CampaignService) SetCampaignMember(ctx context.Context, params SetCampaignMemberParams) error {
+	_, err := s.policy().AuthorizeCampaign(params.Campaign.GUID, params.UserID, permission.ActionManageMembers)
+	if err != nil {
+		return err
+	}
+	if params.Member.Role == campaign.RoleOwner {
+		return &serviceerror.InvalidRequest{Message: "a campaign can only have one owner"}
+	}
+	if params.Member.UserID == params.UserID {
+		return &serviceerror.InvalidRequest{Message: "cannot change the role of the campaign owner"}
+	}
+	u, err := s.UserStore.GetUser(params.Member.UserID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get member: %+v", params)
+	}
+	err = s.CampaignStore.SetCampaignMember(params.Campaign.GUID, u.ID, params.Member.Role)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set campaign member: %+v", params)
+	}
+	return nil
+}
+
+// RemoveCampaignMemberParams params for RemoveCampaignMember
+type RemoveCampaignMemberParams struct {
+	Campaign campaign.Campaign
+	MemberID string
+	UserID   string
+}
+
+// RemoveCampaignMember removes the member from the campaign.
+// The owner may remove any other member, and any other member may remove themselves.
+func (s CampaignService) RemoveCampaignMember(ctx context.Context, params RemoveCampaignMemberParams) error {
+	// leaving a campaign only takes being a member of it.
+	action := permission.ActionManageMembers
+	if params.MemberID == params.UserID {
+		action = permission.ActionRead
+	}
+	role, err := s.policy().AuthorizeCampaign(params.Campaign.GUID, params.UserID, action)
+	if err != nil {
+		return err
+	}
+	if role == permission.RoleOwner && params.MemberID == params.UserID {
+		return &serviceerror.InvalidRequest{Message: "the campaign owner cannot leave the campaign"}
+	}
+	err = s.CampaignStore.RemoveCampaignMember(params.Campaign.GUID, params.MemberID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove campaign member: %+v", params)
+	}
+	return nil
+}
+
+// CampaignPageParams params for AddCampaignPage and RemoveCampaignPage
+type CampaignPageParams struct {
+	Campaign campaign.Campaign
+	PageID   string
+	UserID   string
+}
+
+// AddCampaignPage shares the page with every member of the campaign.
+// The user must be able to edit the page and manage the pages of the campaign.
+func (s CampaignService) AddCampaignPage(ctx context.Context, params CampaignPageParams) error {
+	_, err := s.policy().AuthorizeCampaign(params.Campaign.GUID, params.UserID, permission.ActionManagePages)
+	if err != nil {
+		return err
+	}
+	_, err = s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.CampaignStore.AddCampaignPage(params.Campaign.GUID, params.PageID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add campaign page: %+v", params)
+	}
+	return nil
+}
+
+// RemoveCampaignPage stops sharing the page with the members of the campaign.
+// The user must be able to manage the pages of the campaign.
+func (s CampaignService) RemoveCampaignPage(ctx context.Context, params CampaignPageParams) error {
+	_, err := s.policy().AuthorizeCampaign(params.Campaign.GUID, params.UserID, permission.ActionManagePages)
+	if err != nil {
+		return err
+	}
+	err = s.CampaignStore.RemoveCampaignPage(params.Campaign.GUID, params.PageID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove campaign page: %+v", params)
+	}
+	return nil
+}
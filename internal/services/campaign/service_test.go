@@ -0,0 +1,407 @@
+package campaignservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+var campaignService CampaignService
+var ctx context.Context
+
+func TestMain(m *testing.M) {
+	ctx = context.Background()
+	result := m.Run()
+	os.Exit(result)
+}
+
+type getCampaignRoleCall struct {
+	paramCampaignGUID string
+	paramUserID       string
+	returnRole        campaign.Role
+	returnErr         error
+}
+
+type getUserCall struct {
+	paramUserGUID string
+	returnUser    appuser.User
+	returnErr     error
+}
+
+type getCampaignCall struct {
+	paramCampaignGUID string
+	returnCampaign    campaign.Campaign
+	returnErr         error
+}
+
+type getCampaignMembersCall struct {
+	paramCampaignGUID string
+	returnMembers     []campaign.Member
+	returnErr         error
+}
+
+func TestGetCampaign(t *testing.T) {
+	cases := []struct {
+		name                    string
+		params                  GetCampaignParams
+		getCampaignRoleCalls    []getCampaignRoleCall
+		getCampaignCalls        []getCampaignCall
+		getCampaignMembersCalls []getCampaignMembersCall
+		returnCampaign          campaign.Campaign
+		returnErr               error
+	}{
+		{
+			name: "test happy path",
+			params: GetCampaignParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				UserID:   "UR_2",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2", returnRole: campaign.RoleViewer},
+			},
+			getCampaignCalls: []getCampaignCall{
+				{paramCampaignGUID: "CP_1", returnCampaign: campaign.Campaign{ID: 1, GUID: "CP_1", Name: "Home Group"}},
+			},
+			getCampaignMembersCalls: []getCampaignMembersCall{
+				{
+					paramCampaignGUID: "CP_1",
+					returnMembers: []campaign.Member{
+						{UserID: "UR_1", Role: campaign.RoleOwner},
+						{UserID: "UR_2", Role: campaign.RoleViewer},
+					},
+				},
+			},
+			returnCampaign: campaign.Campaign{
+				ID:   1,
+				GUID: "CP_1",
+				Name: "Home Group",
+				Members: []campaign.Member{
+					{UserID: "UR_1", Role: campaign.RoleOwner},
+					{UserID: "UR_2", Role: campaign.RoleViewer},
+				},
+			},
+		},
+		{
+			name: "test not a member",
+			params: GetCampaignParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				UserID:   "UR_3",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_3", returnErr: &storeerror.NotAuthorized{UserID: "UR_3", TableID: "CP_1"}},
+			},
+			returnErr: errors.New("User UR_3 is not authorized to perform the action on the ID CP_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignStore := new(mocks.CampaignStore)
+			for index := range tc.getCampaignRoleCalls {
+				campaignStore.On("GetCampaignRole", tc.getCampaignRoleCalls[index].paramCampaignGUID, tc.getCampaignRoleCalls[index].paramUserID).Return(tc.getCampaignRoleCalls[index].returnRole, tc.getCampaignRoleCalls[index].returnErr)
+			}
+			for index := range tc.getCampaignCalls {
+				campaignStore.On("GetCampaign", tc.getCampaignCalls[index].paramCampaignGUID).Return(tc.getCampaignCalls[index].returnCampaign, tc.getCampaignCalls[index].returnErr)
+			}
+			for index := range tc.getCampaignMembersCalls {
+				campaignStore.On("GetCampaignMembers", tc.getCampaignMembersCalls[index].paramCampaignGUID).Return(tc.getCampaignMembersCalls[index].returnMembers, tc.getCampaignMembersCalls[index].returnErr)
+			}
+			campaignService = CampaignService{
+				CampaignStore: campaignStore,
+			}
+			result, err := campaignService.GetCampaign(ctx, tc.params)
+			campaignStore.AssertNumberOfCalls(t, "GetCampaignRole", len(tc.getCampaignRoleCalls))
+			campaignStore.AssertNumberOfCalls(t, "GetCampaign", len(tc.getCampaignCalls))
+			campaignStore.AssertNumberOfCalls(t, "GetCampaignMembers", len(tc.getCampaignMembersCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnCampaign, result)
+		})
+	}
+}
+
+type setCampaignMemberCall struct {
+	paramCampaignGUID string
+	paramUserID       int64
+	paramRole         campaign.Role
+	returnErr         error
+}
+
+func TestSetCampaignMember(t *testing.T) {
+	cases := []struct {
+		name                   string
+		params                 SetCampaignMemberParams
+		getCampaignRoleCalls   []getCampaignRoleCall
+		getUserCalls           []getUserCall
+		setCampaignMemberCalls []setCampaignMemberCall
+		returnErr              error
+	}{
+		{
+			name: "test happy path",
+			params: SetCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				Member:   campaign.Member{UserID: "UR_2", Role: campaign.RoleEditor},
+				UserID:   "UR_1",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_1", returnRole: campaign.RoleOwner},
+			},
+			getUserCalls: []getUserCall{
+				{paramUserGUID: "UR_2", returnUser: appuser.User{ID: 2, GUID: "UR_2"}},
+			},
+			setCampaignMemberCalls: []setCampaignMemberCall{
+				{paramCampaignGUID: "CP_1", paramUserID: 2, paramRole: campaign.RoleEditor},
+			},
+		},
+		{
+			name: "test not the owner",
+			params: SetCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				Member:   campaign.Member{UserID: "UR_3", Role: campaign.RoleViewer},
+				UserID:   "UR_2",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2", returnRole: campaign.RoleEditor},
+			},
+			returnErr: errors.New("User UR_2 is not authorized to perform the action on the ID CP_1"),
+		},
+		{
+			name: "test second owner",
+			params: SetCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				Member:   campaign.Member{UserID: "UR_2", Role: campaign.RoleOwner},
+				UserID:   "UR_1",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_1", returnRole: campaign.RoleOwner},
+			},
+			returnErr: errors.New("a campaign can only have one owner"),
+		},
+		{
+			name: "test owner changing their own role",
+			params: SetCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				Member:   campaign.Member{UserID: "UR_1", Role: campaign.RoleViewer},
+				UserID:   "UR_1",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_1", returnRole: campaign.RoleOwner},
+			},
+			returnErr: errors.New("cannot change the role of the campaign owner"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignStore := new(mocks.CampaignStore)
+			userStore := new(mocks.UserStore)
+			for index := range tc.getCampaignRoleCalls {
+				campaignStore.On("GetCampaignRole", tc.getCampaignRoleCalls[index].paramCampaignGUID, tc.getCampaignRoleCalls[index].paramUserID).Return(tc.getCampaignRoleCalls[index].returnRole, tc.getCampaignRoleCalls[index].returnErr)
+			}
+			for index := range tc.getUserCalls {
+				userStore.On("GetUser", tc.getUserCalls[index].paramUserGUID).Return(tc.getUserCalls[index].returnUser, tc.getUserCalls[index].returnErr)
+			}
+			for index := range tc.setCampaignMemberCalls {
+				campaignStore.On("SetCampaignMember", tc.setCampaignMemberCalls[index].paramCampaignGUID, tc.setCampaignMemberCalls[index].paramUserID, tc.setCampaignMemberCalls[index].paramRole).Return(tc.setCampaignMemberCalls[index].returnErr)
+			}
+			campaignService = CampaignService{
+				CampaignStore: campaignStore,
+				UserStore:     userStore,
+			}
+			err := campaignService.SetCampaignMember(ctx, tc.params)
+			campaignStore.AssertNumberOfCalls(t, "GetCampaignRole", len(tc.getCampaignRoleCalls))
+			userStore.AssertNumberOfCalls(t, "GetUser", len(tc.getUserCalls))
+			campaignStore.AssertNumberOfCalls(t, "SetCampaignMember", len(tc.setCampaignMemberCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type removeCampaignMemberCall struct {
+	paramCampaignGUID string
+	paramUserID       string
+	returnErr         error
+}
+
+func TestRemoveCampaignMember(t *testing.T) {
+	cases := []struct {
+		name                      string
+		params                    RemoveCampaignMemberParams
+		getCampaignRoleCalls      []getCampaignRoleCall
+		removeCampaignMemberCalls []removeCampaignMemberCall
+		returnErr                 error
+	}{
+		{
+			name: "test owner removes a member",
+			params: RemoveCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				MemberID: "UR_2",
+				UserID:   "UR_1",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_1", returnRole: campaign.RoleOwner},
+			},
+			removeCampaignMemberCalls: []removeCampaignMemberCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2"},
+			},
+		},
+		{
+			name: "test member leaves",
+			params: RemoveCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				MemberID: "UR_2",
+				UserID:   "UR_2",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2", returnRole: campaign.RoleViewer},
+			},
+			removeCampaignMemberCalls: []removeCampaignMemberCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2"},
+			},
+		},
+		{
+			name: "test member removes another member",
+			params: RemoveCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				MemberID: "UR_3",
+				UserID:   "UR_2",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2", returnRole: campaign.RoleEditor},
+			},
+			returnErr: errors.New("User UR_2 is not authorized to perform the action on the ID CP_1"),
+		},
+		{
+			name: "test owner leaves",
+			params: RemoveCampaignMemberParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				MemberID: "UR_1",
+				UserID:   "UR_1",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_1", returnRole: campaign.RoleOwner},
+			},
+			returnErr: errors.New("the campaign owner cannot leave the campaign"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignStore := new(mocks.CampaignStore)
+			for index := range tc.getCampaignRoleCalls {
+				campaignStore.On("GetCampaignRole", tc.getCampaignRoleCalls[index].paramCampaignGUID, tc.getCampaignRoleCalls[index].paramUserID).Return(tc.getCampaignRoleCalls[index].returnRole, tc.getCampaignRoleCalls[index].returnErr)
+			}
+			for index := range tc.removeCampaignMemberCalls {
+				campaignStore.On("RemoveCampaignMember", tc.removeCampaignMemberCalls[index].paramCampaignGUID, tc.removeCampaignMemberCalls[index].paramUserID).Return(tc.removeCampaignMemberCalls[index].returnErr)
+			}
+			campaignService = CampaignService{
+				CampaignStore: campaignStore,
+			}
+			err := campaignService.RemoveCampaignMember(ctx, tc.params)
+			campaignStore.AssertNumberOfCalls(t, "GetCampaignRole", len(tc.getCampaignRoleCalls))
+			campaignStore.AssertNumberOfCalls(t, "RemoveCampaignMember", len(tc.removeCampaignMemberCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getPageRoleCall struct {
+	paramPageGUID   string
+	paramPageUserID string
+	returnRole      permission.Role
+	returnErr       error
+}
+
+type addCampaignPageCall struct {
+	paramCampaignGUID string
+	paramPageGUID     string
+	returnErr         error
+}
+
+func TestAddCampaignPage(t *testing.T) {
+	cases := []struct {
+		name                 string
+		params               CampaignPageParams
+		getCampaignRoleCalls []getCampaignRoleCall
+		getPageRoleCalls     []getPageRoleCall
+		addCampaignPageCalls []addCampaignPageCall
+		returnErr            error
+	}{
+		{
+			name: "test happy path",
+			params: CampaignPageParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				PageID:   "PG_1",
+				UserID:   "UR_2",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2", returnRole: campaign.RoleEditor},
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_2", returnRole: permission.RoleOwner},
+			},
+			addCampaignPageCalls: []addCampaignPageCall{
+				{paramCampaignGUID: "CP_1", paramPageGUID: "PG_1"},
+			},
+		},
+		{
+			name: "test viewer cannot add pages",
+			params: CampaignPageParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				PageID:   "PG_1",
+				UserID:   "UR_3",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_3", returnRole: campaign.RoleViewer},
+			},
+			returnErr: errors.New("User UR_3 is not authorized to perform the action on the ID CP_1"),
+		},
+		{
+			name: "test page of another user",
+			params: CampaignPageParams{
+				Campaign: campaign.Campaign{GUID: "CP_1"},
+				PageID:   "PG_2",
+				UserID:   "UR_2",
+			},
+			getCampaignRoleCalls: []getCampaignRoleCall{
+				{paramCampaignGUID: "CP_1", paramUserID: "UR_2", returnRole: campaign.RoleEditor},
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_2"},
+			},
+			returnErr: errors.New("User UR_2 is not authorized to perform the action on the ID PG_2"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignStore := new(mocks.CampaignStore)
+			pageStore := new(mocks.PageStore)
+			for index := range tc.getCampaignRoleCalls {
+				campaignStore.On("GetCampaignRole", tc.getCampaignRoleCalls[index].paramCampaignGUID, tc.getCampaignRoleCalls[index].paramUserID).Return(tc.getCampaignRoleCalls[index].returnRole, tc.getCampaignRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.addCampaignPageCalls {
+				campaignStore.On("AddCampaignPage", tc.addCampaignPageCalls[index].paramCampaignGUID, tc.addCampaignPageCalls[index].paramPageGUID).Return(tc.addCampaignPageCalls[index].returnErr)
+			}
+			campaignService = CampaignService{
+				CampaignStore: campaignStore,
+				PageStore:     pageStore,
+			}
+			err := campaignService.AddCampaignPage(ctx, tc.params)
+			campaignStore.AssertNumberOfCalls(t, "GetCampaignRole", len(tc.getCampaignRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			campaignStore.AssertNumberOfCalls(t, "AddCampaignPage", len(tc.addCampaignPageCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
@@ -2,11 +2,28 @@ package pageservice
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagemarkdown"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/search"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	policyservice "github.com/Pergamene/project-spiderweb-service/internal/services/policy"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/logging"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 // PageService is the service for handling page-related APIs
@@ -15,6 +32,18 @@ type PageService struct {
 	PageTemplateStore store.PageTemplateStore
 	VersionStore      store.VersionStore
 	UserStore         store.UserStore
+	PageDetailStore   store.PageDetailStore
+	PropertyStore     store.PropertyStore
+	RelationStore     store.RelationStore
+	// SearchStore is optional; when set, pages are re-indexed whenever they change.
+	SearchStore store.SearchStore
+	// RevisionStore is optional; when set, a revision is recorded whenever a page changes.
+	RevisionStore store.RevisionStore
+}
+
+// policy returns the policy that the service authorizes users with.
+func (s PageService) policy() policyservice.PolicyService {
+	return policyservice.PolicyService{PageStore: s.PageStore, PageTemplateStore: s.PageTemplateStore, VersionStore: s.VersionStore}
 }
 
 // CreatePageParams params for CreatePage
@@ -24,11 +53,28 @@ type CreatePageParams struct {
 }
 
 // CreatePage creates a new page.
+// The page starts with every property declared by its template, set to the template's default values.
 func (s PageService) CreatePage(ctx context.Context, params CreatePageParams) (page.Page, error) {
-	err := s.populatePageIDs(ctx, &params.Page)
+	err := s.authorizePageIDs(params.Page, params.OwnerID)
+	if err != nil {
+		return page.Page{}, err
+	}
+	err = s.populatePageIDs(ctx, &params.Page)
 	if err != nil {
 		return page.Page{}, err
 	}
+	if params.Page.PageTemplate.Disabled {
+		return page.Page{}, &serviceerror.InvalidRequest{Message: fmt.Sprintf("page template %v is disabled", params.Page.PageTemplate.GUID)}
+	}
+	// the template's properties are registered to its owner, so the defaults are resolved in the page owner's registry,
+	// which also rejects any that have since been disabled.
+	defaultProperties := params.Page.PageTemplate.GetDefaultProperties()
+	if len(defaultProperties) != 0 {
+		err = s.setRegisteredPropertyIDs(defaultProperties, params.OwnerID)
+		if err != nil {
+			return page.Page{}, err
+		}
+	}
 	pageGUID, err := s.PageStore.GetUniquePageGUID(params.Page.GUID)
 	if err != nil {
 		return page.Page{}, err
@@ -39,6 +85,17 @@ func (s PageService) CreatePage(ctx context.Context, params CreatePageParams) (p
 	if err != nil {
 		return page, errors.Wrapf(err, "failed to create page: %+v", params)
 	}
+	err = s.IndexPage(ctx, IndexPageParams{Page: page})
+	if err != nil {
+		return page, err
+	}
+	if len(defaultProperties) == 0 {
+		return page, nil
+	}
+	err = s.PageStore.ReplacePageProperties(page.GUID, defaultProperties)
+	if err != nil {
+		return page, errors.Wrapf(err, "failed to add the page template properties: %+v", params)
+	}
 	return page, nil
 }
 
@@ -60,6 +117,50 @@ func (s PageService) populatePageIDs(ctx context.Context, p *page.Page) error {
 	return nil
 }
 
+// authorizePageIDs checks that the user owns the version and template that a page is being put in and created from.
+// Both are counted against their owners, such as when removing a version, so they cannot be used by anyone else.
+// An InvalidRequest is returned for those that the user does not own.
+func (s PageService) authorizePageIDs(p page.Page, userID string) error {
+	if p.Version.GUID != "" {
+		err := s.policy().AuthorizeVersion(p.Version.GUID, userID, permission.ActionEdit)
+		if _, ok := err.(*storeerror.NotAuthorized); ok {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("version %v cannot be used by user %v", p.Version.GUID, userID), Err: err}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if p.PageTemplate.GUID != "" {
+		err := s.policy().AuthorizePageTemplate(p.PageTemplate.GUID, userID, permission.ActionRead)
+		if _, ok := err.(*storeerror.NotAuthorized); ok {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("page template %v cannot be used by user %v", p.PageTemplate.GUID, userID), Err: err}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeMovingPage see authorizePageIDs for the version and template that the page is being moved to.
+// The ones the page already has are left out, so that users it was shared with can still update it.
+func (s PageService) authorizeMovingPage(p page.Page, userID string) error {
+	if p.Version.GUID == "" && p.PageTemplate.GUID == "" {
+		return nil
+	}
+	current, err := s.PageStore.GetPage(p.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page: %+v", p)
+	}
+	if p.Version.GUID == current.Version.GUID {
+		p.Version = version.Version{}
+	}
+	if p.PageTemplate.GUID == current.PageTemplate.GUID {
+		p.PageTemplate = pagetemplate.PageTemplate{}
+	}
+	return s.authorizePageIDs(p, userID)
+}
+
 // UpdatePageParams params for UpdatePage
 type UpdatePageParams struct {
 	Page   page.Page
@@ -68,7 +169,11 @@ type UpdatePageParams struct {
 
 // UpdatePage sets a page to what is provided.
 func (s PageService) UpdatePage(ctx context.Context, params UpdatePageParams) error {
-	_, err := s.PageStore.CanEditPage(params.Page.GUID, params.UserID)
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.authorizeMovingPage(params.Page, params.UserID)
 	if err != nil {
 		return err
 	}
@@ -76,11 +181,21 @@ func (s PageService) UpdatePage(ctx context.Context, params UpdatePageParams) er
 	if err != nil {
 		return err
 	}
-	err = s.PageStore.UpdatePage(params.Page)
+	err = s.RecordRevision(ctx, RecordRevisionParams{
+		Page:   params.Page,
+		UserID: params.UserID,
+		Write: func() error {
+			err := s.PageStore.UpdatePage(params.Page)
+			if err != nil {
+				return errors.Wrapf(err, "failed to update page: %+v", params)
+			}
+			return nil
+		},
+	})
 	if err != nil {
-		return errors.Wrapf(err, "failed to update page: %+v", params)
+		return err
 	}
-	return nil
+	return s.IndexPage(ctx, IndexPageParams{Page: params.Page})
 }
 
 // GetPageParams params for GetPage
@@ -91,7 +206,7 @@ type GetPageParams struct {
 
 // GetPage returns just the page entity.
 func (s PageService) GetPage(ctx context.Context, params GetPageParams) (page.Page, error) {
-	_, err := s.PageStore.CanReadPage(params.Page.GUID, params.UserID)
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionRead)
 	if err != nil {
 		return page.Page{}, err
 	}
@@ -110,10 +225,6 @@ type GetEntirePageParams struct {
 
 // GetEntirePage returns a full page object, with properties, details, etc.
 func (s PageService) GetEntirePage(ctx context.Context, params GetEntirePageParams) (page.Page, error) {
-	_, err := s.PageStore.CanReadPage(params.Page.GUID, params.UserID)
-	if err != nil {
-		return page.Page{}, err
-	}
 	p, err := s.GetPage(ctx, GetPageParams{
 		Page:   params.Page,
 		UserID: params.UserID,
@@ -125,43 +236,279 @@ func (s PageService) GetEntirePage(ctx context.Context, params GetEntirePagePara
 	if err != nil {
 		return p, errors.Wrapf(err, "failed to populate page with ids: %+v", params)
 	}
+	p.PageDetails, err = s.PageDetailStore.GetPageDetails(p.GUID)
+	if err != nil {
+		return p, errors.Wrapf(err, "failed to get page details: %+v", params)
+	}
 	return p, nil
 }
 
 // GetPagesParams params for GetPages
 type GetPagesParams struct {
+	Filter pagefilter.Filter
+	// Sort defaults to pagesort.DefaultSort.
+	Sort pagesort.Sort
+	// Limit is the size of the batch, defaulting to 10.
+	Limit       int
 	NextBatchID string
 	UserID      string
 }
 
+const defaultPagesLimit = 10
+
 // GetPages returns a list of pages filtered and ordered as specified.
+// The returned nextBatchID is an opaque cursor that must be used with the same sort.
 func (s PageService) GetPages(ctx context.Context, params GetPagesParams) ([]page.Page, int, string, error) {
-	ps, total, nextBatchID, err := s.PageStore.GetPages(params.UserID, params.NextBatchID, 10)
+	sort := params.Sort
+	if sort == (pagesort.Sort{}) {
+		sort = pagesort.DefaultSort
+	}
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultPagesLimit
+	}
+	cursor, err := pagesort.DecodeCursor(params.NextBatchID)
+	if err != nil {
+		return []page.Page{}, 0, "", &serviceerror.InvalidRequest{Message: "nextBatchId is not valid", Err: err}
+	}
+	if !cursor.IsZero() && cursor.Sort != sort {
+		return []page.Page{}, 0, "", &serviceerror.InvalidRequest{Message: "nextBatchId was given for a different sort"}
+	}
+	ps, total, nextCursor, err := s.PageStore.GetPages(params.UserID, params.Filter, sort, cursor, limit)
+	if err != nil {
+		return ps, total, "", errors.Wrapf(err, "failed to get pages: %+v", params)
+	}
+	return ps, total, nextCursor.Encode(), nil
+}
+
+// GetPageFacetsParams params for GetPageFacets
+type GetPageFacetsParams struct {
+	Filter pagefilter.Filter
+	UserID string
+}
+
+// GetPageFacets returns how many of the pages matching the filter there are for each page template and each string property value.
+func (s PageService) GetPageFacets(ctx context.Context, params GetPageFacetsParams) (pagefilter.Facets, error) {
+	facets, err := s.PageStore.GetPageFacets(params.UserID, params.Filter)
 	if err != nil {
-		return ps, total, nextBatchID, errors.Wrapf(err, "failed to get pages: %+v", params)
+		return facets, errors.Wrapf(err, "failed to get page facets: %+v", params)
 	}
-	return ps, total, nextBatchID, nil
+	return facets, nil
 }
 
 // RemovePageParams params for RemovePage
 type RemovePageParams struct {
+	Page page.Page
+	// Preview returns the pages that would be affected without removing the page.
+	Preview bool
+	// Unlink rewrites the relations to the page as plain text.
+	Unlink bool
+	// ReplacementPage, when set, is the page the relations to the page are re-pointed to.
+	ReplacementPage page.Page
+	UserID          string
+}
+
+// RemovePage marks the page as removed, returning the pages with relations to it that the user can discover.
+// When unlinking or re-pointing, the relations are only repaired in the pages the user can edit.
+func (s PageService) RemovePage(ctx context.Context, params RemovePageParams) ([]relation.AffectedPage, error) {
+	affected := make([]relation.AffectedPage, 0)
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return affected, err
+	}
+	if params.ReplacementPage.GUID != "" {
+		err = s.checkReplacementPage(params)
+		if err != nil {
+			return affected, err
+		}
+	}
+	backlinks, err := s.GetPageBacklinks(ctx, GetPageBacklinksParams{
+		Page:   params.Page,
+		UserID: params.UserID,
+	})
+	if err != nil {
+		return affected, err
+	}
+	affected = getAffectedPages(params.Page.GUID, backlinks)
+	if params.Preview {
+		return affected, nil
+	}
+	err = s.PageStore.RemovePage(params.Page.GUID)
+	if err != nil {
+		return affected, errors.Wrapf(err, "failed to remove page: %+v", params)
+	}
+	err = s.IndexPage(ctx, IndexPageParams{Page: params.Page})
+	if err != nil {
+		return affected, err
+	}
+	if !params.Unlink && params.ReplacementPage.GUID == "" {
+		return affected, nil
+	}
+	for i := range affected {
+		affected[i].Repaired, err = s.repairRelations(ctx, affected[i].PageGUID, backlinks, params)
+		if err != nil {
+			return affected, errors.Wrapf(err, "failed to repair the relations of page %v: %+v", affected[i].PageGUID, params)
+		}
+	}
+	return affected, nil
+}
+
+func (s PageService) checkReplacementPage(params RemovePageParams) error {
+	if params.ReplacementPage.GUID == params.Page.GUID {
+		return &serviceerror.InvalidRequest{Message: "the replacement page cannot be the page being removed"}
+	}
+	_, err := s.policy().AuthorizePage(params.ReplacementPage.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return err
+	}
+	_, err = s.PageStore.GetPage(params.ReplacementPage.GUID)
+	if _, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		return &serviceerror.InvalidRequest{Message: fmt.Sprintf("replacement page %v does not exist", params.ReplacementPage.GUID), Err: err}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get replacement page: %+v", params)
+	}
+	return nil
+}
+
+// getAffectedPages groups the backlinks by the linking page, in the order they are first seen.
+// Relations from the removed page to itself are left out.
+func getAffectedPages(pageGUID string, backlinks []relation.Backlink) []relation.AffectedPage {
+	affected := make([]relation.AffectedPage, 0)
+	indexes := make(map[string]int)
+	for _, b := range backlinks {
+		if b.PageGUID == pageGUID {
+			continue
+		}
+		index, ok := indexes[b.PageGUID]
+		if !ok {
+			index = len(affected)
+			indexes[b.PageGUID] = index
+			affected = append(affected, relation.AffectedPage{PageGUID: b.PageGUID, PageTitle: b.PageTitle})
+		}
+		affected[index].Relations = affected[index].Relations + 1
+	}
+	return affected
+}
+
+// GetRemovedPagesParams params for GetRemovedPages
+type GetRemovedPagesParams struct {
+	UserID string
+}
+
+// GetRemovedPages returns the removed pages the user can edit, most recently removed first.
+func (s PageService) GetRemovedPages(ctx context.Context, params GetRemovedPagesParams) ([]page.Page, error) {
+	pages, err := s.PageStore.GetRemovedPages(params.UserID)
+	if err != nil {
+		return []page.Page{}, errors.Wrapf(err, "failed to get removed pages: %+v", params)
+	}
+	return pages, nil
+}
+
+// RestorePageParams params for RestorePage
+type RestorePageParams struct {
 	Page   page.Page
 	UserID string
 }
 
-// RemovePage marks the page as removed.
-func (s PageService) RemovePage(ctx context.Context, params RemovePageParams) error {
-	_, err := s.PageStore.CanEditPage(params.Page.GUID, params.UserID)
+// RestorePage undoes the removal of the page. The relations that were unlinked or re-pointed when it was removed are not restored.
+func (s PageService) RestorePage(ctx context.Context, params RestorePageParams) error {
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionEdit)
 	if err != nil {
 		return err
 	}
-	err = s.PageStore.RemovePage(params.Page.GUID)
+	err = s.PageStore.RestorePage(params.Page.GUID)
 	if err != nil {
-		return errors.Wrapf(err, "failed to remove page: %+v", params)
+		return errors.Wrapf(err, "failed to restore page: %+v", params)
+	}
+	return s.IndexPage(ctx, IndexPageParams{Page: params.Page})
+}
+
+// PurgePageParams params for PurgePage
+type PurgePageParams struct {
+	Page   page.Page
+	UserID string
+}
+
+// PurgePage permanently deletes the removed page. Since it cannot be undone, only the page's original owner may purge it.
+func (s PageService) PurgePage(ctx context.Context, params PurgePageParams) error {
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionPurge)
+	if err != nil {
+		return err
+	}
+	err = s.PageStore.PurgePage(params.Page.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to purge page: %+v", params)
 	}
 	return nil
 }
 
+// PurgeRemovedPagesParams params for PurgeRemovedPages
+type PurgeRemovedPagesParams struct {
+	RemovedBefore time.Time
+}
+
+// PurgeRemovedPages permanently deletes every page that was removed before the given time, returning how many were purged.
+// It is meant to be run periodically to enforce how long removed pages are kept for.
+func (s PageService) PurgeRemovedPages(ctx context.Context, params PurgeRemovedPagesParams) (int, error) {
+	pageGUIDs, err := s.PageStore.GetRemovedPageGUIDs(params.RemovedBefore)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the removed pages to purge: %+v", params)
+	}
+	logger := logging.GetFromContext(ctx)
+	for i, pageGUID := range pageGUIDs {
+		err = s.PageStore.PurgePage(pageGUID)
+		if err != nil {
+			return i, errors.Wrapf(err, "failed to purge page %v: %+v", pageGUID, params)
+		}
+		logger.Info("Purged removed page", zap.String("pageId", pageGUID))
+	}
+	return len(pageGUIDs), nil
+}
+
+// repairRelations unlinks or re-points the relations to the removed page in the details of the linking page,
+// returning false if the user cannot edit the linking page.
+func (s PageService) repairRelations(ctx context.Context, pageGUID string, backlinks []relation.Backlink, params RemovePageParams) (bool, error) {
+	_, err := s.policy().AuthorizePage(pageGUID, params.UserID, permission.ActionEdit)
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	err = s.RecordRevision(ctx, RecordRevisionParams{
+		Page:   page.Page{GUID: pageGUID},
+		UserID: params.UserID,
+		Write: func() error {
+			repaired := make(map[string]bool)
+			for _, b := range backlinks {
+				if b.PageGUID != pageGUID || repaired[b.DetailGUID] {
+					continue
+				}
+				repaired[b.DetailGUID] = true
+				detail, err := s.PageDetailStore.GetPageDetail(pageGUID, b.DetailGUID)
+				if err != nil {
+					return err
+				}
+				var changed bool
+				detail.Partitions, changed = relation.Repoint(detail.Partitions, params.Page.GUID, params.ReplacementPage.GUID)
+				if !changed {
+					continue
+				}
+				err = s.PageDetailStore.UpdatePageDetail(pageGUID, detail)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // GetPagePropertiesParams params for GetPageProperties
 type GetPagePropertiesParams struct {
 	Page   page.Page
@@ -171,7 +518,7 @@ type GetPagePropertiesParams struct {
 // GetPageProperties returns the page's properties.
 func (s PageService) GetPageProperties(ctx context.Context, params GetPagePropertiesParams) ([]property.Property, error) {
 	ps := make([]property.Property, 0)
-	_, err := s.PageStore.CanReadPage(params.Page.GUID, params.UserID)
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionRead)
 	if err != nil {
 		return ps, err
 	}
@@ -190,14 +537,605 @@ type ReplacePagePropertiesParams struct {
 }
 
 // ReplacePageProperties replaces the current page's properties with the new properties.
+// Every property must be registered and enabled by the page's owner, and its value must match the registered type.
+// Every property the page's template marks as required must be provided.
 func (s PageService) ReplacePageProperties(ctx context.Context, params ReplacePagePropertiesParams) error {
-	_, err := s.PageStore.CanEditPage(params.Page.GUID, params.UserID)
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.checkRequiredProperties(params.Page.GUID, params.Properties)
+	if err != nil {
+		return err
+	}
+	err = s.setOwnerPropertyIDs(params.Page.GUID, params.Properties)
+	if err != nil {
+		return err
+	}
+	return s.RecordRevision(ctx, RecordRevisionParams{
+		Page:   params.Page,
+		UserID: params.UserID,
+		Write: func() error {
+			err := s.PageStore.ReplacePageProperties(params.Page.GUID, params.Properties)
+			if err != nil {
+				return errors.Wrapf(err, "failed to replace page properties: %+v", params)
+			}
+			return nil
+		},
+	})
+}
+
+func (s PageService) checkRequiredProperties(pageGUID string, properties []property.Property) error {
+	p, err := s.PageStore.GetPage(pageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page: %v", pageGUID)
+	}
+	pt, err := s.PageTemplateStore.GetPageTemplate(p.PageTemplate.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page template: %v", p.PageTemplate.GUID)
+	}
+	providedKeys := make(map[string]bool)
+	for _, p := range properties {
+		providedKeys[p.Key] = true
+	}
+	for _, key := range pt.GetRequiredKeys() {
+		if !providedKeys[key] {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("property %v is required by page template %v", key, pt.Name)}
+		}
+	}
+	return nil
+}
+
+// setOwnerPropertyIDs sets the ids of the properties from the registry of the page's owner,
+// since a page's properties belong to its owner even when it is edited by a user it was shared with.
+func (s PageService) setOwnerPropertyIDs(pageGUID string, properties []property.Property) error {
+	ownerID, err := s.PageStore.GetPageOwnerGUID(pageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get owner of page: %v", pageGUID)
+	}
+	return s.setRegisteredPropertyIDs(properties, ownerID)
+}
+
+func (s PageService) setRegisteredPropertyIDs(properties []property.Property, userID string) error {
+	registeredProperties, err := s.PropertyStore.GetProperties(userID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get registered properties for user: %v", userID)
+	}
+	registeredPropertiesByKey := make(map[string]property.Property)
+	for _, p := range registeredProperties {
+		registeredPropertiesByKey[p.Key] = p
+	}
+	for i := range properties {
+		registeredProperty, ok := registeredPropertiesByKey[properties[i].Key]
+		if !ok {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("property %v is not registered or is disabled", properties[i].Key)}
+		}
+		if registeredProperty.Type != properties[i].Type {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("property %v must be of type %v", properties[i].Key, registeredProperty.Type)}
+		}
+		properties[i].ID = registeredProperty.ID
+	}
+	return nil
+}
+
+// GetPageBacklinksParams params for GetPageBacklinks
+type GetPageBacklinksParams struct {
+	Page   page.Page
+	UserID string
+}
+
+// GetPageBacklinks returns the relations to the page from the details of other pages.
+// Relations from pages the user cannot discover, such as link-only pages, are left out.
+func (s PageService) GetPageBacklinks(ctx context.Context, params GetPageBacklinksParams) ([]relation.Backlink, error) {
+	backlinks := make([]relation.Backlink, 0)
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return backlinks, err
+	}
+	records, err := s.RelationStore.GetBacklinks(params.Page.GUID)
+	if err != nil {
+		return backlinks, errors.Wrapf(err, "failed to get page backlinks: %+v", params)
+	}
+	canRead := make(map[string]bool)
+	for _, record := range records {
+		readable, checked := canRead[record.PageGUID]
+		if !checked {
+			_, err := s.policy().AuthorizePage(record.PageGUID, params.UserID, permission.ActionDiscover)
+			if _, ok := err.(*storeerror.NotAuthorized); !ok && err != nil {
+				return backlinks, errors.Wrapf(err, "failed to check access to linking page %v: %+v", record.PageGUID, params)
+			}
+			readable = err == nil
+			canRead[record.PageGUID] = readable
+		}
+		if readable {
+			backlinks = append(backlinks, record)
+		}
+	}
+	return backlinks, nil
+}
+
+// GetPageGraphParams params for GetPageGraph
+type GetPageGraphParams struct {
+	Page  page.Page
+	Depth int
+	// PageTemplateGUID and VersionGUID, when set, limit the returned pages to those of the given page template and version.
+	PageTemplateGUID string
+	VersionGUID      string
+	UserID           string
+}
+
+// GetPageGraph returns the pages reachable from the page within the given number of relation hops, in either direction,
+// along with the relations between them. Pages the user cannot discover are left out and are not traversed through.
+// Pages that do not match the filters are traversed through but left out, though the page itself is always included.
+func (s PageService) GetPageGraph(ctx context.Context, params GetPageGraphParams) (relation.Graph, error) {
+	graph := relation.Graph{Nodes: []relation.Node{}, Edges: []relation.Edge{}}
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return graph, err
+	}
+	root, err := s.PageStore.GetPage(params.Page.GUID)
+	if err != nil {
+		return graph, errors.Wrapf(err, "failed to get page: %+v", params)
+	}
+	graph.Nodes = append(graph.Nodes, getGraphNode(root, 0))
+	included := map[string]bool{root.GUID: true}
+	visited := map[string]bool{root.GUID: true}
+	frontier := []string{root.GUID}
+	for depth := 1; len(frontier) > 0; depth++ {
+		edges, err := s.RelationStore.GetEdges(frontier)
+		if err != nil {
+			return graph, errors.Wrapf(err, "failed to get page graph edges: %+v", params)
+		}
+		// the edges of the last pages reached are only fetched for the relations between them, not to reach further pages.
+		if depth > params.Depth {
+			graph.Edges = appendGraphEdges(graph.Edges, edges, included)
+			break
+		}
+		next := []string{}
+		for _, edge := range edges {
+			for _, pageGUID := range []string{edge.SourcePageGUID, edge.TargetPageGUID} {
+				if visited[pageGUID] {
+					continue
+				}
+				visited[pageGUID] = true
+				p, ok, err := s.getDiscoverableGraphPage(pageGUID, params.UserID)
+				if err != nil {
+					return graph, errors.Wrapf(err, "failed to get page graph node %v: %+v", pageGUID, params)
+				}
+				if !ok {
+					continue
+				}
+				next = append(next, pageGUID)
+				if params.PageTemplateGUID != "" && p.PageTemplate.GUID != params.PageTemplateGUID {
+					continue
+				}
+				if params.VersionGUID != "" && p.Version.GUID != params.VersionGUID {
+					continue
+				}
+				included[pageGUID] = true
+				graph.Nodes = append(graph.Nodes, getGraphNode(p, depth))
+			}
+		}
+		graph.Edges = appendGraphEdges(graph.Edges, edges, included)
+		frontier = next
+	}
+	return graph, nil
+}
+
+// getDiscoverableGraphPage returns the page, or false if the user cannot discover it or it no longer exists,
+// as is the case for relations to removed pages.
+func (s PageService) getDiscoverableGraphPage(pageGUID, userID string) (page.Page, bool, error) {
+	_, err := s.policy().AuthorizePage(pageGUID, userID, permission.ActionDiscover)
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		return page.Page{}, false, nil
+	}
+	if err != nil {
+		return page.Page{}, false, err
+	}
+	p, err := s.PageStore.GetPage(pageGUID)
+	if _, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		return page.Page{}, false, nil
+	}
+	if err != nil {
+		return page.Page{}, false, err
+	}
+	return p, true, nil
+}
+
+func getGraphNode(p page.Page, depth int) relation.Node {
+	return relation.Node{
+		PageGUID:         p.GUID,
+		Title:            p.Title,
+		PageTemplateGUID: p.PageTemplate.GUID,
+		VersionGUID:      p.Version.GUID,
+		Depth:            depth,
+	}
+}
+
+// appendGraphEdges appends the edges between included pages that are not already in the graph.
+// An edge between two pages of the same depth is returned when the edges of either page are fetched.
+func appendGraphEdges(graphEdges, edges []relation.Edge, included map[string]bool) []relation.Edge {
+	for _, edge := range edges {
+		if !included[edge.SourcePageGUID] || !included[edge.TargetPageGUID] {
+			continue
+		}
+		duplicate := false
+		for _, graphEdge := range graphEdges {
+			if graphEdge.SourcePageGUID == edge.SourcePageGUID && graphEdge.TargetPageGUID == edge.TargetPageGUID {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			graphEdges = append(graphEdges, edge)
+		}
+	}
+	return graphEdges
+}
+
+// GetDanglingRelationsParams params for GetDanglingRelations
+type GetDanglingRelationsParams struct {
+	UserID string
+}
+
+// GetDanglingRelations returns the relations from the pages the user can edit that cannot be followed,
+// because they link to a page that was removed, never existed, or that the user cannot read.
+func (s PageService) GetDanglingRelations(ctx context.Context, params GetDanglingRelationsParams) ([]relation.Link, error) {
+	dangling := make([]relation.Link, 0)
+	links, err := s.RelationStore.GetLinks(params.UserID)
+	if err != nil {
+		return dangling, errors.Wrapf(err, "failed to get links: %+v", params)
+	}
+	canRead := make(map[string]bool)
+	for _, link := range links {
+		if link.Status == relation.StatusLive {
+			readable, checked := canRead[link.TargetPageGUID]
+			if !checked {
+				_, err := s.policy().AuthorizePage(link.TargetPageGUID, params.UserID, permission.ActionRead)
+				if _, ok := err.(*storeerror.NotAuthorized); !ok && err != nil {
+					return dangling, errors.Wrapf(err, "failed to check access to linked page %v: %+v", link.TargetPageGUID, params)
+				}
+				readable = err == nil
+				canRead[link.TargetPageGUID] = readable
+			}
+			if readable {
+				continue
+			}
+			link.Status = relation.StatusUnreadable
+		}
+		dangling = append(dangling, link)
+	}
+	return dangling, nil
+}
+
+// ExportPageMarkdownParams params for ExportPageMarkdown
+type ExportPageMarkdownParams struct {
+	Page   page.Page
+	UserID string
+}
+
+// ExportPageMarkdown returns the page, its properties and its details as a Markdown document.
+func (s PageService) ExportPageMarkdown(ctx context.Context, params ExportPageMarkdownParams) (string, error) {
+	p, err := s.GetPage(ctx, GetPageParams{
+		Page:   params.Page,
+		UserID: params.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+	properties, err := s.PageStore.GetPageProperties(p.GUID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get page properties to export: %+v", params)
+	}
+	p.PageDetails, err = s.PageDetailStore.GetPageDetails(p.GUID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get page details to export: %+v", params)
+	}
+	return pagemarkdown.Export(p, properties), nil
+}
+
+// SearchPagesParams params for SearchPages
+type SearchPagesParams struct {
+	Query  string
+	Limit  int
+	UserID string
+}
+
+// SearchPages returns up to the limit of the pages the user can discover that contain every term of the query, ranked by relevance.
+// Nothing is found without a SearchStore.
+func (s PageService) SearchPages(ctx context.Context, params SearchPagesParams) ([]search.Result, error) {
+	if s.SearchStore == nil {
+		return make([]search.Result, 0), nil
+	}
+	pageGUIDs, err := s.PageStore.GetUserPageGUIDs(params.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the pages of the user to search: %+v", params)
+	}
+	results, err := s.SearchStore.Search(params.Query, search.NewFilter(pageGUIDs), params.Limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to search pages: %+v", params)
+	}
+	return results, nil
+}
+
+// IndexPageParams params for IndexPage
+type IndexPageParams struct {
+	Page page.Page
+}
+
+// IndexPage updates the search index with the current text of the page and its details,
+// or removes the page from the index if it has been removed. Does nothing without a SearchStore.
+func (s PageService) IndexPage(ctx context.Context, params IndexPageParams) error {
+	if s.SearchStore == nil {
+		return nil
+	}
+	p, err := s.PageStore.GetPage(params.Page.GUID)
+	if _, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		err = s.SearchStore.RemovePage(params.Page.GUID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove page from the search index: %+v", params)
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page to index: %+v", params)
+	}
+	details, err := s.PageDetailStore.GetPageDetails(params.Page.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page details to index: %+v", params)
+	}
+	err = s.SearchStore.IndexPage(search.NewDocument(p, details))
+	if err != nil {
+		return errors.Wrapf(err, "failed to index page: %+v", params)
+	}
+	return nil
+}
+
+// IndexAllPages rebuilds the search index from every page that has not been removed. Does nothing without a SearchStore.
+// A page that fails to be indexed is logged and skipped, so that it does not keep the rest of the pages from being found.
+func (s PageService) IndexAllPages(ctx context.Context) error {
+	if s.SearchStore == nil {
+		return nil
+	}
+	pageGUIDs, err := s.PageStore.GetAllPageGUIDs()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the pages to index")
+	}
+	logger := logging.GetFromContext(ctx)
+	failed := 0
+	for _, pageGUID := range pageGUIDs {
+		err = s.IndexPage(ctx, IndexPageParams{Page: page.Page{GUID: pageGUID}})
+		if err != nil {
+			failed++
+			logger.Error("Failed to index page",
+				zap.String("pageId", pageGUID),
+				zap.String("err", err.Error()),
+				zap.String("errVerbose", fmt.Sprintf("%+v", err)),
+			)
+		}
+	}
+	logger.Info("Indexed all pages", zap.Int("pages", len(pageGUIDs)-failed), zap.Int("failed", failed))
+	return nil
+}
+
+// RecordRevisionParams params for RecordRevision
+type RecordRevisionParams struct {
+	Page   page.Page
+	UserID string
+	// Write makes the changes to the page that the revision is recorded for.
+	Write func() error
+}
+
+// RecordRevision makes the write and records a revision of the page by the user with the changes it made.
+// The first time a page is changed, a revision without an author is recorded first with the page's content before the change,
+// so that every page can be restored to how it was before any revisions were recorded.
+// Nothing is recorded if the write fails or does not change the page. Without a RevisionStore, only the write is made.
+func (s PageService) RecordRevision(ctx context.Context, params RecordRevisionParams) error {
+	if s.RevisionStore == nil {
+		return params.Write()
+	}
+	before, err := s.getPageSnapshot(params.Page.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page before the revision: %v", params.Page.GUID)
+	}
+	err = params.Write()
+	if err != nil {
+		return err
+	}
+	after, err := s.getPageSnapshot(params.Page.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page after the revision: %v", params.Page.GUID)
+	}
+	changes := revision.Compare(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	hasRevisions, err := s.RevisionStore.HasRevisions(params.Page.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for page revisions: %v", params.Page.GUID)
+	}
+	if !hasRevisions {
+		err = s.createRevision(revision.Revision{PageGUID: params.Page.GUID, Changes: []revision.Change{}, Snapshot: &before})
+		if err != nil {
+			return err
+		}
+	}
+	u, err := s.UserStore.GetUser(params.UserID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get revision author: %v", params.UserID)
+	}
+	return s.createRevision(revision.Revision{PageGUID: params.Page.GUID, AuthorID: u.ID, Changes: changes, Snapshot: &after})
+}
+
+func (s PageService) createRevision(record revision.Revision) error {
+	revisionGUID, err := s.RevisionStore.GetUniqueRevisionGUID("")
 	if err != nil {
 		return err
 	}
-	err = s.PageStore.ReplacePageProperties(params.Page.GUID, params.Properties)
+	record.GUID = revisionGUID
+	_, err = s.RevisionStore.CreateRevision(record)
 	if err != nil {
-		return errors.Wrapf(err, "failed to replace page properties: %+v", params)
+		return errors.Wrapf(err, "failed to create page revision: %v", record.PageGUID)
 	}
 	return nil
 }
+
+func (s PageService) getPageSnapshot(pageGUID string) (pagediff.Snapshot, error) {
+	p, err := s.PageStore.GetPage(pageGUID)
+	if err != nil {
+		return pagediff.Snapshot{}, errors.Wrapf(err, "failed to get page: %v", pageGUID)
+	}
+	properties, err := s.PageStore.GetPageProperties(pageGUID)
+	if err != nil {
+		return pagediff.Snapshot{}, errors.Wrapf(err, "failed to get page properties: %v", pageGUID)
+	}
+	details, err := s.PageDetailStore.GetPageDetails(pageGUID)
+	if err != nil {
+		return pagediff.Snapshot{}, errors.Wrapf(err, "failed to get page details: %v", pageGUID)
+	}
+	return pagediff.Snapshot{
+		Title:      p.Title,
+		Summary:    p.Summary,
+		Properties: properties,
+		Details:    details,
+	}, nil
+}
+
+// GetPageRevisionsParams params for GetPageRevisions
+type GetPageRevisionsParams struct {
+	Page   page.Page
+	UserID string
+}
+
+// GetPageRevisions returns the revisions of the page, newest first, without their snapshots.
+func (s PageService) GetPageRevisions(ctx context.Context, params GetPageRevisionsParams) ([]revision.Revision, error) {
+	revisions := make([]revision.Revision, 0)
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return revisions, err
+	}
+	revisions, err = s.RevisionStore.GetRevisions(params.Page.GUID)
+	if err != nil {
+		return revisions, errors.Wrapf(err, "failed to get page revisions: %+v", params)
+	}
+	return revisions, nil
+}
+
+// GetPageRevisionParams params for GetPageRevision
+type GetPageRevisionParams struct {
+	Page     page.Page
+	Revision revision.Revision
+	UserID   string
+}
+
+// GetPageRevision returns the revision of the page, with the content of the page as of the revision.
+func (s PageService) GetPageRevision(ctx context.Context, params GetPageRevisionParams) (revision.Revision, error) {
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return revision.Revision{}, err
+	}
+	r, err := s.RevisionStore.GetRevision(params.Page.GUID, params.Revision.GUID)
+	if err != nil {
+		return r, errors.Wrapf(err, "failed to get page revision: %+v", params)
+	}
+	return r, nil
+}
+
+// RestorePageRevisionParams params for RestorePageRevision
+type RestorePageRevisionParams struct {
+	Page     page.Page
+	Revision revision.Revision
+	UserID   string
+}
+
+// RestorePageRevision sets the page's title, summary, properties and details back to how they were as of the revision,
+// which is itself recorded as a new revision. Details removed since the revision are recreated with new ids.
+// Every property of the revision must still be registered and enabled by the page's owner,
+// and every property the page's template now marks as required must be part of the revision.
+func (s PageService) RestorePageRevision(ctx context.Context, params RestorePageRevisionParams) error {
+	_, err := s.policy().AuthorizePage(params.Page.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	r, err := s.RevisionStore.GetRevision(params.Page.GUID, params.Revision.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get page revision: %+v", params)
+	}
+	err = s.checkRequiredProperties(params.Page.GUID, r.Snapshot.Properties)
+	if err != nil {
+		return err
+	}
+	err = s.setOwnerPropertyIDs(params.Page.GUID, r.Snapshot.Properties)
+	if err != nil {
+		return err
+	}
+	err = s.RecordRevision(ctx, RecordRevisionParams{
+		Page:   params.Page,
+		UserID: params.UserID,
+		Write: func() error {
+			return s.restoreSnapshot(params.Page.GUID, *r.Snapshot)
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to restore page revision: %+v", params)
+	}
+	return s.IndexPage(ctx, IndexPageParams{Page: params.Page})
+}
+
+func (s PageService) restoreSnapshot(pageGUID string, snapshot pagediff.Snapshot) error {
+	p, err := s.PageStore.GetPage(pageGUID)
+	if err != nil {
+		return err
+	}
+	p.Title = snapshot.Title
+	p.Summary = snapshot.Summary
+	err = s.PageStore.UpdatePage(p)
+	if err != nil {
+		return err
+	}
+	err = s.PageStore.ReplacePageProperties(pageGUID, snapshot.Properties)
+	if err != nil {
+		return err
+	}
+	current, err := s.PageDetailStore.GetPageDetails(pageGUID)
+	if err != nil {
+		return err
+	}
+	currentGUIDs := make(map[string]bool)
+	for _, d := range current {
+		currentGUIDs[d.GUID] = true
+	}
+	order := make([]string, 0, len(snapshot.Details))
+	restored := make(map[string]bool)
+	for _, d := range snapshot.Details {
+		if currentGUIDs[d.GUID] {
+			err = s.PageDetailStore.UpdatePageDetail(pageGUID, d)
+			if err != nil {
+				return err
+			}
+			restored[d.GUID] = true
+			order = append(order, d.GUID)
+			continue
+		}
+		// the guids of removed details are still taken, so they are recreated with new ones.
+		d.GUID, err = s.PageDetailStore.GetUniquePageDetailGUID("")
+		if err != nil {
+			return err
+		}
+		_, err = s.PageDetailStore.CreatePageDetail(pageGUID, d)
+		if err != nil {
+			return err
+		}
+		order = append(order, d.GUID)
+	}
+	for _, d := range current {
+		if restored[d.GUID] {
+			continue
+		}
+		err = s.PageDetailStore.RemovePageDetail(pageGUID, d.GUID)
+		if err != nil {
+			return err
+		}
+	}
+	return s.PageDetailStore.ReorderPageDetails(pageGUID, order)
+}
@@ -5,14 +5,25 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/search"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
 )
@@ -48,19 +59,35 @@ type getPageTemplateCall struct {
 	returnErr             error
 }
 
+var placeTemplate = pagetemplate.PageTemplate{
+	GUID: "PGT_1",
+	ID:   1,
+	Name: "Place",
+	Properties: []pagetemplate.TemplateProperty{
+		{PropertyID: 1, Key: "population", Type: property.TypeNumber, Required: true},
+		{PropertyID: 2, Key: "banner", Type: property.TypeString, DefaultValue: "none"},
+	},
+}
+
 type getVersionCall struct {
 	paramVersionGUID string
 	returnVersion    version.Version
 	returnErr        error
 }
 
-type canEditPageCall struct {
+type getPageRoleCall struct {
 	paramPageGUID   string
 	paramPageUserID string
-	returnIsOwner   bool
+	returnRole      permission.Role
 	returnErr       error
 }
 
+type canEditCall struct {
+	paramGUID   string
+	paramUserID string
+	returnErr   error
+}
+
 type updatePageCall struct {
 	paramPage page.Page
 	returnErr error
@@ -68,13 +95,16 @@ type updatePageCall struct {
 
 func TestUpdatePage(t *testing.T) {
 	cases := []struct {
-		name                 string
-		params               UpdatePageParams
-		canEditPageCalls     []canEditPageCall
-		getPageTemplateCalls []getPageTemplateCall
-		getVersionCalls      []getVersionCall
-		updatePageCalls      []updatePageCall
-		returnErr            error
+		name                     string
+		params                   UpdatePageParams
+		getPageRoleCalls         []getPageRoleCall
+		getPageCalls             []getPageCall
+		canEditVersionCalls      []canEditCall
+		canEditPageTemplateCalls []canEditCall
+		getPageTemplateCalls     []getPageTemplateCall
+		getVersionCalls          []getVersionCall
+		updatePageCalls          []updatePageCall
+		returnErr                error
 	}{
 		{
 			name: "test happy path",
@@ -85,10 +115,11 @@ func TestUpdatePage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canEditPageCalls: []canEditPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
+					returnRole:      permission.RoleEditor,
 				},
 			},
 			updatePageCalls: []updatePageCall{{paramPage: page.Page{
@@ -107,12 +138,21 @@ func TestUpdatePage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canEditPageCalls: []canEditPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
+					returnRole:      permission.RoleEditor,
 				},
 			},
+			getPageCalls: []getPageCall{
+				{
+					paramPageGUID: "PG_1",
+					returnPage:    page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_2"}, Version: version.Version{GUID: "VR_2"}},
+				},
+			},
+			canEditVersionCalls:      []canEditCall{{paramGUID: "VR_1", paramUserID: "UR_1"}},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_1", paramUserID: "UR_1"}},
 			getPageTemplateCalls: []getPageTemplateCall{
 				{
 					paramPageTemplateGUID: "PGT_1",
@@ -141,29 +181,97 @@ func TestUpdatePage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canEditPageCalls: []canEditPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
-					returnErr:       getStoreUnauthorizedErr("UR_1", "PG_1", nil),
 				},
 			},
 			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
 		},
+		{
+			name: "test editor keeps the owner's version",
+			params: UpdatePageParams{
+				Page: page.Page{
+					GUID:    "PG_1",
+					Title:   "New Title",
+					Version: version.Version{GUID: "VR_1"},
+				},
+				UserID: "UR_2",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_2",
+					returnRole:      permission.RoleEditor,
+				},
+			},
+			getPageCalls: []getPageCall{
+				{
+					paramPageGUID: "PG_1",
+					returnPage:    page.Page{GUID: "PG_1", Version: version.Version{GUID: "VR_1"}},
+				},
+			},
+			getVersionCalls: []getVersionCall{
+				{
+					paramVersionGUID: "VR_1",
+					returnVersion:    version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
+				},
+			},
+			updatePageCalls: []updatePageCall{{paramPage: page.Page{
+				GUID:    "PG_1",
+				Title:   "New Title",
+				Version: version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
+			}}},
+		},
+		{
+			name: "test move into another user's version",
+			params: UpdatePageParams{
+				Page: page.Page{
+					GUID:    "PG_1",
+					Version: version.Version{GUID: "VR_2"},
+				},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_1",
+					returnRole:      permission.RoleOwner,
+				},
+			},
+			getPageCalls: []getPageCall{
+				{
+					paramPageGUID: "PG_1",
+					returnPage:    page.Page{GUID: "PG_1", Version: version.Version{GUID: "VR_1"}},
+				},
+			},
+			canEditVersionCalls: []canEditCall{{paramGUID: "VR_2", paramUserID: "UR_1", returnErr: &storeerror.NotAuthorized{UserID: "UR_1", TableID: "VR_2"}}},
+			returnErr:           errors.New("version VR_2 cannot be used by user UR_1\nUser UR_1 is not authorized to perform the action on the ID VR_2"),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			pageStore := new(mocks.PageStore)
 			pageTemplateStore := new(mocks.PageTemplateStore)
 			versionStore := new(mocks.VersionStore)
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.canEditVersionCalls {
+				versionStore.On("CanEditVersion", tc.canEditVersionCalls[index].paramGUID, tc.canEditVersionCalls[index].paramUserID).Return(tc.canEditVersionCalls[index].returnErr)
+			}
+			for index := range tc.canEditPageTemplateCalls {
+				pageTemplateStore.On("CanEditPageTemplate", tc.canEditPageTemplateCalls[index].paramGUID, tc.canEditPageTemplateCalls[index].paramUserID).Return(tc.canEditPageTemplateCalls[index].returnErr)
+			}
 			for index := range tc.getPageTemplateCalls {
 				pageTemplateStore.On("GetPageTemplate", tc.getPageTemplateCalls[index].paramPageTemplateGUID).Return(tc.getPageTemplateCalls[index].returnPageTemplate, tc.getPageTemplateCalls[index].returnErr)
 			}
 			for index := range tc.getVersionCalls {
 				versionStore.On("GetVersion", tc.getVersionCalls[index].paramVersionGUID).Return(tc.getVersionCalls[index].returnVersion, tc.getVersionCalls[index].returnErr)
 			}
-			for index := range tc.canEditPageCalls {
-				pageStore.On("CanEditPage", tc.canEditPageCalls[index].paramPageGUID, tc.canEditPageCalls[index].paramPageUserID).Return(tc.canEditPageCalls[index].returnIsOwner, tc.canEditPageCalls[index].returnErr)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
 			}
 			for index := range tc.updatePageCalls {
 				pageStore.On("UpdatePage", tc.updatePageCalls[index].paramPage).Return(tc.updatePageCalls[index].returnErr)
@@ -174,9 +282,12 @@ func TestUpdatePage(t *testing.T) {
 				VersionStore:      versionStore,
 			}
 			err := pageService.UpdatePage(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			versionStore.AssertNumberOfCalls(t, "CanEditVersion", len(tc.canEditVersionCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "CanEditPageTemplate", len(tc.canEditPageTemplateCalls))
 			pageTemplateStore.AssertNumberOfCalls(t, "GetPageTemplate", len(tc.getPageTemplateCalls))
 			versionStore.AssertNumberOfCalls(t, "GetVersion", len(tc.getVersionCalls))
-			pageStore.AssertNumberOfCalls(t, "CanEditPage", len(tc.canEditPageCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
 			pageStore.AssertNumberOfCalls(t, "UpdatePage", len(tc.updatePageCalls))
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
@@ -207,15 +318,19 @@ type getUserCall struct {
 
 func TestCreatePage(t *testing.T) {
 	cases := []struct {
-		name                   string
-		params                 CreatePageParams
-		getUserCalls           []getUserCall
-		getPageTemplateCalls   []getPageTemplateCall
-		getVersionCalls        []getVersionCall
-		getUniquePageGUIDCalls []getUniquePageGUIDCall
-		createPageCalls        []createPageCall
-		returnPage             page.Page
-		returnErr              error
+		name                       string
+		params                     CreatePageParams
+		getUserCalls               []getUserCall
+		canEditVersionCalls        []canEditCall
+		canEditPageTemplateCalls   []canEditCall
+		getPageTemplateCalls       []getPageTemplateCall
+		getVersionCalls            []getVersionCall
+		getUniquePageGUIDCalls     []getUniquePageGUIDCall
+		createPageCalls            []createPageCall
+		getPropertiesCalls         []getPropertiesCall
+		replacePagePropertiesCalls []replacePagePropertiesCall
+		returnPage                 page.Page
+		returnErr                  error
 	}{
 		{
 			name: "test happy path",
@@ -233,6 +348,8 @@ func TestCreatePage(t *testing.T) {
 					returnUser:    appuser.User{ID: 1, GUID: "UR_1"},
 				},
 			},
+			canEditVersionCalls:      []canEditCall{{paramGUID: "VR_1", paramUserID: "UR_1"}},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_1", paramUserID: "UR_1"}},
 			getPageTemplateCalls: []getPageTemplateCall{
 				{
 					paramPageTemplateGUID: "PGT_1",
@@ -276,6 +393,151 @@ func TestCreatePage(t *testing.T) {
 				Version:      version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
 			},
 		},
+		{
+			name: "test template properties are pre-populated",
+			params: CreatePageParams{
+				Page: page.Page{
+					Title:        "New Title",
+					PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Version:      version.Version{GUID: "VR_1"},
+				},
+				OwnerID: "UR_1",
+			},
+			getUserCalls: []getUserCall{
+				{
+					paramUserGUID: "UR_1",
+					returnUser:    appuser.User{ID: 1, GUID: "UR_1"},
+				},
+			},
+			canEditVersionCalls:      []canEditCall{{paramGUID: "VR_1", paramUserID: "UR_1"}},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_1", paramUserID: "UR_1"}},
+			getPageTemplateCalls: []getPageTemplateCall{
+				{
+					paramPageTemplateGUID: "PGT_1",
+					returnPageTemplate:    placeTemplate,
+				},
+			},
+			getVersionCalls: []getVersionCall{
+				{
+					paramVersionGUID: "VR_1",
+					returnVersion:    version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
+				},
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{
+					paramUserID: "UR_1",
+					returnProperties: []property.Property{
+						{ID: 11, Key: "population", Type: property.TypeNumber},
+						{ID: 12, Key: "banner", Type: property.TypeString},
+					},
+				},
+			},
+			getUniquePageGUIDCalls: []getUniquePageGUIDCall{
+				{
+					returnGUID: "PG_NEW",
+				},
+			},
+			createPageCalls: []createPageCall{
+				{
+					paramPage: page.Page{
+						GUID:         "PG_NEW",
+						Title:        "New Title",
+						PageTemplate: placeTemplate,
+						Version:      version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
+					},
+					paramOwnerID: 1,
+					returnPage: page.Page{
+						ID:           1,
+						GUID:         "PG_NEW",
+						Title:        "New Title",
+						PageTemplate: placeTemplate,
+						Version:      version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
+					},
+				},
+			},
+			replacePagePropertiesCalls: []replacePagePropertiesCall{
+				{
+					paramPageGUID: "PG_NEW",
+					paramProperties: []property.Property{
+						{ID: 11, Key: "population", Type: property.TypeNumber, Value: float64(0)},
+						{ID: 12, Key: "banner", Type: property.TypeString, Value: "none"},
+					},
+				},
+			},
+			returnPage: page.Page{
+				ID:           1,
+				GUID:         "PG_NEW",
+				Title:        "New Title",
+				PageTemplate: placeTemplate,
+				Version:      version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
+			},
+		},
+		{
+			name: "test disabled template",
+			params: CreatePageParams{
+				Page: page.Page{
+					Title:        "New Title",
+					PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+				},
+				OwnerID: "UR_1",
+			},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_1", paramUserID: "UR_1"}},
+			getPageTemplateCalls: []getPageTemplateCall{
+				{
+					paramPageTemplateGUID: "PGT_1",
+					returnPageTemplate:    pagetemplate.PageTemplate{GUID: "PGT_1", ID: 1, Name: "TEST_NAME_TEMPLATE", Disabled: true},
+				},
+			},
+			returnErr: errors.New("page template PGT_1 is disabled"),
+		},
+		{
+			name: "test template property disabled in the owner's registry",
+			params: CreatePageParams{
+				Page: page.Page{
+					Title:        "New Title",
+					PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+				},
+				OwnerID: "UR_1",
+			},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_1", paramUserID: "UR_1"}},
+			getPageTemplateCalls: []getPageTemplateCall{
+				{
+					paramPageTemplateGUID: "PGT_1",
+					returnPageTemplate:    placeTemplate,
+				},
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{
+					paramUserID:      "UR_1",
+					returnProperties: []property.Property{{ID: 11, Key: "population", Type: property.TypeNumber}},
+				},
+			},
+			returnErr: errors.New("property banner is not registered or is disabled"),
+		},
+		{
+			name: "test another user's version",
+			params: CreatePageParams{
+				Page: page.Page{
+					Title:   "New Title",
+					Version: version.Version{GUID: "VR_2"},
+				},
+				OwnerID: "UR_1",
+			},
+			canEditVersionCalls: []canEditCall{{paramGUID: "VR_2", paramUserID: "UR_1", returnErr: &storeerror.NotAuthorized{UserID: "UR_1", TableID: "VR_2"}}},
+			returnErr:           errors.New("version VR_2 cannot be used by user UR_1\nUser UR_1 is not authorized to perform the action on the ID VR_2"),
+		},
+		{
+			name: "test another user's page template",
+			params: CreatePageParams{
+				Page: page.Page{
+					Title:        "New Title",
+					PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_2"},
+				},
+				OwnerID: "UR_1",
+			},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_2", paramUserID: "UR_1", returnErr: &storeerror.NotAuthorized{UserID: "UR_1", TableID: "PGT_2"}}},
+			returnErr:                errors.New("page template PGT_2 cannot be used by user UR_1\nUser UR_1 is not authorized to perform the action on the ID PGT_2"),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -283,9 +545,16 @@ func TestCreatePage(t *testing.T) {
 			userStore := new(mocks.UserStore)
 			pageTemplateStore := new(mocks.PageTemplateStore)
 			versionStore := new(mocks.VersionStore)
+			propertyStore := new(mocks.PropertyStore)
 			for index := range tc.getUserCalls {
 				userStore.On("GetUser", tc.getUserCalls[index].paramUserGUID).Return(tc.getUserCalls[index].returnUser, tc.getUserCalls[index].returnErr)
 			}
+			for index := range tc.canEditVersionCalls {
+				versionStore.On("CanEditVersion", tc.canEditVersionCalls[index].paramGUID, tc.canEditVersionCalls[index].paramUserID).Return(tc.canEditVersionCalls[index].returnErr)
+			}
+			for index := range tc.canEditPageTemplateCalls {
+				pageTemplateStore.On("CanEditPageTemplate", tc.canEditPageTemplateCalls[index].paramGUID, tc.canEditPageTemplateCalls[index].paramUserID).Return(tc.canEditPageTemplateCalls[index].returnErr)
+			}
 			for index := range tc.getPageTemplateCalls {
 				pageTemplateStore.On("GetPageTemplate", tc.getPageTemplateCalls[index].paramPageTemplateGUID).Return(tc.getPageTemplateCalls[index].returnPageTemplate, tc.getPageTemplateCalls[index].returnErr)
 			}
@@ -298,18 +567,29 @@ func TestCreatePage(t *testing.T) {
 			for index := range tc.createPageCalls {
 				pageStore.On("CreatePage", tc.createPageCalls[index].paramPage, tc.createPageCalls[index].paramOwnerID).Return(tc.createPageCalls[index].returnPage, tc.createPageCalls[index].returnErr)
 			}
+			for index := range tc.getPropertiesCalls {
+				propertyStore.On("GetProperties", tc.getPropertiesCalls[index].paramUserID).Return(tc.getPropertiesCalls[index].returnProperties, tc.getPropertiesCalls[index].returnErr)
+			}
+			for index := range tc.replacePagePropertiesCalls {
+				pageStore.On("ReplacePageProperties", tc.replacePagePropertiesCalls[index].paramPageGUID, tc.replacePagePropertiesCalls[index].paramProperties).Return(tc.replacePagePropertiesCalls[index].returnErr)
+			}
 			pageService = PageService{
 				PageStore:         pageStore,
 				PageTemplateStore: pageTemplateStore,
 				VersionStore:      versionStore,
 				UserStore:         userStore,
+				PropertyStore:     propertyStore,
 			}
 			result, err := pageService.CreatePage(ctx, tc.params)
+			propertyStore.AssertNumberOfCalls(t, "GetProperties", len(tc.getPropertiesCalls))
 			userStore.AssertNumberOfCalls(t, "GetUser", len(tc.getUserCalls))
+			versionStore.AssertNumberOfCalls(t, "CanEditVersion", len(tc.canEditVersionCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "CanEditPageTemplate", len(tc.canEditPageTemplateCalls))
 			pageTemplateStore.AssertNumberOfCalls(t, "GetPageTemplate", len(tc.getPageTemplateCalls))
 			versionStore.AssertNumberOfCalls(t, "GetVersion", len(tc.getVersionCalls))
 			pageStore.AssertNumberOfCalls(t, "GetUniquePageGUID", len(tc.getUniquePageGUIDCalls))
 			pageStore.AssertNumberOfCalls(t, "CreatePage", len(tc.createPageCalls))
+			pageStore.AssertNumberOfCalls(t, "ReplacePageProperties", len(tc.replacePagePropertiesCalls))
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
 				return
@@ -319,13 +599,6 @@ func TestCreatePage(t *testing.T) {
 	}
 }
 
-type canReadPageCall struct {
-	paramPageGUID   string
-	paramPageUserID string
-	returnIsOwner   bool
-	returnErr       error
-}
-
 type getPageCall struct {
 	paramPageGUID string
 	returnPage    page.Page
@@ -336,7 +609,7 @@ func TestGetPage(t *testing.T) {
 	cases := []struct {
 		name             string
 		params           GetPageParams
-		canReadPageCalls []canReadPageCall
+		getPageRoleCalls []getPageRoleCall
 		getPageCalls     []getPageCall
 		returnPage       page.Page
 		returnErr        error
@@ -349,10 +622,11 @@ func TestGetPage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canReadPageCalls: []canReadPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
+					returnRole:      permission.RoleViewer,
 				},
 			},
 			getPageCalls: []getPageCall{
@@ -383,11 +657,10 @@ func TestGetPage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canReadPageCalls: []canReadPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
-					returnErr:       getStoreUnauthorizedErr("UR_1", "PG_1", nil),
 				},
 			},
 			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
@@ -398,8 +671,8 @@ func TestGetPage(t *testing.T) {
 			pageStore := new(mocks.PageStore)
 			pageTemplateStore := new(mocks.PageTemplateStore)
 			versionStore := new(mocks.VersionStore)
-			for index := range tc.canReadPageCalls {
-				pageStore.On("CanReadPage", tc.canReadPageCalls[index].paramPageGUID, tc.canReadPageCalls[index].paramPageUserID).Return(tc.canReadPageCalls[index].returnIsOwner, tc.canReadPageCalls[index].returnErr)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
 			}
 			for index := range tc.getPageCalls {
 				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
@@ -410,7 +683,7 @@ func TestGetPage(t *testing.T) {
 				VersionStore:      versionStore,
 			}
 			result, err := pageService.GetPage(ctx, tc.params)
-			pageStore.AssertNumberOfCalls(t, "CanReadPage", len(tc.canReadPageCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
 			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
@@ -421,14 +694,21 @@ func TestGetPage(t *testing.T) {
 	}
 }
 
+type getPageDetailsCall struct {
+	paramPageGUID string
+	returnDetails []pagedetail.PageDetail
+	returnErr     error
+}
+
 func TestGetEntirePage(t *testing.T) {
 	cases := []struct {
 		name                 string
 		params               GetEntirePageParams
-		canReadPageCalls     []canReadPageCall
+		getPageRoleCalls     []getPageRoleCall
 		getPageTemplateCalls []getPageTemplateCall
 		getVersionCalls      []getVersionCall
 		getPageCalls         []getPageCall
+		getPageDetailsCalls  []getPageDetailsCall
 		returnPage           page.Page
 		returnErr            error
 	}{
@@ -440,10 +720,11 @@ func TestGetEntirePage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canReadPageCalls: []canReadPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
+					returnRole:      permission.RoleViewer,
 				},
 			},
 			getPageTemplateCalls: []getPageTemplateCall{
@@ -470,12 +751,19 @@ func TestGetEntirePage(t *testing.T) {
 					},
 				},
 			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{
+					paramPageGUID: "PG_NEW",
+					returnDetails: []pagedetail.PageDetail{{GUID: "DT_2"}, {GUID: "DT_1"}},
+				},
+			},
 			returnPage: page.Page{
 				ID:           1,
 				GUID:         "PG_NEW",
 				Title:        "New Title",
 				PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1", ID: 1, Name: "TEST_NAME_TEMPLATE"},
 				Version:      version.Version{GUID: "VR_1", ID: 1, Name: "TEST_NAME_VERSION"},
+				PageDetails:  []pagedetail.PageDetail{{GUID: "DT_2"}, {GUID: "DT_1"}},
 			},
 		},
 		{
@@ -486,11 +774,10 @@ func TestGetEntirePage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canReadPageCalls: []canReadPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
-					returnErr:       getStoreUnauthorizedErr("UR_1", "PG_1", nil),
 				},
 			},
 			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
@@ -501,8 +788,9 @@ func TestGetEntirePage(t *testing.T) {
 			pageStore := new(mocks.PageStore)
 			pageTemplateStore := new(mocks.PageTemplateStore)
 			versionStore := new(mocks.VersionStore)
-			for index := range tc.canReadPageCalls {
-				pageStore.On("CanReadPage", tc.canReadPageCalls[index].paramPageGUID, tc.canReadPageCalls[index].paramPageUserID).Return(tc.canReadPageCalls[index].returnIsOwner, tc.canReadPageCalls[index].returnErr)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
 			}
 			for index := range tc.getPageTemplateCalls {
 				pageTemplateStore.On("GetPageTemplate", tc.getPageTemplateCalls[index].paramPageTemplateGUID).Return(tc.getPageTemplateCalls[index].returnPageTemplate, tc.getPageTemplateCalls[index].returnErr)
@@ -513,16 +801,21 @@ func TestGetEntirePage(t *testing.T) {
 			for index := range tc.getPageCalls {
 				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
 			}
+			for index := range tc.getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCalls[index].paramPageGUID).Return(tc.getPageDetailsCalls[index].returnDetails, tc.getPageDetailsCalls[index].returnErr)
+			}
 			pageService = PageService{
 				PageStore:         pageStore,
 				PageTemplateStore: pageTemplateStore,
 				VersionStore:      versionStore,
+				PageDetailStore:   pageDetailStore,
 			}
 			result, err := pageService.GetEntirePage(ctx, tc.params)
-			pageStore.AssertNumberOfCalls(t, "CanReadPage", len(tc.canReadPageCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
 			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
 			pageTemplateStore.AssertNumberOfCalls(t, "GetPageTemplate", len(tc.getPageTemplateCalls))
 			versionStore.AssertNumberOfCalls(t, "GetVersion", len(tc.getVersionCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCalls))
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
 				return
@@ -533,16 +826,21 @@ func TestGetEntirePage(t *testing.T) {
 }
 
 type getPagesCall struct {
-	paramUserID       string
-	paramNextBatchID  string
-	paramLimit        int
-	returnPages       []page.Page
-	returnTotal       int
-	returnNextBatchID string
-	returnErr         error
+	paramUserID      string
+	paramFilter      pagefilter.Filter
+	paramSort        pagesort.Sort
+	paramCursor      pagesort.Cursor
+	paramLimit       int
+	returnPages      []page.Page
+	returnTotal      int
+	returnNextCursor pagesort.Cursor
+	returnErr        error
 }
 
 func TestGetPages(t *testing.T) {
+	titleSort := pagesort.Sort{Field: pagesort.FieldTitle, Order: pagesort.OrderDescending}
+	pg3Cursor := pagesort.Cursor{Sort: pagesort.DefaultSort, Value: "2020-01-01T00:00:03Z", PageID: 3}
+	pg5Cursor := pagesort.Cursor{Sort: pagesort.DefaultSort, Value: "2020-01-01T00:00:05Z", PageID: 5}
 	cases := []struct {
 		name              string
 		params            GetPagesParams
@@ -560,6 +858,7 @@ func TestGetPages(t *testing.T) {
 			getPagesCalls: []getPagesCall{
 				{
 					paramUserID: "UR_1",
+					paramSort:   pagesort.DefaultSort,
 					paramLimit:  10,
 					returnPages: []page.Page{
 						{
@@ -602,13 +901,14 @@ func TestGetPages(t *testing.T) {
 			name: "test happy path, offset and returned nextBatchID",
 			params: GetPagesParams{
 				UserID:      "UR_1",
-				NextBatchID: "PG_3",
+				NextBatchID: pg3Cursor.Encode(),
 			},
 			getPagesCalls: []getPagesCall{
 				{
-					paramUserID:      "UR_1",
-					paramNextBatchID: "PG_3",
-					paramLimit:       10,
+					paramUserID: "UR_1",
+					paramSort:   pagesort.DefaultSort,
+					paramCursor: pg3Cursor,
+					paramLimit:  10,
 					returnPages: []page.Page{
 						{
 							ID:           1,
@@ -625,8 +925,8 @@ func TestGetPages(t *testing.T) {
 							Version:      version.Version{GUID: "VR_2"},
 						},
 					},
-					returnNextBatchID: "PG_5",
-					returnTotal:       10,
+					returnNextCursor: pg5Cursor,
+					returnTotal:      10,
 				},
 			},
 			returnPages: []page.Page{
@@ -645,79 +945,343 @@ func TestGetPages(t *testing.T) {
 					Version:      version.Version{GUID: "VR_2"},
 				},
 			},
-			returnNextBatchID: "PG_5",
+			returnNextBatchID: pg5Cursor.Encode(),
 			returnTotal:       10,
 		},
 		{
-			name: "test unauthorized call",
+			name: "test sorted with a limit",
 			params: GetPagesParams{
+				Sort:   titleSort,
+				Limit:  1,
 				UserID: "UR_1",
 			},
 			getPagesCalls: []getPagesCall{
 				{
 					paramUserID: "UR_1",
-					paramLimit:  10,
-					returnErr:   getStoreUnauthorizedErr("UR_1", "PG_1", nil),
+					paramSort:   titleSort,
+					paramLimit:  1,
+					returnPages: []page.Page{
+						{
+							ID:           2,
+							GUID:         "PG_2",
+							Title:        "Page 2 Title",
+							PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+							Version:      version.Version{GUID: "VR_2"},
+						},
+					},
+					returnNextCursor: pagesort.Cursor{Sort: titleSort, Value: "Page 1 Title", PageID: 1},
+					returnTotal:      2,
+				},
+			},
+			returnPages: []page.Page{
+				{
+					ID:           2,
+					GUID:         "PG_2",
+					Title:        "Page 2 Title",
+					PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Version:      version.Version{GUID: "VR_2"},
 				},
 			},
-			returnErr: errors.New("failed to get pages: {NextBatchID: UserID:UR_1}: User UR_1 is not authorized to perform the action on the ID PG_1"),
+			returnNextBatchID: pagesort.Cursor{Sort: titleSort, Value: "Page 1 Title", PageID: 1}.Encode(),
+			returnTotal:       2,
 		},
-	}
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			pageStore := new(mocks.PageStore)
-			pageTemplateStore := new(mocks.PageTemplateStore)
-			versionStore := new(mocks.VersionStore)
-			for index := range tc.getPagesCalls {
-				pageStore.On("GetPages", tc.getPagesCalls[index].paramUserID, tc.getPagesCalls[index].paramNextBatchID, tc.getPagesCalls[index].paramLimit).Return(tc.getPagesCalls[index].returnPages, tc.getPagesCalls[index].returnTotal, tc.getPagesCalls[index].returnNextBatchID, tc.getPagesCalls[index].returnErr)
-			}
-			pageService = PageService{
-				PageStore:         pageStore,
-				PageTemplateStore: pageTemplateStore,
-				VersionStore:      versionStore,
-			}
-			pages, total, nextBatchID, err := pageService.GetPages(ctx, tc.params)
-			pageStore.AssertNumberOfCalls(t, "GetPages", len(tc.getPagesCalls))
-			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
-			if errExpected {
-				return
-			}
-			require.Equal(t, tc.returnPages, pages)
-			require.Equal(t, tc.returnNextBatchID, nextBatchID)
-			require.Equal(t, tc.returnTotal, total)
-		})
-	}
-}
-
-type removePageCall struct {
-	paramPageGUID string
-	returnErr     error
-}
-
-func TestRemovePage(t *testing.T) {
-	cases := []struct {
-		name             string
-		params           RemovePageParams
-		canEditPageCalls []canEditPageCall
-		removePageCalls  []removePageCall
-		returnErr        error
-	}{
 		{
-			name: "test happy path",
-			params: RemovePageParams{
-				Page: page.Page{
-					GUID:  "PG_1",
-					Title: "New Title",
+			name: "test invalid nextBatchID",
+			params: GetPagesParams{
+				UserID:      "UR_1",
+				NextBatchID: "PG_3",
+			},
+			returnErr: errors.New("nextBatchId is not valid\ncursor is not encoded correctly: invalid character '<' looking for beginning of value"),
+		},
+		{
+			name: "test nextBatchID for a different sort",
+			params: GetPagesParams{
+				Sort:        titleSort,
+				UserID:      "UR_1",
+				NextBatchID: pg3Cursor.Encode(),
+			},
+			returnErr: errors.New("nextBatchId was given for a different sort"),
+		},
+		{
+			name: "test filtered",
+			params: GetPagesParams{
+				Filter: pagefilter.Filter{
+					PageTemplateGUID: "PGT_1",
+					Conditions: []pagefilter.Condition{
+						{Key: "population", Operator: pagefilter.OperatorGreater, Type: property.TypeNumber, NumberValue: 10000},
+					},
+				},
+				UserID: "UR_1",
+			},
+			getPagesCalls: []getPagesCall{
+				{
+					paramUserID: "UR_1",
+					paramFilter: pagefilter.Filter{
+						PageTemplateGUID: "PGT_1",
+						Conditions: []pagefilter.Condition{
+							{Key: "population", Operator: pagefilter.OperatorGreater, Type: property.TypeNumber, NumberValue: 10000},
+						},
+					},
+					paramSort:  pagesort.DefaultSort,
+					paramLimit: 10,
+					returnPages: []page.Page{
+						{
+							ID:           1,
+							GUID:         "PG_1",
+							Title:        "Page 1 Title",
+							PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+							Version:      version.Version{GUID: "VR_1"},
+						},
+					},
+					returnTotal: 1,
+				},
+			},
+			returnPages: []page.Page{
+				{
+					ID:           1,
+					GUID:         "PG_1",
+					Title:        "Page 1 Title",
+					PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Version:      version.Version{GUID: "VR_1"},
+				},
+			},
+			returnTotal: 1,
+		},
+		{
+			name: "test unauthorized call",
+			params: GetPagesParams{
+				UserID: "UR_1",
+			},
+			getPagesCalls: []getPagesCall{
+				{
+					paramUserID: "UR_1",
+					paramSort:   pagesort.DefaultSort,
+					paramLimit:  10,
+					returnErr:   getStoreUnauthorizedErr("UR_1", "PG_1", nil),
+				},
+			},
+			returnErr: errors.New("failed to get pages: {Filter:{PageTemplateGUID: VersionGUID: PermissionType: OwnedOnly:false Conditions:[]} Sort:{Field: Order:} Limit:0 NextBatchID: UserID:UR_1}: User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageTemplateStore := new(mocks.PageTemplateStore)
+			versionStore := new(mocks.VersionStore)
+			for index := range tc.getPagesCalls {
+				pageStore.On("GetPages", tc.getPagesCalls[index].paramUserID, tc.getPagesCalls[index].paramFilter, tc.getPagesCalls[index].paramSort, tc.getPagesCalls[index].paramCursor, tc.getPagesCalls[index].paramLimit).Return(tc.getPagesCalls[index].returnPages, tc.getPagesCalls[index].returnTotal, tc.getPagesCalls[index].returnNextCursor, tc.getPagesCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:         pageStore,
+				PageTemplateStore: pageTemplateStore,
+				VersionStore:      versionStore,
+			}
+			pages, total, nextBatchID, err := pageService.GetPages(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPages", len(tc.getPagesCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnPages, pages)
+			require.Equal(t, tc.returnNextBatchID, nextBatchID)
+			require.Equal(t, tc.returnTotal, total)
+		})
+	}
+}
+
+type removePageCall struct {
+	paramPageGUID string
+	returnErr     error
+}
+
+type getPageDetailCall struct {
+	paramPageGUID   string
+	paramDetailGUID string
+	returnDetail    pagedetail.PageDetail
+	returnErr       error
+}
+
+type updatePageDetailCall struct {
+	paramPageGUID string
+	paramDetail   pagedetail.PageDetail
+	returnErr     error
+}
+
+func getRelationDetail(detailGUID, relationPageGUID string) pagedetail.PageDetail {
+	return pagedetail.PageDetail{
+		GUID:  detailGUID,
+		Title: "Roads",
+		Partitions: []pagedetail.Partition{
+			{
+				TypeString: "p",
+				Partitions: []pagedetail.Partition{
+					{TypeString: "text", Value: "The road east leads to "},
+					{TypeString: "relation", Value: "Barovia", Relation: relationPageGUID},
+				},
+			},
+		},
+	}
+}
+
+func TestRemovePage(t *testing.T) {
+	fromVallaki := relation.Backlink{PageGUID: "PG_2", PageTitle: "Vallaki", DetailGUID: "DT_1", DetailTitle: "Roads", Text: "Barovia", Context: "The road east leads to Barovia"}
+	fromKrezk := relation.Backlink{PageGUID: "PG_3", PageTitle: "Krezk", DetailGUID: "DT_2", DetailTitle: "Roads", Text: "Barovia", Context: "The road east leads to Barovia"}
+	fromVallakiAgain := relation.Backlink{PageGUID: "PG_2", PageTitle: "Vallaki", DetailGUID: "DT_3", DetailTitle: "Roads", Text: "Barovia", Context: "The road east leads to Barovia"}
+	fromItself := relation.Backlink{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_4", DetailTitle: "Roads", Text: "Barovia", Context: "The road east leads to Barovia"}
+	backlinksGetPageRoleCalls := []getPageRoleCall{
+		{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+		{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+		{paramPageGUID: "PG_3", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+		// the page links to itself, so it is checked again as a linking page.
+		{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+	}
+	backlinksCalls := []getBacklinksCall{
+		{paramPageGUID: "PG_1", returnBacklinks: []relation.Backlink{fromVallaki, fromKrezk, fromVallakiAgain, fromItself}},
+	}
+	cases := []struct {
+		name                  string
+		params                RemovePageParams
+		getPageRoleCalls      []getPageRoleCall
+		getPageCalls          []getPageCall
+		getBacklinksCalls     []getBacklinksCall
+		removePageCalls       []removePageCall
+		getPageDetailCalls    []getPageDetailCall
+		updatePageDetailCalls []updatePageDetailCall
+		returnAffected        []relation.AffectedPage
+		returnErr             error
+	}{
+		{
+			name: "test happy path",
+			params: RemovePageParams{
+				Page: page.Page{
+					GUID:  "PG_1",
+					Title: "New Title",
 				},
 				UserID: "UR_1",
 			},
-			canEditPageCalls: []canEditPageCall{
+			getPageRoleCalls: append([]getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
+					returnRole:      permission.RoleEditor,
 				},
+			}, backlinksGetPageRoleCalls...),
+			getBacklinksCalls: backlinksCalls,
+			removePageCalls:   []removePageCall{{paramPageGUID: "PG_1"}},
+			returnAffected: []relation.AffectedPage{
+				{PageGUID: "PG_2", PageTitle: "Vallaki", Relations: 2},
+				{PageGUID: "PG_3", PageTitle: "Krezk", Relations: 1},
+			},
+		},
+		{
+			name: "test preview",
+			params: RemovePageParams{
+				Page:    page.Page{GUID: "PG_1"},
+				Preview: true,
+				UserID:  "UR_1",
+			},
+			getPageRoleCalls:  append([]getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}}, backlinksGetPageRoleCalls...),
+			getBacklinksCalls: backlinksCalls,
+			returnAffected: []relation.AffectedPage{
+				{PageGUID: "PG_2", PageTitle: "Vallaki", Relations: 2},
+				{PageGUID: "PG_3", PageTitle: "Krezk", Relations: 1},
+			},
+		},
+		{
+			name: "test re-point to a replacement page",
+			params: RemovePageParams{
+				Page:            page.Page{GUID: "PG_1"},
+				ReplacementPage: page.Page{GUID: "PG_5"},
+				UserID:          "UR_1",
+			},
+			// the user can edit the first linking page, but can only read the second.
+			getPageRoleCalls: append([]getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+				{paramPageGUID: "PG_3", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_5", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			}, backlinksGetPageRoleCalls...),
+			getPageCalls:      []getPageCall{{paramPageGUID: "PG_5", returnPage: page.Page{GUID: "PG_5", Title: "Old Barovia"}}},
+			getBacklinksCalls: backlinksCalls,
+			removePageCalls:   []removePageCall{{paramPageGUID: "PG_1"}},
+			getPageDetailCalls: []getPageDetailCall{
+				{paramPageGUID: "PG_2", paramDetailGUID: "DT_1", returnDetail: getRelationDetail("DT_1", "PG_1")},
+				{paramPageGUID: "PG_2", paramDetailGUID: "DT_3", returnDetail: getRelationDetail("DT_3", "PG_1")},
+			},
+			updatePageDetailCalls: []updatePageDetailCall{
+				{paramPageGUID: "PG_2", paramDetail: getRelationDetail("DT_1", "PG_5")},
+				{paramPageGUID: "PG_2", paramDetail: getRelationDetail("DT_3", "PG_5")},
+			},
+			returnAffected: []relation.AffectedPage{
+				{PageGUID: "PG_2", PageTitle: "Vallaki", Relations: 2, Repaired: true},
+				{PageGUID: "PG_3", PageTitle: "Krezk", Relations: 1},
+			},
+		},
+		{
+			name: "test unlink",
+			params: RemovePageParams{
+				Page:   page.Page{GUID: "PG_1"},
+				Unlink: true,
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+				{paramPageGUID: "PG_3", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+				{paramPageGUID: "PG_3", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+			},
+			getBacklinksCalls: []getBacklinksCall{
+				{paramPageGUID: "PG_1", returnBacklinks: []relation.Backlink{fromKrezk}},
 			},
 			removePageCalls: []removePageCall{{paramPageGUID: "PG_1"}},
+			getPageDetailCalls: []getPageDetailCall{
+				{paramPageGUID: "PG_3", paramDetailGUID: "DT_2", returnDetail: getRelationDetail("DT_2", "PG_1")},
+			},
+			updatePageDetailCalls: []updatePageDetailCall{
+				{
+					paramPageGUID: "PG_3",
+					paramDetail: pagedetail.PageDetail{
+						GUID:  "DT_2",
+						Title: "Roads",
+						Partitions: []pagedetail.Partition{
+							{
+								TypeString: "p",
+								Partitions: []pagedetail.Partition{
+									{TypeString: "text", Value: "The road east leads to "},
+									{Type: pagedetail.PartitionTypeText, TypeString: "text", Value: "Barovia"},
+								},
+							},
+						},
+					},
+				},
+			},
+			returnAffected: []relation.AffectedPage{
+				{PageGUID: "PG_3", PageTitle: "Krezk", Relations: 1, Repaired: true},
+			},
+		},
+		{
+			name: "test replacement page is the removed page",
+			params: RemovePageParams{
+				Page:            page.Page{GUID: "PG_1"},
+				ReplacementPage: page.Page{GUID: "PG_1"},
+				UserID:          "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			returnErr:        errors.New("the replacement page cannot be the page being removed"),
+		},
+		{
+			name: "test replacement page does not exist",
+			params: RemovePageParams{
+				Page:            page.Page{GUID: "PG_1"},
+				ReplacementPage: page.Page{GUID: "PG_5"},
+				UserID:          "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor},
+				{paramPageGUID: "PG_5", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			},
+			getPageCalls: []getPageCall{{paramPageGUID: "PG_5", returnErr: &storeerror.NotFound{ID: "PG_5"}}},
+			returnErr:    errors.New("replacement page PG_5 does not exist\nCould not find: PG_5"),
 		},
 		{
 			name: "test unauthorized call",
@@ -728,11 +1292,10 @@ func TestRemovePage(t *testing.T) {
 				},
 				UserID: "UR_1",
 			},
-			canEditPageCalls: []canEditPageCall{
+			getPageRoleCalls: []getPageRoleCall{
 				{
 					paramPageGUID:   "PG_1",
 					paramPageUserID: "UR_1",
-					returnErr:       getStoreUnauthorizedErr("UR_1", "PG_1", nil),
 				},
 			},
 			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
@@ -741,26 +1304,1476 @@ func TestRemovePage(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			pageStore := new(mocks.PageStore)
-			pageTemplateStore := new(mocks.PageTemplateStore)
-			versionStore := new(mocks.VersionStore)
-			for index := range tc.canEditPageCalls {
-				pageStore.On("CanEditPage", tc.canEditPageCalls[index].paramPageGUID, tc.canEditPageCalls[index].paramPageUserID).Return(tc.canEditPageCalls[index].returnIsOwner, tc.canEditPageCalls[index].returnErr)
+			pageDetailStore := new(mocks.PageDetailStore)
+			relationStore := new(mocks.RelationStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getBacklinksCalls {
+				relationStore.On("GetBacklinks", tc.getBacklinksCalls[index].paramPageGUID).Return(tc.getBacklinksCalls[index].returnBacklinks, tc.getBacklinksCalls[index].returnErr)
 			}
 			for index := range tc.removePageCalls {
 				pageStore.On("RemovePage", tc.removePageCalls[index].paramPageGUID).Return(tc.removePageCalls[index].returnErr)
 			}
+			for index := range tc.getPageDetailCalls {
+				pageDetailStore.On("GetPageDetail", tc.getPageDetailCalls[index].paramPageGUID, tc.getPageDetailCalls[index].paramDetailGUID).Return(tc.getPageDetailCalls[index].returnDetail, tc.getPageDetailCalls[index].returnErr)
+			}
+			for index := range tc.updatePageDetailCalls {
+				pageDetailStore.On("UpdatePageDetail", tc.updatePageDetailCalls[index].paramPageGUID, tc.updatePageDetailCalls[index].paramDetail).Return(tc.updatePageDetailCalls[index].returnErr)
+			}
 			pageService = PageService{
-				PageStore:         pageStore,
-				PageTemplateStore: pageTemplateStore,
-				VersionStore:      versionStore,
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+				RelationStore:   relationStore,
 			}
-			err := pageService.RemovePage(ctx, tc.params)
-			pageStore.AssertNumberOfCalls(t, "CanEditPage", len(tc.canEditPageCalls))
+			result, err := pageService.RemovePage(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			relationStore.AssertNumberOfCalls(t, "GetBacklinks", len(tc.getBacklinksCalls))
 			pageStore.AssertNumberOfCalls(t, "RemovePage", len(tc.removePageCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetail", len(tc.getPageDetailCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "UpdatePageDetail", len(tc.updatePageDetailCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnAffected, result)
+		})
+	}
+}
+
+type getRemovedPagesCall struct {
+	paramUserID string
+	returnPages []page.Page
+	returnErr   error
+}
+
+func TestGetRemovedPages(t *testing.T) {
+	cases := []struct {
+		name                 string
+		params               GetRemovedPagesParams
+		getRemovedPagesCalls []getRemovedPagesCall
+		returnPages          []page.Page
+		returnErr            error
+	}{
+		{
+			name:                 "test happy path",
+			params:               GetRemovedPagesParams{UserID: "UR_1"},
+			getRemovedPagesCalls: []getRemovedPagesCall{{paramUserID: "UR_1", returnPages: []page.Page{getPage("PG_1", "Berez", "")}}},
+			returnPages:          []page.Page{getPage("PG_1", "Berez", "")},
+		},
+		{
+			name:                 "test store failure",
+			params:               GetRemovedPagesParams{UserID: "UR_1"},
+			getRemovedPagesCalls: []getRemovedPagesCall{{paramUserID: "UR_1", returnErr: errors.New("failure")}},
+			returnErr:            errors.New("failed to get removed pages: {UserID:UR_1}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			for index := range tc.getRemovedPagesCalls {
+				pageStore.On("GetRemovedPages", tc.getRemovedPagesCalls[index].paramUserID).Return(tc.getRemovedPagesCalls[index].returnPages, tc.getRemovedPagesCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore: pageStore,
+			}
+			result, err := pageService.GetRemovedPages(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetRemovedPages", len(tc.getRemovedPagesCalls))
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
 				return
 			}
+			require.Equal(t, tc.returnPages, result)
+		})
+	}
+}
+
+type restorePageCall struct {
+	paramPageGUID string
+	returnErr     error
+}
+
+func TestRestorePage(t *testing.T) {
+	cases := []struct {
+		name             string
+		params           RestorePageParams
+		getPageRoleCalls []getPageRoleCall
+		restorePageCalls []restorePageCall
+		returnErr        error
+	}{
+		{
+			name:             "test happy path",
+			params:           RestorePageParams{Page: page.Page{GUID: "PG_1"}, UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			restorePageCalls: []restorePageCall{{paramPageGUID: "PG_1"}},
+		},
+		{
+			name:             "test not in the trash",
+			params:           RestorePageParams{Page: page.Page{GUID: "PG_1"}, UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			restorePageCalls: []restorePageCall{{paramPageGUID: "PG_1", returnErr: &storeerror.NotFound{ID: "PG_1"}}},
+			returnErr:        errors.New("failed to restore page: {Page:{ID:0 Version:{ID:0 GUID: Name: ParentGUID:} PageTemplate:{ID:0 Name: GUID: Summary: Properties:[] Disabled:false} GUID:PG_1 Title: Summary: PermissionType: PageProperties:[] PageDetails:[] CreatedAt:<nil> UpdatedAt:<nil> DeletedAt:<nil>} UserID:UR_1}: Could not find: PG_1"),
+		},
+		{
+			name:   "test unauthorized",
+			params: RestorePageParams{Page: page.Page{GUID: "PG_1"}, UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1"},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.restorePageCalls {
+				pageStore.On("RestorePage", tc.restorePageCalls[index].paramPageGUID).Return(tc.restorePageCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore: pageStore,
+			}
+			err := pageService.RestorePage(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "RestorePage", len(tc.restorePageCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type purgePageCall struct {
+	paramPageGUID string
+	returnErr     error
+}
+
+func TestPurgePage(t *testing.T) {
+	cases := []struct {
+		name             string
+		params           PurgePageParams
+		getPageRoleCalls []getPageRoleCall
+		purgePageCalls   []purgePageCall
+		returnErr        error
+	}{
+		{
+			name:             "test happy path",
+			params:           PurgePageParams{Page: page.Page{GUID: "PG_1"}, UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}},
+			purgePageCalls:   []purgePageCall{{paramPageGUID: "PG_1"}},
+		},
+		{
+			name:             "test editor who is not the owner",
+			params:           PurgePageParams{Page: page.Page{GUID: "PG_1"}, UserID: "UR_2"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_2", returnRole: permission.RoleEditor}},
+			returnErr:        errors.New("User UR_2 is not authorized to perform the action on the ID PG_1"),
+		},
+		{
+			name:   "test unauthorized",
+			params: PurgePageParams{Page: page.Page{GUID: "PG_1"}, UserID: "UR_2"},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_2"},
+			},
+			returnErr: errors.New("User UR_2 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.purgePageCalls {
+				pageStore.On("PurgePage", tc.purgePageCalls[index].paramPageGUID).Return(tc.purgePageCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore: pageStore,
+			}
+			err := pageService.PurgePage(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "PurgePage", len(tc.purgePageCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getRemovedPageGUIDsCall struct {
+	paramRemovedBefore time.Time
+	returnGUIDs        []string
+	returnErr          error
+}
+
+func TestPurgeRemovedPages(t *testing.T) {
+	removedBefore := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                     string
+		params                   PurgeRemovedPagesParams
+		getRemovedPageGUIDsCalls []getRemovedPageGUIDsCall
+		purgePageCalls           []purgePageCall
+		returnPurged             int
+		returnErr                error
+	}{
+		{
+			name:                     "test happy path",
+			params:                   PurgeRemovedPagesParams{RemovedBefore: removedBefore},
+			getRemovedPageGUIDsCalls: []getRemovedPageGUIDsCall{{paramRemovedBefore: removedBefore, returnGUIDs: []string{"PG_1", "PG_2"}}},
+			purgePageCalls:           []purgePageCall{{paramPageGUID: "PG_1"}, {paramPageGUID: "PG_2"}},
+			returnPurged:             2,
+		},
+		{
+			name:                     "test nothing to purge",
+			params:                   PurgeRemovedPagesParams{RemovedBefore: removedBefore},
+			getRemovedPageGUIDsCalls: []getRemovedPageGUIDsCall{{paramRemovedBefore: removedBefore, returnGUIDs: []string{}}},
+			returnPurged:             0,
+		},
+		{
+			name:                     "test purge failure",
+			params:                   PurgeRemovedPagesParams{RemovedBefore: removedBefore},
+			getRemovedPageGUIDsCalls: []getRemovedPageGUIDsCall{{paramRemovedBefore: removedBefore, returnGUIDs: []string{"PG_1", "PG_2"}}},
+			purgePageCalls:           []purgePageCall{{paramPageGUID: "PG_1"}, {paramPageGUID: "PG_2", returnErr: errors.New("failure")}},
+			returnPurged:             1,
+			returnErr:                errors.New("failed to purge page PG_2: {RemovedBefore:2020-01-01 00:00:00 +0000 UTC}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			for index := range tc.getRemovedPageGUIDsCalls {
+				pageStore.On("GetRemovedPageGUIDs", tc.getRemovedPageGUIDsCalls[index].paramRemovedBefore).Return(tc.getRemovedPageGUIDsCalls[index].returnGUIDs, tc.getRemovedPageGUIDsCalls[index].returnErr)
+			}
+			for index := range tc.purgePageCalls {
+				pageStore.On("PurgePage", tc.purgePageCalls[index].paramPageGUID).Return(tc.purgePageCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore: pageStore,
+			}
+			purged, err := pageService.PurgeRemovedPages(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetRemovedPageGUIDs", len(tc.getRemovedPageGUIDsCalls))
+			pageStore.AssertNumberOfCalls(t, "PurgePage", len(tc.purgePageCalls))
+			require.Equal(t, tc.returnPurged, purged)
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getPropertiesCall struct {
+	paramUserID      string
+	returnProperties []property.Property
+	returnErr        error
+}
+
+type replacePagePropertiesCall struct {
+	paramPageGUID   string
+	paramProperties []property.Property
+	returnErr       error
+}
+
+type getPageOwnerGUIDCall struct {
+	paramPageGUID   string
+	returnOwnerGUID string
+	returnErr       error
+}
+
+func TestReplacePageProperties(t *testing.T) {
+	registeredProperties := []property.Property{
+		{ID: 1, Key: "population", Type: property.TypeNumber},
+		{ID: 2, Key: "banner", Type: property.TypeString},
+	}
+	cases := []struct {
+		name                       string
+		params                     ReplacePagePropertiesParams
+		getPageRoleCalls           []getPageRoleCall
+		getPageCalls               []getPageCall
+		getPageTemplateCalls       []getPageTemplateCall
+		getPageOwnerGUIDCalls      []getPageOwnerGUIDCall
+		getPropertiesCalls         []getPropertiesCall
+		replacePagePropertiesCalls []replacePagePropertiesCall
+		returnErr                  error
+	}{
+		{
+			name: "test editor uses the owner's properties",
+			params: ReplacePagePropertiesParams{
+				Page: page.Page{GUID: "PG_1"},
+				Properties: []property.Property{
+					{Key: "banner", Type: property.TypeString, Value: "lion"},
+					{Key: "population", Type: property.TypeNumber, Value: float64(100)},
+				},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls:      []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageCalls:          []getPageCall{{paramPageGUID: "PG_1", returnPage: page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}}}},
+			getPageTemplateCalls:  []getPageTemplateCall{{paramPageTemplateGUID: "PGT_1", returnPageTemplate: placeTemplate}},
+			getPageOwnerGUIDCalls: []getPageOwnerGUIDCall{{paramPageGUID: "PG_1", returnOwnerGUID: "UR_2"}},
+			getPropertiesCalls:    []getPropertiesCall{{paramUserID: "UR_2", returnProperties: registeredProperties}},
+			replacePagePropertiesCalls: []replacePagePropertiesCall{
+				{
+					paramPageGUID: "PG_1",
+					paramProperties: []property.Property{
+						{ID: 2, Key: "banner", Type: property.TypeString, Value: "lion"},
+						{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(100)},
+					},
+				},
+			},
+		},
+		{
+			name: "test unregistered or disabled property",
+			params: ReplacePagePropertiesParams{
+				Page: page.Page{GUID: "PG_1"},
+				Properties: []property.Property{
+					{Key: "population", Type: property.TypeNumber, Value: float64(100)},
+					{Key: "color", Type: property.TypeString, Value: "blue"},
+				},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls:      []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageCalls:          []getPageCall{{paramPageGUID: "PG_1", returnPage: page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}}}},
+			getPageTemplateCalls:  []getPageTemplateCall{{paramPageTemplateGUID: "PGT_1", returnPageTemplate: placeTemplate}},
+			getPageOwnerGUIDCalls: []getPageOwnerGUIDCall{{paramPageGUID: "PG_1", returnOwnerGUID: "UR_2"}},
+			getPropertiesCalls:    []getPropertiesCall{{paramUserID: "UR_2", returnProperties: registeredProperties}},
+			returnErr:             errors.New("property color is not registered or is disabled"),
+		},
+		{
+			name: "test mismatched type",
+			params: ReplacePagePropertiesParams{
+				Page:       page.Page{GUID: "PG_1"},
+				Properties: []property.Property{{Key: "population", Type: property.TypeString, Value: "many"}},
+				UserID:     "UR_1",
+			},
+			getPageRoleCalls:      []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageCalls:          []getPageCall{{paramPageGUID: "PG_1", returnPage: page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}}}},
+			getPageTemplateCalls:  []getPageTemplateCall{{paramPageTemplateGUID: "PGT_1", returnPageTemplate: placeTemplate}},
+			getPageOwnerGUIDCalls: []getPageOwnerGUIDCall{{paramPageGUID: "PG_1", returnOwnerGUID: "UR_2"}},
+			getPropertiesCalls:    []getPropertiesCall{{paramUserID: "UR_2", returnProperties: registeredProperties}},
+			returnErr:             errors.New("property population must be of type number"),
+		},
+		{
+			name: "test missing required property",
+			params: ReplacePagePropertiesParams{
+				Page:       page.Page{GUID: "PG_1"},
+				Properties: []property.Property{{Key: "banner", Type: property.TypeString, Value: "lion"}},
+				UserID:     "UR_1",
+			},
+			getPageRoleCalls:     []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageCalls:         []getPageCall{{paramPageGUID: "PG_1", returnPage: page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}}}},
+			getPageTemplateCalls: []getPageTemplateCall{{paramPageTemplateGUID: "PGT_1", returnPageTemplate: placeTemplate}},
+			returnErr:            errors.New("property population is required by page template Place"),
+		},
+		{
+			name: "test unauthorized call",
+			params: ReplacePagePropertiesParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_1",
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			propertyStore := new(mocks.PropertyStore)
+			pageTemplateStore := new(mocks.PageTemplateStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getPageTemplateCalls {
+				pageTemplateStore.On("GetPageTemplate", tc.getPageTemplateCalls[index].paramPageTemplateGUID).Return(tc.getPageTemplateCalls[index].returnPageTemplate, tc.getPageTemplateCalls[index].returnErr)
+			}
+			for index := range tc.getPageOwnerGUIDCalls {
+				pageStore.On("GetPageOwnerGUID", tc.getPageOwnerGUIDCalls[index].paramPageGUID).Return(tc.getPageOwnerGUIDCalls[index].returnOwnerGUID, tc.getPageOwnerGUIDCalls[index].returnErr)
+			}
+			for index := range tc.getPropertiesCalls {
+				propertyStore.On("GetProperties", tc.getPropertiesCalls[index].paramUserID).Return(tc.getPropertiesCalls[index].returnProperties, tc.getPropertiesCalls[index].returnErr)
+			}
+			for index := range tc.replacePagePropertiesCalls {
+				pageStore.On("ReplacePageProperties", tc.replacePagePropertiesCalls[index].paramPageGUID, tc.replacePagePropertiesCalls[index].paramProperties).Return(tc.replacePagePropertiesCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:         pageStore,
+				PageTemplateStore: pageTemplateStore,
+				PropertyStore:     propertyStore,
+			}
+			err := pageService.ReplacePageProperties(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "GetPageTemplate", len(tc.getPageTemplateCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageOwnerGUID", len(tc.getPageOwnerGUIDCalls))
+			propertyStore.AssertNumberOfCalls(t, "GetProperties", len(tc.getPropertiesCalls))
+			pageStore.AssertNumberOfCalls(t, "ReplacePageProperties", len(tc.replacePagePropertiesCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getBacklinksCall struct {
+	paramPageGUID   string
+	returnBacklinks []relation.Backlink
+	returnErr       error
+}
+
+func TestGetPageBacklinks(t *testing.T) {
+	fromVallaki := relation.Backlink{PageGUID: "PG_2", PageTitle: "Vallaki", DetailGUID: "DT_1", DetailTitle: "Roads", Text: "Barovia", Context: "The road east leads to Barovia."}
+	fromKrezk := relation.Backlink{PageGUID: "PG_3", PageTitle: "Krezk", DetailGUID: "DT_2", DetailTitle: "History", Text: "Barovia", Context: "Krezk paid tribute to Barovia."}
+	fromVallakiAgain := relation.Backlink{PageGUID: "PG_2", PageTitle: "Vallaki", DetailGUID: "DT_3", DetailTitle: "Politics", Text: "the village", Context: "The Baron distrusts the village."}
+	cases := []struct {
+		name              string
+		params            GetPageBacklinksParams
+		getPageRoleCalls  []getPageRoleCall
+		getBacklinksCalls []getBacklinksCall
+		returnBacklinks   []relation.Backlink
+		returnErr         error
+	}{
+		{
+			name: "test happy path",
+			params: GetPageBacklinksParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_3", paramPageUserID: "UR_1"},
+			},
+			getBacklinksCalls: []getBacklinksCall{
+				{paramPageGUID: "PG_1", returnBacklinks: []relation.Backlink{fromVallaki, fromKrezk, fromVallakiAgain}},
+			},
+			returnBacklinks: []relation.Backlink{fromVallaki, fromVallakiAgain},
+		},
+		{
+			name: "test no backlinks",
+			params: GetPageBacklinksParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			},
+			getBacklinksCalls: []getBacklinksCall{
+				{paramPageGUID: "PG_1", returnBacklinks: []relation.Backlink{}},
+			},
+			returnBacklinks: []relation.Backlink{},
+		},
+		{
+			name: "test unauthorized call",
+			params: GetPageBacklinksParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1"},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			relationStore := new(mocks.RelationStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getBacklinksCalls {
+				relationStore.On("GetBacklinks", tc.getBacklinksCalls[index].paramPageGUID).Return(tc.getBacklinksCalls[index].returnBacklinks, tc.getBacklinksCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:     pageStore,
+				RelationStore: relationStore,
+			}
+			result, err := pageService.GetPageBacklinks(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			relationStore.AssertNumberOfCalls(t, "GetBacklinks", len(tc.getBacklinksCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnBacklinks, result)
+		})
+	}
+}
+
+type getEdgesCall struct {
+	paramPageGUIDs []string
+	returnEdges    []relation.Edge
+	returnErr      error
+}
+
+func TestGetPageGraph(t *testing.T) {
+	barovia := page.Page{GUID: "PG_1", Title: "Barovia", PageTemplate: pagetemplate.PageTemplate{GUID: "PT_1"}, Version: version.Version{GUID: "VR_1"}}
+	vallaki := page.Page{GUID: "PG_2", Title: "Vallaki", PageTemplate: pagetemplate.PageTemplate{GUID: "PT_2"}, Version: version.Version{GUID: "VR_1"}}
+	krezk := page.Page{GUID: "PG_3", Title: "Krezk", PageTemplate: pagetemplate.PageTemplate{GUID: "PT_1"}, Version: version.Version{GUID: "VR_1"}}
+	amberTemple := page.Page{GUID: "PG_6", Title: "Amber Temple", PageTemplate: pagetemplate.PageTemplate{GUID: "PT_1"}, Version: version.Version{GUID: "VR_1"}}
+	toVallaki := relation.Edge{SourcePageGUID: "PG_1", TargetPageGUID: "PG_2", Count: 1}
+	fromKrezk := relation.Edge{SourcePageGUID: "PG_3", TargetPageGUID: "PG_1", Count: 2}
+	toRemoved := relation.Edge{SourcePageGUID: "PG_1", TargetPageGUID: "PG_4", Count: 1}
+	toPrivate := relation.Edge{SourcePageGUID: "PG_1", TargetPageGUID: "PG_5", Count: 1}
+	vallakiToTemple := relation.Edge{SourcePageGUID: "PG_2", TargetPageGUID: "PG_6", Count: 1}
+	krezkToVallaki := relation.Edge{SourcePageGUID: "PG_3", TargetPageGUID: "PG_2", Count: 1}
+	templeToKrezk := relation.Edge{SourcePageGUID: "PG_6", TargetPageGUID: "PG_3", Count: 3}
+	firstHopGetPageRoleCalls := []getPageRoleCall{
+		{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+		{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+		{paramPageGUID: "PG_3", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+		{paramPageGUID: "PG_4", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+		{paramPageGUID: "PG_5", paramPageUserID: "UR_1"},
+	}
+	firstHopGetPageCalls := []getPageCall{
+		{paramPageGUID: "PG_1", returnPage: barovia},
+		{paramPageGUID: "PG_2", returnPage: vallaki},
+		{paramPageGUID: "PG_3", returnPage: krezk},
+		{paramPageGUID: "PG_4", returnErr: &storeerror.NotFound{ID: "PG_4"}},
+	}
+	firstHopGetEdgesCall := getEdgesCall{paramPageGUIDs: []string{"PG_1"}, returnEdges: []relation.Edge{toVallaki, fromKrezk, toRemoved, toPrivate}}
+	secondHopGetEdgesCall := getEdgesCall{paramPageGUIDs: []string{"PG_2", "PG_3"}, returnEdges: []relation.Edge{toVallaki, vallakiToTemple, fromKrezk, krezkToVallaki}}
+	cases := []struct {
+		name             string
+		params           GetPageGraphParams
+		getPageRoleCalls []getPageRoleCall
+		getPageCalls     []getPageCall
+		getEdgesCalls    []getEdgesCall
+		returnGraph      relation.Graph
+		returnErr        error
+	}{
+		{
+			name: "test happy path",
+			params: GetPageGraphParams{
+				Page:   page.Page{GUID: "PG_1"},
+				Depth:  2,
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: append(firstHopGetPageRoleCalls, getPageRoleCall{paramPageGUID: "PG_6", paramPageUserID: "UR_1", returnRole: permission.RoleViewer}),
+			getPageCalls:     append(firstHopGetPageCalls, getPageCall{paramPageGUID: "PG_6", returnPage: amberTemple}),
+			getEdgesCalls: []getEdgesCall{
+				firstHopGetEdgesCall,
+				secondHopGetEdgesCall,
+				{paramPageGUIDs: []string{"PG_6"}, returnEdges: []relation.Edge{vallakiToTemple, templeToKrezk}},
+			},
+			returnGraph: relation.Graph{
+				Nodes: []relation.Node{
+					{PageGUID: "PG_1", Title: "Barovia", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 0},
+					{PageGUID: "PG_2", Title: "Vallaki", PageTemplateGUID: "PT_2", VersionGUID: "VR_1", Depth: 1},
+					{PageGUID: "PG_3", Title: "Krezk", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 1},
+					{PageGUID: "PG_6", Title: "Amber Temple", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 2},
+				},
+				Edges: []relation.Edge{toVallaki, fromKrezk, vallakiToTemple, krezkToVallaki, templeToKrezk},
+			},
+		},
+		{
+			name: "test edges between the last pages reached",
+			params: GetPageGraphParams{
+				Page:   page.Page{GUID: "PG_1"},
+				Depth:  1,
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: firstHopGetPageRoleCalls,
+			getPageCalls:     firstHopGetPageCalls,
+			getEdgesCalls:    []getEdgesCall{firstHopGetEdgesCall, secondHopGetEdgesCall},
+			returnGraph: relation.Graph{
+				Nodes: []relation.Node{
+					{PageGUID: "PG_1", Title: "Barovia", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 0},
+					{PageGUID: "PG_2", Title: "Vallaki", PageTemplateGUID: "PT_2", VersionGUID: "VR_1", Depth: 1},
+					{PageGUID: "PG_3", Title: "Krezk", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 1},
+				},
+				Edges: []relation.Edge{toVallaki, fromKrezk, krezkToVallaki},
+			},
+		},
+		{
+			name: "test traversal through pages of other page templates",
+			params: GetPageGraphParams{
+				Page:             page.Page{GUID: "PG_1"},
+				Depth:            2,
+				PageTemplateGUID: "PT_1",
+				VersionGUID:      "VR_1",
+				UserID:           "UR_1",
+			},
+			getPageRoleCalls: append(firstHopGetPageRoleCalls, getPageRoleCall{paramPageGUID: "PG_6", paramPageUserID: "UR_1", returnRole: permission.RoleViewer}),
+			getPageCalls:     append(firstHopGetPageCalls, getPageCall{paramPageGUID: "PG_6", returnPage: amberTemple}),
+			getEdgesCalls: []getEdgesCall{
+				firstHopGetEdgesCall,
+				secondHopGetEdgesCall,
+				{paramPageGUIDs: []string{"PG_6"}, returnEdges: []relation.Edge{vallakiToTemple, templeToKrezk}},
+			},
+			returnGraph: relation.Graph{
+				Nodes: []relation.Node{
+					{PageGUID: "PG_1", Title: "Barovia", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 0},
+					{PageGUID: "PG_3", Title: "Krezk", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 1},
+					{PageGUID: "PG_6", Title: "Amber Temple", PageTemplateGUID: "PT_1", VersionGUID: "VR_1", Depth: 2},
+				},
+				Edges: []relation.Edge{fromKrezk, templeToKrezk},
+			},
+		},
+		{
+			name: "test unauthorized call",
+			params: GetPageGraphParams{
+				Page:   page.Page{GUID: "PG_1"},
+				Depth:  1,
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1"},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			relationStore := new(mocks.RelationStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getEdgesCalls {
+				relationStore.On("GetEdges", tc.getEdgesCalls[index].paramPageGUIDs).Return(tc.getEdgesCalls[index].returnEdges, tc.getEdgesCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:     pageStore,
+				RelationStore: relationStore,
+			}
+			result, err := pageService.GetPageGraph(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			relationStore.AssertNumberOfCalls(t, "GetEdges", len(tc.getEdgesCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnGraph, result)
+		})
+	}
+}
+
+type getLinksCall struct {
+	paramUserID string
+	returnLinks []relation.Link
+	returnErr   error
+}
+
+func TestGetDanglingRelations(t *testing.T) {
+	toVallaki := relation.Link{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_1", DetailTitle: "Roads", TargetPageGUID: "PG_2", Text: "Vallaki", Status: relation.StatusLive}
+	toBerez := relation.Link{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_2", DetailTitle: "Ruins", TargetPageGUID: "PG_3", Text: "Berez", Status: relation.StatusDeleted}
+	toArgynvostholt := relation.Link{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_3", DetailTitle: "Legends", TargetPageGUID: "PG_9", Text: "Argynvostholt", Status: relation.StatusNonexistent}
+	toKrezk := relation.Link{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_4", DetailTitle: "Roads", TargetPageGUID: "PG_4", Text: "Krezk", Status: relation.StatusLive}
+	toKrezkAgain := relation.Link{PageGUID: "PG_5", PageTitle: "Vallaki", DetailGUID: "DT_5", DetailTitle: "Trade", TargetPageGUID: "PG_4", Text: "Krezk", Status: relation.StatusLive}
+	unreadable := func(l relation.Link) relation.Link {
+		l.Status = relation.StatusUnreadable
+		return l
+	}
+	cases := []struct {
+		name             string
+		params           GetDanglingRelationsParams
+		getPageRoleCalls []getPageRoleCall
+		getLinksCalls    []getLinksCall
+		returnLinks      []relation.Link
+		returnErr        error
+	}{
+		{
+			name:   "test happy path",
+			params: GetDanglingRelationsParams{UserID: "UR_1"},
+			getLinksCalls: []getLinksCall{
+				{paramUserID: "UR_1", returnLinks: []relation.Link{toVallaki, toBerez, toArgynvostholt, toKrezk, toKrezkAgain}},
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_4", paramPageUserID: "UR_1"},
+			},
+			returnLinks: []relation.Link{toBerez, toArgynvostholt, unreadable(toKrezk), unreadable(toKrezkAgain)},
+		},
+		{
+			name:   "test no dangling relations",
+			params: GetDanglingRelationsParams{UserID: "UR_1"},
+			getLinksCalls: []getLinksCall{
+				{paramUserID: "UR_1", returnLinks: []relation.Link{toVallaki}},
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			},
+			returnLinks: []relation.Link{},
+		},
+		{
+			name:   "test store error",
+			params: GetDanglingRelationsParams{UserID: "UR_1"},
+			getLinksCalls: []getLinksCall{
+				{paramUserID: "UR_1", returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to get links: {UserID:UR_1}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			relationStore := new(mocks.RelationStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getLinksCalls {
+				relationStore.On("GetLinks", tc.getLinksCalls[index].paramUserID).Return(tc.getLinksCalls[index].returnLinks, tc.getLinksCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:     pageStore,
+				RelationStore: relationStore,
+			}
+			result, err := pageService.GetDanglingRelations(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			relationStore.AssertNumberOfCalls(t, "GetLinks", len(tc.getLinksCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnLinks, result)
+		})
+	}
+}
+
+type searchCall struct {
+	paramQuery    string
+	paramFilter   search.Filter
+	paramLimit    int
+	returnResults []search.Result
+	returnErr     error
+}
+
+type getUserPageGUIDsCall struct {
+	paramUserID string
+	returnGUIDs []string
+	returnErr   error
+}
+
+func TestSearchPages(t *testing.T) {
+	barovia := search.Result{PageGUID: "PG_1", Title: "Barovia", Score: 2.5}
+	krezk := search.Result{PageGUID: "PG_3", Title: "Krezk", Score: 0.5}
+	cases := []struct {
+		name                  string
+		params                SearchPagesParams
+		noSearchStore         bool
+		getUserPageGUIDsCalls []getUserPageGUIDsCall
+		searchCalls           []searchCall
+		returnResults         []search.Result
+		returnErr             error
+	}{
+		{
+			name:   "test happy path",
+			params: SearchPagesParams{Query: "castle", Limit: 10, UserID: "UR_1"},
+			getUserPageGUIDsCalls: []getUserPageGUIDsCall{
+				{paramUserID: "UR_1", returnGUIDs: []string{"PG_1", "PG_3"}},
+			},
+			searchCalls: []searchCall{
+				{paramQuery: "castle", paramFilter: search.NewFilter([]string{"PG_1", "PG_3"}), paramLimit: 10, returnResults: []search.Result{barovia, krezk}},
+			},
+			returnResults: []search.Result{barovia, krezk},
+		},
+		{
+			name:          "test no search store",
+			params:        SearchPagesParams{Query: "castle", Limit: 10, UserID: "UR_1"},
+			noSearchStore: true,
+			returnResults: []search.Result{},
+		},
+		{
+			name:   "test GetUserPageGUIDs error",
+			params: SearchPagesParams{Query: "castle", Limit: 10, UserID: "UR_1"},
+			getUserPageGUIDsCalls: []getUserPageGUIDsCall{
+				{paramUserID: "UR_1", returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to get the pages of the user to search: {Query:castle Limit:10 UserID:UR_1}: failure"),
+		},
+		{
+			name:   "test search error",
+			params: SearchPagesParams{Query: "castle", Limit: 10, UserID: "UR_1"},
+			getUserPageGUIDsCalls: []getUserPageGUIDsCall{
+				{paramUserID: "UR_1", returnGUIDs: []string{}},
+			},
+			searchCalls: []searchCall{
+				{paramQuery: "castle", paramFilter: search.NewFilter([]string{}), paramLimit: 10, returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to search pages: {Query:castle Limit:10 UserID:UR_1}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			searchStore := new(mocks.SearchStore)
+			for index := range tc.getUserPageGUIDsCalls {
+				pageStore.On("GetUserPageGUIDs", tc.getUserPageGUIDsCalls[index].paramUserID).Return(tc.getUserPageGUIDsCalls[index].returnGUIDs, tc.getUserPageGUIDsCalls[index].returnErr)
+			}
+			for index := range tc.searchCalls {
+				searchStore.On("Search", tc.searchCalls[index].paramQuery, tc.searchCalls[index].paramFilter, tc.searchCalls[index].paramLimit).Return(tc.searchCalls[index].returnResults, tc.searchCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:   pageStore,
+				SearchStore: searchStore,
+			}
+			if tc.noSearchStore {
+				pageService.SearchStore = nil
+			}
+			result, err := pageService.SearchPages(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetUserPageGUIDs", len(tc.getUserPageGUIDsCalls))
+			searchStore.AssertNumberOfCalls(t, "Search", len(tc.searchCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnResults, result)
+		})
+	}
+}
+
+type indexPageCall struct {
+	paramDocument search.Document
+	returnErr     error
+}
+
+type removeIndexedPageCall struct {
+	paramPageGUID string
+	returnErr     error
+}
+
+func TestIndexPage(t *testing.T) {
+	details := []pagedetail.PageDetail{{GUID: "PD_1", Title: "History"}}
+	cases := []struct {
+		name                   string
+		params                 IndexPageParams
+		getPageCalls           []getPageCall
+		getPageDetailsCalls    []getPageDetailsCall
+		indexPageCalls         []indexPageCall
+		removeIndexedPageCalls []removeIndexedPageCall
+		returnErr              error
+	}{
+		{
+			name:   "test happy path",
+			params: IndexPageParams{Page: page.Page{GUID: "PG_1"}},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnPage: getPage("PG_1", "Barovia", "")},
+			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: details},
+			},
+			indexPageCalls: []indexPageCall{
+				{paramDocument: search.NewDocument(getPage("PG_1", "Barovia", ""), details)},
+			},
+		},
+		{
+			name:   "test removed page",
+			params: IndexPageParams{Page: page.Page{GUID: "PG_1"}},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnErr: &storeerror.NotFound{ID: "PG_1"}},
+			},
+			removeIndexedPageCalls: []removeIndexedPageCall{
+				{paramPageGUID: "PG_1"},
+			},
+		},
+		{
+			name:   "test GetPage error",
+			params: IndexPageParams{Page: page.Page{GUID: "PG_1"}},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to get page to index: {Page:{ID:0 Version:{ID:0 GUID: Name: ParentGUID:} PageTemplate:{ID:0 Name: GUID: Summary: Properties:[] Disabled:false} GUID:PG_1 Title: Summary: PermissionType: PageProperties:[] PageDetails:[] CreatedAt:<nil> UpdatedAt:<nil> DeletedAt:<nil>}}: failure"),
+		},
+		{
+			name:   "test IndexPage error",
+			params: IndexPageParams{Page: page.Page{GUID: "PG_1"}},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnPage: getPage("PG_1", "Barovia", "")},
+			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: details},
+			},
+			indexPageCalls: []indexPageCall{
+				{paramDocument: search.NewDocument(getPage("PG_1", "Barovia", ""), details), returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to index page: {Page:{ID:0 Version:{ID:0 GUID: Name: ParentGUID:} PageTemplate:{ID:0 Name: GUID: Summary: Properties:[] Disabled:false} GUID:PG_1 Title: Summary: PermissionType: PageProperties:[] PageDetails:[] CreatedAt:<nil> UpdatedAt:<nil> DeletedAt:<nil>}}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			searchStore := new(mocks.SearchStore)
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCalls[index].paramPageGUID).Return(tc.getPageDetailsCalls[index].returnDetails, tc.getPageDetailsCalls[index].returnErr)
+			}
+			for index := range tc.indexPageCalls {
+				searchStore.On("IndexPage", tc.indexPageCalls[index].paramDocument).Return(tc.indexPageCalls[index].returnErr)
+			}
+			for index := range tc.removeIndexedPageCalls {
+				searchStore.On("RemovePage", tc.removeIndexedPageCalls[index].paramPageGUID).Return(tc.removeIndexedPageCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+				SearchStore:     searchStore,
+			}
+			err := pageService.IndexPage(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCalls))
+			searchStore.AssertNumberOfCalls(t, "IndexPage", len(tc.indexPageCalls))
+			searchStore.AssertNumberOfCalls(t, "RemovePage", len(tc.removeIndexedPageCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getAllPageGUIDsCall struct {
+	returnGUIDs []string
+	returnErr   error
+}
+
+func TestIndexAllPages(t *testing.T) {
+	cases := []struct {
+		name                 string
+		getAllPageGUIDsCalls []getAllPageGUIDsCall
+		getPageCalls         []getPageCall
+		getPageDetailsCalls  []getPageDetailsCall
+		indexPageCalls       []indexPageCall
+		returnErr            error
+	}{
+		{
+			name: "test happy path",
+			getAllPageGUIDsCalls: []getAllPageGUIDsCall{
+				{returnGUIDs: []string{"PG_1", "PG_2"}},
+			},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnPage: getPage("PG_1", "Barovia", "")},
+				{paramPageGUID: "PG_2", returnPage: getPage("PG_2", "Vallaki", "")},
+			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{}},
+				{paramPageGUID: "PG_2", returnDetails: []pagedetail.PageDetail{}},
+			},
+			indexPageCalls: []indexPageCall{
+				{paramDocument: search.NewDocument(getPage("PG_1", "Barovia", ""), []pagedetail.PageDetail{})},
+				{paramDocument: search.NewDocument(getPage("PG_2", "Vallaki", ""), []pagedetail.PageDetail{})},
+			},
+		},
+		{
+			name: "test a page that fails to index is skipped",
+			getAllPageGUIDsCalls: []getAllPageGUIDsCall{
+				{returnGUIDs: []string{"PG_1", "PG_2"}},
+			},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnErr: errors.New("failure")},
+				{paramPageGUID: "PG_2", returnPage: getPage("PG_2", "Vallaki", "")},
+			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_2", returnDetails: []pagedetail.PageDetail{}},
+			},
+			indexPageCalls: []indexPageCall{
+				{paramDocument: search.NewDocument(getPage("PG_2", "Vallaki", ""), []pagedetail.PageDetail{})},
+			},
+		},
+		{
+			name: "test GetAllPageGUIDs error",
+			getAllPageGUIDsCalls: []getAllPageGUIDsCall{
+				{returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to get the pages to index: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			searchStore := new(mocks.SearchStore)
+			for index := range tc.getAllPageGUIDsCalls {
+				pageStore.On("GetAllPageGUIDs").Return(tc.getAllPageGUIDsCalls[index].returnGUIDs, tc.getAllPageGUIDsCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCalls[index].paramPageGUID).Return(tc.getPageDetailsCalls[index].returnDetails, tc.getPageDetailsCalls[index].returnErr)
+			}
+			for index := range tc.indexPageCalls {
+				searchStore.On("IndexPage", tc.indexPageCalls[index].paramDocument).Return(tc.indexPageCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+				SearchStore:     searchStore,
+			}
+			err := pageService.IndexAllPages(ctx)
+			pageStore.AssertNumberOfCalls(t, "GetAllPageGUIDs", len(tc.getAllPageGUIDsCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCalls))
+			searchStore.AssertNumberOfCalls(t, "IndexPage", len(tc.indexPageCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getPageFacetsCall struct {
+	paramUserID  string
+	paramFilter  pagefilter.Filter
+	returnFacets pagefilter.Facets
+	returnErr    error
+}
+
+func TestGetPageFacets(t *testing.T) {
+	facets := pagefilter.Facets{
+		PageTemplates: []pagefilter.PageTemplateFacet{{PageTemplateGUID: "PGT_1", Name: "Settlement", Count: 2}},
+		Properties: []pagefilter.PropertyFacet{
+			{Key: "faction", Values: []pagefilter.ValueFacet{{Value: "Zhentarim", Count: 2}}},
+		},
+	}
+	cases := []struct {
+		name               string
+		params             GetPageFacetsParams
+		getPageFacetsCalls []getPageFacetsCall
+		returnFacets       pagefilter.Facets
+		returnErr          error
+	}{
+		{
+			name: "test happy path",
+			params: GetPageFacetsParams{
+				Filter: pagefilter.Filter{VersionGUID: "VR_1"},
+				UserID: "UR_1",
+			},
+			getPageFacetsCalls: []getPageFacetsCall{
+				{paramUserID: "UR_1", paramFilter: pagefilter.Filter{VersionGUID: "VR_1"}, returnFacets: facets},
+			},
+			returnFacets: facets,
+		},
+		{
+			name: "test store error",
+			params: GetPageFacetsParams{
+				Filter: pagefilter.Filter{VersionGUID: "VR_1"},
+				UserID: "UR_1",
+			},
+			getPageFacetsCalls: []getPageFacetsCall{
+				{paramUserID: "UR_1", paramFilter: pagefilter.Filter{VersionGUID: "VR_1"}, returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to get page facets: {Filter:{PageTemplateGUID: VersionGUID:VR_1 PermissionType: OwnedOnly:false Conditions:[]} UserID:UR_1}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			for index := range tc.getPageFacetsCalls {
+				pageStore.On("GetPageFacets", tc.getPageFacetsCalls[index].paramUserID, tc.getPageFacetsCalls[index].paramFilter).Return(tc.getPageFacetsCalls[index].returnFacets, tc.getPageFacetsCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore: pageStore,
+			}
+			result, err := pageService.GetPageFacets(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageFacets", len(tc.getPageFacetsCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnFacets, result)
+		})
+	}
+}
+
+type getPagePropertiesCall struct {
+	paramPageGUID    string
+	returnProperties []property.Property
+	returnErr        error
+}
+
+func TestExportPageMarkdown(t *testing.T) {
+	cases := []struct {
+		name                   string
+		params                 ExportPageMarkdownParams
+		getPageRoleCalls       []getPageRoleCall
+		getPageCalls           []getPageCall
+		getPagePropertiesCalls []getPagePropertiesCall
+		getPageDetailsCalls    []getPageDetailsCall
+		returnMarkdown         string
+		returnErr              error
+	}{
+		{
+			name: "test happy path",
+			params: ExportPageMarkdownParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnPage: page.Page{GUID: "PG_1", Title: "Barovia"}},
+			},
+			getPagePropertiesCalls: []getPagePropertiesCall{
+				{paramPageGUID: "PG_1", returnProperties: []property.Property{{Key: "ruler", Type: property.TypeString, Value: "Strahd"}}},
+			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{getRelationDetail("DT_1", "PG_2")}},
+			},
+			returnMarkdown: "---\nid: PG_1\ntitle: \"Barovia\"\nproperties:\n  ruler: \"Strahd\"\n---\n\n# Roads\n\nThe road east leads to [[PG_2|Barovia]]\n",
+		},
+		{
+			name: "test unauthorized call",
+			params: ExportPageMarkdownParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1"},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+		{
+			name: "test failure to get details",
+			params: ExportPageMarkdownParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_1", returnPage: page.Page{GUID: "PG_1", Title: "Barovia"}},
+			},
+			getPagePropertiesCalls: []getPagePropertiesCall{
+				{paramPageGUID: "PG_1", returnProperties: []property.Property{}},
+			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnErr: errors.New("failure")},
+			},
+			returnErr: errors.New("failed to get page details to export: {Page:{ID:0 Version:{ID:0 GUID: Name: ParentGUID:} PageTemplate:{ID:0 Name: GUID: Summary: Properties:[] Disabled:false} GUID:PG_1 Title: Summary: PermissionType: PageProperties:[] PageDetails:[] CreatedAt:<nil> UpdatedAt:<nil> DeletedAt:<nil>} UserID:UR_1}: failure"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getPagePropertiesCalls {
+				pageStore.On("GetPageProperties", tc.getPagePropertiesCalls[index].paramPageGUID).Return(tc.getPagePropertiesCalls[index].returnProperties, tc.getPagePropertiesCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCalls[index].paramPageGUID).Return(tc.getPageDetailsCalls[index].returnDetails, tc.getPageDetailsCalls[index].returnErr)
+			}
+			pageService = PageService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			result, err := pageService.ExportPageMarkdown(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageProperties", len(tc.getPagePropertiesCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnMarkdown, result)
+		})
+	}
+}
+
+type revisionMocks struct {
+	pageStore         *mocks.PageStore
+	pageDetailStore   *mocks.PageDetailStore
+	propertyStore     *mocks.PropertyStore
+	userStore         *mocks.UserStore
+	revisionStore     *mocks.RevisionStore
+	pageTemplateStore *mocks.PageTemplateStore
+}
+
+func newRevisionMocks() revisionMocks {
+	return revisionMocks{
+		pageStore:         new(mocks.PageStore),
+		pageDetailStore:   new(mocks.PageDetailStore),
+		propertyStore:     new(mocks.PropertyStore),
+		userStore:         new(mocks.UserStore),
+		revisionStore:     new(mocks.RevisionStore),
+		pageTemplateStore: new(mocks.PageTemplateStore),
+	}
+}
+
+func (m revisionMocks) onSnapshot(p page.Page, properties []property.Property, details []pagedetail.PageDetail) {
+	m.pageStore.On("GetPage", p.GUID).Return(p, nil).Once()
+	m.pageStore.On("GetPageProperties", p.GUID).Return(properties, nil).Once()
+	m.pageDetailStore.On("GetPageDetails", p.GUID).Return(details, nil).Once()
+}
+
+func (m revisionMocks) assertExpectations(t *testing.T) {
+	m.pageStore.AssertExpectations(t)
+	m.pageDetailStore.AssertExpectations(t)
+	m.propertyStore.AssertExpectations(t)
+	m.userStore.AssertExpectations(t)
+	m.revisionStore.AssertExpectations(t)
+	m.pageTemplateStore.AssertExpectations(t)
+}
+
+func TestRecordRevision(t *testing.T) {
+	before := getPage("PG_1", "Barovia", "")
+	after := getPage("PG_1", "Village of Barovia", "")
+	titleChanges := []revision.Change{{Target: pagediff.TargetTitle, Before: "Barovia", After: "Village of Barovia"}}
+	cases := []struct {
+		name                 string
+		withoutRevisionStore bool
+		writeErr             error
+		setupMocks           func(m revisionMocks)
+		writeCalls           int
+		returnErr            error
+	}{
+		{
+			name: "test first revision also records the page before it",
+			setupMocks: func(m revisionMocks) {
+				m.onSnapshot(before, []property.Property{}, []pagedetail.PageDetail{})
+				m.onSnapshot(after, []property.Property{}, []pagedetail.PageDetail{})
+				m.revisionStore.On("HasRevisions", "PG_1").Return(false, nil)
+				m.revisionStore.On("GetUniqueRevisionGUID", "").Return("RV_1", nil).Once()
+				m.revisionStore.On("CreateRevision", revision.Revision{
+					GUID:     "RV_1",
+					PageGUID: "PG_1",
+					Changes:  []revision.Change{},
+					Snapshot: &pagediff.Snapshot{Title: "Barovia", Properties: []property.Property{}, Details: []pagedetail.PageDetail{}},
+				}).Return(revision.Revision{}, nil).Once()
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				m.revisionStore.On("GetUniqueRevisionGUID", "").Return("RV_2", nil).Once()
+				m.revisionStore.On("CreateRevision", revision.Revision{
+					GUID:     "RV_2",
+					PageGUID: "PG_1",
+					AuthorID: 1,
+					Changes:  titleChanges,
+					Snapshot: &pagediff.Snapshot{Title: "Village of Barovia", Properties: []property.Property{}, Details: []pagedetail.PageDetail{}},
+				}).Return(revision.Revision{}, nil).Once()
+			},
+			writeCalls: 1,
+		},
+		{
+			name: "test later revision",
+			setupMocks: func(m revisionMocks) {
+				m.onSnapshot(before, []property.Property{}, []pagedetail.PageDetail{})
+				m.onSnapshot(after, []property.Property{}, []pagedetail.PageDetail{})
+				m.revisionStore.On("HasRevisions", "PG_1").Return(true, nil)
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				m.revisionStore.On("GetUniqueRevisionGUID", "").Return("RV_2", nil)
+				m.revisionStore.On("CreateRevision", revision.Revision{
+					GUID:     "RV_2",
+					PageGUID: "PG_1",
+					AuthorID: 1,
+					Changes:  titleChanges,
+					Snapshot: &pagediff.Snapshot{Title: "Village of Barovia", Properties: []property.Property{}, Details: []pagedetail.PageDetail{}},
+				}).Return(revision.Revision{}, nil)
+			},
+			writeCalls: 1,
+		},
+		{
+			name: "test write without changes is not recorded",
+			setupMocks: func(m revisionMocks) {
+				m.onSnapshot(before, []property.Property{}, []pagedetail.PageDetail{})
+				m.onSnapshot(before, []property.Property{}, []pagedetail.PageDetail{})
+			},
+			writeCalls: 1,
+		},
+		{
+			name:     "test failed write is not recorded",
+			writeErr: errors.New("failure"),
+			setupMocks: func(m revisionMocks) {
+				m.onSnapshot(before, []property.Property{}, []pagedetail.PageDetail{})
+			},
+			writeCalls: 1,
+			returnErr:  errors.New("failure"),
+		},
+		{
+			name:                 "test without a revision store",
+			withoutRevisionStore: true,
+			setupMocks:           func(m revisionMocks) {},
+			writeCalls:           1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newRevisionMocks()
+			tc.setupMocks(m)
+			pageService = PageService{
+				PageStore:       m.pageStore,
+				PageDetailStore: m.pageDetailStore,
+				UserStore:       m.userStore,
+				RevisionStore:   m.revisionStore,
+			}
+			if tc.withoutRevisionStore {
+				pageService.RevisionStore = nil
+			}
+			writeCalls := 0
+			err := pageService.RecordRevision(ctx, RecordRevisionParams{
+				Page:   page.Page{GUID: "PG_1"},
+				UserID: "UR_1",
+				Write: func() error {
+					writeCalls++
+					return tc.writeErr
+				},
+			})
+			m.assertExpectations(t)
+			require.Equal(t, tc.writeCalls, writeCalls)
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+func TestRestorePageRevision(t *testing.T) {
+	ruler := property.Property{Key: "ruler", Type: property.TypeString, Value: "Strahd"}
+	history := pagedetail.PageDetail{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded"}}}
+	editedHistory := pagedetail.PageDetail{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded long ago"}}}
+	geography := pagedetail.PageDetail{GUID: "DT_2", Title: "Geography"}
+	people := pagedetail.PageDetail{GUID: "DT_3", Title: "People"}
+	restored := revision.Revision{
+		GUID:     "RV_1",
+		PageGUID: "PG_1",
+		Snapshot: &pagediff.Snapshot{
+			Title:      "Barovia",
+			Properties: []property.Property{ruler},
+			Details:    []pagedetail.PageDetail{history, geography},
+		},
+	}
+	domainTemplate := pagetemplate.PageTemplate{
+		GUID:       "PGT_2",
+		Name:       "Domain",
+		Properties: []pagetemplate.TemplateProperty{{PropertyID: 2, Key: "ruler", Type: property.TypeString, Required: true}},
+	}
+	cases := []struct {
+		name       string
+		params     RestorePageRevisionParams
+		setupMocks func(m revisionMocks)
+		returnErr  error
+	}{
+		{
+			name: "test happy path",
+			params: RestorePageRevisionParams{
+				Page:     page.Page{GUID: "PG_1"},
+				Revision: revision.Revision{GUID: "RV_1"},
+				UserID:   "UR_1",
+			},
+			setupMocks: func(m revisionMocks) {
+				m.pageStore.On("GetPageRole", "PG_1", "UR_1").Return(permission.RoleOwner, nil)
+				m.revisionStore.On("GetRevision", "PG_1", "RV_1").Return(restored, nil)
+				m.pageStore.On("GetPage", "PG_1").Return(page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_2"}}, nil).Once()
+				m.pageTemplateStore.On("GetPageTemplate", "PGT_2").Return(domainTemplate, nil)
+				m.pageStore.On("GetPageOwnerGUID", "PG_1").Return("UR_1", nil)
+				m.propertyStore.On("GetProperties", "UR_1").Return([]property.Property{{ID: 2, Key: "ruler", Type: property.TypeString}}, nil)
+				m.onSnapshot(getPage("PG_1", "Village of Barovia", ""), []property.Property{}, []pagedetail.PageDetail{editedHistory, people})
+				m.pageStore.On("GetPage", "PG_1").Return(getPage("PG_1", "Village of Barovia", ""), nil).Once()
+				m.pageStore.On("UpdatePage", getPage("PG_1", "Barovia", "")).Return(nil)
+				m.pageStore.On("ReplacePageProperties", "PG_1", []property.Property{{ID: 2, Key: "ruler", Type: property.TypeString, Value: "Strahd"}}).Return(nil)
+				m.pageDetailStore.On("GetPageDetails", "PG_1").Return([]pagedetail.PageDetail{editedHistory, people}, nil).Once()
+				m.pageDetailStore.On("UpdatePageDetail", "PG_1", history).Return(nil)
+				m.pageDetailStore.On("GetUniquePageDetailGUID", "").Return("DT_4", nil)
+				m.pageDetailStore.On("CreatePageDetail", "PG_1", pagedetail.PageDetail{GUID: "DT_4", Title: "Geography"}).Return(pagedetail.PageDetail{ID: 4, GUID: "DT_4", Title: "Geography"}, nil)
+				m.pageDetailStore.On("RemovePageDetail", "PG_1", "DT_3").Return(nil)
+				m.pageDetailStore.On("ReorderPageDetails", "PG_1", []string{"DT_1", "DT_4"}).Return(nil)
+				m.onSnapshot(getPage("PG_1", "Barovia", ""), []property.Property{ruler}, []pagedetail.PageDetail{history, {GUID: "DT_4", Title: "Geography"}})
+				m.revisionStore.On("HasRevisions", "PG_1").Return(true, nil)
+				m.userStore.On("GetUser", "UR_1").Return(appuser.User{ID: 1, GUID: "UR_1"}, nil)
+				m.revisionStore.On("GetUniqueRevisionGUID", "").Return("RV_2", nil)
+				m.revisionStore.On("CreateRevision", mock.Anything).Return(revision.Revision{}, nil)
+			},
+		},
+		{
+			name: "test unauthorized call",
+			params: RestorePageRevisionParams{
+				Page:     page.Page{GUID: "PG_1"},
+				Revision: revision.Revision{GUID: "RV_1"},
+				UserID:   "UR_1",
+			},
+			setupMocks: func(m revisionMocks) {
+				m.pageStore.On("GetPageRole", "PG_1", "UR_1").Return(permission.RoleNone, nil)
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+		{
+			name: "test revision of another page",
+			params: RestorePageRevisionParams{
+				Page:     page.Page{GUID: "PG_1"},
+				Revision: revision.Revision{GUID: "RV_1"},
+				UserID:   "UR_1",
+			},
+			setupMocks: func(m revisionMocks) {
+				m.pageStore.On("GetPageRole", "PG_1", "UR_1").Return(permission.RoleOwner, nil)
+				m.revisionStore.On("GetRevision", "PG_1", "RV_1").Return(revision.Revision{}, &storeerror.NotFound{ID: "RV_1"})
+			},
+			returnErr: errors.New("failed to get page revision: {Page:{ID:0 Version:{ID:0 GUID: Name: ParentGUID:} PageTemplate:{ID:0 Name: GUID: Summary: Properties:[] Disabled:false} GUID:PG_1 Title: Summary: PermissionType: PageProperties:[] PageDetails:[] CreatedAt:<nil> UpdatedAt:<nil> DeletedAt:<nil>} Revision:{ID:0 GUID:RV_1 PageGUID: AuthorID:0 AuthorGUID: CreatedAt:<nil> Changes:[] Snapshot:<nil>} UserID:UR_1}: Could not find: RV_1"),
+		},
+		{
+			name: "test revision without a property the template now requires",
+			params: RestorePageRevisionParams{
+				Page:     page.Page{GUID: "PG_1"},
+				Revision: revision.Revision{GUID: "RV_1"},
+				UserID:   "UR_1",
+			},
+			setupMocks: func(m revisionMocks) {
+				m.pageStore.On("GetPageRole", "PG_1", "UR_1").Return(permission.RoleOwner, nil)
+				m.revisionStore.On("GetRevision", "PG_1", "RV_1").Return(restored, nil)
+				m.pageStore.On("GetPage", "PG_1").Return(page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"}}, nil).Once()
+				m.pageTemplateStore.On("GetPageTemplate", "PGT_1").Return(placeTemplate, nil)
+			},
+			returnErr: errors.New("property population is required by page template Place"),
+		},
+		{
+			name: "test property that is no longer registered",
+			params: RestorePageRevisionParams{
+				Page:     page.Page{GUID: "PG_1"},
+				Revision: revision.Revision{GUID: "RV_1"},
+				UserID:   "UR_1",
+			},
+			setupMocks: func(m revisionMocks) {
+				m.pageStore.On("GetPageRole", "PG_1", "UR_1").Return(permission.RoleOwner, nil)
+				m.revisionStore.On("GetRevision", "PG_1", "RV_1").Return(restored, nil)
+				m.pageStore.On("GetPage", "PG_1").Return(page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_2"}}, nil).Once()
+				m.pageTemplateStore.On("GetPageTemplate", "PGT_2").Return(domainTemplate, nil)
+				m.pageStore.On("GetPageOwnerGUID", "PG_1").Return("UR_1", nil)
+				m.propertyStore.On("GetProperties", "UR_1").Return([]property.Property{}, nil)
+			},
+			returnErr: errors.New("property ruler is not registered or is disabled"),
+		},
+		{
+			name: "test editor restoring a property the owner no longer has",
+			params: RestorePageRevisionParams{
+				Page:     page.Page{GUID: "PG_1"},
+				Revision: revision.Revision{GUID: "RV_1"},
+				UserID:   "UR_2",
+			},
+			setupMocks: func(m revisionMocks) {
+				m.pageStore.On("GetPageRole", "PG_1", "UR_2").Return(permission.RoleEditor, nil)
+				m.revisionStore.On("GetRevision", "PG_1", "RV_1").Return(restored, nil)
+				m.pageStore.On("GetPage", "PG_1").Return(page.Page{GUID: "PG_1", PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_2"}}, nil).Once()
+				m.pageTemplateStore.On("GetPageTemplate", "PGT_2").Return(domainTemplate, nil)
+				m.pageStore.On("GetPageOwnerGUID", "PG_1").Return("UR_1", nil)
+				m.propertyStore.On("GetProperties", "UR_1").Return([]property.Property{}, nil)
+			},
+			returnErr: errors.New("property ruler is not registered or is disabled"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newRevisionMocks()
+			tc.setupMocks(m)
+			pageService = PageService{
+				PageStore:         m.pageStore,
+				PageDetailStore:   m.pageDetailStore,
+				PropertyStore:     m.propertyStore,
+				UserStore:         m.userStore,
+				RevisionStore:     m.revisionStore,
+				PageTemplateStore: m.pageTemplateStore,
+			}
+			err := pageService.RestorePageRevision(ctx, tc.params)
+			m.assertExpectations(t)
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 		})
 	}
 }
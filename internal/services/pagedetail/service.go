@@ -2,15 +2,224 @@ package pagedetailservice
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagehtml"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagemarkdown"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	pageservice "github.com/Pergamene/project-spiderweb-service/internal/services/page"
+	policyservice "github.com/Pergamene/project-spiderweb-service/internal/services/policy"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 	"github.com/pkg/errors"
 )
 
 // PageDetailService is the service for handling page detail-related APIs
 type PageDetailService struct {
+	PageStore       store.PageStore
 	PageDetailStore store.PageDetailStore
+	// SearchIndexer is optional; when set, a page is re-indexed whenever one of its details changes.
+	SearchIndexer SearchIndexer
+	// RevisionRecorder is optional; when set, a revision of the page is recorded whenever one of its details changes.
+	RevisionRecorder RevisionRecorder
+	// PageURL is prefixed to the id of a page to link to it from rendered details.
+	PageURL string
+}
+
+// policy returns the policy that the service authorizes users with.
+func (s PageDetailService) policy() policyservice.PolicyService {
+	return policyservice.PolicyService{PageStore: s.PageStore}
+}
+
+// SearchIndexer keeps the search index up to date with the pages.
+type SearchIndexer interface {
+	IndexPage(ctx context.Context, params pageservice.IndexPageParams) error
+}
+
+// RevisionRecorder records the revisions of the pages.
+type RevisionRecorder interface {
+	RecordRevision(ctx context.Context, params pageservice.RecordRevisionParams) error
+}
+
+func (s PageDetailService) recordRevision(ctx context.Context, pageGUID, userID string, write func() error) error {
+	if s.RevisionRecorder == nil {
+		return write()
+	}
+	return s.RevisionRecorder.RecordRevision(ctx, pageservice.RecordRevisionParams{
+		Page:   page.Page{GUID: pageGUID},
+		UserID: userID,
+		Write:  write,
+	})
+}
+
+func (s PageDetailService) indexPage(ctx context.Context, pageGUID string) error {
+	if s.SearchIndexer == nil {
+		return nil
+	}
+	return s.SearchIndexer.IndexPage(ctx, pageservice.IndexPageParams{Page: page.Page{GUID: pageGUID}})
+}
+
+// CreatePageDetailParams params for CreatePageDetail
+type CreatePageDetailParams struct {
+	Detail pagedetail.PageDetail
+	PageID string
+	UserID string
+}
+
+// CreatePageDetail creates a new detail for the page.
+func (s PageDetailService) CreatePageDetail(ctx context.Context, params CreatePageDetailParams) (pagedetail.PageDetail, error) {
+	_, err := s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return pagedetail.PageDetail{}, err
+	}
+	pageDetailGUID, err := s.PageDetailStore.GetUniquePageDetailGUID(params.Detail.GUID)
+	if err != nil {
+		return pagedetail.PageDetail{}, err
+	}
+	params.Detail.GUID = pageDetailGUID
+	var d pagedetail.PageDetail
+	err = s.recordRevision(ctx, params.PageID, params.UserID, func() error {
+		var err error
+		d, err = s.PageDetailStore.CreatePageDetail(params.PageID, params.Detail)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create detail: %+v", params)
+		}
+		return nil
+	})
+	if err != nil {
+		return d, err
+	}
+	return d, s.indexPage(ctx, params.PageID)
+}
+
+// ImportPageDetailsParams params for ImportPageDetails
+type ImportPageDetailsParams struct {
+	Markdown string
+	// Title is the title of the detail made from any content before the document's first `#` heading.
+	Title string
+	// Preview converts the document without creating any details.
+	Preview bool
+	PageID  string
+	UserID  string
+}
+
+// ImportPageDetails creates a detail for each `#` heading of the Markdown document, in the order they are written.
+// Anything in the document that cannot be represented as partitions is returned as problems, along with its line.
+func (s PageDetailService) ImportPageDetails(ctx context.Context, params ImportPageDetailsParams) ([]pagedetail.PageDetail, []pagemarkdown.Problem, error) {
+	_, err := s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return nil, nil, err
+	}
+	ds, problems := pagemarkdown.Import(params.Markdown, params.Title)
+	if params.Preview {
+		return ds, problems, nil
+	}
+	err = s.recordRevision(ctx, params.PageID, params.UserID, func() error {
+		for i := range ds {
+			pageDetailGUID, err := s.PageDetailStore.GetUniquePageDetailGUID("")
+			if err != nil {
+				return err
+			}
+			ds[i].GUID = pageDetailGUID
+			d, err := s.PageDetailStore.CreatePageDetail(params.PageID, ds[i])
+			if err != nil {
+				return errors.Wrapf(err, "failed to create detail %v: %+v", ds[i].Title, params)
+			}
+			ds[i] = d
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ds, problems, s.indexPage(ctx, params.PageID)
+}
+
+// GetPageDetailParams params for GetPageDetail
+type GetPageDetailParams struct {
+	Detail pagedetail.PageDetail
+	PageID string
+	UserID string
+}
+
+// GetPageDetail returns the page's detail.
+func (s PageDetailService) GetPageDetail(ctx context.Context, params GetPageDetailParams) (pagedetail.PageDetail, error) {
+	_, err := s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return pagedetail.PageDetail{}, err
+	}
+	d, err := s.PageDetailStore.GetPageDetail(params.PageID, params.Detail.GUID)
+	if err != nil {
+		return d, errors.Wrapf(err, "failed to get detail: %+v", params)
+	}
+	return d, nil
+}
+
+// RenderPageDetailParams params for RenderPageDetail
+type RenderPageDetailParams struct {
+	Detail pagedetail.PageDetail
+	PageID string
+	UserID string
+}
+
+// RenderPageDetail returns the page's detail as sanitized HTML.
+// Relations are linked to the related pages with their current titles, unless the user cannot read them.
+func (s PageDetailService) RenderPageDetail(ctx context.Context, params RenderPageDetailParams) (string, error) {
+	d, err := s.GetPageDetail(ctx, GetPageDetailParams{
+		Detail: params.Detail,
+		PageID: params.PageID,
+		UserID: params.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+	titles := make(map[string]string)
+	for _, r := range relation.Extract(d.Partitions) {
+		if _, ok := titles[r.TargetPageGUID]; ok {
+			continue
+		}
+		_, err := s.policy().AuthorizePage(r.TargetPageGUID, params.UserID, permission.ActionRead)
+		if _, ok := err.(*storeerror.NotAuthorized); ok {
+			continue
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to check related page %v: %+v", r.TargetPageGUID, params)
+		}
+		target, err := s.PageStore.GetPage(r.TargetPageGUID)
+		if _, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+			continue
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get related page %v: %+v", r.TargetPageGUID, params)
+		}
+		titles[r.TargetPageGUID] = target.Title
+	}
+	renderer := pagehtml.Renderer{PageURL: s.PageURL, Titles: titles}
+	return renderer.Render(d), nil
+}
+
+// GetPageDetailsParams params for GetPageDetails
+type GetPageDetailsParams struct {
+	PageID string
+	UserID string
+}
+
+// GetPageDetails returns all of the page's details.
+func (s PageDetailService) GetPageDetails(ctx context.Context, params GetPageDetailsParams) ([]pagedetail.PageDetail, error) {
+	ds := make([]pagedetail.PageDetail, 0)
+	_, err := s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return ds, err
+	}
+	ds, err = s.PageDetailStore.GetPageDetails(params.PageID)
+	if err != nil {
+		return ds, errors.Wrapf(err, "failed to get details: %+v", params)
+	}
+	return ds, nil
 }
 
 // UpdatePageDetailParams params for UpdatePageDetail
@@ -20,11 +229,97 @@ type UpdatePageDetailParams struct {
 	UserID string
 }
 
-// UpdatePageDetail Updates a page detail.
+// UpdatePageDetail sets a page detail to what is provided, including its entire partition tree.
 func (s PageDetailService) UpdatePageDetail(ctx context.Context, params UpdatePageDetailParams) error {
-	err := s.PageDetailStore.UpdatePageDetail(params.Detail)
+	_, err := s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.recordRevision(ctx, params.PageID, params.UserID, func() error {
+		err := s.PageDetailStore.UpdatePageDetail(params.PageID, params.Detail)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update detail: %+v", params)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.indexPage(ctx, params.PageID)
+}
+
+// RemovePageDetailParams params for RemovePageDetail
+type RemovePageDetailParams struct {
+	Detail pagedetail.PageDetail
+	PageID string
+	UserID string
+}
+
+// RemovePageDetail marks the page detail as removed.
+func (s PageDetailService) RemovePageDetail(ctx context.Context, params RemovePageDetailParams) error {
+	_, err := s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionEdit)
 	if err != nil {
-		return errors.Wrapf(err, "failed to update detail: %v", params)
+		return err
+	}
+	err = s.recordRevision(ctx, params.PageID, params.UserID, func() error {
+		err := s.PageDetailStore.RemovePageDetail(params.PageID, params.Detail.GUID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove detail: %+v", params)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.indexPage(ctx, params.PageID)
+}
+
+// ReorderPageDetailsParams params for ReorderPageDetails
+type ReorderPageDetailsParams struct {
+	PageDetailIDs []string
+	PageID        string
+	UserID        string
+}
+
+// ReorderPageDetails sets the order of the page's details.
+// The provided ids must be exactly the page's current details, each listed once.
+func (s PageDetailService) ReorderPageDetails(ctx context.Context, params ReorderPageDetailsParams) error {
+	_, err := s.policy().AuthorizePage(params.PageID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	ds, err := s.PageDetailStore.GetPageDetails(params.PageID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get details: %+v", params)
+	}
+	err = validatePageDetailOrder(ds, params.PageDetailIDs)
+	if err != nil {
+		return err
+	}
+	return s.recordRevision(ctx, params.PageID, params.UserID, func() error {
+		err := s.PageDetailStore.ReorderPageDetails(params.PageID, params.PageDetailIDs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to reorder details: %+v", params)
+		}
+		return nil
+	})
+}
+
+func validatePageDetailOrder(details []pagedetail.PageDetail, pageDetailIDs []string) error {
+	remaining := make(map[string]bool)
+	for _, d := range details {
+		remaining[d.GUID] = true
+	}
+	for _, pageDetailID := range pageDetailIDs {
+		if !remaining[pageDetailID] {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("detail %v is not a detail of the page or is listed more than once", pageDetailID)}
+		}
+		delete(remaining, pageDetailID)
+	}
+	for _, d := range details {
+		if remaining[d.GUID] {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("detail %v is missing from the order", d.GUID)}
+		}
 	}
 	return nil
 }
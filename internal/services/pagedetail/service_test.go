@@ -1 +1,566 @@
 package pagedetailservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagemarkdown"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+var pageDetailService PageDetailService
+var ctx context.Context
+
+func TestMain(m *testing.M) {
+	ctx = context.Background()
+	result := m.Run()
+	os.Exit(result)
+}
+
+func getStoreUnauthorizedErr(userID, tableID string, err error) error {
+	return &storeerror.NotAuthorized{
+		UserID:  userID,
+		TableID: tableID,
+		Err:     err,
+	}
+}
+
+type getPageRoleCall struct {
+	paramPageGUID   string
+	paramPageUserID string
+	returnRole      permission.Role
+	returnErr       error
+}
+
+type getUniquePageDetailGUIDCall struct {
+	paramProposedGUID string
+	returnGUID        string
+	returnErr         error
+}
+
+type createPageDetailCall struct {
+	paramPageGUID string
+	paramDetail   pagedetail.PageDetail
+	returnDetail  pagedetail.PageDetail
+	returnErr     error
+}
+
+func TestCreatePageDetail(t *testing.T) {
+	cases := []struct {
+		name                         string
+		params                       CreatePageDetailParams
+		getPageRoleCalls             []getPageRoleCall
+		getUniquePageDetailGUIDCalls []getUniquePageDetailGUIDCall
+		createPageDetailCalls        []createPageDetailCall
+		returnDetail                 pagedetail.PageDetail
+		returnErr                    error
+	}{
+		{
+			name: "test happy path",
+			params: CreatePageDetailParams{
+				Detail: pagedetail.PageDetail{
+					Title: "Detail Title",
+					Partitions: []pagedetail.Partition{
+						{Type: pagedetail.PartitionTypeHeaderOne, TypeString: "h1", Value: "header"},
+					},
+				},
+				PageID: "PG_1",
+				UserID: "UR_1",
+			},
+			getPageRoleCalls:             []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getUniquePageDetailGUIDCalls: []getUniquePageDetailGUIDCall{{returnGUID: "DT_1"}},
+			createPageDetailCalls: []createPageDetailCall{
+				{
+					paramPageGUID: "PG_1",
+					paramDetail: pagedetail.PageDetail{
+						GUID:  "DT_1",
+						Title: "Detail Title",
+						Partitions: []pagedetail.Partition{
+							{Type: pagedetail.PartitionTypeHeaderOne, TypeString: "h1", Value: "header"},
+						},
+					},
+					returnDetail: pagedetail.PageDetail{ID: 1, GUID: "DT_1", Title: "Detail Title"},
+				},
+			},
+			returnDetail: pagedetail.PageDetail{ID: 1, GUID: "DT_1", Title: "Detail Title"},
+		},
+		{
+			name: "test unauthorized call",
+			params: CreatePageDetailParams{
+				Detail: pagedetail.PageDetail{Title: "Detail Title"},
+				PageID: "PG_1",
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_1",
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getUniquePageDetailGUIDCalls {
+				pageDetailStore.On("GetUniquePageDetailGUID", tc.getUniquePageDetailGUIDCalls[index].paramProposedGUID).Return(tc.getUniquePageDetailGUIDCalls[index].returnGUID, tc.getUniquePageDetailGUIDCalls[index].returnErr)
+			}
+			for index := range tc.createPageDetailCalls {
+				pageDetailStore.On("CreatePageDetail", tc.createPageDetailCalls[index].paramPageGUID, tc.createPageDetailCalls[index].paramDetail).Return(tc.createPageDetailCalls[index].returnDetail, tc.createPageDetailCalls[index].returnErr)
+			}
+			pageDetailService = PageDetailService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			result, err := pageDetailService.CreatePageDetail(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetUniquePageDetailGUID", len(tc.getUniquePageDetailGUIDCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "CreatePageDetail", len(tc.createPageDetailCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnDetail, result)
+		})
+	}
+}
+
+func TestImportPageDetails(t *testing.T) {
+	markdown := "Mists.\n\n# History\n\n*How it came to be*\n\nUse `this`."
+	notes := pagedetail.PageDetail{
+		Title:      "Overview",
+		Partitions: []pagedetail.Partition{{Type: pagedetail.PartitionTypeParagraph, TypeString: "p", Value: "Mists."}},
+	}
+	history := pagedetail.PageDetail{
+		Title:      "History",
+		Summary:    "How it came to be",
+		Partitions: []pagedetail.Partition{{Type: pagedetail.PartitionTypeParagraph, TypeString: "p", Value: "Use this."}},
+	}
+	withGUID := func(d pagedetail.PageDetail, guid string) pagedetail.PageDetail {
+		d.GUID = guid
+		return d
+	}
+	withID := func(d pagedetail.PageDetail, id int64) pagedetail.PageDetail {
+		d.ID = id
+		return d
+	}
+	problems := []pagemarkdown.Problem{{Line: 7, Message: "inline code is not supported and is kept as plain text"}}
+	cases := []struct {
+		name                         string
+		params                       ImportPageDetailsParams
+		getPageRoleCalls             []getPageRoleCall
+		getUniquePageDetailGUIDCalls []getUniquePageDetailGUIDCall
+		createPageDetailCalls        []createPageDetailCall
+		returnDetails                []pagedetail.PageDetail
+		returnProblems               []pagemarkdown.Problem
+		returnErr                    error
+	}{
+		{
+			name:                         "test happy path",
+			params:                       ImportPageDetailsParams{Markdown: markdown, Title: "Overview", PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls:             []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getUniquePageDetailGUIDCalls: []getUniquePageDetailGUIDCall{{returnGUID: "DT_1"}, {returnGUID: "DT_2"}},
+			createPageDetailCalls: []createPageDetailCall{
+				{paramPageGUID: "PG_1", paramDetail: withGUID(notes, "DT_1"), returnDetail: withID(withGUID(notes, "DT_1"), 1)},
+				{paramPageGUID: "PG_1", paramDetail: withGUID(history, "DT_2"), returnDetail: withID(withGUID(history, "DT_2"), 2)},
+			},
+			returnDetails:  []pagedetail.PageDetail{withID(withGUID(notes, "DT_1"), 1), withID(withGUID(history, "DT_2"), 2)},
+			returnProblems: problems,
+		},
+		{
+			name:             "test preview",
+			params:           ImportPageDetailsParams{Markdown: markdown, Title: "Overview", Preview: true, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			returnDetails:    []pagedetail.PageDetail{notes, history},
+			returnProblems:   problems,
+		},
+		{
+			name:                         "test failed create",
+			params:                       ImportPageDetailsParams{Markdown: "# History", PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls:             []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getUniquePageDetailGUIDCalls: []getUniquePageDetailGUIDCall{{returnGUID: "DT_1"}},
+			createPageDetailCalls: []createPageDetailCall{
+				{
+					paramPageGUID: "PG_1",
+					paramDetail:   pagedetail.PageDetail{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{}},
+					returnErr:     errors.New("failure"),
+				},
+			},
+			returnErr: errors.New("failed to create detail History: {Markdown:# History Title: Preview:false PageID:PG_1 UserID:UR_1}: failure"),
+		},
+		{
+			name:   "test unauthorized call",
+			params: ImportPageDetailsParams{Markdown: markdown, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_1",
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getUniquePageDetailGUIDCalls {
+				pageDetailStore.On("GetUniquePageDetailGUID", tc.getUniquePageDetailGUIDCalls[index].paramProposedGUID).Return(tc.getUniquePageDetailGUIDCalls[index].returnGUID, tc.getUniquePageDetailGUIDCalls[index].returnErr).Once()
+			}
+			for index := range tc.createPageDetailCalls {
+				pageDetailStore.On("CreatePageDetail", tc.createPageDetailCalls[index].paramPageGUID, tc.createPageDetailCalls[index].paramDetail).Return(tc.createPageDetailCalls[index].returnDetail, tc.createPageDetailCalls[index].returnErr)
+			}
+			pageDetailService = PageDetailService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			result, problems, err := pageDetailService.ImportPageDetails(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetUniquePageDetailGUID", len(tc.getUniquePageDetailGUIDCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "CreatePageDetail", len(tc.createPageDetailCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnDetails, result)
+			require.Equal(t, tc.returnProblems, problems)
+		})
+	}
+}
+
+type getPageDetailsCall struct {
+	paramPageGUID string
+	returnDetails []pagedetail.PageDetail
+	returnErr     error
+}
+
+func TestGetPageDetails(t *testing.T) {
+	cases := []struct {
+		name               string
+		params             GetPageDetailsParams
+		getPageRoleCalls   []getPageRoleCall
+		getPageDetailsCall []getPageDetailsCall
+		returnDetails      []pagedetail.PageDetail
+		returnErr          error
+	}{
+		{
+			name:             "test happy path",
+			params:           GetPageDetailsParams{PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer}},
+			getPageDetailsCall: []getPageDetailsCall{
+				{
+					paramPageGUID: "PG_1",
+					returnDetails: []pagedetail.PageDetail{{GUID: "DT_1"}, {GUID: "DT_2"}},
+				},
+			},
+			returnDetails: []pagedetail.PageDetail{{GUID: "DT_1"}, {GUID: "DT_2"}},
+		},
+		{
+			name:   "test unauthorized call",
+			params: GetPageDetailsParams{PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_1",
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailsCall {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCall[index].paramPageGUID).Return(tc.getPageDetailsCall[index].returnDetails, tc.getPageDetailsCall[index].returnErr)
+			}
+			pageDetailService = PageDetailService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			result, err := pageDetailService.GetPageDetails(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCall))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnDetails, result)
+		})
+	}
+}
+
+type getPageDetailCall struct {
+	paramPageGUID       string
+	paramPageDetailGUID string
+	returnDetail        pagedetail.PageDetail
+	returnErr           error
+}
+
+type getPageCall struct {
+	paramPageGUID string
+	returnPage    page.Page
+	returnErr     error
+}
+
+func TestRenderPageDetail(t *testing.T) {
+	cases := []struct {
+		name               string
+		params             RenderPageDetailParams
+		getPageRoleCalls   []getPageRoleCall
+		getPageDetailCalls []getPageDetailCall
+		getPageCalls       []getPageCall
+		returnHTML         string
+		returnErr          error
+	}{
+		{
+			name:   "test relations to readable, unreadable and removed pages",
+			params: RenderPageDetailParams{Detail: pagedetail.PageDetail{GUID: "DT_1"}, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_3", paramPageUserID: "UR_1"},
+				{paramPageGUID: "PG_4", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			},
+			getPageDetailCalls: []getPageDetailCall{
+				{
+					paramPageGUID:       "PG_1",
+					paramPageDetailGUID: "DT_1",
+					returnDetail: pagedetail.PageDetail{
+						GUID: "DT_1",
+						Partitions: []pagedetail.Partition{
+							{TypeString: "p", Partitions: []pagedetail.Partition{
+								{TypeString: "relation", Value: "Vallaki", Relation: "PG_2"},
+								{TypeString: "relation", Value: "the castle", Relation: "PG_3"},
+								{TypeString: "relation", Value: "Krezk", Relation: "PG_4"},
+								{TypeString: "relation", Value: "town", Relation: "PG_2"},
+							}},
+						},
+					},
+				},
+			},
+			getPageCalls: []getPageCall{
+				{paramPageGUID: "PG_2", returnPage: page.Page{GUID: "PG_2", Title: "Vallaki"}},
+				{paramPageGUID: "PG_4", returnErr: &storeerror.NotFound{ID: "PG_4"}},
+			},
+			returnHTML: "<section id=\"DT_1\">\n" +
+				"<p><a href=\"https://example.com/pages/PG_2\" class=\"relation\" title=\"Vallaki\">Vallaki</a>the castleKrezk" +
+				"<a href=\"https://example.com/pages/PG_2\" class=\"relation\" title=\"Vallaki\">town</a></p>\n" +
+				"</section>",
+		},
+		{
+			name:   "test unauthorized call",
+			params: RenderPageDetailParams{Detail: pagedetail.PageDetail{GUID: "DT_1"}, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1"},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailCalls {
+				pageDetailStore.On("GetPageDetail", tc.getPageDetailCalls[index].paramPageGUID, tc.getPageDetailCalls[index].paramPageDetailGUID).Return(tc.getPageDetailCalls[index].returnDetail, tc.getPageDetailCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			pageDetailService = PageDetailService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+				PageURL:         "https://example.com/pages/",
+			}
+			result, err := pageDetailService.RenderPageDetail(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetail", len(tc.getPageDetailCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnHTML, result)
+		})
+	}
+}
+
+type updatePageDetailCall struct {
+	paramPageGUID string
+	paramDetail   pagedetail.PageDetail
+	returnErr     error
+}
+
+func TestUpdatePageDetail(t *testing.T) {
+	cases := []struct {
+		name                  string
+		params                UpdatePageDetailParams
+		getPageRoleCalls      []getPageRoleCall
+		updatePageDetailCalls []updatePageDetailCall
+		returnErr             error
+	}{
+		{
+			name: "test happy path",
+			params: UpdatePageDetailParams{
+				Detail: pagedetail.PageDetail{GUID: "DT_1", Title: "New Title"},
+				PageID: "PG_1",
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			updatePageDetailCalls: []updatePageDetailCall{
+				{
+					paramPageGUID: "PG_1",
+					paramDetail:   pagedetail.PageDetail{GUID: "DT_1", Title: "New Title"},
+				},
+			},
+		},
+		{
+			name: "test unauthorized call",
+			params: UpdatePageDetailParams{
+				Detail: pagedetail.PageDetail{GUID: "DT_1", Title: "New Title"},
+				PageID: "PG_1",
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_1",
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.updatePageDetailCalls {
+				pageDetailStore.On("UpdatePageDetail", tc.updatePageDetailCalls[index].paramPageGUID, tc.updatePageDetailCalls[index].paramDetail).Return(tc.updatePageDetailCalls[index].returnErr)
+			}
+			pageDetailService = PageDetailService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			err := pageDetailService.UpdatePageDetail(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "UpdatePageDetail", len(tc.updatePageDetailCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type reorderPageDetailsCall struct {
+	paramPageGUID        string
+	paramPageDetailGUIDs []string
+	returnErr            error
+}
+
+func TestReorderPageDetails(t *testing.T) {
+	cases := []struct {
+		name                    string
+		params                  ReorderPageDetailsParams
+		getPageRoleCalls        []getPageRoleCall
+		getPageDetailsCalls     []getPageDetailsCall
+		reorderPageDetailsCalls []reorderPageDetailsCall
+		returnErr               error
+	}{
+		{
+			name:             "test happy path",
+			params:           ReorderPageDetailsParams{PageDetailIDs: []string{"DT_2", "DT_1"}, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{{GUID: "DT_1"}, {GUID: "DT_2"}}},
+			},
+			reorderPageDetailsCalls: []reorderPageDetailsCall{
+				{paramPageGUID: "PG_1", paramPageDetailGUIDs: []string{"DT_2", "DT_1"}},
+			},
+		},
+		{
+			name:             "test missing detail",
+			params:           ReorderPageDetailsParams{PageDetailIDs: []string{"DT_2"}, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{{GUID: "DT_1"}, {GUID: "DT_2"}}},
+			},
+			returnErr: errors.New("detail DT_1 is missing from the order"),
+		},
+		{
+			name:             "test extra detail",
+			params:           ReorderPageDetailsParams{PageDetailIDs: []string{"DT_2", "DT_1", "DT_3"}, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{{GUID: "DT_1"}, {GUID: "DT_2"}}},
+			},
+			returnErr: errors.New("detail DT_3 is not a detail of the page or is listed more than once"),
+		},
+		{
+			name:             "test duplicate detail",
+			params:           ReorderPageDetailsParams{PageDetailIDs: []string{"DT_1", "DT_1"}, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleEditor}},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{{GUID: "DT_1"}, {GUID: "DT_2"}}},
+			},
+			returnErr: errors.New("detail DT_1 is not a detail of the page or is listed more than once"),
+		},
+		{
+			name:   "test unauthorized call",
+			params: ReorderPageDetailsParams{PageDetailIDs: []string{"DT_1"}, PageID: "PG_1", UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{
+				{
+					paramPageGUID:   "PG_1",
+					paramPageUserID: "UR_1",
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCalls[index].paramPageGUID).Return(tc.getPageDetailsCalls[index].returnDetails, tc.getPageDetailsCalls[index].returnErr)
+			}
+			for index := range tc.reorderPageDetailsCalls {
+				pageDetailStore.On("ReorderPageDetails", tc.reorderPageDetailsCalls[index].paramPageGUID, tc.reorderPageDetailsCalls[index].paramPageDetailGUIDs).Return(tc.reorderPageDetailsCalls[index].returnErr)
+			}
+			pageDetailService = PageDetailService{
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			err := pageDetailService.ReorderPageDetails(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "ReorderPageDetails", len(tc.reorderPageDetailsCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package pagetemplateservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	policyservice "github.com/Pergamene/project-spiderweb-service/internal/services/policy"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/pkg/errors"
+)
+
+// PageTemplateService is the service for handling page template APIs
+type PageTemplateService struct {
+	PageTemplateStore store.PageTemplateStore
+	PropertyStore     store.PropertyStore
+	UserStore         store.UserStore
+}
+
+// policy returns the policy that the service authorizes users with.
+func (s PageTemplateService) policy() policyservice.PolicyService {
+	return policyservice.PolicyService{PageTemplateStore: s.PageTemplateStore}
+}
+
+// CreatePageTemplateParams params for CreatePageTemplate
+type CreatePageTemplateParams struct {
+	PageTemplate pagetemplate.PageTemplate
+	OwnerID      string
+}
+
+// CreatePageTemplate creates a new page template for the owner.
+// Every declared property must be registered and enabled by the owner.
+func (s PageTemplateService) CreatePageTemplate(ctx context.Context, params CreatePageTemplateParams) (pagetemplate.PageTemplate, error) {
+	err := s.setRegisteredPropertyIDs(params.PageTemplate.Properties, params.OwnerID)
+	if err != nil {
+		return pagetemplate.PageTemplate{}, err
+	}
+	pageTemplateGUID, err := s.PageTemplateStore.GetUniquePageTemplateGUID(params.PageTemplate.GUID)
+	if err != nil {
+		return pagetemplate.PageTemplate{}, err
+	}
+	params.PageTemplate.GUID = pageTemplateGUID
+	u, err := s.UserStore.GetUser(params.OwnerID)
+	if err != nil {
+		return pagetemplate.PageTemplate{}, errors.Wrapf(err, "failed to get owner: %+v", params)
+	}
+	pt, err := s.PageTemplateStore.CreatePageTemplate(params.PageTemplate, u.ID)
+	if err != nil {
+		return pt, errors.Wrapf(err, "failed to create page template: %+v", params)
+	}
+	return pt, nil
+}
+
+func (s PageTemplateService) setRegisteredPropertyIDs(templateProperties []pagetemplate.TemplateProperty, userID string) error {
+	if len(templateProperties) == 0 {
+		return nil
+	}
+	registeredProperties, err := s.PropertyStore.GetProperties(userID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get registered properties for user: %v", userID)
+	}
+	registeredPropertiesByKey := make(map[string]property.Property)
+	for _, p := range registeredProperties {
+		registeredPropertiesByKey[p.Key] = p
+	}
+	declaredKeys := make(map[string]bool)
+	for i := range templateProperties {
+		key := templateProperties[i].Key
+		if declaredKeys[key] {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("property %v is declared more than once", key)}
+		}
+		declaredKeys[key] = true
+		registeredProperty, ok := registeredPropertiesByKey[key]
+		if !ok {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("property %v is not registered or is disabled", key)}
+		}
+		if registeredProperty.Type != templateProperties[i].Type {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("property %v must be of type %v", key, registeredProperty.Type)}
+		}
+		if !templateProperties[i].IsValidDefaultValue() {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("the default value of property %v must be of type %v", key, registeredProperty.Type)}
+		}
+		templateProperties[i].PropertyID = registeredProperty.ID
+	}
+	return nil
+}
+
+// UpdatePageTemplateParams params for UpdatePageTemplate
+type UpdatePageTemplateParams struct {
+	PageTemplate pagetemplate.PageTemplate
+	UserID       string
+}
+
+// UpdatePageTemplate sets the page template to what is provided, including its declared properties.
+// Pages that already use the template keep their current properties.
+func (s PageTemplateService) UpdatePageTemplate(ctx context.Context, params UpdatePageTemplateParams) error {
+	err := s.policy().AuthorizePageTemplate(params.PageTemplate.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.setRegisteredPropertyIDs(params.PageTemplate.Properties, params.UserID)
+	if err != nil {
+		return err
+	}
+	err = s.PageTemplateStore.UpdatePageTemplate(params.PageTemplate)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update page template: %+v", params)
+	}
+	return nil
+}
+
+// DisablePageTemplateParams params for DisablePageTemplate
+type DisablePageTemplateParams struct {
+	PageTemplate pagetemplate.PageTemplate
+	UserID       string
+}
+
+// DisablePageTemplate hides the page template from GetPageTemplates and prevents new pages from using it.
+// Pages that already use the template are unaffected.
+func (s PageTemplateService) DisablePageTemplate(ctx context.Context, params DisablePageTemplateParams) error {
+	err := s.policy().AuthorizePageTemplate(params.PageTemplate.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.PageTemplateStore.SetPageTemplateDisabled(params.PageTemplate.GUID, true)
+	if err != nil {
+		return errors.Wrapf(err, "failed to disable page template: %+v", params)
+	}
+	return nil
+}
+
+// EnablePageTemplateParams params for EnablePageTemplate
+type EnablePageTemplateParams struct {
+	PageTemplate pagetemplate.PageTemplate
+	UserID       string
+}
+
+// EnablePageTemplate re-enables a disabled page template.
+func (s PageTemplateService) EnablePageTemplate(ctx context.Context, params EnablePageTemplateParams) error {
+	err := s.policy().AuthorizePageTemplate(params.PageTemplate.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.PageTemplateStore.SetPageTemplateDisabled(params.PageTemplate.GUID, false)
+	if err != nil {
+		return errors.Wrapf(err, "failed to enable page template: %+v", params)
+	}
+	return nil
+}
+
+// GetPageTemplatesParams params for GetPageTemplates
+type GetPageTemplatesParams struct {
+	UserID string
+}
+
+// GetPageTemplates returns the user's enabled page templates.
+func (s PageTemplateService) GetPageTemplates(ctx context.Context, params GetPageTemplatesParams) ([]pagetemplate.PageTemplate, error) {
+	pts, err := s.PageTemplateStore.GetPageTemplates(params.UserID)
+	if err != nil {
+		return pts, errors.Wrapf(err, "failed to get page templates: %+v", params)
+	}
+	return pts, nil
+}
@@ -0,0 +1,350 @@
+package pagetemplateservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+var pageTemplateService PageTemplateService
+var ctx context.Context
+
+func TestMain(m *testing.M) {
+	ctx = context.Background()
+	result := m.Run()
+	os.Exit(result)
+}
+
+var registeredProperties = []property.Property{
+	{ID: 1, Key: "population", Type: property.TypeNumber},
+	{ID: 2, Key: "banner", Type: property.TypeString},
+}
+
+type getPropertiesCall struct {
+	paramUserID      string
+	returnProperties []property.Property
+	returnErr        error
+}
+
+type getUniquePageTemplateGUIDCall struct {
+	paramProposedGUID string
+	returnGUID        string
+	returnErr         error
+}
+
+type getUserCall struct {
+	paramUserGUID string
+	returnUser    appuser.User
+	returnErr     error
+}
+
+type createPageTemplateCall struct {
+	paramPageTemplate  pagetemplate.PageTemplate
+	paramOwnerID       int64
+	returnPageTemplate pagetemplate.PageTemplate
+	returnErr          error
+}
+
+func TestCreatePageTemplate(t *testing.T) {
+	cases := []struct {
+		name                           string
+		params                         CreatePageTemplateParams
+		getPropertiesCalls             []getPropertiesCall
+		getUniquePageTemplateGUIDCalls []getUniquePageTemplateGUIDCall
+		getUserCalls                   []getUserCall
+		createPageTemplateCalls        []createPageTemplateCall
+		returnPageTemplate             pagetemplate.PageTemplate
+		returnErr                      error
+	}{
+		{
+			name: "test happy path",
+			params: CreatePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{
+					Name: "Place",
+					Properties: []pagetemplate.TemplateProperty{
+						{Key: "population", Type: property.TypeNumber, Required: true},
+						{Key: "banner", Type: property.TypeString, DefaultValue: "none"},
+					},
+				},
+				OwnerID: "UR_1",
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{paramUserID: "UR_1", returnProperties: registeredProperties},
+			},
+			getUniquePageTemplateGUIDCalls: []getUniquePageTemplateGUIDCall{
+				{returnGUID: "PGT_NEW"},
+			},
+			getUserCalls: []getUserCall{
+				{paramUserGUID: "UR_1", returnUser: appuser.User{ID: 1, GUID: "UR_1"}},
+			},
+			createPageTemplateCalls: []createPageTemplateCall{
+				{
+					paramPageTemplate: pagetemplate.PageTemplate{
+						GUID: "PGT_NEW",
+						Name: "Place",
+						Properties: []pagetemplate.TemplateProperty{
+							{PropertyID: 1, Key: "population", Type: property.TypeNumber, Required: true},
+							{PropertyID: 2, Key: "banner", Type: property.TypeString, DefaultValue: "none"},
+						},
+					},
+					paramOwnerID:       1,
+					returnPageTemplate: pagetemplate.PageTemplate{ID: 1, GUID: "PGT_NEW", Name: "Place"},
+				},
+			},
+			returnPageTemplate: pagetemplate.PageTemplate{ID: 1, GUID: "PGT_NEW", Name: "Place"},
+		},
+		{
+			name: "test unregistered property",
+			params: CreatePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{
+					Name:       "Place",
+					Properties: []pagetemplate.TemplateProperty{{Key: "climate", Type: property.TypeString}},
+				},
+				OwnerID: "UR_1",
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{paramUserID: "UR_1", returnProperties: registeredProperties},
+			},
+			returnErr: errors.New("property climate is not registered or is disabled"),
+		},
+		{
+			name: "test mismatched type",
+			params: CreatePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{
+					Name:       "Place",
+					Properties: []pagetemplate.TemplateProperty{{Key: "population", Type: property.TypeString}},
+				},
+				OwnerID: "UR_1",
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{paramUserID: "UR_1", returnProperties: registeredProperties},
+			},
+			returnErr: errors.New("property population must be of type number"),
+		},
+		{
+			name: "test mismatched default value",
+			params: CreatePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{
+					Name:       "Place",
+					Properties: []pagetemplate.TemplateProperty{{Key: "population", Type: property.TypeNumber, DefaultValue: "many"}},
+				},
+				OwnerID: "UR_1",
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{paramUserID: "UR_1", returnProperties: registeredProperties},
+			},
+			returnErr: errors.New("the default value of property population must be of type number"),
+		},
+		{
+			name: "test property declared twice",
+			params: CreatePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{
+					Name: "Place",
+					Properties: []pagetemplate.TemplateProperty{
+						{Key: "population", Type: property.TypeNumber},
+						{Key: "population", Type: property.TypeNumber},
+					},
+				},
+				OwnerID: "UR_1",
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{paramUserID: "UR_1", returnProperties: registeredProperties},
+			},
+			returnErr: errors.New("property population is declared more than once"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateStore := new(mocks.PageTemplateStore)
+			propertyStore := new(mocks.PropertyStore)
+			userStore := new(mocks.UserStore)
+			for index := range tc.getPropertiesCalls {
+				propertyStore.On("GetProperties", tc.getPropertiesCalls[index].paramUserID).Return(tc.getPropertiesCalls[index].returnProperties, tc.getPropertiesCalls[index].returnErr)
+			}
+			for index := range tc.getUniquePageTemplateGUIDCalls {
+				pageTemplateStore.On("GetUniquePageTemplateGUID", tc.getUniquePageTemplateGUIDCalls[index].paramProposedGUID).Return(tc.getUniquePageTemplateGUIDCalls[index].returnGUID, tc.getUniquePageTemplateGUIDCalls[index].returnErr)
+			}
+			for index := range tc.getUserCalls {
+				userStore.On("GetUser", tc.getUserCalls[index].paramUserGUID).Return(tc.getUserCalls[index].returnUser, tc.getUserCalls[index].returnErr)
+			}
+			for index := range tc.createPageTemplateCalls {
+				pageTemplateStore.On("CreatePageTemplate", tc.createPageTemplateCalls[index].paramPageTemplate, tc.createPageTemplateCalls[index].paramOwnerID).Return(tc.createPageTemplateCalls[index].returnPageTemplate, tc.createPageTemplateCalls[index].returnErr)
+			}
+			pageTemplateService = PageTemplateService{
+				PageTemplateStore: pageTemplateStore,
+				PropertyStore:     propertyStore,
+				UserStore:         userStore,
+			}
+			result, err := pageTemplateService.CreatePageTemplate(ctx, tc.params)
+			propertyStore.AssertNumberOfCalls(t, "GetProperties", len(tc.getPropertiesCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "GetUniquePageTemplateGUID", len(tc.getUniquePageTemplateGUIDCalls))
+			userStore.AssertNumberOfCalls(t, "GetUser", len(tc.getUserCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "CreatePageTemplate", len(tc.createPageTemplateCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnPageTemplate, result)
+		})
+	}
+}
+
+type canEditPageTemplateCall struct {
+	paramPageTemplateGUID string
+	paramUserID           string
+	returnErr             error
+}
+
+type updatePageTemplateCall struct {
+	paramPageTemplate pagetemplate.PageTemplate
+	returnErr         error
+}
+
+func TestUpdatePageTemplate(t *testing.T) {
+	cases := []struct {
+		name                     string
+		params                   UpdatePageTemplateParams
+		canEditPageTemplateCalls []canEditPageTemplateCall
+		getPropertiesCalls       []getPropertiesCall
+		updatePageTemplateCalls  []updatePageTemplateCall
+		returnErr                error
+	}{
+		{
+			name: "test happy path",
+			params: UpdatePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{
+					GUID:       "PGT_1",
+					Name:       "Item",
+					Properties: []pagetemplate.TemplateProperty{{Key: "banner", Type: property.TypeString, Required: true}},
+				},
+				UserID: "UR_1",
+			},
+			canEditPageTemplateCalls: []canEditPageTemplateCall{
+				{paramPageTemplateGUID: "PGT_1", paramUserID: "UR_1"},
+			},
+			getPropertiesCalls: []getPropertiesCall{
+				{paramUserID: "UR_1", returnProperties: registeredProperties},
+			},
+			updatePageTemplateCalls: []updatePageTemplateCall{
+				{
+					paramPageTemplate: pagetemplate.PageTemplate{
+						GUID:       "PGT_1",
+						Name:       "Item",
+						Properties: []pagetemplate.TemplateProperty{{PropertyID: 2, Key: "banner", Type: property.TypeString, Required: true}},
+					},
+				},
+			},
+		},
+		{
+			name: "test unauthorized call",
+			params: UpdatePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1", Name: "Item"},
+				UserID:       "UR_1",
+			},
+			canEditPageTemplateCalls: []canEditPageTemplateCall{
+				{
+					paramPageTemplateGUID: "PGT_1",
+					paramUserID:           "UR_1",
+					returnErr:             &storeerror.NotAuthorized{UserID: "UR_1", TableID: "PGT_1"},
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PGT_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateStore := new(mocks.PageTemplateStore)
+			propertyStore := new(mocks.PropertyStore)
+			for index := range tc.canEditPageTemplateCalls {
+				pageTemplateStore.On("CanEditPageTemplate", tc.canEditPageTemplateCalls[index].paramPageTemplateGUID, tc.canEditPageTemplateCalls[index].paramUserID).Return(tc.canEditPageTemplateCalls[index].returnErr)
+			}
+			for index := range tc.getPropertiesCalls {
+				propertyStore.On("GetProperties", tc.getPropertiesCalls[index].paramUserID).Return(tc.getPropertiesCalls[index].returnProperties, tc.getPropertiesCalls[index].returnErr)
+			}
+			for index := range tc.updatePageTemplateCalls {
+				pageTemplateStore.On("UpdatePageTemplate", tc.updatePageTemplateCalls[index].paramPageTemplate).Return(tc.updatePageTemplateCalls[index].returnErr)
+			}
+			pageTemplateService = PageTemplateService{
+				PageTemplateStore: pageTemplateStore,
+				PropertyStore:     propertyStore,
+			}
+			err := pageTemplateService.UpdatePageTemplate(ctx, tc.params)
+			pageTemplateStore.AssertNumberOfCalls(t, "CanEditPageTemplate", len(tc.canEditPageTemplateCalls))
+			propertyStore.AssertNumberOfCalls(t, "GetProperties", len(tc.getPropertiesCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "UpdatePageTemplate", len(tc.updatePageTemplateCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type setPageTemplateDisabledCall struct {
+	paramPageTemplateGUID string
+	paramIsDisabled       bool
+	returnErr             error
+}
+
+func TestDisablePageTemplate(t *testing.T) {
+	cases := []struct {
+		name                         string
+		params                       DisablePageTemplateParams
+		canEditPageTemplateCalls     []canEditPageTemplateCall
+		setPageTemplateDisabledCalls []setPageTemplateDisabledCall
+		returnErr                    error
+	}{
+		{
+			name: "test happy path",
+			params: DisablePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+				UserID:       "UR_1",
+			},
+			canEditPageTemplateCalls: []canEditPageTemplateCall{
+				{paramPageTemplateGUID: "PGT_1", paramUserID: "UR_1"},
+			},
+			setPageTemplateDisabledCalls: []setPageTemplateDisabledCall{
+				{paramPageTemplateGUID: "PGT_1", paramIsDisabled: true},
+			},
+		},
+		{
+			name: "test unauthorized call",
+			params: DisablePageTemplateParams{
+				PageTemplate: pagetemplate.PageTemplate{GUID: "PGT_1"},
+				UserID:       "UR_1",
+			},
+			canEditPageTemplateCalls: []canEditPageTemplateCall{
+				{
+					paramPageTemplateGUID: "PGT_1",
+					paramUserID:           "UR_1",
+					returnErr:             &storeerror.NotAuthorized{UserID: "UR_1", TableID: "PGT_1"},
+				},
+			},
+			returnErr: errors.New("User UR_1 is not authorized to perform the action on the ID PGT_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateStore := new(mocks.PageTemplateStore)
+			for index := range tc.canEditPageTemplateCalls {
+				pageTemplateStore.On("CanEditPageTemplate", tc.canEditPageTemplateCalls[index].paramPageTemplateGUID, tc.canEditPageTemplateCalls[index].paramUserID).Return(tc.canEditPageTemplateCalls[index].returnErr)
+			}
+			for index := range tc.setPageTemplateDisabledCalls {
+				pageTemplateStore.On("SetPageTemplateDisabled", tc.setPageTemplateDisabledCalls[index].paramPageTemplateGUID, tc.setPageTemplateDisabledCalls[index].paramIsDisabled).Return(tc.setPageTemplateDisabledCalls[index].returnErr)
+			}
+			pageTemplateService = PageTemplateService{
+				PageTemplateStore: pageTemplateStore,
+			}
+			err := pageTemplateService.DisablePageTemplate(ctx, tc.params)
+			pageTemplateStore.AssertNumberOfCalls(t, "CanEditPageTemplate", len(tc.canEditPageTemplateCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "SetPageTemplateDisabled", len(tc.setPageTemplateDisabledCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
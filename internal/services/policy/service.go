@@ -0,0 +1,121 @@
+package policyservice
+
+import (
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/pkg/errors"
+)
+
+// PolicyService decides whether users may take actions on entities, by their role on the entity and the permission.Policy.
+// Every service authorizes through it rather than checking access itself, so that the rules are kept in one place.
+type PolicyService struct {
+	PageStore         store.PageStore
+	PageTemplateStore store.PageTemplateStore
+	VersionStore      store.VersionStore
+	CampaignStore     store.CampaignStore
+	PropertyStore     store.PropertyStore
+}
+
+// AuthorizeParams params for Authorize
+type AuthorizeParams struct {
+	Entity permission.Entity
+	// GUID is the key of the entity for properties, which are only looked up by key.
+	GUID   string
+	UserID string
+	Action permission.Action
+}
+
+// Authorize returns the user's role on the entity if the policy allows them to take the action on it.
+// If not, a storeerror.NotAuthorized will be returned, which is not wrapped so that it can be checked for.
+func (s PolicyService) Authorize(params AuthorizeParams) (permission.Role, error) {
+	role, err := s.getRole(params)
+	if err != nil {
+		return permission.RoleNone, errors.Wrapf(err, "failed to get role: %+v", params)
+	}
+	if !permission.Allows(params.Entity, params.Action, role) {
+		return role, &storeerror.NotAuthorized{
+			UserID:  params.UserID,
+			TableID: params.GUID,
+		}
+	}
+	return role, nil
+}
+
+// AuthorizePage see Authorize for a page.
+func (s PolicyService) AuthorizePage(pageGUID, userID string, action permission.Action) (permission.Role, error) {
+	return s.Authorize(AuthorizeParams{Entity: permission.EntityPage, GUID: pageGUID, UserID: userID, Action: action})
+}
+
+// AuthorizePageTemplate see Authorize for a page template.
+func (s PolicyService) AuthorizePageTemplate(pageTemplateGUID, userID string, action permission.Action) error {
+	_, err := s.Authorize(AuthorizeParams{Entity: permission.EntityPageTemplate, GUID: pageTemplateGUID, UserID: userID, Action: action})
+	return err
+}
+
+// AuthorizeVersion see Authorize for a version.
+func (s PolicyService) AuthorizeVersion(versionGUID, userID string, action permission.Action) error {
+	_, err := s.Authorize(AuthorizeParams{Entity: permission.EntityVersion, GUID: versionGUID, UserID: userID, Action: action})
+	return err
+}
+
+// AuthorizeCampaign see Authorize for a campaign.
+func (s PolicyService) AuthorizeCampaign(campaignGUID, userID string, action permission.Action) (permission.Role, error) {
+	return s.Authorize(AuthorizeParams{Entity: permission.EntityCampaign, GUID: campaignGUID, UserID: userID, Action: action})
+}
+
+// AuthorizeProperty see Authorize for a property of the user's registry.
+func (s PolicyService) AuthorizeProperty(propertyKey, userID string, action permission.Action) error {
+	_, err := s.Authorize(AuthorizeParams{Entity: permission.EntityProperty, GUID: propertyKey, UserID: userID, Action: action})
+	return err
+}
+
+func (s PolicyService) getRole(params AuthorizeParams) (permission.Role, error) {
+	switch params.Entity {
+	case permission.EntityPage:
+		return s.PageStore.GetPageRole(params.GUID, params.UserID)
+	case permission.EntityPageTemplate:
+		return getOwnerRole(s.PageTemplateStore.CanEditPageTemplate(params.GUID, params.UserID))
+	case permission.EntityVersion:
+		return getOwnerRole(s.VersionStore.CanEditVersion(params.GUID, params.UserID))
+	case permission.EntityCampaign:
+		return getCampaignRole(s.CampaignStore.GetCampaignRole(params.GUID, params.UserID))
+	case permission.EntityProperty:
+		// a property can only be found in its owner's registry, so finding it is owning it.
+		_, err := s.PropertyStore.GetProperty(params.GUID, params.UserID)
+		return getOwnerRole(err)
+	default:
+		return permission.RoleNone, errors.Errorf("no policy for entity %v", params.Entity)
+	}
+}
+
+// getOwnerRole returns the role for the result of checking whether a user owns an entity,
+// since page templates and versions cannot be shared, so their owners are the only users with a role.
+func getOwnerRole(err error) (permission.Role, error) {
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		return permission.RoleNone, nil
+	}
+	if err != nil {
+		return permission.RoleNone, err
+	}
+	return permission.RoleOwner, nil
+}
+
+// campaignRoles are the roles that the members of a campaign have on it.
+var campaignRoles = map[campaign.Role]permission.Role{
+	campaign.RoleOwner:  permission.RoleOwner,
+	campaign.RoleEditor: permission.RoleEditor,
+	campaign.RoleViewer: permission.RoleViewer,
+}
+
+// getCampaignRole returns the role for the result of getting a user's role in a campaign, which users who are not members do not have.
+func getCampaignRole(role campaign.Role, err error) (permission.Role, error) {
+	if _, ok := err.(*storeerror.NotAuthorized); ok {
+		return permission.RoleNone, nil
+	}
+	if err != nil {
+		return permission.RoleNone, err
+	}
+	return campaignRoles[role], nil
+}
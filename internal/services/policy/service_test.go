@@ -0,0 +1,180 @@
+package policyservice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type getPageRoleCall struct {
+	paramPageGUID string
+	paramUserID   string
+	returnRole    permission.Role
+	returnErr     error
+}
+
+type canEditCall struct {
+	paramGUID   string
+	paramUserID string
+	returnErr   error
+}
+
+type getCampaignRoleCall struct {
+	paramGUID   string
+	paramUserID string
+	returnRole  campaign.Role
+	returnErr   error
+}
+
+type getPropertyCall struct {
+	paramKey    string
+	paramUserID string
+	returnErr   error
+}
+
+func TestAuthorize(t *testing.T) {
+	cases := []struct {
+		name                     string
+		params                   AuthorizeParams
+		getPageRoleCalls         []getPageRoleCall
+		canEditPageTemplateCalls []canEditCall
+		canEditVersionCalls      []canEditCall
+		getCampaignRoleCalls     []getCampaignRoleCall
+		getPropertyCalls         []getPropertyCall
+		returnRole               permission.Role
+		returnErr                error
+	}{
+		{
+			name:             "test owner edits page",
+			params:           AuthorizeParams{Entity: permission.EntityPage, GUID: "PG_1", UserID: "UR_1", Action: permission.ActionEdit},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramUserID: "UR_1", returnRole: permission.RoleOwner}},
+			returnRole:       permission.RoleOwner,
+		},
+		{
+			name:             "test viewer reads page",
+			params:           AuthorizeParams{Entity: permission.EntityPage, GUID: "PG_1", UserID: "UR_1", Action: permission.ActionRead},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramUserID: "UR_1", returnRole: permission.RoleViewer}},
+			returnRole:       permission.RoleViewer,
+		},
+		{
+			name:             "test viewer edits page",
+			params:           AuthorizeParams{Entity: permission.EntityPage, GUID: "PG_1", UserID: "UR_1", Action: permission.ActionEdit},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramUserID: "UR_1", returnRole: permission.RoleViewer}},
+			returnRole:       permission.RoleViewer,
+			returnErr:        &storeerror.NotAuthorized{UserID: "UR_1", TableID: "PG_1"},
+		},
+		{
+			name:             "test link only discovers page",
+			params:           AuthorizeParams{Entity: permission.EntityPage, GUID: "PG_1", UserID: "UR_1", Action: permission.ActionDiscover},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramUserID: "UR_1", returnRole: permission.RoleLinkOnly}},
+			returnRole:       permission.RoleLinkOnly,
+			returnErr:        &storeerror.NotAuthorized{UserID: "UR_1", TableID: "PG_1"},
+		},
+		{
+			name:             "test page role failure",
+			params:           AuthorizeParams{Entity: permission.EntityPage, GUID: "PG_1", UserID: "UR_1", Action: permission.ActionRead},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramUserID: "UR_1", returnErr: errors.New("failure")}},
+			returnErr:        errors.New("failed to get role: {Entity:page GUID:PG_1 UserID:UR_1 Action:read}: failure"),
+		},
+		{
+			name:                     "test owner edits page template",
+			params:                   AuthorizeParams{Entity: permission.EntityPageTemplate, GUID: "PGT_1", UserID: "UR_1", Action: permission.ActionEdit},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_1", paramUserID: "UR_1"}},
+			returnRole:               permission.RoleOwner,
+		},
+		{
+			name:                     "test other user edits page template",
+			params:                   AuthorizeParams{Entity: permission.EntityPageTemplate, GUID: "PGT_1", UserID: "UR_2", Action: permission.ActionEdit},
+			canEditPageTemplateCalls: []canEditCall{{paramGUID: "PGT_1", paramUserID: "UR_2", returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "PGT_1"}}},
+			returnErr:                &storeerror.NotAuthorized{UserID: "UR_2", TableID: "PGT_1"},
+		},
+		{
+			name:                "test version ownership failure",
+			params:              AuthorizeParams{Entity: permission.EntityVersion, GUID: "VR_1", UserID: "UR_1", Action: permission.ActionEdit},
+			canEditVersionCalls: []canEditCall{{paramGUID: "VR_1", paramUserID: "UR_1", returnErr: errors.New("failure")}},
+			returnErr:           errors.New("failed to get role: {Entity:version GUID:VR_1 UserID:UR_1 Action:edit}: failure"),
+		},
+		{
+			name:                 "test campaign viewer reads campaign",
+			params:               AuthorizeParams{Entity: permission.EntityCampaign, GUID: "CP_1", UserID: "UR_1", Action: permission.ActionRead},
+			getCampaignRoleCalls: []getCampaignRoleCall{{paramGUID: "CP_1", paramUserID: "UR_1", returnRole: campaign.RoleViewer}},
+			returnRole:           permission.RoleViewer,
+		},
+		{
+			name:                 "test campaign editor manages members",
+			params:               AuthorizeParams{Entity: permission.EntityCampaign, GUID: "CP_1", UserID: "UR_1", Action: permission.ActionManageMembers},
+			getCampaignRoleCalls: []getCampaignRoleCall{{paramGUID: "CP_1", paramUserID: "UR_1", returnRole: campaign.RoleEditor}},
+			returnRole:           permission.RoleEditor,
+			returnErr:            &storeerror.NotAuthorized{UserID: "UR_1", TableID: "CP_1"},
+		},
+		{
+			name:                 "test non member reads campaign",
+			params:               AuthorizeParams{Entity: permission.EntityCampaign, GUID: "CP_1", UserID: "UR_2", Action: permission.ActionRead},
+			getCampaignRoleCalls: []getCampaignRoleCall{{paramGUID: "CP_1", paramUserID: "UR_2", returnRole: campaign.RoleViewer, returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "CP_1"}}},
+			returnErr:            &storeerror.NotAuthorized{UserID: "UR_2", TableID: "CP_1"},
+		},
+		{
+			name:             "test owner edits property",
+			params:           AuthorizeParams{Entity: permission.EntityProperty, GUID: "population", UserID: "UR_1", Action: permission.ActionEdit},
+			getPropertyCalls: []getPropertyCall{{paramKey: "population", paramUserID: "UR_1"}},
+			returnRole:       permission.RoleOwner,
+		},
+		{
+			name:             "test property not in registry",
+			params:           AuthorizeParams{Entity: permission.EntityProperty, GUID: "population", UserID: "UR_1", Action: permission.ActionEdit},
+			getPropertyCalls: []getPropertyCall{{paramKey: "population", paramUserID: "UR_1", returnErr: &storeerror.NotFound{ID: "population"}}},
+			returnErr:        errors.New("failed to get role: {Entity:property GUID:population UserID:UR_1 Action:edit}: Could not find: population"),
+		},
+		{
+			name:      "test entity without a policy",
+			params:    AuthorizeParams{Entity: permission.Entity("unknown"), GUID: "UN_1", UserID: "UR_1", Action: permission.ActionRead},
+			returnErr: errors.New("failed to get role: {Entity:unknown GUID:UN_1 UserID:UR_1 Action:read}: no policy for entity unknown"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageTemplateStore := new(mocks.PageTemplateStore)
+			versionStore := new(mocks.VersionStore)
+			campaignStore := new(mocks.CampaignStore)
+			propertyStore := new(mocks.PropertyStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.canEditPageTemplateCalls {
+				pageTemplateStore.On("CanEditPageTemplate", tc.canEditPageTemplateCalls[index].paramGUID, tc.canEditPageTemplateCalls[index].paramUserID).Return(tc.canEditPageTemplateCalls[index].returnErr)
+			}
+			for index := range tc.canEditVersionCalls {
+				versionStore.On("CanEditVersion", tc.canEditVersionCalls[index].paramGUID, tc.canEditVersionCalls[index].paramUserID).Return(tc.canEditVersionCalls[index].returnErr)
+			}
+			for index := range tc.getCampaignRoleCalls {
+				campaignStore.On("GetCampaignRole", tc.getCampaignRoleCalls[index].paramGUID, tc.getCampaignRoleCalls[index].paramUserID).Return(tc.getCampaignRoleCalls[index].returnRole, tc.getCampaignRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPropertyCalls {
+				propertyStore.On("GetProperty", tc.getPropertyCalls[index].paramKey, tc.getPropertyCalls[index].paramUserID).Return(property.Property{}, tc.getPropertyCalls[index].returnErr)
+			}
+			policyService := PolicyService{
+				PageStore:         pageStore,
+				PageTemplateStore: pageTemplateStore,
+				VersionStore:      versionStore,
+				CampaignStore:     campaignStore,
+				PropertyStore:     propertyStore,
+			}
+			role, err := policyService.Authorize(tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "CanEditPageTemplate", len(tc.canEditPageTemplateCalls))
+			versionStore.AssertNumberOfCalls(t, "CanEditVersion", len(tc.canEditVersionCalls))
+			campaignStore.AssertNumberOfCalls(t, "GetCampaignRole", len(tc.getCampaignRoleCalls))
+			propertyStore.AssertNumberOfCalls(t, "GetProperty", len(tc.getPropertyCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			require.Equal(t, tc.returnRole, role)
+		})
+	}
+}
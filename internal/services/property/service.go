@@ -0,0 +1,160 @@
+package propertyservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	policyservice "github.com/Pergamene/project-spiderweb-service/internal/services/policy"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/pkg/errors"
+)
+
+// PropertyService is the service for handling the property registry APIs
+type PropertyService struct {
+	PropertyStore store.PropertyStore
+	UserStore     store.UserStore
+}
+
+func (s PropertyService) policy() policyservice.PolicyService {
+	return policyservice.PolicyService{PropertyStore: s.PropertyStore}
+}
+
+// CreatePropertyParams params for CreateProperty
+type CreatePropertyParams struct {
+	Property property.Property
+	OwnerID  string
+}
+
+// CreateProperty registers a new property for the owner. The key must not already be registered by the owner.
+func (s PropertyService) CreateProperty(ctx context.Context, params CreatePropertyParams) (property.Property, error) {
+	err := s.checkKeyIsUnused(params.Property.Key, params.OwnerID)
+	if err != nil {
+		return property.Property{}, err
+	}
+	u, err := s.UserStore.GetUser(params.OwnerID)
+	if err != nil {
+		return property.Property{}, errors.Wrapf(err, "failed to get owner: %+v", params)
+	}
+	p, err := s.PropertyStore.CreateProperty(params.Property, u.ID)
+	if err != nil {
+		return p, errors.Wrapf(err, "failed to create property: %+v", params)
+	}
+	return p, nil
+}
+
+func (s PropertyService) checkKeyIsUnused(key, userID string) error {
+	_, err := s.PropertyStore.GetProperty(key, userID)
+	if err == nil {
+		return &storeerror.DupEntry{ID: key}
+	}
+	if _, ok := err.(*storeerror.NotFound); ok {
+		return nil
+	}
+	return errors.Wrapf(err, "failed to check if property key %v is in use", key)
+}
+
+// UpdatePropertyParams params for UpdateProperty
+type UpdatePropertyParams struct {
+	Key      string
+	Property property.Property
+	UserID   string
+}
+
+// UpdateProperty sets the key and type of the user's property with the given key.
+// The type may only be changed while no pages use the property.
+func (s PropertyService) UpdateProperty(ctx context.Context, params UpdatePropertyParams) error {
+	err := s.policy().AuthorizeProperty(params.Key, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	p, err := s.PropertyStore.GetProperty(params.Key, params.UserID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get property: %+v", params)
+	}
+	if params.Property.Key != p.Key {
+		err = s.checkKeyIsUnused(params.Property.Key, params.UserID)
+		if err != nil {
+			return err
+		}
+	}
+	if params.Property.Type != p.Type {
+		inUse, err := s.PropertyStore.IsPropertyInUse(p.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check if property is in use: %+v", params)
+		}
+		if inUse {
+			return &serviceerror.InvalidRequest{Message: fmt.Sprintf("cannot change the type of property %v while pages use it", p.Key)}
+		}
+	}
+	params.Property.ID = p.ID
+	err = s.PropertyStore.UpdateProperty(params.Property)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update property: %+v", params)
+	}
+	return nil
+}
+
+// DisablePropertyParams params for DisableProperty
+type DisablePropertyParams struct {
+	Key    string
+	UserID string
+}
+
+// DisableProperty hides the user's property from GetProperties and prevents it from being added to pages.
+// Pages that already use the property keep their values.
+func (s PropertyService) DisableProperty(ctx context.Context, params DisablePropertyParams) error {
+	err := s.policy().AuthorizeProperty(params.Key, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	p, err := s.PropertyStore.GetProperty(params.Key, params.UserID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get property: %+v", params)
+	}
+	err = s.PropertyStore.SetPropertyDisabled(p.ID, true)
+	if err != nil {
+		return errors.Wrapf(err, "failed to disable property: %+v", params)
+	}
+	return nil
+}
+
+// EnablePropertyParams params for EnableProperty
+type EnablePropertyParams struct {
+	Key    string
+	UserID string
+}
+
+// EnableProperty re-enables a disabled property of the user.
+func (s PropertyService) EnableProperty(ctx context.Context, params EnablePropertyParams) error {
+	err := s.policy().AuthorizeProperty(params.Key, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	p, err := s.PropertyStore.GetProperty(params.Key, params.UserID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get property: %+v", params)
+	}
+	err = s.PropertyStore.SetPropertyDisabled(p.ID, false)
+	if err != nil {
+		return errors.Wrapf(err, "failed to enable property: %+v", params)
+	}
+	return nil
+}
+
+// GetPropertiesParams params for GetProperties
+type GetPropertiesParams struct {
+	UserID string
+}
+
+// GetProperties returns the user's enabled properties.
+func (s PropertyService) GetProperties(ctx context.Context, params GetPropertiesParams) ([]property.Property, error) {
+	ps, err := s.PropertyStore.GetProperties(params.UserID)
+	if err != nil {
+		return ps, errors.Wrapf(err, "failed to get properties: %+v", params)
+	}
+	return ps, nil
+}
@@ -0,0 +1,280 @@
+package propertyservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+var propertyService PropertyService
+var ctx context.Context
+
+func TestMain(m *testing.M) {
+	ctx = context.Background()
+	result := m.Run()
+	os.Exit(result)
+}
+
+type getPropertyCall struct {
+	paramKey       string
+	paramUserID    string
+	returnProperty property.Property
+	returnErr      error
+}
+
+type getUserCall struct {
+	paramUserGUID string
+	returnUser    appuser.User
+	returnErr     error
+}
+
+type createPropertyCall struct {
+	paramProperty  property.Property
+	paramOwnerID   int64
+	returnProperty property.Property
+	returnErr      error
+}
+
+func TestCreateProperty(t *testing.T) {
+	cases := []struct {
+		name                string
+		params              CreatePropertyParams
+		getPropertyCalls    []getPropertyCall
+		getUserCalls        []getUserCall
+		createPropertyCalls []createPropertyCall
+		returnProperty      property.Property
+		returnErr           error
+	}{
+		{
+			name: "test happy path",
+			params: CreatePropertyParams{
+				Property: property.Property{Key: "population", Type: property.TypeNumber},
+				OwnerID:  "UR_1",
+			},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnErr: &storeerror.NotFound{ID: "population"}},
+			},
+			getUserCalls: []getUserCall{
+				{paramUserGUID: "UR_1", returnUser: appuser.User{ID: 1, GUID: "UR_1"}},
+			},
+			createPropertyCalls: []createPropertyCall{
+				{
+					paramProperty:  property.Property{Key: "population", Type: property.TypeNumber},
+					paramOwnerID:   1,
+					returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber},
+				},
+			},
+			returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber},
+		},
+		{
+			name: "test key already registered",
+			params: CreatePropertyParams{
+				Property: property.Property{Key: "population", Type: property.TypeNumber},
+				OwnerID:  "UR_1",
+			},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeString}},
+			},
+			returnErr: errors.New("Duplicate id: population"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyStore := new(mocks.PropertyStore)
+			userStore := new(mocks.UserStore)
+			for index := range tc.getPropertyCalls {
+				propertyStore.On("GetProperty", tc.getPropertyCalls[index].paramKey, tc.getPropertyCalls[index].paramUserID).Return(tc.getPropertyCalls[index].returnProperty, tc.getPropertyCalls[index].returnErr)
+			}
+			for index := range tc.getUserCalls {
+				userStore.On("GetUser", tc.getUserCalls[index].paramUserGUID).Return(tc.getUserCalls[index].returnUser, tc.getUserCalls[index].returnErr)
+			}
+			for index := range tc.createPropertyCalls {
+				propertyStore.On("CreateProperty", tc.createPropertyCalls[index].paramProperty, tc.createPropertyCalls[index].paramOwnerID).Return(tc.createPropertyCalls[index].returnProperty, tc.createPropertyCalls[index].returnErr)
+			}
+			propertyService = PropertyService{
+				PropertyStore: propertyStore,
+				UserStore:     userStore,
+			}
+			result, err := propertyService.CreateProperty(ctx, tc.params)
+			propertyStore.AssertNumberOfCalls(t, "GetProperty", len(tc.getPropertyCalls))
+			userStore.AssertNumberOfCalls(t, "GetUser", len(tc.getUserCalls))
+			propertyStore.AssertNumberOfCalls(t, "CreateProperty", len(tc.createPropertyCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnProperty, result)
+		})
+	}
+}
+
+type isPropertyInUseCall struct {
+	paramPropertyID int64
+	returnInUse     bool
+	returnErr       error
+}
+
+type updatePropertyCall struct {
+	paramProperty property.Property
+	returnErr     error
+}
+
+func TestUpdateProperty(t *testing.T) {
+	cases := []struct {
+		name                 string
+		params               UpdatePropertyParams
+		getPropertyCalls     []getPropertyCall
+		isPropertyInUseCalls []isPropertyInUseCall
+		updatePropertyCalls  []updatePropertyCall
+		returnErr            error
+	}{
+		{
+			name: "test rename",
+			params: UpdatePropertyParams{
+				Key:      "population",
+				Property: property.Property{Key: "citizens", Type: property.TypeNumber},
+				UserID:   "UR_1",
+			},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+				{paramKey: "citizens", paramUserID: "UR_1", returnErr: &storeerror.NotFound{ID: "citizens"}},
+			},
+			updatePropertyCalls: []updatePropertyCall{
+				{paramProperty: property.Property{ID: 1, Key: "citizens", Type: property.TypeNumber}},
+			},
+		},
+		{
+			name: "test rename onto existing key",
+			params: UpdatePropertyParams{
+				Key:      "population",
+				Property: property.Property{Key: "banner", Type: property.TypeNumber},
+				UserID:   "UR_1",
+			},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+				{paramKey: "banner", paramUserID: "UR_1", returnProperty: property.Property{ID: 2, Key: "banner", Type: property.TypeString}},
+			},
+			returnErr: errors.New("Duplicate id: banner"),
+		},
+		{
+			name: "test type change while unused",
+			params: UpdatePropertyParams{
+				Key:      "population",
+				Property: property.Property{Key: "population", Type: property.TypeString},
+				UserID:   "UR_1",
+			},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+			},
+			isPropertyInUseCalls: []isPropertyInUseCall{
+				{paramPropertyID: 1, returnInUse: false},
+			},
+			updatePropertyCalls: []updatePropertyCall{
+				{paramProperty: property.Property{ID: 1, Key: "population", Type: property.TypeString}},
+			},
+		},
+		{
+			name: "test type change while in use",
+			params: UpdatePropertyParams{
+				Key:      "population",
+				Property: property.Property{Key: "population", Type: property.TypeString},
+				UserID:   "UR_1",
+			},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+			},
+			isPropertyInUseCalls: []isPropertyInUseCall{
+				{paramPropertyID: 1, returnInUse: true},
+			},
+			returnErr: errors.New("cannot change the type of property population while pages use it"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyStore := new(mocks.PropertyStore)
+			for index := range tc.getPropertyCalls {
+				propertyStore.On("GetProperty", tc.getPropertyCalls[index].paramKey, tc.getPropertyCalls[index].paramUserID).Return(tc.getPropertyCalls[index].returnProperty, tc.getPropertyCalls[index].returnErr)
+			}
+			for index := range tc.isPropertyInUseCalls {
+				propertyStore.On("IsPropertyInUse", tc.isPropertyInUseCalls[index].paramPropertyID).Return(tc.isPropertyInUseCalls[index].returnInUse, tc.isPropertyInUseCalls[index].returnErr)
+			}
+			for index := range tc.updatePropertyCalls {
+				propertyStore.On("UpdateProperty", tc.updatePropertyCalls[index].paramProperty).Return(tc.updatePropertyCalls[index].returnErr)
+			}
+			propertyService = PropertyService{
+				PropertyStore: propertyStore,
+			}
+			err := propertyService.UpdateProperty(ctx, tc.params)
+			propertyStore.AssertNumberOfCalls(t, "GetProperty", len(tc.getPropertyCalls))
+			propertyStore.AssertNumberOfCalls(t, "IsPropertyInUse", len(tc.isPropertyInUseCalls))
+			propertyStore.AssertNumberOfCalls(t, "UpdateProperty", len(tc.updatePropertyCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type setPropertyDisabledCall struct {
+	paramPropertyID int64
+	paramIsDisabled bool
+	returnErr       error
+}
+
+func TestDisableProperty(t *testing.T) {
+	cases := []struct {
+		name                     string
+		params                   DisablePropertyParams
+		getPropertyCalls         []getPropertyCall
+		setPropertyDisabledCalls []setPropertyDisabledCall
+		returnErr                error
+	}{
+		{
+			name:   "test happy path",
+			params: DisablePropertyParams{Key: "population", UserID: "UR_1"},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+				{paramKey: "population", paramUserID: "UR_1", returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber}},
+			},
+			setPropertyDisabledCalls: []setPropertyDisabledCall{
+				{paramPropertyID: 1, paramIsDisabled: true},
+			},
+		},
+		{
+			name:   "test not found",
+			params: DisablePropertyParams{Key: "population", UserID: "UR_1"},
+			getPropertyCalls: []getPropertyCall{
+				{paramKey: "population", paramUserID: "UR_1", returnErr: &storeerror.NotFound{ID: "population"}},
+			},
+			returnErr: errors.New("failed to get role: {Entity:property GUID:population UserID:UR_1 Action:edit}: Could not find: population"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyStore := new(mocks.PropertyStore)
+			for index := range tc.getPropertyCalls {
+				propertyStore.On("GetProperty", tc.getPropertyCalls[index].paramKey, tc.getPropertyCalls[index].paramUserID).Return(tc.getPropertyCalls[index].returnProperty, tc.getPropertyCalls[index].returnErr)
+			}
+			for index := range tc.setPropertyDisabledCalls {
+				propertyStore.On("SetPropertyDisabled", tc.setPropertyDisabledCalls[index].paramPropertyID, tc.setPropertyDisabledCalls[index].paramIsDisabled).Return(tc.setPropertyDisabledCalls[index].returnErr)
+			}
+			propertyService = PropertyService{
+				PropertyStore: propertyStore,
+			}
+			err := propertyService.DisableProperty(ctx, tc.params)
+			propertyStore.AssertNumberOfCalls(t, "GetProperty", len(tc.getPropertyCalls))
+			propertyStore.AssertNumberOfCalls(t, "SetPropertyDisabled", len(tc.setPropertyDisabledCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
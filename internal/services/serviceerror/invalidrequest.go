@@ -0,0 +1,16 @@
+package serviceerror
+
+import "fmt"
+
+// InvalidRequest is an error that signifies that the request conflicts with the current state of the data and cannot be fulfilled.
+type InvalidRequest struct {
+	Message string
+	Err     error
+}
+
+func (e *InvalidRequest) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v\n%v", e.Message, e.Err)
+	}
+	return e.Message
+}
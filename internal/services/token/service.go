@@ -0,0 +1,35 @@
+package tokenservice
+
+import (
+	"context"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/usertoken"
+	"github.com/pkg/errors"
+)
+
+// TokenService is the service for handling token-related APIs
+type TokenService struct {
+	UserStore  store.UserStore
+	UserTokens usertoken.Signer
+}
+
+// IssueTokenParams params for IssueToken
+type IssueTokenParams struct {
+	UserID string
+}
+
+// IssueToken returns a signed token that the user can authenticate with until it expires.
+// The user must be the one asking for the token, since anyone holding it can act as them.
+// Tokens are only issued to users that exist, so a storeerror.NotFound will be returned for any other user.
+func (s TokenService) IssueToken(ctx context.Context, params IssueTokenParams) (usertoken.Token, error) {
+	user, err := s.UserStore.GetUser(params.UserID)
+	if err != nil {
+		return usertoken.Token{}, errors.Wrapf(err, "failed to get user: %+v", params)
+	}
+	token, err := s.UserTokens.Sign(user.GUID)
+	if err != nil {
+		return usertoken.Token{}, errors.Wrapf(err, "failed to sign token: %+v", params)
+	}
+	return token, nil
+}
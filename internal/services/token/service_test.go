@@ -0,0 +1,94 @@
+package tokenservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/clock"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/usertoken"
+	"github.com/stretchr/testify/require"
+)
+
+type getUserCall struct {
+	paramUserGUID string
+	returnUser    appuser.User
+	returnErr     error
+}
+
+func TestIssueToken(t *testing.T) {
+	now := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	signer := usertoken.Signer{
+		Secret:   []byte("SECRET"),
+		Issuer:   "spiderweb",
+		Audience: "spiderweb-api",
+		TTL:      time.Hour,
+		Clock:    clock.MockClock{MockedTime: &now},
+	}
+	cases := []struct {
+		name            string
+		params          IssueTokenParams
+		secret          []byte
+		getUserCalls    []getUserCall
+		returnSubject   string
+		returnExpiresAt time.Time
+		returnErr       error
+	}{
+		{
+			name:   "test issue token",
+			params: IssueTokenParams{UserID: "UR_1"},
+			secret: []byte("SECRET"),
+			getUserCalls: []getUserCall{
+				{paramUserGUID: "UR_1", returnUser: appuser.User{ID: 1, GUID: "UR_1", Email: "test@example.com"}},
+			},
+			returnSubject:   "UR_1",
+			returnExpiresAt: now.Add(time.Hour),
+		},
+		{
+			name:   "test unknown user",
+			params: IssueTokenParams{UserID: "UR_2"},
+			secret: []byte("SECRET"),
+			getUserCalls: []getUserCall{
+				{paramUserGUID: "UR_2", returnErr: &storeerror.NotFound{ID: "UR_2"}},
+			},
+			returnErr: errors.New("failed to get user: {UserID:UR_2}: Could not find: UR_2"),
+		},
+		{
+			name:   "test no secret",
+			params: IssueTokenParams{UserID: "UR_1"},
+			getUserCalls: []getUserCall{
+				{paramUserGUID: "UR_1", returnUser: appuser.User{ID: 1, GUID: "UR_1"}},
+			},
+			returnErr: errors.New("failed to sign token: {UserID:UR_1}: must have a secret to sign a token"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			userStore := new(mocks.UserStore)
+			for index := range tc.getUserCalls {
+				userStore.On("GetUser", tc.getUserCalls[index].paramUserGUID).Return(tc.getUserCalls[index].returnUser, tc.getUserCalls[index].returnErr)
+			}
+			tokenSigner := signer
+			tokenSigner.Secret = tc.secret
+			tokenService := TokenService{
+				UserStore:  userStore,
+				UserTokens: tokenSigner,
+			}
+			result, err := tokenService.IssueToken(context.Background(), tc.params)
+			userStore.AssertNumberOfCalls(t, "GetUser", len(tc.getUserCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnExpiresAt, result.ExpiresAt)
+			claims, err := tokenSigner.Verify(result.Token)
+			require.NoError(t, err)
+			require.Equal(t, tc.returnSubject, claims.Subject)
+		})
+	}
+}
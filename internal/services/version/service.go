@@ -0,0 +1,681 @@
+package versionservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	pageservice "github.com/Pergamene/project-spiderweb-service/internal/services/page"
+	policyservice "github.com/Pergamene/project-spiderweb-service/internal/services/policy"
+	"github.com/Pergamene/project-spiderweb-service/internal/services/serviceerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/pkg/errors"
+)
+
+// VersionService is the service for handling version-related APIs
+type VersionService struct {
+	VersionStore      store.VersionStore
+	PageStore         store.PageStore
+	PageDetailStore   store.PageDetailStore
+	PageTemplateStore store.PageTemplateStore
+	UserStore         store.UserStore
+	// SearchIndexer is optional; when set, forked and merged pages are re-indexed.
+	SearchIndexer SearchIndexer
+	// RevisionRecorder is optional; when set, a revision of the parent page is recorded for every merge that changes it.
+	RevisionRecorder RevisionRecorder
+}
+
+// policy returns the policy that the service authorizes users with.
+func (s VersionService) policy() policyservice.PolicyService {
+	return policyservice.PolicyService{PageStore: s.PageStore, VersionStore: s.VersionStore}
+}
+
+// SearchIndexer keeps the search index up to date with the pages.
+type SearchIndexer interface {
+	IndexPage(ctx context.Context, params pageservice.IndexPageParams) error
+}
+
+// RevisionRecorder records the revisions of the pages.
+type RevisionRecorder interface {
+	RecordRevision(ctx context.Context, params pageservice.RecordRevisionParams) error
+}
+
+func (s VersionService) recordRevision(ctx context.Context, pageGUID, userID string, write func() error) error {
+	if s.RevisionRecorder == nil {
+		return write()
+	}
+	return s.RevisionRecorder.RecordRevision(ctx, pageservice.RecordRevisionParams{
+		Page:   page.Page{GUID: pageGUID},
+		UserID: userID,
+		Write:  write,
+	})
+}
+
+func (s VersionService) indexPage(ctx context.Context, pageGUID string) error {
+	if s.SearchIndexer == nil {
+		return nil
+	}
+	return s.SearchIndexer.IndexPage(ctx, pageservice.IndexPageParams{Page: page.Page{GUID: pageGUID}})
+}
+
+// CreateVersionParams params for CreateVersion
+type CreateVersionParams struct {
+	Version version.Version
+	OwnerID string
+}
+
+// CreateVersion creates a new version for the owner.
+// If a parent is provided, the version is created as a child of the parent, which the owner must be able to edit,
+// since a version cannot be removed while it has children.
+func (s VersionService) CreateVersion(ctx context.Context, params CreateVersionParams) (version.Version, error) {
+	if params.Version.ParentGUID != "" {
+		_, err := s.VersionStore.GetVersion(params.Version.ParentGUID)
+		if _, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+			return version.Version{}, &serviceerror.InvalidRequest{Message: fmt.Sprintf("parent version %v does not exist", params.Version.ParentGUID), Err: err}
+		}
+		if err != nil {
+			return version.Version{}, errors.Wrapf(err, "failed to get parent version: %+v", params)
+		}
+		err = s.policy().AuthorizeVersion(params.Version.ParentGUID, params.OwnerID, permission.ActionEdit)
+		if err != nil {
+			return version.Version{}, err
+		}
+	}
+	versionGUID, err := s.VersionStore.GetUniqueVersionGUID(params.Version.GUID)
+	if err != nil {
+		return version.Version{}, err
+	}
+	params.Version.GUID = versionGUID
+	u, err := s.UserStore.GetUser(params.OwnerID)
+	if err != nil {
+		return version.Version{}, errors.Wrapf(err, "failed to get owner: %+v", params)
+	}
+	v, err := s.VersionStore.CreateVersion(params.Version, u.ID)
+	if err != nil {
+		return v, errors.Wrapf(err, "failed to create version: %+v", params)
+	}
+	return v, nil
+}
+
+// GetVersionParams params for GetVersion
+type GetVersionParams struct {
+	Version version.Version
+	UserID  string
+}
+
+// GetVersion returns the version.
+func (s VersionService) GetVersion(ctx context.Context, params GetVersionParams) (version.Version, error) {
+	err := s.policy().AuthorizeVersion(params.Version.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return version.Version{}, err
+	}
+	v, err := s.VersionStore.GetVersion(params.Version.GUID)
+	if err != nil {
+		return v, errors.Wrapf(err, "failed to get version: %+v", params)
+	}
+	return v, nil
+}
+
+// GetVersionsParams params for GetVersions
+type GetVersionsParams struct {
+	UserID string
+}
+
+// GetVersions returns the versions owned by the user.
+func (s VersionService) GetVersions(ctx context.Context, params GetVersionsParams) ([]version.Version, error) {
+	vs, err := s.VersionStore.GetVersions(params.UserID)
+	if err != nil {
+		return vs, errors.Wrapf(err, "failed to get versions: %+v", params)
+	}
+	return vs, nil
+}
+
+// UpdateVersionParams params for UpdateVersion
+type UpdateVersionParams struct {
+	Version version.Version
+	UserID  string
+}
+
+// UpdateVersion renames the version.
+func (s VersionService) UpdateVersion(ctx context.Context, params UpdateVersionParams) error {
+	err := s.policy().AuthorizeVersion(params.Version.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	err = s.VersionStore.UpdateVersion(params.Version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update version: %+v", params)
+	}
+	return nil
+}
+
+// RemoveVersionParams params for RemoveVersion
+type RemoveVersionParams struct {
+	Version version.Version
+	UserID  string
+}
+
+// RemoveVersion removes the version. A version cannot be removed while it has child versions or pages.
+func (s VersionService) RemoveVersion(ctx context.Context, params RemoveVersionParams) error {
+	err := s.policy().AuthorizeVersion(params.Version.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return err
+	}
+	hasChildVersions, err := s.VersionStore.HasChildVersions(params.Version.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for child versions: %+v", params)
+	}
+	if hasChildVersions {
+		return &serviceerror.InvalidRequest{Message: fmt.Sprintf("version %v still has child versions", params.Version.GUID)}
+	}
+	hasPages, err := s.VersionStore.HasVersionPages(params.Version.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for version pages: %+v", params)
+	}
+	if hasPages {
+		return &serviceerror.InvalidRequest{Message: fmt.Sprintf("version %v still has pages", params.Version.GUID)}
+	}
+	err = s.VersionStore.RemoveVersion(params.Version.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove version: %+v", params)
+	}
+	return nil
+}
+
+// GetVersionAncestryParams params for GetVersionAncestry
+type GetVersionAncestryParams struct {
+	Version version.Version
+	UserID  string
+}
+
+// GetVersionAncestry returns the ancestors of the version, starting with its parent and ending with the root of its version tree.
+func (s VersionService) GetVersionAncestry(ctx context.Context, params GetVersionAncestryParams) ([]version.Version, error) {
+	err := s.policy().AuthorizeVersion(params.Version.GUID, params.UserID, permission.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.VersionStore.GetVersion(params.Version.GUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get version: %+v", params)
+	}
+	ancestors := make([]version.Version, 0)
+	visited := map[string]bool{v.GUID: true}
+	for !v.IsRoot() {
+		if visited[v.ParentGUID] {
+			return nil, errors.Errorf("version tree of %v has a cycle at %v", params.Version.GUID, v.ParentGUID)
+		}
+		visited[v.ParentGUID] = true
+		v, err = s.VersionStore.GetVersion(v.ParentGUID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get ancestor version: %+v", params)
+		}
+		ancestors = append(ancestors, v)
+	}
+	return ancestors, nil
+}
+
+// ForkPagesParams params for ForkPages
+type ForkPagesParams struct {
+	Version version.Version
+	PageIDs []string
+	UserID  string
+}
+
+// ForkPages copies each page, along with its properties and details, from the parent version into the child version.
+// The user must be able to edit the child version and each of the pages, and a page can only be forked into a version once.
+func (s VersionService) ForkPages(ctx context.Context, params ForkPagesParams) ([]version.PageFork, error) {
+	err := s.policy().AuthorizeVersion(params.Version.GUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return nil, err
+	}
+	child, err := s.VersionStore.GetVersion(params.Version.GUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get version: %+v", params)
+	}
+	if child.IsRoot() {
+		return nil, &serviceerror.InvalidRequest{Message: fmt.Sprintf("version %v has no parent to fork pages from", child.GUID)}
+	}
+	sourcePages, err := s.getForkablePages(child, params.PageIDs, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.UserStore.GetUser(params.UserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get owner: %+v", params)
+	}
+	forks := make([]version.PageFork, 0, len(sourcePages))
+	for _, sourcePage := range sourcePages {
+		fork, err := s.forkPage(sourcePage, child, u.ID)
+		if err != nil {
+			return forks, errors.Wrapf(err, "failed to fork page %v: %+v", sourcePage.GUID, params)
+		}
+		forks = append(forks, fork)
+		err = s.indexPage(ctx, fork.PageGUID)
+		if err != nil {
+			return forks, err
+		}
+	}
+	return forks, nil
+}
+
+// getForkablePages validates every page before any of them are forked, so a bad page does not leave the fork half done.
+func (s VersionService) getForkablePages(child version.Version, pageGUIDs []string, userID string) ([]page.Page, error) {
+	pages := make([]page.Page, 0, len(pageGUIDs))
+	seen := make(map[string]bool)
+	for _, pageGUID := range pageGUIDs {
+		if seen[pageGUID] {
+			return nil, &serviceerror.InvalidRequest{Message: fmt.Sprintf("page %v is listed more than once", pageGUID)}
+		}
+		seen[pageGUID] = true
+		_, err := s.policy().AuthorizePage(pageGUID, userID, permission.ActionEdit)
+		if err != nil {
+			return nil, err
+		}
+		p, err := s.PageStore.GetPage(pageGUID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get page: %v", pageGUID)
+		}
+		if p.Version.GUID != child.ParentGUID {
+			return nil, &serviceerror.InvalidRequest{Message: fmt.Sprintf("page %v is not in the parent version %v", pageGUID, child.ParentGUID)}
+		}
+		_, err = s.VersionStore.GetForkedPageGUID(pageGUID, child.GUID)
+		if err == nil {
+			return nil, &serviceerror.InvalidRequest{Message: fmt.Sprintf("page %v has already been forked into version %v", pageGUID, child.GUID)}
+		}
+		if _, ok := errors.Cause(err).(*storeerror.NotFound); !ok {
+			return nil, errors.Wrapf(err, "failed to check for an existing fork of page: %v", pageGUID)
+		}
+		pages = append(pages, p)
+	}
+	return pages, nil
+}
+
+func (s VersionService) forkPage(sourcePage page.Page, child version.Version, ownerID int64) (version.PageFork, error) {
+	fork := version.PageFork{
+		SourcePageGUID: sourcePage.GUID,
+		DetailGUIDs:    make(map[string]string),
+		Base: &pagediff.Snapshot{
+			Title:   sourcePage.Title,
+			Summary: sourcePage.Summary,
+		},
+	}
+	pt, err := s.PageTemplateStore.GetPageTemplate(sourcePage.PageTemplate.GUID)
+	if err != nil {
+		return fork, errors.Wrap(err, "failed to get page template")
+	}
+	pageGUID, err := s.PageStore.GetUniquePageGUID("")
+	if err != nil {
+		return fork, err
+	}
+	forkedPage := sourcePage
+	forkedPage.GUID = pageGUID
+	forkedPage.Version = child
+	forkedPage.PageTemplate = pt
+	forkedPage, err = s.PageStore.CreatePage(forkedPage, ownerID)
+	if err != nil {
+		return fork, errors.Wrap(err, "failed to create forked page")
+	}
+	fork.PageGUID = forkedPage.GUID
+	properties, err := s.PageStore.GetPageProperties(sourcePage.GUID)
+	if err != nil {
+		return fork, errors.Wrap(err, "failed to get page properties")
+	}
+	fork.Base.Properties = properties
+	if len(properties) > 0 {
+		err = s.PageStore.ReplacePageProperties(forkedPage.GUID, properties)
+		if err != nil {
+			return fork, errors.Wrap(err, "failed to copy page properties")
+		}
+	}
+	details, err := s.PageDetailStore.GetPageDetails(sourcePage.GUID)
+	if err != nil {
+		return fork, errors.Wrap(err, "failed to get page details")
+	}
+	fork.Base.Details = details
+	for _, detail := range details {
+		detailGUID, err := s.PageDetailStore.GetUniquePageDetailGUID("")
+		if err != nil {
+			return fork, err
+		}
+		forkedDetail := detail
+		forkedDetail.GUID = detailGUID
+		_, err = s.PageDetailStore.CreatePageDetail(forkedPage.GUID, forkedDetail)
+		if err != nil {
+			return fork, errors.Wrap(err, "failed to copy page detail")
+		}
+		fork.DetailGUIDs[detailGUID] = detail.GUID
+	}
+	err = s.VersionStore.CreatePageFork(fork)
+	if err != nil {
+		return fork, errors.Wrap(err, "failed to record the page fork")
+	}
+	return fork, nil
+}
+
+// DiffPageParams params for DiffPage
+type DiffPageParams struct {
+	Page   page.Page
+	UserID string
+}
+
+// DiffPage compares a page in a child version against the page it was forked from in the parent version.
+// Each change is measured against the page as it was when it was forked, or when it was last merged back,
+// so changes made in only one of the versions can be told apart from conflicts.
+func (s VersionService) DiffPage(ctx context.Context, params DiffPageParams) (pagediff.Diff, error) {
+	state, err := s.getPageDiffState(params.Page.GUID, params.UserID)
+	if err != nil {
+		return pagediff.Diff{}, err
+	}
+	return state.diff(), nil
+}
+
+// MergePageParams params for MergePage
+type MergePageParams struct {
+	Page       page.Page
+	Selections []pagediff.Selection
+	UserID     string
+}
+
+// MergePage merges the selected changes of a page in a child version back into the page it was forked from.
+// Conflicts must pick a side: the child's side overwrites the parent, while the parent's side keeps the parent as it is.
+// Merged child changes are no longer reported by DiffPage, but a conflict that keeps the parent's side becomes a pending
+// change of the parent, which DiffPage then reports with StatusParent. The remaining diff is returned.
+func (s VersionService) MergePage(ctx context.Context, params MergePageParams) (pagediff.Diff, error) {
+	state, err := s.getPageDiffState(params.Page.GUID, params.UserID)
+	if err != nil {
+		return pagediff.Diff{}, err
+	}
+	_, err = s.policy().AuthorizePage(state.fork.SourcePageGUID, params.UserID, permission.ActionEdit)
+	if err != nil {
+		return pagediff.Diff{}, err
+	}
+	diff := state.diff()
+	changes := make([]pagediff.Change, 0, len(params.Selections))
+	sides := make([]pagediff.Side, 0, len(params.Selections))
+	for _, selection := range params.Selections {
+		change, side, err := getSelectedChange(diff, selection)
+		if err != nil {
+			return diff, err
+		}
+		changes = append(changes, change)
+		sides = append(sides, side)
+	}
+	merged := pageMerge{
+		parent:      state.parent,
+		base:        state.base,
+		detailGUIDs: state.fork.DetailGUIDs,
+	}
+	err = s.recordRevision(ctx, state.fork.SourcePageGUID, params.UserID, func() error {
+		for i, change := range changes {
+			err := s.mergeChange(&merged, state, change, sides[i])
+			if err != nil {
+				return errors.Wrapf(err, "failed to merge the %v change %v: %+v", change.Target, change.Key, params)
+			}
+		}
+		if merged.pageChanged {
+			parentPage := state.parentPage
+			parentPage.Title = merged.parent.Title
+			parentPage.Summary = merged.parent.Summary
+			err := s.PageStore.UpdatePage(parentPage)
+			if err != nil {
+				return errors.Wrapf(err, "failed to update parent page: %+v", params)
+			}
+		}
+		if merged.propertiesChanged {
+			err := s.PageStore.ReplacePageProperties(state.fork.SourcePageGUID, merged.parent.Properties)
+			if err != nil {
+				return errors.Wrapf(err, "failed to update parent page properties: %+v", params)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return diff, err
+	}
+	state.fork.Base = &merged.base
+	state.fork.DetailGUIDs = merged.detailGUIDs
+	err = s.VersionStore.UpdatePageFork(state.fork)
+	if err != nil {
+		return diff, errors.Wrapf(err, "failed to update page fork: %+v", params)
+	}
+	state.parent = merged.parent
+	state.base = merged.base
+	if len(changes) > 0 {
+		err = s.indexPage(ctx, state.fork.SourcePageGUID)
+		if err != nil {
+			return state.diff(), err
+		}
+	}
+	return state.diff(), nil
+}
+
+// pageDiffState is everything needed to compare a forked page against its source.
+type pageDiffState struct {
+	fork       version.PageFork
+	parentPage page.Page
+	base       pagediff.Snapshot
+	parent     pagediff.Snapshot
+	child      pagediff.Snapshot
+}
+
+func (state pageDiffState) diff() pagediff.Diff {
+	return pagediff.Diff{
+		PageGUID:       state.fork.PageGUID,
+		ParentPageGUID: state.fork.SourcePageGUID,
+		Changes:        pagediff.Compare(state.base, state.parent, state.child, state.fork.DetailGUIDs),
+	}
+}
+
+func (s VersionService) getPageDiffState(pageGUID, userID string) (pageDiffState, error) {
+	var state pageDiffState
+	_, err := s.policy().AuthorizePage(pageGUID, userID, permission.ActionRead)
+	if err != nil {
+		return state, err
+	}
+	state.fork, err = s.VersionStore.GetPageFork(pageGUID)
+	if _, ok := errors.Cause(err).(*storeerror.NotFound); ok {
+		return state, &serviceerror.InvalidRequest{Message: fmt.Sprintf("page %v was not forked from a parent version", pageGUID), Err: err}
+	}
+	if err != nil {
+		return state, errors.Wrapf(err, "failed to get page fork: %v", pageGUID)
+	}
+	_, err = s.policy().AuthorizePage(state.fork.SourcePageGUID, userID, permission.ActionRead)
+	if err != nil {
+		return state, err
+	}
+	_, state.child, err = s.getPageSnapshot(pageGUID)
+	if err != nil {
+		return state, err
+	}
+	state.parentPage, state.parent, err = s.getPageSnapshot(state.fork.SourcePageGUID)
+	if err != nil {
+		return state, err
+	}
+	// forks made before bases were recorded are compared as if the parent has not changed since.
+	state.base = state.parent
+	if state.fork.Base != nil {
+		state.base = *state.fork.Base
+	}
+	return state, nil
+}
+
+func (s VersionService) getPageSnapshot(pageGUID string) (page.Page, pagediff.Snapshot, error) {
+	p, err := s.PageStore.GetPage(pageGUID)
+	if err != nil {
+		return p, pagediff.Snapshot{}, errors.Wrapf(err, "failed to get page: %v", pageGUID)
+	}
+	properties, err := s.PageStore.GetPageProperties(pageGUID)
+	if err != nil {
+		return p, pagediff.Snapshot{}, errors.Wrapf(err, "failed to get page properties: %v", pageGUID)
+	}
+	details, err := s.PageDetailStore.GetPageDetails(pageGUID)
+	if err != nil {
+		return p, pagediff.Snapshot{}, errors.Wrapf(err, "failed to get page details: %v", pageGUID)
+	}
+	return p, pagediff.Snapshot{
+		Title:      p.Title,
+		Summary:    p.Summary,
+		Properties: properties,
+		Details:    details,
+	}, nil
+}
+
+func getSelectedChange(diff pagediff.Diff, selection pagediff.Selection) (pagediff.Change, pagediff.Side, error) {
+	change, ok := diff.GetChange(selection.Target, selection.Key)
+	if !ok {
+		return change, "", &serviceerror.InvalidRequest{Message: fmt.Sprintf("there is no change to %v to merge", describeChange(selection))}
+	}
+	switch change.Status {
+	case pagediff.StatusParent:
+		return change, "", &serviceerror.InvalidRequest{Message: fmt.Sprintf("the change to %v was only made in the parent version", describeChange(selection))}
+	case pagediff.StatusConflict:
+		if selection.Side == "" {
+			return change, "", &serviceerror.InvalidRequest{Message: fmt.Sprintf("the change to %v conflicts with the parent version, so a side must be picked", describeChange(selection))}
+		}
+		return change, selection.Side, nil
+	default:
+		if selection.Side == pagediff.SideParent {
+			return change, "", &serviceerror.InvalidRequest{Message: fmt.Sprintf("the change to %v does not conflict with the parent version, so it can only be merged from the child", describeChange(selection))}
+		}
+		return change, pagediff.SideChild, nil
+	}
+}
+
+func describeChange(selection pagediff.Selection) string {
+	if selection.Key == "" {
+		return string(selection.Target)
+	}
+	return fmt.Sprintf("%v %v", selection.Target, selection.Key)
+}
+
+// pageMerge tracks the parent page and the fork's base as each change is merged.
+type pageMerge struct {
+	parent            pagediff.Snapshot
+	base              pagediff.Snapshot
+	detailGUIDs       map[string]string
+	pageChanged       bool
+	propertiesChanged bool
+}
+
+func (s VersionService) mergeChange(merged *pageMerge, state pageDiffState, change pagediff.Change, side pagediff.Side) error {
+	takeChild := side == pagediff.SideChild
+	switch change.Target {
+	case pagediff.TargetTitle:
+		if takeChild {
+			merged.parent.Title = state.child.Title
+			merged.pageChanged = true
+		}
+		merged.base.Title = state.child.Title
+	case pagediff.TargetSummary:
+		if takeChild {
+			merged.parent.Summary = state.child.Summary
+			merged.pageChanged = true
+		}
+		merged.base.Summary = state.child.Summary
+	case pagediff.TargetProperty:
+		if takeChild {
+			merged.parent.Properties = setProperty(merged.parent.Properties, change.Key, change.Child)
+			merged.propertiesChanged = true
+		}
+		merged.base.Properties = setProperty(merged.base.Properties, change.Key, change.Child)
+	case pagediff.TargetDetail:
+		return s.mergeDetailChange(merged, state, change, takeChild)
+	}
+	return nil
+}
+
+func (s VersionService) mergeDetailChange(merged *pageMerge, state pageDiffState, change pagediff.Change, takeChild bool) error {
+	// details only in the parent are keyed by the parent's guid, all others by the child's.
+	parentDetailGUID, forked := merged.detailGUIDs[change.Key]
+	if !forked && change.Child == nil {
+		parentDetailGUID = change.Key
+	}
+	childDetail, inChild := change.Child.(pagedetail.PageDetail)
+	_, inParent := change.Parent.(pagedetail.PageDetail)
+	if takeChild {
+		switch {
+		case !inChild:
+			err := s.PageDetailStore.RemovePageDetail(state.fork.SourcePageGUID, parentDetailGUID)
+			if err != nil {
+				return errors.Wrap(err, "failed to remove parent page detail")
+			}
+		case inParent:
+			mergedDetail := childDetail
+			mergedDetail.GUID = parentDetailGUID
+			err := s.PageDetailStore.UpdatePageDetail(state.fork.SourcePageGUID, mergedDetail)
+			if err != nil {
+				return errors.Wrap(err, "failed to update parent page detail")
+			}
+		default:
+			detailGUID, err := s.PageDetailStore.GetUniquePageDetailGUID("")
+			if err != nil {
+				return err
+			}
+			mergedDetail := childDetail
+			mergedDetail.GUID = detailGUID
+			_, err = s.PageDetailStore.CreatePageDetail(state.fork.SourcePageGUID, mergedDetail)
+			if err != nil {
+				return errors.Wrap(err, "failed to create parent page detail")
+			}
+			merged.base.Details = setDetail(merged.base.Details, parentDetailGUID, nil)
+			parentDetailGUID = detailGUID
+			merged.detailGUIDs[childDetail.GUID] = detailGUID
+		}
+		merged.parent.Details = setDetail(merged.parent.Details, parentDetailGUID, change.Child)
+	}
+	merged.base.Details = setDetail(merged.base.Details, parentDetailGUID, change.Child)
+	return nil
+}
+
+// setProperty returns a copy of the properties with the given property replaced, added or, if value is nil, removed.
+func setProperty(properties []property.Property, key string, value interface{}) []property.Property {
+	updated := make([]property.Property, 0, len(properties)+1)
+	found := false
+	for _, p := range properties {
+		if p.Key != key {
+			updated = append(updated, p)
+			continue
+		}
+		found = true
+		if value != nil {
+			updated = append(updated, value.(property.Property))
+		}
+	}
+	if !found && value != nil {
+		updated = append(updated, value.(property.Property))
+	}
+	return updated
+}
+
+// setDetail returns a copy of the details with the given detail replaced, added or, if value is nil, removed.
+// The detail keeps the given guid, so that it matches the parent page.
+func setDetail(details []pagedetail.PageDetail, guid string, value interface{}) []pagedetail.PageDetail {
+	updated := make([]pagedetail.PageDetail, 0, len(details)+1)
+	found := false
+	for _, d := range details {
+		if d.GUID != guid {
+			updated = append(updated, d)
+			continue
+		}
+		found = true
+		if value != nil {
+			updated = append(updated, withGUID(value.(pagedetail.PageDetail), guid))
+		}
+	}
+	if !found && value != nil {
+		updated = append(updated, withGUID(value.(pagedetail.PageDetail), guid))
+	}
+	return updated
+}
+
+func withGUID(d pagedetail.PageDetail, guid string) pagedetail.PageDetail {
+	d.GUID = guid
+	d.ID = 0
+	return d
+}
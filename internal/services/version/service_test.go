@@ -0,0 +1,962 @@
+package versionservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+var versionService VersionService
+var ctx context.Context
+
+func TestMain(m *testing.M) {
+	ctx = context.Background()
+	result := m.Run()
+	os.Exit(result)
+}
+
+type getVersionCall struct {
+	paramVersionGUID string
+	returnVersion    version.Version
+	returnErr        error
+}
+
+type createVersionCall struct {
+	paramVersion  version.Version
+	paramOwnerID  int64
+	returnVersion version.Version
+	returnErr     error
+}
+
+func TestCreateVersion(t *testing.T) {
+	cases := []struct {
+		name                string
+		params              CreateVersionParams
+		getVersionCalls     []getVersionCall
+		canEditVersionCalls []canEditVersionCall
+		getUserCalls        []getUserCall
+		createVersionCalls  []createVersionCall
+		returnVersion       version.Version
+		returnErr           error
+	}{
+		{
+			name: "test child of own version",
+			params: CreateVersionParams{
+				Version: version.Version{Name: "Session 12", ParentGUID: "VR_1"},
+				OwnerID: "UR_1",
+			},
+			getVersionCalls:     []getVersionCall{{paramVersionGUID: "VR_1", returnVersion: version.Version{ID: 1, GUID: "VR_1", Name: "Default"}}},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_1", paramUserID: "UR_1"}},
+			getUserCalls:        []getUserCall{{paramUserGUID: "UR_1", returnUser: appuser.User{ID: 1, GUID: "UR_1"}}},
+			createVersionCalls: []createVersionCall{{
+				paramVersion:  version.Version{GUID: "VR_2", Name: "Session 12", ParentGUID: "VR_1"},
+				paramOwnerID:  1,
+				returnVersion: version.Version{ID: 2, GUID: "VR_2", Name: "Session 12", ParentGUID: "VR_1"},
+			}},
+			returnVersion: version.Version{ID: 2, GUID: "VR_2", Name: "Session 12", ParentGUID: "VR_1"},
+		},
+		{
+			name: "test parent does not exist",
+			params: CreateVersionParams{
+				Version: version.Version{Name: "Session 12", ParentGUID: "VR_1"},
+				OwnerID: "UR_1",
+			},
+			getVersionCalls: []getVersionCall{{paramVersionGUID: "VR_1", returnErr: &storeerror.NotFound{ID: "VR_1"}}},
+			returnErr:       errors.New("parent version VR_1 does not exist\nCould not find: VR_1"),
+		},
+		{
+			name: "test child of another user's version",
+			params: CreateVersionParams{
+				Version: version.Version{Name: "Session 12", ParentGUID: "VR_1"},
+				OwnerID: "UR_2",
+			},
+			getVersionCalls:     []getVersionCall{{paramVersionGUID: "VR_1", returnVersion: version.Version{ID: 1, GUID: "VR_1", Name: "Default"}}},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_1", paramUserID: "UR_2", returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_1"}}},
+			returnErr:           &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := new(mocks.VersionStore)
+			userStore := new(mocks.UserStore)
+			for index := range tc.getVersionCalls {
+				versionStore.On("GetVersion", tc.getVersionCalls[index].paramVersionGUID).Return(tc.getVersionCalls[index].returnVersion, tc.getVersionCalls[index].returnErr)
+			}
+			for index := range tc.canEditVersionCalls {
+				versionStore.On("CanEditVersion", tc.canEditVersionCalls[index].paramVersionGUID, tc.canEditVersionCalls[index].paramUserID).Return(tc.canEditVersionCalls[index].returnErr)
+			}
+			versionStore.On("GetUniqueVersionGUID", "").Return("VR_2", nil)
+			for index := range tc.getUserCalls {
+				userStore.On("GetUser", tc.getUserCalls[index].paramUserGUID).Return(tc.getUserCalls[index].returnUser, tc.getUserCalls[index].returnErr)
+			}
+			for index := range tc.createVersionCalls {
+				versionStore.On("CreateVersion", tc.createVersionCalls[index].paramVersion, tc.createVersionCalls[index].paramOwnerID).Return(tc.createVersionCalls[index].returnVersion, tc.createVersionCalls[index].returnErr)
+			}
+			versionService = VersionService{
+				VersionStore: versionStore,
+				UserStore:    userStore,
+			}
+			result, err := versionService.CreateVersion(ctx, tc.params)
+			versionStore.AssertNumberOfCalls(t, "GetVersion", len(tc.getVersionCalls))
+			versionStore.AssertNumberOfCalls(t, "CanEditVersion", len(tc.canEditVersionCalls))
+			userStore.AssertNumberOfCalls(t, "GetUser", len(tc.getUserCalls))
+			versionStore.AssertNumberOfCalls(t, "CreateVersion", len(tc.createVersionCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnVersion, result)
+		})
+	}
+}
+
+func TestGetVersionAncestry(t *testing.T) {
+	cases := []struct {
+		name                string
+		params              GetVersionAncestryParams
+		canEditVersionCalls []canEditVersionCall
+		getVersionCalls     []getVersionCall
+		returnVersions      []version.Version
+		returnErr           error
+	}{
+		{
+			name: "test grandchild",
+			params: GetVersionAncestryParams{
+				Version: version.Version{GUID: "VR_3"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_3", paramUserID: "UR_1"}},
+			getVersionCalls: []getVersionCall{
+				{paramVersionGUID: "VR_3", returnVersion: version.Version{ID: 3, GUID: "VR_3", Name: "Session 12", ParentGUID: "VR_2"}},
+				{paramVersionGUID: "VR_2", returnVersion: version.Version{ID: 2, GUID: "VR_2", Name: "New Campaign Changes", ParentGUID: "VR_1"}},
+				{paramVersionGUID: "VR_1", returnVersion: version.Version{ID: 1, GUID: "VR_1", Name: "Default"}},
+			},
+			returnVersions: []version.Version{
+				{ID: 2, GUID: "VR_2", Name: "New Campaign Changes", ParentGUID: "VR_1"},
+				{ID: 1, GUID: "VR_1", Name: "Default"},
+			},
+		},
+		{
+			name: "test root",
+			params: GetVersionAncestryParams{
+				Version: version.Version{GUID: "VR_1"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_1", paramUserID: "UR_1"}},
+			getVersionCalls: []getVersionCall{
+				{paramVersionGUID: "VR_1", returnVersion: version.Version{ID: 1, GUID: "VR_1", Name: "Default"}},
+			},
+			returnVersions: []version.Version{},
+		},
+		{
+			name: "test cycle",
+			params: GetVersionAncestryParams{
+				Version: version.Version{GUID: "VR_1"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_1", paramUserID: "UR_1"}},
+			getVersionCalls: []getVersionCall{
+				{paramVersionGUID: "VR_1", returnVersion: version.Version{ID: 1, GUID: "VR_1", Name: "Default", ParentGUID: "VR_2"}},
+				{paramVersionGUID: "VR_2", returnVersion: version.Version{ID: 2, GUID: "VR_2", Name: "New Campaign Changes", ParentGUID: "VR_1"}},
+			},
+			returnErr: errors.New("version tree of VR_1 has a cycle at VR_1"),
+		},
+		{
+			name: "test other user's version",
+			params: GetVersionAncestryParams{
+				Version: version.Version{GUID: "VR_3"},
+				UserID:  "UR_2",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_3", paramUserID: "UR_2", returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_3"}}},
+			returnErr:           &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_3"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := new(mocks.VersionStore)
+			for index := range tc.canEditVersionCalls {
+				versionStore.On("CanEditVersion", tc.canEditVersionCalls[index].paramVersionGUID, tc.canEditVersionCalls[index].paramUserID).Return(tc.canEditVersionCalls[index].returnErr)
+			}
+			for index := range tc.getVersionCalls {
+				versionStore.On("GetVersion", tc.getVersionCalls[index].paramVersionGUID).Return(tc.getVersionCalls[index].returnVersion, tc.getVersionCalls[index].returnErr)
+			}
+			versionService = VersionService{
+				VersionStore: versionStore,
+			}
+			result, err := versionService.GetVersionAncestry(ctx, tc.params)
+			versionStore.AssertNumberOfCalls(t, "CanEditVersion", len(tc.canEditVersionCalls))
+			versionStore.AssertNumberOfCalls(t, "GetVersion", len(tc.getVersionCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnVersions, result)
+		})
+	}
+}
+
+type canEditVersionCall struct {
+	paramVersionGUID string
+	paramUserID      string
+	returnErr        error
+}
+
+type hasCall struct {
+	paramVersionGUID string
+	returnHas        bool
+	returnErr        error
+}
+
+type removeVersionCall struct {
+	paramVersionGUID string
+	returnErr        error
+}
+
+func TestRemoveVersion(t *testing.T) {
+	cases := []struct {
+		name                  string
+		params                RemoveVersionParams
+		canEditVersionCalls   []canEditVersionCall
+		hasChildVersionsCalls []hasCall
+		hasVersionPagesCalls  []hasCall
+		removeVersionCalls    []removeVersionCall
+		returnErr             error
+	}{
+		{
+			name: "test happy path",
+			params: RemoveVersionParams{
+				Version: version.Version{GUID: "VR_2"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls:   []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_1"}},
+			hasChildVersionsCalls: []hasCall{{paramVersionGUID: "VR_2"}},
+			hasVersionPagesCalls:  []hasCall{{paramVersionGUID: "VR_2"}},
+			removeVersionCalls:    []removeVersionCall{{paramVersionGUID: "VR_2"}},
+		},
+		{
+			name: "test not the owner",
+			params: RemoveVersionParams{
+				Version: version.Version{GUID: "VR_2"},
+				UserID:  "UR_2",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_2", returnErr: &storeerror.NotAuthorized{UserID: "UR_2", TableID: "VR_2"}}},
+			returnErr:           errors.New("User UR_2 is not authorized to perform the action on the ID VR_2"),
+		},
+		{
+			name: "test has child versions",
+			params: RemoveVersionParams{
+				Version: version.Version{GUID: "VR_2"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls:   []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_1"}},
+			hasChildVersionsCalls: []hasCall{{paramVersionGUID: "VR_2", returnHas: true}},
+			returnErr:             errors.New("version VR_2 still has child versions"),
+		},
+		{
+			name: "test has pages",
+			params: RemoveVersionParams{
+				Version: version.Version{GUID: "VR_2"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls:   []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_1"}},
+			hasChildVersionsCalls: []hasCall{{paramVersionGUID: "VR_2"}},
+			hasVersionPagesCalls:  []hasCall{{paramVersionGUID: "VR_2", returnHas: true}},
+			returnErr:             errors.New("version VR_2 still has pages"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := new(mocks.VersionStore)
+			for index := range tc.canEditVersionCalls {
+				versionStore.On("CanEditVersion", tc.canEditVersionCalls[index].paramVersionGUID, tc.canEditVersionCalls[index].paramUserID).Return(tc.canEditVersionCalls[index].returnErr)
+			}
+			for index := range tc.hasChildVersionsCalls {
+				versionStore.On("HasChildVersions", tc.hasChildVersionsCalls[index].paramVersionGUID).Return(tc.hasChildVersionsCalls[index].returnHas, tc.hasChildVersionsCalls[index].returnErr)
+			}
+			for index := range tc.hasVersionPagesCalls {
+				versionStore.On("HasVersionPages", tc.hasVersionPagesCalls[index].paramVersionGUID).Return(tc.hasVersionPagesCalls[index].returnHas, tc.hasVersionPagesCalls[index].returnErr)
+			}
+			for index := range tc.removeVersionCalls {
+				versionStore.On("RemoveVersion", tc.removeVersionCalls[index].paramVersionGUID).Return(tc.removeVersionCalls[index].returnErr)
+			}
+			versionService = VersionService{
+				VersionStore: versionStore,
+			}
+			err := versionService.RemoveVersion(ctx, tc.params)
+			versionStore.AssertNumberOfCalls(t, "CanEditVersion", len(tc.canEditVersionCalls))
+			versionStore.AssertNumberOfCalls(t, "HasChildVersions", len(tc.hasChildVersionsCalls))
+			versionStore.AssertNumberOfCalls(t, "HasVersionPages", len(tc.hasVersionPagesCalls))
+			versionStore.AssertNumberOfCalls(t, "RemoveVersion", len(tc.removeVersionCalls))
+			testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+		})
+	}
+}
+
+type getPageRoleCall struct {
+	paramPageGUID   string
+	paramPageUserID string
+	returnRole      permission.Role
+	returnErr       error
+}
+
+type getPageCall struct {
+	paramPageGUID string
+	returnPage    page.Page
+	returnErr     error
+}
+
+type getForkedPageGUIDCall struct {
+	paramSourcePageGUID string
+	paramVersionGUID    string
+	returnPageGUID      string
+	returnErr           error
+}
+
+type getUserCall struct {
+	paramUserGUID string
+	returnUser    appuser.User
+	returnErr     error
+}
+
+type getPageTemplateCall struct {
+	paramPageTemplateGUID string
+	returnPageTemplate    pagetemplate.PageTemplate
+	returnErr             error
+}
+
+type getUniqueGUIDCall struct {
+	paramProposedGUID string
+	returnGUID        string
+	returnErr         error
+}
+
+type createPageCall struct {
+	paramPage    page.Page
+	paramOwnerID int64
+	returnPage   page.Page
+	returnErr    error
+}
+
+type getPagePropertiesCall struct {
+	paramPageGUID    string
+	returnProperties []property.Property
+	returnErr        error
+}
+
+type replacePagePropertiesCall struct {
+	paramPageGUID   string
+	paramProperties []property.Property
+	returnErr       error
+}
+
+type getPageDetailsCall struct {
+	paramPageGUID string
+	returnDetails []pagedetail.PageDetail
+	returnErr     error
+}
+
+type createPageDetailCall struct {
+	paramPageGUID string
+	paramDetail   pagedetail.PageDetail
+	returnDetail  pagedetail.PageDetail
+	returnErr     error
+}
+
+type createPageForkCall struct {
+	paramFork version.PageFork
+	returnErr error
+}
+
+func TestForkPages(t *testing.T) {
+	childVersion := version.Version{ID: 2, GUID: "VR_2", Name: "New Campaign Changes", ParentGUID: "VR_1"}
+	sourcePage := page.Page{
+		ID:             1,
+		GUID:           "PG_1",
+		Version:        version.Version{GUID: "VR_1"},
+		PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+		Title:          "Barovia",
+		PermissionType: permission.TypePrivate,
+	}
+	forkBase := &pagediff.Snapshot{
+		Title:      "Barovia",
+		Properties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(500)}},
+		Details:    []pagedetail.PageDetail{{ID: 1, GUID: "DT_1", Title: "History"}},
+	}
+	cases := []struct {
+		name                       string
+		params                     ForkPagesParams
+		canEditVersionCalls        []canEditVersionCall
+		getVersionCalls            []getVersionCall
+		getPageRoleCalls           []getPageRoleCall
+		getPageCalls               []getPageCall
+		getForkedPageGUIDCalls     []getForkedPageGUIDCall
+		getUserCalls               []getUserCall
+		getPageTemplateCalls       []getPageTemplateCall
+		getUniquePageGUIDCalls     []getUniqueGUIDCall
+		createPageCalls            []createPageCall
+		getPagePropertiesCalls     []getPagePropertiesCall
+		replacePagePropertiesCalls []replacePagePropertiesCall
+		getPageDetailsCalls        []getPageDetailsCall
+		getUniqueDetailGUIDCalls   []getUniqueGUIDCall
+		createPageDetailCalls      []createPageDetailCall
+		createPageForkCalls        []createPageForkCall
+		returnForks                []version.PageFork
+		returnErr                  error
+	}{
+		{
+			name: "test happy path",
+			params: ForkPagesParams{
+				Version: version.Version{GUID: "VR_2"},
+				PageIDs: []string{"PG_1"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls:    []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_1"}},
+			getVersionCalls:        []getVersionCall{{paramVersionGUID: "VR_2", returnVersion: childVersion}},
+			getPageRoleCalls:       []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}},
+			getPageCalls:           []getPageCall{{paramPageGUID: "PG_1", returnPage: sourcePage}},
+			getForkedPageGUIDCalls: []getForkedPageGUIDCall{{paramSourcePageGUID: "PG_1", paramVersionGUID: "VR_2", returnErr: &storeerror.NotFound{ID: "PG_1"}}},
+			getUserCalls:           []getUserCall{{paramUserGUID: "UR_1", returnUser: appuser.User{ID: 1, GUID: "UR_1"}}},
+			getPageTemplateCalls:   []getPageTemplateCall{{paramPageTemplateGUID: "PGT_1", returnPageTemplate: pagetemplate.PageTemplate{ID: 1, GUID: "PGT_1"}}},
+			getUniquePageGUIDCalls: []getUniqueGUIDCall{{returnGUID: "PG_2"}},
+			createPageCalls: []createPageCall{
+				{
+					paramPage: page.Page{
+						ID:             1,
+						GUID:           "PG_2",
+						Version:        childVersion,
+						PageTemplate:   pagetemplate.PageTemplate{ID: 1, GUID: "PGT_1"},
+						Title:          "Barovia",
+						PermissionType: permission.TypePrivate,
+					},
+					paramOwnerID: 1,
+					returnPage:   page.Page{ID: 2, GUID: "PG_2"},
+				},
+			},
+			getPagePropertiesCalls: []getPagePropertiesCall{
+				{paramPageGUID: "PG_1", returnProperties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(500)}}},
+			},
+			replacePagePropertiesCalls: []replacePagePropertiesCall{
+				{paramPageGUID: "PG_2", paramProperties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(500)}}},
+			},
+			getPageDetailsCalls: []getPageDetailsCall{
+				{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{{ID: 1, GUID: "DT_1", Title: "History"}}},
+			},
+			getUniqueDetailGUIDCalls: []getUniqueGUIDCall{{returnGUID: "DT_2"}},
+			createPageDetailCalls: []createPageDetailCall{
+				{paramPageGUID: "PG_2", paramDetail: pagedetail.PageDetail{ID: 1, GUID: "DT_2", Title: "History"}, returnDetail: pagedetail.PageDetail{ID: 2, GUID: "DT_2", Title: "History"}},
+			},
+			createPageForkCalls: []createPageForkCall{
+				{paramFork: version.PageFork{SourcePageGUID: "PG_1", PageGUID: "PG_2", DetailGUIDs: map[string]string{"DT_2": "DT_1"}, Base: forkBase}},
+			},
+			returnForks: []version.PageFork{
+				{SourcePageGUID: "PG_1", PageGUID: "PG_2", DetailGUIDs: map[string]string{"DT_2": "DT_1"}, Base: forkBase},
+			},
+		},
+		{
+			name: "test root version",
+			params: ForkPagesParams{
+				Version: version.Version{GUID: "VR_1"},
+				PageIDs: []string{"PG_1"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_1", paramUserID: "UR_1"}},
+			getVersionCalls:     []getVersionCall{{paramVersionGUID: "VR_1", returnVersion: version.Version{ID: 1, GUID: "VR_1", Name: "Default"}}},
+			returnErr:           errors.New("version VR_1 has no parent to fork pages from"),
+		},
+		{
+			name: "test page outside of the parent version",
+			params: ForkPagesParams{
+				Version: version.Version{GUID: "VR_2"},
+				PageIDs: []string{"PG_3"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_1"}},
+			getVersionCalls:     []getVersionCall{{paramVersionGUID: "VR_2", returnVersion: childVersion}},
+			getPageRoleCalls:    []getPageRoleCall{{paramPageGUID: "PG_3", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}},
+			getPageCalls:        []getPageCall{{paramPageGUID: "PG_3", returnPage: page.Page{ID: 3, GUID: "PG_3", Version: version.Version{GUID: "VR_2"}}}},
+			returnErr:           errors.New("page PG_3 is not in the parent version VR_1"),
+		},
+		{
+			name: "test already forked",
+			params: ForkPagesParams{
+				Version: version.Version{GUID: "VR_2"},
+				PageIDs: []string{"PG_1"},
+				UserID:  "UR_1",
+			},
+			canEditVersionCalls:    []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_1"}},
+			getVersionCalls:        []getVersionCall{{paramVersionGUID: "VR_2", returnVersion: childVersion}},
+			getPageRoleCalls:       []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}},
+			getPageCalls:           []getPageCall{{paramPageGUID: "PG_1", returnPage: sourcePage}},
+			getForkedPageGUIDCalls: []getForkedPageGUIDCall{{paramSourcePageGUID: "PG_1", paramVersionGUID: "VR_2", returnPageGUID: "PG_2"}},
+			returnErr:              errors.New("page PG_1 has already been forked into version VR_2"),
+		},
+		{
+			name: "test not the owner of the page",
+			params: ForkPagesParams{
+				Version: version.Version{GUID: "VR_2"},
+				PageIDs: []string{"PG_1"},
+				UserID:  "UR_2",
+			},
+			canEditVersionCalls: []canEditVersionCall{{paramVersionGUID: "VR_2", paramUserID: "UR_2"}},
+			getVersionCalls:     []getVersionCall{{paramVersionGUID: "VR_2", returnVersion: childVersion}},
+			getPageRoleCalls:    []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_2"}},
+			returnErr:           errors.New("User UR_2 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := new(mocks.VersionStore)
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			pageTemplateStore := new(mocks.PageTemplateStore)
+			userStore := new(mocks.UserStore)
+			for index := range tc.canEditVersionCalls {
+				versionStore.On("CanEditVersion", tc.canEditVersionCalls[index].paramVersionGUID, tc.canEditVersionCalls[index].paramUserID).Return(tc.canEditVersionCalls[index].returnErr)
+			}
+			for index := range tc.getVersionCalls {
+				versionStore.On("GetVersion", tc.getVersionCalls[index].paramVersionGUID).Return(tc.getVersionCalls[index].returnVersion, tc.getVersionCalls[index].returnErr)
+			}
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getForkedPageGUIDCalls {
+				versionStore.On("GetForkedPageGUID", tc.getForkedPageGUIDCalls[index].paramSourcePageGUID, tc.getForkedPageGUIDCalls[index].paramVersionGUID).Return(tc.getForkedPageGUIDCalls[index].returnPageGUID, tc.getForkedPageGUIDCalls[index].returnErr)
+			}
+			for index := range tc.getUserCalls {
+				userStore.On("GetUser", tc.getUserCalls[index].paramUserGUID).Return(tc.getUserCalls[index].returnUser, tc.getUserCalls[index].returnErr)
+			}
+			for index := range tc.getPageTemplateCalls {
+				pageTemplateStore.On("GetPageTemplate", tc.getPageTemplateCalls[index].paramPageTemplateGUID).Return(tc.getPageTemplateCalls[index].returnPageTemplate, tc.getPageTemplateCalls[index].returnErr)
+			}
+			for index := range tc.getUniquePageGUIDCalls {
+				pageStore.On("GetUniquePageGUID", tc.getUniquePageGUIDCalls[index].paramProposedGUID).Return(tc.getUniquePageGUIDCalls[index].returnGUID, tc.getUniquePageGUIDCalls[index].returnErr)
+			}
+			for index := range tc.createPageCalls {
+				pageStore.On("CreatePage", tc.createPageCalls[index].paramPage, tc.createPageCalls[index].paramOwnerID).Return(tc.createPageCalls[index].returnPage, tc.createPageCalls[index].returnErr)
+			}
+			for index := range tc.getPagePropertiesCalls {
+				pageStore.On("GetPageProperties", tc.getPagePropertiesCalls[index].paramPageGUID).Return(tc.getPagePropertiesCalls[index].returnProperties, tc.getPagePropertiesCalls[index].returnErr)
+			}
+			for index := range tc.replacePagePropertiesCalls {
+				pageStore.On("ReplacePageProperties", tc.replacePagePropertiesCalls[index].paramPageGUID, tc.replacePagePropertiesCalls[index].paramProperties).Return(tc.replacePagePropertiesCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCalls[index].paramPageGUID).Return(tc.getPageDetailsCalls[index].returnDetails, tc.getPageDetailsCalls[index].returnErr)
+			}
+			for index := range tc.getUniqueDetailGUIDCalls {
+				pageDetailStore.On("GetUniquePageDetailGUID", tc.getUniqueDetailGUIDCalls[index].paramProposedGUID).Return(tc.getUniqueDetailGUIDCalls[index].returnGUID, tc.getUniqueDetailGUIDCalls[index].returnErr)
+			}
+			for index := range tc.createPageDetailCalls {
+				pageDetailStore.On("CreatePageDetail", tc.createPageDetailCalls[index].paramPageGUID, tc.createPageDetailCalls[index].paramDetail).Return(tc.createPageDetailCalls[index].returnDetail, tc.createPageDetailCalls[index].returnErr)
+			}
+			for index := range tc.createPageForkCalls {
+				versionStore.On("CreatePageFork", tc.createPageForkCalls[index].paramFork).Return(tc.createPageForkCalls[index].returnErr)
+			}
+			versionService = VersionService{
+				VersionStore:      versionStore,
+				PageStore:         pageStore,
+				PageDetailStore:   pageDetailStore,
+				PageTemplateStore: pageTemplateStore,
+				UserStore:         userStore,
+			}
+			result, err := versionService.ForkPages(ctx, tc.params)
+			versionStore.AssertNumberOfCalls(t, "CanEditVersion", len(tc.canEditVersionCalls))
+			versionStore.AssertNumberOfCalls(t, "GetVersion", len(tc.getVersionCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			versionStore.AssertNumberOfCalls(t, "GetForkedPageGUID", len(tc.getForkedPageGUIDCalls))
+			userStore.AssertNumberOfCalls(t, "GetUser", len(tc.getUserCalls))
+			pageTemplateStore.AssertNumberOfCalls(t, "GetPageTemplate", len(tc.getPageTemplateCalls))
+			pageStore.AssertNumberOfCalls(t, "GetUniquePageGUID", len(tc.getUniquePageGUIDCalls))
+			pageStore.AssertNumberOfCalls(t, "CreatePage", len(tc.createPageCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageProperties", len(tc.getPagePropertiesCalls))
+			pageStore.AssertNumberOfCalls(t, "ReplacePageProperties", len(tc.replacePagePropertiesCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetUniquePageDetailGUID", len(tc.getUniqueDetailGUIDCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "CreatePageDetail", len(tc.createPageDetailCalls))
+			versionStore.AssertNumberOfCalls(t, "CreatePageFork", len(tc.createPageForkCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnForks, result)
+		})
+	}
+}
+
+type getPageForkCall struct {
+	paramPageGUID string
+	returnFork    version.PageFork
+	returnErr     error
+}
+
+func getPageDiffFixtures() (pagediff.Snapshot, []getPageCall, []getPagePropertiesCall, []getPageDetailsCall) {
+	base := pagediff.Snapshot{
+		Title:      "Barovia",
+		Properties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(500)}},
+		Details:    []pagedetail.PageDetail{{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded by Barov."}}}},
+	}
+	getPageCalls := []getPageCall{
+		{paramPageGUID: "PG_2", returnPage: page.Page{ID: 2, GUID: "PG_2", Version: version.Version{GUID: "VR_2"}, Title: "Barovia Valley"}},
+		{paramPageGUID: "PG_1", returnPage: page.Page{ID: 1, GUID: "PG_1", Version: version.Version{GUID: "VR_1"}, Title: "Barovia", Summary: "A land of mists"}},
+	}
+	getPagePropertiesCalls := []getPagePropertiesCall{
+		{paramPageGUID: "PG_2", returnProperties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(600)}}},
+		{paramPageGUID: "PG_1", returnProperties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(700)}}},
+	}
+	getPageDetailsCalls := []getPageDetailsCall{
+		{paramPageGUID: "PG_2", returnDetails: []pagedetail.PageDetail{{ID: 2, GUID: "DT_2", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded by Barov the Conqueror."}}}}},
+		{paramPageGUID: "PG_1", returnDetails: []pagedetail.PageDetail{{ID: 1, GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded by Barov."}}}}},
+	}
+	return base, getPageCalls, getPagePropertiesCalls, getPageDetailsCalls
+}
+
+func TestDiffPage(t *testing.T) {
+	base, getPageCalls, getPagePropertiesCalls, getPageDetailsCalls := getPageDiffFixtures()
+	fork := version.PageFork{SourcePageGUID: "PG_1", PageGUID: "PG_2", DetailGUIDs: map[string]string{"DT_2": "DT_1"}, Base: &base}
+	cases := []struct {
+		name                   string
+		params                 DiffPageParams
+		getPageRoleCalls       []getPageRoleCall
+		getPageForkCalls       []getPageForkCall
+		getPageCalls           []getPageCall
+		getPagePropertiesCalls []getPagePropertiesCall
+		getPageDetailsCalls    []getPageDetailsCall
+		returnDiff             pagediff.Diff
+		returnErr              error
+	}{
+		{
+			name:   "test happy path",
+			params: DiffPageParams{Page: page.Page{GUID: "PG_2"}, UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleOwner},
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner},
+			},
+			getPageForkCalls:       []getPageForkCall{{paramPageGUID: "PG_2", returnFork: fork}},
+			getPageCalls:           getPageCalls,
+			getPagePropertiesCalls: getPagePropertiesCalls,
+			getPageDetailsCalls:    getPageDetailsCalls,
+			returnDiff: pagediff.Diff{
+				PageGUID:       "PG_2",
+				ParentPageGUID: "PG_1",
+				Changes: []pagediff.Change{
+					{Target: pagediff.TargetTitle, Status: pagediff.StatusChild, Base: "Barovia", Parent: "Barovia", Child: "Barovia Valley"},
+					{Target: pagediff.TargetSummary, Status: pagediff.StatusParent, Base: "", Parent: "A land of mists", Child: ""},
+					{
+						Target: pagediff.TargetProperty,
+						Key:    "population",
+						Status: pagediff.StatusConflict,
+						Base:   base.Properties[0],
+						Parent: getPagePropertiesCalls[1].returnProperties[0],
+						Child:  getPagePropertiesCalls[0].returnProperties[0],
+					},
+					{
+						Target: pagediff.TargetDetail,
+						Key:    "DT_2",
+						Status: pagediff.StatusChild,
+						Base:   base.Details[0],
+						Parent: getPageDetailsCalls[1].returnDetails[0],
+						Child:  getPageDetailsCalls[0].returnDetails[0],
+						Partitions: []pagediff.PartitionDiff{
+							{
+								Op:        pagediff.OpModified,
+								Partition: pagedetail.Partition{TypeString: "p", Value: "Founded by Barov the Conqueror."},
+								Previous:  &pagedetail.Partition{TypeString: "p", Value: "Founded by Barov."},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:             "test page not forked",
+			params:           DiffPageParams{Page: page.Page{GUID: "PG_1"}, UserID: "UR_1"},
+			getPageRoleCalls: []getPageRoleCall{{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}},
+			getPageForkCalls: []getPageForkCall{{paramPageGUID: "PG_1", returnErr: &storeerror.NotFound{ID: "PG_1"}}},
+			returnErr:        errors.New("page PG_1 was not forked from a parent version\nCould not find: PG_1"),
+		},
+		{
+			name:   "test not able to read the parent page",
+			params: DiffPageParams{Page: page.Page{GUID: "PG_2"}, UserID: "UR_2"},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_2", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_2"},
+			},
+			getPageForkCalls: []getPageForkCall{{paramPageGUID: "PG_2", returnFork: fork}},
+			returnErr:        errors.New("User UR_2 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := new(mocks.VersionStore)
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageForkCalls {
+				versionStore.On("GetPageFork", tc.getPageForkCalls[index].paramPageGUID).Return(tc.getPageForkCalls[index].returnFork, tc.getPageForkCalls[index].returnErr)
+			}
+			for index := range tc.getPageCalls {
+				pageStore.On("GetPage", tc.getPageCalls[index].paramPageGUID).Return(tc.getPageCalls[index].returnPage, tc.getPageCalls[index].returnErr)
+			}
+			for index := range tc.getPagePropertiesCalls {
+				pageStore.On("GetPageProperties", tc.getPagePropertiesCalls[index].paramPageGUID).Return(tc.getPagePropertiesCalls[index].returnProperties, tc.getPagePropertiesCalls[index].returnErr)
+			}
+			for index := range tc.getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", tc.getPageDetailsCalls[index].paramPageGUID).Return(tc.getPageDetailsCalls[index].returnDetails, tc.getPageDetailsCalls[index].returnErr)
+			}
+			versionService = VersionService{
+				VersionStore:    versionStore,
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			result, err := versionService.DiffPage(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			versionStore.AssertNumberOfCalls(t, "GetPageFork", len(tc.getPageForkCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPage", len(tc.getPageCalls))
+			pageStore.AssertNumberOfCalls(t, "GetPageProperties", len(tc.getPagePropertiesCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "GetPageDetails", len(tc.getPageDetailsCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnDiff, result)
+		})
+	}
+}
+
+type updatePageCall struct {
+	paramPage page.Page
+	returnErr error
+}
+
+type updatePageDetailCall struct {
+	paramPageGUID string
+	paramDetail   pagedetail.PageDetail
+	returnErr     error
+}
+
+type updatePageForkCall struct {
+	paramFork version.PageFork
+	returnErr error
+}
+
+func TestMergePage(t *testing.T) {
+	base, getPageCalls, getPagePropertiesCalls, getPageDetailsCalls := getPageDiffFixtures()
+	fork := version.PageFork{SourcePageGUID: "PG_1", PageGUID: "PG_2", DetailGUIDs: map[string]string{"DT_2": "DT_1"}, Base: &base}
+	diffGetPageRoleCalls := []getPageRoleCall{
+		{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleOwner},
+		{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner},
+	}
+	mergedDetail := pagedetail.PageDetail{ID: 2, GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded by Barov the Conqueror."}}}
+	mergedBase := &pagediff.Snapshot{
+		Title:      "Barovia Valley",
+		Properties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(600)}},
+		Details:    []pagedetail.PageDetail{{GUID: "DT_1", Title: "History", Partitions: []pagedetail.Partition{{TypeString: "p", Value: "Founded by Barov the Conqueror."}}}},
+	}
+	cases := []struct {
+		name                       string
+		params                     MergePageParams
+		getPageRoleCalls           []getPageRoleCall
+		getPageForkCalls           []getPageForkCall
+		updatePageCalls            []updatePageCall
+		replacePagePropertiesCalls []replacePagePropertiesCall
+		updatePageDetailCalls      []updatePageDetailCall
+		updatePageForkCalls        []updatePageForkCall
+		returnDiff                 pagediff.Diff
+		returnErr                  error
+	}{
+		{
+			name: "test happy path",
+			params: MergePageParams{
+				Page: page.Page{GUID: "PG_2"},
+				Selections: []pagediff.Selection{
+					{Target: pagediff.TargetTitle},
+					{Target: pagediff.TargetProperty, Key: "population", Side: pagediff.SideChild},
+					{Target: pagediff.TargetDetail, Key: "DT_2"},
+				},
+				UserID: "UR_1",
+			},
+			getPageRoleCalls: append(diffGetPageRoleCalls, getPageRoleCall{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}),
+			getPageForkCalls: []getPageForkCall{{paramPageGUID: "PG_2", returnFork: fork}},
+			updatePageCalls: []updatePageCall{
+				{paramPage: page.Page{ID: 1, GUID: "PG_1", Version: version.Version{GUID: "VR_1"}, Title: "Barovia Valley", Summary: "A land of mists"}},
+			},
+			replacePagePropertiesCalls: []replacePagePropertiesCall{
+				{paramPageGUID: "PG_1", paramProperties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(600)}}},
+			},
+			updatePageDetailCalls: []updatePageDetailCall{{paramPageGUID: "PG_1", paramDetail: mergedDetail}},
+			updatePageForkCalls: []updatePageForkCall{
+				{paramFork: version.PageFork{SourcePageGUID: "PG_1", PageGUID: "PG_2", DetailGUIDs: map[string]string{"DT_2": "DT_1"}, Base: mergedBase}},
+			},
+			returnDiff: pagediff.Diff{
+				PageGUID:       "PG_2",
+				ParentPageGUID: "PG_1",
+				Changes: []pagediff.Change{
+					{Target: pagediff.TargetSummary, Status: pagediff.StatusParent, Base: "", Parent: "A land of mists", Child: ""},
+				},
+			},
+		},
+		{
+			name: "test keeping the parent side of a conflict",
+			params: MergePageParams{
+				Page:       page.Page{GUID: "PG_2"},
+				Selections: []pagediff.Selection{{Target: pagediff.TargetProperty, Key: "population", Side: pagediff.SideParent}},
+				UserID:     "UR_1",
+			},
+			getPageRoleCalls: append(diffGetPageRoleCalls, getPageRoleCall{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}),
+			getPageForkCalls: []getPageForkCall{{paramPageGUID: "PG_2", returnFork: fork}},
+			updatePageForkCalls: []updatePageForkCall{
+				{
+					paramFork: version.PageFork{
+						SourcePageGUID: "PG_1",
+						PageGUID:       "PG_2",
+						DetailGUIDs:    map[string]string{"DT_2": "DT_1"},
+						Base: &pagediff.Snapshot{
+							Title:      "Barovia",
+							Properties: []property.Property{{ID: 1, Key: "population", Type: property.TypeNumber, Value: float64(600)}},
+							Details:    base.Details,
+						},
+					},
+				},
+			},
+			returnDiff: pagediff.Diff{
+				PageGUID:       "PG_2",
+				ParentPageGUID: "PG_1",
+				Changes: []pagediff.Change{
+					{Target: pagediff.TargetTitle, Status: pagediff.StatusChild, Base: "Barovia", Parent: "Barovia", Child: "Barovia Valley"},
+					{Target: pagediff.TargetSummary, Status: pagediff.StatusParent, Base: "", Parent: "A land of mists", Child: ""},
+					{
+						Target: pagediff.TargetProperty,
+						Key:    "population",
+						Status: pagediff.StatusParent,
+						Base:   getPagePropertiesCalls[0].returnProperties[0],
+						Parent: getPagePropertiesCalls[1].returnProperties[0],
+						Child:  getPagePropertiesCalls[0].returnProperties[0],
+					},
+					{
+						Target: pagediff.TargetDetail,
+						Key:    "DT_2",
+						Status: pagediff.StatusChild,
+						Base:   base.Details[0],
+						Parent: getPageDetailsCalls[1].returnDetails[0],
+						Child:  getPageDetailsCalls[0].returnDetails[0],
+						Partitions: []pagediff.PartitionDiff{
+							{
+								Op:        pagediff.OpModified,
+								Partition: pagedetail.Partition{TypeString: "p", Value: "Founded by Barov the Conqueror."},
+								Previous:  &pagedetail.Partition{TypeString: "p", Value: "Founded by Barov."},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "test conflict without a side",
+			params: MergePageParams{
+				Page:       page.Page{GUID: "PG_2"},
+				Selections: []pagediff.Selection{{Target: pagediff.TargetProperty, Key: "population"}},
+				UserID:     "UR_1",
+			},
+			getPageRoleCalls: append(diffGetPageRoleCalls, getPageRoleCall{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}),
+			getPageForkCalls: []getPageForkCall{{paramPageGUID: "PG_2", returnFork: fork}},
+			returnErr:        errors.New("the change to property population conflicts with the parent version, so a side must be picked"),
+		},
+		{
+			name: "test change only made in the parent",
+			params: MergePageParams{
+				Page:       page.Page{GUID: "PG_2"},
+				Selections: []pagediff.Selection{{Target: pagediff.TargetSummary}},
+				UserID:     "UR_1",
+			},
+			getPageRoleCalls: append(diffGetPageRoleCalls, getPageRoleCall{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleOwner}),
+			getPageForkCalls: []getPageForkCall{{paramPageGUID: "PG_2", returnFork: fork}},
+			returnErr:        errors.New("the change to summary was only made in the parent version"),
+		},
+		{
+			name: "test not able to edit the parent page",
+			params: MergePageParams{
+				Page:       page.Page{GUID: "PG_2"},
+				Selections: []pagediff.Selection{{Target: pagediff.TargetTitle}},
+				UserID:     "UR_1",
+			},
+			getPageRoleCalls: []getPageRoleCall{
+				{paramPageGUID: "PG_2", paramPageUserID: "UR_1", returnRole: permission.RoleOwner},
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+				{paramPageGUID: "PG_1", paramPageUserID: "UR_1", returnRole: permission.RoleViewer},
+			},
+			getPageForkCalls: []getPageForkCall{{paramPageGUID: "PG_2", returnFork: fork}},
+			returnErr:        errors.New("User UR_1 is not authorized to perform the action on the ID PG_1"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := new(mocks.VersionStore)
+			pageStore := new(mocks.PageStore)
+			pageDetailStore := new(mocks.PageDetailStore)
+			for index := range tc.getPageRoleCalls {
+				pageStore.On("GetPageRole", tc.getPageRoleCalls[index].paramPageGUID, tc.getPageRoleCalls[index].paramPageUserID).Return(tc.getPageRoleCalls[index].returnRole, tc.getPageRoleCalls[index].returnErr)
+			}
+			for index := range tc.getPageForkCalls {
+				versionStore.On("GetPageFork", tc.getPageForkCalls[index].paramPageGUID).Return(tc.getPageForkCalls[index].returnFork, tc.getPageForkCalls[index].returnErr)
+			}
+			for index := range getPageCalls {
+				pageStore.On("GetPage", getPageCalls[index].paramPageGUID).Return(getPageCalls[index].returnPage, getPageCalls[index].returnErr)
+			}
+			for index := range getPagePropertiesCalls {
+				pageStore.On("GetPageProperties", getPagePropertiesCalls[index].paramPageGUID).Return(getPagePropertiesCalls[index].returnProperties, getPagePropertiesCalls[index].returnErr)
+			}
+			for index := range getPageDetailsCalls {
+				pageDetailStore.On("GetPageDetails", getPageDetailsCalls[index].paramPageGUID).Return(getPageDetailsCalls[index].returnDetails, getPageDetailsCalls[index].returnErr)
+			}
+			for index := range tc.updatePageCalls {
+				pageStore.On("UpdatePage", tc.updatePageCalls[index].paramPage).Return(tc.updatePageCalls[index].returnErr)
+			}
+			for index := range tc.replacePagePropertiesCalls {
+				pageStore.On("ReplacePageProperties", tc.replacePagePropertiesCalls[index].paramPageGUID, tc.replacePagePropertiesCalls[index].paramProperties).Return(tc.replacePagePropertiesCalls[index].returnErr)
+			}
+			for index := range tc.updatePageDetailCalls {
+				pageDetailStore.On("UpdatePageDetail", tc.updatePageDetailCalls[index].paramPageGUID, tc.updatePageDetailCalls[index].paramDetail).Return(tc.updatePageDetailCalls[index].returnErr)
+			}
+			for index := range tc.updatePageForkCalls {
+				versionStore.On("UpdatePageFork", tc.updatePageForkCalls[index].paramFork).Return(tc.updatePageForkCalls[index].returnErr)
+			}
+			versionService = VersionService{
+				VersionStore:    versionStore,
+				PageStore:       pageStore,
+				PageDetailStore: pageDetailStore,
+			}
+			result, err := versionService.MergePage(ctx, tc.params)
+			pageStore.AssertNumberOfCalls(t, "GetPageRole", len(tc.getPageRoleCalls))
+			versionStore.AssertNumberOfCalls(t, "GetPageFork", len(tc.getPageForkCalls))
+			pageStore.AssertNumberOfCalls(t, "UpdatePage", len(tc.updatePageCalls))
+			pageStore.AssertNumberOfCalls(t, "ReplacePageProperties", len(tc.replacePagePropertiesCalls))
+			pageDetailStore.AssertNumberOfCalls(t, "UpdatePageDetail", len(tc.updatePageDetailCalls))
+			versionStore.AssertNumberOfCalls(t, "UpdatePageFork", len(tc.updatePageForkCalls))
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnDiff, result)
+		})
+	}
+}
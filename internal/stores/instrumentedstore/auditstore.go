@@ -0,0 +1,42 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// AuditStore records the latency and errors of every call to the store.AuditStore it wraps.
+type AuditStore struct {
+	store    store.AuditStore
+	observer Observer
+}
+
+var _ store.AuditStore = AuditStore{}
+
+// NewAuditStore returns a AuditStore that records the calls to auditStore with the observer.
+func NewAuditStore(auditStore store.AuditStore, observer Observer) AuditStore {
+	return AuditStore{
+		store:    auditStore,
+		observer: observer,
+	}
+}
+
+// GetUniqueAuditEntryGUID see store.AuditStore
+func (s AuditStore) GetUniqueAuditEntryGUID(proposedAuditEntryGUID string) (_ string, err error) {
+	defer observe(s.observer, "AuditStore", "GetUniqueAuditEntryGUID", time.Now(), &err)
+	return s.store.GetUniqueAuditEntryGUID(proposedAuditEntryGUID)
+}
+
+// CreateAuditEntry see store.AuditStore
+func (s AuditStore) CreateAuditEntry(record audit.Entry) (_ audit.Entry, err error) {
+	defer observe(s.observer, "AuditStore", "CreateAuditEntry", time.Now(), &err)
+	return s.store.CreateAuditEntry(record)
+}
+
+// GetAuditEntries see store.AuditStore
+func (s AuditStore) GetAuditEntries(filter audit.Filter, startGUID string, limit int) (_ []audit.Entry, _ string, err error) {
+	defer observe(s.observer, "AuditStore", "GetAuditEntries", time.Now(), &err)
+	return s.store.GetAuditEntries(filter, startGUID, limit)
+}
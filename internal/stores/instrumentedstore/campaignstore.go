@@ -0,0 +1,96 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// CampaignStore records the latency and errors of every call to the store.CampaignStore it wraps.
+type CampaignStore struct {
+	store    store.CampaignStore
+	observer Observer
+}
+
+var _ store.CampaignStore = CampaignStore{}
+
+// NewCampaignStore returns a CampaignStore that records the calls to campaignStore with the observer.
+func NewCampaignStore(campaignStore store.CampaignStore, observer Observer) CampaignStore {
+	return CampaignStore{
+		store:    campaignStore,
+		observer: observer,
+	}
+}
+
+// GetUniqueCampaignGUID see store.CampaignStore
+func (s CampaignStore) GetUniqueCampaignGUID(proposedCampaignGUID string) (_ string, err error) {
+	defer observe(s.observer, "CampaignStore", "GetUniqueCampaignGUID", time.Now(), &err)
+	return s.store.GetUniqueCampaignGUID(proposedCampaignGUID)
+}
+
+// CreateCampaign see store.CampaignStore
+func (s CampaignStore) CreateCampaign(record campaign.Campaign, ownerID int64) (_ campaign.Campaign, err error) {
+	defer observe(s.observer, "CampaignStore", "CreateCampaign", time.Now(), &err)
+	return s.store.CreateCampaign(record, ownerID)
+}
+
+// GetCampaignRole see store.CampaignStore
+func (s CampaignStore) GetCampaignRole(campaignGUID, userID string) (_ campaign.Role, err error) {
+	defer observe(s.observer, "CampaignStore", "GetCampaignRole", time.Now(), &err)
+	return s.store.GetCampaignRole(campaignGUID, userID)
+}
+
+// GetCampaign see store.CampaignStore
+func (s CampaignStore) GetCampaign(campaignGUID string) (_ campaign.Campaign, err error) {
+	defer observe(s.observer, "CampaignStore", "GetCampaign", time.Now(), &err)
+	return s.store.GetCampaign(campaignGUID)
+}
+
+// GetCampaigns see store.CampaignStore
+func (s CampaignStore) GetCampaigns(userID string) (_ []campaign.Campaign, err error) {
+	defer observe(s.observer, "CampaignStore", "GetCampaigns", time.Now(), &err)
+	return s.store.GetCampaigns(userID)
+}
+
+// UpdateCampaign see store.CampaignStore
+func (s CampaignStore) UpdateCampaign(record campaign.Campaign) (err error) {
+	defer observe(s.observer, "CampaignStore", "UpdateCampaign", time.Now(), &err)
+	return s.store.UpdateCampaign(record)
+}
+
+// RemoveCampaign see store.CampaignStore
+func (s CampaignStore) RemoveCampaign(campaignGUID string) (err error) {
+	defer observe(s.observer, "CampaignStore", "RemoveCampaign", time.Now(), &err)
+	return s.store.RemoveCampaign(campaignGUID)
+}
+
+// GetCampaignMembers see store.CampaignStore
+func (s CampaignStore) GetCampaignMembers(campaignGUID string) (_ []campaign.Member, err error) {
+	defer observe(s.observer, "CampaignStore", "GetCampaignMembers", time.Now(), &err)
+	return s.store.GetCampaignMembers(campaignGUID)
+}
+
+// SetCampaignMember see store.CampaignStore
+func (s CampaignStore) SetCampaignMember(campaignGUID string, userID int64, role campaign.Role) (err error) {
+	defer observe(s.observer, "CampaignStore", "SetCampaignMember", time.Now(), &err)
+	return s.store.SetCampaignMember(campaignGUID, userID, role)
+}
+
+// RemoveCampaignMember see store.CampaignStore
+func (s CampaignStore) RemoveCampaignMember(campaignGUID, userID string) (err error) {
+	defer observe(s.observer, "CampaignStore", "RemoveCampaignMember", time.Now(), &err)
+	return s.store.RemoveCampaignMember(campaignGUID, userID)
+}
+
+// AddCampaignPage see store.CampaignStore
+func (s CampaignStore) AddCampaignPage(campaignGUID, pageGUID string) (err error) {
+	defer observe(s.observer, "CampaignStore", "AddCampaignPage", time.Now(), &err)
+	return s.store.AddCampaignPage(campaignGUID, pageGUID)
+}
+
+// RemoveCampaignPage see store.CampaignStore
+func (s CampaignStore) RemoveCampaignPage(campaignGUID, pageGUID string) (err error) {
+	defer observe(s.observer, "CampaignStore", "RemoveCampaignPage", time.Now(), &err)
+	return s.store.RemoveCampaignPage(campaignGUID, pageGUID)
+}
@@ -0,0 +1,29 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// HealthcheckStore records the latency and errors of every call to the store.HealthcheckStore it wraps.
+type HealthcheckStore struct {
+	store    store.HealthcheckStore
+	observer Observer
+}
+
+var _ store.HealthcheckStore = HealthcheckStore{}
+
+// NewHealthcheckStore returns a HealthcheckStore that records the calls to healthcheckStore with the observer.
+func NewHealthcheckStore(healthcheckStore store.HealthcheckStore, observer Observer) HealthcheckStore {
+	return HealthcheckStore{
+		store:    healthcheckStore,
+		observer: observer,
+	}
+}
+
+// IsHealthy see store.HealthcheckStore
+func (s HealthcheckStore) IsHealthy() (_ bool, err error) {
+	defer observe(s.observer, "HealthcheckStore", "IsHealthy", time.Now(), &err)
+	return s.store.IsHealthy()
+}
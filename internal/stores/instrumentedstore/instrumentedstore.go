@@ -0,0 +1,14 @@
+// Package instrumentedstore wraps the stores to record the latency and errors of every call to them, such as for metrics.
+package instrumentedstore
+
+import "time"
+
+// Observer records the calls to the stores.
+type Observer interface {
+	ObserveStoreCall(store, method string, latency time.Duration, err error)
+}
+
+// observe records the call to the store's method that started at start. It is deferred, so it takes the err returned by the call.
+func observe(observer Observer, store, method string, start time.Time, err *error) {
+	observer.ObserveStoreCall(store, method, time.Since(start), *err)
+}
@@ -0,0 +1,61 @@
+package instrumentedstore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store/mocks"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type observedCall struct {
+	store  string
+	method string
+	err    error
+}
+
+type testObserver struct {
+	calls []observedCall
+}
+
+func (o *testObserver) ObserveStoreCall(store, method string, latency time.Duration, err error) {
+	o.calls = append(o.calls, observedCall{store: store, method: method, err: err})
+}
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		name          string
+		returnPage    page.Page
+		returnErr     error
+		expectedCalls []observedCall
+	}{
+		{
+			name:          "test successful call",
+			returnPage:    page.Page{GUID: "PG_1"},
+			expectedCalls: []observedCall{{store: "PageStore", method: "GetPage"}},
+		},
+		{
+			name:          "test failed call",
+			returnErr:     errors.New("failure"),
+			expectedCalls: []observedCall{{store: "PageStore", method: "GetPage", err: errors.New("failure")}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := new(mocks.PageStore)
+			pageStore.On("GetPage", "PG_1").Return(tc.returnPage, tc.returnErr)
+			observer := &testObserver{}
+			result, err := NewPageStore(pageStore, observer).GetPage("PG_1")
+			pageStore.AssertNumberOfCalls(t, "GetPage", 1)
+			require.Equal(t, tc.expectedCalls, observer.calls)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnPage, result)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// PageDetailStore records the latency and errors of every call to the store.PageDetailStore it wraps.
+type PageDetailStore struct {
+	store    store.PageDetailStore
+	observer Observer
+}
+
+var _ store.PageDetailStore = PageDetailStore{}
+
+// NewPageDetailStore returns a PageDetailStore that records the calls to pageDetailStore with the observer.
+func NewPageDetailStore(pageDetailStore store.PageDetailStore, observer Observer) PageDetailStore {
+	return PageDetailStore{
+		store:    pageDetailStore,
+		observer: observer,
+	}
+}
+
+// GetUniquePageDetailGUID see store.PageDetailStore
+func (s PageDetailStore) GetUniquePageDetailGUID(proposedPageDetailGUID string) (_ string, err error) {
+	defer observe(s.observer, "PageDetailStore", "GetUniquePageDetailGUID", time.Now(), &err)
+	return s.store.GetUniquePageDetailGUID(proposedPageDetailGUID)
+}
+
+// CreatePageDetail see store.PageDetailStore
+func (s PageDetailStore) CreatePageDetail(pageGUID string, record pagedetail.PageDetail) (_ pagedetail.PageDetail, err error) {
+	defer observe(s.observer, "PageDetailStore", "CreatePageDetail", time.Now(), &err)
+	return s.store.CreatePageDetail(pageGUID, record)
+}
+
+// GetPageDetail see store.PageDetailStore
+func (s PageDetailStore) GetPageDetail(pageGUID, pageDetailGUID string) (_ pagedetail.PageDetail, err error) {
+	defer observe(s.observer, "PageDetailStore", "GetPageDetail", time.Now(), &err)
+	return s.store.GetPageDetail(pageGUID, pageDetailGUID)
+}
+
+// GetPageDetails see store.PageDetailStore
+func (s PageDetailStore) GetPageDetails(pageGUID string) (_ []pagedetail.PageDetail, err error) {
+	defer observe(s.observer, "PageDetailStore", "GetPageDetails", time.Now(), &err)
+	return s.store.GetPageDetails(pageGUID)
+}
+
+// UpdatePageDetail see store.PageDetailStore
+func (s PageDetailStore) UpdatePageDetail(pageGUID string, record pagedetail.PageDetail) (err error) {
+	defer observe(s.observer, "PageDetailStore", "UpdatePageDetail", time.Now(), &err)
+	return s.store.UpdatePageDetail(pageGUID, record)
+}
+
+// RemovePageDetail see store.PageDetailStore
+func (s PageDetailStore) RemovePageDetail(pageGUID, pageDetailGUID string) (err error) {
+	defer observe(s.observer, "PageDetailStore", "RemovePageDetail", time.Now(), &err)
+	return s.store.RemovePageDetail(pageGUID, pageDetailGUID)
+}
+
+// ReorderPageDetails see store.PageDetailStore
+func (s PageDetailStore) ReorderPageDetails(pageGUID string, pageDetailGUIDs []string) (err error) {
+	defer observe(s.observer, "PageDetailStore", "ReorderPageDetails", time.Now(), &err)
+	return s.store.ReorderPageDetails(pageGUID, pageDetailGUIDs)
+}
@@ -0,0 +1,130 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// PageStore records the latency and errors of every call to the store.PageStore it wraps.
+type PageStore struct {
+	store    store.PageStore
+	observer Observer
+}
+
+var _ store.PageStore = PageStore{}
+
+// NewPageStore returns a PageStore that records the calls to pageStore with the observer.
+func NewPageStore(pageStore store.PageStore, observer Observer) PageStore {
+	return PageStore{
+		store:    pageStore,
+		observer: observer,
+	}
+}
+
+// GetUniquePageGUID see store.PageStore
+func (s PageStore) GetUniquePageGUID(proposedPageGUID string) (_ string, err error) {
+	defer observe(s.observer, "PageStore", "GetUniquePageGUID", time.Now(), &err)
+	return s.store.GetUniquePageGUID(proposedPageGUID)
+}
+
+// GetPageRole see store.PageStore
+func (s PageStore) GetPageRole(pageGUID, userID string) (_ permission.Role, err error) {
+	defer observe(s.observer, "PageStore", "GetPageRole", time.Now(), &err)
+	return s.store.GetPageRole(pageGUID, userID)
+}
+
+// GetPageOwnerGUID see store.PageStore
+func (s PageStore) GetPageOwnerGUID(pageGUID string) (_ string, err error) {
+	defer observe(s.observer, "PageStore", "GetPageOwnerGUID", time.Now(), &err)
+	return s.store.GetPageOwnerGUID(pageGUID)
+}
+
+// UpdatePage see store.PageStore
+func (s PageStore) UpdatePage(record page.Page) (err error) {
+	defer observe(s.observer, "PageStore", "UpdatePage", time.Now(), &err)
+	return s.store.UpdatePage(record)
+}
+
+// CreatePage see store.PageStore
+func (s PageStore) CreatePage(record page.Page, ownerID int64) (_ page.Page, err error) {
+	defer observe(s.observer, "PageStore", "CreatePage", time.Now(), &err)
+	return s.store.CreatePage(record, ownerID)
+}
+
+// GetPage see store.PageStore
+func (s PageStore) GetPage(pageGUID string) (_ page.Page, err error) {
+	defer observe(s.observer, "PageStore", "GetPage", time.Now(), &err)
+	return s.store.GetPage(pageGUID)
+}
+
+// GetPages see store.PageStore
+func (s PageStore) GetPages(userID string, filter pagefilter.Filter, sort pagesort.Sort, cursor pagesort.Cursor, limit int) (_ []page.Page, _ int, _ pagesort.Cursor, err error) {
+	defer observe(s.observer, "PageStore", "GetPages", time.Now(), &err)
+	return s.store.GetPages(userID, filter, sort, cursor, limit)
+}
+
+// GetPageFacets see store.PageStore
+func (s PageStore) GetPageFacets(userID string, filter pagefilter.Filter) (_ pagefilter.Facets, err error) {
+	defer observe(s.observer, "PageStore", "GetPageFacets", time.Now(), &err)
+	return s.store.GetPageFacets(userID, filter)
+}
+
+// RemovePage see store.PageStore
+func (s PageStore) RemovePage(pageGUID string) (err error) {
+	defer observe(s.observer, "PageStore", "RemovePage", time.Now(), &err)
+	return s.store.RemovePage(pageGUID)
+}
+
+// GetRemovedPages see store.PageStore
+func (s PageStore) GetRemovedPages(userID string) (_ []page.Page, err error) {
+	defer observe(s.observer, "PageStore", "GetRemovedPages", time.Now(), &err)
+	return s.store.GetRemovedPages(userID)
+}
+
+// GetRemovedPageGUIDs see store.PageStore
+func (s PageStore) GetRemovedPageGUIDs(removedBefore time.Time) (_ []string, err error) {
+	defer observe(s.observer, "PageStore", "GetRemovedPageGUIDs", time.Now(), &err)
+	return s.store.GetRemovedPageGUIDs(removedBefore)
+}
+
+// RestorePage see store.PageStore
+func (s PageStore) RestorePage(pageGUID string) (err error) {
+	defer observe(s.observer, "PageStore", "RestorePage", time.Now(), &err)
+	return s.store.RestorePage(pageGUID)
+}
+
+// PurgePage see store.PageStore
+func (s PageStore) PurgePage(pageGUID string) (err error) {
+	defer observe(s.observer, "PageStore", "PurgePage", time.Now(), &err)
+	return s.store.PurgePage(pageGUID)
+}
+
+// GetAllPageGUIDs see store.PageStore
+func (s PageStore) GetAllPageGUIDs() (_ []string, err error) {
+	defer observe(s.observer, "PageStore", "GetAllPageGUIDs", time.Now(), &err)
+	return s.store.GetAllPageGUIDs()
+}
+
+// GetUserPageGUIDs see store.PageStore
+func (s PageStore) GetUserPageGUIDs(userID string) (_ []string, err error) {
+	defer observe(s.observer, "PageStore", "GetUserPageGUIDs", time.Now(), &err)
+	return s.store.GetUserPageGUIDs(userID)
+}
+
+// GetPageProperties see store.PageStore
+func (s PageStore) GetPageProperties(pageGUID string) (_ []property.Property, err error) {
+	defer observe(s.observer, "PageStore", "GetPageProperties", time.Now(), &err)
+	return s.store.GetPageProperties(pageGUID)
+}
+
+// ReplacePageProperties see store.PageStore
+func (s PageStore) ReplacePageProperties(pageGUID string, pageProperties []property.Property) (err error) {
+	defer observe(s.observer, "PageStore", "ReplacePageProperties", time.Now(), &err)
+	return s.store.ReplacePageProperties(pageGUID, pageProperties)
+}
@@ -0,0 +1,66 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// PageTemplateStore records the latency and errors of every call to the store.PageTemplateStore it wraps.
+type PageTemplateStore struct {
+	store    store.PageTemplateStore
+	observer Observer
+}
+
+var _ store.PageTemplateStore = PageTemplateStore{}
+
+// NewPageTemplateStore returns a PageTemplateStore that records the calls to pageTemplateStore with the observer.
+func NewPageTemplateStore(pageTemplateStore store.PageTemplateStore, observer Observer) PageTemplateStore {
+	return PageTemplateStore{
+		store:    pageTemplateStore,
+		observer: observer,
+	}
+}
+
+// GetUniquePageTemplateGUID see store.PageTemplateStore
+func (s PageTemplateStore) GetUniquePageTemplateGUID(proposedPageTemplateGUID string) (_ string, err error) {
+	defer observe(s.observer, "PageTemplateStore", "GetUniquePageTemplateGUID", time.Now(), &err)
+	return s.store.GetUniquePageTemplateGUID(proposedPageTemplateGUID)
+}
+
+// CreatePageTemplate see store.PageTemplateStore
+func (s PageTemplateStore) CreatePageTemplate(record pagetemplate.PageTemplate, ownerID int64) (_ pagetemplate.PageTemplate, err error) {
+	defer observe(s.observer, "PageTemplateStore", "CreatePageTemplate", time.Now(), &err)
+	return s.store.CreatePageTemplate(record, ownerID)
+}
+
+// CanEditPageTemplate see store.PageTemplateStore
+func (s PageTemplateStore) CanEditPageTemplate(pageTemplateGUID, userID string) (err error) {
+	defer observe(s.observer, "PageTemplateStore", "CanEditPageTemplate", time.Now(), &err)
+	return s.store.CanEditPageTemplate(pageTemplateGUID, userID)
+}
+
+// GetPageTemplate see store.PageTemplateStore
+func (s PageTemplateStore) GetPageTemplate(pageTemplateGUID string) (_ pagetemplate.PageTemplate, err error) {
+	defer observe(s.observer, "PageTemplateStore", "GetPageTemplate", time.Now(), &err)
+	return s.store.GetPageTemplate(pageTemplateGUID)
+}
+
+// GetPageTemplates see store.PageTemplateStore
+func (s PageTemplateStore) GetPageTemplates(userID string) (_ []pagetemplate.PageTemplate, err error) {
+	defer observe(s.observer, "PageTemplateStore", "GetPageTemplates", time.Now(), &err)
+	return s.store.GetPageTemplates(userID)
+}
+
+// UpdatePageTemplate see store.PageTemplateStore
+func (s PageTemplateStore) UpdatePageTemplate(record pagetemplate.PageTemplate) (err error) {
+	defer observe(s.observer, "PageTemplateStore", "UpdatePageTemplate", time.Now(), &err)
+	return s.store.UpdatePageTemplate(record)
+}
+
+// SetPageTemplateDisabled see store.PageTemplateStore
+func (s PageTemplateStore) SetPageTemplateDisabled(pageTemplateGUID string, isDisabled bool) (err error) {
+	defer observe(s.observer, "PageTemplateStore", "SetPageTemplateDisabled", time.Now(), &err)
+	return s.store.SetPageTemplateDisabled(pageTemplateGUID, isDisabled)
+}
@@ -0,0 +1,60 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// PropertyStore records the latency and errors of every call to the store.PropertyStore it wraps.
+type PropertyStore struct {
+	store    store.PropertyStore
+	observer Observer
+}
+
+var _ store.PropertyStore = PropertyStore{}
+
+// NewPropertyStore returns a PropertyStore that records the calls to propertyStore with the observer.
+func NewPropertyStore(propertyStore store.PropertyStore, observer Observer) PropertyStore {
+	return PropertyStore{
+		store:    propertyStore,
+		observer: observer,
+	}
+}
+
+// CreateProperty see store.PropertyStore
+func (s PropertyStore) CreateProperty(record property.Property, ownerID int64) (_ property.Property, err error) {
+	defer observe(s.observer, "PropertyStore", "CreateProperty", time.Now(), &err)
+	return s.store.CreateProperty(record, ownerID)
+}
+
+// GetProperty see store.PropertyStore
+func (s PropertyStore) GetProperty(key, userID string) (_ property.Property, err error) {
+	defer observe(s.observer, "PropertyStore", "GetProperty", time.Now(), &err)
+	return s.store.GetProperty(key, userID)
+}
+
+// GetProperties see store.PropertyStore
+func (s PropertyStore) GetProperties(userID string) (_ []property.Property, err error) {
+	defer observe(s.observer, "PropertyStore", "GetProperties", time.Now(), &err)
+	return s.store.GetProperties(userID)
+}
+
+// UpdateProperty see store.PropertyStore
+func (s PropertyStore) UpdateProperty(record property.Property) (err error) {
+	defer observe(s.observer, "PropertyStore", "UpdateProperty", time.Now(), &err)
+	return s.store.UpdateProperty(record)
+}
+
+// SetPropertyDisabled see store.PropertyStore
+func (s PropertyStore) SetPropertyDisabled(propertyID int64, isDisabled bool) (err error) {
+	defer observe(s.observer, "PropertyStore", "SetPropertyDisabled", time.Now(), &err)
+	return s.store.SetPropertyDisabled(propertyID, isDisabled)
+}
+
+// IsPropertyInUse see store.PropertyStore
+func (s PropertyStore) IsPropertyInUse(propertyID int64) (_ bool, err error) {
+	defer observe(s.observer, "PropertyStore", "IsPropertyInUse", time.Now(), &err)
+	return s.store.IsPropertyInUse(propertyID)
+}
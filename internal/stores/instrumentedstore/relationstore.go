@@ -0,0 +1,42 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// RelationStore records the latency and errors of every call to the store.RelationStore it wraps.
+type RelationStore struct {
+	store    store.RelationStore
+	observer Observer
+}
+
+var _ store.RelationStore = RelationStore{}
+
+// NewRelationStore returns a RelationStore that records the calls to relationStore with the observer.
+func NewRelationStore(relationStore store.RelationStore, observer Observer) RelationStore {
+	return RelationStore{
+		store:    relationStore,
+		observer: observer,
+	}
+}
+
+// GetBacklinks see store.RelationStore
+func (s RelationStore) GetBacklinks(pageGUID string) (_ []relation.Backlink, err error) {
+	defer observe(s.observer, "RelationStore", "GetBacklinks", time.Now(), &err)
+	return s.store.GetBacklinks(pageGUID)
+}
+
+// GetEdges see store.RelationStore
+func (s RelationStore) GetEdges(pageGUIDs []string) (_ []relation.Edge, err error) {
+	defer observe(s.observer, "RelationStore", "GetEdges", time.Now(), &err)
+	return s.store.GetEdges(pageGUIDs)
+}
+
+// GetLinks see store.RelationStore
+func (s RelationStore) GetLinks(userID string) (_ []relation.Link, err error) {
+	defer observe(s.observer, "RelationStore", "GetLinks", time.Now(), &err)
+	return s.store.GetLinks(userID)
+}
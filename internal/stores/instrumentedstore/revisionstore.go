@@ -0,0 +1,54 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// RevisionStore records the latency and errors of every call to the store.RevisionStore it wraps.
+type RevisionStore struct {
+	store    store.RevisionStore
+	observer Observer
+}
+
+var _ store.RevisionStore = RevisionStore{}
+
+// NewRevisionStore returns a RevisionStore that records the calls to revisionStore with the observer.
+func NewRevisionStore(revisionStore store.RevisionStore, observer Observer) RevisionStore {
+	return RevisionStore{
+		store:    revisionStore,
+		observer: observer,
+	}
+}
+
+// GetUniqueRevisionGUID see store.RevisionStore
+func (s RevisionStore) GetUniqueRevisionGUID(proposedRevisionGUID string) (_ string, err error) {
+	defer observe(s.observer, "RevisionStore", "GetUniqueRevisionGUID", time.Now(), &err)
+	return s.store.GetUniqueRevisionGUID(proposedRevisionGUID)
+}
+
+// CreateRevision see store.RevisionStore
+func (s RevisionStore) CreateRevision(record revision.Revision) (_ revision.Revision, err error) {
+	defer observe(s.observer, "RevisionStore", "CreateRevision", time.Now(), &err)
+	return s.store.CreateRevision(record)
+}
+
+// GetRevisions see store.RevisionStore
+func (s RevisionStore) GetRevisions(pageGUID string) (_ []revision.Revision, err error) {
+	defer observe(s.observer, "RevisionStore", "GetRevisions", time.Now(), &err)
+	return s.store.GetRevisions(pageGUID)
+}
+
+// GetRevision see store.RevisionStore
+func (s RevisionStore) GetRevision(pageGUID, revisionGUID string) (_ revision.Revision, err error) {
+	defer observe(s.observer, "RevisionStore", "GetRevision", time.Now(), &err)
+	return s.store.GetRevision(pageGUID, revisionGUID)
+}
+
+// HasRevisions see store.RevisionStore
+func (s RevisionStore) HasRevisions(pageGUID string) (_ bool, err error) {
+	defer observe(s.observer, "RevisionStore", "HasRevisions", time.Now(), &err)
+	return s.store.HasRevisions(pageGUID)
+}
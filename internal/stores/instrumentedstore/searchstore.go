@@ -0,0 +1,42 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/search"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// SearchStore records the latency and errors of every call to the store.SearchStore it wraps.
+type SearchStore struct {
+	store    store.SearchStore
+	observer Observer
+}
+
+var _ store.SearchStore = SearchStore{}
+
+// NewSearchStore returns a SearchStore that records the calls to searchStore with the observer.
+func NewSearchStore(searchStore store.SearchStore, observer Observer) SearchStore {
+	return SearchStore{
+		store:    searchStore,
+		observer: observer,
+	}
+}
+
+// IndexPage see store.SearchStore
+func (s SearchStore) IndexPage(document search.Document) (err error) {
+	defer observe(s.observer, "SearchStore", "IndexPage", time.Now(), &err)
+	return s.store.IndexPage(document)
+}
+
+// RemovePage see store.SearchStore
+func (s SearchStore) RemovePage(pageGUID string) (err error) {
+	defer observe(s.observer, "SearchStore", "RemovePage", time.Now(), &err)
+	return s.store.RemovePage(pageGUID)
+}
+
+// Search see store.SearchStore
+func (s SearchStore) Search(query string, filter search.Filter, limit int) (_ []search.Result, err error) {
+	defer observe(s.observer, "SearchStore", "Search", time.Now(), &err)
+	return s.store.Search(query, filter, limit)
+}
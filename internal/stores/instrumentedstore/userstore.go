@@ -0,0 +1,30 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/appuser"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// UserStore records the latency and errors of every call to the store.UserStore it wraps.
+type UserStore struct {
+	store    store.UserStore
+	observer Observer
+}
+
+var _ store.UserStore = UserStore{}
+
+// NewUserStore returns a UserStore that records the calls to userStore with the observer.
+func NewUserStore(userStore store.UserStore, observer Observer) UserStore {
+	return UserStore{
+		store:    userStore,
+		observer: observer,
+	}
+}
+
+// GetUser see store.UserStore
+func (s UserStore) GetUser(userGUID string) (_ appuser.User, err error) {
+	defer observe(s.observer, "UserStore", "GetUser", time.Now(), &err)
+	return s.store.GetUser(userGUID)
+}
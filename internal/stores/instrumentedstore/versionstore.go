@@ -0,0 +1,102 @@
+package instrumentedstore
+
+import (
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/store"
+)
+
+// VersionStore records the latency and errors of every call to the store.VersionStore it wraps.
+type VersionStore struct {
+	store    store.VersionStore
+	observer Observer
+}
+
+var _ store.VersionStore = VersionStore{}
+
+// NewVersionStore returns a VersionStore that records the calls to versionStore with the observer.
+func NewVersionStore(versionStore store.VersionStore, observer Observer) VersionStore {
+	return VersionStore{
+		store:    versionStore,
+		observer: observer,
+	}
+}
+
+// GetUniqueVersionGUID see store.VersionStore
+func (s VersionStore) GetUniqueVersionGUID(proposedVersionGUID string) (_ string, err error) {
+	defer observe(s.observer, "VersionStore", "GetUniqueVersionGUID", time.Now(), &err)
+	return s.store.GetUniqueVersionGUID(proposedVersionGUID)
+}
+
+// CreateVersion see store.VersionStore
+func (s VersionStore) CreateVersion(record version.Version, ownerID int64) (_ version.Version, err error) {
+	defer observe(s.observer, "VersionStore", "CreateVersion", time.Now(), &err)
+	return s.store.CreateVersion(record, ownerID)
+}
+
+// CanEditVersion see store.VersionStore
+func (s VersionStore) CanEditVersion(versionGUID, userID string) (err error) {
+	defer observe(s.observer, "VersionStore", "CanEditVersion", time.Now(), &err)
+	return s.store.CanEditVersion(versionGUID, userID)
+}
+
+// GetVersion see store.VersionStore
+func (s VersionStore) GetVersion(versionGUID string) (_ version.Version, err error) {
+	defer observe(s.observer, "VersionStore", "GetVersion", time.Now(), &err)
+	return s.store.GetVersion(versionGUID)
+}
+
+// GetVersions see store.VersionStore
+func (s VersionStore) GetVersions(userID string) (_ []version.Version, err error) {
+	defer observe(s.observer, "VersionStore", "GetVersions", time.Now(), &err)
+	return s.store.GetVersions(userID)
+}
+
+// UpdateVersion see store.VersionStore
+func (s VersionStore) UpdateVersion(record version.Version) (err error) {
+	defer observe(s.observer, "VersionStore", "UpdateVersion", time.Now(), &err)
+	return s.store.UpdateVersion(record)
+}
+
+// RemoveVersion see store.VersionStore
+func (s VersionStore) RemoveVersion(versionGUID string) (err error) {
+	defer observe(s.observer, "VersionStore", "RemoveVersion", time.Now(), &err)
+	return s.store.RemoveVersion(versionGUID)
+}
+
+// HasChildVersions see store.VersionStore
+func (s VersionStore) HasChildVersions(versionGUID string) (_ bool, err error) {
+	defer observe(s.observer, "VersionStore", "HasChildVersions", time.Now(), &err)
+	return s.store.HasChildVersions(versionGUID)
+}
+
+// HasVersionPages see store.VersionStore
+func (s VersionStore) HasVersionPages(versionGUID string) (_ bool, err error) {
+	defer observe(s.observer, "VersionStore", "HasVersionPages", time.Now(), &err)
+	return s.store.HasVersionPages(versionGUID)
+}
+
+// CreatePageFork see store.VersionStore
+func (s VersionStore) CreatePageFork(fork version.PageFork) (err error) {
+	defer observe(s.observer, "VersionStore", "CreatePageFork", time.Now(), &err)
+	return s.store.CreatePageFork(fork)
+}
+
+// GetForkedPageGUID see store.VersionStore
+func (s VersionStore) GetForkedPageGUID(sourcePageGUID, versionGUID string) (_ string, err error) {
+	defer observe(s.observer, "VersionStore", "GetForkedPageGUID", time.Now(), &err)
+	return s.store.GetForkedPageGUID(sourcePageGUID, versionGUID)
+}
+
+// GetPageFork see store.VersionStore
+func (s VersionStore) GetPageFork(pageGUID string) (_ version.PageFork, err error) {
+	defer observe(s.observer, "VersionStore", "GetPageFork", time.Now(), &err)
+	return s.store.GetPageFork(pageGUID)
+}
+
+// UpdatePageFork see store.VersionStore
+func (s VersionStore) UpdatePageFork(fork version.PageFork) (err error) {
+	defer observe(s.observer, "VersionStore", "UpdatePageFork", time.Now(), &err)
+	return s.store.UpdatePageFork(fork)
+}
@@ -0,0 +1,267 @@
+package memorystore
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/search"
+	"github.com/pkg/errors"
+)
+
+// The weight of a matched term in each field, so that matching titles rank above matching details.
+var fieldWeights = map[search.Field]float64{
+	search.FieldTitle:   3,
+	search.FieldSummary: 2,
+	search.FieldDetail:  1,
+}
+
+const (
+	maxSnippets = 3
+	// snippetLength is the most characters of a text included in a snippet.
+	snippetLength = 160
+	// snippetLead is the number of characters kept before the first match when a text is cut for a snippet.
+	snippetLead = 40
+	snippetCut  = "…"
+)
+
+// SearchStore is an in-process full-text index of pages.
+type SearchStore struct {
+	mutex     *sync.RWMutex
+	documents map[string]indexedDocument
+	// postings maps each term to the pages it appears in.
+	postings map[string]map[string]bool
+}
+
+type indexedDocument struct {
+	document search.Document
+	// tokens are the tokens of each of the document's texts.
+	tokens [][]search.Token
+}
+
+// NewSearchStore returns an empty SearchStore
+func NewSearchStore() SearchStore {
+	return SearchStore{
+		mutex:     &sync.RWMutex{},
+		documents: make(map[string]indexedDocument),
+		postings:  make(map[string]map[string]bool),
+	}
+}
+
+// IndexPage adds the page to the index, replacing whatever was indexed for it before.
+func (s SearchStore) IndexPage(document search.Document) error {
+	if document.PageGUID == "" {
+		return errors.New("must provide document.PageGUID to index the page")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removePage(document.PageGUID)
+	indexed := indexedDocument{
+		document: document,
+		tokens:   make([][]search.Token, 0, len(document.Texts)),
+	}
+	for _, text := range document.Texts {
+		tokens := search.Tokenize(text.Value)
+		indexed.tokens = append(indexed.tokens, tokens)
+		for _, token := range tokens {
+			if s.postings[token.Term] == nil {
+				s.postings[token.Term] = make(map[string]bool)
+			}
+			s.postings[token.Term][document.PageGUID] = true
+		}
+	}
+	s.documents[document.PageGUID] = indexed
+	return nil
+}
+
+// RemovePage removes the page from the index.
+func (s SearchStore) RemovePage(pageGUID string) error {
+	if pageGUID == "" {
+		return errors.New("must provide pageGUID to remove the page")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removePage(pageGUID)
+	return nil
+}
+
+func (s SearchStore) removePage(pageGUID string) {
+	indexed, ok := s.documents[pageGUID]
+	if !ok {
+		return
+	}
+	for _, tokens := range indexed.tokens {
+		for _, token := range tokens {
+			delete(s.postings[token.Term], pageGUID)
+			if len(s.postings[token.Term]) == 0 {
+				delete(s.postings, token.Term)
+			}
+		}
+	}
+	delete(s.documents, pageGUID)
+}
+
+// Search returns up to the limit of the pages the filter allows that contain all of the terms of the query, ranked by relevance.
+// Terms are weighted by how rare they are across pages and by the field they are found in.
+// Pages are filtered before they are scored, and only the returned pages are snippeted.
+func (s SearchStore) Search(query string, filter search.Filter, limit int) ([]search.Result, error) {
+	results := make([]search.Result, 0)
+	terms := getTerms(query)
+	if len(terms) == 0 || limit <= 0 {
+		return results, nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for pageGUID := range s.postings[terms[0]] {
+		indexed := s.documents[pageGUID]
+		if !filter.Allows(indexed.document) || !containsAll(indexed, terms) {
+			continue
+		}
+		results = append(results, search.Result{
+			PageGUID: pageGUID,
+			Title:    indexed.document.Title,
+			Score:    s.score(indexed, terms),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Title != results[j].Title {
+			return results[i].Title < results[j].Title
+		}
+		return results[i].PageGUID < results[j].PageGUID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	for i := range results {
+		results[i].Snippets = getSnippets(s.documents[results[i].PageGUID], terms)
+	}
+	return results, nil
+}
+
+// getTerms returns each term of the query once, in order.
+func getTerms(query string) []string {
+	seen := make(map[string]bool)
+	terms := []string{}
+	for _, token := range search.Tokenize(query) {
+		if seen[token.Term] {
+			continue
+		}
+		seen[token.Term] = true
+		terms = append(terms, token.Term)
+	}
+	return terms
+}
+
+func containsAll(indexed indexedDocument, terms []string) bool {
+	for _, term := range terms {
+		found := false
+		for _, tokens := range indexed.tokens {
+			if hasTerm(tokens, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func hasTerm(tokens []search.Token, term string) bool {
+	for _, token := range tokens {
+		if token.Term == term {
+			return true
+		}
+	}
+	return false
+}
+
+// score sums, for each term, the weighted number of times it appears in the page dampened logarithmically,
+// multiplied by the inverse of the number of pages it appears in.
+func (s SearchStore) score(indexed indexedDocument, terms []string) float64 {
+	score := 0.0
+	for _, term := range terms {
+		frequency := 0.0
+		for i, tokens := range indexed.tokens {
+			for _, token := range tokens {
+				if token.Term == term {
+					frequency += fieldWeights[indexed.document.Texts[i].Field]
+				}
+			}
+		}
+		if frequency == 0 {
+			continue
+		}
+		inverseFrequency := math.Log(1 + float64(len(s.documents))/float64(len(s.postings[term])))
+		score += (1 + math.Log(frequency)) * inverseFrequency
+	}
+	return math.Round(score*1000) / 1000
+}
+
+// getSnippets returns an excerpt of each text of the page that matched a term, in the order of the texts.
+func getSnippets(indexed indexedDocument, terms []string) []search.Snippet {
+	isTerm := make(map[string]bool)
+	for _, term := range terms {
+		isTerm[term] = true
+	}
+	snippets := []search.Snippet{}
+	for i, tokens := range indexed.tokens {
+		if len(snippets) == maxSnippets {
+			break
+		}
+		matches := []search.Token{}
+		for _, token := range tokens {
+			if isTerm[token.Term] {
+				matches = append(matches, token)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		text := indexed.document.Texts[i]
+		snippet := getSnippet(text.Value, matches)
+		snippet.Field = text.Field
+		snippet.DetailGUID = text.DetailGUID
+		snippets = append(snippets, snippet)
+	}
+	return snippets
+}
+
+// getSnippet cuts the text down to the snippet length around the first match, and highlights the matches within it.
+func getSnippet(value string, matches []search.Token) search.Snippet {
+	runes := []rune(value)
+	start := 0
+	end := len(runes)
+	if len(runes) > snippetLength {
+		start = matches[0].Start - snippetLead
+		if start < 0 {
+			start = 0
+		}
+		end = start + snippetLength
+		if end > len(runes) {
+			end = len(runes)
+			start = end - snippetLength
+		}
+	}
+	text := string(runes[start:end])
+	offset := -start
+	if start > 0 {
+		text = snippetCut + text
+		offset += len([]rune(snippetCut))
+	}
+	if end < len(runes) {
+		text = text + snippetCut
+	}
+	highlights := []search.Highlight{}
+	for _, match := range matches {
+		if match.Start < start || match.End > end {
+			continue
+		}
+		highlights = append(highlights, search.Highlight{Start: match.Start + offset, End: match.End + offset})
+	}
+	return search.Snippet{Text: text, Highlights: highlights}
+}
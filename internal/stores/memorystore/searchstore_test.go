@@ -0,0 +1,177 @@
+package memorystore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/search"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestDocuments() []search.Document {
+	return []search.Document{
+		{
+			PageGUID:       "PG_1",
+			Title:          "Barovia",
+			PermissionType: permission.TypePublic,
+			Texts: []search.Text{
+				{Field: search.FieldTitle, Value: "Barovia"},
+				{Field: search.FieldSummary, Value: "A village beneath the castle"},
+			},
+		},
+		{
+			PageGUID:       "PG_2",
+			Title:          "Castle Ravenloft",
+			PermissionType: permission.TypePrivate,
+			Texts: []search.Text{
+				{Field: search.FieldTitle, Value: "Castle Ravenloft"},
+				{Field: search.FieldDetail, DetailGUID: "PD_1", Value: "Strahd rules Barovia from the castle."},
+			},
+		},
+		{
+			PageGUID:       "PG_3",
+			Title:          "Vallaki",
+			PermissionType: permission.TypeLinkOnly,
+			Texts: []search.Text{
+				{Field: search.FieldTitle, Value: "Vallaki"},
+				{Field: search.FieldDetail, DetailGUID: "PD_2", Value: "A town west of the castle"},
+			},
+		},
+	}
+}
+
+func getResultGUIDs(results []search.Result) []string {
+	guids := make([]string, 0, len(results))
+	for _, result := range results {
+		guids = append(guids, result.PageGUID)
+	}
+	return guids
+}
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		name        string
+		paramQuery  string
+		paramFilter search.Filter
+		paramLimit  int
+		returnGUIDs []string
+	}{
+		{
+			name:        "blank query",
+			paramQuery:  " ! ",
+			paramFilter: search.NewFilter([]string{"PG_2", "PG_3"}),
+			paramLimit:  10,
+			returnGUIDs: []string{},
+		},
+		{
+			name:        "no matches",
+			paramQuery:  "krezk",
+			paramFilter: search.NewFilter([]string{"PG_2", "PG_3"}),
+			paramLimit:  10,
+			returnGUIDs: []string{},
+		},
+		{
+			name:        "matches in titles rank above summaries and details",
+			paramQuery:  "CASTLE",
+			paramFilter: search.NewFilter([]string{"PG_2", "PG_3"}),
+			paramLimit:  10,
+			returnGUIDs: []string{"PG_2", "PG_1", "PG_3"},
+		},
+		{
+			name:        "only public pages and the user's pages are found",
+			paramQuery:  "castle",
+			paramFilter: search.NewFilter([]string{"PG_2"}),
+			paramLimit:  10,
+			returnGUIDs: []string{"PG_2", "PG_1"},
+		},
+		{
+			name:        "up to the limit",
+			paramQuery:  "castle",
+			paramFilter: search.NewFilter([]string{"PG_2", "PG_3"}),
+			paramLimit:  2,
+			returnGUIDs: []string{"PG_2", "PG_1"},
+		},
+		{
+			name:        "every term must match",
+			paramQuery:  "castle barovia",
+			paramFilter: search.NewFilter([]string{"PG_2", "PG_3"}),
+			paramLimit:  10,
+			returnGUIDs: []string{"PG_1", "PG_2"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSearchStore()
+			for _, document := range getTestDocuments() {
+				require.NoError(t, s.IndexPage(document))
+			}
+			results, err := s.Search(tc.paramQuery, tc.paramFilter, tc.paramLimit)
+			require.NoError(t, err)
+			require.Equal(t, tc.returnGUIDs, getResultGUIDs(results))
+		})
+	}
+}
+
+func TestSearchSnippets(t *testing.T) {
+	long := strings.Repeat("mist ", 20) + "Strahd waits in the castle. " + strings.Repeat("fog ", 40)
+	s := NewSearchStore()
+	err := s.IndexPage(search.Document{
+		PageGUID: "PG_1",
+		Title:    "Castle Ravenloft",
+		Texts: []search.Text{
+			{Field: search.FieldTitle, Value: "Castle Ravenloft"},
+			{Field: search.FieldSummary, Value: "The castle of Strahd"},
+			{Field: search.FieldDetail, DetailGUID: "PD_1", Value: long},
+			{Field: search.FieldDetail, DetailGUID: "PD_2", Value: "The castle walls"},
+		},
+	})
+	require.NoError(t, err)
+	results, err := s.Search("castle strahd", search.NewFilter([]string{"PG_1"}), 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, []search.Snippet{
+		{
+			Field:      search.FieldTitle,
+			Text:       "Castle Ravenloft",
+			Highlights: []search.Highlight{{Start: 0, End: 6}},
+		},
+		{
+			Field:      search.FieldSummary,
+			Text:       "The castle of Strahd",
+			Highlights: []search.Highlight{{Start: 4, End: 10}, {Start: 14, End: 20}},
+		},
+		{
+			Field:      search.FieldDetail,
+			DetailGUID: "PD_1",
+			Text:       "…mist mist mist mist mist mist mist mist Strahd waits in the castle. " + strings.Repeat("fog ", 23) + "…",
+			Highlights: []search.Highlight{{Start: 41, End: 47}, {Start: 61, End: 67}},
+		},
+	}, results[0].Snippets)
+}
+
+func TestReindexAndRemovePage(t *testing.T) {
+	s := NewSearchStore()
+	for _, document := range getTestDocuments() {
+		require.NoError(t, s.IndexPage(document))
+	}
+	err := s.IndexPage(search.Document{
+		PageGUID: "PG_3",
+		Title:    "Krezk",
+		Texts:    []search.Text{{Field: search.FieldTitle, Value: "Krezk"}},
+	})
+	require.NoError(t, err)
+	results, err := s.Search("vallaki", search.NewFilter([]string{"PG_3"}), 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{}, getResultGUIDs(results))
+	results, err = s.Search("krezk", search.NewFilter([]string{"PG_3"}), 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"PG_3"}, getResultGUIDs(results))
+	require.NoError(t, s.RemovePage("PG_3"))
+	require.NoError(t, s.RemovePage("PG_4"))
+	results, err = s.Search("krezk", search.NewFilter([]string{"PG_3"}), 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{}, getResultGUIDs(results))
+	require.Error(t, s.RemovePage(""))
+	require.Error(t, s.IndexPage(search.Document{}))
+}
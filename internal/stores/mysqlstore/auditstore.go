@@ -0,0 +1,152 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
+)
+
+// AuditStore is the mysql for the audit log
+type AuditStore struct {
+	db *sql.DB
+}
+
+// NewAuditStore returns an AuditStore
+func NewAuditStore(mysqldb *sql.DB) AuditStore {
+	return AuditStore{
+		db: mysqldb,
+	}
+}
+
+// GetUniqueAuditEntryGUID returns a guid for the audit entry that is guaranteed to be unique or errors.
+// If the proposedAuditEntryGUID is not a zero-value and not unique, it will error.
+func (s AuditStore) GetUniqueAuditEntryGUID(proposedAuditEntryGUID string) (string, error) {
+	err := guidgen.CheckProposedGUID(proposedAuditEntryGUID, "AU", 15)
+	if err != nil {
+		return "", err
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	return getUniqueGUID(s.db, "AU", 15, "AuditEntry", proposedAuditEntryGUID, 0)
+}
+
+// CreateAuditEntry appends the entry to the audit log. Entries are never updated or removed once created.
+// The user and entity are kept as guids rather than references, so that the entry outlives them.
+func (s AuditStore) CreateAuditEntry(record audit.Entry) (audit.Entry, error) {
+	if record.GUID == "" {
+		return record, errors.New("must provide record.GUID to create the audit entry")
+	}
+	if record.Actor == "" {
+		return record, errors.New("must provide record.Actor to create the audit entry")
+	}
+	if s.db == nil {
+		return record, &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	query := wrapsql.InsertQuery{
+		IntoTable: "AuditEntry",
+		InjectedValues: wrapsql.InjectedValues{
+			"guid":          record.GUID,
+			"actor":         record.Actor,
+			"userGuid":      record.UserID,
+			"method":        record.Method,
+			"path":          record.Path,
+			"status":        record.Status,
+			"entityGuid":    record.EntityGUID,
+			"beforeSummary": record.Before,
+			"afterSummary":  record.After,
+			"createdAt":     &t,
+		},
+	}
+	id, err := wrapsql.ExecSingleInsert(s.db, query)
+	if err != nil {
+		return record, err
+	}
+	record.ID = id
+	record.CreatedAt = &t
+	return record, nil
+}
+
+// GetAuditEntries returns the entries matching the filter, newest first, starting from the entry with startGUID if given.
+// It also returns the guid to start the next batch from, which is empty if there are no more entries.
+func (s AuditStore) GetAuditEntries(filter audit.Filter, startGUID string, limit int) (entries []audit.Entry, nextGUID string, returnErr error) {
+	if limit < 1 {
+		returnErr = errors.New("must provide a limit of at least 1 to get the audit entries")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	whereOperations, values := getAuditEntryWhereOperations(filter)
+	if startGUID != "" {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "AuditEntry.ID", Operator: "<= (SELECT `ID` FROM `AuditEntry` WHERE `guid` = ?)"})
+		values = append(values, startGUID)
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"AuditEntry.ID", "AuditEntry.guid", "AuditEntry.actor", "AuditEntry.userGuid", "AuditEntry.method", "AuditEntry.path", "AuditEntry.status", "AuditEntry.entityGuid", "AuditEntry.beforeSummary", "AuditEntry.afterSummary", "AuditEntry.createdAt"},
+		FromTable: "AuditEntry",
+		WhereClause: wrapsql.WhereClause{
+			Operator:        "AND",
+			WhereOperations: whereOperations,
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "AuditEntry.ID",
+			SortBy: "DESC",
+		},
+		Limit: limit + 1, // plus one so we can get an extra record to determine the next batch
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), values...)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	entries = make([]audit.Entry, 0)
+	defer rows.Close()
+	for rows.Next() {
+		e := audit.Entry{}
+		err := rows.Scan(&e.ID, &e.GUID, &e.Actor, &e.UserID, &e.Method, &e.Path, &e.Status, &e.EntityGUID, &e.Before, &e.After, &e.CreatedAt)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) > limit {
+		nextGUID = entries[len(entries)-1].GUID
+		entries = entries[:len(entries)-1]
+	}
+	return
+}
+
+func getAuditEntryWhereOperations(filter audit.Filter) ([]wrapsql.WhereOperation, []interface{}) {
+	whereOperations := []wrapsql.WhereOperation{}
+	values := []interface{}{}
+	if filter.UserID != "" {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "AuditEntry.userGuid", Operator: "= ?"})
+		values = append(values, filter.UserID)
+	}
+	if filter.EntityGUID != "" {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "AuditEntry.entityGuid", Operator: "= ?"})
+		values = append(values, filter.EntityGUID)
+	}
+	if filter.From != nil {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "AuditEntry.createdAt", Operator: ">= ?"})
+		values = append(values, filter.From)
+	}
+	if filter.To != nil {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "AuditEntry.createdAt", Operator: "< ?"})
+		values = append(values, filter.To)
+	}
+	return whereOperations, values
+}
@@ -0,0 +1,107 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testAuditStoreClearAllTables(db *sql.DB) error {
+	return clearTableForTest(db, "AuditEntry")
+}
+
+func TestGetAuditEntries(t *testing.T) {
+	firstAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondAt := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	preTestQueries := []string{
+		"INSERT INTO AuditEntry (`guid`, `actor`, `userGuid`, `method`, `path`, `status`, `entityGuid`, `beforeSummary`, `afterSummary`, `createdAt`) VALUES( \"AU_1\", \"proxyUser\", \"UR_1\", \"POST\", \"/api/pages\", 200, \"PG_1\", \"\", \"{\\\"id\\\":\\\"PG_1\\\"}\", \"2020-01-01 00:00:00\" )",
+		"INSERT INTO AuditEntry (`guid`, `actor`, `userGuid`, `method`, `path`, `status`, `entityGuid`, `beforeSummary`, `afterSummary`, `createdAt`) VALUES( \"AU_2\", \"admin\", \"\", \"DELETE\", \"/api/pages/PG_1\", 200, \"PG_1\", \"{\\\"id\\\":\\\"PG_1\\\"}\", \"\", \"2020-01-02 00:00:00\" )",
+		"INSERT INTO AuditEntry (`guid`, `actor`, `userGuid`, `method`, `path`, `status`, `entityGuid`, `beforeSummary`, `afterSummary`, `createdAt`) VALUES( \"AU_3\", \"proxyUser\", \"UR_1\", \"POST\", \"/api/properties\", 400, \"\", \"\", \"\", \"2020-01-02 00:00:00\" )",
+	}
+	first := audit.Entry{ID: 1, GUID: "AU_1", Actor: "proxyUser", UserID: "UR_1", Method: "POST", Path: "/api/pages", Status: 200, EntityGUID: "PG_1", After: "{\"id\":\"PG_1\"}", CreatedAt: &firstAt}
+	second := audit.Entry{ID: 2, GUID: "AU_2", Actor: "admin", Method: "DELETE", Path: "/api/pages/PG_1", Status: 200, EntityGUID: "PG_1", Before: "{\"id\":\"PG_1\"}", CreatedAt: &secondAt}
+	third := audit.Entry{ID: 3, GUID: "AU_3", Actor: "proxyUser", UserID: "UR_1", Method: "POST", Path: "/api/properties", Status: 400, CreatedAt: &secondAt}
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramFilter            audit.Filter
+		paramStartGUID         string
+		paramLimit             int
+		returnEntries          []audit.Entry
+		returnNextGUID         string
+		returnErr              error
+	}{
+		{
+			name:           "newest first",
+			preTestQueries: preTestQueries,
+			paramLimit:     10,
+			returnEntries:  []audit.Entry{third, second, first},
+		},
+		{
+			name:           "by user",
+			preTestQueries: preTestQueries,
+			paramFilter:    audit.Filter{UserID: "UR_1"},
+			paramLimit:     10,
+			returnEntries:  []audit.Entry{third, first},
+		},
+		{
+			name:           "by entity and time range",
+			preTestQueries: preTestQueries,
+			paramFilter:    audit.Filter{EntityGUID: "PG_1", From: &firstAt, To: &secondAt},
+			paramLimit:     10,
+			returnEntries:  []audit.Entry{first},
+		},
+		{
+			name:           "first batch",
+			preTestQueries: preTestQueries,
+			paramLimit:     2,
+			returnEntries:  []audit.Entry{third, second},
+			returnNextGUID: "AU_1",
+		},
+		{
+			name:           "next batch",
+			preTestQueries: preTestQueries,
+			paramStartGUID: "AU_1",
+			paramLimit:     2,
+			returnEntries:  []audit.Entry{first},
+		},
+		{
+			name:          "no entries",
+			paramLimit:    10,
+			returnEntries: []audit.Entry{},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramLimit:             10,
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditStore := AuditStore{
+				db: mysqldb,
+			}
+			err := testAuditStoreClearAllTables(auditStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(auditStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				auditStore.db = nil
+			}
+			entries, nextGUID, err := auditStore.GetAuditEntries(tc.paramFilter, tc.paramStartGUID, tc.paramLimit)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnEntries, entries)
+			require.Equal(t, tc.returnNextGUID, nextGUID)
+		})
+	}
+}
@@ -0,0 +1,483 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
+)
+
+// CampaignStore is the mysql for campaigns
+type CampaignStore struct {
+	db *sql.DB
+}
+
+// NewCampaignStore returns a CampaignStore
+func NewCampaignStore(mysqldb *sql.DB) CampaignStore {
+	return CampaignStore{
+		db: mysqldb,
+	}
+}
+
+// GetUniqueCampaignGUID returns a guid for the campaign that is guaranteed to be unique or errors.
+// If the proposedCampaignGUID is not a zero-value and not unique, it will error.
+func (s CampaignStore) GetUniqueCampaignGUID(proposedCampaignGUID string) (string, error) {
+	err := guidgen.CheckProposedGUID(proposedCampaignGUID, "CP", 15)
+	if err != nil {
+		return "", err
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	return getUniqueGUID(s.db, "CP", 15, "Campaign", proposedCampaignGUID, 0)
+}
+
+// CreateCampaign creates a new campaign with the given owner as its only member.
+func (s CampaignStore) CreateCampaign(record campaign.Campaign, ownerID int64) (campaign.Campaign, error) {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if record.GUID == "" {
+		return record, errors.New("must provide record.GUID to create the campaign")
+	}
+	if record.Name == "" {
+		return record, errors.New("must provide record.Name to create the campaign")
+	}
+	if ownerID == 0 {
+		return record, errors.New("must provide ownerID to create the campaign")
+	}
+	if s.db == nil {
+		return record, &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	id, err := wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "Campaign",
+		InjectedValues: wrapsql.InjectedValues{
+			"guid":      record.GUID,
+			"name":      record.Name,
+			"summary":   record.Summary,
+			"createdAt": &t,
+			"updatedAt": &t,
+		},
+	})
+	if err != nil {
+		return record, err
+	}
+	record.ID = id
+	_, err = wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "CampaignMember",
+		InjectedValues: wrapsql.InjectedValues{
+			"Campaign_ID": record.ID,
+			"User_ID":     ownerID,
+			"role":        string(campaign.RoleOwner),
+		},
+	})
+	if err != nil {
+		return record, errors.Wrap(err, "unable to add the campaign owner")
+	}
+	return record, nil
+}
+
+// GetCampaignRole returns the role of the given user in the given campaign.
+// If the user is not a member, a storeerror.NotAuthorized will be returned.
+func (s CampaignStore) GetCampaignRole(guid, userID string) (campaign.Role, error) {
+	if guid == "" {
+		return campaign.RoleViewer, errors.New("must provide a guid to check privileges")
+	}
+	if userID == "" {
+		return campaign.RoleViewer, errors.New("must provide a userID to check privileges")
+	}
+	if s.db == nil {
+		return campaign.RoleViewer, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"CampaignMember.role"},
+		FromTable: "CampaignMember",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Campaign", On: wrapsql.OnClause{LeftSide: "CampaignMember.Campaign_ID", RightSide: "Campaign.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "CampaignMember.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Campaign.guid", Operator: "= ?"},
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Campaign.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid, userID)
+	var roleString string
+	err = wrapsql.GetSingleRow(guid, rows, err, &roleString)
+	if _, ok := err.(*storeerror.NotFound); ok {
+		return campaign.RoleViewer, &storeerror.NotAuthorized{
+			UserID:  userID,
+			TableID: guid,
+		}
+	}
+	if err != nil {
+		return campaign.RoleViewer, err
+	}
+	return campaign.GetRole(roleString)
+}
+
+// GetCampaign returns the given campaign, without its members.
+func (s CampaignStore) GetCampaign(guid string) (campaign.Campaign, error) {
+	if guid == "" {
+		return campaign.Campaign{}, errors.New("must provide guid to get the campaign")
+	}
+	if s.db == nil {
+		return campaign.Campaign{}, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"ID", "guid", "name", "summary"},
+		FromTable: "Campaign",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+				{LeftSide: "deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	var c campaign.Campaign
+	err = wrapsql.GetSingleRow(guid, rows, err, &c.ID, &c.GUID, &c.Name, &c.Summary)
+	return c, err
+}
+
+// GetCampaigns returns all the campaigns the user is a member of, ordered by name.
+func (s CampaignStore) GetCampaigns(userID string) (returnCampaigns []campaign.Campaign, returnErr error) {
+	if userID == "" {
+		returnErr = errors.New("must provide userID to get the campaigns")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Campaign.ID", "Campaign.guid", "Campaign.name", "Campaign.summary"},
+		FromTable: "Campaign",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "CampaignMember", On: wrapsql.OnClause{LeftSide: "CampaignMember.Campaign_ID", RightSide: "Campaign.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "CampaignMember.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Campaign.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Campaign.name",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnCampaigns = make([]campaign.Campaign, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var c campaign.Campaign
+		err := rows.Scan(&c.ID, &c.GUID, &c.Name, &c.Summary)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		returnCampaigns = append(returnCampaigns, c)
+	}
+	return
+}
+
+// UpdateCampaign sets the name and summary of the given campaign.
+func (s CampaignStore) UpdateCampaign(record campaign.Campaign) error {
+	if record.GUID == "" {
+		return errors.New("must provide record.GUID to update the campaign")
+	}
+	if record.Name == "" {
+		return errors.New("must provide record.Name to update the campaign")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "Campaign",
+		InjectedValues: wrapsql.InjectedValues{
+			"name":      record.Name,
+			"summary":   record.Summary,
+			"updatedAt": &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+				{LeftSide: "deletedAt", Operator: "IS NULL"},
+			},
+		},
+	}
+	return wrapsql.ExecSingleUpdate(s.db, query, record.GUID)
+}
+
+// RemoveCampaign marks the given campaign as removed by setting the deletedAt property.
+// The pages of a removed campaign are no longer shared with its members.
+func (s CampaignStore) RemoveCampaign(guid string) error {
+	if guid == "" {
+		return errors.New("must provide guid to remove the campaign")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "Campaign",
+		InjectedValues: wrapsql.InjectedValues{
+			"deletedAt": &t,
+			"updatedAt": &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+			},
+		},
+	}
+	return wrapsql.ExecSingleUpdate(s.db, query, guid)
+}
+
+// GetCampaignMembers returns all the members of the given campaign, ordered by user.
+func (s CampaignStore) GetCampaignMembers(guid string) (returnMembers []campaign.Member, returnErr error) {
+	if guid == "" {
+		returnErr = errors.New("must provide guid to get the campaign members")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"User.guid", "CampaignMember.role"},
+		FromTable: "CampaignMember",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Campaign", On: wrapsql.OnClause{LeftSide: "CampaignMember.Campaign_ID", RightSide: "Campaign.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "CampaignMember.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Campaign.guid", Operator: "= ?"},
+				{LeftSide: "Campaign.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "User.guid",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnMembers = make([]campaign.Member, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var m campaign.Member
+		var roleString string
+		err := rows.Scan(&m.UserID, &roleString)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		m.Role, err = campaign.GetRole(roleString)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		returnMembers = append(returnMembers, m)
+	}
+	return
+}
+
+// SetCampaignMember adds the user to the campaign with the given role, or changes their role if they are already a member.
+func (s CampaignStore) SetCampaignMember(guid string, userID int64, role campaign.Role) error {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if guid == "" {
+		return errors.New("must provide guid to set the campaign member")
+	}
+	if userID == 0 {
+		return errors.New("must provide userID to set the campaign member")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	campaignID, err := s.getCampaignID(guid)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Campaign.ID for guid: %v", guid)
+	}
+	err = s.deleteCampaignMember(campaignID, userID)
+	if err != nil {
+		return err
+	}
+	_, err = wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "CampaignMember",
+		InjectedValues: wrapsql.InjectedValues{
+			"Campaign_ID": campaignID,
+			"User_ID":     userID,
+			"role":        string(role),
+		},
+	})
+	return err
+}
+
+// RemoveCampaignMember removes the user from the campaign.
+func (s CampaignStore) RemoveCampaignMember(guid, userID string) error {
+	if guid == "" {
+		return errors.New("must provide guid to remove the campaign member")
+	}
+	if userID == "" {
+		return errors.New("must provide userID to remove the campaign member")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	campaignID, err := s.getCampaignID(guid)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Campaign.ID for guid: %v", guid)
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"ID"},
+		FromTable: "User",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	var userDBID int64
+	err = wrapsql.GetSingleRow(userID, rows, err, &userDBID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get User.ID for guid: %v", userID)
+	}
+	return s.deleteCampaignMember(campaignID, userDBID)
+}
+
+func (s CampaignStore) deleteCampaignMember(campaignID, userID int64) error {
+	err := wrapsql.ExecDelete(s.db, wrapsql.DeleteQuery{
+		FromTable: "CampaignMember",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Campaign_ID", Operator: "= ?"},
+				{LeftSide: "User_ID", Operator: "= ?"},
+			},
+		},
+	}, campaignID, userID)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete from CampaignMember")
+	}
+	return nil
+}
+
+// AddCampaignPage shares the page with every member of the campaign.
+// Adding a page that is already part of the campaign has no effect.
+func (s CampaignStore) AddCampaignPage(guid, pageGUID string) error {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if guid == "" {
+		return errors.New("must provide guid to add the campaign page")
+	}
+	if pageGUID == "" {
+		return errors.New("must provide pageGUID to add the campaign page")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	campaignID, err := s.getCampaignID(guid)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Campaign.ID for guid: %v", guid)
+	}
+	pageID, err := getPageID(s.db, pageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", pageGUID)
+	}
+	err = s.deleteCampaignPage(campaignID, pageID)
+	if err != nil {
+		return err
+	}
+	_, err = wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "CampaignPage",
+		InjectedValues: wrapsql.InjectedValues{
+			"Campaign_ID": campaignID,
+			"Page_ID":     pageID,
+		},
+	})
+	return err
+}
+
+// RemoveCampaignPage stops sharing the page with the members of the campaign.
+func (s CampaignStore) RemoveCampaignPage(guid, pageGUID string) error {
+	if guid == "" {
+		return errors.New("must provide guid to remove the campaign page")
+	}
+	if pageGUID == "" {
+		return errors.New("must provide pageGUID to remove the campaign page")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	campaignID, err := s.getCampaignID(guid)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Campaign.ID for guid: %v", guid)
+	}
+	pageID, err := getPageID(s.db, pageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", pageGUID)
+	}
+	return s.deleteCampaignPage(campaignID, pageID)
+}
+
+func (s CampaignStore) deleteCampaignPage(campaignID, pageID int64) error {
+	err := wrapsql.ExecDelete(s.db, wrapsql.DeleteQuery{
+		FromTable: "CampaignPage",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Campaign_ID", Operator: "= ?"},
+				{LeftSide: "Page_ID", Operator: "= ?"},
+			},
+		},
+	}, campaignID, pageID)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete from CampaignPage")
+	}
+	return nil
+}
+
+func (s CampaignStore) getCampaignID(guid string) (int64, error) {
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"ID"},
+		FromTable: "Campaign",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+				{LeftSide: "deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	var campaignID int64
+	err = wrapsql.GetSingleRow(guid, rows, err, &campaignID)
+	return campaignID, err
+}
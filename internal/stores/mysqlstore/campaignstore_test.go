@@ -0,0 +1,152 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testCampaignStoreClearAllTables(db *sql.DB) error {
+	tables := []string{"Campaign", "CampaignMember", "CampaignPage", "User"}
+	for _, table := range tables {
+		err := clearTableForTest(db, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGetCampaignRole(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramGUID              string
+		paramUserID            string
+		returnRole             campaign.Role
+		returnErr              error
+	}{
+		{
+			name: "editor",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"OW\")",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 2, \"ED\")",
+			},
+			paramGUID:   "CP_1",
+			paramUserID: "UR_2",
+			returnRole:  campaign.RoleEditor,
+		},
+		{
+			name: "not a member",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"OW\")",
+			},
+			paramGUID:   "CP_1",
+			paramUserID: "UR_2",
+			returnErr:   &storeerror.NotAuthorized{UserID: "UR_2", TableID: "CP_1"},
+		},
+		{
+			name: "removed campaign",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"OW\")",
+			},
+			paramGUID:   "CP_1",
+			paramUserID: "UR_1",
+			returnErr:   &storeerror.NotAuthorized{UserID: "UR_1", TableID: "CP_1"},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramGUID:              "CP_1",
+			paramUserID:            "UR_1",
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignStore := CampaignStore{
+				db: mysqldb,
+			}
+			err := testCampaignStoreClearAllTables(campaignStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(campaignStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				campaignStore.db = nil
+			}
+			result, err := campaignStore.GetCampaignRole(tc.paramGUID, tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnRole, result)
+		})
+	}
+}
+
+func TestGetCampaigns(t *testing.T) {
+	cases := []struct {
+		name            string
+		preTestQueries  []string
+		paramUserID     string
+		returnCampaigns []campaign.Campaign
+		returnErr       error
+	}{
+		{
+			name: "only active campaigns of the member",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_2\", \"Away Group\", \"\", NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( \"CP_3\", \"Old Group\", \"\", NOW(), NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_4\", \"Other Group\", \"\", NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"OW\")",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 2, 1, \"VI\")",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 3, 1, \"OW\")",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 4, 2, \"OW\")",
+			},
+			paramUserID: "UR_1",
+			returnCampaigns: []campaign.Campaign{
+				{ID: 2, GUID: "CP_2", Name: "Away Group"},
+				{ID: 1, GUID: "CP_1", Name: "Home Group"},
+			},
+		},
+		{
+			name:            "no campaigns",
+			paramUserID:     "UR_1",
+			returnCampaigns: []campaign.Campaign{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			campaignStore := CampaignStore{
+				db: mysqldb,
+			}
+			err := testCampaignStoreClearAllTables(campaignStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(campaignStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := campaignStore.GetCampaigns(tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnCampaigns, result)
+		})
+	}
+}
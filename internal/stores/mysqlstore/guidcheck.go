@@ -6,13 +6,12 @@ import (
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
 	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
-	"github.com/pkg/errors"
 )
 
 func getUniqueGUID(db *sql.DB, prefix string, length int, table, proposedGUID string, retry int) (string, error) {
 	guid := proposedGUID
 	if guid == "" {
-		guid = guidgen.GenerateGUID("PG", 15)
+		guid = guidgen.GenerateGUID(prefix, length)
 	}
 	statement := wrapsql.SelectStatement{
 		Selectors: []string{"guid"},
@@ -29,7 +28,7 @@ func getUniqueGUID(db *sql.DB, prefix string, length int, table, proposedGUID st
 	err = wrapsql.GetSingleRow(guid, rows, err, &resGUID)
 	if err == nil {
 		if proposedGUID != "" {
-			return "", errors.Errorf("the proposed guid %v already exists", proposedGUID)
+			return "", &storeerror.DupEntry{ID: proposedGUID}
 		}
 		if retry >= guidgen.MaxGUIDRetryAttempts {
 			return "", guidgen.ErrMaxGUIDRetryAttempts
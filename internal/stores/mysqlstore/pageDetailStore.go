@@ -2,10 +2,13 @@ package mysqlstore
 
 import (
 	"database/sql"
-	"errors"
+	"time"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
 )
 
 // PageDetailStore is the mysql for a page detail
@@ -20,22 +23,371 @@ func NewPageDetailStore(mysqldb *sql.DB) PageDetailStore {
 	}
 }
 
-// UpdatePageDetail updates the given page.
-func (s PageDetailStore) UpdatePageDetail(record pagedetail.PageDetail) error {
+// GetUniquePageDetailGUID returns a guid for the page detail that is guaranteed to be unique or errors.
+// If the proposedPageDetailGUID is not a zero-value and not unique, it will error.
+func (s PageDetailStore) GetUniquePageDetailGUID(proposedPageDetailGUID string) (string, error) {
+	err := guidgen.CheckProposedGUID(proposedPageDetailGUID, "DT", 15)
+	if err != nil {
+		return "", err
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	return getUniqueGUID(s.db, "DT", 15, "PageDetail", proposedPageDetailGUID, 0)
+}
+
+// CreatePageDetail creates a new detail for the given page.
+func (s PageDetailStore) CreatePageDetail(pageGUID string, record pagedetail.PageDetail) (pagedetail.PageDetail, error) {
+	if pageGUID == "" {
+		return record, errors.New("must provide pageGUID to create the page detail")
+	}
 	if record.GUID == "" {
-		return errors.New("must provide record.GUID to update the page")
+		return record, errors.New("must provide record.GUID to create the page detail")
 	}
 	if record.Title == "" {
-		return errors.New("must provide record.Title to update the page")
+		return record, errors.New("must provide record.Title to create the page detail")
 	}
 	if s.db == nil {
-		return &storeerror.DBNotSetUp{}
+		return record, &storeerror.DBNotSetUp{}
+	}
+	pageID, err := getPageID(s.db, pageGUID)
+	if err != nil {
+		return record, errors.Wrapf(err, "unable to get Page.ID for guid: %v", pageGUID)
+	}
+	encodedPartitions, err := pagedetail.EncodePartitions(record.Partitions)
+	if err != nil {
+		return record, errors.Wrap(err, "unable to encode the page detail partitions")
 	}
-	statement, err := s.db.Prepare("UPDATE `Page` SET `title` = ?, `summary` = ? WHERE `guid` = ?")
+	t := time.Now()
+	id, err := wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "PageDetail",
+		InjectedValues: wrapsql.InjectedValues{
+			"Page_ID":    pageID,
+			"guid":       record.GUID,
+			"title":      record.Title,
+			"summary":    record.Summary,
+			"partitions": encodedPartitions,
+			"createdAt":  &t,
+			"updatedAt":  &t,
+		},
+	})
 	if err != nil {
+		return record, err
+	}
+	record.ID = id
+	err = s.appendPageDetailOrder(pageID, record.ID)
+	if err != nil {
+		return record, errors.Wrap(err, "unable to add page detail order")
+	}
+	err = replacePageDetailRelations(s.db, record.ID, record.Partitions)
+	if err != nil {
+		return record, errors.Wrap(err, "unable to index the page detail relations")
+	}
+	return record, nil
+}
+
+func (s PageDetailStore) appendPageDetailOrder(pageID, pageDetailID int64) error {
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"order"},
+		FromTable: "PageDetailOrder",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page_ID", Operator: "= ?"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "order",
+			SortBy: "DESC",
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageID)
+	var lastOrder int64
+	err = wrapsql.GetSingleRow("", rows, err, &lastOrder)
+	if _, ok := err.(*storeerror.NotFound); ok {
+		lastOrder = -1
+	} else if err != nil {
 		return err
 	}
-	defer statement.Close()
-	_, err = statement.Exec(record.Title, record.Summary, record.GUID)
+	_, err = wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "PageDetailOrder",
+		InjectedValues: wrapsql.InjectedValues{
+			"Page_ID":       pageID,
+			"PageDetail_ID": pageDetailID,
+			"order":         lastOrder + 1,
+		},
+	})
 	return err
 }
+
+// GetPageDetail returns the given detail of the given page.
+func (s PageDetailStore) GetPageDetail(pageGUID, pageDetailGUID string) (pagedetail.PageDetail, error) {
+	if pageGUID == "" {
+		return pagedetail.PageDetail{}, errors.New("must provide pageGUID to get the page detail")
+	}
+	if pageDetailGUID == "" {
+		return pagedetail.PageDetail{}, errors.New("must provide pageDetailGUID to get the page detail")
+	}
+	if s.db == nil {
+		return pagedetail.PageDetail{}, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageDetail.ID", "PageDetail.guid", "PageDetail.title", "PageDetail.summary", "PageDetail.partitions"},
+		FromTable: "PageDetail",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageDetail.Page_ID", RightSide: "Page.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "PageDetail.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "PageDetail.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID, pageDetailGUID)
+	var d pagedetail.PageDetail
+	var encodedPartitions string
+	err = wrapsql.GetSingleRow(pageDetailGUID, rows, err, &d.ID, &d.GUID, &d.Title, &d.Summary, &encodedPartitions)
+	if err != nil {
+		return pagedetail.PageDetail{}, err
+	}
+	d.Partitions, err = pagedetail.DecodePartitions(encodedPartitions)
+	if err != nil {
+		return pagedetail.PageDetail{}, errors.Wrapf(err, "unable to decode the partitions of page detail: %v", pageDetailGUID)
+	}
+	return d, nil
+}
+
+// GetPageDetails returns all the details of the given page, in order.
+func (s PageDetailStore) GetPageDetails(pageGUID string) (returnDetails []pagedetail.PageDetail, returnErr error) {
+	if pageGUID == "" {
+		returnErr = errors.New("must provide pageGUID to get the page details")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageDetail.ID", "PageDetail.guid", "PageDetail.title", "PageDetail.summary", "PageDetail.partitions"},
+		FromTable: "PageDetail",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageDetail.Page_ID", RightSide: "Page.ID"}},
+			{
+				JoinTable: "PageDetailOrder",
+				On: wrapsql.OnClause{
+					Operator: "AND",
+					OnOperations: []wrapsql.OnClause{
+						{LeftSide: "PageDetailOrder.Page_ID", RightSide: "Page.ID"},
+						{LeftSide: "PageDetailOrder.PageDetail_ID", RightSide: "PageDetail.ID"},
+					},
+				},
+			},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "PageDetail.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "PageDetailOrder.order",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnDetails = make([]pagedetail.PageDetail, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var d pagedetail.PageDetail
+		var encodedPartitions string
+		err := rows.Scan(&d.ID, &d.GUID, &d.Title, &d.Summary, &encodedPartitions)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		d.Partitions, err = pagedetail.DecodePartitions(encodedPartitions)
+		if err != nil {
+			returnErr = errors.Wrapf(err, "unable to decode the partitions of page detail: %v", d.GUID)
+			return
+		}
+		returnDetails = append(returnDetails, d)
+	}
+	return
+}
+
+// UpdatePageDetail sets the given detail of the given page.
+func (s PageDetailStore) UpdatePageDetail(pageGUID string, record pagedetail.PageDetail) error {
+	if pageGUID == "" {
+		return errors.New("must provide pageGUID to update the page detail")
+	}
+	if record.GUID == "" {
+		return errors.New("must provide record.GUID to update the page detail")
+	}
+	if record.Title == "" {
+		return errors.New("must provide record.Title to update the page detail")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	pageDetailID, err := getPageDetailID(s.db, pageGUID, record.GUID)
+	if err != nil {
+		return err
+	}
+	encodedPartitions, err := pagedetail.EncodePartitions(record.Partitions)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the page detail partitions")
+	}
+	t := time.Now()
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "PageDetail",
+		InjectedValues: wrapsql.InjectedValues{
+			"title":      record.Title,
+			"summary":    record.Summary,
+			"partitions": encodedPartitions,
+			"updatedAt":  &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "ID", Operator: "= ?"},
+			},
+		},
+	}
+	err = wrapsql.ExecSingleUpdate(s.db, query, pageDetailID)
+	if err != nil {
+		return err
+	}
+	err = replacePageDetailRelations(s.db, pageDetailID, record.Partitions)
+	if err != nil {
+		return errors.Wrap(err, "unable to index the page detail relations")
+	}
+	return nil
+}
+
+// RemovePageDetail marks the given page detail as removed by setting the deletedAt property.
+func (s PageDetailStore) RemovePageDetail(pageGUID, pageDetailGUID string) error {
+	if pageGUID == "" {
+		return errors.New("must provide pageGUID to remove the page detail")
+	}
+	if pageDetailGUID == "" {
+		return errors.New("must provide pageDetailGUID to remove the page detail")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	pageDetailID, err := getPageDetailID(s.db, pageGUID, pageDetailGUID)
+	if err != nil {
+		return err
+	}
+	t := time.Now()
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "PageDetail",
+		InjectedValues: wrapsql.InjectedValues{
+			"deletedAt": &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "ID", Operator: "= ?"},
+			},
+		},
+	}
+	err = wrapsql.ExecSingleUpdate(s.db, query, pageDetailID)
+	if err != nil {
+		return err
+	}
+	err = wrapsql.ExecDelete(s.db, wrapsql.DeleteQuery{
+		FromTable: "PageDetailOrder",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "PageDetail_ID", Operator: "= ?"},
+			},
+		},
+	}, pageDetailID)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete from PageDetailOrder")
+	}
+	return nil
+}
+
+// ReorderPageDetails sets the order of the page's details to the order of the given guids.
+// Details of the page that are not provided will no longer be returned by GetPageDetails.
+func (s PageDetailStore) ReorderPageDetails(pageGUID string, pageDetailGUIDs []string) error {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if pageGUID == "" {
+		return errors.New("must provide pageGUID to reorder the page details")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	pageID, err := getPageID(s.db, pageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", pageGUID)
+	}
+	query := wrapsql.BatchInsertQuery{
+		IntoTable:           "PageDetailOrder",
+		BatchInjectedValues: wrapsql.BatchInjectedValues{},
+	}
+	for i, pageDetailGUID := range pageDetailGUIDs {
+		pageDetailID, err := getPageDetailID(s.db, pageGUID, pageDetailGUID)
+		if err != nil {
+			return err
+		}
+		query.BatchInjectedValues["Page_ID"] = append(query.BatchInjectedValues["Page_ID"], pageID)
+		query.BatchInjectedValues["PageDetail_ID"] = append(query.BatchInjectedValues["PageDetail_ID"], pageDetailID)
+		query.BatchInjectedValues["order"] = append(query.BatchInjectedValues["order"], i)
+	}
+	err = wrapsql.ExecDelete(s.db, wrapsql.DeleteQuery{
+		FromTable: "PageDetailOrder",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page_ID", Operator: "= ?"},
+			},
+		},
+	}, pageID)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete from PageDetailOrder")
+	}
+	if len(pageDetailGUIDs) == 0 {
+		return nil
+	}
+	err = wrapsql.ExecBatchInsert(s.db, query)
+	if err != nil {
+		return errors.Wrap(err, "unable to insert page detail order")
+	}
+	return nil
+}
+
+func getPageDetailID(db *sql.DB, pageGUID, pageDetailGUID string) (int64, error) {
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageDetail.ID"},
+		FromTable: "PageDetail",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageDetail.Page_ID", RightSide: "Page.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "PageDetail.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "PageDetail.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := db.Query(wrapsql.GetSelectString(statement), pageGUID, pageDetailGUID)
+	var pageDetailID int64
+	err = wrapsql.GetSingleRow(pageDetailGUID, rows, err, &pageDetailID)
+	return pageDetailID, err
+}
@@ -0,0 +1,296 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testPageDetailStoreClearAllTables(db *sql.DB) error {
+	tables := []string{"Page", "PageDetail", "PageDetailOrder", "PageDetailRelation"}
+	for _, table := range tables {
+		err := clearTableForTest(db, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestCreatePageDetail(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramPageGUID          string
+		paramRecord            pagedetail.PageDetail
+		expectedDBDetail       pagedetail.PageDetail
+		returnErr              error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+			},
+			paramPageGUID: "PG_1",
+			paramRecord: pagedetail.PageDetail{
+				GUID:  "DT_1",
+				Title: "detail title",
+				Partitions: []pagedetail.Partition{
+					{
+						Type:       pagedetail.PartitionTypeParagraph,
+						TypeString: "p",
+						Partitions: []pagedetail.Partition{
+							{Type: pagedetail.PartitionTypeText, TypeString: "text", Value: "some text"},
+						},
+					},
+				},
+			},
+			expectedDBDetail: pagedetail.PageDetail{
+				ID:    1,
+				GUID:  "DT_1",
+				Title: "detail title",
+				Partitions: []pagedetail.Partition{
+					{
+						Type:       pagedetail.PartitionTypeParagraph,
+						TypeString: "p",
+						Partitions: []pagedetail.Partition{
+							{Type: pagedetail.PartitionTypeText, TypeString: "text", Value: "some text"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "page does not exist",
+			paramPageGUID: "PG_1",
+			paramRecord: pagedetail.PageDetail{
+				GUID:  "DT_1",
+				Title: "detail title",
+			},
+			returnErr: errors.New("unable to get Page.ID for guid: PG_1: Could not find: PG_1"),
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramPageGUID:          "PG_1",
+			paramRecord: pagedetail.PageDetail{
+				GUID:  "DT_1",
+				Title: "detail title",
+			},
+			returnErr: &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailStore := PageDetailStore{
+				db: mysqldb,
+			}
+			err := testPageDetailStoreClearAllTables(pageDetailStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageDetailStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				pageDetailStore.db = nil
+			}
+			_, err = pageDetailStore.CreatePageDetail(tc.paramPageGUID, tc.paramRecord)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			d, err := pageDetailStore.GetPageDetail(tc.paramPageGUID, tc.paramRecord.GUID)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDBDetail, d)
+		})
+	}
+}
+
+func TestUpdatePageDetail(t *testing.T) {
+	cases := []struct {
+		name             string
+		preTestQueries   []string
+		paramPageGUID    string
+		paramRecord      pagedetail.PageDetail
+		expectedDBDetail pagedetail.PageDetail
+		returnErr        error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES( 1, \"DT_1\", \"original title\", \"\", \"[]\", NOW(), NOW() )",
+			},
+			paramPageGUID: "PG_1",
+			paramRecord: pagedetail.PageDetail{
+				GUID:    "DT_1",
+				Title:   "new title",
+				Summary: "new summary",
+				Partitions: []pagedetail.Partition{
+					{Type: pagedetail.PartitionTypeHeaderOne, TypeString: "h1", Value: "header"},
+				},
+			},
+			expectedDBDetail: pagedetail.PageDetail{
+				ID:      1,
+				GUID:    "DT_1",
+				Title:   "new title",
+				Summary: "new summary",
+				Partitions: []pagedetail.Partition{
+					{Type: pagedetail.PartitionTypeHeaderOne, TypeString: "h1", Value: "header"},
+				},
+			},
+		},
+		{
+			name: "detail belongs to a different page",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"other title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES( 2, \"DT_1\", \"original title\", \"\", \"[]\", NOW(), NOW() )",
+			},
+			paramPageGUID: "PG_1",
+			paramRecord: pagedetail.PageDetail{
+				GUID:  "DT_1",
+				Title: "new title",
+			},
+			returnErr: &storeerror.NotFound{ID: "DT_1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailStore := PageDetailStore{
+				db: mysqldb,
+			}
+			err := testPageDetailStoreClearAllTables(pageDetailStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageDetailStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			err = pageDetailStore.UpdatePageDetail(tc.paramPageGUID, tc.paramRecord)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			d, err := pageDetailStore.GetPageDetail(tc.paramPageGUID, tc.paramRecord.GUID)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDBDetail, d)
+		})
+	}
+}
+
+func TestRemovePageDetail(t *testing.T) {
+	cases := []struct {
+		name                string
+		preTestQueries      []string
+		paramPageGUID       string
+		paramPageDetailGUID string
+		expectedDBDetails   []pagedetail.PageDetail
+		returnErr           error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES( 1, \"DT_1\", \"first\", \"\", \"[]\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES( 1, \"DT_2\", \"second\", \"\", \"[]\", NOW(), NOW() )",
+				"INSERT INTO PageDetailOrder (`Page_ID`, `PageDetail_ID`, `order`) VALUES( 1, 1, 0 )",
+				"INSERT INTO PageDetailOrder (`Page_ID`, `PageDetail_ID`, `order`) VALUES( 1, 2, 1 )",
+			},
+			paramPageGUID:       "PG_1",
+			paramPageDetailGUID: "DT_1",
+			expectedDBDetails: []pagedetail.PageDetail{
+				{ID: 2, GUID: "DT_2", Title: "second", Partitions: []pagedetail.Partition{}},
+			},
+		},
+		{
+			name: "already removed",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, \"DT_1\", \"first\", \"\", \"[]\", NOW(), NOW(), NOW() )",
+			},
+			paramPageGUID:       "PG_1",
+			paramPageDetailGUID: "DT_1",
+			returnErr:           &storeerror.NotFound{ID: "DT_1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailStore := PageDetailStore{
+				db: mysqldb,
+			}
+			err := testPageDetailStoreClearAllTables(pageDetailStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageDetailStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			err = pageDetailStore.RemovePageDetail(tc.paramPageGUID, tc.paramPageDetailGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			ds, err := pageDetailStore.GetPageDetails(tc.paramPageGUID)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDBDetails, ds)
+		})
+	}
+}
+
+func TestReorderPageDetails(t *testing.T) {
+	cases := []struct {
+		name                 string
+		preTestQueries       []string
+		paramPageGUID        string
+		paramPageDetailGUIDs []string
+		expectedDBDetails    []pagedetail.PageDetail
+		returnErr            error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES( 1, \"DT_1\", \"first\", \"\", \"[]\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES( 1, \"DT_2\", \"second\", \"\", \"[]\", NOW(), NOW() )",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES( 1, \"DT_3\", \"third\", \"\", \"[]\", NOW(), NOW() )",
+				"INSERT INTO PageDetailOrder (`Page_ID`, `PageDetail_ID`, `order`) VALUES( 1, 1, 0 )",
+				"INSERT INTO PageDetailOrder (`Page_ID`, `PageDetail_ID`, `order`) VALUES( 1, 2, 1 )",
+				"INSERT INTO PageDetailOrder (`Page_ID`, `PageDetail_ID`, `order`) VALUES( 1, 3, 2 )",
+			},
+			paramPageGUID:        "PG_1",
+			paramPageDetailGUIDs: []string{"DT_3", "DT_1", "DT_2"},
+			expectedDBDetails: []pagedetail.PageDetail{
+				{ID: 3, GUID: "DT_3", Title: "third", Partitions: []pagedetail.Partition{}},
+				{ID: 1, GUID: "DT_1", Title: "first", Partitions: []pagedetail.Partition{}},
+				{ID: 2, GUID: "DT_2", Title: "second", Partitions: []pagedetail.Partition{}},
+			},
+		},
+		{
+			name: "detail does not exist",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+			},
+			paramPageGUID:        "PG_1",
+			paramPageDetailGUIDs: []string{"DT_1"},
+			returnErr:            &storeerror.NotFound{ID: "DT_1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageDetailStore := PageDetailStore{
+				db: mysqldb,
+			}
+			err := testPageDetailStoreClearAllTables(pageDetailStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageDetailStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			err = pageDetailStore.ReorderPageDetails(tc.paramPageGUID, tc.paramPageDetailGUIDs)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			ds, err := pageDetailStore.GetPageDetails(tc.paramPageGUID)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDBDetails, ds)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package mysqlstore
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
@@ -11,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
 )
@@ -84,17 +87,18 @@ func (s PageStore) CreatePage(record page.Page, ownerID int64) (page.Page, error
 	return record, nil
 }
 
-// CanEditPage checks if the given user can modify the given page. If not, a storeerror.NotAuthorized will be returned.
-// Will also return whether or not the user is the original owner.
-func (s PageStore) CanEditPage(guid, userID string) (bool, error) {
+// GetPageRole returns how the given user is related to the given page, which is permission.RoleNone if they are not
+// or the page does not exist. An owner or editor of the page takes precedence over a campaign member,
+// who takes precedence over the role the page's permission gives to every user.
+func (s PageStore) GetPageRole(guid, userID string) (permission.Role, error) {
 	if guid == "" {
-		return false, errors.New("must provide a guid to check privileges")
+		return permission.RoleNone, errors.New("must provide a guid to get the role")
 	}
 	if userID == "" {
-		return false, errors.New("must provide a userID to check privileges")
+		return permission.RoleNone, errors.New("must provide a userID to get the role")
 	}
 	if s.db == nil {
-		return false, &storeerror.DBNotSetUp{}
+		return permission.RoleNone, &storeerror.DBNotSetUp{}
 	}
 	statement := wrapsql.SelectStatement{
 		Selectors: []string{"PageOwner.isOwner"},
@@ -114,31 +118,16 @@ func (s PageStore) CanEditPage(guid, userID string) (bool, error) {
 	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid, userID)
 	var isOwner bool
 	err = wrapsql.GetSingleRow(guid, rows, err, &isOwner)
-	if _, ok := err.(*storeerror.NotFound); ok {
-		return false, &storeerror.NotAuthorized{
-			UserID:  userID,
-			TableID: guid,
+	if err == nil {
+		if isOwner {
+			return permission.RoleOwner, nil
 		}
+		return permission.RoleEditor, nil
 	}
-	return isOwner, err
-}
-
-// CanReadPage checks if the given user can read the given page. If not, a storeerror.NotAuthorized will be returned.
-// Will also return whether or not the user is the original owner.
-func (s PageStore) CanReadPage(guid, userID string) (bool, error) {
-	isOwner, err := s.CanEditPage(guid, userID)
-	if err != nil {
-		if _, ok := err.(*storeerror.NotAuthorized); !ok {
-			return isOwner, err
-		}
-	}
-	if isOwner {
-		return isOwner, nil
-	}
-	if s.db == nil {
-		return isOwner, &storeerror.DBNotSetUp{}
+	if _, ok := err.(*storeerror.NotFound); !ok {
+		return permission.RoleNone, err
 	}
-	statement := wrapsql.SelectStatement{
+	statement = wrapsql.SelectStatement{
 		Selectors: []string{"permission"},
 		FromTable: "Page",
 		WhereClause: wrapsql.WhereClause{
@@ -148,20 +137,57 @@ func (s PageStore) CanReadPage(guid, userID string) (bool, error) {
 		},
 		Limit: 1,
 	}
-	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	rows, err = s.db.Query(wrapsql.GetSelectString(statement), guid)
 	var pagePermission string
 	err = wrapsql.GetSingleRow(guid, rows, err, &pagePermission)
 	if _, ok := err.(*storeerror.NotFound); ok {
-		return false, &storeerror.NotAuthorized{
-			UserID:  userID,
-			TableID: guid,
-		}
+		return permission.RoleNone, nil
+	}
+	if err != nil {
+		return permission.RoleNone, err
 	}
 	p, err := permission.GetPermissionType(pagePermission)
+	if err != nil {
+		return permission.RoleNone, err
+	}
+	if p.Role() == permission.RolePublic {
+		return permission.RolePublic, nil
+	}
+	isMember, err := s.isCampaignMemberOfPage(guid, userID)
+	if err != nil {
+		return permission.RoleNone, errors.Wrapf(err, "unable to check the campaigns of page: %v", guid)
+	}
+	if isMember {
+		return permission.RoleViewer, nil
+	}
+	return p.Role(), nil
+}
+
+func (s PageStore) isCampaignMemberOfPage(guid, userID string) (bool, error) {
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"COUNT(1)"},
+		FromTable: "CampaignPage",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "CampaignPage.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "Campaign", On: wrapsql.OnClause{LeftSide: "CampaignPage.Campaign_ID", RightSide: "Campaign.ID"}},
+			{JoinTable: "CampaignMember", On: wrapsql.OnClause{LeftSide: "CampaignMember.Campaign_ID", RightSide: "Campaign.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "CampaignMember.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Campaign.deletedAt", Operator: "IS NULL"},
+			},
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid, userID)
+	var total int
+	err = wrapsql.GetSingleRow("", rows, err, &total)
 	if err != nil {
 		return false, err
 	}
-	return p.IsPublic(), nil
+	return total > 0, nil
 }
 
 // UpdatePage sets the given page.
@@ -199,6 +225,35 @@ func (s PageStore) UpdatePage(record page.Page) error {
 	return wrapsql.ExecSingleUpdate(s.db, query, record.GUID)
 }
 
+// GetPageOwnerGUID returns the guid of the user who owns the given page, rather than of any user it was shared with.
+func (s PageStore) GetPageOwnerGUID(guid string) (string, error) {
+	if guid == "" {
+		return "", errors.New("must provide guid to get the page owner")
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"User.guid"},
+		FromTable: "PageOwner",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageOwner.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageOwner.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "PageOwner.isOwner", Operator: "= ?"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid, true)
+	var ownerGUID string
+	err = wrapsql.GetSingleRow(guid, rows, err, &ownerGUID)
+	return ownerGUID, err
+}
+
 // GetPage returns back the given page.
 func (s PageStore) GetPage(guid string) (page.Page, error) {
 	if guid == "" {
@@ -236,40 +291,55 @@ func (s PageStore) GetPage(guid string) (page.Page, error) {
 	return p, err
 }
 
-// GetPages returns a list of pages based on the nextBatchId
-func (s PageStore) GetPages(userID, thisBatchID string, limit int) (pages []page.Page, total int, nextBatchID string, returnErr error) {
+// GetPages returns a batch of the pages matching the filter in the order of the sort, starting at the cursor.
+// The returned cursor marks the start of the next batch, and is zero if there are no more pages.
+func (s PageStore) GetPages(userID string, filter pagefilter.Filter, sort pagesort.Sort, cursor pagesort.Cursor, limit int) (pages []page.Page, total int, nextCursor pagesort.Cursor, returnErr error) {
 	if userID == "" {
 		returnErr = errors.New("must provide userID to get pages")
 		return
 	}
-	var err error
-	thisPageID := int64(0)
-	if thisBatchID != "" {
-		thisPageID, err = s.getPageID(thisBatchID)
+	sortColumn, err := getPageSortColumn(sort.Field)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	sortBy := "ASC"
+	cursorOperator := ">= (?,?)"
+	if sort.Order == pagesort.OrderDescending {
+		sortBy = "DESC"
+		cursorOperator = "<= (?,?)"
+	}
+	whereOperations, values := getPageListWhereOperations(filter)
+	if !cursor.IsZero() {
+		if cursor.Sort != sort {
+			returnErr = errors.Errorf("cursor sort %+v does not match the sort %+v", cursor.Sort, sort)
+			return
+		}
+		cursorValue, err := getPageCursorValue(cursor)
 		if err != nil {
-			returnErr = errors.Wrapf(err, "unable to use thisBatchID: %v", thisBatchID)
+			returnErr = errors.Wrapf(err, "unable to use cursor: %+v", cursor)
 			return
 		}
+		// the page ID breaks ties in the sort column, so that each page has a distinct position
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: wrapsql.GetRowString(sortColumn, "Page.ID"), Operator: cursorOperator})
+		values = append(values, cursorValue, cursor.PageID)
 	}
 	statement := wrapsql.SelectStatement{
-		Selectors: []string{"Page.guid", "Page.ID", "Version.guid", "PageTemplate.guid", "Page.title", "Page.summary", "Page.permission", "Page.createdAt", "Page.updatedAt"},
-		FromTable: "Page",
-		JoinClauses: []wrapsql.JoinClause{
-			{JoinTable: "PageOwner", On: wrapsql.OnClause{LeftSide: "PageOwner.Page_ID", RightSide: "Page.ID"}},
-			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageOwner.User_ID", RightSide: "User.ID"}},
-			{JoinTable: "Version", On: wrapsql.OnClause{LeftSide: "Page.Version_ID", RightSide: "Version.ID"}},
-			{JoinTable: "PageTemplate", On: wrapsql.OnClause{LeftSide: "Page.PageTemplate_ID", RightSide: "PageTemplate.ID"}},
-		},
+		Selectors:   []string{"Page.guid", "Page.ID", "Version.guid", "PageTemplate.guid", "Page.title", "Page.summary", "Page.permission", "Page.createdAt", "Page.updatedAt"},
+		FromTable:   "Page",
+		JoinClauses: getPageListJoinClauses(),
 		WhereClause: wrapsql.WhereClause{
-			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
-				{LeftSide: "Page.ID", Operator: fmt.Sprintf(">= %v", thisPageID)},
-				{LeftSide: "User.guid", Operator: "= ?"},
-				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
-			},
+			Operator:        "AND",
+			WhereOperations: whereOperations,
 		},
-		Limit: limit + 1, // plus one so we can get an extra record to determine the nextBatchID
+		OrderClause: wrapsql.OrderClause{
+			Column: sortColumn,
+			SortBy: sortBy,
+			ThenBy: []wrapsql.OrderClause{{Column: "Page.ID", SortBy: sortBy}},
+		},
+		Limit: limit + 1, // plus one so we can get an extra record to determine the next cursor
 	}
-	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), append([]interface{}{userID}, values...)...)
 	if err != nil {
 		returnErr = err
 		return
@@ -299,36 +369,73 @@ func (s PageStore) GetPages(userID, thisBatchID string, limit int) (pages []page
 		pages = make([]page.Page, 0)
 	}
 	if len(pages) > limit {
-		lastPage := pages[len(pages)-1]
-		nextBatchID = lastPage.GUID
+		nextCursor = getPageCursor(pages[len(pages)-1], sort)
 		pages = pages[:len(pages)-1]
 	}
-	total, err = s.getTotalPages(userID)
+	total, err = s.getTotalPages(userID, filter)
 	if err != nil {
 		returnErr = err
 	}
 	return
 }
 
-func (s PageStore) getTotalPages(userID string) (int, error) {
+func getPageSortColumn(field pagesort.Field) (string, error) {
+	switch field {
+	case pagesort.FieldTitle:
+		return "Page.title", nil
+	case pagesort.FieldCreatedAt:
+		return "Page.createdAt", nil
+	case pagesort.FieldUpdatedAt:
+		return "Page.updatedAt", nil
+	default:
+		return "", errors.Errorf("invalid sort field %v", field)
+	}
+}
+
+// getPageCursor returns the cursor that marks the position of the page in the sort.
+func getPageCursor(p page.Page, sort pagesort.Sort) pagesort.Cursor {
+	cursor := pagesort.Cursor{Sort: sort, PageID: p.ID}
+	switch sort.Field {
+	case pagesort.FieldTitle:
+		cursor.Value = p.Title
+	case pagesort.FieldCreatedAt:
+		cursor.Value = formatCursorTime(p.CreatedAt)
+	case pagesort.FieldUpdatedAt:
+		cursor.Value = formatCursorTime(p.UpdatedAt)
+	}
+	return cursor
+}
+
+func formatCursorTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// getPageCursorValue returns the cursor's value as it is compared against the sort column.
+func getPageCursorValue(cursor pagesort.Cursor) (interface{}, error) {
+	if cursor.Sort.Field == pagesort.FieldTitle {
+		return cursor.Value, nil
+	}
+	return time.Parse(time.RFC3339Nano, cursor.Value)
+}
+
+func (s PageStore) getTotalPages(userID string, filter pagefilter.Filter) (int, error) {
 	if userID == "" {
 		return -1, errors.New("must provide userID to get pages")
 	}
+	whereOperations, values := getPageListWhereOperations(filter)
 	statement := wrapsql.SelectStatement{
-		Selectors: []string{"COUNT(1)"},
-		FromTable: "Page",
-		JoinClauses: []wrapsql.JoinClause{
-			{JoinTable: "PageOwner", On: wrapsql.OnClause{LeftSide: "PageOwner.Page_ID", RightSide: "Page.ID"}},
-			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageOwner.User_ID", RightSide: "User.ID"}},
-		},
+		Selectors:   []string{"COUNT(1)"},
+		FromTable:   "Page",
+		JoinClauses: getPageListJoinClauses(),
 		WhereClause: wrapsql.WhereClause{
-			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
-				{LeftSide: "User.guid", Operator: "= ?"},
-				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
-			},
+			Operator:        "AND",
+			WhereOperations: whereOperations,
 		},
 	}
-	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), append([]interface{}{userID}, values...)...)
 	var total int
 	err = wrapsql.GetSingleRow(userID, rows, err, &total)
 	if err != nil {
@@ -337,7 +444,189 @@ func (s PageStore) getTotalPages(userID string) (int, error) {
 	return total, nil
 }
 
+// GetPageFacets returns the number of the user's pages matching the filter for each page template and for each value of their string properties.
+func (s PageStore) GetPageFacets(userID string, filter pagefilter.Filter) (pagefilter.Facets, error) {
+	facets := pagefilter.Facets{
+		PageTemplates: make([]pagefilter.PageTemplateFacet, 0),
+		Properties:    make([]pagefilter.PropertyFacet, 0),
+	}
+	if userID == "" {
+		return facets, errors.New("must provide userID to get page facets")
+	}
+	var err error
+	facets.PageTemplates, err = s.getPageTemplateFacets(userID, filter)
+	if err != nil {
+		return facets, errors.Wrap(err, "failed to get page template facets")
+	}
+	facets.Properties, err = s.getPropertyFacets(userID, filter)
+	if err != nil {
+		return facets, errors.Wrap(err, "failed to get property facets")
+	}
+	return facets, nil
+}
+
+func (s PageStore) getPageTemplateFacets(userID string, filter pagefilter.Filter) ([]pagefilter.PageTemplateFacet, error) {
+	templateFacets := make([]pagefilter.PageTemplateFacet, 0)
+	whereOperations, values := getPageListWhereOperations(filter)
+	statement := wrapsql.SelectStatement{
+		Selectors:   []string{"PageTemplate.guid", "PageTemplate.name", "COUNT(1)"},
+		FromTable:   "Page",
+		JoinClauses: getPageListJoinClauses(),
+		WhereClause: wrapsql.WhereClause{
+			Operator:        "AND",
+			WhereOperations: whereOperations,
+		},
+		GroupBy: []string{"PageTemplate.guid", "PageTemplate.name"},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), append([]interface{}{userID}, values...)...)
+	if err != nil {
+		return templateFacets, err
+	}
+	if err := rows.Err(); err != nil {
+		return templateFacets, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var f pagefilter.PageTemplateFacet
+		err := rows.Scan(&f.PageTemplateGUID, &f.Name, &f.Count)
+		if err != nil {
+			return templateFacets, err
+		}
+		templateFacets = append(templateFacets, f)
+	}
+	sort.Slice(templateFacets, func(i, j int) bool {
+		if templateFacets[i].Count != templateFacets[j].Count {
+			return templateFacets[i].Count > templateFacets[j].Count
+		}
+		return templateFacets[i].Name < templateFacets[j].Name
+	})
+	return templateFacets, nil
+}
+
+func (s PageStore) getPropertyFacets(userID string, filter pagefilter.Filter) ([]pagefilter.PropertyFacet, error) {
+	propertyFacets := make([]pagefilter.PropertyFacet, 0)
+	whereOperations, values := getPageListWhereOperations(filter)
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Property.key", "PagePropertyString.value", "COUNT(1)"},
+		FromTable: "Page",
+		JoinClauses: append(getPageListJoinClauses(),
+			wrapsql.JoinClause{JoinTable: "PagePropertyString", On: wrapsql.OnClause{LeftSide: "Page.ID", RightSide: "PagePropertyString.Page_ID"}},
+			wrapsql.JoinClause{JoinTable: "Property", On: wrapsql.OnClause{LeftSide: "PagePropertyString.Property_ID", RightSide: "Property.ID"}},
+		),
+		WhereClause: wrapsql.WhereClause{
+			Operator:        "AND",
+			WhereOperations: append(whereOperations, wrapsql.WhereOperation{LeftSide: "PagePropertyString.deletedAt", Operator: "IS NULL"}),
+		},
+		GroupBy: []string{"Property.key", "PagePropertyString.value"},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), append([]interface{}{userID}, values...)...)
+	if err != nil {
+		return propertyFacets, err
+	}
+	if err := rows.Err(); err != nil {
+		return propertyFacets, err
+	}
+	defer rows.Close()
+	keyIndexes := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var f pagefilter.ValueFacet
+		err := rows.Scan(&key, &f.Value, &f.Count)
+		if err != nil {
+			return propertyFacets, err
+		}
+		index, ok := keyIndexes[key]
+		if !ok {
+			index = len(propertyFacets)
+			keyIndexes[key] = index
+			propertyFacets = append(propertyFacets, pagefilter.PropertyFacet{Key: key})
+		}
+		propertyFacets[index].Values = append(propertyFacets[index].Values, f)
+	}
+	sort.Slice(propertyFacets, func(i, j int) bool {
+		return propertyFacets[i].Key < propertyFacets[j].Key
+	})
+	for _, propertyFacet := range propertyFacets {
+		valueFacets := propertyFacet.Values
+		sort.Slice(valueFacets, func(i, j int) bool {
+			if valueFacets[i].Count != valueFacets[j].Count {
+				return valueFacets[i].Count > valueFacets[j].Count
+			}
+			return valueFacets[i].Value < valueFacets[j].Value
+		})
+	}
+	return propertyFacets, nil
+}
+
+// getPageListJoinClauses joins a page to its owner, version and page template.
+func getPageListJoinClauses() []wrapsql.JoinClause {
+	return []wrapsql.JoinClause{
+		{JoinTable: "PageOwner", On: wrapsql.OnClause{LeftSide: "PageOwner.Page_ID", RightSide: "Page.ID"}},
+		{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageOwner.User_ID", RightSide: "User.ID"}},
+		{JoinTable: "Version", On: wrapsql.OnClause{LeftSide: "Page.Version_ID", RightSide: "Version.ID"}},
+		{JoinTable: "PageTemplate", On: wrapsql.OnClause{LeftSide: "Page.PageTemplate_ID", RightSide: "PageTemplate.ID"}},
+	}
+}
+
+// getPageListWhereOperations narrows the joined pages down to the user's pages that match the filter.
+// The user's guid is the first injected value, followed by the returned values.
+func getPageListWhereOperations(filter pagefilter.Filter) ([]wrapsql.WhereOperation, []interface{}) {
+	whereOperations := []wrapsql.WhereOperation{
+		{LeftSide: "User.guid", Operator: "= ?"},
+		{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+	}
+	var values []interface{}
+	if filter.PageTemplateGUID != "" {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "PageTemplate.guid", Operator: "= ?"})
+		values = append(values, filter.PageTemplateGUID)
+	}
+	if filter.VersionGUID != "" {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "Version.guid", Operator: "= ?"})
+		values = append(values, filter.VersionGUID)
+	}
+	if filter.PermissionType != "" {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "Page.permission", Operator: "= ?"})
+		values = append(values, string(filter.PermissionType))
+	}
+	if filter.OwnedOnly {
+		whereOperations = append(whereOperations, wrapsql.WhereOperation{LeftSide: "PageOwner.isOwner", Operator: "= ?"})
+		values = append(values, true)
+	}
+	for _, condition := range filter.Conditions {
+		whereOperations = append(whereOperations, getConditionWhereOperation(condition))
+		values = append(values, condition.Key, condition.Value())
+	}
+	return whereOperations, values
+}
+
+// getConditionWhereOperation matches the pages with a property that meets the condition, injecting the key and then the value.
+func getConditionWhereOperation(condition pagefilter.Condition) wrapsql.WhereOperation {
+	tableName := "PagePropertyString"
+	if condition.Type == property.TypeNumber {
+		tableName = "PagePropertyNumber"
+	}
+	subquery := wrapsql.SelectStatement{
+		Selectors: []string{tableName + ".Page_ID"},
+		FromTable: tableName,
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Property", On: wrapsql.OnClause{LeftSide: tableName + ".Property_ID", RightSide: "Property.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Property.key", Operator: "= ?"},
+				{LeftSide: tableName + ".value", Operator: fmt.Sprintf("%v ?", condition.Operator)},
+				{LeftSide: tableName + ".deletedAt", Operator: "IS NULL"},
+			},
+		},
+	}
+	return wrapsql.WhereOperation{LeftSide: "Page.ID", Operator: fmt.Sprintf("IN (%v)", wrapsql.GetSelectString(subquery))}
+}
+
 func (s PageStore) getPageID(guid string) (int64, error) {
+	return getPageID(s.db, guid)
+}
+
+func getPageID(db *sql.DB, guid string) (int64, error) {
 	if guid == "" {
 		return -1, errors.New("must provide guid to get the page id")
 	}
@@ -351,7 +640,7 @@ func (s PageStore) getPageID(guid string) (int64, error) {
 		},
 		Limit: 1,
 	}
-	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	rows, err := db.Query(wrapsql.GetSelectString(statement), guid)
 	var pageID int64
 	err = wrapsql.GetSingleRow(guid, rows, err, &pageID)
 	return pageID, err
@@ -366,6 +655,357 @@ func (s PageStore) RemovePage(guid string) error {
 	})
 }
 
+// GetRemovedPages returns the removed pages the user can edit, most recently removed first.
+func (s PageStore) GetRemovedPages(userID string) (pages []page.Page, returnErr error) {
+	if userID == "" {
+		returnErr = errors.New("must provide userID to get removed pages")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors:   []string{"Page.guid", "Page.ID", "Version.guid", "PageTemplate.guid", "Page.title", "Page.summary", "Page.permission", "Page.createdAt", "Page.updatedAt", "Page.deletedAt"},
+		FromTable:   "Page",
+		JoinClauses: getPageListJoinClauses(),
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NOT NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Page.deletedAt",
+			SortBy: "DESC",
+			ThenBy: []wrapsql.OrderClause{{Column: "Page.ID", SortBy: "DESC"}},
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	pages = make([]page.Page, 0)
+	var permissionString string
+	defer rows.Close()
+	for rows.Next() {
+		p := page.Page{}
+		err := rows.Scan(&p.GUID, &p.ID, &p.Version.GUID, &p.PageTemplate.GUID, &p.Title, &p.Summary, &permissionString, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		pt, err := permission.GetPermissionType(permissionString)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		p.PermissionType = pt
+		pages = append(pages, p)
+	}
+	return
+}
+
+// GetRemovedPageGUIDs returns the guids of every page that was removed before the given time, in the order they were created.
+func (s PageStore) GetRemovedPageGUIDs(removedBefore time.Time) (returnGUIDs []string, returnErr error) {
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"guid"},
+		FromTable: "Page",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "deletedAt", Operator: "< ?"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "ID",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), removedBefore)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnGUIDs = make([]string, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var guid string
+		err := rows.Scan(&guid)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		returnGUIDs = append(returnGUIDs, guid)
+	}
+	return
+}
+
+// getRemovedPageID returns the id of the given page if it has been removed, or a storeerror.NotFound if it has not.
+func (s PageStore) getRemovedPageID(guid string) (int64, error) {
+	if guid == "" {
+		return -1, errors.New("must provide guid to get the removed page id")
+	}
+	if s.db == nil {
+		return -1, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"ID"},
+		FromTable: "Page",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+				{LeftSide: "deletedAt", Operator: "IS NOT NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	var pageID int64
+	err = wrapsql.GetSingleRow(guid, rows, err, &pageID)
+	return pageID, err
+}
+
+// RestorePage undoes the removal of the given page.
+// If the page has not been removed, a storeerror.NotFound will be returned.
+func (s PageStore) RestorePage(guid string) error {
+	pageID, err := s.getRemovedPageID(guid)
+	if err != nil {
+		return err
+	}
+	return wrapsql.ExecSingleUpdate(s.db, wrapsql.UpdateQuery{
+		UpdateTable:    "Page",
+		InjectedValues: wrapsql.InjectedValues{"deletedAt": nil},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "ID", Operator: "= ?"},
+			},
+		},
+	}, pageID)
+}
+
+// PurgePage permanently deletes the given removed page, along with its properties, details, orders, owners,
+// revisions, forks and campaign memberships. Relations to the page from other pages are left dangling.
+// If the page has not been removed, a storeerror.NotFound will be returned.
+// The page itself is deleted last, so that a purge that fails part way can be tried again.
+func (s PageStore) PurgePage(guid string) error {
+	pageID, err := s.getRemovedPageID(guid)
+	if err != nil {
+		return err
+	}
+	pageDetailIDs := "IN (SELECT `ID` FROM `PageDetail` WHERE `Page_ID` = ?)"
+	purgeQueries := []struct {
+		query  wrapsql.DeleteQuery
+		values []interface{}
+	}{
+		{
+			query: wrapsql.DeleteQuery{FromTable: "PageDetailRelation", WhereClause: wrapsql.WhereClause{
+				Operator: "AND", WhereOperations: []wrapsql.WhereOperation{{LeftSide: "PageDetail_ID", Operator: pageDetailIDs}},
+			}},
+			values: []interface{}{pageID},
+		},
+		{
+			query: wrapsql.DeleteQuery{FromTable: "PageDetailFork", WhereClause: wrapsql.WhereClause{
+				Operator: "OR", WhereOperations: []wrapsql.WhereOperation{
+					{LeftSide: "PageDetail_ID", Operator: pageDetailIDs},
+					{LeftSide: "Source_PageDetail_ID", Operator: pageDetailIDs},
+				},
+			}},
+			values: []interface{}{pageID, pageID},
+		},
+		{
+			query:  wrapsql.DeleteQuery{FromTable: "PageDetailOrder", WhereClause: getPageIDWhereClause("Page_ID")},
+			values: []interface{}{pageID},
+		},
+		{
+			query:  wrapsql.DeleteQuery{FromTable: "PageDetail", WhereClause: getPageIDWhereClause("Page_ID")},
+			values: []interface{}{pageID},
+		},
+		{
+			query: wrapsql.DeleteQuery{FromTable: "PageFork", WhereClause: wrapsql.WhereClause{
+				Operator: "OR", WhereOperations: []wrapsql.WhereOperation{
+					{LeftSide: "Page_ID", Operator: "= ?"},
+					{LeftSide: "Source_Page_ID", Operator: "= ?"},
+				},
+			}},
+			values: []interface{}{pageID, pageID},
+		},
+		{
+			query:  wrapsql.DeleteQuery{FromTable: "CampaignPage", WhereClause: getPageIDWhereClause("Page_ID")},
+			values: []interface{}{pageID},
+		},
+		{
+			query:  wrapsql.DeleteQuery{FromTable: "PageRevision", WhereClause: getPageIDWhereClause("Page_ID")},
+			values: []interface{}{pageID},
+		},
+	}
+	for _, purgeQuery := range purgeQueries {
+		err := wrapsql.ExecDelete(s.db, purgeQuery.query, purgeQuery.values...)
+		if err != nil {
+			return errors.Wrapf(err, "unable to delete from %v", purgeQuery.query.FromTable)
+		}
+	}
+	err = s.deletePageProperties(pageID)
+	if err != nil {
+		return err
+	}
+	err = wrapsql.ExecDelete(s.db, wrapsql.DeleteQuery{FromTable: "PageOwner", WhereClause: getPageIDWhereClause("Page_ID")}, pageID)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete from PageOwner")
+	}
+	err = wrapsql.ExecDelete(s.db, wrapsql.DeleteQuery{FromTable: "Page", WhereClause: getPageIDWhereClause("ID")}, pageID)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete from Page")
+	}
+	return nil
+}
+
+func getPageIDWhereClause(column string) wrapsql.WhereClause {
+	return wrapsql.WhereClause{
+		Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+			{LeftSide: column, Operator: "= ?"},
+		},
+	}
+}
+
+// GetUserPageGUIDs returns the guids of the pages that the user has a role in whatever their permission,
+// which are those they own or edit and those in the campaigns they are a member of, that have not been removed.
+func (s PageStore) GetUserPageGUIDs(userID string) ([]string, error) {
+	if userID == "" {
+		return nil, errors.New("must provide userID to get the user's pages")
+	}
+	if s.db == nil {
+		return nil, &storeerror.DBNotSetUp{}
+	}
+	ownedGUIDs, err := s.queryPageGUIDs(wrapsql.SelectStatement{
+		Selectors: []string{"Page.guid"},
+		FromTable: "Page",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "PageOwner", On: wrapsql.OnClause{LeftSide: "PageOwner.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageOwner.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Page.ID",
+			SortBy: "ASC",
+		},
+	}, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get the pages owned by user: %v", userID)
+	}
+	campaignGUIDs, err := s.queryPageGUIDs(wrapsql.SelectStatement{
+		Selectors: []string{"Page.guid"},
+		FromTable: "CampaignPage",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "CampaignPage.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "Campaign", On: wrapsql.OnClause{LeftSide: "CampaignPage.Campaign_ID", RightSide: "Campaign.ID"}},
+			{JoinTable: "CampaignMember", On: wrapsql.OnClause{LeftSide: "CampaignMember.Campaign_ID", RightSide: "Campaign.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "CampaignMember.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "Campaign.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Page.ID",
+			SortBy: "ASC",
+		},
+	}, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get the campaign pages of user: %v", userID)
+	}
+	seen := make(map[string]bool)
+	guids := make([]string, 0, len(ownedGUIDs)+len(campaignGUIDs))
+	for _, guid := range append(ownedGUIDs, campaignGUIDs...) {
+		if seen[guid] {
+			continue
+		}
+		seen[guid] = true
+		guids = append(guids, guid)
+	}
+	return guids, nil
+}
+
+func (s PageStore) queryPageGUIDs(statement wrapsql.SelectStatement, values ...interface{}) ([]string, error) {
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	guids := make([]string, 0)
+	for rows.Next() {
+		var guid string
+		err := rows.Scan(&guid)
+		if err != nil {
+			return nil, err
+		}
+		guids = append(guids, guid)
+	}
+	return guids, rows.Err()
+}
+
+// GetAllPageGUIDs returns the guids of every page that has not been removed, in the order they were created.
+func (s PageStore) GetAllPageGUIDs() (returnGUIDs []string, returnErr error) {
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"guid"},
+		FromTable: "Page",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "ID",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement))
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnGUIDs = make([]string, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var guid string
+		err := rows.Scan(&guid)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		returnGUIDs = append(returnGUIDs, guid)
+	}
+	return
+}
+
 // GetUniquePageGUID returns a guid for the page that is guaranteed to be unique or errors.
 // If the proposedPageGuid is not a zero-value and not unique, it will error.
 func (s PageStore) GetUniquePageGUID(proposedPageGUID string) (string, error) {
@@ -543,23 +1183,28 @@ func (s PageStore) getNumberTypePageProperties(pageGUID string) (returnPropertie
 }
 
 // ReplacePageProperties replaces the current page's properties with the new properties.
+// Each of the pageProperties must have the ID of its registered property set.
 func (s PageStore) ReplacePageProperties(pageGUID string, pageProperties []property.Property) error {
 	// @TODO: all this needs to be wrapped into a transaction with rollback.
 	if pageGUID == "" {
 		return errors.New("must provide pageGUID to replace the page properties")
 	}
-	pageID, err := s.getPageID(pageGUID)
-	if err != nil {
-		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", pageID)
+	for i, p := range pageProperties {
+		if p.ID == 0 {
+			return errors.Errorf("must provide the ID for the property at %v with key %v", i, p.Key)
+		}
 	}
-	err = s.setPagePropertyIDs(pageProperties)
+	pageID, err := s.getPageID(pageGUID)
 	if err != nil {
-		return errors.Wrap(err, "unable to get Property.ID for the pageProperties")
+		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", pageGUID)
 	}
 	err = s.deletePageProperties(pageID)
 	if err != nil {
 		return errors.Wrap(err, "unable to delete page properties")
 	}
+	if len(pageProperties) == 0 {
+		return nil
+	}
 	err = s.addPagePropertyOrders(pageID, pageProperties)
 	if err != nil {
 		return errors.Wrap(err, "unable to add page properties orders")
@@ -577,7 +1222,8 @@ func (s PageStore) ReplacePageProperties(pageGUID string, pageProperties []prope
 
 func (s PageStore) addPagePropertyOrders(pageID int64, pageProperties []property.Property) error {
 	query := wrapsql.BatchInsertQuery{
-		IntoTable: "PagePropertyOrder",
+		IntoTable:           "PagePropertyOrder",
+		BatchInjectedValues: wrapsql.BatchInjectedValues{},
 	}
 	for i, pageProperty := range pageProperties {
 		query.BatchInjectedValues["Page_ID"] = append(query.BatchInjectedValues["Page_ID"], pageID)
@@ -607,7 +1253,8 @@ func (s PageStore) addTypedPageProperties(pageID int64, pageProperties []propert
 		return errors.Errorf("unsupported page property type for instert: %v", propertyType)
 	}
 	query := wrapsql.BatchInsertQuery{
-		IntoTable: tableName,
+		IntoTable:           tableName,
+		BatchInjectedValues: wrapsql.BatchInjectedValues{},
 	}
 	for _, pageProperty := range scopedPageProperties {
 		query.BatchInjectedValues["Page_ID"] = append(query.BatchInjectedValues["Page_ID"], pageID)
@@ -670,67 +1317,3 @@ func (s PageStore) deletePageProperties(pageID int64) error {
 	}
 	return nil
 }
-
-func (s PageStore) setPagePropertyIDs(pageProperties []property.Property) error {
-	var keys []string
-	for _, p := range pageProperties {
-		keys = append(keys, p.Key)
-	}
-	pps, err := s.getPropertyIDs(keys)
-	if err != nil {
-		return err
-	}
-	for _, pp := range pps {
-		for i := range pageProperties {
-			if pageProperties[i].Key == pp.Key {
-				pageProperties[i].ID = pp.ID
-			}
-		}
-	}
-	for i, p := range pageProperties {
-		if p.ID == 0 {
-			return errors.Errorf("unable to find the ID for the property at %v with key %v", i, p.Key)
-		}
-	}
-	return nil
-}
-
-func (s PageStore) getPropertyIDs(propertyKeys []string) (returnProperties []property.Property, returnErr error) {
-	for i, propertyKey := range propertyKeys {
-		if propertyKey == "" {
-			return nil, errors.Errorf("property key at %v must be non-zero value", i)
-		}
-	}
-	statement := wrapsql.SelectStatement{
-		Selectors: []string{"ID", "key"},
-		FromTable: "Property",
-		WhereClause: wrapsql.WhereClause{
-			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
-				{LeftSide: "key", Operator: "IN (" + wrapsql.GetNValueStubList(len(propertyKeys)) + ")"},
-			},
-		},
-	}
-	rows, err := s.db.Query(wrapsql.GetSelectString(statement), propertyKeys)
-	if err != nil {
-		returnErr = err
-		return
-	}
-	if err := rows.Err(); err != nil {
-		returnErr = err
-		return
-	}
-	defer rows.Close()
-	for rows.Next() {
-		p := property.Property{}
-		err := rows.Scan(&p.ID, &p.Key)
-		if err != nil {
-			returnErr = err
-			return
-		}
-		returnProperties = append(returnProperties, p)
-	}
-	if len(returnProperties) == 0 {
-		returnProperties = make([]property.Property, 0)
-	}
-	return
-}
@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
@@ -17,7 +20,7 @@ import (
 )
 
 func testPageStoreClearAllTables(db *sql.DB) error {
-	tables := []string{"Page", "PageOwner", "PageTemplate", "User", "Version"}
+	tables := []string{"Campaign", "CampaignMember", "CampaignPage", "Page", "PageOwner", "PagePropertyNumber", "PagePropertyString", "PageTemplate", "Property", "User", "Version"}
 	for _, table := range tables {
 		err := clearTableForTest(db, table)
 		if err != nil {
@@ -105,33 +108,161 @@ func TestCreatePage(t *testing.T) {
 			p.UpdatedAt = nil
 			p.DeletedAt = nil
 			require.Equal(t, tc.expectedDPPage, p)
-			isOwner, err := pageStore.CanEditPage(tc.expectedPageGUID, tc.expectedOwnerGUID)
+			role, err := pageStore.GetPageRole(tc.expectedPageGUID, tc.expectedOwnerGUID)
 			require.NoError(t, err)
-			require.Equal(t, true, isOwner)
+			require.Equal(t, permission.RoleOwner, role)
 		})
 	}
 }
 
-func TestCanEditPage(t *testing.T) {
+func TestGetPageRole(t *testing.T) {
 	cases := []struct {
 		name                   string
 		shouldReplaceDBWithNil bool
 		preTestQueries         []string
 		paramGUID              string
 		paramUserID            string
-		returnCanEdit          bool
+		returnRole             permission.Role
 		returnErr              error
 	}{
 		{
-			name: "happy path",
+			name: "owner",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleOwner,
+		},
+		{
+			name: "editor",
 			preTestQueries: []string{
 				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
 				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, false)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleEditor,
+		},
+		{
+			name: "owner of a public page",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PU\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
 				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
 			},
-			paramGUID:     "PG_1",
-			paramUserID:   "UR_1",
-			returnCanEdit: true,
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleOwner,
+		},
+		{
+			name: "not owner but public",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PU\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RolePublic,
+		},
+		{
+			name: "not owner but link only",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"LO\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleLinkOnly,
+		},
+		{
+			name: "not owner and private",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleNone,
+		},
+		{
+			name: "not owner and private but campaign member",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 2, \"OW\")",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"VI\")",
+				"INSERT INTO CampaignPage (`Campaign_ID`, `Page_ID`) VALUES( 1, 1)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleViewer,
+		},
+		{
+			name: "link only and campaign member",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"LO\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"VI\")",
+				"INSERT INTO CampaignPage (`Campaign_ID`, `Page_ID`) VALUES( 1, 1)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleViewer,
+		},
+		{
+			name: "private page of a removed campaign",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"VI\")",
+				"INSERT INTO CampaignPage (`Campaign_ID`, `Page_ID`) VALUES( 1, 1)",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleNone,
+		},
+		{
+			name: "page does not exist",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+			},
+			paramGUID:   "PG_1",
+			paramUserID: "UR_1",
+			returnRole:  permission.RoleNone,
+		},
+		{
+			name:        "missing guid",
+			paramGUID:   "",
+			paramUserID: "UR_1",
+			returnErr:   errors.New("must provide a guid to get the role"),
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramGUID:              "PG_1",
+			paramUserID:            "UR_1",
+			returnErr:              &storeerror.DBNotSetUp{},
 		},
 	}
 	for _, tc := range cases {
@@ -146,60 +277,52 @@ func TestCanEditPage(t *testing.T) {
 			if tc.shouldReplaceDBWithNil {
 				pageStore.db = nil
 			}
-			canEdit, err := pageStore.CanEditPage(tc.paramGUID, tc.paramUserID)
+			role, err := pageStore.GetPageRole(tc.paramGUID, tc.paramUserID)
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
 				return
 			}
-			require.Equal(t, tc.returnCanEdit, canEdit)
+			require.Equal(t, tc.returnRole, role)
 		})
 	}
 }
 
-func TestCanReadPage(t *testing.T) {
+func TestGetPageOwnerGUID(t *testing.T) {
 	cases := []struct {
 		name                   string
 		shouldReplaceDBWithNil bool
 		preTestQueries         []string
 		paramGUID              string
-		paramUserID            string
-		returnCanRead          bool
+		returnOwnerGUID        string
 		returnErr              error
 	}{
 		{
-			name: "happy path, is owner",
+			name: "shared with an editor",
 			preTestQueries: []string{
 				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
 				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
-			},
-			paramGUID:     "PG_1",
-			paramUserID:   "UR_1",
-			returnCanRead: true,
-		},
-		{
-			name: "happy path, not owner but public",
-			preTestQueries: []string{
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PU\", NOW(), NOW() )",
-				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
 				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, false)",
 				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
 			},
-			paramGUID:     "PG_1",
-			paramUserID:   "UR_1",
-			returnCanRead: true,
+			paramGUID:       "PG_1",
+			returnOwnerGUID: "UR_2",
 		},
 		{
-			name: "happy path, not owner and private: can't read",
-			preTestQueries: []string{
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
-				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
-				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 2, true)",
-			},
-			paramGUID:     "PG_1",
-			paramUserID:   "UR_1",
-			returnCanRead: false,
+			name:      "page does not exist",
+			paramGUID: "PG_1",
+			returnErr: &storeerror.NotFound{ID: "PG_1"},
+		},
+		{
+			name:      "missing guid",
+			paramGUID: "",
+			returnErr: errors.New("must provide guid to get the page owner"),
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramGUID:              "PG_1",
+			returnErr:              &storeerror.DBNotSetUp{},
 		},
 	}
 	for _, tc := range cases {
@@ -214,12 +337,12 @@ func TestCanReadPage(t *testing.T) {
 			if tc.shouldReplaceDBWithNil {
 				pageStore.db = nil
 			}
-			canRead, err := pageStore.CanReadPage(tc.paramGUID, tc.paramUserID)
+			ownerGUID, err := pageStore.GetPageOwnerGUID(tc.paramGUID)
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
 				return
 			}
-			require.Equal(t, tc.returnCanRead, canRead)
+			require.Equal(t, tc.returnOwnerGUID, ownerGUID)
 		})
 	}
 }
@@ -353,32 +476,21 @@ func TestGetPages(t *testing.T) {
 		shouldReplaceDBWithNil bool
 		preTestQueries         []string
 		paramUserID            string
-		paramThisBatchID       string
+		paramFilter            pagefilter.Filter
+		paramSort              pagesort.Sort
+		paramCursor            pagesort.Cursor
 		paramLimit             int
 		returnPages            []page.Page
 		returnTotal            int
-		returnNextBatchID      string
+		returnNextCursor       pagesort.Cursor
 		returnErr              error
 	}{
 		{
-			name: "happy path",
-			preTestQueries: []string{
-				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"TEST_VERSION\", NOW(), NOW())",
-				"INSERT INTO PageTemplate (`Version_ID`, `guid`, `name`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_1\", \"TEST_TEMPLATE\", true, true, true, NOW(), NOW())",
-				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
-				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"test title\", \"\", \"PR\", NOW(), NOW() )",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"test title 2\", \"some kind of summary\", \"PU\", NOW(), NOW() )",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_3\", \"test title 3\", \"\", \"PR\", NOW(), NOW() )",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_4\", \"test title 4\", \"\", \"PR\", NOW(), NOW() )",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 2, 1, true)",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 3, 1, true)",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 4, 2, true)",
-			},
-			paramUserID:      "UR_1",
-			paramThisBatchID: "",
-			paramLimit:       2,
+			name:           "happy path",
+			preTestQueries: getPageListPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramSort:      pagesort.DefaultSort,
+			paramLimit:     2,
 			returnPages: []page.Page{
 				{
 					ID:             1,
@@ -398,40 +510,172 @@ func TestGetPages(t *testing.T) {
 					PermissionType: permission.TypePublic,
 				},
 			},
-			returnNextBatchID: "PG_3",
-			returnTotal:       3,
+			returnNextCursor: pagesort.Cursor{Sort: pagesort.DefaultSort, Value: "2020-01-01T00:00:03Z", PageID: 3},
+			returnTotal:      3,
 		},
 		{
-			name: "happy path, but with a next batch id used to offset request",
-			preTestQueries: []string{
-				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"TEST_VERSION\", NOW(), NOW())",
-				"INSERT INTO PageTemplate (`Version_ID`, `guid`, `name`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_1\", \"TEST_TEMPLATE\", true, true, true, NOW(), NOW())",
-				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
-				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"test title\", \"\", \"PR\", NOW(), NOW() )",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"test title 2\", \"some kind of summary\", \"PU\", NOW(), NOW() )",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_3\", \"test title 3\", \"\", \"PR\", NOW(), NOW() )",
-				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_4\", \"test title 4\", \"\", \"PR\", NOW(), NOW() )",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 2, 1, true)",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 3, 1, true)",
-				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 4, 2, true)",
+			name:           "happy path, but with a cursor used to offset request",
+			preTestQueries: getPageListPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramSort:      pagesort.DefaultSort,
+			paramCursor:    pagesort.Cursor{Sort: pagesort.DefaultSort, Value: "2020-01-01T00:00:03Z", PageID: 3},
+			paramLimit:     2,
+			returnPages: []page.Page{
+				{
+					ID:             3,
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					GUID:           "PG_3",
+					Title:          "test title 3",
+					PermissionType: permission.TypePrivate,
+				},
 			},
-			paramUserID:      "UR_1",
-			paramThisBatchID: "PG_3",
-			paramLimit:       2,
+			returnTotal: 3,
+		},
+		{
+			name:           "sorted by title descending",
+			preTestQueries: getPageListPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramSort:      pagesort.Sort{Field: pagesort.FieldTitle, Order: pagesort.OrderDescending},
+			paramLimit:     2,
 			returnPages: []page.Page{
 				{
 					ID:             3,
+					GUID:           "PG_3",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Title:          "test title 3",
+					PermissionType: permission.TypePrivate,
+				},
+				{
+					ID:             2,
+					GUID:           "PG_2",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Title:          "test title 2",
+					Summary:        "some kind of summary",
+					PermissionType: permission.TypePublic,
+				},
+			},
+			returnNextCursor: pagesort.Cursor{Sort: pagesort.Sort{Field: pagesort.FieldTitle, Order: pagesort.OrderDescending}, Value: "test title", PageID: 1},
+			returnTotal:      3,
+		},
+		{
+			name:           "sorted by title descending with a cursor",
+			preTestQueries: getPageListPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramSort:      pagesort.Sort{Field: pagesort.FieldTitle, Order: pagesort.OrderDescending},
+			paramCursor:    pagesort.Cursor{Sort: pagesort.Sort{Field: pagesort.FieldTitle, Order: pagesort.OrderDescending}, Value: "test title", PageID: 1},
+			paramLimit:     2,
+			returnPages: []page.Page{
+				{
+					ID:             1,
+					GUID:           "PG_1",
 					Version:        version.Version{GUID: "VR_1"},
 					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Title:          "test title",
+					PermissionType: permission.TypePrivate,
+				},
+			},
+			returnTotal: 3,
+		},
+		{
+			name:           "cursor for a different sort",
+			preTestQueries: getPageListPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramSort:      pagesort.Sort{Field: pagesort.FieldTitle, Order: pagesort.OrderDescending},
+			paramCursor:    pagesort.Cursor{Sort: pagesort.DefaultSort, Value: "2020-01-01T00:00:03Z", PageID: 3},
+			paramLimit:     2,
+			returnErr:      errors.New("cursor sort {Field:createdAt Order:asc} does not match the sort {Field:title Order:desc}"),
+		},
+		{
+			name: "only owned pages",
+			preTestQueries: append(getPageListPreTestQueries(),
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 4, 1, false)",
+			),
+			paramUserID: "UR_1",
+			paramFilter: pagefilter.Filter{OwnedOnly: true},
+			paramSort:   pagesort.DefaultSort,
+			paramLimit:  10,
+			returnPages: []page.Page{
+				{
+					ID:             1,
+					GUID:           "PG_1",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Title:          "test title",
+					PermissionType: permission.TypePrivate,
+				},
+				{
+					ID:             2,
+					GUID:           "PG_2",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Title:          "test title 2",
+					Summary:        "some kind of summary",
+					PermissionType: permission.TypePublic,
+				},
+				{
+					ID:             3,
 					GUID:           "PG_3",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
 					Title:          "test title 3",
 					PermissionType: permission.TypePrivate,
 				},
 			},
 			returnTotal: 3,
 		},
+		{
+			name:           "filtered by page template, permission and properties",
+			preTestQueries: getPageFilterPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramFilter: pagefilter.Filter{
+				PageTemplateGUID: "PGT_1",
+				PermissionType:   permission.TypePrivate,
+				Conditions: []pagefilter.Condition{
+					{Key: "population", Operator: pagefilter.OperatorGreater, Type: property.TypeNumber, NumberValue: 10000},
+					{Key: "faction", Operator: pagefilter.OperatorEqual, Type: property.TypeString, StringValue: "Zhentarim"},
+				},
+			},
+			paramSort:  pagesort.DefaultSort,
+			paramLimit: 10,
+			returnPages: []page.Page{
+				{
+					ID:             1,
+					GUID:           "PG_1",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Title:          "Waterdeep",
+					PermissionType: permission.TypePrivate,
+				},
+			},
+			returnTotal: 1,
+		},
+		{
+			name:           "filtered by version and a property that does not equal a value",
+			preTestQueries: getPageFilterPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramFilter: pagefilter.Filter{
+				VersionGUID: "VR_1",
+				Conditions: []pagefilter.Condition{
+					{Key: "faction", Operator: pagefilter.OperatorNotEqual, Type: property.TypeString, StringValue: "Zhentarim"},
+				},
+			},
+			paramSort:  pagesort.DefaultSort,
+			paramLimit: 10,
+			returnPages: []page.Page{
+				{
+					ID:             3,
+					GUID:           "PG_3",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_2"},
+					Title:          "Harpers Hideout",
+					PermissionType: permission.TypePublic,
+				},
+			},
+			returnTotal: 1,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -445,7 +689,7 @@ func TestGetPages(t *testing.T) {
 			if tc.shouldReplaceDBWithNil {
 				pageStore.db = nil
 			}
-			pages, total, nextBatchID, err := pageStore.GetPages(tc.paramUserID, tc.paramThisBatchID, tc.paramLimit)
+			pages, total, nextCursor, err := pageStore.GetPages(tc.paramUserID, tc.paramFilter, tc.paramSort, tc.paramCursor, tc.paramLimit)
 			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
 			if errExpected {
 				return
@@ -457,7 +701,134 @@ func TestGetPages(t *testing.T) {
 			}
 			require.Equal(t, tc.returnPages, pages)
 			require.Equal(t, tc.returnTotal, total)
-			require.Equal(t, tc.returnNextBatchID, nextBatchID)
+			require.Equal(t, tc.returnNextCursor, nextCursor)
+		})
+	}
+}
+
+// getPageListPreTestQueries sets up three pages of UR_1 created a second apart and one page of UR_2.
+func getPageListPreTestQueries() []string {
+	return []string{
+		"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"TEST_VERSION\", NOW(), NOW())",
+		"INSERT INTO PageTemplate (`Version_ID`, `guid`, `name`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_1\", \"TEST_TEMPLATE\", true, true, true, NOW(), NOW())",
+		"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+		"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"test title\", \"\", \"PR\", \"2020-01-01 00:00:01\", NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"test title 2\", \"some kind of summary\", \"PU\", \"2020-01-01 00:00:02\", NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_3\", \"test title 3\", \"\", \"PR\", \"2020-01-01 00:00:03\", NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_4\", \"test title 4\", \"\", \"PR\", \"2020-01-01 00:00:04\", NOW() )",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 2, 1, true)",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 3, 1, true)",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 4, 2, true)",
+	}
+}
+
+// getPageFilterPreTestQueries sets up pages of UR_1 across two page templates and versions with number and string properties.
+func getPageFilterPreTestQueries() []string {
+	return []string{
+		"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"TEST_VERSION\", NOW(), NOW())",
+		"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_2\", \"TEST_VERSION_2\", NOW(), NOW())",
+		"INSERT INTO PageTemplate (`Version_ID`, `guid`, `name`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_1\", \"Settlement\", true, true, true, NOW(), NOW())",
+		"INSERT INTO PageTemplate (`Version_ID`, `guid`, `name`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_2\", \"Location\", true, true, true, NOW(), NOW())",
+		"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+		"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Waterdeep\", \"\", \"PR\", NOW(), NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"Phandalin\", \"\", \"PR\", NOW(), NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 2, \"PG_3\", \"Harpers Hideout\", \"\", \"PU\", NOW(), NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 2, 1, \"PG_4\", \"Neverwinter\", \"\", \"PR\", NOW(), NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_5\", \"Luskan\", \"\", \"PR\", NOW(), NOW() )",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 2, 1, true)",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 3, 1, true)",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 4, 1, true)",
+		"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 5, 2, true)",
+		"INSERT INTO Property (`Version_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"NU\", \"population\", NOW(), NOW())",
+		"INSERT INTO Property (`Version_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"ST\", \"faction\", NOW(), NOW())",
+		"INSERT INTO PagePropertyNumber (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, 1, 130000, \"PR\", NOW(), NOW())",
+		"INSERT INTO PagePropertyNumber (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 2, 1, 1, 800, \"PR\", NOW(), NOW())",
+		"INSERT INTO PagePropertyNumber (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 4, 1, 2, 23000, \"PR\", NOW(), NOW())",
+		"INSERT INTO PagePropertyString (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 2, 1, \"Zhentarim\", \"PR\", NOW(), NOW())",
+		"INSERT INTO PagePropertyString (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 2, 2, 1, \"Zhentarim\", \"PR\", NOW(), NOW())",
+		"INSERT INTO PagePropertyString (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 3, 2, 1, \"Harpers\", \"PR\", NOW(), NOW())",
+		"INSERT INTO PagePropertyString (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 4, 2, 2, \"Lords' Alliance\", \"PR\", NOW(), NOW())",
+		"INSERT INTO PagePropertyString (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 5, 2, 1, \"Zhentarim\", \"PR\", NOW(), NOW())",
+	}
+}
+
+func TestGetPageFacets(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramUserID            string
+		paramFilter            pagefilter.Filter
+		returnFacets           pagefilter.Facets
+		returnErr              error
+	}{
+		{
+			name:           "happy path",
+			preTestQueries: getPageFilterPreTestQueries(),
+			paramUserID:    "UR_1",
+			returnFacets: pagefilter.Facets{
+				PageTemplates: []pagefilter.PageTemplateFacet{
+					{PageTemplateGUID: "PGT_1", Name: "Settlement", Count: 3},
+					{PageTemplateGUID: "PGT_2", Name: "Location", Count: 1},
+				},
+				Properties: []pagefilter.PropertyFacet{
+					{Key: "faction", Values: []pagefilter.ValueFacet{
+						{Value: "Zhentarim", Count: 2},
+						{Value: "Harpers", Count: 1},
+						{Value: "Lords' Alliance", Count: 1},
+					}},
+				},
+			},
+		},
+		{
+			name:           "filtered",
+			preTestQueries: getPageFilterPreTestQueries(),
+			paramUserID:    "UR_1",
+			paramFilter: pagefilter.Filter{
+				Conditions: []pagefilter.Condition{
+					{Key: "population", Operator: pagefilter.OperatorGreaterOrEqual, Type: property.TypeNumber, NumberValue: 10000},
+				},
+			},
+			returnFacets: pagefilter.Facets{
+				PageTemplates: []pagefilter.PageTemplateFacet{
+					{PageTemplateGUID: "PGT_1", Name: "Settlement", Count: 2},
+				},
+				Properties: []pagefilter.PropertyFacet{
+					{Key: "faction", Values: []pagefilter.ValueFacet{
+						{Value: "Lords' Alliance", Count: 1},
+						{Value: "Zhentarim", Count: 1},
+					}},
+				},
+			},
+		},
+		{
+			name:        "no user id",
+			paramUserID: "",
+			returnErr:   errors.New("must provide userID to get page facets"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := PageStore{
+				db: mysqldb,
+			}
+			err := testPageStoreClearAllTables(pageStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				pageStore.db = nil
+			}
+			facets, err := pageStore.GetPageFacets(tc.paramUserID, tc.paramFilter)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnFacets, facets)
 		})
 	}
 }
@@ -511,6 +882,367 @@ func TestRemovePage(t *testing.T) {
 	}
 }
 
+func TestGetRemovedPages(t *testing.T) {
+	removedAt := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramUserID            string
+		returnPages            []page.Page
+		returnErr              error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"TEST_VERSION\", NOW(), NOW())",
+				"INSERT INTO PageTemplate (`Version_ID`, `guid`, `name`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_1\", \"TEST_TEMPLATE\", true, true, true, NOW(), NOW())",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", \"2020-01-01 00:00:00\", \"2020-01-01 00:00:00\" )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_2\", \"Berez\", \"\", \"PR\", \"2020-01-01 00:00:00\", \"2020-01-01 00:00:00\", \"2020-01-02 00:00:00\" )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_3\", \"Vallaki\", \"\", \"PR\", \"2020-01-01 00:00:00\", \"2020-01-01 00:00:00\", \"2020-01-02 00:00:00\" )",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES (1, 1, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES (2, 1, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES (3, 2, true)",
+			},
+			paramUserID: "UR_1",
+			returnPages: []page.Page{
+				{
+					ID:             2,
+					GUID:           "PG_2",
+					Version:        version.Version{GUID: "VR_1"},
+					PageTemplate:   pagetemplate.PageTemplate{GUID: "PGT_1"},
+					Title:          "Berez",
+					PermissionType: permission.TypePrivate,
+					CreatedAt:      &createdAt,
+					UpdatedAt:      &createdAt,
+					DeletedAt:      &removedAt,
+				},
+			},
+		},
+		{
+			name:        "nothing removed",
+			paramUserID: "UR_1",
+			returnPages: []page.Page{},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramUserID:            "UR_1",
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := PageStore{
+				db: mysqldb,
+			}
+			err := testPageStoreClearAllTables(pageStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				pageStore.db = nil
+			}
+			result, err := pageStore.GetRemovedPages(tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnPages, result)
+		})
+	}
+}
+
+func TestGetRemovedPageGUIDs(t *testing.T) {
+	preTestQueries := []string{
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_2\", \"Berez\", \"\", \"PR\", NOW(), NOW(), \"2020-01-01 00:00:00\" )",
+		"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_3\", \"Vallaki\", \"\", \"PR\", NOW(), NOW(), \"2020-02-01 00:00:00\" )",
+	}
+	cases := []struct {
+		name               string
+		preTestQueries     []string
+		paramRemovedBefore time.Time
+		returnGUIDs        []string
+		returnErr          error
+	}{
+		{
+			name:               "removed before",
+			preTestQueries:     preTestQueries,
+			paramRemovedBefore: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			returnGUIDs:        []string{"PG_2"},
+		},
+		{
+			name:               "nothing removed before",
+			preTestQueries:     preTestQueries,
+			paramRemovedBefore: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			returnGUIDs:        []string{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := PageStore{
+				db: mysqldb,
+			}
+			err := testPageStoreClearAllTables(pageStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := pageStore.GetRemovedPageGUIDs(tc.paramRemovedBefore)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnGUIDs, result)
+		})
+	}
+}
+
+func TestRestorePage(t *testing.T) {
+	cases := []struct {
+		name           string
+		preTestQueries []string
+		paramGUID      string
+		returnErr      error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"TEST_VERSION\", NOW(), NOW())",
+				"INSERT INTO PageTemplate (`Version_ID`, `guid`, `name`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_1\", \"TEST_TEMPLATE\", true, true, true, NOW(), NOW())",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_1\", \"Berez\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+			},
+			paramGUID: "PG_1",
+		},
+		{
+			name: "not removed",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+			},
+			paramGUID: "PG_1",
+			returnErr: &storeerror.NotFound{ID: "PG_1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := PageStore{
+				db: mysqldb,
+			}
+			err := testPageStoreClearAllTables(pageStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			err = pageStore.RestorePage(tc.paramGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			_, err = pageStore.GetPage(tc.paramGUID)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPurgePage(t *testing.T) {
+	cases := []struct {
+		name           string
+		preTestQueries []string
+		paramGUID      string
+		returnErr      error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"NU\", \"population\", NOW(), NOW())",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_1\", \"Berez\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES (1, 1, true)",
+				"INSERT INTO PagePropertyNumber (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, 1, 500, \"PR\", NOW(), NOW())",
+				"INSERT INTO PagePropertyOrder (`Page_ID`, `Property_ID`, `order`) VALUES (1, 1, 0)",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES (1, \"DT_1\", \"History\", \"\", \"[]\", NOW(), NOW())",
+				"INSERT INTO PageDetail (`Page_ID`, `guid`, `title`, `summary`, `partitions`, `createdAt`, `updatedAt`) VALUES (2, \"DT_2\", \"History\", \"\", \"[]\", NOW(), NOW())",
+				"INSERT INTO PageDetailOrder (`Page_ID`, `PageDetail_ID`, `order`) VALUES (1, 1, 0)",
+				"INSERT INTO PageDetailRelation (`PageDetail_ID`, `targetGUID`, `text`, `context`, `order`) VALUES (1, \"PG_2\", \"Barovia\", \"\", 0)",
+				"INSERT INTO PageFork (`Page_ID`, `Source_Page_ID`) VALUES (2, 1)",
+				"INSERT INTO PageDetailFork (`PageDetail_ID`, `Source_PageDetail_ID`) VALUES (2, 1)",
+				"INSERT INTO PageRevision (`Page_ID`, `guid`, `changes`, `snapshot`, `createdAt`) VALUES( 1, \"RV_1\", \"[]\", \"{}\", NOW() )",
+			},
+			paramGUID: "PG_1",
+		},
+		{
+			name: "not removed",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+			},
+			paramGUID: "PG_1",
+			returnErr: &storeerror.NotFound{ID: "PG_1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := PageStore{
+				db: mysqldb,
+			}
+			err := testPageStoreClearAllTables(pageStore.db)
+			require.NoError(t, err)
+			for _, table := range []string{"PageDetail", "PageDetailOrder", "PageDetailRelation", "PageFork", "PageDetailFork", "PagePropertyOrder", "PageRevision"} {
+				err = clearTableForTest(pageStore.db, table)
+				require.NoError(t, err)
+			}
+			err = execPreTestQueries(pageStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			err = pageStore.PurgePage(tc.paramGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			for _, table := range []string{"PageOwner", "PagePropertyNumber", "PagePropertyOrder", "PageDetailOrder", "PageDetailRelation", "PageFork", "PageDetailFork", "PageRevision"} {
+				var total int
+				err = pageStore.db.QueryRow("SELECT COUNT(1) FROM " + table).Scan(&total)
+				require.NoError(t, err)
+				require.Equal(t, 0, total, "rows left in %v", table)
+			}
+			var total int
+			err = pageStore.db.QueryRow("SELECT COUNT(1) FROM PageDetail").Scan(&total)
+			require.NoError(t, err)
+			require.Equal(t, 1, total)
+			_, err = pageStore.getPageID(tc.paramGUID)
+			if _, ok := err.(*storeerror.NotFound); !ok {
+				t.Fatalf("Page %v was not purged", tc.paramGUID)
+			}
+		})
+	}
+}
+
+func TestGetAllPageGUIDs(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		returnGUIDs            []string
+		returnErr              error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_2\", \"Berez\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_3\", \"Vallaki\", \"\", \"PU\", NOW(), NOW() )",
+			},
+			returnGUIDs: []string{"PG_1", "PG_3"},
+		},
+		{
+			name:        "no pages",
+			returnGUIDs: []string{},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := PageStore{
+				db: mysqldb,
+			}
+			err := testPageStoreClearAllTables(pageStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				pageStore.db = nil
+			}
+			result, err := pageStore.GetAllPageGUIDs()
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnGUIDs, result)
+		})
+	}
+}
+
+func TestGetUserPageGUIDs(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramUserID            string
+		returnGUIDs            []string
+		returnErr              error
+	}{
+		{
+			name: "owned, edited and campaign pages",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_3\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_4\", \"Barovia\", \"\", \"PU\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_5\", \"Barovia\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_6\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"bob2@test.com\", NOW(), NOW())",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 2, 2, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 2, 1, false)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 3, 2, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 4, 2, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 5, 1, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 6, 2, true)",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`) VALUES( \"CP_1\", \"Home Group\", \"\", NOW(), NOW())",
+				"INSERT INTO Campaign (`guid`, `name`, `summary`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( \"CP_2\", \"Old Group\", \"\", NOW(), NOW(), NOW())",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 1, 1, \"VI\")",
+				"INSERT INTO CampaignMember (`Campaign_ID`, `User_ID`, `role`) VALUES( 2, 1, \"VI\")",
+				"INSERT INTO CampaignPage (`Campaign_ID`, `Page_ID`) VALUES( 1, 1)",
+				"INSERT INTO CampaignPage (`Campaign_ID`, `Page_ID`) VALUES( 1, 3)",
+				"INSERT INTO CampaignPage (`Campaign_ID`, `Page_ID`) VALUES( 2, 6)",
+			},
+			paramUserID: "UR_1",
+			returnGUIDs: []string{"PG_1", "PG_2", "PG_3"},
+		},
+		{
+			name:        "no pages",
+			paramUserID: "UR_1",
+			returnGUIDs: []string{},
+		},
+		{
+			name:      "no user",
+			returnErr: errors.New("must provide userID to get the user's pages"),
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramUserID:            "UR_1",
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageStore := PageStore{
+				db: mysqldb,
+			}
+			err := testPageStoreClearAllTables(pageStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				pageStore.db = nil
+			}
+			result, err := pageStore.GetUserPageGUIDs(tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnGUIDs, result)
+		})
+	}
+}
+
 func TestGetUniquePageGUID(t *testing.T) {
 	cases := []struct {
 		name                   string
@@ -546,7 +1278,7 @@ func TestGetUniquePageGUID(t *testing.T) {
 				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_123456789012\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
 			},
 			paramProposedPageGUID: "PG_123456789012",
-			returnErr:             errors.New("the proposed guid PG_123456789012 already exists"),
+			returnErr:             errors.New("Duplicate id: PG_123456789012"),
 		},
 	}
 	for _, tc := range cases {
@@ -2,10 +2,13 @@ package mysqlstore
 
 import (
 	"database/sql"
-	"errors"
+	"time"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
 	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 )
@@ -22,7 +25,139 @@ func NewPageTemplateStore(mysqldb *sql.DB) PageTemplateStore {
 	}
 }
 
-// GetPageTemplate returns the given pagetemplate.
+// GetUniquePageTemplateGUID returns a guid for the page template that is guaranteed to be unique or errors.
+// If the proposedPageTemplateGUID is not a zero-value and not unique, it will error.
+func (s PageTemplateStore) GetUniquePageTemplateGUID(proposedPageTemplateGUID string) (string, error) {
+	err := guidgen.CheckProposedGUID(proposedPageTemplateGUID, "PGT", 15)
+	if err != nil {
+		return "", err
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	return getUniqueGUID(s.db, "PGT", 15, "PageTemplate", proposedPageTemplateGUID, 0)
+}
+
+// CreatePageTemplate creates a new page template for the given owner, along with its declared properties.
+// Each of the record's properties must have the ID of its registered property set.
+func (s PageTemplateStore) CreatePageTemplate(record pagetemplate.PageTemplate, ownerID int64) (pagetemplate.PageTemplate, error) {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if record.GUID == "" {
+		return record, errors.New("must provide record.GUID to create the page template")
+	}
+	if record.Name == "" {
+		return record, errors.New("must provide record.Name to create the page template")
+	}
+	if ownerID == 0 {
+		return record, errors.New("must provide ownerID to create the page template")
+	}
+	err := checkTemplatePropertyIDs(record.Properties)
+	if err != nil {
+		return record, err
+	}
+	if s.db == nil {
+		return record, &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	id, err := wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "PageTemplate",
+		InjectedValues: wrapsql.InjectedValues{
+			"User_ID":       ownerID,
+			"guid":          record.GUID,
+			"name":          record.Name,
+			"summary":       record.Summary,
+			"hasProperties": len(record.Properties) > 0,
+			"hasDetails":    true,
+			"hasRelations":  true,
+			"createdAt":     &t,
+			"updatedAt":     &t,
+		},
+	})
+	if err != nil {
+		return record, err
+	}
+	record.ID = id
+	err = s.addTemplateProperties(record.ID, record.Properties)
+	if err != nil {
+		return record, errors.Wrap(err, "unable to add page template properties")
+	}
+	return record, nil
+}
+
+func checkTemplatePropertyIDs(templateProperties []pagetemplate.TemplateProperty) error {
+	for i, p := range templateProperties {
+		if p.PropertyID == 0 {
+			return errors.Errorf("must provide the PropertyID for the template property at %v with key %v", i, p.Key)
+		}
+	}
+	return nil
+}
+
+func (s PageTemplateStore) addTemplateProperties(pageTemplateID int64, templateProperties []pagetemplate.TemplateProperty) error {
+	if len(templateProperties) == 0 {
+		return nil
+	}
+	query := wrapsql.BatchInsertQuery{
+		IntoTable:           "PageTemplateProperty",
+		BatchInjectedValues: wrapsql.BatchInjectedValues{},
+	}
+	for i, templateProperty := range templateProperties {
+		encodedDefaultValue, err := pagetemplate.EncodeDefaultValue(templateProperty.DefaultValue)
+		if err != nil {
+			return errors.Wrapf(err, "unable to encode the default value of template property: %v", templateProperty.Key)
+		}
+		query.BatchInjectedValues["PageTemplate_ID"] = append(query.BatchInjectedValues["PageTemplate_ID"], pageTemplateID)
+		query.BatchInjectedValues["Property_ID"] = append(query.BatchInjectedValues["Property_ID"], templateProperty.PropertyID)
+		query.BatchInjectedValues["required"] = append(query.BatchInjectedValues["required"], templateProperty.Required)
+		query.BatchInjectedValues["defaultValue"] = append(query.BatchInjectedValues["defaultValue"], encodedDefaultValue)
+		query.BatchInjectedValues["order"] = append(query.BatchInjectedValues["order"], i)
+	}
+	err := wrapsql.ExecBatchInsert(s.db, query)
+	if err != nil {
+		return errors.Wrap(err, "unable to insert page template properties")
+	}
+	return nil
+}
+
+// CanEditPageTemplate checks if the given user owns the given page template. If not, a storeerror.NotAuthorized will be returned.
+func (s PageTemplateStore) CanEditPageTemplate(guid, userID string) error {
+	if guid == "" {
+		return errors.New("must provide a guid to check privileges")
+	}
+	if userID == "" {
+		return errors.New("must provide a userID to check privileges")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageTemplate.ID"},
+		FromTable: "PageTemplate",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageTemplate.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "PageTemplate.guid", Operator: "= ?"},
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "PageTemplate.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid, userID)
+	var pageTemplateID int64
+	err = wrapsql.GetSingleRow(guid, rows, err, &pageTemplateID)
+	if _, ok := err.(*storeerror.NotFound); ok {
+		return &storeerror.NotAuthorized{
+			UserID:  userID,
+			TableID: guid,
+		}
+	}
+	return err
+}
+
+// GetPageTemplate returns the given pagetemplate, whether or not it is disabled.
 func (s PageTemplateStore) GetPageTemplate(guid string) (pagetemplate.PageTemplate, error) {
 	if guid == "" {
 		return pagetemplate.PageTemplate{}, errors.New("must provide guid to get the pageTemplate")
@@ -31,7 +166,7 @@ func (s PageTemplateStore) GetPageTemplate(guid string) (pagetemplate.PageTempla
 		return pagetemplate.PageTemplate{}, &storeerror.DBNotSetUp{}
 	}
 	statement := wrapsql.SelectStatement{
-		Selectors: []string{"ID", "guid", "name"},
+		Selectors: []string{"ID", "guid", "name", "summary", "disabledAt"},
 		FromTable: "PageTemplate",
 		WhereClause: wrapsql.WhereClause{
 			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
@@ -43,6 +178,232 @@ func (s PageTemplateStore) GetPageTemplate(guid string) (pagetemplate.PageTempla
 	}
 	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
 	var pageTemplate pagetemplate.PageTemplate
-	err = wrapsql.GetSingleRow(guid, rows, err, &pageTemplate.ID, &pageTemplate.GUID, &pageTemplate.Name)
-	return pageTemplate, err
+	var disabledAt *time.Time
+	err = wrapsql.GetSingleRow(guid, rows, err, &pageTemplate.ID, &pageTemplate.GUID, &pageTemplate.Name, &pageTemplate.Summary, &disabledAt)
+	if err != nil {
+		return pagetemplate.PageTemplate{}, err
+	}
+	pageTemplate.Disabled = disabledAt != nil
+	pageTemplate.Properties, err = s.getTemplateProperties(pageTemplate.ID)
+	if err != nil {
+		return pagetemplate.PageTemplate{}, errors.Wrapf(err, "unable to get the properties of page template: %v", guid)
+	}
+	return pageTemplate, nil
+}
+
+// GetPageTemplates returns all of the user's enabled page templates, ordered by name.
+func (s PageTemplateStore) GetPageTemplates(userID string) (returnPageTemplates []pagetemplate.PageTemplate, returnErr error) {
+	if userID == "" {
+		returnErr = errors.New("must provide userID to get the page templates")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageTemplate.ID", "PageTemplate.guid", "PageTemplate.name", "PageTemplate.summary"},
+		FromTable: "PageTemplate",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageTemplate.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "PageTemplate.disabledAt", Operator: "IS NULL"},
+				{LeftSide: "PageTemplate.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "PageTemplate.name",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnPageTemplates = make([]pagetemplate.PageTemplate, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var pt pagetemplate.PageTemplate
+		err := rows.Scan(&pt.ID, &pt.GUID, &pt.Name, &pt.Summary)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		returnPageTemplates = append(returnPageTemplates, pt)
+	}
+	for i := range returnPageTemplates {
+		returnPageTemplates[i].Properties, err = s.getTemplateProperties(returnPageTemplates[i].ID)
+		if err != nil {
+			returnErr = errors.Wrapf(err, "unable to get the properties of page template: %v", returnPageTemplates[i].GUID)
+			return
+		}
+	}
+	return
+}
+
+func (s PageTemplateStore) getTemplateProperties(pageTemplateID int64) (returnProperties []pagetemplate.TemplateProperty, returnErr error) {
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Property.ID", "Property.key", "Property.type", "PageTemplateProperty.required", "PageTemplateProperty.defaultValue"},
+		FromTable: "PageTemplateProperty",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Property", On: wrapsql.OnClause{LeftSide: "PageTemplateProperty.Property_ID", RightSide: "Property.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "PageTemplateProperty.PageTemplate_ID", Operator: "= ?"},
+				{LeftSide: "Property.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "PageTemplateProperty.order",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageTemplateID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnProperties = make([]pagetemplate.TemplateProperty, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var p pagetemplate.TemplateProperty
+		var typeString string
+		var encodedDefaultValue *string
+		err := rows.Scan(&p.PropertyID, &p.Key, &typeString, &p.Required, &encodedDefaultValue)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		p.Type, err = property.GetPropertyType(typeString)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		p.DefaultValue, err = pagetemplate.DecodeDefaultValue(encodedDefaultValue)
+		if err != nil {
+			returnErr = errors.Wrapf(err, "unable to decode the default value of template property: %v", p.Key)
+			return
+		}
+		returnProperties = append(returnProperties, p)
+	}
+	return
+}
+
+// UpdatePageTemplate sets the name and summary of the given page template, and replaces its declared properties.
+// Each of the record's properties must have the ID of its registered property set.
+func (s PageTemplateStore) UpdatePageTemplate(record pagetemplate.PageTemplate) error {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if record.GUID == "" {
+		return errors.New("must provide record.GUID to update the page template")
+	}
+	if record.Name == "" {
+		return errors.New("must provide record.Name to update the page template")
+	}
+	err := checkTemplatePropertyIDs(record.Properties)
+	if err != nil {
+		return err
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	pageTemplateID, err := s.getPageTemplateID(record.GUID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get PageTemplate.ID for guid: %v", record.GUID)
+	}
+	t := time.Now()
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "PageTemplate",
+		InjectedValues: wrapsql.InjectedValues{
+			"name":          record.Name,
+			"summary":       record.Summary,
+			"hasProperties": len(record.Properties) > 0,
+			"updatedAt":     &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "ID", Operator: "= ?"},
+			},
+		},
+	}
+	err = wrapsql.ExecSingleUpdate(s.db, query, pageTemplateID)
+	if err != nil {
+		return err
+	}
+	err = wrapsql.ExecDelete(s.db, wrapsql.DeleteQuery{
+		FromTable: "PageTemplateProperty",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "PageTemplate_ID", Operator: "= ?"},
+			},
+		},
+	}, pageTemplateID)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete from PageTemplateProperty")
+	}
+	err = s.addTemplateProperties(pageTemplateID, record.Properties)
+	if err != nil {
+		return errors.Wrap(err, "unable to add page template properties")
+	}
+	return nil
+}
+
+// SetPageTemplateDisabled disables or re-enables the given page template.
+// Disabled page templates are not returned by GetPageTemplates, but remain on the pages that already use them.
+func (s PageTemplateStore) SetPageTemplateDisabled(guid string, isDisabled bool) error {
+	if guid == "" {
+		return errors.New("must provide guid to disable or enable the page template")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	var disabledAt *time.Time
+	if isDisabled {
+		disabledAt = &t
+	}
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "PageTemplate",
+		InjectedValues: wrapsql.InjectedValues{
+			"disabledAt": disabledAt,
+			"updatedAt":  &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+				{LeftSide: "deletedAt", Operator: "IS NULL"},
+			},
+		},
+	}
+	return wrapsql.ExecSingleUpdate(s.db, query, guid)
+}
+
+func (s PageTemplateStore) getPageTemplateID(guid string) (int64, error) {
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"ID"},
+		FromTable: "PageTemplate",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+				{LeftSide: "deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	var pageTemplateID int64
+	err = wrapsql.GetSingleRow(guid, rows, err, &pageTemplateID)
+	return pageTemplateID, err
 }
@@ -5,13 +5,15 @@ import (
 	"testing"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/pagetemplate"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
 	"github.com/stretchr/testify/require"
 )
 
 func testPageTemplateStoreClearAllTables(db *sql.DB) error {
-	tables := []string{"PageTemplate"}
+	tables := []string{"PageTemplate", "PageTemplateProperty", "Property", "User"}
 	for _, table := range tables {
 		err := clearTableForTest(db, table)
 		if err != nil {
@@ -37,11 +39,40 @@ func TestGetPageTemplate(t *testing.T) {
 			},
 			paramGUID: "PGT_1",
 			returnPageTemplate: pagetemplate.PageTemplate{
-				ID:   1,
-				GUID: "PGT_1",
-				Name: "TEST_TEMPLATE",
+				ID:         1,
+				GUID:       "PGT_1",
+				Name:       "TEST_TEMPLATE",
+				Properties: []pagetemplate.TemplateProperty{},
 			},
 		},
+		{
+			name: "disabled template with properties",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"NU\", \"population\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"ST\", \"banner\", NOW(), NOW())",
+				"INSERT INTO PageTemplate (`User_ID`, `guid`, `name`, `summary`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`, `disabledAt`) VALUES(1, \"PGT_1\", \"Place\", \"A place\", true, true, true, NOW(), NOW(), NOW())",
+				"INSERT INTO PageTemplateProperty (`PageTemplate_ID`, `Property_ID`, `required`, `defaultValue`, `order`) VALUES(1, 2, false, '\"none\"', 1)",
+				"INSERT INTO PageTemplateProperty (`PageTemplate_ID`, `Property_ID`, `required`, `defaultValue`, `order`) VALUES(1, 1, true, NULL, 0)",
+			},
+			paramGUID: "PGT_1",
+			returnPageTemplate: pagetemplate.PageTemplate{
+				ID:      1,
+				GUID:    "PGT_1",
+				Name:    "Place",
+				Summary: "A place",
+				Properties: []pagetemplate.TemplateProperty{
+					{PropertyID: 1, Key: "population", Type: property.TypeNumber, Required: true},
+					{PropertyID: 2, Key: "banner", Type: property.TypeString, DefaultValue: "none"},
+				},
+				Disabled: true,
+			},
+		},
+		{
+			name:      "not found",
+			paramGUID: "PGT_1",
+			returnErr: &storeerror.NotFound{ID: "PGT_1"},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -64,3 +95,47 @@ func TestGetPageTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPageTemplates(t *testing.T) {
+	cases := []struct {
+		name                string
+		preTestQueries      []string
+		paramUserID         string
+		returnPageTemplates []pagetemplate.PageTemplate
+		returnErr           error
+	}{
+		{
+			name: "only enabled templates of the user",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO PageTemplate (`User_ID`, `guid`, `name`, `summary`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_1\", \"Place\", \"\", false, true, true, NOW(), NOW())",
+				"INSERT INTO PageTemplate (`User_ID`, `guid`, `name`, `summary`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(1, \"PGT_2\", \"Item\", \"\", false, true, true, NOW(), NOW())",
+				"INSERT INTO PageTemplate (`User_ID`, `guid`, `name`, `summary`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`, `disabledAt`) VALUES(1, \"PGT_3\", \"Event\", \"\", false, true, true, NOW(), NOW(), NOW())",
+				"INSERT INTO PageTemplate (`User_ID`, `guid`, `name`, `summary`, `hasProperties`, `hasDetails`, `hasRelations`, `createdAt`, `updatedAt`) VALUES(2, \"PGT_4\", \"Person\", \"\", false, true, true, NOW(), NOW())",
+			},
+			paramUserID: "UR_1",
+			returnPageTemplates: []pagetemplate.PageTemplate{
+				{ID: 2, GUID: "PGT_2", Name: "Item", Properties: []pagetemplate.TemplateProperty{}},
+				{ID: 1, GUID: "PGT_1", Name: "Place", Properties: []pagetemplate.TemplateProperty{}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pageTemplateStore := PageTemplateStore{
+				db: mysqldb,
+			}
+			err := testPageTemplateStoreClearAllTables(pageTemplateStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(pageTemplateStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := pageTemplateStore.GetPageTemplates(tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnPageTemplates, result)
+		})
+	}
+}
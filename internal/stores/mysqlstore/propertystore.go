@@ -1 +1,252 @@
 package mysqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
+)
+
+// PropertyStore is the mysql for the property registry
+type PropertyStore struct {
+	db *sql.DB
+}
+
+// NewPropertyStore returns a PropertyStore
+func NewPropertyStore(mysqldb *sql.DB) PropertyStore {
+	return PropertyStore{
+		db: mysqldb,
+	}
+}
+
+// CreateProperty registers a new property for the given owner.
+func (s PropertyStore) CreateProperty(record property.Property, ownerID int64) (property.Property, error) {
+	if record.Key == "" {
+		return record, errors.New("must provide record.Key to create the property")
+	}
+	if ownerID == 0 {
+		return record, errors.New("must provide ownerID to create the property")
+	}
+	if s.db == nil {
+		return record, &storeerror.DBNotSetUp{}
+	}
+	dbType, err := property.GetDBPropertyType(record.Type)
+	if err != nil {
+		return record, err
+	}
+	t := time.Now()
+	id, err := wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable: "Property",
+		InjectedValues: wrapsql.InjectedValues{
+			"User_ID":   ownerID,
+			"key":       record.Key,
+			"type":      dbType,
+			"createdAt": &t,
+			"updatedAt": &t,
+		},
+	})
+	if err != nil {
+		return record, err
+	}
+	record.ID = id
+	return record, nil
+}
+
+// GetProperty returns the user's property with the given key, whether or not it is disabled.
+func (s PropertyStore) GetProperty(key, userID string) (property.Property, error) {
+	if key == "" {
+		return property.Property{}, errors.New("must provide key to get the property")
+	}
+	if userID == "" {
+		return property.Property{}, errors.New("must provide userID to get the property")
+	}
+	if s.db == nil {
+		return property.Property{}, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Property.ID", "Property.key", "Property.type", "Property.disabledAt"},
+		FromTable: "Property",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "Property.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Property.key", Operator: "= ?"},
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Property.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), key, userID)
+	var typeString string
+	var disabledAt *time.Time
+	var p property.Property
+	err = wrapsql.GetSingleRow(key, rows, err, &p.ID, &p.Key, &typeString, &disabledAt)
+	if err != nil {
+		return property.Property{}, err
+	}
+	p.Type, err = property.GetPropertyType(typeString)
+	if err != nil {
+		return property.Property{}, err
+	}
+	p.Disabled = disabledAt != nil
+	return p, nil
+}
+
+// GetProperties returns all of the user's enabled properties, ordered by key.
+func (s PropertyStore) GetProperties(userID string) (returnProperties []property.Property, returnErr error) {
+	if userID == "" {
+		returnErr = errors.New("must provide userID to get the properties")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Property.ID", "Property.key", "Property.type"},
+		FromTable: "Property",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "Property.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Property.disabledAt", Operator: "IS NULL"},
+				{LeftSide: "Property.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Property.key",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnProperties = make([]property.Property, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var typeString string
+		var p property.Property
+		err := rows.Scan(&p.ID, &p.Key, &typeString)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		p.Type, err = property.GetPropertyType(typeString)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		returnProperties = append(returnProperties, p)
+	}
+	return
+}
+
+// UpdateProperty sets the key and type of the given property.
+func (s PropertyStore) UpdateProperty(record property.Property) error {
+	if record.ID == 0 {
+		return errors.New("must provide record.ID to update the property")
+	}
+	if record.Key == "" {
+		return errors.New("must provide record.Key to update the property")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	dbType, err := property.GetDBPropertyType(record.Type)
+	if err != nil {
+		return err
+	}
+	t := time.Now()
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "Property",
+		InjectedValues: wrapsql.InjectedValues{
+			"key":       record.Key,
+			"type":      dbType,
+			"updatedAt": &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "ID", Operator: "= ?"},
+			},
+		},
+	}
+	return wrapsql.ExecSingleUpdate(s.db, query, record.ID)
+}
+
+// SetPropertyDisabled disables or re-enables the given property.
+// Disabled properties are not returned by GetProperties, but remain on the pages that already use them.
+func (s PropertyStore) SetPropertyDisabled(propertyID int64, isDisabled bool) error {
+	if propertyID == 0 {
+		return errors.New("must provide propertyID to disable or enable the property")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	var disabledAt *time.Time
+	if isDisabled {
+		disabledAt = &t
+	}
+	query := wrapsql.UpdateQuery{
+		UpdateTable: "Property",
+		InjectedValues: wrapsql.InjectedValues{
+			"disabledAt": disabledAt,
+			"updatedAt":  &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "ID", Operator: "= ?"},
+			},
+		},
+	}
+	return wrapsql.ExecSingleUpdate(s.db, query, propertyID)
+}
+
+// IsPropertyInUse returns true if any page currently has a value for the given property.
+func (s PropertyStore) IsPropertyInUse(propertyID int64) (bool, error) {
+	if propertyID == 0 {
+		return false, errors.New("must provide propertyID to check if the property is in use")
+	}
+	if s.db == nil {
+		return false, &storeerror.DBNotSetUp{}
+	}
+	for _, tableName := range []string{"PagePropertyNumber", "PagePropertyString"} {
+		statement := wrapsql.SelectStatement{
+			Selectors: []string{"COUNT(1)"},
+			FromTable: tableName,
+			JoinClauses: []wrapsql.JoinClause{
+				{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: tableName + ".Page_ID", RightSide: "Page.ID"}},
+			},
+			WhereClause: wrapsql.WhereClause{
+				Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+					{LeftSide: tableName + ".Property_ID", Operator: "= ?"},
+					{LeftSide: tableName + ".deletedAt", Operator: "IS NULL"},
+					{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+				},
+			},
+		}
+		rows, err := s.db.Query(wrapsql.GetSelectString(statement), propertyID)
+		var total int
+		err = wrapsql.GetSingleRow("", rows, err, &total)
+		if err != nil {
+			return false, errors.Wrapf(err, "unable to count the uses of the property in %v", tableName)
+		}
+		if total > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
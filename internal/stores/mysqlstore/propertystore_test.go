@@ -0,0 +1,164 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testPropertyStoreClearAllTables(db *sql.DB) error {
+	tables := []string{"Page", "PagePropertyNumber", "PagePropertyString", "Property", "User"}
+	for _, table := range tables {
+		err := clearTableForTest(db, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGetProperty(t *testing.T) {
+	cases := []struct {
+		name           string
+		preTestQueries []string
+		paramKey       string
+		paramUserID    string
+		returnProperty property.Property
+		returnErr      error
+	}{
+		{
+			name: "disabled property",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`, `disabledAt`) VALUES( 1, \"NU\", \"population\", NOW(), NOW(), NOW())",
+			},
+			paramKey:       "population",
+			paramUserID:    "UR_1",
+			returnProperty: property.Property{ID: 1, Key: "population", Type: property.TypeNumber, Disabled: true},
+		},
+		{
+			name: "property of another user",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 2, \"NU\", \"population\", NOW(), NOW())",
+			},
+			paramKey:    "population",
+			paramUserID: "UR_1",
+			returnErr:   &storeerror.NotFound{ID: "population"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyStore := PropertyStore{
+				db: mysqldb,
+			}
+			err := testPropertyStoreClearAllTables(propertyStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(propertyStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := propertyStore.GetProperty(tc.paramKey, tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnProperty, result)
+		})
+	}
+}
+
+func TestGetProperties(t *testing.T) {
+	cases := []struct {
+		name             string
+		preTestQueries   []string
+		paramUserID      string
+		returnProperties []property.Property
+		returnErr        error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"NU\", \"population\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"ST\", \"banner\", NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`, `disabledAt`) VALUES( 1, \"ST\", \"color\", NOW(), NOW(), NOW())",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 2, \"ST\", \"symbol\", NOW(), NOW())",
+			},
+			paramUserID: "UR_1",
+			returnProperties: []property.Property{
+				{ID: 2, Key: "banner", Type: property.TypeString},
+				{ID: 1, Key: "population", Type: property.TypeNumber},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyStore := PropertyStore{
+				db: mysqldb,
+			}
+			err := testPropertyStoreClearAllTables(propertyStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(propertyStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := propertyStore.GetProperties(tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnProperties, result)
+		})
+	}
+}
+
+func TestIsPropertyInUse(t *testing.T) {
+	cases := []struct {
+		name            string
+		preTestQueries  []string
+		paramPropertyID int64
+		returnInUse     bool
+		returnErr       error
+	}{
+		{
+			name: "in use",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"ST\", \"banner\", NOW(), NOW())",
+				"INSERT INTO PagePropertyString (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, 1, \"lion heads\", \"PR\", NOW(), NOW())",
+			},
+			paramPropertyID: 1,
+			returnInUse:     true,
+		},
+		{
+			name: "only used by a removed page",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_1\", \"original title\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+				"INSERT INTO Property (`User_ID`, `type`, `key`, `createdAt`, `updatedAt`) VALUES( 1, \"NU\", \"population\", NOW(), NOW())",
+				"INSERT INTO PagePropertyNumber (`Page_ID`, `Property_ID`, `Version_ID`, `value`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, 1, 100000, \"PR\", NOW(), NOW())",
+			},
+			paramPropertyID: 1,
+			returnInUse:     false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			propertyStore := PropertyStore{
+				db: mysqldb,
+			}
+			err := testPropertyStoreClearAllTables(propertyStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(propertyStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := propertyStore.IsPropertyInUse(tc.paramPropertyID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnInUse, result)
+		})
+	}
+}
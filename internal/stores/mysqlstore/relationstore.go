@@ -0,0 +1,247 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
+)
+
+// RelationStore is the mysql for the relations between pages
+type RelationStore struct {
+	db *sql.DB
+}
+
+// NewRelationStore returns a RelationStore
+func NewRelationStore(mysqldb *sql.DB) RelationStore {
+	return RelationStore{
+		db: mysqldb,
+	}
+}
+
+// GetBacklinks returns every relation to the given page from the details of other pages, ordered by the linking page's title.
+// Relations from removed pages and details are excluded.
+func (s RelationStore) GetBacklinks(pageGUID string) (returnBacklinks []relation.Backlink, returnErr error) {
+	if pageGUID == "" {
+		returnErr = errors.New("must provide pageGUID to get the backlinks")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Page.guid", "Page.title", "PageDetail.guid", "PageDetail.title", "PageDetailRelation.text", "PageDetailRelation.context"},
+		FromTable: "PageDetailRelation",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "PageDetail", On: wrapsql.OnClause{LeftSide: "PageDetailRelation.PageDetail_ID", RightSide: "PageDetail.ID"}},
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageDetail.Page_ID", RightSide: "Page.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "PageDetailRelation.targetGUID", Operator: "= ?"},
+				{LeftSide: "PageDetail.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Page.title",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnBacklinks = make([]relation.Backlink, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var b relation.Backlink
+		err := rows.Scan(&b.PageGUID, &b.PageTitle, &b.DetailGUID, &b.DetailTitle, &b.Text, &b.Context)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		returnBacklinks = append(returnBacklinks, b)
+	}
+	return
+}
+
+// GetEdges returns the relations from and to each of the given pages, grouped by the linking and the linked page,
+// and ordered by their guids. Relations from removed pages and details are excluded.
+func (s RelationStore) GetEdges(pageGUIDs []string) ([]relation.Edge, error) {
+	if len(pageGUIDs) == 0 {
+		return nil, errors.New("must provide pageGUIDs to get the edges")
+	}
+	if s.db == nil {
+		return nil, &storeerror.DBNotSetUp{}
+	}
+	inPages := make(map[string]bool)
+	guids := make([]interface{}, 0, len(pageGUIDs))
+	for _, guid := range pageGUIDs {
+		inPages[guid] = true
+		guids = append(guids, guid)
+	}
+	edges := make([]relation.Edge, 0)
+	edgeIndexes := make(map[string]int)
+	for _, column := range []string{"Page.guid", "PageDetailRelation.targetGUID"} {
+		statement := wrapsql.SelectStatement{
+			Selectors: []string{"Page.guid", "PageDetailRelation.targetGUID"},
+			FromTable: "PageDetailRelation",
+			JoinClauses: []wrapsql.JoinClause{
+				{JoinTable: "PageDetail", On: wrapsql.OnClause{LeftSide: "PageDetailRelation.PageDetail_ID", RightSide: "PageDetail.ID"}},
+				{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageDetail.Page_ID", RightSide: "Page.ID"}},
+			},
+			WhereClause: wrapsql.WhereClause{
+				Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+					{LeftSide: column, Operator: fmt.Sprintf("IN (%v)", wrapsql.GetNValueStubList(len(guids)))},
+					{LeftSide: "PageDetail.deletedAt", Operator: "IS NULL"},
+					{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+				},
+			},
+		}
+		rows, err := s.db.Query(wrapsql.GetSelectString(statement), guids...)
+		if err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var sourceGUID, targetGUID string
+			err := rows.Scan(&sourceGUID, &targetGUID)
+			if err != nil {
+				return nil, err
+			}
+			// relations between two of the given pages are found by both queries, so only count them the first time.
+			if column == "PageDetailRelation.targetGUID" && inPages[sourceGUID] {
+				continue
+			}
+			key := sourceGUID + " " + targetGUID
+			index, ok := edgeIndexes[key]
+			if !ok {
+				index = len(edges)
+				edgeIndexes[key] = index
+				edges = append(edges, relation.Edge{SourcePageGUID: sourceGUID, TargetPageGUID: targetGUID})
+			}
+			edges[index].Count = edges[index].Count + 1
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].SourcePageGUID != edges[j].SourcePageGUID {
+			return edges[i].SourcePageGUID < edges[j].SourcePageGUID
+		}
+		return edges[i].TargetPageGUID < edges[j].TargetPageGUID
+	})
+	return edges, nil
+}
+
+// GetLinks returns every relation from the details of the pages the user can edit, ordered by the linking page's title.
+// The status of each link is live, deleted or nonexistent, depending on the page it links to.
+// Relations from removed pages and details are excluded.
+func (s RelationStore) GetLinks(userID string) (returnLinks []relation.Link, returnErr error) {
+	if userID == "" {
+		returnErr = errors.New("must provide userID to get the links")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Page.guid", "Page.title", "PageDetail.guid", "PageDetail.title", "PageDetailRelation.targetGUID", "PageDetailRelation.text", "PageDetailRelation.context", "TargetPage.guid", "TargetPage.deletedAt"},
+		FromTable: "PageDetailRelation",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "PageDetail", On: wrapsql.OnClause{LeftSide: "PageDetailRelation.PageDetail_ID", RightSide: "PageDetail.ID"}},
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageDetail.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "PageOwner", On: wrapsql.OnClause{LeftSide: "PageOwner.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageOwner.User_ID", RightSide: "User.ID"}},
+			{JoinType: "LEFT", JoinTable: "Page AS TargetPage", On: wrapsql.OnClause{LeftSide: "PageDetailRelation.targetGUID", RightSide: "TargetPage.guid"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "PageDetail.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Page.title",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnLinks = make([]relation.Link, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var l relation.Link
+		var targetGUID sql.NullString
+		var targetDeletedAt *time.Time
+		err := rows.Scan(&l.PageGUID, &l.PageTitle, &l.DetailGUID, &l.DetailTitle, &l.TargetPageGUID, &l.Text, &l.Context, &targetGUID, &targetDeletedAt)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		switch {
+		case !targetGUID.Valid:
+			l.Status = relation.StatusNonexistent
+		case targetDeletedAt != nil:
+			l.Status = relation.StatusDeleted
+		default:
+			l.Status = relation.StatusLive
+		}
+		returnLinks = append(returnLinks, l)
+	}
+	return
+}
+
+// replacePageDetailRelations indexes the relations in the partitions of the page detail, replacing any it had before.
+func replacePageDetailRelations(db *sql.DB, pageDetailID int64, partitions []pagedetail.Partition) error {
+	err := wrapsql.ExecDelete(db, wrapsql.DeleteQuery{
+		FromTable: "PageDetailRelation",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "PageDetail_ID", Operator: "= ?"},
+			},
+		},
+	}, pageDetailID)
+	if err != nil {
+		return err
+	}
+	relations := relation.Extract(partitions)
+	if len(relations) == 0 {
+		return nil
+	}
+	query := wrapsql.BatchInsertQuery{
+		IntoTable:           "PageDetailRelation",
+		BatchInjectedValues: wrapsql.BatchInjectedValues{},
+	}
+	for i, r := range relations {
+		query.BatchInjectedValues["PageDetail_ID"] = append(query.BatchInjectedValues["PageDetail_ID"], pageDetailID)
+		query.BatchInjectedValues["targetGUID"] = append(query.BatchInjectedValues["targetGUID"], r.TargetPageGUID)
+		query.BatchInjectedValues["text"] = append(query.BatchInjectedValues["text"], r.Text)
+		query.BatchInjectedValues["context"] = append(query.BatchInjectedValues["context"], r.Context)
+		query.BatchInjectedValues["order"] = append(query.BatchInjectedValues["order"], i)
+	}
+	return wrapsql.ExecBatchInsert(db, query)
+}
@@ -0,0 +1,270 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagedetail"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testRelationStoreClearAllTables(db *sql.DB) error {
+	tables := []string{"User", "Page", "PageOwner", "PageDetail", "PageDetailOrder", "PageDetailRelation"}
+	for _, table := range tables {
+		err := clearTableForTest(db, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getRelationPartitions(targetPageGUID, text string) []pagedetail.Partition {
+	return []pagedetail.Partition{
+		{
+			Type:       pagedetail.PartitionTypeParagraph,
+			TypeString: "p",
+			Partitions: []pagedetail.Partition{
+				{Type: pagedetail.PartitionTypeText, TypeString: "text", Value: "The road leads to "},
+				{Type: pagedetail.PartitionTypeRelation, TypeString: "relation", Value: text, Relation: targetPageGUID},
+			},
+		},
+	}
+}
+
+func TestGetBacklinks(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramDetails           map[string]pagedetail.PageDetail
+		paramUpdatedDetails    map[string]pagedetail.PageDetail
+		paramPageGUID          string
+		returnBacklinks        []relation.Backlink
+		returnErr              error
+	}{
+		{
+			name: "indexed when details are created and updated",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"Vallaki\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_3\", \"Krezk\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_4\", \"Berez\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+			},
+			paramDetails: map[string]pagedetail.PageDetail{
+				"PG_2": {GUID: "DT_1", Title: "Roads", Partitions: getRelationPartitions("PG_1", "Barovia")},
+				"PG_3": {GUID: "DT_2", Title: "Roads", Partitions: getRelationPartitions("PG_2", "Vallaki")},
+				"PG_4": {GUID: "DT_3", Title: "Roads", Partitions: getRelationPartitions("PG_1", "Barovia")},
+			},
+			paramUpdatedDetails: map[string]pagedetail.PageDetail{
+				"PG_3": {GUID: "DT_2", Title: "Roads", Partitions: getRelationPartitions("PG_1", "the village")},
+			},
+			paramPageGUID: "PG_1",
+			returnBacklinks: []relation.Backlink{
+				{PageGUID: "PG_3", PageTitle: "Krezk", DetailGUID: "DT_2", DetailTitle: "Roads", Text: "the village", Context: "The road leads to the village"},
+				{PageGUID: "PG_2", PageTitle: "Vallaki", DetailGUID: "DT_1", DetailTitle: "Roads", Text: "Barovia", Context: "The road leads to Barovia"},
+			},
+		},
+		{
+			name:            "no backlinks",
+			paramPageGUID:   "PG_1",
+			returnBacklinks: []relation.Backlink{},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramPageGUID:          "PG_1",
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			relationStore := RelationStore{
+				db: mysqldb,
+			}
+			pageDetailStore := PageDetailStore{
+				db: mysqldb,
+			}
+			err := testRelationStoreClearAllTables(relationStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(relationStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			for pageGUID, detail := range tc.paramDetails {
+				_, err = pageDetailStore.CreatePageDetail(pageGUID, detail)
+				require.NoError(t, err)
+			}
+			for pageGUID, detail := range tc.paramUpdatedDetails {
+				err = pageDetailStore.UpdatePageDetail(pageGUID, detail)
+				require.NoError(t, err)
+			}
+			if tc.shouldReplaceDBWithNil {
+				relationStore.db = nil
+			}
+			result, err := relationStore.GetBacklinks(tc.paramPageGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnBacklinks, result)
+		})
+	}
+}
+
+func TestGetEdges(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramDetails           []map[string]pagedetail.PageDetail
+		paramPageGUIDs         []string
+		returnEdges            []relation.Edge
+		returnErr              error
+	}{
+		{
+			name: "relations from and to the pages",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"Vallaki\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_3\", \"Krezk\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_4\", \"Berez\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+			},
+			paramDetails: []map[string]pagedetail.PageDetail{
+				{"PG_1": {GUID: "DT_1", Title: "Roads", Partitions: getRelationPartitions("PG_2", "Vallaki")}},
+				{"PG_1": {GUID: "DT_2", Title: "History", Partitions: getRelationPartitions("PG_2", "the town")}},
+				{"PG_2": {GUID: "DT_3", Title: "Roads", Partitions: getRelationPartitions("PG_1", "Barovia")}},
+				{"PG_3": {GUID: "DT_4", Title: "Roads", Partitions: getRelationPartitions("PG_1", "Barovia")}},
+				{"PG_3": {GUID: "DT_5", Title: "Trade", Partitions: getRelationPartitions("PG_2", "Vallaki")}},
+				{"PG_4": {GUID: "DT_6", Title: "Roads", Partitions: getRelationPartitions("PG_1", "Barovia")}},
+			},
+			paramPageGUIDs: []string{"PG_1", "PG_2"},
+			returnEdges: []relation.Edge{
+				{SourcePageGUID: "PG_1", TargetPageGUID: "PG_2", Count: 2},
+				{SourcePageGUID: "PG_2", TargetPageGUID: "PG_1", Count: 1},
+				{SourcePageGUID: "PG_3", TargetPageGUID: "PG_1", Count: 1},
+				{SourcePageGUID: "PG_3", TargetPageGUID: "PG_2", Count: 1},
+			},
+		},
+		{
+			name:           "no edges",
+			paramPageGUIDs: []string{"PG_1"},
+			returnEdges:    []relation.Edge{},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramPageGUIDs:         []string{"PG_1"},
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			relationStore := RelationStore{
+				db: mysqldb,
+			}
+			pageDetailStore := PageDetailStore{
+				db: mysqldb,
+			}
+			err := testRelationStoreClearAllTables(relationStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(relationStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			for _, details := range tc.paramDetails {
+				for pageGUID, detail := range details {
+					_, err = pageDetailStore.CreatePageDetail(pageGUID, detail)
+					require.NoError(t, err)
+				}
+			}
+			if tc.shouldReplaceDBWithNil {
+				relationStore.db = nil
+			}
+			result, err := relationStore.GetEdges(tc.paramPageGUIDs)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnEdges, result)
+		})
+	}
+}
+
+func TestGetLinks(t *testing.T) {
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramDetails           []map[string]pagedetail.PageDetail
+		paramUserID            string
+		returnLinks            []relation.Link
+		returnErr              error
+	}{
+		{
+			name: "links from the pages of the user",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"Barovia\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"Vallaki\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"PG_3\", \"Berez\", \"\", \"PR\", NOW(), NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_4\", \"Krezk\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 1, 1, true)",
+				"INSERT INTO PageOwner (`Page_ID`, `User_ID`, `isOwner`) VALUES( 4, 2, true)",
+			},
+			paramDetails: []map[string]pagedetail.PageDetail{
+				{"PG_1": {GUID: "DT_1", Title: "Roads", Partitions: getRelationPartitions("PG_2", "Vallaki")}},
+				{"PG_1": {GUID: "DT_2", Title: "Ruins", Partitions: getRelationPartitions("PG_3", "Berez")}},
+				{"PG_1": {GUID: "DT_3", Title: "Legends", Partitions: getRelationPartitions("PG_9", "Argynvostholt")}},
+				{"PG_4": {GUID: "DT_4", Title: "Roads", Partitions: getRelationPartitions("PG_3", "Berez")}},
+			},
+			paramUserID: "UR_1",
+			returnLinks: []relation.Link{
+				{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_1", DetailTitle: "Roads", TargetPageGUID: "PG_2", Text: "Vallaki", Context: "The road leads to Vallaki", Status: relation.StatusLive},
+				{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_2", DetailTitle: "Ruins", TargetPageGUID: "PG_3", Text: "Berez", Context: "The road leads to Berez", Status: relation.StatusDeleted},
+				{PageGUID: "PG_1", PageTitle: "Barovia", DetailGUID: "DT_3", DetailTitle: "Legends", TargetPageGUID: "PG_9", Text: "Argynvostholt", Context: "The road leads to Argynvostholt", Status: relation.StatusNonexistent},
+			},
+		},
+		{
+			name:        "no links",
+			paramUserID: "UR_1",
+			returnLinks: []relation.Link{},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramUserID:            "UR_1",
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			relationStore := RelationStore{
+				db: mysqldb,
+			}
+			pageDetailStore := PageDetailStore{
+				db: mysqldb,
+			}
+			err := testRelationStoreClearAllTables(relationStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(relationStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			for _, details := range tc.paramDetails {
+				for pageGUID, detail := range details {
+					_, err = pageDetailStore.CreatePageDetail(pageGUID, detail)
+					require.NoError(t, err)
+				}
+			}
+			if tc.shouldReplaceDBWithNil {
+				relationStore.db = nil
+			}
+			result, err := relationStore.GetLinks(tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnLinks, result)
+		})
+	}
+}
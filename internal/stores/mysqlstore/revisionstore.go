@@ -0,0 +1,222 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
+)
+
+// RevisionStore is the mysql for page revisions
+type RevisionStore struct {
+	db *sql.DB
+}
+
+// NewRevisionStore returns a RevisionStore
+func NewRevisionStore(mysqldb *sql.DB) RevisionStore {
+	return RevisionStore{
+		db: mysqldb,
+	}
+}
+
+// GetUniqueRevisionGUID returns a guid for the revision that is guaranteed to be unique or errors.
+// If the proposedRevisionGUID is not a zero-value and not unique, it will error.
+func (s RevisionStore) GetUniqueRevisionGUID(proposedRevisionGUID string) (string, error) {
+	err := guidgen.CheckProposedGUID(proposedRevisionGUID, "RV", 15)
+	if err != nil {
+		return "", err
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	return getUniqueGUID(s.db, "RV", 15, "PageRevision", proposedRevisionGUID, 0)
+}
+
+// CreateRevision records a new revision of the page. Revisions are never updated once created.
+// The record.AuthorID may be zero for a revision that was not made by a user.
+func (s RevisionStore) CreateRevision(record revision.Revision) (revision.Revision, error) {
+	if record.GUID == "" {
+		return record, errors.New("must provide record.GUID to create the revision")
+	}
+	if record.PageGUID == "" {
+		return record, errors.New("must provide record.PageGUID to create the revision")
+	}
+	if record.Snapshot == nil {
+		return record, errors.New("must provide record.Snapshot to create the revision")
+	}
+	if s.db == nil {
+		return record, &storeerror.DBNotSetUp{}
+	}
+	pageID, err := getPageID(s.db, record.PageGUID)
+	if err != nil {
+		return record, errors.Wrapf(err, "unable to get Page.ID for guid: %v", record.PageGUID)
+	}
+	encodedChanges, err := revision.EncodeChanges(record.Changes)
+	if err != nil {
+		return record, errors.Wrap(err, "unable to encode the revision changes")
+	}
+	encodedSnapshot, err := pagediff.EncodeSnapshot(*record.Snapshot)
+	if err != nil {
+		return record, errors.Wrap(err, "unable to encode the revision snapshot")
+	}
+	t := time.Now()
+	query := wrapsql.InsertQuery{
+		IntoTable: "PageRevision",
+		InjectedValues: wrapsql.InjectedValues{
+			"Page_ID":   pageID,
+			"guid":      record.GUID,
+			"changes":   encodedChanges,
+			"snapshot":  encodedSnapshot,
+			"createdAt": &t,
+		},
+	}
+	if record.AuthorID != 0 {
+		query.InjectedValues["User_ID"] = record.AuthorID
+	}
+	id, err := wrapsql.ExecSingleInsert(s.db, query)
+	if err != nil {
+		return record, err
+	}
+	record.ID = id
+	record.CreatedAt = &t
+	return record, nil
+}
+
+// GetRevisions returns the revisions of the page, newest first, without their snapshots.
+func (s RevisionStore) GetRevisions(pageGUID string) (returnRevisions []revision.Revision, returnErr error) {
+	if pageGUID == "" {
+		returnErr = errors.New("must provide pageGUID to get the revisions")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageRevision.ID", "PageRevision.guid", "User.guid", "PageRevision.changes", "PageRevision.createdAt"},
+		FromTable: "PageRevision",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageRevision.Page_ID", RightSide: "Page.ID"}},
+			{JoinType: "LEFT", JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageRevision.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "PageRevision.ID",
+			SortBy: "DESC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnRevisions = make([]revision.Revision, 0)
+	defer rows.Close()
+	for rows.Next() {
+		r := revision.Revision{PageGUID: pageGUID}
+		var authorGUID sql.NullString
+		var encodedChanges string
+		err := rows.Scan(&r.ID, &r.GUID, &authorGUID, &encodedChanges, &r.CreatedAt)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		r.AuthorGUID = authorGUID.String
+		r.Changes, err = revision.DecodeChanges(encodedChanges)
+		if err != nil {
+			returnErr = errors.Wrapf(err, "unable to decode the changes of revision %v", r.GUID)
+			return
+		}
+		returnRevisions = append(returnRevisions, r)
+	}
+	return
+}
+
+// GetRevision returns the given revision of the page, with its snapshot.
+func (s RevisionStore) GetRevision(pageGUID, revisionGUID string) (revision.Revision, error) {
+	r := revision.Revision{PageGUID: pageGUID}
+	if pageGUID == "" {
+		return r, errors.New("must provide pageGUID to get the revision")
+	}
+	if revisionGUID == "" {
+		return r, errors.New("must provide revisionGUID to get the revision")
+	}
+	if s.db == nil {
+		return r, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageRevision.ID", "PageRevision.guid", "User.guid", "PageRevision.changes", "PageRevision.snapshot", "PageRevision.createdAt"},
+		FromTable: "PageRevision",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageRevision.Page_ID", RightSide: "Page.ID"}},
+			{JoinType: "LEFT", JoinTable: "User", On: wrapsql.OnClause{LeftSide: "PageRevision.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "PageRevision.guid", Operator: "= ?"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID, revisionGUID)
+	var authorGUID sql.NullString
+	var encodedChanges, encodedSnapshot string
+	err = wrapsql.GetSingleRow(revisionGUID, rows, err, &r.ID, &r.GUID, &authorGUID, &encodedChanges, &encodedSnapshot, &r.CreatedAt)
+	if err != nil {
+		return r, err
+	}
+	r.AuthorGUID = authorGUID.String
+	r.Changes, err = revision.DecodeChanges(encodedChanges)
+	if err != nil {
+		return r, errors.Wrap(err, "unable to decode the revision changes")
+	}
+	snapshot, err := pagediff.DecodeSnapshot(encodedSnapshot)
+	if err != nil {
+		return r, errors.Wrap(err, "unable to decode the revision snapshot")
+	}
+	r.Snapshot = &snapshot
+	return r, nil
+}
+
+// HasRevisions returns whether or not any revision has been recorded for the page.
+func (s RevisionStore) HasRevisions(pageGUID string) (bool, error) {
+	if pageGUID == "" {
+		return false, errors.New("must provide pageGUID to check for revisions")
+	}
+	if s.db == nil {
+		return false, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"COUNT(1)"},
+		FromTable: "PageRevision",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageRevision.Page_ID", RightSide: "Page.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+			},
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID)
+	var total int
+	err = wrapsql.GetSingleRow(pageGUID, rows, err, &total)
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
@@ -0,0 +1,195 @@
+package mysqlstore
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testRevisionStoreClearAllTables(db *sql.DB) error {
+	tables := []string{"PageRevision", "Page", "User"}
+	for _, table := range tables {
+		err := clearTableForTest(db, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGetRevisions(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name            string
+		preTestQueries  []string
+		paramPageGUID   string
+		returnRevisions []revision.Revision
+		returnErr       error
+	}{
+		{
+			name: "newest first",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageRevision (`Page_ID`, `guid`, `changes`, `snapshot`, `createdAt`) VALUES( 1, \"RV_1\", \"[]\", \"{}\", \"2020-01-01 00:00:00\" )",
+				"INSERT INTO PageRevision (`Page_ID`, `User_ID`, `guid`, `changes`, `snapshot`, `createdAt`) VALUES( 1, 1, \"RV_2\", \"[{\\\"target\\\":\\\"title\\\",\\\"before\\\":\\\"old\\\",\\\"after\\\":\\\"title\\\"}]\", \"{}\", \"2020-01-01 00:00:00\" )",
+				"INSERT INTO PageRevision (`Page_ID`, `User_ID`, `guid`, `changes`, `snapshot`, `createdAt`) VALUES( 2, 1, \"RV_3\", \"[]\", \"{}\", \"2020-01-01 00:00:00\" )",
+			},
+			paramPageGUID: "PG_1",
+			returnRevisions: []revision.Revision{
+				{
+					ID:         2,
+					GUID:       "RV_2",
+					PageGUID:   "PG_1",
+					AuthorGUID: "UR_1",
+					CreatedAt:  &createdAt,
+					Changes:    []revision.Change{{Target: pagediff.TargetTitle, Before: "old", After: "title"}},
+				},
+				{ID: 1, GUID: "RV_1", PageGUID: "PG_1", CreatedAt: &createdAt, Changes: []revision.Change{}},
+			},
+		},
+		{
+			name:            "no revisions",
+			paramPageGUID:   "PG_1",
+			returnRevisions: []revision.Revision{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			revisionStore := RevisionStore{
+				db: mysqldb,
+			}
+			err := testRevisionStoreClearAllTables(revisionStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(revisionStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := revisionStore.GetRevisions(tc.paramPageGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnRevisions, result)
+		})
+	}
+}
+
+func TestGetRevision(t *testing.T) {
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name                   string
+		shouldReplaceDBWithNil bool
+		preTestQueries         []string
+		paramPageGUID          string
+		paramRevisionGUID      string
+		returnRevision         revision.Revision
+		returnErr              error
+	}{
+		{
+			name: "happy path",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageRevision (`Page_ID`, `guid`, `changes`, `snapshot`, `createdAt`) VALUES( 1, \"RV_1\", \"[]\", \"{\\\"title\\\":\\\"title\\\",\\\"summary\\\":\\\"\\\"}\", \"2020-01-01 00:00:00\" )",
+			},
+			paramPageGUID:     "PG_1",
+			paramRevisionGUID: "RV_1",
+			returnRevision: revision.Revision{
+				ID:        1,
+				GUID:      "RV_1",
+				PageGUID:  "PG_1",
+				CreatedAt: &createdAt,
+				Changes:   []revision.Change{},
+				Snapshot:  &pagediff.Snapshot{Title: "title"},
+			},
+		},
+		{
+			name: "revision of another page",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_2\", \"title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageRevision (`Page_ID`, `guid`, `changes`, `snapshot`, `createdAt`) VALUES( 2, \"RV_1\", \"[]\", \"{}\", NOW() )",
+			},
+			paramPageGUID:     "PG_1",
+			paramRevisionGUID: "RV_1",
+			returnErr:         &storeerror.NotFound{ID: "RV_1"},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramPageGUID:          "PG_1",
+			paramRevisionGUID:      "RV_1",
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			revisionStore := RevisionStore{
+				db: mysqldb,
+			}
+			err := testRevisionStoreClearAllTables(revisionStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(revisionStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			if tc.shouldReplaceDBWithNil {
+				revisionStore.db = nil
+			}
+			result, err := revisionStore.GetRevision(tc.paramPageGUID, tc.paramRevisionGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnRevision, result)
+		})
+	}
+}
+
+func TestHasRevisions(t *testing.T) {
+	cases := []struct {
+		name           string
+		preTestQueries []string
+		paramPageGUID  string
+		returnBool     bool
+		returnErr      error
+	}{
+		{
+			name: "has revisions",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"title\", \"\", \"PR\", NOW(), NOW() )",
+				"INSERT INTO PageRevision (`Page_ID`, `guid`, `changes`, `snapshot`, `createdAt`) VALUES( 1, \"RV_1\", \"[]\", \"{}\", NOW() )",
+			},
+			paramPageGUID: "PG_1",
+			returnBool:    true,
+		},
+		{
+			name: "no revisions",
+			preTestQueries: []string{
+				"INSERT INTO Page (`Version_ID`, `PageTemplate_ID`, `guid`, `title`, `summary`, `permission`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"PG_1\", \"title\", \"\", \"PR\", NOW(), NOW() )",
+			},
+			paramPageGUID: "PG_1",
+			returnBool:    false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			revisionStore := RevisionStore{
+				db: mysqldb,
+			}
+			err := testRevisionStoreClearAllTables(revisionStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(revisionStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := revisionStore.HasRevisions(tc.paramPageGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnBool, result)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package mysqlstore
 
 import (
 	"database/sql"
-	"errors"
+	"time"
 
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagediff"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
 	"github.com/Pergamene/project-spiderweb-service/internal/util/wrapsql"
+	"github.com/pkg/errors"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 )
@@ -22,6 +25,97 @@ func NewVersionStore(mysqldb *sql.DB) VersionStore {
 	}
 }
 
+// GetUniqueVersionGUID returns a guid for the version that is guaranteed to be unique or errors.
+// If the proposedVersionGUID is not a zero-value and not unique, it will error.
+func (s VersionStore) GetUniqueVersionGUID(proposedVersionGUID string) (string, error) {
+	err := guidgen.CheckProposedGUID(proposedVersionGUID, "VR", 15)
+	if err != nil {
+		return "", err
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	return getUniqueGUID(s.db, "VR", 15, "Version", proposedVersionGUID, 0)
+}
+
+// CreateVersion creates a new version. If record.ParentGUID is provided, the version is created as its child.
+func (s VersionStore) CreateVersion(record version.Version, ownerID int64) (version.Version, error) {
+	if record.GUID == "" {
+		return record, errors.New("must provide record.GUID to create the version")
+	}
+	if record.Name == "" {
+		return record, errors.New("must provide record.Name to create the version")
+	}
+	if ownerID == 0 {
+		return record, errors.New("must provide ownerID to create the version")
+	}
+	if s.db == nil {
+		return record, &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	query := wrapsql.InsertQuery{
+		IntoTable: "Version",
+		InjectedValues: wrapsql.InjectedValues{
+			"User_ID":   ownerID,
+			"guid":      record.GUID,
+			"name":      record.Name,
+			"createdAt": &t,
+			"updatedAt": &t,
+		},
+	}
+	if record.ParentGUID != "" {
+		parentID, err := getVersionID(s.db, record.ParentGUID)
+		if err != nil {
+			return record, errors.Wrapf(err, "unable to get Version.ID for the parent guid: %v", record.ParentGUID)
+		}
+		query.InjectedValues["Parent_Version_ID"] = parentID
+	}
+	id, err := wrapsql.ExecSingleInsert(s.db, query)
+	if err != nil {
+		return record, err
+	}
+	record.ID = id
+	return record, nil
+}
+
+// CanEditVersion checks if the given user can modify the given version. If not, a storeerror.NotAuthorized will be returned.
+func (s VersionStore) CanEditVersion(guid, userID string) error {
+	if guid == "" {
+		return errors.New("must provide a guid to check privileges")
+	}
+	if userID == "" {
+		return errors.New("must provide a userID to check privileges")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Version.ID"},
+		FromTable: "Version",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "Version.User_ID", RightSide: "User.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Version.guid", Operator: "= ?"},
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Version.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid, userID)
+	var versionID int64
+	err = wrapsql.GetSingleRow(guid, rows, err, &versionID)
+	if _, ok := err.(*storeerror.NotFound); ok {
+		return &storeerror.NotAuthorized{
+			UserID:  userID,
+			TableID: guid,
+		}
+	}
+	return err
+}
+
 // GetVersion returns the given version.
 func (s VersionStore) GetVersion(guid string) (version.Version, error) {
 	if guid == "" {
@@ -31,18 +125,445 @@ func (s VersionStore) GetVersion(guid string) (version.Version, error) {
 		return version.Version{}, &storeerror.DBNotSetUp{}
 	}
 	statement := wrapsql.SelectStatement{
-		Selectors: []string{"ID", "guid", "name"},
+		Selectors: []string{"Version.ID", "Version.guid", "Version.name", "ParentVersion.guid"},
 		FromTable: "Version",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinType: "LEFT", JoinTable: "Version AS ParentVersion", On: wrapsql.OnClause{LeftSide: "Version.Parent_Version_ID", RightSide: "ParentVersion.ID"}},
+		},
 		WhereClause: wrapsql.WhereClause{
 			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
-				{LeftSide: "guid", Operator: "= ?"},
-				{LeftSide: "deletedAt", Operator: "IS NULL"},
+				{LeftSide: "Version.guid", Operator: "= ?"},
+				{LeftSide: "Version.deletedAt", Operator: "IS NULL"},
 			},
 		},
 		Limit: 1,
 	}
 	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
 	var v version.Version
-	err = wrapsql.GetSingleRow(guid, rows, err, &v.ID, &v.GUID, &v.Name)
+	var parentGUID sql.NullString
+	err = wrapsql.GetSingleRow(guid, rows, err, &v.ID, &v.GUID, &v.Name, &parentGUID)
+	v.ParentGUID = parentGUID.String
 	return v, err
 }
+
+// GetVersions returns all the versions owned by the user, ordered by name.
+func (s VersionStore) GetVersions(userID string) (returnVersions []version.Version, returnErr error) {
+	if userID == "" {
+		returnErr = errors.New("must provide userID to get the versions")
+		return
+	}
+	if s.db == nil {
+		returnErr = &storeerror.DBNotSetUp{}
+		return
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Version.ID", "Version.guid", "Version.name", "ParentVersion.guid"},
+		FromTable: "Version",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "User", On: wrapsql.OnClause{LeftSide: "Version.User_ID", RightSide: "User.ID"}},
+			{JoinType: "LEFT", JoinTable: "Version AS ParentVersion", On: wrapsql.OnClause{LeftSide: "Version.Parent_Version_ID", RightSide: "ParentVersion.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "User.guid", Operator: "= ?"},
+				{LeftSide: "Version.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		OrderClause: wrapsql.OrderClause{
+			Column: "Version.name",
+			SortBy: "ASC",
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), userID)
+	if err != nil {
+		returnErr = err
+		return
+	}
+	if err := rows.Err(); err != nil {
+		returnErr = err
+		return
+	}
+	returnVersions = make([]version.Version, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var v version.Version
+		var parentGUID sql.NullString
+		err := rows.Scan(&v.ID, &v.GUID, &v.Name, &parentGUID)
+		if err != nil {
+			returnErr = err
+			return
+		}
+		v.ParentGUID = parentGUID.String
+		returnVersions = append(returnVersions, v)
+	}
+	return
+}
+
+// UpdateVersion renames the given version. The parent of a version cannot be changed.
+func (s VersionStore) UpdateVersion(record version.Version) error {
+	if record.GUID == "" {
+		return errors.New("must provide record.GUID to update the version")
+	}
+	if record.Name == "" {
+		return errors.New("must provide record.Name to update the version")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	return wrapsql.ExecSingleUpdate(s.db, wrapsql.UpdateQuery{
+		UpdateTable: "Version",
+		InjectedValues: wrapsql.InjectedValues{
+			"name":      record.Name,
+			"updatedAt": &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+			},
+		},
+	}, record.GUID)
+}
+
+// RemoveVersion marks the given version as removed by setting the deletedAt property.
+func (s VersionStore) RemoveVersion(guid string) error {
+	if guid == "" {
+		return errors.New("must provide guid to remove the version")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	t := time.Now()
+	return wrapsql.ExecSingleUpdate(s.db, wrapsql.UpdateQuery{
+		UpdateTable: "Version",
+		InjectedValues: wrapsql.InjectedValues{
+			"deletedAt": &t,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+			},
+		},
+	}, guid)
+}
+
+// HasChildVersions returns whether or not any version that is not removed has the given version as its parent.
+func (s VersionStore) HasChildVersions(guid string) (bool, error) {
+	if guid == "" {
+		return false, errors.New("must provide guid to check for child versions")
+	}
+	if s.db == nil {
+		return false, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"COUNT(1)"},
+		FromTable: "Version",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Version AS ParentVersion", On: wrapsql.OnClause{LeftSide: "Version.Parent_Version_ID", RightSide: "ParentVersion.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "ParentVersion.guid", Operator: "= ?"},
+				{LeftSide: "Version.deletedAt", Operator: "IS NULL"},
+			},
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	var total int
+	err = wrapsql.GetSingleRow(guid, rows, err, &total)
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// HasVersionPages returns whether or not any page that is not removed belongs to the given version.
+func (s VersionStore) HasVersionPages(guid string) (bool, error) {
+	if guid == "" {
+		return false, errors.New("must provide guid to check for version pages")
+	}
+	if s.db == nil {
+		return false, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"COUNT(1)"},
+		FromTable: "Page",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Version", On: wrapsql.OnClause{LeftSide: "Page.Version_ID", RightSide: "Version.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Version.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+			},
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), guid)
+	var total int
+	err = wrapsql.GetSingleRow(guid, rows, err, &total)
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// CreatePageFork records that the page, and each of its details, were forked from the source page.
+func (s VersionStore) CreatePageFork(fork version.PageFork) error {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if fork.SourcePageGUID == "" {
+		return errors.New("must provide fork.SourcePageGUID to create the page fork")
+	}
+	if fork.PageGUID == "" {
+		return errors.New("must provide fork.PageGUID to create the page fork")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	sourcePageID, err := getPageID(s.db, fork.SourcePageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", fork.SourcePageGUID)
+	}
+	pageID, err := getPageID(s.db, fork.PageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", fork.PageGUID)
+	}
+	injectedValues := wrapsql.InjectedValues{
+		"Page_ID":        pageID,
+		"Source_Page_ID": sourcePageID,
+	}
+	if fork.Base != nil {
+		encodedBase, err := pagediff.EncodeSnapshot(*fork.Base)
+		if err != nil {
+			return errors.Wrap(err, "unable to encode the page fork base")
+		}
+		injectedValues["base"] = encodedBase
+	}
+	_, err = wrapsql.ExecSingleInsert(s.db, wrapsql.InsertQuery{
+		IntoTable:      "PageFork",
+		InjectedValues: injectedValues,
+	})
+	if err != nil {
+		return err
+	}
+	return s.insertPageDetailForks(fork)
+}
+
+func (s VersionStore) insertPageDetailForks(fork version.PageFork) error {
+	if len(fork.DetailGUIDs) == 0 {
+		return nil
+	}
+	query := wrapsql.BatchInsertQuery{
+		IntoTable:           "PageDetailFork",
+		BatchInjectedValues: wrapsql.BatchInjectedValues{},
+	}
+	for detailGUID, sourceDetailGUID := range fork.DetailGUIDs {
+		detailID, err := getPageDetailID(s.db, fork.PageGUID, detailGUID)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get PageDetail.ID for guid: %v", detailGUID)
+		}
+		sourceDetailID, err := getPageDetailID(s.db, fork.SourcePageGUID, sourceDetailGUID)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get PageDetail.ID for guid: %v", sourceDetailGUID)
+		}
+		query.BatchInjectedValues["PageDetail_ID"] = append(query.BatchInjectedValues["PageDetail_ID"], detailID)
+		query.BatchInjectedValues["Source_PageDetail_ID"] = append(query.BatchInjectedValues["Source_PageDetail_ID"], sourceDetailID)
+	}
+	err := wrapsql.ExecBatchInsert(s.db, query)
+	if err != nil {
+		return errors.Wrap(err, "unable to insert the page detail forks")
+	}
+	return nil
+}
+
+// GetForkedPageGUID returns the guid of the page that was forked from the source page into the given version.
+// If the source page has not been forked into the version, a storeerror.NotFound will be returned.
+func (s VersionStore) GetForkedPageGUID(sourcePageGUID, versionGUID string) (string, error) {
+	if sourcePageGUID == "" {
+		return "", errors.New("must provide sourcePageGUID to get the forked page")
+	}
+	if versionGUID == "" {
+		return "", errors.New("must provide versionGUID to get the forked page")
+	}
+	if s.db == nil {
+		return "", &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"Page.guid"},
+		FromTable: "PageFork",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageFork.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "Page AS SourcePage", On: wrapsql.OnClause{LeftSide: "PageFork.Source_Page_ID", RightSide: "SourcePage.ID"}},
+			{JoinTable: "Version", On: wrapsql.OnClause{LeftSide: "Page.Version_ID", RightSide: "Version.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "SourcePage.guid", Operator: "= ?"},
+				{LeftSide: "Version.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), sourcePageGUID, versionGUID)
+	var pageGUID string
+	err = wrapsql.GetSingleRow(sourcePageGUID, rows, err, &pageGUID)
+	return pageGUID, err
+}
+
+// GetPageFork returns the fork that the given page was created by.
+// If the page was not forked from another page, a storeerror.NotFound will be returned.
+func (s VersionStore) GetPageFork(pageGUID string) (version.PageFork, error) {
+	fork := version.PageFork{PageGUID: pageGUID}
+	if pageGUID == "" {
+		return fork, errors.New("must provide pageGUID to get the page fork")
+	}
+	if s.db == nil {
+		return fork, &storeerror.DBNotSetUp{}
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"SourcePage.guid", "PageFork.base"},
+		FromTable: "PageFork",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageFork.Page_ID", RightSide: "Page.ID"}},
+			{JoinTable: "Page AS SourcePage", On: wrapsql.OnClause{LeftSide: "PageFork.Source_Page_ID", RightSide: "SourcePage.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "SourcePage.deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID)
+	var encodedBase sql.NullString
+	err = wrapsql.GetSingleRow(pageGUID, rows, err, &fork.SourcePageGUID, &encodedBase)
+	if err != nil {
+		return fork, err
+	}
+	if encodedBase.Valid {
+		base, err := pagediff.DecodeSnapshot(encodedBase.String)
+		if err != nil {
+			return fork, errors.Wrap(err, "unable to decode the page fork base")
+		}
+		fork.Base = &base
+	}
+	fork.DetailGUIDs, err = s.getPageDetailForks(pageGUID)
+	if err != nil {
+		return fork, errors.Wrap(err, "unable to get the page detail forks")
+	}
+	return fork, nil
+}
+
+func (s VersionStore) getPageDetailForks(pageGUID string) (map[string]string, error) {
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"PageDetail.guid", "SourcePageDetail.guid"},
+		FromTable: "PageDetailFork",
+		JoinClauses: []wrapsql.JoinClause{
+			{JoinTable: "PageDetail", On: wrapsql.OnClause{LeftSide: "PageDetailFork.PageDetail_ID", RightSide: "PageDetail.ID"}},
+			{JoinTable: "PageDetail AS SourcePageDetail", On: wrapsql.OnClause{LeftSide: "PageDetailFork.Source_PageDetail_ID", RightSide: "SourcePageDetail.ID"}},
+			{JoinTable: "Page", On: wrapsql.OnClause{LeftSide: "PageDetail.Page_ID", RightSide: "Page.ID"}},
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page.guid", Operator: "= ?"},
+				{LeftSide: "PageDetail.deletedAt", Operator: "IS NULL"},
+				{LeftSide: "SourcePageDetail.deletedAt", Operator: "IS NULL"},
+			},
+		},
+	}
+	rows, err := s.db.Query(wrapsql.GetSelectString(statement), pageGUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	detailGUIDs := make(map[string]string)
+	defer rows.Close()
+	for rows.Next() {
+		var detailGUID, sourceDetailGUID string
+		err := rows.Scan(&detailGUID, &sourceDetailGUID)
+		if err != nil {
+			return nil, err
+		}
+		detailGUIDs[detailGUID] = sourceDetailGUID
+	}
+	return detailGUIDs, nil
+}
+
+// UpdatePageFork sets the base of the fork, and records any details that have been linked to the source page since it was forked.
+func (s VersionStore) UpdatePageFork(fork version.PageFork) error {
+	// @TODO: all this needs to be wrapped into a transaction with rollback.
+	if fork.SourcePageGUID == "" {
+		return errors.New("must provide fork.SourcePageGUID to update the page fork")
+	}
+	if fork.PageGUID == "" {
+		return errors.New("must provide fork.PageGUID to update the page fork")
+	}
+	if fork.Base == nil {
+		return errors.New("must provide fork.Base to update the page fork")
+	}
+	if s.db == nil {
+		return &storeerror.DBNotSetUp{}
+	}
+	pageID, err := getPageID(s.db, fork.PageGUID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get Page.ID for guid: %v", fork.PageGUID)
+	}
+	encodedBase, err := pagediff.EncodeSnapshot(*fork.Base)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the page fork base")
+	}
+	err = wrapsql.ExecSingleUpdate(s.db, wrapsql.UpdateQuery{
+		UpdateTable: "PageFork",
+		InjectedValues: wrapsql.InjectedValues{
+			"base": encodedBase,
+		},
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "Page_ID", Operator: "= ?"},
+			},
+		},
+	}, pageID)
+	if err != nil {
+		return err
+	}
+	existingDetailGUIDs, err := s.getPageDetailForks(fork.PageGUID)
+	if err != nil {
+		return errors.Wrap(err, "unable to get the page detail forks")
+	}
+	newDetailForks := version.PageFork{
+		SourcePageGUID: fork.SourcePageGUID,
+		PageGUID:       fork.PageGUID,
+		DetailGUIDs:    make(map[string]string),
+	}
+	for detailGUID, sourceDetailGUID := range fork.DetailGUIDs {
+		if _, ok := existingDetailGUIDs[detailGUID]; !ok {
+			newDetailForks.DetailGUIDs[detailGUID] = sourceDetailGUID
+		}
+	}
+	return s.insertPageDetailForks(newDetailForks)
+}
+
+func getVersionID(db *sql.DB, guid string) (int64, error) {
+	if guid == "" {
+		return -1, errors.New("must provide guid to get the version id")
+	}
+	statement := wrapsql.SelectStatement{
+		Selectors: []string{"ID"},
+		FromTable: "Version",
+		WhereClause: wrapsql.WhereClause{
+			Operator: "AND", WhereOperations: []wrapsql.WhereOperation{
+				{LeftSide: "guid", Operator: "= ?"},
+				{LeftSide: "deletedAt", Operator: "IS NULL"},
+			},
+		},
+		Limit: 1,
+	}
+	rows, err := db.Query(wrapsql.GetSelectString(statement), guid)
+	var versionID int64
+	err = wrapsql.GetSingleRow(guid, rows, err, &versionID)
+	return versionID, err
+}
@@ -5,13 +5,14 @@ import (
 	"testing"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/models/version"
+	"github.com/Pergamene/project-spiderweb-service/internal/stores/storeerror"
 
 	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
 	"github.com/stretchr/testify/require"
 )
 
 func testVersionStoreClearAllTables(db *sql.DB) error {
-	tables := []string{"Version"}
+	tables := []string{"Version", "User"}
 	for _, table := range tables {
 		err := clearTableForTest(db, table)
 		if err != nil {
@@ -42,6 +43,34 @@ func TestGetVersion(t *testing.T) {
 				Name: "TEST_VERSION",
 			},
 		},
+		{
+			name: "child version",
+			preTestQueries: []string{
+				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"Default\", NOW(), NOW())",
+				"INSERT INTO Version (`Parent_Version_ID`, `guid`, `name`, `createdAt`, `updatedAt`) VALUES( 1, \"VR_2\", \"New Campaign Changes\", NOW(), NOW())",
+			},
+			paramGUID: "VR_2",
+			returnVersion: version.Version{
+				ID:         2,
+				GUID:       "VR_2",
+				Name:       "New Campaign Changes",
+				ParentGUID: "VR_1",
+			},
+		},
+		{
+			name: "removed version",
+			preTestQueries: []string{
+				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( \"VR_1\", \"Default\", NOW(), NOW(), NOW())",
+			},
+			paramGUID: "VR_1",
+			returnErr: &storeerror.NotFound{ID: "VR_1"},
+		},
+		{
+			name:                   "db not set up",
+			shouldReplaceDBWithNil: true,
+			paramGUID:              "VR_1",
+			returnErr:              &storeerror.DBNotSetUp{},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -64,3 +93,98 @@ func TestGetVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersions(t *testing.T) {
+	cases := []struct {
+		name           string
+		preTestQueries []string
+		paramUserID    string
+		returnVersions []version.Version
+		returnErr      error
+	}{
+		{
+			name: "only active versions of the user",
+			preTestQueries: []string{
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_1\", \"bob@test.com\", NOW(), NOW())",
+				"INSERT INTO User (`guid`, `email`, `createdAt`, `updatedAt`) VALUES( \"UR_2\", \"alice@test.com\", NOW(), NOW())",
+				"INSERT INTO Version (`User_ID`, `guid`, `name`, `createdAt`, `updatedAt`) VALUES( 1, \"VR_1\", \"Default\", NOW(), NOW())",
+				"INSERT INTO Version (`User_ID`, `Parent_Version_ID`, `guid`, `name`, `createdAt`, `updatedAt`) VALUES( 1, 1, \"VR_2\", \"New Campaign Changes\", NOW(), NOW())",
+				"INSERT INTO Version (`User_ID`, `Parent_Version_ID`, `guid`, `name`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, 1, \"VR_3\", \"Abandoned Changes\", NOW(), NOW(), NOW())",
+				"INSERT INTO Version (`User_ID`, `guid`, `name`, `createdAt`, `updatedAt`) VALUES( 2, \"VR_4\", \"Another Default\", NOW(), NOW())",
+			},
+			paramUserID: "UR_1",
+			returnVersions: []version.Version{
+				{ID: 1, GUID: "VR_1", Name: "Default"},
+				{ID: 2, GUID: "VR_2", Name: "New Campaign Changes", ParentGUID: "VR_1"},
+			},
+		},
+		{
+			name:           "no versions",
+			paramUserID:    "UR_1",
+			returnVersions: []version.Version{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := VersionStore{
+				db: mysqldb,
+			}
+			err := testVersionStoreClearAllTables(versionStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(versionStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := versionStore.GetVersions(tc.paramUserID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnVersions, result)
+		})
+	}
+}
+
+func TestHasChildVersions(t *testing.T) {
+	cases := []struct {
+		name           string
+		preTestQueries []string
+		paramGUID      string
+		returnHas      bool
+		returnErr      error
+	}{
+		{
+			name: "has a child",
+			preTestQueries: []string{
+				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"Default\", NOW(), NOW())",
+				"INSERT INTO Version (`Parent_Version_ID`, `guid`, `name`, `createdAt`, `updatedAt`) VALUES( 1, \"VR_2\", \"New Campaign Changes\", NOW(), NOW())",
+			},
+			paramGUID: "VR_1",
+			returnHas: true,
+		},
+		{
+			name: "only removed children",
+			preTestQueries: []string{
+				"INSERT INTO Version (`guid`, `name`, `createdAt`, `updatedAt`) VALUES( \"VR_1\", \"Default\", NOW(), NOW())",
+				"INSERT INTO Version (`Parent_Version_ID`, `guid`, `name`, `createdAt`, `updatedAt`, `deletedAt`) VALUES( 1, \"VR_2\", \"New Campaign Changes\", NOW(), NOW(), NOW())",
+			},
+			paramGUID: "VR_1",
+			returnHas: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versionStore := VersionStore{
+				db: mysqldb,
+			}
+			err := testVersionStoreClearAllTables(versionStore.db)
+			require.NoError(t, err)
+			err = execPreTestQueries(versionStore.db, tc.preTestQueries)
+			require.NoError(t, err)
+			result, err := versionStore.HasChildVersions(tc.paramGUID)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.returnHas, result)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package store
+
+import "github.com/Pergamene/project-spiderweb-service/internal/models/audit"
+
+// AuditStore defines the required functionality for any associated store.
+type AuditStore interface {
+	GetUniqueAuditEntryGUID(proposedAuditEntryGUID string) (string, error)
+	CreateAuditEntry(record audit.Entry) (audit.Entry, error)
+	GetAuditEntries(filter audit.Filter, startGUID string, limit int) ([]audit.Entry, string, error)
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"github.com/Pergamene/project-spiderweb-service/internal/models/campaign"
+)
+
+// CampaignStore defines the required functionality for any associated store.
+type CampaignStore interface {
+	GetUniqueCampaignGUID(proposedCampaignGUID string) (string, error)
+	CreateCampaign(record campaign.Campaign, ownerID int64) (campaign.Campaign, error)
+	GetCampaignRole(campaignGUID, userID string) (campaign.Role, error)
+	GetCampaign(campaignGUID string) (campaign.Campaign, error)
+	GetCampaigns(userID string) ([]campaign.Campaign, error)
+	UpdateCampaign(record campaign.Campaign) error
+	RemoveCampaign(campaignGUID string) error
+	GetCampaignMembers(campaignGUID string) ([]campaign.Member, error)
+	SetCampaignMember(campaignGUID string, userID int64, role campaign.Role) error
+	RemoveCampaignMember(campaignGUID, userID string) error
+	AddCampaignPage(campaignGUID, pageGUID string) error
+	RemoveCampaignPage(campaignGUID, pageGUID string) error
+}
@@ -1,28 +1,34 @@
 // Code generated by mockery v1.0.0. DO NOT EDIT.
+
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
 import page "github.com/Pergamene/project-spiderweb-service/internal/models/page"
+import pagefilter "github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+import pagesort "github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
+import permission "github.com/Pergamene/project-spiderweb-service/internal/models/permission"
+import property "github.com/Pergamene/project-spiderweb-service/internal/models/property"
+import time "time"
 
 // PageStore is an autogenerated mock type for the PageStore type
 type PageStore struct {
 	mock.Mock
 }
 
-// CanEditPage provides a mock function with given fields: pageGUID, userID
-func (_m *PageStore) CanEditPage(pageGUID string, userID string) (bool, error) {
-	ret := _m.Called(pageGUID, userID)
+// CreatePage provides a mock function with given fields: record, ownerID
+func (_m *PageStore) CreatePage(record page.Page, ownerID int64) (page.Page, error) {
+	ret := _m.Called(record, ownerID)
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
-		r0 = rf(pageGUID, userID)
+	var r0 page.Page
+	if rf, ok := ret.Get(0).(func(page.Page, int64) page.Page); ok {
+		r0 = rf(record, ownerID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(page.Page)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(pageGUID, userID)
+	if rf, ok := ret.Get(1).(func(page.Page, int64) error); ok {
+		r1 = rf(record, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -30,20 +36,22 @@ func (_m *PageStore) CanEditPage(pageGUID string, userID string) (bool, error) {
 	return r0, r1
 }
 
-// CanReadPage provides a mock function with given fields: pageGUID, userID
-func (_m *PageStore) CanReadPage(pageGUID string, userID string) (bool, error) {
-	ret := _m.Called(pageGUID, userID)
+// GetAllPageGUIDs provides a mock function with given fields:
+func (_m *PageStore) GetAllPageGUIDs() ([]string, error) {
+	ret := _m.Called()
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
-		r0 = rf(pageGUID, userID)
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(pageGUID, userID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,20 +59,20 @@ func (_m *PageStore) CanReadPage(pageGUID string, userID string) (bool, error) {
 	return r0, r1
 }
 
-// CreatePage provides a mock function with given fields: record, ownerID
-func (_m *PageStore) CreatePage(record page.Page, ownerID int64) (page.Page, error) {
-	ret := _m.Called(record, ownerID)
+// GetPage provides a mock function with given fields: pageGUID
+func (_m *PageStore) GetPage(pageGUID string) (page.Page, error) {
+	ret := _m.Called(pageGUID)
 
 	var r0 page.Page
-	if rf, ok := ret.Get(0).(func(page.Page, int64) page.Page); ok {
-		r0 = rf(record, ownerID)
+	if rf, ok := ret.Get(0).(func(string) page.Page); ok {
+		r0 = rf(pageGUID)
 	} else {
 		r0 = ret.Get(0).(page.Page)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(page.Page, int64) error); ok {
-		r1 = rf(record, ownerID)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pageGUID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,15 +80,59 @@ func (_m *PageStore) CreatePage(record page.Page, ownerID int64) (page.Page, err
 	return r0, r1
 }
 
-// GetPage provides a mock function with given fields: pageGUID
-func (_m *PageStore) GetPage(pageGUID string) (page.Page, error) {
+// GetPageFacets provides a mock function with given fields: userID, filter
+func (_m *PageStore) GetPageFacets(userID string, filter pagefilter.Filter) (pagefilter.Facets, error) {
+	ret := _m.Called(userID, filter)
+
+	var r0 pagefilter.Facets
+	if rf, ok := ret.Get(0).(func(string, pagefilter.Filter) pagefilter.Facets); ok {
+		r0 = rf(userID, filter)
+	} else {
+		r0 = ret.Get(0).(pagefilter.Facets)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, pagefilter.Filter) error); ok {
+		r1 = rf(userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageOwnerGUID provides a mock function with given fields: pageGUID
+func (_m *PageStore) GetPageOwnerGUID(pageGUID string) (string, error) {
 	ret := _m.Called(pageGUID)
 
-	var r0 page.Page
-	if rf, ok := ret.Get(0).(func(string) page.Page); ok {
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
 		r0 = rf(pageGUID)
 	} else {
-		r0 = ret.Get(0).(page.Page)
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pageGUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageProperties provides a mock function with given fields: pageGUID
+func (_m *PageStore) GetPageProperties(pageGUID string) ([]property.Property, error) {
+	ret := _m.Called(pageGUID)
+
+	var r0 []property.Property
+	if rf, ok := ret.Get(0).(func(string) []property.Property); ok {
+		r0 = rf(pageGUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]property.Property)
+		}
 	}
 
 	var r1 error
@@ -93,13 +145,34 @@ func (_m *PageStore) GetPage(pageGUID string) (page.Page, error) {
 	return r0, r1
 }
 
-// GetPages provides a mock function with given fields: userID, nextBatchID, limit
-func (_m *PageStore) GetPages(userID string, nextBatchID string, limit int) ([]page.Page, int, string, error) {
-	ret := _m.Called(userID, nextBatchID, limit)
+// GetPageRole provides a mock function with given fields: pageGUID, userID
+func (_m *PageStore) GetPageRole(pageGUID string, userID string) (permission.Role, error) {
+	ret := _m.Called(pageGUID, userID)
+
+	var r0 permission.Role
+	if rf, ok := ret.Get(0).(func(string, string) permission.Role); ok {
+		r0 = rf(pageGUID, userID)
+	} else {
+		r0 = ret.Get(0).(permission.Role)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(pageGUID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPages provides a mock function with given fields: userID, filter, sort, cursor, limit
+func (_m *PageStore) GetPages(userID string, filter pagefilter.Filter, sort pagesort.Sort, cursor pagesort.Cursor, limit int) ([]page.Page, int, pagesort.Cursor, error) {
+	ret := _m.Called(userID, filter, sort, cursor, limit)
 
 	var r0 []page.Page
-	if rf, ok := ret.Get(0).(func(string, string, int) []page.Page); ok {
-		r0 = rf(userID, nextBatchID, limit)
+	if rf, ok := ret.Get(0).(func(string, pagefilter.Filter, pagesort.Sort, pagesort.Cursor, int) []page.Page); ok {
+		r0 = rf(userID, filter, sort, cursor, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]page.Page)
@@ -107,22 +180,22 @@ func (_m *PageStore) GetPages(userID string, nextBatchID string, limit int) ([]p
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(string, string, int) int); ok {
-		r1 = rf(userID, nextBatchID, limit)
+	if rf, ok := ret.Get(1).(func(string, pagefilter.Filter, pagesort.Sort, pagesort.Cursor, int) int); ok {
+		r1 = rf(userID, filter, sort, cursor, limit)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	var r2 string
-	if rf, ok := ret.Get(2).(func(string, string, int) string); ok {
-		r2 = rf(userID, nextBatchID, limit)
+	var r2 pagesort.Cursor
+	if rf, ok := ret.Get(2).(func(string, pagefilter.Filter, pagesort.Sort, pagesort.Cursor, int) pagesort.Cursor); ok {
+		r2 = rf(userID, filter, sort, cursor, limit)
 	} else {
-		r2 = ret.Get(2).(string)
+		r2 = ret.Get(2).(pagesort.Cursor)
 	}
 
 	var r3 error
-	if rf, ok := ret.Get(3).(func(string, string, int) error); ok {
-		r3 = rf(userID, nextBatchID, limit)
+	if rf, ok := ret.Get(3).(func(string, pagefilter.Filter, pagesort.Sort, pagesort.Cursor, int) error); ok {
+		r3 = rf(userID, filter, sort, cursor, limit)
 	} else {
 		r3 = ret.Error(3)
 	}
@@ -130,6 +203,52 @@ func (_m *PageStore) GetPages(userID string, nextBatchID string, limit int) ([]p
 	return r0, r1, r2, r3
 }
 
+// GetRemovedPageGUIDs provides a mock function with given fields: removedBefore
+func (_m *PageStore) GetRemovedPageGUIDs(removedBefore time.Time) ([]string, error) {
+	ret := _m.Called(removedBefore)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(time.Time) []string); ok {
+		r0 = rf(removedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(removedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRemovedPages provides a mock function with given fields: userID
+func (_m *PageStore) GetRemovedPages(userID string) ([]page.Page, error) {
+	ret := _m.Called(userID)
+
+	var r0 []page.Page
+	if rf, ok := ret.Get(0).(func(string) []page.Page); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]page.Page)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUniquePageGUID provides a mock function with given fields: proposedPageGUID
 func (_m *PageStore) GetUniquePageGUID(proposedPageGUID string) (string, error) {
 	ret := _m.Called(proposedPageGUID)
@@ -151,6 +270,43 @@ func (_m *PageStore) GetUniquePageGUID(proposedPageGUID string) (string, error)
 	return r0, r1
 }
 
+// GetUserPageGUIDs provides a mock function with given fields: userID
+func (_m *PageStore) GetUserPageGUIDs(userID string) ([]string, error) {
+	ret := _m.Called(userID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgePage provides a mock function with given fields: pageGUID
+func (_m *PageStore) PurgePage(pageGUID string) error {
+	ret := _m.Called(pageGUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pageGUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemovePage provides a mock function with given fields: pageGUID
 func (_m *PageStore) RemovePage(pageGUID string) error {
 	ret := _m.Called(pageGUID)
@@ -165,6 +321,34 @@ func (_m *PageStore) RemovePage(pageGUID string) error {
 	return r0
 }
 
+// ReplacePageProperties provides a mock function with given fields: pageGUID, pageProperties
+func (_m *PageStore) ReplacePageProperties(pageGUID string, pageProperties []property.Property) error {
+	ret := _m.Called(pageGUID, pageProperties)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []property.Property) error); ok {
+		r0 = rf(pageGUID, pageProperties)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestorePage provides a mock function with given fields: pageGUID
+func (_m *PageStore) RestorePage(pageGUID string) error {
+	ret := _m.Called(pageGUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pageGUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePage provides a mock function with given fields: record
 func (_m *PageStore) UpdatePage(record page.Page) error {
 	ret := _m.Called(record)
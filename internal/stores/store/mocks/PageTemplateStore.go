@@ -1,4 +1,5 @@
 // Code generated by mockery v1.0.0. DO NOT EDIT.
+
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
@@ -9,6 +10,41 @@ type PageTemplateStore struct {
 	mock.Mock
 }
 
+// CanEditPageTemplate provides a mock function with given fields: pageTemplateGUID, userID
+func (_m *PageTemplateStore) CanEditPageTemplate(pageTemplateGUID string, userID string) error {
+	ret := _m.Called(pageTemplateGUID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(pageTemplateGUID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePageTemplate provides a mock function with given fields: record, ownerID
+func (_m *PageTemplateStore) CreatePageTemplate(record pagetemplate.PageTemplate, ownerID int64) (pagetemplate.PageTemplate, error) {
+	ret := _m.Called(record, ownerID)
+
+	var r0 pagetemplate.PageTemplate
+	if rf, ok := ret.Get(0).(func(pagetemplate.PageTemplate, int64) pagetemplate.PageTemplate); ok {
+		r0 = rf(record, ownerID)
+	} else {
+		r0 = ret.Get(0).(pagetemplate.PageTemplate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(pagetemplate.PageTemplate, int64) error); ok {
+		r1 = rf(record, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPageTemplate provides a mock function with given fields: pageTemplateGUID
 func (_m *PageTemplateStore) GetPageTemplate(pageTemplateGUID string) (pagetemplate.PageTemplate, error) {
 	ret := _m.Called(pageTemplateGUID)
@@ -29,3 +65,75 @@ func (_m *PageTemplateStore) GetPageTemplate(pageTemplateGUID string) (pagetempl
 
 	return r0, r1
 }
+
+// GetPageTemplates provides a mock function with given fields: userID
+func (_m *PageTemplateStore) GetPageTemplates(userID string) ([]pagetemplate.PageTemplate, error) {
+	ret := _m.Called(userID)
+
+	var r0 []pagetemplate.PageTemplate
+	if rf, ok := ret.Get(0).(func(string) []pagetemplate.PageTemplate); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pagetemplate.PageTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUniquePageTemplateGUID provides a mock function with given fields: proposedPageTemplateGUID
+func (_m *PageTemplateStore) GetUniquePageTemplateGUID(proposedPageTemplateGUID string) (string, error) {
+	ret := _m.Called(proposedPageTemplateGUID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(proposedPageTemplateGUID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(proposedPageTemplateGUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPageTemplateDisabled provides a mock function with given fields: pageTemplateGUID, isDisabled
+func (_m *PageTemplateStore) SetPageTemplateDisabled(pageTemplateGUID string, isDisabled bool) error {
+	ret := _m.Called(pageTemplateGUID, isDisabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(pageTemplateGUID, isDisabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePageTemplate provides a mock function with given fields: record
+func (_m *PageTemplateStore) UpdatePageTemplate(record pagetemplate.PageTemplate) error {
+	ret := _m.Called(record)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(pagetemplate.PageTemplate) error); ok {
+		r0 = rf(record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -1,8 +1,8 @@
 // Code generated by mockery v1.0.0. DO NOT EDIT.
+
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
-
 import version "github.com/Pergamene/project-spiderweb-service/internal/models/version"
 
 // VersionStore is an autogenerated mock type for the VersionStore type
@@ -10,6 +10,118 @@ type VersionStore struct {
 	mock.Mock
 }
 
+// CanEditVersion provides a mock function with given fields: versionGUID, userID
+func (_m *VersionStore) CanEditVersion(versionGUID string, userID string) error {
+	ret := _m.Called(versionGUID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(versionGUID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePageFork provides a mock function with given fields: fork
+func (_m *VersionStore) CreatePageFork(fork version.PageFork) error {
+	ret := _m.Called(fork)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(version.PageFork) error); ok {
+		r0 = rf(fork)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateVersion provides a mock function with given fields: record, ownerID
+func (_m *VersionStore) CreateVersion(record version.Version, ownerID int64) (version.Version, error) {
+	ret := _m.Called(record, ownerID)
+
+	var r0 version.Version
+	if rf, ok := ret.Get(0).(func(version.Version, int64) version.Version); ok {
+		r0 = rf(record, ownerID)
+	} else {
+		r0 = ret.Get(0).(version.Version)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(version.Version, int64) error); ok {
+		r1 = rf(record, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForkedPageGUID provides a mock function with given fields: sourcePageGUID, versionGUID
+func (_m *VersionStore) GetForkedPageGUID(sourcePageGUID string, versionGUID string) (string, error) {
+	ret := _m.Called(sourcePageGUID, versionGUID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(sourcePageGUID, versionGUID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(sourcePageGUID, versionGUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPageFork provides a mock function with given fields: pageGUID
+func (_m *VersionStore) GetPageFork(pageGUID string) (version.PageFork, error) {
+	ret := _m.Called(pageGUID)
+
+	var r0 version.PageFork
+	if rf, ok := ret.Get(0).(func(string) version.PageFork); ok {
+		r0 = rf(pageGUID)
+	} else {
+		r0 = ret.Get(0).(version.PageFork)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pageGUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUniqueVersionGUID provides a mock function with given fields: proposedVersionGUID
+func (_m *VersionStore) GetUniqueVersionGUID(proposedVersionGUID string) (string, error) {
+	ret := _m.Called(proposedVersionGUID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(proposedVersionGUID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(proposedVersionGUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVersion provides a mock function with given fields: versionGUID
 func (_m *VersionStore) GetVersion(versionGUID string) (version.Version, error) {
 	ret := _m.Called(versionGUID)
@@ -30,3 +142,110 @@ func (_m *VersionStore) GetVersion(versionGUID string) (version.Version, error)
 
 	return r0, r1
 }
+
+// GetVersions provides a mock function with given fields: userID
+func (_m *VersionStore) GetVersions(userID string) ([]version.Version, error) {
+	ret := _m.Called(userID)
+
+	var r0 []version.Version
+	if rf, ok := ret.Get(0).(func(string) []version.Version); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]version.Version)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasChildVersions provides a mock function with given fields: versionGUID
+func (_m *VersionStore) HasChildVersions(versionGUID string) (bool, error) {
+	ret := _m.Called(versionGUID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(versionGUID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(versionGUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasVersionPages provides a mock function with given fields: versionGUID
+func (_m *VersionStore) HasVersionPages(versionGUID string) (bool, error) {
+	ret := _m.Called(versionGUID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(versionGUID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(versionGUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveVersion provides a mock function with given fields: versionGUID
+func (_m *VersionStore) RemoveVersion(versionGUID string) error {
+	ret := _m.Called(versionGUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(versionGUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePageFork provides a mock function with given fields: fork
+func (_m *VersionStore) UpdatePageFork(fork version.PageFork) error {
+	ret := _m.Called(fork)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(version.PageFork) error); ok {
+		r0 = rf(fork)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateVersion provides a mock function with given fields: record
+func (_m *VersionStore) UpdateVersion(record version.Version) error {
+	ret := _m.Called(record)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(version.Version) error); ok {
+		r0 = rf(record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -4,5 +4,11 @@ import "github.com/Pergamene/project-spiderweb-service/internal/models/pagedetai
 
 // PageDetailStore defines the required functionality for any associated store.
 type PageDetailStore interface {
-	UpdatePageDetail(record pagedetail.PageDetail) error
+	GetUniquePageDetailGUID(proposedPageDetailGUID string) (string, error)
+	CreatePageDetail(pageGUID string, record pagedetail.PageDetail) (pagedetail.PageDetail, error)
+	GetPageDetail(pageGUID, pageDetailGUID string) (pagedetail.PageDetail, error)
+	GetPageDetails(pageGUID string) ([]pagedetail.PageDetail, error)
+	UpdatePageDetail(pageGUID string, record pagedetail.PageDetail) error
+	RemovePageDetail(pageGUID, pageDetailGUID string) error
+	ReorderPageDetails(pageGUID string, pageDetailGUIDs []string) error
 }
@@ -1,20 +1,32 @@
 package store
 
 import (
+	"time"
+
 	"github.com/Pergamene/project-spiderweb-service/internal/models/page"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagefilter"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/pagesort"
+	"github.com/Pergamene/project-spiderweb-service/internal/models/permission"
 	"github.com/Pergamene/project-spiderweb-service/internal/models/property"
 )
 
 // PageStore defines the required functionality for any associated store.
 type PageStore interface {
 	GetUniquePageGUID(proposedPageGUID string) (string, error)
-	CanEditPage(pageGUID, userID string) (bool, error)
-	CanReadPage(pageGUID, userID string) (bool, error)
+	GetPageRole(pageGUID, userID string) (permission.Role, error)
+	GetPageOwnerGUID(pageGUID string) (string, error)
 	UpdatePage(record page.Page) error
 	CreatePage(record page.Page, ownerID int64) (page.Page, error)
 	GetPage(pageGUID string) (page.Page, error)
-	GetPages(userID string, nextBatchID string, limit int) ([]page.Page, int, string, error)
+	GetPages(userID string, filter pagefilter.Filter, sort pagesort.Sort, cursor pagesort.Cursor, limit int) ([]page.Page, int, pagesort.Cursor, error)
+	GetPageFacets(userID string, filter pagefilter.Filter) (pagefilter.Facets, error)
 	RemovePage(pageGUID string) error
+	GetRemovedPages(userID string) ([]page.Page, error)
+	GetRemovedPageGUIDs(removedBefore time.Time) ([]string, error)
+	RestorePage(pageGUID string) error
+	PurgePage(pageGUID string) error
+	GetAllPageGUIDs() ([]string, error)
+	GetUserPageGUIDs(userID string) ([]string, error)
 	GetPageProperties(pageGUID string) ([]property.Property, error)
 	ReplacePageProperties(pageGUID string, pageProperties []property.Property) error
 }
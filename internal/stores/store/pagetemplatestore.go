@@ -6,5 +6,11 @@ import (
 
 // PageTemplateStore defines the required functionality for any associated store.
 type PageTemplateStore interface {
+	GetUniquePageTemplateGUID(proposedPageTemplateGUID string) (string, error)
+	CreatePageTemplate(record pagetemplate.PageTemplate, ownerID int64) (pagetemplate.PageTemplate, error)
+	CanEditPageTemplate(pageTemplateGUID, userID string) error
 	GetPageTemplate(pageTemplateGUID string) (pagetemplate.PageTemplate, error)
+	GetPageTemplates(userID string) ([]pagetemplate.PageTemplate, error)
+	UpdatePageTemplate(record pagetemplate.PageTemplate) error
+	SetPageTemplateDisabled(pageTemplateGUID string, isDisabled bool) error
 }
@@ -0,0 +1,13 @@
+package store
+
+import "github.com/Pergamene/project-spiderweb-service/internal/models/property"
+
+// PropertyStore defines the required functionality for any associated store.
+type PropertyStore interface {
+	CreateProperty(record property.Property, ownerID int64) (property.Property, error)
+	GetProperty(key, userID string) (property.Property, error)
+	GetProperties(userID string) ([]property.Property, error)
+	UpdateProperty(record property.Property) error
+	SetPropertyDisabled(propertyID int64, isDisabled bool) error
+	IsPropertyInUse(propertyID int64) (bool, error)
+}
@@ -0,0 +1,10 @@
+package store
+
+import "github.com/Pergamene/project-spiderweb-service/internal/models/relation"
+
+// RelationStore defines the required functionality for any associated store.
+type RelationStore interface {
+	GetBacklinks(pageGUID string) ([]relation.Backlink, error)
+	GetEdges(pageGUIDs []string) ([]relation.Edge, error)
+	GetLinks(userID string) ([]relation.Link, error)
+}
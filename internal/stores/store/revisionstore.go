@@ -0,0 +1,12 @@
+package store
+
+import "github.com/Pergamene/project-spiderweb-service/internal/models/revision"
+
+// RevisionStore defines the required functionality for any associated store.
+type RevisionStore interface {
+	GetUniqueRevisionGUID(proposedRevisionGUID string) (string, error)
+	CreateRevision(record revision.Revision) (revision.Revision, error)
+	GetRevisions(pageGUID string) ([]revision.Revision, error)
+	GetRevision(pageGUID, revisionGUID string) (revision.Revision, error)
+	HasRevisions(pageGUID string) (bool, error)
+}
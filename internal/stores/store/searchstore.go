@@ -0,0 +1,10 @@
+package store
+
+import "github.com/Pergamene/project-spiderweb-service/internal/models/search"
+
+// SearchStore defines the required functionality for any associated store.
+type SearchStore interface {
+	IndexPage(document search.Document) error
+	RemovePage(pageGUID string) error
+	Search(query string, filter search.Filter, limit int) ([]search.Result, error)
+}
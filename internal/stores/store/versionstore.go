@@ -4,5 +4,17 @@ import "github.com/Pergamene/project-spiderweb-service/internal/models/version"
 
 // VersionStore defines the required functionality for any associated store.
 type VersionStore interface {
+	GetUniqueVersionGUID(proposedVersionGUID string) (string, error)
+	CreateVersion(record version.Version, ownerID int64) (version.Version, error)
+	CanEditVersion(versionGUID, userID string) error
 	GetVersion(versionGUID string) (version.Version, error)
+	GetVersions(userID string) ([]version.Version, error)
+	UpdateVersion(record version.Version) error
+	RemoveVersion(versionGUID string) error
+	HasChildVersions(versionGUID string) (bool, error)
+	HasVersionPages(versionGUID string) (bool, error)
+	CreatePageFork(fork version.PageFork) error
+	GetForkedPageGUID(sourcePageGUID, versionGUID string) (string, error)
+	GetPageFork(pageGUID string) (version.PageFork, error)
+	UpdatePageFork(fork version.PageFork) error
 }
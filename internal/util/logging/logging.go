@@ -0,0 +1,56 @@
+// Package logging provides the service's structured logger and passes it along with the context.
+package logging
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The formats a logger can write in.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// New returns a logger that writes to stderr at or above the level, such as debug, info, warn or error,
+// and in the format, which is either FormatJSON or FormatConsole.
+func New(level, format string) (*zap.Logger, error) {
+	var zapLevel zapcore.Level
+	if err := zapLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, errors.Errorf("invalid log level %q", level)
+	}
+	config := zap.NewProductionConfig()
+	switch format {
+	case FormatJSON:
+	case FormatConsole:
+		config.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+	default:
+		return nil, errors.Errorf("invalid log format %q, must be %v or %v", format, FormatJSON, FormatConsole)
+	}
+	config.Encoding = format
+	config.Level = zap.NewAtomicLevelAt(zapLevel)
+	// every request is logged once, so none should be sampled away.
+	config.Sampling = nil
+	return config.Build()
+}
+
+type loggerKeyType string
+
+const loggerKey = loggerKeyType("logger")
+
+// SetOnContext sets the logger on the context.
+func SetOnContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// GetFromContext returns the logger from the context, or a logger that discards everything if there is none.
+func GetFromContext(ctx context.Context) *zap.Logger {
+	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
+	if !ok || logger == nil {
+		return zap.NewNop()
+	}
+	return logger
+}
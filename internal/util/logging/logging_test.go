@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name         string
+		paramLevel   string
+		paramFormat  string
+		debugEnabled bool
+		returnErr    error
+	}{
+		{
+			name:        "test json",
+			paramLevel:  "info",
+			paramFormat: FormatJSON,
+		},
+		{
+			name:         "test console at debug",
+			paramLevel:   "debug",
+			paramFormat:  FormatConsole,
+			debugEnabled: true,
+		},
+		{
+			name:        "test invalid level",
+			paramLevel:  "loud",
+			paramFormat: FormatJSON,
+			returnErr:   errors.New("invalid log level \"loud\""),
+		},
+		{
+			name:        "test invalid format",
+			paramLevel:  "info",
+			paramFormat: "xml",
+			returnErr:   errors.New("invalid log format \"xml\", must be json or console"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, err := New(tc.paramLevel, tc.paramFormat)
+			errExpected := testutils.TestErrorAgainstCase(t, err, tc.returnErr)
+			if errExpected {
+				return
+			}
+			require.Equal(t, tc.debugEnabled, logger.Core().Enabled(zap.DebugLevel))
+			require.True(t, logger.Core().Enabled(zap.InfoLevel))
+		})
+	}
+}
+
+func TestGetFromContext(t *testing.T) {
+	logger := zap.NewExample()
+	require.Equal(t, logger, GetFromContext(SetOnContext(context.Background(), logger)))
+	require.NotNil(t, GetFromContext(context.Background()))
+}
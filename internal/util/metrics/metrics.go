@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// namespace prefixes the name of every metric of the service.
+const namespace = "spiderweb_"
+
+// unmatchedRoute is the route of requests that did not match any route, so that they are counted together.
+const unmatchedRoute = "unmatched"
+
+// Metrics are the metrics of the service's HTTP requests, store calls and database connections.
+type Metrics struct {
+	registry          *Registry
+	httpRequests      *CounterVec
+	httpRequestTiming *HistogramVec
+	storeCalls        *CounterVec
+	storeErrors       *CounterVec
+	storeCallTiming   *HistogramVec
+}
+
+// New returns Metrics with nothing observed yet.
+func New() *Metrics {
+	registry := &Registry{}
+	return &Metrics{
+		registry:          registry,
+		httpRequests:      registry.NewCounterVec(namespace+"http_requests_total", "HTTP requests by route and status code.", "method", "route", "status"),
+		httpRequestTiming: registry.NewHistogramVec(namespace+"http_request_duration_seconds", "Latency of HTTP requests by route.", DefaultBuckets, "method", "route"),
+		storeCalls:        registry.NewCounterVec(namespace+"store_calls_total", "Calls to the stores by method.", "store", "method"),
+		storeErrors:       registry.NewCounterVec(namespace+"store_errors_total", "Calls to the stores that returned an error, by method.", "store", "method"),
+		storeCallTiming:   registry.NewHistogramVec(namespace+"store_call_duration_seconds", "Latency of calls to the stores by method.", DefaultBuckets, "store", "method"),
+	}
+}
+
+// ObserveRequest records a request that was responded to. The route is its pattern, such as /api/pages/:pageID,
+// rather than its path, so that requests for different ids are counted together.
+func (m *Metrics) ObserveRequest(method, route string, status int, latency time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	m.httpRequests.Inc(method, route, strconv.Itoa(status))
+	m.httpRequestTiming.Observe(latency.Seconds(), method, route)
+}
+
+// ObserveStoreCall records a call to a store's method, and whether it returned an error.
+func (m *Metrics) ObserveStoreCall(store, method string, latency time.Duration, err error) {
+	m.storeCalls.Inc(store, method)
+	if err != nil {
+		m.storeErrors.Inc(store, method)
+	}
+	m.storeCallTiming.Observe(latency.Seconds(), store, method)
+}
+
+// ObserveDB reports the connection pool stats of the db, read whenever the metrics are reported.
+func (m *Metrics) ObserveDB(db *sql.DB) {
+	m.registry.NewGaugeFunc(namespace+"db_max_open_connections", "Maximum number of open connections to the database.", func() float64 {
+		return float64(db.Stats().MaxOpenConnections)
+	})
+	m.registry.NewGaugeFunc(namespace+"db_open_connections", "Number of established connections to the database, both in use and idle.", func() float64 {
+		return float64(db.Stats().OpenConnections)
+	})
+	m.registry.NewGaugeFunc(namespace+"db_in_use_connections", "Number of connections to the database currently in use.", func() float64 {
+		return float64(db.Stats().InUse)
+	})
+	m.registry.NewGaugeFunc(namespace+"db_idle_connections", "Number of idle connections to the database.", func() float64 {
+		return float64(db.Stats().Idle)
+	})
+	m.registry.NewCounterFunc(namespace+"db_wait_count_total", "Number of connections waited for.", func() float64 {
+		return float64(db.Stats().WaitCount)
+	})
+	m.registry.NewCounterFunc(namespace+"db_wait_duration_seconds_total", "Time spent waiting for new connections.", func() float64 {
+		return db.Stats().WaitDuration.Seconds()
+	})
+	m.registry.NewCounterFunc(namespace+"db_max_idle_closed_total", "Number of connections closed for exceeding the maximum idle connections.", func() float64 {
+		return float64(db.Stats().MaxIdleClosed)
+	})
+	m.registry.NewCounterFunc(namespace+"db_max_lifetime_closed_total", "Number of connections closed for exceeding their maximum lifetime.", func() float64 {
+		return float64(db.Stats().MaxLifetimeClosed)
+	})
+}
+
+// ServeHTTP responds with every metric in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body bytes.Buffer
+	m.registry.Report(&body)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
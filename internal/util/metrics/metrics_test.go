@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	m.ObserveRequest(http.MethodGet, "/api/pages/:pageID", http.StatusOK, 20*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+	m.ObserveStoreCall("PageStore", "GetPages", 30*time.Millisecond, nil)
+	m.ObserveStoreCall("PageStore", "GetPages", 40*time.Millisecond, errors.New("failure"))
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	report := w.Body.String()
+	require.Contains(t, report, "spiderweb_http_requests_total{method=\"GET\",route=\"/api/pages/:pageID\",status=\"200\"} 1\n")
+	require.Contains(t, report, "spiderweb_http_requests_total{method=\"GET\",route=\"unmatched\",status=\"404\"} 1\n")
+	require.Contains(t, report, "spiderweb_http_request_duration_seconds_bucket{method=\"GET\",route=\"/api/pages/:pageID\",le=\"0.025\"} 1\n")
+	require.Contains(t, report, "spiderweb_store_calls_total{store=\"PageStore\",method=\"GetPages\"} 2\n")
+	require.Contains(t, report, "spiderweb_store_errors_total{store=\"PageStore\",method=\"GetPages\"} 1\n")
+	require.Contains(t, report, "spiderweb_store_call_duration_seconds_count{store=\"PageStore\",method=\"GetPages\"} 2\n")
+}
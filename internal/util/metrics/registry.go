@@ -0,0 +1,235 @@
+// Package metrics keeps counters, histograms and gauges, and reports them in the Prometheus text format.
+// See: https://prometheus.io/docs/instrumenting/exposition_formats/#text-based-format
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the Prometheus text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds, in seconds, of the histogram buckets for timing typical requests.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type metric interface {
+	write(w io.Writer)
+}
+
+// Registry keeps the metrics to report.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// Report writes every metric in the order it was registered.
+func (r *Registry) Report(w io.Writer) {
+	r.mu.Lock()
+	metrics := append([]metric{}, r.metrics...)
+	r.mu.Unlock()
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// CounterVec counts, separately for each combination of label values.
+type CounterVec struct {
+	name       string
+	help       string
+	labelNames []string
+	mu         sync.Mutex
+	values     map[string]float64
+}
+
+// NewCounterVec registers a CounterVec.
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     map[string]float64{},
+	}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the count for the label values, which are in the order of the label names.
+func (c *CounterVec) Inc(labelValues ...string) {
+	key := joinLabelValues(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key]++
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	writeHeader(w, c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%v%v %v\n", c.name, formatLabels(c.labelNames, splitLabelValues(key)), formatValue(c.values[key]))
+	}
+}
+
+// HistogramVec counts observations into buckets, separately for each combination of label values.
+type HistogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+	mu         sync.Mutex
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+}
+
+// NewHistogramVec registers a HistogramVec with buckets of the given upper bounds, in increasing order.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	h := &HistogramVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    buckets,
+		histograms: map[string]*histogram{},
+	}
+	r.register(h)
+	return h
+}
+
+// Observe records the value for the label values, which are in the order of the label names.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := joinLabelValues(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist, ok := h.histograms[key]
+	if !ok {
+		hist = &histogram{bucketCounts: make([]uint64, len(h.buckets))}
+		h.histograms[key] = hist
+	}
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			hist.bucketCounts[i]++
+		}
+	}
+	hist.count++
+	hist.sum += value
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	writeHeader(w, h.name, h.help, "histogram")
+	keys := make([]string, 0, len(h.histograms))
+	for key := range h.histograms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	bucketLabelNames := append(append([]string{}, h.labelNames...), "le")
+	for _, key := range keys {
+		hist := h.histograms[key]
+		labelValues := splitLabelValues(key)
+		for i, upperBound := range h.buckets {
+			fmt.Fprintf(w, "%v_bucket%v %v\n", h.name, formatLabels(bucketLabelNames, withLabelValue(labelValues, formatValue(upperBound))), hist.bucketCounts[i])
+		}
+		fmt.Fprintf(w, "%v_bucket%v %v\n", h.name, formatLabels(bucketLabelNames, withLabelValue(labelValues, "+Inf")), hist.count)
+		fmt.Fprintf(w, "%v_sum%v %v\n", h.name, formatLabels(h.labelNames, labelValues), formatValue(hist.sum))
+		fmt.Fprintf(w, "%v_count%v %v\n", h.name, formatLabels(h.labelNames, labelValues), hist.count)
+	}
+}
+
+// valueFunc is a metric whose single value is read when it is reported.
+type valueFunc struct {
+	name       string
+	help       string
+	metricType string
+	value      func() float64
+}
+
+// NewGaugeFunc registers a gauge whose value is read from the func when it is reported.
+func (r *Registry) NewGaugeFunc(name, help string, value func() float64) {
+	r.register(&valueFunc{name: name, help: help, metricType: "gauge", value: value})
+}
+
+// NewCounterFunc registers a counter whose value is read from the func when it is reported.
+// The value must only ever increase.
+func (r *Registry) NewCounterFunc(name, help string, value func() float64) {
+	r.register(&valueFunc{name: name, help: help, metricType: "counter", value: value})
+}
+
+func (v *valueFunc) write(w io.Writer) {
+	writeHeader(w, v.name, v.help, v.metricType)
+	fmt.Fprintf(w, "%v %v\n", v.name, formatValue(v.value()))
+}
+
+func writeHeader(w io.Writer, name, help, metricType string) {
+	fmt.Fprintf(w, "# HELP %v %v\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	fmt.Fprintf(w, "# TYPE %v %v\n", name, metricType)
+}
+
+// labelValueSeparator cannot appear in a label value that is valid UTF-8, so it safely joins them into a key.
+const labelValueSeparator = "\xff"
+
+func joinLabelValues(labelValues []string) string {
+	return strings.Join(labelValues, labelValueSeparator)
+}
+
+func splitLabelValues(key string) []string {
+	return strings.Split(key, labelValueSeparator)
+}
+
+func withLabelValue(labelValues []string, labelValue string) []string {
+	return append(append([]string{}, labelValues...), labelValue)
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(labelNames, labelValues []string) string {
+	if len(labelNames) == 0 {
+		return ""
+	}
+	labels := make([]string, len(labelNames))
+	for i, labelName := range labelNames {
+		labelValue := ""
+		if i < len(labelValues) {
+			labelValue = labelValues[i]
+		}
+		labels[i] = fmt.Sprintf("%v=\"%v\"", labelName, labelValueReplacer.Replace(labelValue))
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	cases := []struct {
+		name           string
+		setup          func(r *Registry)
+		expectedReport string
+	}{
+		{
+			name: "test counter",
+			setup: func(r *Registry) {
+				c := r.NewCounterVec("requests_total", "Requests.", "route", "status")
+				c.Inc("/b", "200")
+				c.Inc("/a", "500")
+				c.Inc("/b", "200")
+			},
+			expectedReport: "# HELP requests_total Requests.\n" +
+				"# TYPE requests_total counter\n" +
+				"requests_total{route=\"/a\",status=\"500\"} 1\n" +
+				"requests_total{route=\"/b\",status=\"200\"} 2\n",
+		},
+		{
+			name: "test histogram",
+			setup: func(r *Registry) {
+				h := r.NewHistogramVec("duration_seconds", "Duration.", []float64{0.1, 1}, "route")
+				h.Observe(0.05, "/a")
+				h.Observe(0.5, "/a")
+				h.Observe(2, "/a")
+			},
+			expectedReport: "# HELP duration_seconds Duration.\n" +
+				"# TYPE duration_seconds histogram\n" +
+				"duration_seconds_bucket{route=\"/a\",le=\"0.1\"} 1\n" +
+				"duration_seconds_bucket{route=\"/a\",le=\"1\"} 2\n" +
+				"duration_seconds_bucket{route=\"/a\",le=\"+Inf\"} 3\n" +
+				"duration_seconds_sum{route=\"/a\"} 2.55\n" +
+				"duration_seconds_count{route=\"/a\"} 3\n",
+		},
+		{
+			name: "test gauge and counter funcs",
+			setup: func(r *Registry) {
+				r.NewGaugeFunc("open_connections", "Open connections.", func() float64 { return 3 })
+				r.NewCounterFunc("waits_total", "Waits.", func() float64 { return math.Inf(1) })
+			},
+			expectedReport: "# HELP open_connections Open connections.\n" +
+				"# TYPE open_connections gauge\n" +
+				"open_connections 3\n" +
+				"# HELP waits_total Waits.\n" +
+				"# TYPE waits_total counter\n" +
+				"waits_total +Inf\n",
+		},
+		{
+			name: "test label values are escaped",
+			setup: func(r *Registry) {
+				c := r.NewCounterVec("requests_total", "Requests.", "route")
+				c.Inc("/a\"b\\c\nd")
+			},
+			expectedReport: "# HELP requests_total Requests.\n" +
+				"# TYPE requests_total counter\n" +
+				"requests_total{route=\"/a\\\"b\\\\c\\nd\"} 1\n",
+		},
+		{
+			name: "test nothing observed",
+			setup: func(r *Registry) {
+				r.NewCounterVec("requests_total", "Requests.", "route")
+			},
+			expectedReport: "# HELP requests_total Requests.\n" +
+				"# TYPE requests_total counter\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Registry{}
+			tc.setup(r)
+			var report bytes.Buffer
+			r.Report(&report)
+			require.Equal(t, tc.expectedReport, report.String())
+		})
+	}
+}
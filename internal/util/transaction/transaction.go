@@ -1,7 +1,65 @@
 package transaction
 
+import (
+	"context"
+	"regexp"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/guidgen"
+	"github.com/pkg/errors"
+)
+
+// RequestIDHeader is the header a caller may set the request id with. It is always echoed in the response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the longest request id that is accepted from a caller.
+const maxRequestIDLength = 128
+
+var isValidRequestID = regexp.MustCompile(`^[a-zA-Z0-9._:-]+$`).MatchString
+
 // Transaction are the details that are attached to each request.
 type Transaction struct {
-	TransactionID   string
-	RequestID       string
+	// TransactionID is unique to each request the service handles.
+	TransactionID string
+	// RequestID identifies the request to the caller. It is given by the caller, such as to keep the same id
+	// across retries, or is the TransactionID if they did not give a valid one.
+	RequestID string
+}
+
+// New returns a Transaction with a new TransactionID, keeping the given request id if it is valid.
+func New(requestID string) Transaction {
+	t := Transaction{
+		TransactionID: guidgen.GenerateGUID("TX", 20),
+		RequestID:     requestID,
+	}
+	if len(requestID) > maxRequestIDLength || !isValidRequestID(requestID) {
+		t.RequestID = t.TransactionID
+	}
+	return t
+}
+
+type transactionKeyType string
+
+const transactionKey = transactionKeyType("transaction")
+
+// SetOnContext sets the Transaction on the context.
+func SetOnContext(ctx context.Context, t Transaction) context.Context {
+	return context.WithValue(ctx, transactionKey, t)
+}
+
+// GetFromContext returns the Transaction from the context, or a zero-value Transaction if there is none.
+func GetFromContext(ctx context.Context) Transaction {
+	t, _ := ctx.Value(transactionKey).(Transaction)
+	return t
+}
+
+// WrapError annotates the error with the ids of the context's Transaction, so that it can be matched to the request it failed.
+func WrapError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	t := GetFromContext(ctx)
+	if t.RequestID == "" {
+		return err
+	}
+	return errors.Wrapf(err, "request %v (transaction %v)", t.RequestID, t.TransactionID)
 }
@@ -0,0 +1,84 @@
+package transaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name           string
+		paramRequestID string
+		keepsRequestID bool
+	}{
+		{
+			name:           "test the caller's request id is kept",
+			paramRequestID: "3f2c9a1e-7d4b-4c8e-9a51-0b6e2d7f1c33",
+			keepsRequestID: true,
+		},
+		{
+			name:           "test no request id",
+			paramRequestID: "",
+		},
+		{
+			name:           "test request id with invalid characters",
+			paramRequestID: "abc\ndef",
+		},
+		{
+			name:           "test request id that is too long",
+			paramRequestID: strings.Repeat("a", maxRequestIDLength+1),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := New(tc.paramRequestID)
+			require.True(t, strings.HasPrefix(result.TransactionID, "TX_"))
+			if tc.keepsRequestID {
+				require.Equal(t, tc.paramRequestID, result.RequestID)
+				return
+			}
+			require.Equal(t, result.TransactionID, result.RequestID)
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	ctx := SetOnContext(context.Background(), Transaction{TransactionID: "TX_1", RequestID: "RQ_1"})
+	cases := []struct {
+		name      string
+		paramCtx  context.Context
+		paramErr  error
+		returnErr error
+	}{
+		{
+			name:      "test error is annotated",
+			paramCtx:  ctx,
+			paramErr:  errors.New("failure"),
+			returnErr: errors.New("request RQ_1 (transaction TX_1): failure"),
+		},
+		{
+			name:      "test no transaction",
+			paramCtx:  context.Background(),
+			paramErr:  errors.New("failure"),
+			returnErr: errors.New("failure"),
+		},
+		{
+			name:     "test no error",
+			paramCtx: ctx,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := WrapError(tc.paramCtx, tc.paramErr)
+			if tc.returnErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.returnErr.Error())
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Package usertoken signs and verifies the bearer tokens that users authenticate with.
+// Tokens are JWTs signed with HMAC-SHA256 (HS256), so that they can be checked without a lookup.
+package usertoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/clock"
+	"github.com/pkg/errors"
+)
+
+const algorithm = "HS256"
+
+// Signer signs and verifies tokens for users.
+// Tokens are only valid for the Signer's Issuer and Audience, and until they expire after the TTL.
+type Signer struct {
+	Secret   []byte
+	Issuer   string
+	Audience string
+	TTL      time.Duration
+	Clock    clock.Clock
+}
+
+// Token is a signed token for a user.
+type Token struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Claims are what a token says about its user.
+type Claims struct {
+	Subject   string `json:"sub"`
+	Issuer    string `json:"iss"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+// InvalidToken is an error that signifies that a token cannot be trusted.
+type InvalidToken struct {
+	Reason string
+}
+
+func (e *InvalidToken) Error() string {
+	return fmt.Sprintf("invalid token: %v", e.Reason)
+}
+
+// Sign returns a token for the user that expires after the TTL.
+func (s Signer) Sign(userID string) (Token, error) {
+	if userID == "" {
+		return Token{}, errors.New("must provide a user id to sign a token")
+	}
+	if len(s.Secret) == 0 {
+		return Token{}, errors.New("must have a secret to sign a token")
+	}
+	now := s.now()
+	expiresAt := now.Add(s.TTL)
+	claims := Claims{
+		Subject:   userID,
+		Issuer:    s.Issuer,
+		Audience:  s.Audience,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+	encodedHeader, err := encode(header{Algorithm: algorithm, Type: "JWT"})
+	if err != nil {
+		return Token{}, err
+	}
+	encodedClaims, err := encode(claims)
+	if err != nil {
+		return Token{}, err
+	}
+	signingInput := encodedHeader + "." + encodedClaims
+	return Token{
+		Token:     signingInput + "." + s.signature(signingInput),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+	}, nil
+}
+
+// Verify returns the claims of the token if it was signed by the Signer, is for its Issuer and Audience and has not expired.
+// If not, an InvalidToken will be returned.
+func (s Signer) Verify(token string) (Claims, error) {
+	if len(s.Secret) == 0 {
+		return Claims{}, &InvalidToken{Reason: "tokens are not accepted"}
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, &InvalidToken{Reason: "malformed"}
+	}
+	var h header
+	if decode(parts[0], &h) != nil {
+		return Claims{}, &InvalidToken{Reason: "malformed header"}
+	}
+	// only HS256 is accepted, so that a token cannot choose to be checked with a weaker algorithm or none at all.
+	if h.Algorithm != algorithm {
+		return Claims{}, &InvalidToken{Reason: "unsupported algorithm"}
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(parts[0]+"."+parts[1]))) {
+		return Claims{}, &InvalidToken{Reason: "bad signature"}
+	}
+	var claims Claims
+	if decode(parts[1], &claims) != nil {
+		return Claims{}, &InvalidToken{Reason: "malformed claims"}
+	}
+	if claims.Subject == "" {
+		return Claims{}, &InvalidToken{Reason: "no subject"}
+	}
+	if claims.Issuer != s.Issuer {
+		return Claims{}, &InvalidToken{Reason: "wrong issuer"}
+	}
+	if claims.Audience != s.Audience {
+		return Claims{}, &InvalidToken{Reason: "wrong audience"}
+	}
+	if s.now().Unix() >= claims.ExpiresAt {
+		return Claims{}, &InvalidToken{Reason: "expired"}
+	}
+	return claims, nil
+}
+
+func (s Signer) signature(signingInput string) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s Signer) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
+
+func encode(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decode(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
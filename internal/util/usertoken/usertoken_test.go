@@ -0,0 +1,141 @@
+package usertoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pergamene/project-spiderweb-service/internal/util/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func testSigner(now time.Time) Signer {
+	return Signer{
+		Secret:   []byte("SECRET"),
+		Issuer:   "spiderweb",
+		Audience: "spiderweb-api",
+		TTL:      time.Hour,
+		Clock:    clock.MockClock{MockedTime: &now},
+	}
+}
+
+func TestSign(t *testing.T) {
+	now := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	token, err := testSigner(now).Sign("UR_1")
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Hour), token.ExpiresAt)
+	require.Len(t, strings.Split(token.Token, "."), 3)
+
+	_, err = testSigner(now).Sign("")
+	require.EqualError(t, err, "must provide a user id to sign a token")
+
+	noSecret := testSigner(now)
+	noSecret.Secret = nil
+	_, err = noSecret.Sign("UR_1")
+	require.EqualError(t, err, "must have a secret to sign a token")
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	token, err := testSigner(now).Sign("UR_1")
+	require.NoError(t, err)
+	parts := strings.Split(token.Token, ".")
+	noneHeader, _ := encode(header{Algorithm: "none", Type: "JWT"})
+	cases := []struct {
+		name         string
+		signer       func(s Signer) Signer
+		paramToken   string
+		returnClaims Claims
+		returnErr    string
+	}{
+		{
+			name:       "test valid token",
+			paramToken: token.Token,
+			returnClaims: Claims{
+				Subject:   "UR_1",
+				Issuer:    "spiderweb",
+				Audience:  "spiderweb-api",
+				IssuedAt:  now.Unix(),
+				ExpiresAt: now.Add(time.Hour).Unix(),
+			},
+		},
+		{
+			name: "test expired token",
+			signer: func(s Signer) Signer {
+				later := now.Add(time.Hour)
+				s.Clock = clock.MockClock{MockedTime: &later}
+				return s
+			},
+			paramToken: token.Token,
+			returnErr:  "invalid token: expired",
+		},
+		{
+			name: "test different secret",
+			signer: func(s Signer) Signer {
+				s.Secret = []byte("OTHER_SECRET")
+				return s
+			},
+			paramToken: token.Token,
+			returnErr:  "invalid token: bad signature",
+		},
+		{
+			name: "test wrong issuer",
+			signer: func(s Signer) Signer {
+				s.Issuer = "someone-else"
+				return s
+			},
+			paramToken: token.Token,
+			returnErr:  "invalid token: wrong issuer",
+		},
+		{
+			name: "test wrong audience",
+			signer: func(s Signer) Signer {
+				s.Audience = "some-other-api"
+				return s
+			},
+			paramToken: token.Token,
+			returnErr:  "invalid token: wrong audience",
+		},
+		{
+			name: "test no secret",
+			signer: func(s Signer) Signer {
+				s.Secret = nil
+				return s
+			},
+			paramToken: token.Token,
+			returnErr:  "invalid token: tokens are not accepted",
+		},
+		{
+			name:       "test tampered claims",
+			paramToken: parts[0] + "." + parts[1] + "e30" + "." + parts[2],
+			returnErr:  "invalid token: bad signature",
+		},
+		{
+			name:       "test unsigned token",
+			paramToken: noneHeader + "." + parts[1] + ".",
+			returnErr:  "invalid token: unsupported algorithm",
+		},
+		{
+			name:       "test malformed token",
+			paramToken: "not-a-token",
+			returnErr:  "invalid token: malformed",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer := testSigner(now)
+			if tc.signer != nil {
+				signer = tc.signer(signer)
+			}
+			claims, err := signer.Verify(tc.paramToken)
+			if tc.returnErr != "" {
+				require.EqualError(t, err, tc.returnErr)
+				_, ok := err.(*InvalidToken)
+				require.True(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.returnClaims, claims)
+		})
+	}
+}
@@ -44,12 +44,15 @@ type SelectStatement struct {
 	FromTable   string
 	JoinClauses []JoinClause
 	WhereClause WhereClause
+	GroupBy     []string
 	OrderClause OrderClause
 	Limit       int
 }
 
 // JoinClause is used to generate a JOIN clause
+// JoinTable may include an alias, such as "Version AS ParentVersion", for self joins.
 type JoinClause struct {
+	JoinType  string // either empty for an inner join or LEFT
 	JoinTable string
 	On        OnClause
 }
@@ -81,6 +84,8 @@ type WhereClause struct {
 type OrderClause struct {
 	Column string
 	SortBy string
+	// ThenBy orders the rows that have the same value for Column.
+	ThenBy []OrderClause
 }
 
 // GetSelectString returns a statement string intended for a SELECT call.
@@ -95,8 +100,11 @@ func GetSelectString(ss SelectStatement) string {
 	if whereString != "" {
 		statement = statement + fmt.Sprintf(" WHERE %v", whereString)
 	}
+	if len(ss.GroupBy) > 0 {
+		statement = statement + fmt.Sprintf(" GROUP BY %v", getEscapedSequence(ss.GroupBy))
+	}
 	if ss.OrderClause.Column != "" {
-		statement = statement + fmt.Sprintf(" ORDER BY %v %v", getEscapedString(ss.OrderClause.Column), ss.OrderClause.SortBy)
+		statement = statement + fmt.Sprintf(" ORDER BY %v", getOrderString(ss.OrderClause))
 	}
 	if ss.Limit != 0 {
 		statement = statement + fmt.Sprintf(" LIMIT %v", ss.Limit)
@@ -104,6 +112,20 @@ func GetSelectString(ss SelectStatement) string {
 	return statement
 }
 
+func getOrderString(order OrderClause) string {
+	orderStrings := []string{fmt.Sprintf("%v %v", getEscapedString(order.Column), order.SortBy)}
+	for _, thenBy := range order.ThenBy {
+		orderStrings = append(orderStrings, getOrderString(thenBy))
+	}
+	return strings.Join(orderStrings, ",")
+}
+
+// GetRowString returns the columns as a row constructor, such as "(`Page`.`title`,`Page`.`ID`)",
+// which can be used as the LeftSide of a WhereOperation to compare several columns at once.
+func GetRowString(columns ...string) string {
+	return "(" + getEscapedSequence(columns) + ")"
+}
+
 func getEscapedSequence(sequence []string) string {
 	var escapedSequence []string
 	for _, s := range sequence {
@@ -117,7 +139,7 @@ func getEscapedSequence(sequence []string) string {
 }
 
 func shouldBeEscaped(s string) bool {
-	return !strings.HasPrefix(s, "COUNT(")
+	return !strings.HasPrefix(s, "COUNT(") && !strings.HasPrefix(s, "(")
 }
 
 func getEscapedString(s string) string {
@@ -134,7 +156,11 @@ func GetJoinsString(joins []JoinClause) string {
 }
 
 func getJoinString(join JoinClause) string {
-	return fmt.Sprintf("JOIN %v ON %v", join.JoinTable, getOnString(join.On))
+	joinString := fmt.Sprintf("JOIN %v ON %v", join.JoinTable, getOnString(join.On))
+	if join.JoinType != "" {
+		return join.JoinType + " " + joinString
+	}
+	return joinString
 }
 
 func getOnString(on OnClause) string {
@@ -84,6 +84,61 @@ func TestGetSelectString(t *testing.T) {
 			},
 			returnStatement: "SELECT `Property`.`ID`,`Property`.`type`,`Property`.`key`,`PagePropertyString`.`value`,`PagePropertyNumber`.`value`,`PagePropertyOrder`.`order` FROM Page JOIN PagePropertyString ON `Page`.`ID` = `PagePropertyString`.`Page_ID` JOIN PagePropertyNumber ON `Page`.`ID` = `PagePropertyNumber`.`Page_ID` JOIN Property ON `PagePropertyString`.`Property_ID` = `Property`.`ID` AND `PagePropertyNumber`.`Property_ID` = `Property`.`ID` JOIN PagePropertyOrder ON `PagePropertyOrder`.`Page_ID` = `Page`.`ID` AND `PagePropertyOrder`.`Property_ID` = `Property`.`ID` WHERE `Page`.`guid` = ? AND `Page`.`deletedAt` IS NULL AND `Property`.`deletedAt` IS NULL AND `PagePropertyString`.`deletedAt` IS NULL AND `PagePropertyNumber`.`deletedAt` IS NULL ORDER BY `PagePropertyOrder`.`order` ASC",
 		},
+		{
+			name: "test 'get version' statement",
+			paramSelectStatement: SelectStatement{
+				Selectors: []string{"Version.ID", "Version.guid", "Version.name", "ParentVersion.guid"},
+				FromTable: "Version",
+				JoinClauses: []JoinClause{
+					{JoinType: "LEFT", JoinTable: "Version AS ParentVersion", On: OnClause{LeftSide: "Version.Parent_Version_ID", RightSide: "ParentVersion.ID"}},
+				},
+				WhereClause: WhereClause{
+					Operator: "AND", WhereOperations: []WhereOperation{
+						{LeftSide: "Version.guid", Operator: "= ?"},
+						{LeftSide: "Version.deletedAt", Operator: "IS NULL"},
+					},
+				},
+				Limit: 1,
+			},
+			returnStatement: "SELECT `Version`.`ID`,`Version`.`guid`,`Version`.`name`,`ParentVersion`.`guid` FROM Version LEFT JOIN Version AS ParentVersion ON `Version`.`Parent_Version_ID` = `ParentVersion`.`ID` WHERE `Version`.`guid` = ? AND `Version`.`deletedAt` IS NULL LIMIT 1",
+		},
+		{
+			name: "test 'count pages per template' statement",
+			paramSelectStatement: SelectStatement{
+				Selectors: []string{"PageTemplate.guid", "PageTemplate.name", "COUNT(1)"},
+				FromTable: "Page",
+				JoinClauses: []JoinClause{
+					{JoinTable: "PageTemplate", On: OnClause{LeftSide: "Page.PageTemplate_ID", RightSide: "PageTemplate.ID"}},
+				},
+				WhereClause: WhereClause{
+					Operator: "AND", WhereOperations: []WhereOperation{
+						{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+					},
+				},
+				GroupBy: []string{"PageTemplate.guid", "PageTemplate.name"},
+			},
+			returnStatement: "SELECT `PageTemplate`.`guid`,`PageTemplate`.`name`,COUNT(1) FROM Page JOIN PageTemplate ON `Page`.`PageTemplate_ID` = `PageTemplate`.`ID` WHERE `Page`.`deletedAt` IS NULL GROUP BY `PageTemplate`.`guid`,`PageTemplate`.`name`",
+		},
+		{
+			name: "test 'get pages after a cursor' statement",
+			paramSelectStatement: SelectStatement{
+				Selectors: []string{"Page.guid", "Page.title"},
+				FromTable: "Page",
+				WhereClause: WhereClause{
+					Operator: "AND", WhereOperations: []WhereOperation{
+						{LeftSide: "Page.deletedAt", Operator: "IS NULL"},
+						{LeftSide: GetRowString("Page.title", "Page.ID"), Operator: "<= (?,?)"},
+					},
+				},
+				OrderClause: OrderClause{
+					Column: "Page.title",
+					SortBy: "DESC",
+					ThenBy: []OrderClause{{Column: "Page.ID", SortBy: "DESC"}},
+				},
+				Limit: 11,
+			},
+			returnStatement: "SELECT `Page`.`guid`,`Page`.`title` FROM Page WHERE `Page`.`deletedAt` IS NULL AND (`Page`.`title`,`Page`.`ID`) <= (?,?) ORDER BY `Page`.`title` DESC,`Page`.`ID` DESC LIMIT 11",
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {